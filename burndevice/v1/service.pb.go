@@ -9,6 +9,7 @@ package burndevicev1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -34,20 +35,26 @@ const (
 	DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION  DestructionType = 6
 	DestructionType_DESTRUCTION_TYPE_BOOT_CORRUPTION     DestructionType = 7
 	DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC        DestructionType = 8
+	DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION     DestructionType = 9
+	DestructionType_DESTRUCTION_TYPE_CPU_BURN            DestructionType = 10
+	DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION    DestructionType = 11
 )
 
 // Enum value maps for DestructionType.
 var (
 	DestructionType_name = map[int32]string{
-		0: "DESTRUCTION_TYPE_UNSPECIFIED",
-		1: "DESTRUCTION_TYPE_FILE_DELETION",
-		2: "DESTRUCTION_TYPE_REGISTRY_CORRUPTION",
-		3: "DESTRUCTION_TYPE_SERVICE_TERMINATION",
-		4: "DESTRUCTION_TYPE_MEMORY_EXHAUSTION",
-		5: "DESTRUCTION_TYPE_DISK_FILL",
-		6: "DESTRUCTION_TYPE_NETWORK_DISRUPTION",
-		7: "DESTRUCTION_TYPE_BOOT_CORRUPTION",
-		8: "DESTRUCTION_TYPE_KERNEL_PANIC",
+		0:  "DESTRUCTION_TYPE_UNSPECIFIED",
+		1:  "DESTRUCTION_TYPE_FILE_DELETION",
+		2:  "DESTRUCTION_TYPE_REGISTRY_CORRUPTION",
+		3:  "DESTRUCTION_TYPE_SERVICE_TERMINATION",
+		4:  "DESTRUCTION_TYPE_MEMORY_EXHAUSTION",
+		5:  "DESTRUCTION_TYPE_DISK_FILL",
+		6:  "DESTRUCTION_TYPE_NETWORK_DISRUPTION",
+		7:  "DESTRUCTION_TYPE_BOOT_CORRUPTION",
+		8:  "DESTRUCTION_TYPE_KERNEL_PANIC",
+		9:  "DESTRUCTION_TYPE_FILE_CORRUPTION",
+		10: "DESTRUCTION_TYPE_CPU_BURN",
+		11: "DESTRUCTION_TYPE_INODE_EXHAUSTION",
 	}
 	DestructionType_value = map[string]int32{
 		"DESTRUCTION_TYPE_UNSPECIFIED":         0,
@@ -59,6 +66,9 @@ var (
 		"DESTRUCTION_TYPE_NETWORK_DISRUPTION":  6,
 		"DESTRUCTION_TYPE_BOOT_CORRUPTION":     7,
 		"DESTRUCTION_TYPE_KERNEL_PANIC":        8,
+		"DESTRUCTION_TYPE_FILE_CORRUPTION":     9,
+		"DESTRUCTION_TYPE_CPU_BURN":            10,
+		"DESTRUCTION_TYPE_INODE_EXHAUSTION":    11,
 	}
 )
 
@@ -153,6 +163,10 @@ const (
 	DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED   DestructionEventType = 3
 	DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR       DestructionEventType = 4
 	DestructionEventType_DESTRUCTION_EVENT_TYPE_WARNING     DestructionEventType = 5
+	DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED   DestructionEventType = 6
+	// Boundaries between the steps of a stored scenario
+	DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_STARTED  DestructionEventType = 7
+	DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_FINISHED DestructionEventType = 8
 )
 
 // Enum value maps for DestructionEventType.
@@ -164,14 +178,20 @@ var (
 		3: "DESTRUCTION_EVENT_TYPE_COMPLETED",
 		4: "DESTRUCTION_EVENT_TYPE_ERROR",
 		5: "DESTRUCTION_EVENT_TYPE_WARNING",
+		6: "DESTRUCTION_EVENT_TYPE_CANCELLED",
+		7: "DESTRUCTION_EVENT_TYPE_STEP_STARTED",
+		8: "DESTRUCTION_EVENT_TYPE_STEP_FINISHED",
 	}
 	DestructionEventType_value = map[string]int32{
-		"DESTRUCTION_EVENT_TYPE_UNSPECIFIED": 0,
-		"DESTRUCTION_EVENT_TYPE_STARTED":     1,
-		"DESTRUCTION_EVENT_TYPE_PROGRESS":    2,
-		"DESTRUCTION_EVENT_TYPE_COMPLETED":   3,
-		"DESTRUCTION_EVENT_TYPE_ERROR":       4,
-		"DESTRUCTION_EVENT_TYPE_WARNING":     5,
+		"DESTRUCTION_EVENT_TYPE_UNSPECIFIED":   0,
+		"DESTRUCTION_EVENT_TYPE_STARTED":       1,
+		"DESTRUCTION_EVENT_TYPE_PROGRESS":      2,
+		"DESTRUCTION_EVENT_TYPE_COMPLETED":     3,
+		"DESTRUCTION_EVENT_TYPE_ERROR":         4,
+		"DESTRUCTION_EVENT_TYPE_WARNING":       5,
+		"DESTRUCTION_EVENT_TYPE_CANCELLED":     6,
+		"DESTRUCTION_EVENT_TYPE_STEP_STARTED":  7,
+		"DESTRUCTION_EVENT_TYPE_STEP_FINISHED": 8,
 	}
 )
 
@@ -202,15 +222,134 @@ func (DestructionEventType) EnumDescriptor() ([]byte, []int) {
 	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{2}
 }
 
+// How a multi-target request treats a failed target
+type FailurePolicy int32
+
+const (
+	FailurePolicy_FAILURE_POLICY_UNSPECIFIED FailurePolicy = 0 // Same as CONTINUE
+	FailurePolicy_FAILURE_POLICY_CONTINUE    FailurePolicy = 1 // Process every target; fail if any failed
+	FailurePolicy_FAILURE_POLICY_FAIL_FAST   FailurePolicy = 2 // Stop at the first failed target
+)
+
+// Enum value maps for FailurePolicy.
+var (
+	FailurePolicy_name = map[int32]string{
+		0: "FAILURE_POLICY_UNSPECIFIED",
+		1: "FAILURE_POLICY_CONTINUE",
+		2: "FAILURE_POLICY_FAIL_FAST",
+	}
+	FailurePolicy_value = map[string]int32{
+		"FAILURE_POLICY_UNSPECIFIED": 0,
+		"FAILURE_POLICY_CONTINUE":    1,
+		"FAILURE_POLICY_FAIL_FAST":   2,
+	}
+)
+
+func (x FailurePolicy) Enum() *FailurePolicy {
+	p := new(FailurePolicy)
+	*p = x
+	return p
+}
+
+func (x FailurePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FailurePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_burndevice_v1_service_proto_enumTypes[3].Descriptor()
+}
+
+func (FailurePolicy) Type() protoreflect.EnumType {
+	return &file_burndevice_v1_service_proto_enumTypes[3]
+}
+
+func (x FailurePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FailurePolicy.Descriptor instead.
+func (FailurePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
 type ExecuteDestructionRequest struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	Type               DestructionType        `protobuf:"varint,1,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
 	Targets            []string               `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
 	Severity           DestructionSeverity    `protobuf:"varint,3,opt,name=severity,proto3,enum=burndevice.v1.DestructionSeverity" json:"severity,omitempty"`
 	ConfirmDestruction bool                   `protobuf:"varint,4,opt,name=confirm_destruction,json=confirmDestruction,proto3" json:"confirm_destruction,omitempty"`
-	AiScenarioId       string                 `protobuf:"bytes,5,opt,name=ai_scenario_id,json=aiScenarioId,proto3" json:"ai_scenario_id,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// A scenario returned by GenerateAttackScenario. With type and targets
+	// unset the server runs every step of it in order; otherwise the ID is
+	// only recorded with the request.
+	AiScenarioId string `protobuf:"bytes,5,opt,name=ai_scenario_id,json=aiScenarioId,proto3" json:"ai_scenario_id,omitempty"`
+	DryRun       bool   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Pace file deletion to at most this many files per second; 0 uses the
+	// server's engine.max_ops_per_second
+	MaxOpsPerSecond float64 `protobuf:"fixed64,7,opt,name=max_ops_per_second,json=maxOpsPerSecond,proto3" json:"max_ops_per_second,omitempty"`
+	// Pace file deletion to at most this many bytes per second; 0 uses the
+	// server's engine.max_bytes_per_second
+	MaxBytesPerSecond int64 `protobuf:"varint,8,opt,name=max_bytes_per_second,json=maxBytesPerSecond,proto3" json:"max_bytes_per_second,omitempty"`
+	// Must echo the server's security.confirmation_phrase for requests at or
+	// above security.confirmation_phrase_severity
+	ConfirmationText string `protobuf:"bytes,9,opt,name=confirmation_text,json=confirmationText,proto3" json:"confirmation_text,omitempty"`
+	// Replace directory targets of a file deletion with every file beneath
+	// them. Glob targets are always expanded.
+	Recursive bool `protobuf:"varint,10,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	// How long a CPU burn runs, an inode exhaustion holds what it consumed
+	// or a network disruption keeps its targets cut off; unset uses 30, 10
+	// and 30 seconds respectively. Longer than the server's
+	// security.max_duration is rejected.
+	Duration *durationpb.Duration `protobuf:"bytes,11,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Inode exhaustion: hold open file descriptors instead of creating files
+	// in the target directories
+	FileDescriptors bool `protobuf:"varint,12,opt,name=file_descriptors,json=fileDescriptors,proto3" json:"file_descriptors,omitempty"`
+	// Required, along with CRITICAL severity, confirmation and the server's
+	// security.allow_irreversible, for types that cannot be undone
+	AcknowledgeIrreversible bool `protobuf:"varint,13,opt,name=acknowledge_irreversible,json=acknowledgeIrreversible,proto3" json:"acknowledge_irreversible,omitempty"`
+	// File deletion: move targets into the server's security.quarantine_dir
+	// instead of backing them up and deleting them. Always on when the
+	// server sets security.quarantine.
+	Quarantine bool `protobuf:"varint,14,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// File deletion and service termination: put the targets back this long
+	// after the destruction finishes, from their backups or by starting the
+	// services again. Cancelling the task restores them at once.
+	AutoRestoreAfter *durationpb.Duration `protobuf:"bytes,15,opt,name=auto_restore_after,json=autoRestoreAfter,proto3" json:"auto_restore_after,omitempty"`
+	// What a failed target does to the rest of the run (default CONTINUE)
+	FailurePolicy FailurePolicy `protobuf:"varint,16,opt,name=failure_policy,json=failurePolicy,proto3,enum=burndevice.v1.FailurePolicy" json:"failure_policy,omitempty"`
+	// Don't check targets up front, such as file deletion targets existing
+	// and being removable, for targets an earlier step creates
+	SkipPreflight bool `protobuf:"varint,17,opt,name=skip_preflight,json=skipPreflight,proto3" json:"skip_preflight,omitempty"`
+	// File deletion filters. A file is only deleted when it matches one of
+	// include_patterns (when set), none of exclude_patterns, was last
+	// modified at least min_age ago and is at most max_file_size bytes (0
+	// for no limit). Patterns are globs matched against the file name, or
+	// against the whole path when they contain a separator. With any filter
+	// set, directory targets are expanded into their files as with
+	// recursive.
+	IncludePatterns []string             `protobuf:"bytes,18,rep,name=include_patterns,json=includePatterns,proto3" json:"include_patterns,omitempty"`
+	ExcludePatterns []string             `protobuf:"bytes,19,rep,name=exclude_patterns,json=excludePatterns,proto3" json:"exclude_patterns,omitempty"`
+	MinAge          *durationpb.Duration `protobuf:"bytes,20,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxFileSize     int64                `protobuf:"varint,21,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	// File deletion: overwrite every file with random data before deleting
+	// it, security.wipe_passes times, even at severities that would not. A
+	// backup is still taken first when the severity keeps one.
+	Wipe bool `protobuf:"varint,22,opt,name=wipe,proto3" json:"wipe,omitempty"`
+	// How hard a resource-pressure type pushes, from 1 to 100: the percentage
+	// of available memory, disk space, inodes or file descriptors consumed,
+	// of cores a CPU burn keeps busy or of packets a network disruption
+	// drops. 0 uses the severity's default.
+	Intensity int32 `protobuf:"varint,23,opt,name=intensity,proto3" json:"intensity,omitempty"`
+	// Lets the request run outside security.allowed_windows when it matches
+	// the server's security.window_override_token. Every override is
+	// audited.
+	WindowOverrideToken string `protobuf:"bytes,24,opt,name=window_override_token,json=windowOverrideToken,proto3" json:"window_override_token,omitempty"`
+	// Makes retries safe: the server answers a request repeating a recent
+	// key with the response to the first instead of executing again, for
+	// server.idempotency_ttl. Reusing a key for a different request fails
+	// with ALREADY_EXISTS.
+	IdempotencyKey string `protobuf:"bytes,25,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ExecuteDestructionRequest) Reset() {
@@ -278,176 +417,188 @@ func (x *ExecuteDestructionRequest) GetAiScenarioId() string {
 	return ""
 }
 
-type ExecuteDestructionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Results       []*DestructionResult   `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ExecuteDestructionRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
 }
 
-func (x *ExecuteDestructionResponse) Reset() {
-	*x = ExecuteDestructionResponse{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ExecuteDestructionRequest) GetMaxOpsPerSecond() float64 {
+	if x != nil {
+		return x.MaxOpsPerSecond
+	}
+	return 0
 }
 
-func (x *ExecuteDestructionResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExecuteDestructionRequest) GetMaxBytesPerSecond() int64 {
+	if x != nil {
+		return x.MaxBytesPerSecond
+	}
+	return 0
 }
 
-func (*ExecuteDestructionResponse) ProtoMessage() {}
+func (x *ExecuteDestructionRequest) GetConfirmationText() string {
+	if x != nil {
+		return x.ConfirmationText
+	}
+	return ""
+}
 
-func (x *ExecuteDestructionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[1]
+func (x *ExecuteDestructionRequest) GetRecursive() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Recursive
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use ExecuteDestructionResponse.ProtoReflect.Descriptor instead.
-func (*ExecuteDestructionResponse) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{1}
+func (x *ExecuteDestructionRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
 }
 
-func (x *ExecuteDestructionResponse) GetSuccess() bool {
+func (x *ExecuteDestructionRequest) GetFileDescriptors() bool {
 	if x != nil {
-		return x.Success
+		return x.FileDescriptors
 	}
 	return false
 }
 
-func (x *ExecuteDestructionResponse) GetMessage() string {
+func (x *ExecuteDestructionRequest) GetAcknowledgeIrreversible() bool {
 	if x != nil {
-		return x.Message
+		return x.AcknowledgeIrreversible
 	}
-	return ""
+	return false
 }
 
-func (x *ExecuteDestructionResponse) GetResults() []*DestructionResult {
+func (x *ExecuteDestructionRequest) GetQuarantine() bool {
 	if x != nil {
-		return x.Results
+		return x.Quarantine
 	}
-	return nil
+	return false
 }
 
-func (x *ExecuteDestructionResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *ExecuteDestructionRequest) GetAutoRestoreAfter() *durationpb.Duration {
 	if x != nil {
-		return x.Timestamp
+		return x.AutoRestoreAfter
 	}
 	return nil
 }
 
-type StreamDestructionRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Type               DestructionType        `protobuf:"varint,1,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
-	Targets            []string               `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
-	Severity           DestructionSeverity    `protobuf:"varint,3,opt,name=severity,proto3,enum=burndevice.v1.DestructionSeverity" json:"severity,omitempty"`
-	ConfirmDestruction bool                   `protobuf:"varint,4,opt,name=confirm_destruction,json=confirmDestruction,proto3" json:"confirm_destruction,omitempty"`
-	AiScenarioId       string                 `protobuf:"bytes,5,opt,name=ai_scenario_id,json=aiScenarioId,proto3" json:"ai_scenario_id,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *ExecuteDestructionRequest) GetFailurePolicy() FailurePolicy {
+	if x != nil {
+		return x.FailurePolicy
+	}
+	return FailurePolicy_FAILURE_POLICY_UNSPECIFIED
 }
 
-func (x *StreamDestructionRequest) Reset() {
-	*x = StreamDestructionRequest{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ExecuteDestructionRequest) GetSkipPreflight() bool {
+	if x != nil {
+		return x.SkipPreflight
+	}
+	return false
 }
 
-func (x *StreamDestructionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExecuteDestructionRequest) GetIncludePatterns() []string {
+	if x != nil {
+		return x.IncludePatterns
+	}
+	return nil
 }
 
-func (*StreamDestructionRequest) ProtoMessage() {}
-
-func (x *StreamDestructionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[2]
+func (x *ExecuteDestructionRequest) GetExcludePatterns() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ExcludePatterns
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use StreamDestructionRequest.ProtoReflect.Descriptor instead.
-func (*StreamDestructionRequest) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{2}
+func (x *ExecuteDestructionRequest) GetMinAge() *durationpb.Duration {
+	if x != nil {
+		return x.MinAge
+	}
+	return nil
 }
 
-func (x *StreamDestructionRequest) GetType() DestructionType {
+func (x *ExecuteDestructionRequest) GetMaxFileSize() int64 {
 	if x != nil {
-		return x.Type
+		return x.MaxFileSize
 	}
-	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+	return 0
 }
 
-func (x *StreamDestructionRequest) GetTargets() []string {
+func (x *ExecuteDestructionRequest) GetWipe() bool {
 	if x != nil {
-		return x.Targets
+		return x.Wipe
 	}
-	return nil
+	return false
 }
 
-func (x *StreamDestructionRequest) GetSeverity() DestructionSeverity {
+func (x *ExecuteDestructionRequest) GetIntensity() int32 {
 	if x != nil {
-		return x.Severity
+		return x.Intensity
 	}
-	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+	return 0
 }
 
-func (x *StreamDestructionRequest) GetConfirmDestruction() bool {
+func (x *ExecuteDestructionRequest) GetWindowOverrideToken() string {
 	if x != nil {
-		return x.ConfirmDestruction
+		return x.WindowOverrideToken
 	}
-	return false
+	return ""
 }
 
-func (x *StreamDestructionRequest) GetAiScenarioId() string {
+func (x *ExecuteDestructionRequest) GetIdempotencyKey() string {
 	if x != nil {
-		return x.AiScenarioId
+		return x.IdempotencyKey
 	}
 	return ""
 }
 
-type StreamDestructionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Type          DestructionEventType   `protobuf:"varint,3,opt,name=type,proto3,enum=burndevice.v1.DestructionEventType" json:"type,omitempty"`
-	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
-	Progress      float64                `protobuf:"fixed64,5,opt,name=progress,proto3" json:"progress,omitempty"`
+type ExecuteDestructionResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Success   bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message   string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results   []*DestructionResult   `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TaskId    string                 `protobuf:"bytes,5,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// One entry per step when the request ran a stored scenario, in the
+	// order the steps ran; results holds their target results in that order
+	Steps []*ScenarioStepResult `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	// File deletion: files the request's filters left in place
+	FilesFiltered int64 `protobuf:"varint,7,opt,name=files_filtered,json=filesFiltered,proto3" json:"files_filtered,omitempty"`
+	// Targets that did not succeed, skipped ones included, in result order
+	FailedTargets []string `protobuf:"bytes,8,rep,name=failed_targets,json=failedTargets,proto3" json:"failed_targets,omitempty"`
+	// The counters of every result's metrics added up, execution time
+	// included; wipe_passes is the most passes any target got, and
+	// cpu_usage_percent is left unset
+	TotalMetrics *DestructionMetrics `protobuf:"bytes,9,opt,name=total_metrics,json=totalMetrics,proto3" json:"total_metrics,omitempty"`
+	// When the task started and finished
+	StartedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// The task's pre and post execute hooks, in the order they ran
+	HookResults   []*HookResult `protobuf:"bytes,12,rep,name=hook_results,json=hookResults,proto3" json:"hook_results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamDestructionResponse) Reset() {
-	*x = StreamDestructionResponse{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[3]
+func (x *ExecuteDestructionResponse) Reset() {
+	*x = ExecuteDestructionResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamDestructionResponse) String() string {
+func (x *ExecuteDestructionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamDestructionResponse) ProtoMessage() {}
+func (*ExecuteDestructionResponse) ProtoMessage() {}
 
-func (x *StreamDestructionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[3]
+func (x *ExecuteDestructionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,138 +609,124 @@ func (x *StreamDestructionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamDestructionResponse.ProtoReflect.Descriptor instead.
-func (*StreamDestructionResponse) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ExecuteDestructionResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteDestructionResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *StreamDestructionResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *ExecuteDestructionResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Timestamp
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *StreamDestructionResponse) GetMessage() string {
+func (x *ExecuteDestructionResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *StreamDestructionResponse) GetType() DestructionEventType {
+func (x *ExecuteDestructionResponse) GetResults() []*DestructionResult {
 	if x != nil {
-		return x.Type
+		return x.Results
 	}
-	return DestructionEventType_DESTRUCTION_EVENT_TYPE_UNSPECIFIED
+	return nil
 }
 
-func (x *StreamDestructionResponse) GetTarget() string {
+func (x *ExecuteDestructionResponse) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Target
+		return x.Timestamp
 	}
-	return ""
+	return nil
 }
 
-func (x *StreamDestructionResponse) GetProgress() float64 {
+func (x *ExecuteDestructionResponse) GetTaskId() string {
 	if x != nil {
-		return x.Progress
+		return x.TaskId
 	}
-	return 0
-}
-
-type DestructionResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	Metrics       *DestructionMetrics    `protobuf:"bytes,4,opt,name=metrics,proto3" json:"metrics,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *DestructionResult) Reset() {
-	*x = DestructionResult{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *DestructionResult) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExecuteDestructionResponse) GetSteps() []*ScenarioStepResult {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
 }
 
-func (*DestructionResult) ProtoMessage() {}
-
-func (x *DestructionResult) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[4]
+func (x *ExecuteDestructionResponse) GetFilesFiltered() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.FilesFiltered
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use DestructionResult.ProtoReflect.Descriptor instead.
-func (*DestructionResult) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{4}
+func (x *ExecuteDestructionResponse) GetFailedTargets() []string {
+	if x != nil {
+		return x.FailedTargets
+	}
+	return nil
 }
 
-func (x *DestructionResult) GetTarget() string {
+func (x *ExecuteDestructionResponse) GetTotalMetrics() *DestructionMetrics {
 	if x != nil {
-		return x.Target
+		return x.TotalMetrics
 	}
-	return ""
+	return nil
 }
 
-func (x *DestructionResult) GetSuccess() bool {
+func (x *ExecuteDestructionResponse) GetStartedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Success
+		return x.StartedAt
 	}
-	return false
+	return nil
 }
 
-func (x *DestructionResult) GetErrorMessage() string {
+func (x *ExecuteDestructionResponse) GetCompletedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ErrorMessage
+		return x.CompletedAt
 	}
-	return ""
+	return nil
 }
 
-func (x *DestructionResult) GetMetrics() *DestructionMetrics {
+func (x *ExecuteDestructionResponse) GetHookResults() []*HookResult {
 	if x != nil {
-		return x.Metrics
+		return x.HookResults
 	}
 	return nil
 }
 
-type DestructionMetrics struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	FilesDeleted         int64                  `protobuf:"varint,1,opt,name=files_deleted,json=filesDeleted,proto3" json:"files_deleted,omitempty"`
-	BytesDestroyed       int64                  `protobuf:"varint,2,opt,name=bytes_destroyed,json=bytesDestroyed,proto3" json:"bytes_destroyed,omitempty"`
-	ExecutionTimeSeconds float64                `protobuf:"fixed64,3,opt,name=execution_time_seconds,json=executionTimeSeconds,proto3" json:"execution_time_seconds,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+type ScenarioStepResult struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Order       int32                  `protobuf:"varint,1,opt,name=order,proto3" json:"order,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type        DestructionType        `protobuf:"varint,3,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	TaskId      string                 `protobuf:"bytes,4,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Success     bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	Message     string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// The step never ran because an earlier one failed
+	Skipped       bool `protobuf:"varint,7,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DestructionMetrics) Reset() {
-	*x = DestructionMetrics{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[5]
+func (x *ScenarioStepResult) Reset() {
+	*x = ScenarioStepResult{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DestructionMetrics) String() string {
+func (x *ScenarioStepResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DestructionMetrics) ProtoMessage() {}
+func (*ScenarioStepResult) ProtoMessage() {}
 
-func (x *DestructionMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[5]
+func (x *ScenarioStepResult) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -600,53 +737,3897 @@ func (x *DestructionMetrics) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DestructionMetrics.ProtoReflect.Descriptor instead.
-func (*DestructionMetrics) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScenarioStepResult.ProtoReflect.Descriptor instead.
+func (*ScenarioStepResult) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ScenarioStepResult) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *ScenarioStepResult) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ScenarioStepResult) GetType() DestructionType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *ScenarioStepResult) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ScenarioStepResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScenarioStepResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ScenarioStepResult) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+type StreamDestructionRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Type               DestructionType        `protobuf:"varint,1,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	Targets            []string               `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	Severity           DestructionSeverity    `protobuf:"varint,3,opt,name=severity,proto3,enum=burndevice.v1.DestructionSeverity" json:"severity,omitempty"`
+	ConfirmDestruction bool                   `protobuf:"varint,4,opt,name=confirm_destruction,json=confirmDestruction,proto3" json:"confirm_destruction,omitempty"`
+	// A scenario returned by GenerateAttackScenario. With type and targets
+	// unset the server runs every step of it in order; otherwise the ID is
+	// only recorded with the request.
+	AiScenarioId string `protobuf:"bytes,5,opt,name=ai_scenario_id,json=aiScenarioId,proto3" json:"ai_scenario_id,omitempty"`
+	DryRun       bool   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Pace file deletion to at most this many files per second; 0 uses the
+	// server's engine.max_ops_per_second
+	MaxOpsPerSecond float64 `protobuf:"fixed64,7,opt,name=max_ops_per_second,json=maxOpsPerSecond,proto3" json:"max_ops_per_second,omitempty"`
+	// Pace file deletion to at most this many bytes per second; 0 uses the
+	// server's engine.max_bytes_per_second
+	MaxBytesPerSecond int64 `protobuf:"varint,8,opt,name=max_bytes_per_second,json=maxBytesPerSecond,proto3" json:"max_bytes_per_second,omitempty"`
+	// Must echo the server's security.confirmation_phrase for requests at or
+	// above security.confirmation_phrase_severity
+	ConfirmationText string `protobuf:"bytes,9,opt,name=confirmation_text,json=confirmationText,proto3" json:"confirmation_text,omitempty"`
+	// Replace directory targets of a file deletion with every file beneath
+	// them. Glob targets are always expanded.
+	Recursive bool `protobuf:"varint,10,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	// How long a CPU burn runs, an inode exhaustion holds what it consumed
+	// or a network disruption keeps its targets cut off; unset uses 30, 10
+	// and 30 seconds respectively. Longer than the server's
+	// security.max_duration is rejected.
+	Duration *durationpb.Duration `protobuf:"bytes,11,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Inode exhaustion: hold open file descriptors instead of creating files
+	// in the target directories
+	FileDescriptors bool `protobuf:"varint,12,opt,name=file_descriptors,json=fileDescriptors,proto3" json:"file_descriptors,omitempty"`
+	// Required, along with CRITICAL severity, confirmation and the server's
+	// security.allow_irreversible, for types that cannot be undone
+	AcknowledgeIrreversible bool `protobuf:"varint,13,opt,name=acknowledge_irreversible,json=acknowledgeIrreversible,proto3" json:"acknowledge_irreversible,omitempty"`
+	// File deletion: move targets into the server's security.quarantine_dir
+	// instead of backing them up and deleting them. Always on when the
+	// server sets security.quarantine.
+	Quarantine bool `protobuf:"varint,14,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	// File deletion and service termination: put the targets back this long
+	// after the destruction finishes, from their backups or by starting the
+	// services again. Cancelling the task restores them at once.
+	AutoRestoreAfter *durationpb.Duration `protobuf:"bytes,15,opt,name=auto_restore_after,json=autoRestoreAfter,proto3" json:"auto_restore_after,omitempty"`
+	// What a failed target does to the rest of the run (default CONTINUE)
+	FailurePolicy FailurePolicy `protobuf:"varint,16,opt,name=failure_policy,json=failurePolicy,proto3,enum=burndevice.v1.FailurePolicy" json:"failure_policy,omitempty"`
+	// Don't check targets up front, such as file deletion targets existing
+	// and being removable, for targets an earlier step creates
+	SkipPreflight bool `protobuf:"varint,17,opt,name=skip_preflight,json=skipPreflight,proto3" json:"skip_preflight,omitempty"`
+	// File deletion filters. A file is only deleted when it matches one of
+	// include_patterns (when set), none of exclude_patterns, was last
+	// modified at least min_age ago and is at most max_file_size bytes (0
+	// for no limit). Patterns are globs matched against the file name, or
+	// against the whole path when they contain a separator. With any filter
+	// set, directory targets are expanded into their files as with
+	// recursive.
+	IncludePatterns []string             `protobuf:"bytes,18,rep,name=include_patterns,json=includePatterns,proto3" json:"include_patterns,omitempty"`
+	ExcludePatterns []string             `protobuf:"bytes,19,rep,name=exclude_patterns,json=excludePatterns,proto3" json:"exclude_patterns,omitempty"`
+	MinAge          *durationpb.Duration `protobuf:"bytes,20,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	MaxFileSize     int64                `protobuf:"varint,21,opt,name=max_file_size,json=maxFileSize,proto3" json:"max_file_size,omitempty"`
+	// File deletion: overwrite every file with random data before deleting
+	// it, security.wipe_passes times, even at severities that would not. A
+	// backup is still taken first when the severity keeps one.
+	Wipe bool `protobuf:"varint,22,opt,name=wipe,proto3" json:"wipe,omitempty"`
+	// How hard a resource-pressure type pushes, from 1 to 100: the percentage
+	// of available memory, disk space, inodes or file descriptors consumed,
+	// of cores a CPU burn keeps busy or of packets a network disruption
+	// drops. 0 uses the severity's default.
+	Intensity int32 `protobuf:"varint,23,opt,name=intensity,proto3" json:"intensity,omitempty"`
+	// Lets the request run outside security.allowed_windows when it matches
+	// the server's security.window_override_token. Every override is
+	// audited.
+	WindowOverrideToken string `protobuf:"bytes,24,opt,name=window_override_token,json=windowOverrideToken,proto3" json:"window_override_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *StreamDestructionRequest) Reset() {
+	*x = StreamDestructionRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamDestructionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamDestructionRequest) ProtoMessage() {}
+
+func (x *StreamDestructionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamDestructionRequest.ProtoReflect.Descriptor instead.
+func (*StreamDestructionRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamDestructionRequest) GetType() DestructionType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *StreamDestructionRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *StreamDestructionRequest) GetSeverity() DestructionSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+}
+
+func (x *StreamDestructionRequest) GetConfirmDestruction() bool {
+	if x != nil {
+		return x.ConfirmDestruction
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetAiScenarioId() string {
+	if x != nil {
+		return x.AiScenarioId
+	}
+	return ""
+}
+
+func (x *StreamDestructionRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetMaxOpsPerSecond() float64 {
+	if x != nil {
+		return x.MaxOpsPerSecond
+	}
+	return 0
+}
+
+func (x *StreamDestructionRequest) GetMaxBytesPerSecond() int64 {
+	if x != nil {
+		return x.MaxBytesPerSecond
+	}
+	return 0
+}
+
+func (x *StreamDestructionRequest) GetConfirmationText() string {
+	if x != nil {
+		return x.ConfirmationText
+	}
+	return ""
+}
+
+func (x *StreamDestructionRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *StreamDestructionRequest) GetFileDescriptors() bool {
+	if x != nil {
+		return x.FileDescriptors
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetAcknowledgeIrreversible() bool {
+	if x != nil {
+		return x.AcknowledgeIrreversible
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetQuarantine() bool {
+	if x != nil {
+		return x.Quarantine
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetAutoRestoreAfter() *durationpb.Duration {
+	if x != nil {
+		return x.AutoRestoreAfter
+	}
+	return nil
+}
+
+func (x *StreamDestructionRequest) GetFailurePolicy() FailurePolicy {
+	if x != nil {
+		return x.FailurePolicy
+	}
+	return FailurePolicy_FAILURE_POLICY_UNSPECIFIED
+}
+
+func (x *StreamDestructionRequest) GetSkipPreflight() bool {
+	if x != nil {
+		return x.SkipPreflight
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetIncludePatterns() []string {
+	if x != nil {
+		return x.IncludePatterns
+	}
+	return nil
+}
+
+func (x *StreamDestructionRequest) GetExcludePatterns() []string {
+	if x != nil {
+		return x.ExcludePatterns
+	}
+	return nil
+}
+
+func (x *StreamDestructionRequest) GetMinAge() *durationpb.Duration {
+	if x != nil {
+		return x.MinAge
+	}
+	return nil
+}
+
+func (x *StreamDestructionRequest) GetMaxFileSize() int64 {
+	if x != nil {
+		return x.MaxFileSize
+	}
+	return 0
+}
+
+func (x *StreamDestructionRequest) GetWipe() bool {
+	if x != nil {
+		return x.Wipe
+	}
+	return false
+}
+
+func (x *StreamDestructionRequest) GetIntensity() int32 {
+	if x != nil {
+		return x.Intensity
+	}
+	return 0
+}
+
+func (x *StreamDestructionRequest) GetWindowOverrideToken() string {
+	if x != nil {
+		return x.WindowOverrideToken
+	}
+	return ""
+}
+
+type StreamDestructionResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Message   string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Type      DestructionEventType   `protobuf:"varint,3,opt,name=type,proto3,enum=burndevice.v1.DestructionEventType" json:"type,omitempty"`
+	Target    string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Progress  float64                `protobuf:"fixed64,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	TaskId    string                 `protobuf:"bytes,6,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Stored scenario step the event belongs to, counted from 1; 0 outside
+	// a scenario
+	Step int32 `protobuf:"varint,7,opt,name=step,proto3" json:"step,omitempty"`
+	// File deletion: files the request's filters left in place; set on the
+	// final event
+	FilesFiltered int64 `protobuf:"varint,8,opt,name=files_filtered,json=filesFiltered,proto3" json:"files_filtered,omitempty"`
+	// Targets that did not succeed, skipped ones included; set on the final
+	// event
+	FailedTargets []string `protobuf:"bytes,9,rep,name=failed_targets,json=failedTargets,proto3" json:"failed_targets,omitempty"`
+	// Every target's result, and when the task started and finished; set on
+	// the final event so streaming clients get what ExecuteDestruction
+	// returns
+	Results     []*DestructionResult   `protobuf:"bytes,10,rep,name=results,proto3" json:"results,omitempty"`
+	StartedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// The task's pre and post execute hooks; set on the final event
+	HookResults   []*HookResult `protobuf:"bytes,13,rep,name=hook_results,json=hookResults,proto3" json:"hook_results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamDestructionResponse) Reset() {
+	*x = StreamDestructionResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamDestructionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamDestructionResponse) ProtoMessage() {}
+
+func (x *StreamDestructionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamDestructionResponse.ProtoReflect.Descriptor instead.
+func (*StreamDestructionResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StreamDestructionResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *StreamDestructionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StreamDestructionResponse) GetType() DestructionEventType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionEventType_DESTRUCTION_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *StreamDestructionResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *StreamDestructionResponse) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *StreamDestructionResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *StreamDestructionResponse) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *StreamDestructionResponse) GetFilesFiltered() int64 {
+	if x != nil {
+		return x.FilesFiltered
+	}
+	return 0
+}
+
+func (x *StreamDestructionResponse) GetFailedTargets() []string {
+	if x != nil {
+		return x.FailedTargets
+	}
+	return nil
+}
+
+func (x *StreamDestructionResponse) GetResults() []*DestructionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *StreamDestructionResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *StreamDestructionResponse) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *StreamDestructionResponse) GetHookResults() []*HookResult {
+	if x != nil {
+		return x.HookResults
+	}
+	return nil
+}
+
+type DestructionResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Target       string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Success      bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Metrics      *DestructionMetrics    `protobuf:"bytes,4,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Action       string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	HookResults  []*HookResult          `protobuf:"bytes,6,rep,name=hook_results,json=hookResults,proto3" json:"hook_results,omitempty"`
+	BackupPath   string                 `protobuf:"bytes,7,opt,name=backup_path,json=backupPath,proto3" json:"backup_path,omitempty"`
+	Output       string                 `protobuf:"bytes,8,opt,name=output,proto3" json:"output,omitempty"`
+	Stderr       string                 `protobuf:"bytes,9,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// State the target was in before it was destroyed (e.g. a service's
+	// "active"), so the change can be reversed later
+	PreviousState string `protobuf:"bytes,10,opt,name=previous_state,json=previousState,proto3" json:"previous_state,omitempty"`
+	// SHA-256 of a file target, taken while it was backed up
+	BackupChecksum string `protobuf:"bytes,11,opt,name=backup_checksum,json=backupChecksum,proto3" json:"backup_checksum,omitempty"`
+	// How many times deleting a backed-up file target was attempted, more
+	// than 1 when engine.target_retries retried a failure
+	Attempts int32 `protobuf:"varint,12,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// The target was never attempted, e.g. after a fail-fast failure, a
+	// timeout or an exhausted budget. Skipped targets are not successful.
+	Skipped bool `protobuf:"varint,13,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// When work on the target began and ended; unset for skipped targets,
+	// and started_at is unset for targets refused before any work began
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestructionResult) Reset() {
+	*x = DestructionResult{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestructionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestructionResult) ProtoMessage() {}
+
+func (x *DestructionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestructionResult.ProtoReflect.Descriptor instead.
+func (*DestructionResult) Descriptor() ([]byte, []int) {
 	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DestructionMetrics) GetFilesDeleted() int64 {
+func (x *DestructionResult) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DestructionResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetMetrics() *DestructionMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *DestructionResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetHookResults() []*HookResult {
+	if x != nil {
+		return x.HookResults
+	}
+	return nil
+}
+
+func (x *DestructionResult) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetPreviousState() string {
+	if x != nil {
+		return x.PreviousState
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetBackupChecksum() string {
+	if x != nil {
+		return x.BackupChecksum
+	}
+	return ""
+}
+
+func (x *DestructionResult) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DestructionResult) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *DestructionResult) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *DestructionResult) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type HookResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Command      string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Success      bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Output       string                 `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// "pre_execute" or "post_execute" for the hooks run once per task;
+	// empty for the post_target hooks run per target
+	Phase string `protobuf:"bytes,5,opt,name=phase,proto3" json:"phase,omitempty"`
+	// The command's exit status; -1 when it could not be started or was
+	// killed
+	ExitCode      int32 `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HookResult) Reset() {
+	*x = HookResult{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HookResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HookResult) ProtoMessage() {}
+
+func (x *HookResult) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HookResult.ProtoReflect.Descriptor instead.
+func (*HookResult) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HookResult) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *HookResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *HookResult) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *HookResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *HookResult) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *HookResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+type DestructionMetrics struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	FilesDeleted         int64                  `protobuf:"varint,1,opt,name=files_deleted,json=filesDeleted,proto3" json:"files_deleted,omitempty"`
+	BytesDestroyed       int64                  `protobuf:"varint,2,opt,name=bytes_destroyed,json=bytesDestroyed,proto3" json:"bytes_destroyed,omitempty"`
+	ExecutionTimeSeconds float64                `protobuf:"fixed64,3,opt,name=execution_time_seconds,json=executionTimeSeconds,proto3" json:"execution_time_seconds,omitempty"`
+	BytesAllocated       int64                  `protobuf:"varint,4,opt,name=bytes_allocated,json=bytesAllocated,proto3" json:"bytes_allocated,omitempty"`
+	BytesOverwritten     int64                  `protobuf:"varint,5,opt,name=bytes_overwritten,json=bytesOverwritten,proto3" json:"bytes_overwritten,omitempty"`
+	BytesWritten         int64                  `protobuf:"varint,6,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	// File corruption: offsets overwritten with random bytes, and how many
+	// of those bytes actually changed value
+	OffsetsCorrupted int64 `protobuf:"varint,7,opt,name=offsets_corrupted,json=offsetsCorrupted,proto3" json:"offsets_corrupted,omitempty"`
+	BytesCorrupted   int64 `protobuf:"varint,8,opt,name=bytes_corrupted,json=bytesCorrupted,proto3" json:"bytes_corrupted,omitempty"`
+	// CPU burn: cores kept busy, and the average CPU usage sampled while
+	// they were
+	CoresBurned     int64   `protobuf:"varint,9,opt,name=cores_burned,json=coresBurned,proto3" json:"cores_burned,omitempty"`
+	CpuUsagePercent float64 `protobuf:"fixed64,10,opt,name=cpu_usage_percent,json=cpuUsagePercent,proto3" json:"cpu_usage_percent,omitempty"`
+	// Inode exhaustion: files and directories created, or file descriptors
+	// held open
+	InodesConsumed int64 `protobuf:"varint,11,opt,name=inodes_consumed,json=inodesConsumed,proto3" json:"inodes_consumed,omitempty"`
+	FdsConsumed    int64 `protobuf:"varint,12,opt,name=fds_consumed,json=fdsConsumed,proto3" json:"fds_consumed,omitempty"`
+	// File deletion: random overwrite passes made over each file before it
+	// was removed
+	WipePasses    int64 `protobuf:"varint,13,opt,name=wipe_passes,json=wipePasses,proto3" json:"wipe_passes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestructionMetrics) Reset() {
+	*x = DestructionMetrics{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestructionMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestructionMetrics) ProtoMessage() {}
+
+func (x *DestructionMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestructionMetrics.ProtoReflect.Descriptor instead.
+func (*DestructionMetrics) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DestructionMetrics) GetFilesDeleted() int64 {
+	if x != nil {
+		return x.FilesDeleted
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetBytesDestroyed() int64 {
+	if x != nil {
+		return x.BytesDestroyed
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetExecutionTimeSeconds() float64 {
+	if x != nil {
+		return x.ExecutionTimeSeconds
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetBytesAllocated() int64 {
+	if x != nil {
+		return x.BytesAllocated
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetBytesOverwritten() int64 {
+	if x != nil {
+		return x.BytesOverwritten
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetBytesWritten() int64 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetOffsetsCorrupted() int64 {
+	if x != nil {
+		return x.OffsetsCorrupted
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetBytesCorrupted() int64 {
+	if x != nil {
+		return x.BytesCorrupted
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetCoresBurned() int64 {
+	if x != nil {
+		return x.CoresBurned
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetCpuUsagePercent() float64 {
+	if x != nil {
+		return x.CpuUsagePercent
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetInodesConsumed() int64 {
+	if x != nil {
+		return x.InodesConsumed
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetFdsConsumed() int64 {
+	if x != nil {
+		return x.FdsConsumed
+	}
+	return 0
+}
+
+func (x *DestructionMetrics) GetWipePasses() int64 {
+	if x != nil {
+		return x.WipePasses
+	}
+	return 0
+}
+
+type RestoreDestructionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Targets       []string               `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	Force         bool                   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	RemoveBackup  bool                   `protobuf:"varint,4,opt,name=remove_backup,json=removeBackup,proto3" json:"remove_backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreDestructionRequest) Reset() {
+	*x = RestoreDestructionRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreDestructionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDestructionRequest) ProtoMessage() {}
+
+func (x *RestoreDestructionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDestructionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreDestructionRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreDestructionRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *RestoreDestructionRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *RestoreDestructionRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *RestoreDestructionRequest) GetRemoveBackup() bool {
+	if x != nil {
+		return x.RemoveBackup
+	}
+	return false
+}
+
+type RestoreDestructionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*RestoreResult       `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreDestructionResponse) Reset() {
+	*x = RestoreDestructionResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreDestructionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDestructionResponse) ProtoMessage() {}
+
+func (x *RestoreDestructionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDestructionResponse.ProtoReflect.Descriptor instead.
+func (*RestoreDestructionResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RestoreDestructionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreDestructionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RestoreDestructionResponse) GetResults() []*RestoreResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *RestoreDestructionResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type RestoreResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	BackupPath    string                 `protobuf:"bytes,4,opt,name=backup_path,json=backupPath,proto3" json:"backup_path,omitempty"`
+	BytesRestored int64                  `protobuf:"varint,5,opt,name=bytes_restored,json=bytesRestored,proto3" json:"bytes_restored,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreResult) Reset() {
+	*x = RestoreResult{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreResult) ProtoMessage() {}
+
+func (x *RestoreResult) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreResult.ProtoReflect.Descriptor instead.
+func (*RestoreResult) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RestoreResult) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *RestoreResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *RestoreResult) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+func (x *RestoreResult) GetBytesRestored() int64 {
+	if x != nil {
+		return x.BytesRestored
+	}
+	return 0
+}
+
+type VerifyBackupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Targets       []string               `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBackupRequest) Reset() {
+	*x = VerifyBackupRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBackupRequest) ProtoMessage() {}
+
+func (x *VerifyBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBackupRequest.ProtoReflect.Descriptor instead.
+func (*VerifyBackupRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyBackupRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *VerifyBackupRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+type VerifyBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Results       []*VerifyBackupResult  `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBackupResponse) Reset() {
+	*x = VerifyBackupResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBackupResponse) ProtoMessage() {}
+
+func (x *VerifyBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBackupResponse.ProtoReflect.Descriptor instead.
+func (*VerifyBackupResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VerifyBackupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *VerifyBackupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VerifyBackupResponse) GetResults() []*VerifyBackupResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type VerifyBackupResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Target       string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Success      bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	BackupPath   string                 `protobuf:"bytes,4,opt,name=backup_path,json=backupPath,proto3" json:"backup_path,omitempty"`
+	// Files whose checksum matched; files backed up without one are not
+	// counted
+	FilesVerified int64 `protobuf:"varint,5,opt,name=files_verified,json=filesVerified,proto3" json:"files_verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBackupResult) Reset() {
+	*x = VerifyBackupResult{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBackupResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBackupResult) ProtoMessage() {}
+
+func (x *VerifyBackupResult) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBackupResult.ProtoReflect.Descriptor instead.
+func (*VerifyBackupResult) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VerifyBackupResult) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *VerifyBackupResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *VerifyBackupResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *VerifyBackupResult) GetBackupPath() string {
+	if x != nil {
+		return x.BackupPath
+	}
+	return ""
+}
+
+func (x *VerifyBackupResult) GetFilesVerified() int64 {
+	if x != nil {
+		return x.FilesVerified
+	}
+	return 0
+}
+
+type CleanupBackupsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Directory searched for backups; it must be an allowed target
+	Dir string `protobuf:"bytes,1,opt,name=dir,proto3" json:"dir,omitempty"`
+	// Only backups last modified longer ago than this are removed
+	OlderThan     *durationpb.Duration `protobuf:"bytes,2,opt,name=older_than,json=olderThan,proto3" json:"older_than,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupBackupsRequest) Reset() {
+	*x = CleanupBackupsRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupBackupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupBackupsRequest) ProtoMessage() {}
+
+func (x *CleanupBackupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupBackupsRequest.ProtoReflect.Descriptor instead.
+func (*CleanupBackupsRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CleanupBackupsRequest) GetDir() string {
+	if x != nil {
+		return x.Dir
+	}
+	return ""
+}
+
+func (x *CleanupBackupsRequest) GetOlderThan() *durationpb.Duration {
+	if x != nil {
+		return x.OlderThan
+	}
+	return nil
+}
+
+type CleanupBackupsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Backups removed, each with its checksum
+	Removed       []string `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	BytesFreed    int64    `protobuf:"varint,4,opt,name=bytes_freed,json=bytesFreed,proto3" json:"bytes_freed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupBackupsResponse) Reset() {
+	*x = CleanupBackupsResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupBackupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupBackupsResponse) ProtoMessage() {}
+
+func (x *CleanupBackupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupBackupsResponse.ProtoReflect.Descriptor instead.
+func (*CleanupBackupsResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CleanupBackupsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CleanupBackupsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CleanupBackupsResponse) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *CleanupBackupsResponse) GetBytesFreed() int64 {
+	if x != nil {
+		return x.BytesFreed
+	}
+	return 0
+}
+
+type GetTaskStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatusRequest) Reset() {
+	*x = GetTaskStatusRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatusRequest) ProtoMessage() {}
+
+func (x *GetTaskStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskStatusRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTaskStatusRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type GetTaskStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *TaskStatus            `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatusResponse) Reset() {
+	*x = GetTaskStatusResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatusResponse) ProtoMessage() {}
+
+func (x *GetTaskStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskStatusResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTaskStatusResponse) GetTask() *TaskStatus {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type CancelDestructionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelDestructionRequest) Reset() {
+	*x = CancelDestructionRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelDestructionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelDestructionRequest) ProtoMessage() {}
+
+func (x *CancelDestructionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelDestructionRequest.ProtoReflect.Descriptor instead.
+func (*CancelDestructionRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelDestructionRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type CancelDestructionResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Found            bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TargetsProcessed int32                  `protobuf:"varint,3,opt,name=targets_processed,json=targetsProcessed,proto3" json:"targets_processed,omitempty"`
+	Task             *TaskStatus            `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CancelDestructionResponse) Reset() {
+	*x = CancelDestructionResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelDestructionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelDestructionResponse) ProtoMessage() {}
+
+func (x *CancelDestructionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelDestructionResponse.ProtoReflect.Descriptor instead.
+func (*CancelDestructionResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CancelDestructionResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *CancelDestructionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CancelDestructionResponse) GetTargetsProcessed() int32 {
+	if x != nil {
+		return x.TargetsProcessed
+	}
+	return 0
+}
+
+func (x *CancelDestructionResponse) GetTask() *TaskStatus {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*TaskStatus          `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTasksResponse) GetTasks() []*TaskStatus {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type ExpandTargetsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Glob pattern, or a regular expression when root is set
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Directory walked when pattern is a regular expression; must pass the
+	// blocked and allowed lists
+	Root string `protobuf:"bytes,2,opt,name=root,proto3" json:"root,omitempty"`
+	// Maximum number of matches to return; capped by the server
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandTargetsRequest) Reset() {
+	*x = ExpandTargetsRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandTargetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandTargetsRequest) ProtoMessage() {}
+
+func (x *ExpandTargetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandTargetsRequest.ProtoReflect.Descriptor instead.
+func (*ExpandTargetsRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExpandTargetsRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *ExpandTargetsRequest) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *ExpandTargetsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ExpandTargetsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Matches []*TargetMatch         `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	// Number of matches found; a walk under root stops at the limit, so it
+	// doesn't count the matches past it
+	TotalMatches int32 `protobuf:"varint,2,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	// Set when more matches exist than were returned
+	Truncated     bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandTargetsResponse) Reset() {
+	*x = ExpandTargetsResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandTargetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandTargetsResponse) ProtoMessage() {}
+
+func (x *ExpandTargetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandTargetsResponse.ProtoReflect.Descriptor instead.
+func (*ExpandTargetsResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExpandTargetsResponse) GetMatches() []*TargetMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ExpandTargetsResponse) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+func (x *ExpandTargetsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type TargetMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	IsDir         bool                   `protobuf:"varint,2,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Blocked       bool                   `protobuf:"varint,4,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	Allowed       bool                   `protobuf:"varint,5,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetMatch) Reset() {
+	*x = TargetMatch{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetMatch) ProtoMessage() {}
+
+func (x *TargetMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetMatch.ProtoReflect.Descriptor instead.
+func (*TargetMatch) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TargetMatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *TargetMatch) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *TargetMatch) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *TargetMatch) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *TargetMatch) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+type PlanDestructionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Paths or glob patterns; directories are walked
+	Targets []string `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	// How many of the largest files to list per target; defaults to 5 and is
+	// capped by the server
+	LargestFiles  int32 `protobuf:"varint,2,opt,name=largest_files,json=largestFiles,proto3" json:"largest_files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanDestructionRequest) Reset() {
+	*x = PlanDestructionRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanDestructionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanDestructionRequest) ProtoMessage() {}
+
+func (x *PlanDestructionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanDestructionRequest.ProtoReflect.Descriptor instead.
+func (*PlanDestructionRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PlanDestructionRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *PlanDestructionRequest) GetLargestFiles() int32 {
+	if x != nil {
+		return x.LargestFiles
+	}
+	return 0
+}
+
+type PlanDestructionResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Targets []*TargetPlan          `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	// Files and bytes across every target that passes policy
+	TotalFiles int64 `protobuf:"varint,2,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
+	TotalBytes int64 `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	// Targets the blocked and allowed lists refuse, or that could not be
+	// scanned
+	RefusedTargets int32 `protobuf:"varint,4,opt,name=refused_targets,json=refusedTargets,proto3" json:"refused_targets,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PlanDestructionResponse) Reset() {
+	*x = PlanDestructionResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanDestructionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanDestructionResponse) ProtoMessage() {}
+
+func (x *PlanDestructionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanDestructionResponse.ProtoReflect.Descriptor instead.
+func (*PlanDestructionResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PlanDestructionResponse) GetTargets() []*TargetPlan {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *PlanDestructionResponse) GetTotalFiles() int64 {
+	if x != nil {
+		return x.TotalFiles
+	}
+	return 0
+}
+
+func (x *PlanDestructionResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *PlanDestructionResponse) GetRefusedTargets() int32 {
+	if x != nil {
+		return x.RefusedTargets
+	}
+	return 0
+}
+
+type TargetPlan struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Target string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	IsDir  bool                   `protobuf:"varint,2,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	// Files beneath the target, or 1 for a single file; symlinks are
+	// counted, never followed
+	Files int64 `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	// Bytes of the regular files among them
+	Bytes int64 `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// A directory's largest regular files, largest first
+	LargestFiles []*FileSize `protobuf:"bytes,5,rep,name=largest_files,json=largestFiles,proto3" json:"largest_files,omitempty"`
+	// Whether the target passes the blocked and allowed lists
+	Allowed bool `protobuf:"varint,6,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// Why the target is refused or could not be scanned
+	ErrorMessage  string `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetPlan) Reset() {
+	*x = TargetPlan{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetPlan) ProtoMessage() {}
+
+func (x *TargetPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetPlan.ProtoReflect.Descriptor instead.
+func (*TargetPlan) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TargetPlan) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TargetPlan) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *TargetPlan) GetFiles() int64 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *TargetPlan) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *TargetPlan) GetLargestFiles() []*FileSize {
+	if x != nil {
+		return x.LargestFiles
+	}
+	return nil
+}
+
+func (x *TargetPlan) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *TargetPlan) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type FileSize struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileSize) Reset() {
+	*x = FileSize{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileSize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileSize) ProtoMessage() {}
+
+func (x *FileSize) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileSize.ProtoReflect.Descriptor instead.
+func (*FileSize) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *FileSize) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileSize) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetTaskHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return tasks of this type; unspecified returns every type
+	Type DestructionType `protobuf:"varint,1,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	// Only return tasks started at or after this time
+	Since *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	// Only return tasks started before this time
+	Until *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	// Maximum number of tasks per page; capped by the server
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryRequest) Reset() {
+	*x = GetTaskHistoryRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryRequest) ProtoMessage() {}
+
+func (x *GetTaskHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetTaskHistoryRequest) GetType() DestructionType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *GetTaskHistoryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetTaskHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetTaskHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*TaskRecord          `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Empty when there are no more pages
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Number of tasks matching the filters across all pages
+	Total         int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryResponse) Reset() {
+	*x = GetTaskHistoryResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTaskHistoryResponse) GetTasks() []*TaskRecord {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetTaskHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// TaskRecord is the persisted outcome of a finished task
+type TaskRecord struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TaskId     string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Type       DestructionType        `protobuf:"varint,2,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	Severity   DestructionSeverity    `protobuf:"varint,3,opt,name=severity,proto3,enum=burndevice.v1.DestructionSeverity" json:"severity,omitempty"`
+	Targets    []string               `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	State      string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Success    bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	Message    string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	StartedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Results    []*DestructionResult   `protobuf:"bytes,10,rep,name=results,proto3" json:"results,omitempty"`
+	// "auto_restore" for the restore that follows a destruction requested
+	// with auto_restore_after; empty for the destruction itself
+	Phase string `protobuf:"bytes,11,opt,name=phase,proto3" json:"phase,omitempty"`
+	// When the destruction's automatic restore is due
+	AutoRestoreAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=auto_restore_at,json=autoRestoreAt,proto3" json:"auto_restore_at,omitempty"`
+	// The task's pre and post execute hooks, in the order they ran
+	HookResults []*HookResult `protobuf:"bytes,13,rep,name=hook_results,json=hookResults,proto3" json:"hook_results,omitempty"`
+	// The idempotency key of the request that ran the task, if any
+	IdempotencyKey string `protobuf:"bytes,14,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TaskRecord) Reset() {
+	*x = TaskRecord{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRecord) ProtoMessage() {}
+
+func (x *TaskRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRecord.ProtoReflect.Descriptor instead.
+func (*TaskRecord) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TaskRecord) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskRecord) GetType() DestructionType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *TaskRecord) GetSeverity() DestructionSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+}
+
+func (x *TaskRecord) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TaskRecord) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TaskRecord) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TaskRecord) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetResults() []*DestructionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *TaskRecord) GetAutoRestoreAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AutoRestoreAt
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetHookResults() []*HookResult {
+	if x != nil {
+		return x.HookResults
+	}
+	return nil
+}
+
+func (x *TaskRecord) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// A restore queued by a destruction's auto_restore_after
+type AutoRestore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Type          DestructionType        `protobuf:"varint,2,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	Targets       []string               `protobuf:"bytes,3,rep,name=targets,proto3" json:"targets,omitempty"`
+	RestoreAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=restore_at,json=restoreAt,proto3" json:"restore_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AutoRestore) Reset() {
+	*x = AutoRestore{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AutoRestore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AutoRestore) ProtoMessage() {}
+
+func (x *AutoRestore) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AutoRestore.ProtoReflect.Descriptor instead.
+func (*AutoRestore) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AutoRestore) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *AutoRestore) GetType() DestructionType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *AutoRestore) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *AutoRestore) GetRestoreAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RestoreAt
+	}
+	return nil
+}
+
+type SubscribeEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only forward events of this task when set
+	TaskId        string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SubscribeEventsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type ScheduleDestructionRequest struct {
+	state   protoimpl.MessageState     `protogen:"open.v1"`
+	Request *ExecuteDestructionRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Run once after this delay; exactly one of delay and cron must be set
+	Delay *durationpb.Duration `protobuf:"bytes,2,opt,name=delay,proto3" json:"delay,omitempty"`
+	// Run repeatedly on this five-field cron expression, in server local time
+	Cron          string `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleDestructionRequest) Reset() {
+	*x = ScheduleDestructionRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleDestructionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleDestructionRequest) ProtoMessage() {}
+
+func (x *ScheduleDestructionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleDestructionRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleDestructionRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ScheduleDestructionRequest) GetRequest() *ExecuteDestructionRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *ScheduleDestructionRequest) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+func (x *ScheduleDestructionRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+type ScheduleDestructionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleDestructionResponse) Reset() {
+	*x = ScheduleDestructionResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleDestructionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleDestructionResponse) ProtoMessage() {}
+
+func (x *ScheduleDestructionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleDestructionResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleDestructionResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ScheduleDestructionResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{36}
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type DeleteScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleRequest) Reset() {
+	*x = DeleteScheduleRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteScheduleRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type DeleteScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleResponse) Reset() {
+	*x = DeleteScheduleResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteScheduleResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type Schedule struct {
+	state      protoimpl.MessageState     `protogen:"open.v1"`
+	ScheduleId string                     `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Request    *ExecuteDestructionRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	// Empty for one-off schedules, which are removed once they run
+	Cron      string                 `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	NextRun   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Client the destruction runs on behalf of, for quotas
+	Client        string                 `protobuf:"bytes,6,opt,name=client,proto3" json:"client,omitempty"`
+	LastRun       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	LastTaskId    string                 `protobuf:"bytes,8,opt,name=last_task_id,json=lastTaskId,proto3" json:"last_task_id,omitempty"`
+	LastError     string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Schedule) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *Schedule) GetRequest() *ExecuteDestructionRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *Schedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *Schedule) GetNextRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRun
+	}
+	return nil
+}
+
+func (x *Schedule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *Schedule) GetLastRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRun
+	}
+	return nil
+}
+
+func (x *Schedule) GetLastTaskId() string {
+	if x != nil {
+		return x.LastTaskId
+	}
+	return ""
+}
+
+func (x *Schedule) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{41}
+}
+
+type GetServerInfoResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Hostname string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Os       string                 `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	// Effective user ID (a SID on Windows) and account name of the server
+	Uid      string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Username string `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	// Root on Unix, an elevated Administrator on Windows
+	Privileged bool `protobuf:"varint,5,opt,name=privileged,proto3" json:"privileged,omitempty"`
+	// Whether security.allow_root acknowledges running privileged
+	AllowRoot bool `protobuf:"varint,6,opt,name=allow_root,json=allowRoot,proto3" json:"allow_root,omitempty"`
+	// Operator notice clients show before executing anything (empty when
+	// server.connection_banner is unset)
+	ConnectionBanner string `protobuf:"bytes,7,opt,name=connection_banner,json=connectionBanner,proto3" json:"connection_banner,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetServerInfoResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+func (x *GetServerInfoResponse) GetAllowRoot() bool {
+	if x != nil {
+		return x.AllowRoot
+	}
+	return false
+}
+
+func (x *GetServerInfoResponse) GetConnectionBanner() string {
+	if x != nil {
+		return x.ConnectionBanner
+	}
+	return ""
+}
+
+type CheckCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCapabilitiesRequest) Reset() {
+	*x = CheckCapabilitiesRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCapabilitiesRequest) ProtoMessage() {}
+
+func (x *CheckCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*CheckCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{43}
+}
+
+type CheckCapabilitiesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// GOOS/GOARCH of the server
+	Platform string `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	// Root on Unix, an elevated Administrator on Windows
+	Privileged    bool          `protobuf:"varint,2,opt,name=privileged,proto3" json:"privileged,omitempty"`
+	Capabilities  []*Capability `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCapabilitiesResponse) Reset() {
+	*x = CheckCapabilitiesResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCapabilitiesResponse) ProtoMessage() {}
+
+func (x *CheckCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*CheckCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CheckCapabilitiesResponse) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *CheckCapabilitiesResponse) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+func (x *CheckCapabilitiesResponse) GetCapabilities() []*Capability {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+// Whether one destruction type can run on the server's host
+type Capability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  DestructionType        `protobuf:"varint,1,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	// A destructor is registered for the type
+	Implemented bool `protobuf:"varint,2,opt,name=implemented,proto3" json:"implemented,omitempty"`
+	// security.enabled_types allows the type
+	Enabled bool `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// The host has what the type needs (binaries, privileges, platform)
+	Available bool `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	// Why the type can't run, when it can't
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Capability) Reset() {
+	*x = Capability{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Capability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capability) ProtoMessage() {}
+
+func (x *Capability) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capability.ProtoReflect.Descriptor instead.
+func (*Capability) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Capability) GetType() DestructionType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *Capability) GetImplemented() bool {
+	if x != nil {
+		return x.Implemented
+	}
+	return false
+}
+
+func (x *Capability) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Capability) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *Capability) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GetMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetricsRequest) Reset() {
+	*x = GetMetricsRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricsRequest) ProtoMessage() {}
+
+func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{46}
+}
+
+// Counters only ever grow while the server runs and start over from zero
+// when it restarts, which started_at identifies
+type GetMetricsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Finished destructions, dry runs excluded
+	DestructionsTotal       int64 `protobuf:"varint,3,opt,name=destructions_total,json=destructionsTotal,proto3" json:"destructions_total,omitempty"`
+	DestructionsFailed      int64 `protobuf:"varint,4,opt,name=destructions_failed,json=destructionsFailed,proto3" json:"destructions_failed,omitempty"`
+	FilesDeletedTotal       int64 `protobuf:"varint,5,opt,name=files_deleted_total,json=filesDeletedTotal,proto3" json:"files_deleted_total,omitempty"`
+	BytesDestroyedTotal     int64 `protobuf:"varint,6,opt,name=bytes_destroyed_total,json=bytesDestroyedTotal,proto3" json:"bytes_destroyed_total,omitempty"`
+	ScenariosGeneratedTotal int64 `protobuf:"varint,7,opt,name=scenarios_generated_total,json=scenariosGeneratedTotal,proto3" json:"scenarios_generated_total,omitempty"`
+	AiTokensTotal           int64 `protobuf:"varint,8,opt,name=ai_tokens_total,json=aiTokensTotal,proto3" json:"ai_tokens_total,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *GetMetricsResponse) Reset() {
+	*x = GetMetricsResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetricsResponse) ProtoMessage() {}
+
+func (x *GetMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetricsResponse.ProtoReflect.Descriptor instead.
+func (*GetMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetMetricsResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *GetMetricsResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetMetricsResponse) GetDestructionsTotal() int64 {
+	if x != nil {
+		return x.DestructionsTotal
+	}
+	return 0
+}
+
+func (x *GetMetricsResponse) GetDestructionsFailed() int64 {
+	if x != nil {
+		return x.DestructionsFailed
+	}
+	return 0
+}
+
+func (x *GetMetricsResponse) GetFilesDeletedTotal() int64 {
+	if x != nil {
+		return x.FilesDeletedTotal
+	}
+	return 0
+}
+
+func (x *GetMetricsResponse) GetBytesDestroyedTotal() int64 {
+	if x != nil {
+		return x.BytesDestroyedTotal
+	}
+	return 0
+}
+
+func (x *GetMetricsResponse) GetScenariosGeneratedTotal() int64 {
+	if x != nil {
+		return x.ScenariosGeneratedTotal
+	}
+	return 0
+}
+
+func (x *GetMetricsResponse) GetAiTokensTotal() int64 {
+	if x != nil {
+		return x.AiTokensTotal
+	}
+	return 0
+}
+
+type TaskStatus struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TaskId           string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Type             DestructionType        `protobuf:"varint,2,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	Severity         DestructionSeverity    `protobuf:"varint,3,opt,name=severity,proto3,enum=burndevice.v1.DestructionSeverity" json:"severity,omitempty"`
+	Targets          []string               `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	State            string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Progress         float64                `protobuf:"fixed64,6,opt,name=progress,proto3" json:"progress,omitempty"`
+	CurrentTarget    string                 `protobuf:"bytes,7,opt,name=current_target,json=currentTarget,proto3" json:"current_target,omitempty"`
+	StartedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	TargetsProcessed int32                  `protobuf:"varint,9,opt,name=targets_processed,json=targetsProcessed,proto3" json:"targets_processed,omitempty"`
+	Results          []*DestructionResult   `protobuf:"bytes,10,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TaskStatus) Reset() {
+	*x = TaskStatus{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStatus) ProtoMessage() {}
+
+func (x *TaskStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStatus.ProtoReflect.Descriptor instead.
+func (*TaskStatus) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TaskStatus) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskStatus) GetType() DestructionType {
+	if x != nil {
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *TaskStatus) GetSeverity() DestructionSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+}
+
+func (x *TaskStatus) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *TaskStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TaskStatus) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *TaskStatus) GetCurrentTarget() string {
+	if x != nil {
+		return x.CurrentTarget
+	}
+	return ""
+}
+
+func (x *TaskStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *TaskStatus) GetTargetsProcessed() int32 {
+	if x != nil {
+		return x.TargetsProcessed
+	}
+	return 0
+}
+
+func (x *TaskStatus) GetResults() []*DestructionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetSystemInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemInfoRequest) Reset() {
+	*x = GetSystemInfoRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemInfoRequest) ProtoMessage() {}
+
+func (x *GetSystemInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemInfoRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{49}
+}
+
+type GetSystemInfoResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Os              string                 `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Architecture    string                 `protobuf:"bytes,2,opt,name=architecture,proto3" json:"architecture,omitempty"`
+	Hostname        string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	CriticalPaths   []string               `protobuf:"bytes,4,rep,name=critical_paths,json=criticalPaths,proto3" json:"critical_paths,omitempty"`
+	RunningServices []string               `protobuf:"bytes,5,rep,name=running_services,json=runningServices,proto3" json:"running_services,omitempty"`
+	Resources       *SystemResources       `protobuf:"bytes,6,opt,name=resources,proto3" json:"resources,omitempty"`
+	// Disk space of the filesystem holding each critical path and allowed
+	// target, which may be separate mounts from the root filesystem
+	PathDisks     []*PathDiskUsage `protobuf:"bytes,7,rep,name=path_disks,json=pathDisks,proto3" json:"path_disks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemInfoResponse) Reset() {
+	*x = GetSystemInfoResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemInfoResponse) ProtoMessage() {}
+
+func (x *GetSystemInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetSystemInfoResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetSystemInfoResponse) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetCriticalPaths() []string {
+	if x != nil {
+		return x.CriticalPaths
+	}
+	return nil
+}
+
+func (x *GetSystemInfoResponse) GetRunningServices() []string {
+	if x != nil {
+		return x.RunningServices
+	}
+	return nil
+}
+
+func (x *GetSystemInfoResponse) GetResources() *SystemResources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *GetSystemInfoResponse) GetPathDisks() []*PathDiskUsage {
+	if x != nil {
+		return x.PathDisks
+	}
+	return nil
+}
+
+type PathDiskUsage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	TotalDisk     int64                  `protobuf:"varint,2,opt,name=total_disk,json=totalDisk,proto3" json:"total_disk,omitempty"`
+	AvailableDisk int64                  `protobuf:"varint,3,opt,name=available_disk,json=availableDisk,proto3" json:"available_disk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathDiskUsage) Reset() {
+	*x = PathDiskUsage{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathDiskUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathDiskUsage) ProtoMessage() {}
+
+func (x *PathDiskUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathDiskUsage.ProtoReflect.Descriptor instead.
+func (*PathDiskUsage) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *PathDiskUsage) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PathDiskUsage) GetTotalDisk() int64 {
+	if x != nil {
+		return x.TotalDisk
+	}
+	return 0
+}
+
+func (x *PathDiskUsage) GetAvailableDisk() int64 {
+	if x != nil {
+		return x.AvailableDisk
+	}
+	return 0
+}
+
+type SystemResources struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TotalMemory     int64                  `protobuf:"varint,1,opt,name=total_memory,json=totalMemory,proto3" json:"total_memory,omitempty"`
+	AvailableMemory int64                  `protobuf:"varint,2,opt,name=available_memory,json=availableMemory,proto3" json:"available_memory,omitempty"`
+	TotalDisk       int64                  `protobuf:"varint,3,opt,name=total_disk,json=totalDisk,proto3" json:"total_disk,omitempty"`
+	AvailableDisk   int64                  `protobuf:"varint,4,opt,name=available_disk,json=availableDisk,proto3" json:"available_disk,omitempty"`
+	CpuUsage        float64                `protobuf:"fixed64,5,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SystemResources) Reset() {
+	*x = SystemResources{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemResources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemResources) ProtoMessage() {}
+
+func (x *SystemResources) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemResources.ProtoReflect.Descriptor instead.
+func (*SystemResources) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SystemResources) GetTotalMemory() int64 {
+	if x != nil {
+		return x.TotalMemory
+	}
+	return 0
+}
+
+func (x *SystemResources) GetAvailableMemory() int64 {
+	if x != nil {
+		return x.AvailableMemory
+	}
+	return 0
+}
+
+func (x *SystemResources) GetTotalDisk() int64 {
+	if x != nil {
+		return x.TotalDisk
+	}
+	return 0
+}
+
+func (x *SystemResources) GetAvailableDisk() int64 {
 	if x != nil {
-		return x.FilesDeleted
+		return x.AvailableDisk
 	}
 	return 0
 }
 
-func (x *DestructionMetrics) GetBytesDestroyed() int64 {
+func (x *SystemResources) GetCpuUsage() float64 {
 	if x != nil {
-		return x.BytesDestroyed
+		return x.CpuUsage
 	}
 	return 0
 }
 
-func (x *DestructionMetrics) GetExecutionTimeSeconds() float64 {
+type GenerateAttackScenarioRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TargetDescription string                 `protobuf:"bytes,1,opt,name=target_description,json=targetDescription,proto3" json:"target_description,omitempty"`
+	MaxSeverity       DestructionSeverity    `protobuf:"varint,2,opt,name=max_severity,json=maxSeverity,proto3,enum=burndevice.v1.DestructionSeverity" json:"max_severity,omitempty"`
+	AiModel           string                 `protobuf:"bytes,3,opt,name=ai_model,json=aiModel,proto3" json:"ai_model,omitempty"`
+	// Destruction types the scenario may use; empty allows every type the
+	// server has enabled
+	AllowedTypes  []DestructionType `protobuf:"varint,4,rep,packed,name=allowed_types,json=allowedTypes,proto3,enum=burndevice.v1.DestructionType" json:"allowed_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateAttackScenarioRequest) Reset() {
+	*x = GenerateAttackScenarioRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateAttackScenarioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateAttackScenarioRequest) ProtoMessage() {}
+
+func (x *GenerateAttackScenarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[53]
 	if x != nil {
-		return x.ExecutionTimeSeconds
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-type GetSystemInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// Deprecated: Use GenerateAttackScenarioRequest.ProtoReflect.Descriptor instead.
+func (*GenerateAttackScenarioRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GenerateAttackScenarioRequest) GetTargetDescription() string {
+	if x != nil {
+		return x.TargetDescription
+	}
+	return ""
+}
+
+func (x *GenerateAttackScenarioRequest) GetMaxSeverity() DestructionSeverity {
+	if x != nil {
+		return x.MaxSeverity
+	}
+	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+}
+
+func (x *GenerateAttackScenarioRequest) GetAiModel() string {
+	if x != nil {
+		return x.AiModel
+	}
+	return ""
+}
+
+func (x *GenerateAttackScenarioRequest) GetAllowedTypes() []DestructionType {
+	if x != nil {
+		return x.AllowedTypes
+	}
+	return nil
+}
+
+type GenerateAttackScenarioResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ScenarioId        string                 `protobuf:"bytes,1,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`
+	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Steps             []*AttackStep          `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	EstimatedSeverity DestructionSeverity    `protobuf:"varint,4,opt,name=estimated_severity,json=estimatedSeverity,proto3,enum=burndevice.v1.DestructionSeverity" json:"estimated_severity,omitempty"`
+	Rationale         string                 `protobuf:"bytes,5,opt,name=rationale,proto3" json:"rationale,omitempty"`
+	Warnings          []string               `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// Tokens the AI provider reported for generating the scenario
+	TokensUsed int64 `protobuf:"varint,7,opt,name=tokens_used,json=tokensUsed,proto3" json:"tokens_used,omitempty"`
+	// When the server stored the scenario
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemInfoRequest) Reset() {
-	*x = GetSystemInfoRequest{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[6]
+func (x *GenerateAttackScenarioResponse) Reset() {
+	*x = GenerateAttackScenarioResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemInfoRequest) String() string {
+func (x *GenerateAttackScenarioResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemInfoRequest) ProtoMessage() {}
+func (*GenerateAttackScenarioResponse) ProtoMessage() {}
 
-func (x *GetSystemInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[6]
+func (x *GenerateAttackScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,38 +4638,95 @@ func (x *GetSystemInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetSystemInfoRequest) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GenerateAttackScenarioResponse.ProtoReflect.Descriptor instead.
+func (*GenerateAttackScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{54}
 }
 
-type GetSystemInfoResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Os              string                 `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
-	Architecture    string                 `protobuf:"bytes,2,opt,name=architecture,proto3" json:"architecture,omitempty"`
-	Hostname        string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	CriticalPaths   []string               `protobuf:"bytes,4,rep,name=critical_paths,json=criticalPaths,proto3" json:"critical_paths,omitempty"`
-	RunningServices []string               `protobuf:"bytes,5,rep,name=running_services,json=runningServices,proto3" json:"running_services,omitempty"`
-	Resources       *SystemResources       `protobuf:"bytes,6,opt,name=resources,proto3" json:"resources,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *GenerateAttackScenarioResponse) GetScenarioId() string {
+	if x != nil {
+		return x.ScenarioId
+	}
+	return ""
 }
 
-func (x *GetSystemInfoResponse) Reset() {
-	*x = GetSystemInfoResponse{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[7]
+func (x *GenerateAttackScenarioResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GenerateAttackScenarioResponse) GetSteps() []*AttackStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *GenerateAttackScenarioResponse) GetEstimatedSeverity() DestructionSeverity {
+	if x != nil {
+		return x.EstimatedSeverity
+	}
+	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+}
+
+func (x *GenerateAttackScenarioResponse) GetRationale() string {
+	if x != nil {
+		return x.Rationale
+	}
+	return ""
+}
+
+func (x *GenerateAttackScenarioResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *GenerateAttackScenarioResponse) GetTokensUsed() int64 {
+	if x != nil {
+		return x.TokensUsed
+	}
+	return 0
+}
+
+func (x *GenerateAttackScenarioResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AttackStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         int32                  `protobuf:"varint,1,opt,name=order,proto3" json:"order,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          DestructionType        `protobuf:"varint,3,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
+	Targets       []string               `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	Rationale     string                 `protobuf:"bytes,5,opt,name=rationale,proto3" json:"rationale,omitempty"`
+	Risk          string                 `protobuf:"bytes,6,opt,name=risk,proto3" json:"risk,omitempty"`
+	Commands      []string               `protobuf:"bytes,7,rep,name=commands,proto3" json:"commands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttackStep) Reset() {
+	*x = AttackStep{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemInfoResponse) String() string {
+func (x *AttackStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemInfoResponse) ProtoMessage() {}
+func (*AttackStep) ProtoMessage() {}
 
-func (x *GetSystemInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[7]
+func (x *AttackStep) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,79 +4737,171 @@ func (x *GetSystemInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetSystemInfoResponse) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AttackStep.ProtoReflect.Descriptor instead.
+func (*AttackStep) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *GetSystemInfoResponse) GetOs() string {
+func (x *AttackStep) GetOrder() int32 {
 	if x != nil {
-		return x.Os
+		return x.Order
+	}
+	return 0
+}
+
+func (x *AttackStep) GetDescription() string {
+	if x != nil {
+		return x.Description
 	}
 	return ""
 }
 
-func (x *GetSystemInfoResponse) GetArchitecture() string {
+func (x *AttackStep) GetType() DestructionType {
 	if x != nil {
-		return x.Architecture
+		return x.Type
+	}
+	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+}
+
+func (x *AttackStep) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *AttackStep) GetRationale() string {
+	if x != nil {
+		return x.Rationale
 	}
 	return ""
 }
 
-func (x *GetSystemInfoResponse) GetHostname() string {
+func (x *AttackStep) GetRisk() string {
 	if x != nil {
-		return x.Hostname
+		return x.Risk
 	}
 	return ""
 }
 
-func (x *GetSystemInfoResponse) GetCriticalPaths() []string {
-	if x != nil {
-		return x.CriticalPaths
-	}
-	return nil
+func (x *AttackStep) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+type SaveScenarioRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// An empty scenario_id is assigned by the server
+	Scenario      *GenerateAttackScenarioResponse `protobuf:"bytes,1,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveScenarioRequest) Reset() {
+	*x = SaveScenarioRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveScenarioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveScenarioRequest) ProtoMessage() {}
+
+func (x *SaveScenarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveScenarioRequest.ProtoReflect.Descriptor instead.
+func (*SaveScenarioRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SaveScenarioRequest) GetScenario() *GenerateAttackScenarioResponse {
+	if x != nil {
+		return x.Scenario
+	}
+	return nil
+}
+
+type SaveScenarioResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScenarioId    string                 `protobuf:"bytes,1,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveScenarioResponse) Reset() {
+	*x = SaveScenarioResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveScenarioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GetSystemInfoResponse) GetRunningServices() []string {
+func (*SaveScenarioResponse) ProtoMessage() {}
+
+func (x *SaveScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[57]
 	if x != nil {
-		return x.RunningServices
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetSystemInfoResponse) GetResources() *SystemResources {
+// Deprecated: Use SaveScenarioResponse.ProtoReflect.Descriptor instead.
+func (*SaveScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SaveScenarioResponse) GetScenarioId() string {
 	if x != nil {
-		return x.Resources
+		return x.ScenarioId
 	}
-	return nil
+	return ""
 }
 
-type SystemResources struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	TotalMemory     int64                  `protobuf:"varint,1,opt,name=total_memory,json=totalMemory,proto3" json:"total_memory,omitempty"`
-	AvailableMemory int64                  `protobuf:"varint,2,opt,name=available_memory,json=availableMemory,proto3" json:"available_memory,omitempty"`
-	TotalDisk       int64                  `protobuf:"varint,3,opt,name=total_disk,json=totalDisk,proto3" json:"total_disk,omitempty"`
-	AvailableDisk   int64                  `protobuf:"varint,4,opt,name=available_disk,json=availableDisk,proto3" json:"available_disk,omitempty"`
-	CpuUsage        float64                `protobuf:"fixed64,5,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetScenarioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScenarioId    string                 `protobuf:"bytes,1,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SystemResources) Reset() {
-	*x = SystemResources{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[8]
+func (x *GetScenarioRequest) Reset() {
+	*x = GetScenarioRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SystemResources) String() string {
+func (x *GetScenarioRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemResources) ProtoMessage() {}
+func (*GetScenarioRequest) ProtoMessage() {}
 
-func (x *SystemResources) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[8]
+func (x *GetScenarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -782,70 +4912,83 @@ func (x *SystemResources) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemResources.ProtoReflect.Descriptor instead.
-func (*SystemResources) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetScenarioRequest.ProtoReflect.Descriptor instead.
+func (*GetScenarioRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *SystemResources) GetTotalMemory() int64 {
+func (x *GetScenarioRequest) GetScenarioId() string {
 	if x != nil {
-		return x.TotalMemory
+		return x.ScenarioId
 	}
-	return 0
+	return ""
 }
 
-func (x *SystemResources) GetAvailableMemory() int64 {
-	if x != nil {
-		return x.AvailableMemory
-	}
-	return 0
+type GetScenarioResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Scenario      *GenerateAttackScenarioResponse `protobuf:"bytes,1,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SystemResources) GetTotalDisk() int64 {
-	if x != nil {
-		return x.TotalDisk
-	}
-	return 0
+func (x *GetScenarioResponse) Reset() {
+	*x = GetScenarioResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *SystemResources) GetAvailableDisk() int64 {
+func (x *GetScenarioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScenarioResponse) ProtoMessage() {}
+
+func (x *GetScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[59]
 	if x != nil {
-		return x.AvailableDisk
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *SystemResources) GetCpuUsage() float64 {
+// Deprecated: Use GetScenarioResponse.ProtoReflect.Descriptor instead.
+func (*GetScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetScenarioResponse) GetScenario() *GenerateAttackScenarioResponse {
 	if x != nil {
-		return x.CpuUsage
+		return x.Scenario
 	}
-	return 0
+	return nil
 }
 
-type GenerateAttackScenarioRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	TargetDescription string                 `protobuf:"bytes,1,opt,name=target_description,json=targetDescription,proto3" json:"target_description,omitempty"`
-	MaxSeverity       DestructionSeverity    `protobuf:"varint,2,opt,name=max_severity,json=maxSeverity,proto3,enum=burndevice.v1.DestructionSeverity" json:"max_severity,omitempty"`
-	AiModel           string                 `protobuf:"bytes,3,opt,name=ai_model,json=aiModel,proto3" json:"ai_model,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type ListScenariosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateAttackScenarioRequest) Reset() {
-	*x = GenerateAttackScenarioRequest{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[9]
+func (x *ListScenariosRequest) Reset() {
+	*x = ListScenariosRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateAttackScenarioRequest) String() string {
+func (x *ListScenariosRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateAttackScenarioRequest) ProtoMessage() {}
+func (*ListScenariosRequest) ProtoMessage() {}
 
-func (x *GenerateAttackScenarioRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[9]
+func (x *ListScenariosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,57 +4999,82 @@ func (x *GenerateAttackScenarioRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateAttackScenarioRequest.ProtoReflect.Descriptor instead.
-func (*GenerateAttackScenarioRequest) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListScenariosRequest.ProtoReflect.Descriptor instead.
+func (*ListScenariosRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *GenerateAttackScenarioRequest) GetTargetDescription() string {
-	if x != nil {
-		return x.TargetDescription
-	}
-	return ""
+type ListScenariosResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first
+	Scenarios     []*ScenarioSummary `protobuf:"bytes,1,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GenerateAttackScenarioRequest) GetMaxSeverity() DestructionSeverity {
+func (x *ListScenariosResponse) Reset() {
+	*x = ListScenariosResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScenariosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScenariosResponse) ProtoMessage() {}
+
+func (x *ListScenariosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[61]
 	if x != nil {
-		return x.MaxSeverity
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *GenerateAttackScenarioRequest) GetAiModel() string {
+// Deprecated: Use ListScenariosResponse.ProtoReflect.Descriptor instead.
+func (*ListScenariosResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListScenariosResponse) GetScenarios() []*ScenarioSummary {
 	if x != nil {
-		return x.AiModel
+		return x.Scenarios
 	}
-	return ""
+	return nil
 }
 
-type GenerateAttackScenarioResponse struct {
+type ScenarioSummary struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	ScenarioId        string                 `protobuf:"bytes,1,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`
 	Description       string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Steps             []*AttackStep          `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
-	EstimatedSeverity DestructionSeverity    `protobuf:"varint,4,opt,name=estimated_severity,json=estimatedSeverity,proto3,enum=burndevice.v1.DestructionSeverity" json:"estimated_severity,omitempty"`
+	EstimatedSeverity DestructionSeverity    `protobuf:"varint,3,opt,name=estimated_severity,json=estimatedSeverity,proto3,enum=burndevice.v1.DestructionSeverity" json:"estimated_severity,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Steps             int32                  `protobuf:"varint,5,opt,name=steps,proto3" json:"steps,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
-func (x *GenerateAttackScenarioResponse) Reset() {
-	*x = GenerateAttackScenarioResponse{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[10]
+func (x *ScenarioSummary) Reset() {
+	*x = ScenarioSummary{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GenerateAttackScenarioResponse) String() string {
+func (x *ScenarioSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GenerateAttackScenarioResponse) ProtoMessage() {}
+func (*ScenarioSummary) ProtoMessage() {}
 
-func (x *GenerateAttackScenarioResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[10]
+func (x *ScenarioSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -917,65 +5085,68 @@ func (x *GenerateAttackScenarioResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GenerateAttackScenarioResponse.ProtoReflect.Descriptor instead.
-func (*GenerateAttackScenarioResponse) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ScenarioSummary.ProtoReflect.Descriptor instead.
+func (*ScenarioSummary) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *GenerateAttackScenarioResponse) GetScenarioId() string {
+func (x *ScenarioSummary) GetScenarioId() string {
 	if x != nil {
 		return x.ScenarioId
 	}
 	return ""
 }
 
-func (x *GenerateAttackScenarioResponse) GetDescription() string {
+func (x *ScenarioSummary) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *GenerateAttackScenarioResponse) GetSteps() []*AttackStep {
+func (x *ScenarioSummary) GetEstimatedSeverity() DestructionSeverity {
 	if x != nil {
-		return x.Steps
+		return x.EstimatedSeverity
+	}
+	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+}
+
+func (x *ScenarioSummary) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-func (x *GenerateAttackScenarioResponse) GetEstimatedSeverity() DestructionSeverity {
+func (x *ScenarioSummary) GetSteps() int32 {
 	if x != nil {
-		return x.EstimatedSeverity
+		return x.Steps
 	}
-	return DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED
+	return 0
 }
 
-type AttackStep struct {
+type DeleteScenarioRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         int32                  `protobuf:"varint,1,opt,name=order,proto3" json:"order,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Type          DestructionType        `protobuf:"varint,3,opt,name=type,proto3,enum=burndevice.v1.DestructionType" json:"type,omitempty"`
-	Targets       []string               `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
-	Rationale     string                 `protobuf:"bytes,5,opt,name=rationale,proto3" json:"rationale,omitempty"`
+	ScenarioId    string                 `protobuf:"bytes,1,opt,name=scenario_id,json=scenarioId,proto3" json:"scenario_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttackStep) Reset() {
-	*x = AttackStep{}
-	mi := &file_burndevice_v1_service_proto_msgTypes[11]
+func (x *DeleteScenarioRequest) Reset() {
+	*x = DeleteScenarioRequest{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttackStep) String() string {
+func (x *DeleteScenarioRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttackStep) ProtoMessage() {}
+func (*DeleteScenarioRequest) ProtoMessage() {}
 
-func (x *AttackStep) ProtoReflect() protoreflect.Message {
-	mi := &file_burndevice_v1_service_proto_msgTypes[11]
+func (x *DeleteScenarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -986,115 +5157,488 @@ func (x *AttackStep) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttackStep.ProtoReflect.Descriptor instead.
-func (*AttackStep) Descriptor() ([]byte, []int) {
-	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DeleteScenarioRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScenarioRequest) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *AttackStep) GetOrder() int32 {
+func (x *DeleteScenarioRequest) GetScenarioId() string {
 	if x != nil {
-		return x.Order
+		return x.ScenarioId
 	}
-	return 0
+	return ""
 }
 
-func (x *AttackStep) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+type DeleteScenarioResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttackStep) GetType() DestructionType {
-	if x != nil {
-		return x.Type
-	}
-	return DestructionType_DESTRUCTION_TYPE_UNSPECIFIED
+func (x *DeleteScenarioResponse) Reset() {
+	*x = DeleteScenarioResponse{}
+	mi := &file_burndevice_v1_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttackStep) GetTargets() []string {
+func (x *DeleteScenarioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScenarioResponse) ProtoMessage() {}
+
+func (x *DeleteScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_burndevice_v1_service_proto_msgTypes[64]
 	if x != nil {
-		return x.Targets
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AttackStep) GetRationale() string {
+// Deprecated: Use DeleteScenarioResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_burndevice_v1_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DeleteScenarioResponse) GetDeleted() bool {
 	if x != nil {
-		return x.Rationale
+		return x.Deleted
 	}
-	return ""
+	return false
 }
 
 var File_burndevice_v1_service_proto protoreflect.FileDescriptor
 
 const file_burndevice_v1_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1bburndevice/v1/service.proto\x12\rburndevice.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x80\x02\n" +
+	"\x1bburndevice/v1/service.proto\x12\rburndevice.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf1\b\n" +
 	"\x19ExecuteDestructionRequest\x122\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12\x18\n" +
 	"\atargets\x18\x02 \x03(\tR\atargets\x12>\n" +
 	"\bseverity\x18\x03 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\bseverity\x12/\n" +
 	"\x13confirm_destruction\x18\x04 \x01(\bR\x12confirmDestruction\x12$\n" +
-	"\x0eai_scenario_id\x18\x05 \x01(\tR\faiScenarioId\"\xc6\x01\n" +
+	"\x0eai_scenario_id\x18\x05 \x01(\tR\faiScenarioId\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\x12+\n" +
+	"\x12max_ops_per_second\x18\a \x01(\x01R\x0fmaxOpsPerSecond\x12/\n" +
+	"\x14max_bytes_per_second\x18\b \x01(\x03R\x11maxBytesPerSecond\x12+\n" +
+	"\x11confirmation_text\x18\t \x01(\tR\x10confirmationText\x12\x1c\n" +
+	"\trecursive\x18\n" +
+	" \x01(\bR\trecursive\x125\n" +
+	"\bduration\x18\v \x01(\v2\x19.google.protobuf.DurationR\bduration\x12)\n" +
+	"\x10file_descriptors\x18\f \x01(\bR\x0ffileDescriptors\x129\n" +
+	"\x18acknowledge_irreversible\x18\r \x01(\bR\x17acknowledgeIrreversible\x12\x1e\n" +
+	"\n" +
+	"quarantine\x18\x0e \x01(\bR\n" +
+	"quarantine\x12G\n" +
+	"\x12auto_restore_after\x18\x0f \x01(\v2\x19.google.protobuf.DurationR\x10autoRestoreAfter\x12C\n" +
+	"\x0efailure_policy\x18\x10 \x01(\x0e2\x1c.burndevice.v1.FailurePolicyR\rfailurePolicy\x12%\n" +
+	"\x0eskip_preflight\x18\x11 \x01(\bR\rskipPreflight\x12)\n" +
+	"\x10include_patterns\x18\x12 \x03(\tR\x0fincludePatterns\x12)\n" +
+	"\x10exclude_patterns\x18\x13 \x03(\tR\x0fexcludePatterns\x122\n" +
+	"\amin_age\x18\x14 \x01(\v2\x19.google.protobuf.DurationR\x06minAge\x12\"\n" +
+	"\rmax_file_size\x18\x15 \x01(\x03R\vmaxFileSize\x12\x12\n" +
+	"\x04wipe\x18\x16 \x01(\bR\x04wipe\x12\x1c\n" +
+	"\tintensity\x18\x17 \x01(\x05R\tintensity\x122\n" +
+	"\x15window_override_token\x18\x18 \x01(\tR\x13windowOverrideToken\x12'\n" +
+	"\x0fidempotency_key\x18\x19 \x01(\tR\x0eidempotencyKey\"\xe6\x04\n" +
 	"\x1aExecuteDestructionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
 	"\aresults\x18\x03 \x03(\v2 .burndevice.v1.DestructionResultR\aresults\x128\n" +
-	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xff\x01\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x17\n" +
+	"\atask_id\x18\x05 \x01(\tR\x06taskId\x127\n" +
+	"\x05steps\x18\x06 \x03(\v2!.burndevice.v1.ScenarioStepResultR\x05steps\x12%\n" +
+	"\x0efiles_filtered\x18\a \x01(\x03R\rfilesFiltered\x12%\n" +
+	"\x0efailed_targets\x18\b \x03(\tR\rfailedTargets\x12F\n" +
+	"\rtotal_metrics\x18\t \x01(\v2!.burndevice.v1.DestructionMetricsR\ftotalMetrics\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12<\n" +
+	"\fhook_results\x18\f \x03(\v2\x19.burndevice.v1.HookResultR\vhookResults\"\xe7\x01\n" +
+	"\x12ScenarioStepResult\x12\x14\n" +
+	"\x05order\x18\x01 \x01(\x05R\x05order\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x122\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12\x17\n" +
+	"\atask_id\x18\x04 \x01(\tR\x06taskId\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\x18\n" +
+	"\askipped\x18\a \x01(\bR\askipped\"\xc7\b\n" +
 	"\x18StreamDestructionRequest\x122\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12\x18\n" +
 	"\atargets\x18\x02 \x03(\tR\atargets\x12>\n" +
 	"\bseverity\x18\x03 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\bseverity\x12/\n" +
 	"\x13confirm_destruction\x18\x04 \x01(\bR\x12confirmDestruction\x12$\n" +
-	"\x0eai_scenario_id\x18\x05 \x01(\tR\faiScenarioId\"\xdc\x01\n" +
+	"\x0eai_scenario_id\x18\x05 \x01(\tR\faiScenarioId\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\x12+\n" +
+	"\x12max_ops_per_second\x18\a \x01(\x01R\x0fmaxOpsPerSecond\x12/\n" +
+	"\x14max_bytes_per_second\x18\b \x01(\x03R\x11maxBytesPerSecond\x12+\n" +
+	"\x11confirmation_text\x18\t \x01(\tR\x10confirmationText\x12\x1c\n" +
+	"\trecursive\x18\n" +
+	" \x01(\bR\trecursive\x125\n" +
+	"\bduration\x18\v \x01(\v2\x19.google.protobuf.DurationR\bduration\x12)\n" +
+	"\x10file_descriptors\x18\f \x01(\bR\x0ffileDescriptors\x129\n" +
+	"\x18acknowledge_irreversible\x18\r \x01(\bR\x17acknowledgeIrreversible\x12\x1e\n" +
+	"\n" +
+	"quarantine\x18\x0e \x01(\bR\n" +
+	"quarantine\x12G\n" +
+	"\x12auto_restore_after\x18\x0f \x01(\v2\x19.google.protobuf.DurationR\x10autoRestoreAfter\x12C\n" +
+	"\x0efailure_policy\x18\x10 \x01(\x0e2\x1c.burndevice.v1.FailurePolicyR\rfailurePolicy\x12%\n" +
+	"\x0eskip_preflight\x18\x11 \x01(\bR\rskipPreflight\x12)\n" +
+	"\x10include_patterns\x18\x12 \x03(\tR\x0fincludePatterns\x12)\n" +
+	"\x10exclude_patterns\x18\x13 \x03(\tR\x0fexcludePatterns\x122\n" +
+	"\amin_age\x18\x14 \x01(\v2\x19.google.protobuf.DurationR\x06minAge\x12\"\n" +
+	"\rmax_file_size\x18\x15 \x01(\x03R\vmaxFileSize\x12\x12\n" +
+	"\x04wipe\x18\x16 \x01(\bR\x04wipe\x12\x1c\n" +
+	"\tintensity\x18\x17 \x01(\x05R\tintensity\x122\n" +
+	"\x15window_override_token\x18\x18 \x01(\tR\x13windowOverrideToken\"\xcb\x04\n" +
 	"\x19StreamDestructionResponse\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x127\n" +
 	"\x04type\x18\x03 \x01(\x0e2#.burndevice.v1.DestructionEventTypeR\x04type\x12\x16\n" +
 	"\x06target\x18\x04 \x01(\tR\x06target\x12\x1a\n" +
-	"\bprogress\x18\x05 \x01(\x01R\bprogress\"\xa7\x01\n" +
+	"\bprogress\x18\x05 \x01(\x01R\bprogress\x12\x17\n" +
+	"\atask_id\x18\x06 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04step\x18\a \x01(\x05R\x04step\x12%\n" +
+	"\x0efiles_filtered\x18\b \x01(\x03R\rfilesFiltered\x12%\n" +
+	"\x0efailed_targets\x18\t \x03(\tR\rfailedTargets\x12:\n" +
+	"\aresults\x18\n" +
+	" \x03(\v2 .burndevice.v1.DestructionResultR\aresults\x129\n" +
+	"\n" +
+	"started_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12<\n" +
+	"\fhook_results\x18\r \x03(\v2\x19.burndevice.v1.HookResultR\vhookResults\"\xce\x04\n" +
 	"\x11DestructionResult\x12\x16\n" +
 	"\x06target\x18\x01 \x01(\tR\x06target\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
 	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12;\n" +
-	"\ametrics\x18\x04 \x01(\v2!.burndevice.v1.DestructionMetricsR\ametrics\"\x98\x01\n" +
+	"\ametrics\x18\x04 \x01(\v2!.burndevice.v1.DestructionMetricsR\ametrics\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12<\n" +
+	"\fhook_results\x18\x06 \x03(\v2\x19.burndevice.v1.HookResultR\vhookResults\x12\x1f\n" +
+	"\vbackup_path\x18\a \x01(\tR\n" +
+	"backupPath\x12\x16\n" +
+	"\x06output\x18\b \x01(\tR\x06output\x12\x16\n" +
+	"\x06stderr\x18\t \x01(\tR\x06stderr\x12%\n" +
+	"\x0eprevious_state\x18\n" +
+	" \x01(\tR\rpreviousState\x12'\n" +
+	"\x0fbackup_checksum\x18\v \x01(\tR\x0ebackupChecksum\x12\x1a\n" +
+	"\battempts\x18\f \x01(\x05R\battempts\x12\x18\n" +
+	"\askipped\x18\r \x01(\bR\askipped\x129\n" +
+	"\n" +
+	"started_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xb0\x01\n" +
+	"\n" +
+	"HookResult\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06output\x18\x03 \x01(\tR\x06output\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12\x14\n" +
+	"\x05phase\x18\x05 \x01(\tR\x05phase\x12\x1b\n" +
+	"\texit_code\x18\x06 \x01(\x05R\bexitCode\"\xa5\x04\n" +
 	"\x12DestructionMetrics\x12#\n" +
 	"\rfiles_deleted\x18\x01 \x01(\x03R\ffilesDeleted\x12'\n" +
 	"\x0fbytes_destroyed\x18\x02 \x01(\x03R\x0ebytesDestroyed\x124\n" +
-	"\x16execution_time_seconds\x18\x03 \x01(\x01R\x14executionTimeSeconds\"\x16\n" +
-	"\x14GetSystemInfoRequest\"\xf7\x01\n" +
+	"\x16execution_time_seconds\x18\x03 \x01(\x01R\x14executionTimeSeconds\x12'\n" +
+	"\x0fbytes_allocated\x18\x04 \x01(\x03R\x0ebytesAllocated\x12+\n" +
+	"\x11bytes_overwritten\x18\x05 \x01(\x03R\x10bytesOverwritten\x12#\n" +
+	"\rbytes_written\x18\x06 \x01(\x03R\fbytesWritten\x12+\n" +
+	"\x11offsets_corrupted\x18\a \x01(\x03R\x10offsetsCorrupted\x12'\n" +
+	"\x0fbytes_corrupted\x18\b \x01(\x03R\x0ebytesCorrupted\x12!\n" +
+	"\fcores_burned\x18\t \x01(\x03R\vcoresBurned\x12*\n" +
+	"\x11cpu_usage_percent\x18\n" +
+	" \x01(\x01R\x0fcpuUsagePercent\x12'\n" +
+	"\x0finodes_consumed\x18\v \x01(\x03R\x0einodesConsumed\x12!\n" +
+	"\ffds_consumed\x18\f \x01(\x03R\vfdsConsumed\x12\x1f\n" +
+	"\vwipe_passes\x18\r \x01(\x03R\n" +
+	"wipePasses\"\x89\x01\n" +
+	"\x19RestoreDestructionRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
+	"\atargets\x18\x02 \x03(\tR\atargets\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x12#\n" +
+	"\rremove_backup\x18\x04 \x01(\bR\fremoveBackup\"\xc2\x01\n" +
+	"\x1aRestoreDestructionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
+	"\aresults\x18\x03 \x03(\v2\x1c.burndevice.v1.RestoreResultR\aresults\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\xae\x01\n" +
+	"\rRestoreResult\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12\x1f\n" +
+	"\vbackup_path\x18\x04 \x01(\tR\n" +
+	"backupPath\x12%\n" +
+	"\x0ebytes_restored\x18\x05 \x01(\x03R\rbytesRestored\"H\n" +
+	"\x13VerifyBackupRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
+	"\atargets\x18\x02 \x03(\tR\atargets\"\x87\x01\n" +
+	"\x14VerifyBackupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\aresults\x18\x03 \x03(\v2!.burndevice.v1.VerifyBackupResultR\aresults\"\xb3\x01\n" +
+	"\x12VerifyBackupResult\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12\x1f\n" +
+	"\vbackup_path\x18\x04 \x01(\tR\n" +
+	"backupPath\x12%\n" +
+	"\x0efiles_verified\x18\x05 \x01(\x03R\rfilesVerified\"c\n" +
+	"\x15CleanupBackupsRequest\x12\x10\n" +
+	"\x03dir\x18\x01 \x01(\tR\x03dir\x128\n" +
+	"\n" +
+	"older_than\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\tolderThan\"\x87\x01\n" +
+	"\x16CleanupBackupsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aremoved\x18\x03 \x03(\tR\aremoved\x12\x1f\n" +
+	"\vbytes_freed\x18\x04 \x01(\x03R\n" +
+	"bytesFreed\"/\n" +
+	"\x14GetTaskStatusRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"F\n" +
+	"\x15GetTaskStatusResponse\x12-\n" +
+	"\x04task\x18\x01 \x01(\v2\x19.burndevice.v1.TaskStatusR\x04task\"3\n" +
+	"\x18CancelDestructionRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xa7\x01\n" +
+	"\x19CancelDestructionResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x11targets_processed\x18\x03 \x01(\x05R\x10targetsProcessed\x12-\n" +
+	"\x04task\x18\x04 \x01(\v2\x19.burndevice.v1.TaskStatusR\x04task\"\x12\n" +
+	"\x10ListTasksRequest\"D\n" +
+	"\x11ListTasksResponse\x12/\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x19.burndevice.v1.TaskStatusR\x05tasks\"Z\n" +
+	"\x14ExpandTargetsRequest\x12\x18\n" +
+	"\apattern\x18\x01 \x01(\tR\apattern\x12\x12\n" +
+	"\x04root\x18\x02 \x01(\tR\x04root\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x90\x01\n" +
+	"\x15ExpandTargetsResponse\x124\n" +
+	"\amatches\x18\x01 \x03(\v2\x1a.burndevice.v1.TargetMatchR\amatches\x12#\n" +
+	"\rtotal_matches\x18\x02 \x01(\x05R\ftotalMatches\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\"\x80\x01\n" +
+	"\vTargetMatch\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x15\n" +
+	"\x06is_dir\x18\x02 \x01(\bR\x05isDir\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x18\n" +
+	"\ablocked\x18\x04 \x01(\bR\ablocked\x12\x18\n" +
+	"\aallowed\x18\x05 \x01(\bR\aallowed\"W\n" +
+	"\x16PlanDestructionRequest\x12\x18\n" +
+	"\atargets\x18\x01 \x03(\tR\atargets\x12#\n" +
+	"\rlargest_files\x18\x02 \x01(\x05R\flargestFiles\"\xb9\x01\n" +
+	"\x17PlanDestructionResponse\x123\n" +
+	"\atargets\x18\x01 \x03(\v2\x19.burndevice.v1.TargetPlanR\atargets\x12\x1f\n" +
+	"\vtotal_files\x18\x02 \x01(\x03R\n" +
+	"totalFiles\x12\x1f\n" +
+	"\vtotal_bytes\x18\x03 \x01(\x03R\n" +
+	"totalBytes\x12'\n" +
+	"\x0frefused_targets\x18\x04 \x01(\x05R\x0erefusedTargets\"\xe4\x01\n" +
+	"\n" +
+	"TargetPlan\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x15\n" +
+	"\x06is_dir\x18\x02 \x01(\bR\x05isDir\x12\x14\n" +
+	"\x05files\x18\x03 \x01(\x03R\x05files\x12\x14\n" +
+	"\x05bytes\x18\x04 \x01(\x03R\x05bytes\x12<\n" +
+	"\rlargest_files\x18\x05 \x03(\v2\x17.burndevice.v1.FileSizeR\flargestFiles\x12\x18\n" +
+	"\aallowed\x18\x06 \x01(\bR\aallowed\x12#\n" +
+	"\rerror_message\x18\a \x01(\tR\ferrorMessage\"2\n" +
+	"\bFileSize\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\"\xeb\x01\n" +
+	"\x15GetTaskHistoryRequest\x122\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"\x87\x01\n" +
+	"\x16GetTaskHistoryResponse\x12/\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x19.burndevice.v1.TaskRecordR\x05tasks\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\xf2\x04\n" +
+	"\n" +
+	"TaskRecord\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x122\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12>\n" +
+	"\bseverity\x18\x03 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\bseverity\x12\x18\n" +
+	"\atargets\x18\x04 \x03(\tR\atargets\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12:\n" +
+	"\aresults\x18\n" +
+	" \x03(\v2 .burndevice.v1.DestructionResultR\aresults\x12\x14\n" +
+	"\x05phase\x18\v \x01(\tR\x05phase\x12B\n" +
+	"\x0fauto_restore_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\rautoRestoreAt\x12<\n" +
+	"\fhook_results\x18\r \x03(\v2\x19.burndevice.v1.HookResultR\vhookResults\x12'\n" +
+	"\x0fidempotency_key\x18\x0e \x01(\tR\x0eidempotencyKey\"\xaf\x01\n" +
+	"\vAutoRestore\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x122\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12\x18\n" +
+	"\atargets\x18\x03 \x03(\tR\atargets\x129\n" +
+	"\n" +
+	"restore_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\trestoreAt\"1\n" +
+	"\x16SubscribeEventsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xa5\x01\n" +
+	"\x1aScheduleDestructionRequest\x12B\n" +
+	"\arequest\x18\x01 \x01(\v2(.burndevice.v1.ExecuteDestructionRequestR\arequest\x12/\n" +
+	"\x05delay\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x05delay\x12\x12\n" +
+	"\x04cron\x18\x03 \x01(\tR\x04cron\"R\n" +
+	"\x1bScheduleDestructionResponse\x123\n" +
+	"\bschedule\x18\x01 \x01(\v2\x17.burndevice.v1.ScheduleR\bschedule\"\x16\n" +
+	"\x14ListSchedulesRequest\"N\n" +
+	"\x15ListSchedulesResponse\x125\n" +
+	"\tschedules\x18\x01 \x03(\v2\x17.burndevice.v1.ScheduleR\tschedules\"8\n" +
+	"\x15DeleteScheduleRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"2\n" +
+	"\x16DeleteScheduleResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\x85\x03\n" +
+	"\bSchedule\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12B\n" +
+	"\arequest\x18\x02 \x01(\v2(.burndevice.v1.ExecuteDestructionRequestR\arequest\x12\x12\n" +
+	"\x04cron\x18\x03 \x01(\tR\x04cron\x125\n" +
+	"\bnext_run\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\anextRun\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06client\x18\x06 \x01(\tR\x06client\x125\n" +
+	"\blast_run\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\alastRun\x12 \n" +
+	"\flast_task_id\x18\b \x01(\tR\n" +
+	"lastTaskId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\"\x16\n" +
+	"\x14GetServerInfoRequest\"\xdd\x01\n" +
+	"\x15GetServerInfoResponse\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x0e\n" +
+	"\x02os\x18\x02 \x01(\tR\x02os\x12\x10\n" +
+	"\x03uid\x18\x03 \x01(\tR\x03uid\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1e\n" +
+	"\n" +
+	"privileged\x18\x05 \x01(\bR\n" +
+	"privileged\x12\x1d\n" +
+	"\n" +
+	"allow_root\x18\x06 \x01(\bR\tallowRoot\x12+\n" +
+	"\x11connection_banner\x18\a \x01(\tR\x10connectionBanner\"\x1a\n" +
+	"\x18CheckCapabilitiesRequest\"\x96\x01\n" +
+	"\x19CheckCapabilitiesResponse\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x1e\n" +
+	"\n" +
+	"privileged\x18\x02 \x01(\bR\n" +
+	"privileged\x12=\n" +
+	"\fcapabilities\x18\x03 \x03(\v2\x19.burndevice.v1.CapabilityR\fcapabilities\"\xb2\x01\n" +
+	"\n" +
+	"Capability\x122\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12 \n" +
+	"\vimplemented\x18\x02 \x01(\bR\vimplemented\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\x13\n" +
+	"\x11GetMetricsRequest\"\xb1\x03\n" +
+	"\x12GetMetricsResponse\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x129\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12-\n" +
+	"\x12destructions_total\x18\x03 \x01(\x03R\x11destructionsTotal\x12/\n" +
+	"\x13destructions_failed\x18\x04 \x01(\x03R\x12destructionsFailed\x12.\n" +
+	"\x13files_deleted_total\x18\x05 \x01(\x03R\x11filesDeletedTotal\x122\n" +
+	"\x15bytes_destroyed_total\x18\x06 \x01(\x03R\x13bytesDestroyedTotal\x12:\n" +
+	"\x19scenarios_generated_total\x18\a \x01(\x03R\x17scenariosGeneratedTotal\x12&\n" +
+	"\x0fai_tokens_total\x18\b \x01(\x03R\raiTokensTotal\"\xb0\x03\n" +
+	"\n" +
+	"TaskStatus\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x122\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12>\n" +
+	"\bseverity\x18\x03 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\bseverity\x12\x18\n" +
+	"\atargets\x18\x04 \x03(\tR\atargets\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12\x1a\n" +
+	"\bprogress\x18\x06 \x01(\x01R\bprogress\x12%\n" +
+	"\x0ecurrent_target\x18\a \x01(\tR\rcurrentTarget\x129\n" +
+	"\n" +
+	"started_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12+\n" +
+	"\x11targets_processed\x18\t \x01(\x05R\x10targetsProcessed\x12:\n" +
+	"\aresults\x18\n" +
+	" \x03(\v2 .burndevice.v1.DestructionResultR\aresults\"\x16\n" +
+	"\x14GetSystemInfoRequest\"\xb4\x02\n" +
 	"\x15GetSystemInfoResponse\x12\x0e\n" +
 	"\x02os\x18\x01 \x01(\tR\x02os\x12\"\n" +
 	"\farchitecture\x18\x02 \x01(\tR\farchitecture\x12\x1a\n" +
 	"\bhostname\x18\x03 \x01(\tR\bhostname\x12%\n" +
 	"\x0ecritical_paths\x18\x04 \x03(\tR\rcriticalPaths\x12)\n" +
 	"\x10running_services\x18\x05 \x03(\tR\x0frunningServices\x12<\n" +
-	"\tresources\x18\x06 \x01(\v2\x1e.burndevice.v1.SystemResourcesR\tresources\"\xc2\x01\n" +
+	"\tresources\x18\x06 \x01(\v2\x1e.burndevice.v1.SystemResourcesR\tresources\x12;\n" +
+	"\n" +
+	"path_disks\x18\a \x03(\v2\x1c.burndevice.v1.PathDiskUsageR\tpathDisks\"i\n" +
+	"\rPathDiskUsage\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"total_disk\x18\x02 \x01(\x03R\ttotalDisk\x12%\n" +
+	"\x0eavailable_disk\x18\x03 \x01(\x03R\ravailableDisk\"\xc2\x01\n" +
 	"\x0fSystemResources\x12!\n" +
 	"\ftotal_memory\x18\x01 \x01(\x03R\vtotalMemory\x12)\n" +
 	"\x10available_memory\x18\x02 \x01(\x03R\x0favailableMemory\x12\x1d\n" +
 	"\n" +
 	"total_disk\x18\x03 \x01(\x03R\ttotalDisk\x12%\n" +
 	"\x0eavailable_disk\x18\x04 \x01(\x03R\ravailableDisk\x12\x1b\n" +
-	"\tcpu_usage\x18\x05 \x01(\x01R\bcpuUsage\"\xb0\x01\n" +
+	"\tcpu_usage\x18\x05 \x01(\x01R\bcpuUsage\"\xf5\x01\n" +
 	"\x1dGenerateAttackScenarioRequest\x12-\n" +
 	"\x12target_description\x18\x01 \x01(\tR\x11targetDescription\x12E\n" +
 	"\fmax_severity\x18\x02 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\vmaxSeverity\x12\x19\n" +
-	"\bai_model\x18\x03 \x01(\tR\aaiModel\"\xe7\x01\n" +
+	"\bai_model\x18\x03 \x01(\tR\aaiModel\x12C\n" +
+	"\rallowed_types\x18\x04 \x03(\x0e2\x1e.burndevice.v1.DestructionTypeR\fallowedTypes\"\xfd\x02\n" +
 	"\x1eGenerateAttackScenarioResponse\x12\x1f\n" +
 	"\vscenario_id\x18\x01 \x01(\tR\n" +
 	"scenarioId\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12/\n" +
 	"\x05steps\x18\x03 \x03(\v2\x19.burndevice.v1.AttackStepR\x05steps\x12Q\n" +
-	"\x12estimated_severity\x18\x04 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\x11estimatedSeverity\"\xb0\x01\n" +
+	"\x12estimated_severity\x18\x04 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\x11estimatedSeverity\x12\x1c\n" +
+	"\trationale\x18\x05 \x01(\tR\trationale\x12\x1a\n" +
+	"\bwarnings\x18\x06 \x03(\tR\bwarnings\x12\x1f\n" +
+	"\vtokens_used\x18\a \x01(\x03R\n" +
+	"tokensUsed\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xe0\x01\n" +
 	"\n" +
 	"AttackStep\x12\x14\n" +
 	"\x05order\x18\x01 \x01(\x05R\x05order\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x122\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x1e.burndevice.v1.DestructionTypeR\x04type\x12\x18\n" +
 	"\atargets\x18\x04 \x03(\tR\atargets\x12\x1c\n" +
-	"\trationale\x18\x05 \x01(\tR\trationale*\xe5\x02\n" +
+	"\trationale\x18\x05 \x01(\tR\trationale\x12\x12\n" +
+	"\x04risk\x18\x06 \x01(\tR\x04risk\x12\x1a\n" +
+	"\bcommands\x18\a \x03(\tR\bcommands\"`\n" +
+	"\x13SaveScenarioRequest\x12I\n" +
+	"\bscenario\x18\x01 \x01(\v2-.burndevice.v1.GenerateAttackScenarioResponseR\bscenario\"7\n" +
+	"\x14SaveScenarioResponse\x12\x1f\n" +
+	"\vscenario_id\x18\x01 \x01(\tR\n" +
+	"scenarioId\"5\n" +
+	"\x12GetScenarioRequest\x12\x1f\n" +
+	"\vscenario_id\x18\x01 \x01(\tR\n" +
+	"scenarioId\"`\n" +
+	"\x13GetScenarioResponse\x12I\n" +
+	"\bscenario\x18\x01 \x01(\v2-.burndevice.v1.GenerateAttackScenarioResponseR\bscenario\"\x16\n" +
+	"\x14ListScenariosRequest\"U\n" +
+	"\x15ListScenariosResponse\x12<\n" +
+	"\tscenarios\x18\x01 \x03(\v2\x1e.burndevice.v1.ScenarioSummaryR\tscenarios\"\xf8\x01\n" +
+	"\x0fScenarioSummary\x12\x1f\n" +
+	"\vscenario_id\x18\x01 \x01(\tR\n" +
+	"scenarioId\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12Q\n" +
+	"\x12estimated_severity\x18\x03 \x01(\x0e2\".burndevice.v1.DestructionSeverityR\x11estimatedSeverity\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05steps\x18\x05 \x01(\x05R\x05steps\"8\n" +
+	"\x15DeleteScenarioRequest\x12\x1f\n" +
+	"\vscenario_id\x18\x01 \x01(\tR\n" +
+	"scenarioId\"2\n" +
+	"\x16DeleteScenarioResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted*\xd1\x03\n" +
 	"\x0fDestructionType\x12 \n" +
 	"\x1cDESTRUCTION_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eDESTRUCTION_TYPE_FILE_DELETION\x10\x01\x12(\n" +
@@ -1104,25 +5648,57 @@ const file_burndevice_v1_service_proto_rawDesc = "" +
 	"\x1aDESTRUCTION_TYPE_DISK_FILL\x10\x05\x12'\n" +
 	"#DESTRUCTION_TYPE_NETWORK_DISRUPTION\x10\x06\x12$\n" +
 	" DESTRUCTION_TYPE_BOOT_CORRUPTION\x10\a\x12!\n" +
-	"\x1dDESTRUCTION_TYPE_KERNEL_PANIC\x10\b*\xbc\x01\n" +
+	"\x1dDESTRUCTION_TYPE_KERNEL_PANIC\x10\b\x12$\n" +
+	" DESTRUCTION_TYPE_FILE_CORRUPTION\x10\t\x12\x1d\n" +
+	"\x19DESTRUCTION_TYPE_CPU_BURN\x10\n" +
+	"\x12%\n" +
+	"!DESTRUCTION_TYPE_INODE_EXHAUSTION\x10\v*\xbc\x01\n" +
 	"\x13DestructionSeverity\x12$\n" +
 	" DESTRUCTION_SEVERITY_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18DESTRUCTION_SEVERITY_LOW\x10\x01\x12\x1f\n" +
 	"\x1bDESTRUCTION_SEVERITY_MEDIUM\x10\x02\x12\x1d\n" +
 	"\x19DESTRUCTION_SEVERITY_HIGH\x10\x03\x12!\n" +
-	"\x1dDESTRUCTION_SEVERITY_CRITICAL\x10\x04*\xf3\x01\n" +
+	"\x1dDESTRUCTION_SEVERITY_CRITICAL\x10\x04*\xec\x02\n" +
 	"\x14DestructionEventType\x12&\n" +
 	"\"DESTRUCTION_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eDESTRUCTION_EVENT_TYPE_STARTED\x10\x01\x12#\n" +
 	"\x1fDESTRUCTION_EVENT_TYPE_PROGRESS\x10\x02\x12$\n" +
 	" DESTRUCTION_EVENT_TYPE_COMPLETED\x10\x03\x12 \n" +
 	"\x1cDESTRUCTION_EVENT_TYPE_ERROR\x10\x04\x12\"\n" +
-	"\x1eDESTRUCTION_EVENT_TYPE_WARNING\x10\x052\xbb\x03\n" +
+	"\x1eDESTRUCTION_EVENT_TYPE_WARNING\x10\x05\x12$\n" +
+	" DESTRUCTION_EVENT_TYPE_CANCELLED\x10\x06\x12'\n" +
+	"#DESTRUCTION_EVENT_TYPE_STEP_STARTED\x10\a\x12(\n" +
+	"$DESTRUCTION_EVENT_TYPE_STEP_FINISHED\x10\b*j\n" +
+	"\rFailurePolicy\x12\x1e\n" +
+	"\x1aFAILURE_POLICY_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17FAILURE_POLICY_CONTINUE\x10\x01\x12\x1c\n" +
+	"\x18FAILURE_POLICY_FAIL_FAST\x10\x022\x9f\x12\n" +
 	"\x11BurnDeviceService\x12i\n" +
 	"\x12ExecuteDestruction\x12(.burndevice.v1.ExecuteDestructionRequest\x1a).burndevice.v1.ExecuteDestructionResponse\x12Z\n" +
 	"\rGetSystemInfo\x12#.burndevice.v1.GetSystemInfoRequest\x1a$.burndevice.v1.GetSystemInfoResponse\x12u\n" +
 	"\x16GenerateAttackScenario\x12,.burndevice.v1.GenerateAttackScenarioRequest\x1a-.burndevice.v1.GenerateAttackScenarioResponse\x12h\n" +
-	"\x11StreamDestruction\x12'.burndevice.v1.StreamDestructionRequest\x1a(.burndevice.v1.StreamDestructionResponse0\x01B=Z;github.com/BurnDevice/BurnDevice/burndevice/v1;burndevicev1b\x06proto3"
+	"\x11StreamDestruction\x12'.burndevice.v1.StreamDestructionRequest\x1a(.burndevice.v1.StreamDestructionResponse0\x01\x12i\n" +
+	"\x12RestoreDestruction\x12(.burndevice.v1.RestoreDestructionRequest\x1a).burndevice.v1.RestoreDestructionResponse\x12W\n" +
+	"\fVerifyBackup\x12\".burndevice.v1.VerifyBackupRequest\x1a#.burndevice.v1.VerifyBackupResponse\x12]\n" +
+	"\x0eCleanupBackups\x12$.burndevice.v1.CleanupBackupsRequest\x1a%.burndevice.v1.CleanupBackupsResponse\x12Z\n" +
+	"\rGetTaskStatus\x12#.burndevice.v1.GetTaskStatusRequest\x1a$.burndevice.v1.GetTaskStatusResponse\x12f\n" +
+	"\x11CancelDestruction\x12'.burndevice.v1.CancelDestructionRequest\x1a(.burndevice.v1.CancelDestructionResponse\x12N\n" +
+	"\tListTasks\x12\x1f.burndevice.v1.ListTasksRequest\x1a .burndevice.v1.ListTasksResponse\x12Z\n" +
+	"\rExpandTargets\x12#.burndevice.v1.ExpandTargetsRequest\x1a$.burndevice.v1.ExpandTargetsResponse\x12`\n" +
+	"\x0fPlanDestruction\x12%.burndevice.v1.PlanDestructionRequest\x1a&.burndevice.v1.PlanDestructionResponse\x12]\n" +
+	"\x0eGetTaskHistory\x12$.burndevice.v1.GetTaskHistoryRequest\x1a%.burndevice.v1.GetTaskHistoryResponse\x12d\n" +
+	"\x0fSubscribeEvents\x12%.burndevice.v1.SubscribeEventsRequest\x1a(.burndevice.v1.StreamDestructionResponse0\x01\x12l\n" +
+	"\x13ScheduleDestruction\x12).burndevice.v1.ScheduleDestructionRequest\x1a*.burndevice.v1.ScheduleDestructionResponse\x12Z\n" +
+	"\rListSchedules\x12#.burndevice.v1.ListSchedulesRequest\x1a$.burndevice.v1.ListSchedulesResponse\x12]\n" +
+	"\x0eDeleteSchedule\x12$.burndevice.v1.DeleteScheduleRequest\x1a%.burndevice.v1.DeleteScheduleResponse\x12Z\n" +
+	"\rGetServerInfo\x12#.burndevice.v1.GetServerInfoRequest\x1a$.burndevice.v1.GetServerInfoResponse\x12Q\n" +
+	"\n" +
+	"GetMetrics\x12 .burndevice.v1.GetMetricsRequest\x1a!.burndevice.v1.GetMetricsResponse\x12f\n" +
+	"\x11CheckCapabilities\x12'.burndevice.v1.CheckCapabilitiesRequest\x1a(.burndevice.v1.CheckCapabilitiesResponse\x12W\n" +
+	"\fSaveScenario\x12\".burndevice.v1.SaveScenarioRequest\x1a#.burndevice.v1.SaveScenarioResponse\x12T\n" +
+	"\vGetScenario\x12!.burndevice.v1.GetScenarioRequest\x1a\".burndevice.v1.GetScenarioResponse\x12Z\n" +
+	"\rListScenarios\x12#.burndevice.v1.ListScenariosRequest\x1a$.burndevice.v1.ListScenariosResponse\x12]\n" +
+	"\x0eDeleteScenario\x12$.burndevice.v1.DeleteScenarioRequest\x1a%.burndevice.v1.DeleteScenarioResponseB=Z;github.com/BurnDevice/BurnDevice/burndevice/v1;burndevicev1b\x06proto3"
 
 var (
 	file_burndevice_v1_service_proto_rawDescOnce sync.Once
@@ -1136,54 +5712,217 @@ func file_burndevice_v1_service_proto_rawDescGZIP() []byte {
 	return file_burndevice_v1_service_proto_rawDescData
 }
 
-var file_burndevice_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_burndevice_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_burndevice_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_burndevice_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 65)
 var file_burndevice_v1_service_proto_goTypes = []any{
 	(DestructionType)(0),                   // 0: burndevice.v1.DestructionType
 	(DestructionSeverity)(0),               // 1: burndevice.v1.DestructionSeverity
 	(DestructionEventType)(0),              // 2: burndevice.v1.DestructionEventType
-	(*ExecuteDestructionRequest)(nil),      // 3: burndevice.v1.ExecuteDestructionRequest
-	(*ExecuteDestructionResponse)(nil),     // 4: burndevice.v1.ExecuteDestructionResponse
-	(*StreamDestructionRequest)(nil),       // 5: burndevice.v1.StreamDestructionRequest
-	(*StreamDestructionResponse)(nil),      // 6: burndevice.v1.StreamDestructionResponse
-	(*DestructionResult)(nil),              // 7: burndevice.v1.DestructionResult
-	(*DestructionMetrics)(nil),             // 8: burndevice.v1.DestructionMetrics
-	(*GetSystemInfoRequest)(nil),           // 9: burndevice.v1.GetSystemInfoRequest
-	(*GetSystemInfoResponse)(nil),          // 10: burndevice.v1.GetSystemInfoResponse
-	(*SystemResources)(nil),                // 11: burndevice.v1.SystemResources
-	(*GenerateAttackScenarioRequest)(nil),  // 12: burndevice.v1.GenerateAttackScenarioRequest
-	(*GenerateAttackScenarioResponse)(nil), // 13: burndevice.v1.GenerateAttackScenarioResponse
-	(*AttackStep)(nil),                     // 14: burndevice.v1.AttackStep
-	(*timestamppb.Timestamp)(nil),          // 15: google.protobuf.Timestamp
+	(FailurePolicy)(0),                     // 3: burndevice.v1.FailurePolicy
+	(*ExecuteDestructionRequest)(nil),      // 4: burndevice.v1.ExecuteDestructionRequest
+	(*ExecuteDestructionResponse)(nil),     // 5: burndevice.v1.ExecuteDestructionResponse
+	(*ScenarioStepResult)(nil),             // 6: burndevice.v1.ScenarioStepResult
+	(*StreamDestructionRequest)(nil),       // 7: burndevice.v1.StreamDestructionRequest
+	(*StreamDestructionResponse)(nil),      // 8: burndevice.v1.StreamDestructionResponse
+	(*DestructionResult)(nil),              // 9: burndevice.v1.DestructionResult
+	(*HookResult)(nil),                     // 10: burndevice.v1.HookResult
+	(*DestructionMetrics)(nil),             // 11: burndevice.v1.DestructionMetrics
+	(*RestoreDestructionRequest)(nil),      // 12: burndevice.v1.RestoreDestructionRequest
+	(*RestoreDestructionResponse)(nil),     // 13: burndevice.v1.RestoreDestructionResponse
+	(*RestoreResult)(nil),                  // 14: burndevice.v1.RestoreResult
+	(*VerifyBackupRequest)(nil),            // 15: burndevice.v1.VerifyBackupRequest
+	(*VerifyBackupResponse)(nil),           // 16: burndevice.v1.VerifyBackupResponse
+	(*VerifyBackupResult)(nil),             // 17: burndevice.v1.VerifyBackupResult
+	(*CleanupBackupsRequest)(nil),          // 18: burndevice.v1.CleanupBackupsRequest
+	(*CleanupBackupsResponse)(nil),         // 19: burndevice.v1.CleanupBackupsResponse
+	(*GetTaskStatusRequest)(nil),           // 20: burndevice.v1.GetTaskStatusRequest
+	(*GetTaskStatusResponse)(nil),          // 21: burndevice.v1.GetTaskStatusResponse
+	(*CancelDestructionRequest)(nil),       // 22: burndevice.v1.CancelDestructionRequest
+	(*CancelDestructionResponse)(nil),      // 23: burndevice.v1.CancelDestructionResponse
+	(*ListTasksRequest)(nil),               // 24: burndevice.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),              // 25: burndevice.v1.ListTasksResponse
+	(*ExpandTargetsRequest)(nil),           // 26: burndevice.v1.ExpandTargetsRequest
+	(*ExpandTargetsResponse)(nil),          // 27: burndevice.v1.ExpandTargetsResponse
+	(*TargetMatch)(nil),                    // 28: burndevice.v1.TargetMatch
+	(*PlanDestructionRequest)(nil),         // 29: burndevice.v1.PlanDestructionRequest
+	(*PlanDestructionResponse)(nil),        // 30: burndevice.v1.PlanDestructionResponse
+	(*TargetPlan)(nil),                     // 31: burndevice.v1.TargetPlan
+	(*FileSize)(nil),                       // 32: burndevice.v1.FileSize
+	(*GetTaskHistoryRequest)(nil),          // 33: burndevice.v1.GetTaskHistoryRequest
+	(*GetTaskHistoryResponse)(nil),         // 34: burndevice.v1.GetTaskHistoryResponse
+	(*TaskRecord)(nil),                     // 35: burndevice.v1.TaskRecord
+	(*AutoRestore)(nil),                    // 36: burndevice.v1.AutoRestore
+	(*SubscribeEventsRequest)(nil),         // 37: burndevice.v1.SubscribeEventsRequest
+	(*ScheduleDestructionRequest)(nil),     // 38: burndevice.v1.ScheduleDestructionRequest
+	(*ScheduleDestructionResponse)(nil),    // 39: burndevice.v1.ScheduleDestructionResponse
+	(*ListSchedulesRequest)(nil),           // 40: burndevice.v1.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),          // 41: burndevice.v1.ListSchedulesResponse
+	(*DeleteScheduleRequest)(nil),          // 42: burndevice.v1.DeleteScheduleRequest
+	(*DeleteScheduleResponse)(nil),         // 43: burndevice.v1.DeleteScheduleResponse
+	(*Schedule)(nil),                       // 44: burndevice.v1.Schedule
+	(*GetServerInfoRequest)(nil),           // 45: burndevice.v1.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil),          // 46: burndevice.v1.GetServerInfoResponse
+	(*CheckCapabilitiesRequest)(nil),       // 47: burndevice.v1.CheckCapabilitiesRequest
+	(*CheckCapabilitiesResponse)(nil),      // 48: burndevice.v1.CheckCapabilitiesResponse
+	(*Capability)(nil),                     // 49: burndevice.v1.Capability
+	(*GetMetricsRequest)(nil),              // 50: burndevice.v1.GetMetricsRequest
+	(*GetMetricsResponse)(nil),             // 51: burndevice.v1.GetMetricsResponse
+	(*TaskStatus)(nil),                     // 52: burndevice.v1.TaskStatus
+	(*GetSystemInfoRequest)(nil),           // 53: burndevice.v1.GetSystemInfoRequest
+	(*GetSystemInfoResponse)(nil),          // 54: burndevice.v1.GetSystemInfoResponse
+	(*PathDiskUsage)(nil),                  // 55: burndevice.v1.PathDiskUsage
+	(*SystemResources)(nil),                // 56: burndevice.v1.SystemResources
+	(*GenerateAttackScenarioRequest)(nil),  // 57: burndevice.v1.GenerateAttackScenarioRequest
+	(*GenerateAttackScenarioResponse)(nil), // 58: burndevice.v1.GenerateAttackScenarioResponse
+	(*AttackStep)(nil),                     // 59: burndevice.v1.AttackStep
+	(*SaveScenarioRequest)(nil),            // 60: burndevice.v1.SaveScenarioRequest
+	(*SaveScenarioResponse)(nil),           // 61: burndevice.v1.SaveScenarioResponse
+	(*GetScenarioRequest)(nil),             // 62: burndevice.v1.GetScenarioRequest
+	(*GetScenarioResponse)(nil),            // 63: burndevice.v1.GetScenarioResponse
+	(*ListScenariosRequest)(nil),           // 64: burndevice.v1.ListScenariosRequest
+	(*ListScenariosResponse)(nil),          // 65: burndevice.v1.ListScenariosResponse
+	(*ScenarioSummary)(nil),                // 66: burndevice.v1.ScenarioSummary
+	(*DeleteScenarioRequest)(nil),          // 67: burndevice.v1.DeleteScenarioRequest
+	(*DeleteScenarioResponse)(nil),         // 68: burndevice.v1.DeleteScenarioResponse
+	(*durationpb.Duration)(nil),            // 69: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),          // 70: google.protobuf.Timestamp
 }
 var file_burndevice_v1_service_proto_depIdxs = []int32{
-	0,  // 0: burndevice.v1.ExecuteDestructionRequest.type:type_name -> burndevice.v1.DestructionType
-	1,  // 1: burndevice.v1.ExecuteDestructionRequest.severity:type_name -> burndevice.v1.DestructionSeverity
-	7,  // 2: burndevice.v1.ExecuteDestructionResponse.results:type_name -> burndevice.v1.DestructionResult
-	15, // 3: burndevice.v1.ExecuteDestructionResponse.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 4: burndevice.v1.StreamDestructionRequest.type:type_name -> burndevice.v1.DestructionType
-	1,  // 5: burndevice.v1.StreamDestructionRequest.severity:type_name -> burndevice.v1.DestructionSeverity
-	15, // 6: burndevice.v1.StreamDestructionResponse.timestamp:type_name -> google.protobuf.Timestamp
-	2,  // 7: burndevice.v1.StreamDestructionResponse.type:type_name -> burndevice.v1.DestructionEventType
-	8,  // 8: burndevice.v1.DestructionResult.metrics:type_name -> burndevice.v1.DestructionMetrics
-	11, // 9: burndevice.v1.GetSystemInfoResponse.resources:type_name -> burndevice.v1.SystemResources
-	1,  // 10: burndevice.v1.GenerateAttackScenarioRequest.max_severity:type_name -> burndevice.v1.DestructionSeverity
-	14, // 11: burndevice.v1.GenerateAttackScenarioResponse.steps:type_name -> burndevice.v1.AttackStep
-	1,  // 12: burndevice.v1.GenerateAttackScenarioResponse.estimated_severity:type_name -> burndevice.v1.DestructionSeverity
-	0,  // 13: burndevice.v1.AttackStep.type:type_name -> burndevice.v1.DestructionType
-	3,  // 14: burndevice.v1.BurnDeviceService.ExecuteDestruction:input_type -> burndevice.v1.ExecuteDestructionRequest
-	9,  // 15: burndevice.v1.BurnDeviceService.GetSystemInfo:input_type -> burndevice.v1.GetSystemInfoRequest
-	12, // 16: burndevice.v1.BurnDeviceService.GenerateAttackScenario:input_type -> burndevice.v1.GenerateAttackScenarioRequest
-	5,  // 17: burndevice.v1.BurnDeviceService.StreamDestruction:input_type -> burndevice.v1.StreamDestructionRequest
-	4,  // 18: burndevice.v1.BurnDeviceService.ExecuteDestruction:output_type -> burndevice.v1.ExecuteDestructionResponse
-	10, // 19: burndevice.v1.BurnDeviceService.GetSystemInfo:output_type -> burndevice.v1.GetSystemInfoResponse
-	13, // 20: burndevice.v1.BurnDeviceService.GenerateAttackScenario:output_type -> burndevice.v1.GenerateAttackScenarioResponse
-	6,  // 21: burndevice.v1.BurnDeviceService.StreamDestruction:output_type -> burndevice.v1.StreamDestructionResponse
-	18, // [18:22] is the sub-list for method output_type
-	14, // [14:18] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	0,   // 0: burndevice.v1.ExecuteDestructionRequest.type:type_name -> burndevice.v1.DestructionType
+	1,   // 1: burndevice.v1.ExecuteDestructionRequest.severity:type_name -> burndevice.v1.DestructionSeverity
+	69,  // 2: burndevice.v1.ExecuteDestructionRequest.duration:type_name -> google.protobuf.Duration
+	69,  // 3: burndevice.v1.ExecuteDestructionRequest.auto_restore_after:type_name -> google.protobuf.Duration
+	3,   // 4: burndevice.v1.ExecuteDestructionRequest.failure_policy:type_name -> burndevice.v1.FailurePolicy
+	69,  // 5: burndevice.v1.ExecuteDestructionRequest.min_age:type_name -> google.protobuf.Duration
+	9,   // 6: burndevice.v1.ExecuteDestructionResponse.results:type_name -> burndevice.v1.DestructionResult
+	70,  // 7: burndevice.v1.ExecuteDestructionResponse.timestamp:type_name -> google.protobuf.Timestamp
+	6,   // 8: burndevice.v1.ExecuteDestructionResponse.steps:type_name -> burndevice.v1.ScenarioStepResult
+	11,  // 9: burndevice.v1.ExecuteDestructionResponse.total_metrics:type_name -> burndevice.v1.DestructionMetrics
+	70,  // 10: burndevice.v1.ExecuteDestructionResponse.started_at:type_name -> google.protobuf.Timestamp
+	70,  // 11: burndevice.v1.ExecuteDestructionResponse.completed_at:type_name -> google.protobuf.Timestamp
+	10,  // 12: burndevice.v1.ExecuteDestructionResponse.hook_results:type_name -> burndevice.v1.HookResult
+	0,   // 13: burndevice.v1.ScenarioStepResult.type:type_name -> burndevice.v1.DestructionType
+	0,   // 14: burndevice.v1.StreamDestructionRequest.type:type_name -> burndevice.v1.DestructionType
+	1,   // 15: burndevice.v1.StreamDestructionRequest.severity:type_name -> burndevice.v1.DestructionSeverity
+	69,  // 16: burndevice.v1.StreamDestructionRequest.duration:type_name -> google.protobuf.Duration
+	69,  // 17: burndevice.v1.StreamDestructionRequest.auto_restore_after:type_name -> google.protobuf.Duration
+	3,   // 18: burndevice.v1.StreamDestructionRequest.failure_policy:type_name -> burndevice.v1.FailurePolicy
+	69,  // 19: burndevice.v1.StreamDestructionRequest.min_age:type_name -> google.protobuf.Duration
+	70,  // 20: burndevice.v1.StreamDestructionResponse.timestamp:type_name -> google.protobuf.Timestamp
+	2,   // 21: burndevice.v1.StreamDestructionResponse.type:type_name -> burndevice.v1.DestructionEventType
+	9,   // 22: burndevice.v1.StreamDestructionResponse.results:type_name -> burndevice.v1.DestructionResult
+	70,  // 23: burndevice.v1.StreamDestructionResponse.started_at:type_name -> google.protobuf.Timestamp
+	70,  // 24: burndevice.v1.StreamDestructionResponse.completed_at:type_name -> google.protobuf.Timestamp
+	10,  // 25: burndevice.v1.StreamDestructionResponse.hook_results:type_name -> burndevice.v1.HookResult
+	11,  // 26: burndevice.v1.DestructionResult.metrics:type_name -> burndevice.v1.DestructionMetrics
+	10,  // 27: burndevice.v1.DestructionResult.hook_results:type_name -> burndevice.v1.HookResult
+	70,  // 28: burndevice.v1.DestructionResult.started_at:type_name -> google.protobuf.Timestamp
+	70,  // 29: burndevice.v1.DestructionResult.completed_at:type_name -> google.protobuf.Timestamp
+	14,  // 30: burndevice.v1.RestoreDestructionResponse.results:type_name -> burndevice.v1.RestoreResult
+	70,  // 31: burndevice.v1.RestoreDestructionResponse.timestamp:type_name -> google.protobuf.Timestamp
+	17,  // 32: burndevice.v1.VerifyBackupResponse.results:type_name -> burndevice.v1.VerifyBackupResult
+	69,  // 33: burndevice.v1.CleanupBackupsRequest.older_than:type_name -> google.protobuf.Duration
+	52,  // 34: burndevice.v1.GetTaskStatusResponse.task:type_name -> burndevice.v1.TaskStatus
+	52,  // 35: burndevice.v1.CancelDestructionResponse.task:type_name -> burndevice.v1.TaskStatus
+	52,  // 36: burndevice.v1.ListTasksResponse.tasks:type_name -> burndevice.v1.TaskStatus
+	28,  // 37: burndevice.v1.ExpandTargetsResponse.matches:type_name -> burndevice.v1.TargetMatch
+	31,  // 38: burndevice.v1.PlanDestructionResponse.targets:type_name -> burndevice.v1.TargetPlan
+	32,  // 39: burndevice.v1.TargetPlan.largest_files:type_name -> burndevice.v1.FileSize
+	0,   // 40: burndevice.v1.GetTaskHistoryRequest.type:type_name -> burndevice.v1.DestructionType
+	70,  // 41: burndevice.v1.GetTaskHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	70,  // 42: burndevice.v1.GetTaskHistoryRequest.until:type_name -> google.protobuf.Timestamp
+	35,  // 43: burndevice.v1.GetTaskHistoryResponse.tasks:type_name -> burndevice.v1.TaskRecord
+	0,   // 44: burndevice.v1.TaskRecord.type:type_name -> burndevice.v1.DestructionType
+	1,   // 45: burndevice.v1.TaskRecord.severity:type_name -> burndevice.v1.DestructionSeverity
+	70,  // 46: burndevice.v1.TaskRecord.started_at:type_name -> google.protobuf.Timestamp
+	70,  // 47: burndevice.v1.TaskRecord.finished_at:type_name -> google.protobuf.Timestamp
+	9,   // 48: burndevice.v1.TaskRecord.results:type_name -> burndevice.v1.DestructionResult
+	70,  // 49: burndevice.v1.TaskRecord.auto_restore_at:type_name -> google.protobuf.Timestamp
+	10,  // 50: burndevice.v1.TaskRecord.hook_results:type_name -> burndevice.v1.HookResult
+	0,   // 51: burndevice.v1.AutoRestore.type:type_name -> burndevice.v1.DestructionType
+	70,  // 52: burndevice.v1.AutoRestore.restore_at:type_name -> google.protobuf.Timestamp
+	4,   // 53: burndevice.v1.ScheduleDestructionRequest.request:type_name -> burndevice.v1.ExecuteDestructionRequest
+	69,  // 54: burndevice.v1.ScheduleDestructionRequest.delay:type_name -> google.protobuf.Duration
+	44,  // 55: burndevice.v1.ScheduleDestructionResponse.schedule:type_name -> burndevice.v1.Schedule
+	44,  // 56: burndevice.v1.ListSchedulesResponse.schedules:type_name -> burndevice.v1.Schedule
+	4,   // 57: burndevice.v1.Schedule.request:type_name -> burndevice.v1.ExecuteDestructionRequest
+	70,  // 58: burndevice.v1.Schedule.next_run:type_name -> google.protobuf.Timestamp
+	70,  // 59: burndevice.v1.Schedule.created_at:type_name -> google.protobuf.Timestamp
+	70,  // 60: burndevice.v1.Schedule.last_run:type_name -> google.protobuf.Timestamp
+	49,  // 61: burndevice.v1.CheckCapabilitiesResponse.capabilities:type_name -> burndevice.v1.Capability
+	0,   // 62: burndevice.v1.Capability.type:type_name -> burndevice.v1.DestructionType
+	70,  // 63: burndevice.v1.GetMetricsResponse.timestamp:type_name -> google.protobuf.Timestamp
+	70,  // 64: burndevice.v1.GetMetricsResponse.started_at:type_name -> google.protobuf.Timestamp
+	0,   // 65: burndevice.v1.TaskStatus.type:type_name -> burndevice.v1.DestructionType
+	1,   // 66: burndevice.v1.TaskStatus.severity:type_name -> burndevice.v1.DestructionSeverity
+	70,  // 67: burndevice.v1.TaskStatus.started_at:type_name -> google.protobuf.Timestamp
+	9,   // 68: burndevice.v1.TaskStatus.results:type_name -> burndevice.v1.DestructionResult
+	56,  // 69: burndevice.v1.GetSystemInfoResponse.resources:type_name -> burndevice.v1.SystemResources
+	55,  // 70: burndevice.v1.GetSystemInfoResponse.path_disks:type_name -> burndevice.v1.PathDiskUsage
+	1,   // 71: burndevice.v1.GenerateAttackScenarioRequest.max_severity:type_name -> burndevice.v1.DestructionSeverity
+	0,   // 72: burndevice.v1.GenerateAttackScenarioRequest.allowed_types:type_name -> burndevice.v1.DestructionType
+	59,  // 73: burndevice.v1.GenerateAttackScenarioResponse.steps:type_name -> burndevice.v1.AttackStep
+	1,   // 74: burndevice.v1.GenerateAttackScenarioResponse.estimated_severity:type_name -> burndevice.v1.DestructionSeverity
+	70,  // 75: burndevice.v1.GenerateAttackScenarioResponse.created_at:type_name -> google.protobuf.Timestamp
+	0,   // 76: burndevice.v1.AttackStep.type:type_name -> burndevice.v1.DestructionType
+	58,  // 77: burndevice.v1.SaveScenarioRequest.scenario:type_name -> burndevice.v1.GenerateAttackScenarioResponse
+	58,  // 78: burndevice.v1.GetScenarioResponse.scenario:type_name -> burndevice.v1.GenerateAttackScenarioResponse
+	66,  // 79: burndevice.v1.ListScenariosResponse.scenarios:type_name -> burndevice.v1.ScenarioSummary
+	1,   // 80: burndevice.v1.ScenarioSummary.estimated_severity:type_name -> burndevice.v1.DestructionSeverity
+	70,  // 81: burndevice.v1.ScenarioSummary.created_at:type_name -> google.protobuf.Timestamp
+	4,   // 82: burndevice.v1.BurnDeviceService.ExecuteDestruction:input_type -> burndevice.v1.ExecuteDestructionRequest
+	53,  // 83: burndevice.v1.BurnDeviceService.GetSystemInfo:input_type -> burndevice.v1.GetSystemInfoRequest
+	57,  // 84: burndevice.v1.BurnDeviceService.GenerateAttackScenario:input_type -> burndevice.v1.GenerateAttackScenarioRequest
+	7,   // 85: burndevice.v1.BurnDeviceService.StreamDestruction:input_type -> burndevice.v1.StreamDestructionRequest
+	12,  // 86: burndevice.v1.BurnDeviceService.RestoreDestruction:input_type -> burndevice.v1.RestoreDestructionRequest
+	15,  // 87: burndevice.v1.BurnDeviceService.VerifyBackup:input_type -> burndevice.v1.VerifyBackupRequest
+	18,  // 88: burndevice.v1.BurnDeviceService.CleanupBackups:input_type -> burndevice.v1.CleanupBackupsRequest
+	20,  // 89: burndevice.v1.BurnDeviceService.GetTaskStatus:input_type -> burndevice.v1.GetTaskStatusRequest
+	22,  // 90: burndevice.v1.BurnDeviceService.CancelDestruction:input_type -> burndevice.v1.CancelDestructionRequest
+	24,  // 91: burndevice.v1.BurnDeviceService.ListTasks:input_type -> burndevice.v1.ListTasksRequest
+	26,  // 92: burndevice.v1.BurnDeviceService.ExpandTargets:input_type -> burndevice.v1.ExpandTargetsRequest
+	29,  // 93: burndevice.v1.BurnDeviceService.PlanDestruction:input_type -> burndevice.v1.PlanDestructionRequest
+	33,  // 94: burndevice.v1.BurnDeviceService.GetTaskHistory:input_type -> burndevice.v1.GetTaskHistoryRequest
+	37,  // 95: burndevice.v1.BurnDeviceService.SubscribeEvents:input_type -> burndevice.v1.SubscribeEventsRequest
+	38,  // 96: burndevice.v1.BurnDeviceService.ScheduleDestruction:input_type -> burndevice.v1.ScheduleDestructionRequest
+	40,  // 97: burndevice.v1.BurnDeviceService.ListSchedules:input_type -> burndevice.v1.ListSchedulesRequest
+	42,  // 98: burndevice.v1.BurnDeviceService.DeleteSchedule:input_type -> burndevice.v1.DeleteScheduleRequest
+	45,  // 99: burndevice.v1.BurnDeviceService.GetServerInfo:input_type -> burndevice.v1.GetServerInfoRequest
+	50,  // 100: burndevice.v1.BurnDeviceService.GetMetrics:input_type -> burndevice.v1.GetMetricsRequest
+	47,  // 101: burndevice.v1.BurnDeviceService.CheckCapabilities:input_type -> burndevice.v1.CheckCapabilitiesRequest
+	60,  // 102: burndevice.v1.BurnDeviceService.SaveScenario:input_type -> burndevice.v1.SaveScenarioRequest
+	62,  // 103: burndevice.v1.BurnDeviceService.GetScenario:input_type -> burndevice.v1.GetScenarioRequest
+	64,  // 104: burndevice.v1.BurnDeviceService.ListScenarios:input_type -> burndevice.v1.ListScenariosRequest
+	67,  // 105: burndevice.v1.BurnDeviceService.DeleteScenario:input_type -> burndevice.v1.DeleteScenarioRequest
+	5,   // 106: burndevice.v1.BurnDeviceService.ExecuteDestruction:output_type -> burndevice.v1.ExecuteDestructionResponse
+	54,  // 107: burndevice.v1.BurnDeviceService.GetSystemInfo:output_type -> burndevice.v1.GetSystemInfoResponse
+	58,  // 108: burndevice.v1.BurnDeviceService.GenerateAttackScenario:output_type -> burndevice.v1.GenerateAttackScenarioResponse
+	8,   // 109: burndevice.v1.BurnDeviceService.StreamDestruction:output_type -> burndevice.v1.StreamDestructionResponse
+	13,  // 110: burndevice.v1.BurnDeviceService.RestoreDestruction:output_type -> burndevice.v1.RestoreDestructionResponse
+	16,  // 111: burndevice.v1.BurnDeviceService.VerifyBackup:output_type -> burndevice.v1.VerifyBackupResponse
+	19,  // 112: burndevice.v1.BurnDeviceService.CleanupBackups:output_type -> burndevice.v1.CleanupBackupsResponse
+	21,  // 113: burndevice.v1.BurnDeviceService.GetTaskStatus:output_type -> burndevice.v1.GetTaskStatusResponse
+	23,  // 114: burndevice.v1.BurnDeviceService.CancelDestruction:output_type -> burndevice.v1.CancelDestructionResponse
+	25,  // 115: burndevice.v1.BurnDeviceService.ListTasks:output_type -> burndevice.v1.ListTasksResponse
+	27,  // 116: burndevice.v1.BurnDeviceService.ExpandTargets:output_type -> burndevice.v1.ExpandTargetsResponse
+	30,  // 117: burndevice.v1.BurnDeviceService.PlanDestruction:output_type -> burndevice.v1.PlanDestructionResponse
+	34,  // 118: burndevice.v1.BurnDeviceService.GetTaskHistory:output_type -> burndevice.v1.GetTaskHistoryResponse
+	8,   // 119: burndevice.v1.BurnDeviceService.SubscribeEvents:output_type -> burndevice.v1.StreamDestructionResponse
+	39,  // 120: burndevice.v1.BurnDeviceService.ScheduleDestruction:output_type -> burndevice.v1.ScheduleDestructionResponse
+	41,  // 121: burndevice.v1.BurnDeviceService.ListSchedules:output_type -> burndevice.v1.ListSchedulesResponse
+	43,  // 122: burndevice.v1.BurnDeviceService.DeleteSchedule:output_type -> burndevice.v1.DeleteScheduleResponse
+	46,  // 123: burndevice.v1.BurnDeviceService.GetServerInfo:output_type -> burndevice.v1.GetServerInfoResponse
+	51,  // 124: burndevice.v1.BurnDeviceService.GetMetrics:output_type -> burndevice.v1.GetMetricsResponse
+	48,  // 125: burndevice.v1.BurnDeviceService.CheckCapabilities:output_type -> burndevice.v1.CheckCapabilitiesResponse
+	61,  // 126: burndevice.v1.BurnDeviceService.SaveScenario:output_type -> burndevice.v1.SaveScenarioResponse
+	63,  // 127: burndevice.v1.BurnDeviceService.GetScenario:output_type -> burndevice.v1.GetScenarioResponse
+	65,  // 128: burndevice.v1.BurnDeviceService.ListScenarios:output_type -> burndevice.v1.ListScenariosResponse
+	68,  // 129: burndevice.v1.BurnDeviceService.DeleteScenario:output_type -> burndevice.v1.DeleteScenarioResponse
+	106, // [106:130] is the sub-list for method output_type
+	82,  // [82:106] is the sub-list for method input_type
+	82,  // [82:82] is the sub-list for extension type_name
+	82,  // [82:82] is the sub-list for extension extendee
+	0,   // [0:82] is the sub-list for field type_name
 }
 
 func init() { file_burndevice_v1_service_proto_init() }
@@ -1196,8 +5935,8 @@ func file_burndevice_v1_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_burndevice_v1_service_proto_rawDesc), len(file_burndevice_v1_service_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   12,
+			NumEnums:      4,
+			NumMessages:   65,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
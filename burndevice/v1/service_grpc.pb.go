@@ -23,6 +23,26 @@ const (
 	BurnDeviceService_GetSystemInfo_FullMethodName          = "/burndevice.v1.BurnDeviceService/GetSystemInfo"
 	BurnDeviceService_GenerateAttackScenario_FullMethodName = "/burndevice.v1.BurnDeviceService/GenerateAttackScenario"
 	BurnDeviceService_StreamDestruction_FullMethodName      = "/burndevice.v1.BurnDeviceService/StreamDestruction"
+	BurnDeviceService_RestoreDestruction_FullMethodName     = "/burndevice.v1.BurnDeviceService/RestoreDestruction"
+	BurnDeviceService_VerifyBackup_FullMethodName           = "/burndevice.v1.BurnDeviceService/VerifyBackup"
+	BurnDeviceService_CleanupBackups_FullMethodName         = "/burndevice.v1.BurnDeviceService/CleanupBackups"
+	BurnDeviceService_GetTaskStatus_FullMethodName          = "/burndevice.v1.BurnDeviceService/GetTaskStatus"
+	BurnDeviceService_CancelDestruction_FullMethodName      = "/burndevice.v1.BurnDeviceService/CancelDestruction"
+	BurnDeviceService_ListTasks_FullMethodName              = "/burndevice.v1.BurnDeviceService/ListTasks"
+	BurnDeviceService_ExpandTargets_FullMethodName          = "/burndevice.v1.BurnDeviceService/ExpandTargets"
+	BurnDeviceService_PlanDestruction_FullMethodName        = "/burndevice.v1.BurnDeviceService/PlanDestruction"
+	BurnDeviceService_GetTaskHistory_FullMethodName         = "/burndevice.v1.BurnDeviceService/GetTaskHistory"
+	BurnDeviceService_SubscribeEvents_FullMethodName        = "/burndevice.v1.BurnDeviceService/SubscribeEvents"
+	BurnDeviceService_ScheduleDestruction_FullMethodName    = "/burndevice.v1.BurnDeviceService/ScheduleDestruction"
+	BurnDeviceService_ListSchedules_FullMethodName          = "/burndevice.v1.BurnDeviceService/ListSchedules"
+	BurnDeviceService_DeleteSchedule_FullMethodName         = "/burndevice.v1.BurnDeviceService/DeleteSchedule"
+	BurnDeviceService_GetServerInfo_FullMethodName          = "/burndevice.v1.BurnDeviceService/GetServerInfo"
+	BurnDeviceService_GetMetrics_FullMethodName             = "/burndevice.v1.BurnDeviceService/GetMetrics"
+	BurnDeviceService_CheckCapabilities_FullMethodName      = "/burndevice.v1.BurnDeviceService/CheckCapabilities"
+	BurnDeviceService_SaveScenario_FullMethodName           = "/burndevice.v1.BurnDeviceService/SaveScenario"
+	BurnDeviceService_GetScenario_FullMethodName            = "/burndevice.v1.BurnDeviceService/GetScenario"
+	BurnDeviceService_ListScenarios_FullMethodName          = "/burndevice.v1.BurnDeviceService/ListScenarios"
+	BurnDeviceService_DeleteScenario_FullMethodName         = "/burndevice.v1.BurnDeviceService/DeleteScenario"
 )
 
 // BurnDeviceServiceClient is the client API for BurnDeviceService service.
@@ -39,6 +59,47 @@ type BurnDeviceServiceClient interface {
 	GenerateAttackScenario(ctx context.Context, in *GenerateAttackScenarioRequest, opts ...grpc.CallOption) (*GenerateAttackScenarioResponse, error)
 	// Stream destruction progress
 	StreamDestruction(ctx context.Context, in *StreamDestructionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamDestructionResponse], error)
+	// Restore destroyed targets from their backups
+	RestoreDestruction(ctx context.Context, in *RestoreDestructionRequest, opts ...grpc.CallOption) (*RestoreDestructionResponse, error)
+	// Check backups against the checksums taken when they were made
+	VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error)
+	// Remove old backups left next to targets
+	CleanupBackups(ctx context.Context, in *CleanupBackupsRequest, opts ...grpc.CallOption) (*CleanupBackupsResponse, error)
+	// Get the status of a running task
+	GetTaskStatus(ctx context.Context, in *GetTaskStatusRequest, opts ...grpc.CallOption) (*GetTaskStatusResponse, error)
+	// Abort a running destruction task
+	CancelDestruction(ctx context.Context, in *CancelDestructionRequest, opts ...grpc.CallOption) (*CancelDestructionResponse, error)
+	// List running tasks
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	// Preview which paths a target pattern resolves to, without changing anything
+	ExpandTargets(ctx context.Context, in *ExpandTargetsRequest, opts ...grpc.CallOption) (*ExpandTargetsResponse, error)
+	// Estimate how many files and bytes destroying targets would touch, and
+	// whether each passes policy, without changing anything
+	PlanDestruction(ctx context.Context, in *PlanDestructionRequest, opts ...grpc.CallOption) (*PlanDestructionResponse, error)
+	// List finished tasks, newest first
+	GetTaskHistory(ctx context.Context, in *GetTaskHistoryRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error)
+	// Watch the lifecycle events of every task, including unary executions
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamDestructionResponse], error)
+	// Run a destruction request later, after a delay or on a cron schedule
+	ScheduleDestruction(ctx context.Context, in *ScheduleDestructionRequest, opts ...grpc.CallOption) (*ScheduleDestructionResponse, error)
+	// List pending schedules, soonest first
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	// Remove a pending schedule
+	DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error)
+	// Describe the server process, including the account it runs as
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	// Snapshot the counters accumulated since the server started
+	GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error)
+	// Report which destruction types this server can carry out on its host
+	CheckCapabilities(ctx context.Context, in *CheckCapabilitiesRequest, opts ...grpc.CallOption) (*CheckCapabilitiesResponse, error)
+	// Save an attack scenario, replacing any stored under the same ID
+	SaveScenario(ctx context.Context, in *SaveScenarioRequest, opts ...grpc.CallOption) (*SaveScenarioResponse, error)
+	// Get a stored attack scenario
+	GetScenario(ctx context.Context, in *GetScenarioRequest, opts ...grpc.CallOption) (*GetScenarioResponse, error)
+	// List stored attack scenarios
+	ListScenarios(ctx context.Context, in *ListScenariosRequest, opts ...grpc.CallOption) (*ListScenariosResponse, error)
+	// Delete a stored attack scenario
+	DeleteScenario(ctx context.Context, in *DeleteScenarioRequest, opts ...grpc.CallOption) (*DeleteScenarioResponse, error)
 }
 
 type burnDeviceServiceClient struct {
@@ -98,6 +159,215 @@ func (c *burnDeviceServiceClient) StreamDestruction(ctx context.Context, in *Str
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type BurnDeviceService_StreamDestructionClient = grpc.ServerStreamingClient[StreamDestructionResponse]
 
+func (c *burnDeviceServiceClient) RestoreDestruction(ctx context.Context, in *RestoreDestructionRequest, opts ...grpc.CallOption) (*RestoreDestructionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreDestructionResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_RestoreDestruction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyBackupResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_VerifyBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) CleanupBackups(ctx context.Context, in *CleanupBackupsRequest, opts ...grpc.CallOption) (*CleanupBackupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupBackupsResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_CleanupBackups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) GetTaskStatus(ctx context.Context, in *GetTaskStatusRequest, opts ...grpc.CallOption) (*GetTaskStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskStatusResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_GetTaskStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) CancelDestruction(ctx context.Context, in *CancelDestructionRequest, opts ...grpc.CallOption) (*CancelDestructionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelDestructionResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_CancelDestruction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) ExpandTargets(ctx context.Context, in *ExpandTargetsRequest, opts ...grpc.CallOption) (*ExpandTargetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpandTargetsResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_ExpandTargets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) PlanDestruction(ctx context.Context, in *PlanDestructionRequest, opts ...grpc.CallOption) (*PlanDestructionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanDestructionResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_PlanDestruction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) GetTaskHistory(ctx context.Context, in *GetTaskHistoryRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskHistoryResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_GetTaskHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamDestructionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BurnDeviceService_ServiceDesc.Streams[1], BurnDeviceService_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsRequest, StreamDestructionResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BurnDeviceService_SubscribeEventsClient = grpc.ServerStreamingClient[StreamDestructionResponse]
+
+func (c *burnDeviceServiceClient) ScheduleDestruction(ctx context.Context, in *ScheduleDestructionRequest, opts ...grpc.CallOption) (*ScheduleDestructionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleDestructionResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_ScheduleDestruction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_ListSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteScheduleResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_DeleteSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServerInfoResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) GetMetrics(ctx context.Context, in *GetMetricsRequest, opts ...grpc.CallOption) (*GetMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMetricsResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_GetMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) CheckCapabilities(ctx context.Context, in *CheckCapabilitiesRequest, opts ...grpc.CallOption) (*CheckCapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_CheckCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) SaveScenario(ctx context.Context, in *SaveScenarioRequest, opts ...grpc.CallOption) (*SaveScenarioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveScenarioResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_SaveScenario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) GetScenario(ctx context.Context, in *GetScenarioRequest, opts ...grpc.CallOption) (*GetScenarioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScenarioResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_GetScenario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) ListScenarios(ctx context.Context, in *ListScenariosRequest, opts ...grpc.CallOption) (*ListScenariosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScenariosResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_ListScenarios_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *burnDeviceServiceClient) DeleteScenario(ctx context.Context, in *DeleteScenarioRequest, opts ...grpc.CallOption) (*DeleteScenarioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteScenarioResponse)
+	err := c.cc.Invoke(ctx, BurnDeviceService_DeleteScenario_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BurnDeviceServiceServer is the server API for BurnDeviceService service.
 // All implementations must embed UnimplementedBurnDeviceServiceServer
 // for forward compatibility.
@@ -112,6 +382,47 @@ type BurnDeviceServiceServer interface {
 	GenerateAttackScenario(context.Context, *GenerateAttackScenarioRequest) (*GenerateAttackScenarioResponse, error)
 	// Stream destruction progress
 	StreamDestruction(*StreamDestructionRequest, grpc.ServerStreamingServer[StreamDestructionResponse]) error
+	// Restore destroyed targets from their backups
+	RestoreDestruction(context.Context, *RestoreDestructionRequest) (*RestoreDestructionResponse, error)
+	// Check backups against the checksums taken when they were made
+	VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error)
+	// Remove old backups left next to targets
+	CleanupBackups(context.Context, *CleanupBackupsRequest) (*CleanupBackupsResponse, error)
+	// Get the status of a running task
+	GetTaskStatus(context.Context, *GetTaskStatusRequest) (*GetTaskStatusResponse, error)
+	// Abort a running destruction task
+	CancelDestruction(context.Context, *CancelDestructionRequest) (*CancelDestructionResponse, error)
+	// List running tasks
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	// Preview which paths a target pattern resolves to, without changing anything
+	ExpandTargets(context.Context, *ExpandTargetsRequest) (*ExpandTargetsResponse, error)
+	// Estimate how many files and bytes destroying targets would touch, and
+	// whether each passes policy, without changing anything
+	PlanDestruction(context.Context, *PlanDestructionRequest) (*PlanDestructionResponse, error)
+	// List finished tasks, newest first
+	GetTaskHistory(context.Context, *GetTaskHistoryRequest) (*GetTaskHistoryResponse, error)
+	// Watch the lifecycle events of every task, including unary executions
+	SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[StreamDestructionResponse]) error
+	// Run a destruction request later, after a delay or on a cron schedule
+	ScheduleDestruction(context.Context, *ScheduleDestructionRequest) (*ScheduleDestructionResponse, error)
+	// List pending schedules, soonest first
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	// Remove a pending schedule
+	DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error)
+	// Describe the server process, including the account it runs as
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	// Snapshot the counters accumulated since the server started
+	GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error)
+	// Report which destruction types this server can carry out on its host
+	CheckCapabilities(context.Context, *CheckCapabilitiesRequest) (*CheckCapabilitiesResponse, error)
+	// Save an attack scenario, replacing any stored under the same ID
+	SaveScenario(context.Context, *SaveScenarioRequest) (*SaveScenarioResponse, error)
+	// Get a stored attack scenario
+	GetScenario(context.Context, *GetScenarioRequest) (*GetScenarioResponse, error)
+	// List stored attack scenarios
+	ListScenarios(context.Context, *ListScenariosRequest) (*ListScenariosResponse, error)
+	// Delete a stored attack scenario
+	DeleteScenario(context.Context, *DeleteScenarioRequest) (*DeleteScenarioResponse, error)
 	mustEmbedUnimplementedBurnDeviceServiceServer()
 }
 
@@ -123,16 +434,76 @@ type BurnDeviceServiceServer interface {
 type UnimplementedBurnDeviceServiceServer struct{}
 
 func (UnimplementedBurnDeviceServiceServer) ExecuteDestruction(context.Context, *ExecuteDestructionRequest) (*ExecuteDestructionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecuteDestruction not implemented")
+	return nil, status.Error(codes.Unimplemented, "method ExecuteDestruction not implemented")
 }
 func (UnimplementedBurnDeviceServiceServer) GetSystemInfo(context.Context, *GetSystemInfoRequest) (*GetSystemInfoResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSystemInfo not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GetSystemInfo not implemented")
 }
 func (UnimplementedBurnDeviceServiceServer) GenerateAttackScenario(context.Context, *GenerateAttackScenarioRequest) (*GenerateAttackScenarioResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GenerateAttackScenario not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GenerateAttackScenario not implemented")
 }
 func (UnimplementedBurnDeviceServiceServer) StreamDestruction(*StreamDestructionRequest, grpc.ServerStreamingServer[StreamDestructionResponse]) error {
-	return status.Errorf(codes.Unimplemented, "method StreamDestruction not implemented")
+	return status.Error(codes.Unimplemented, "method StreamDestruction not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) RestoreDestruction(context.Context, *RestoreDestructionRequest) (*RestoreDestructionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreDestruction not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyBackup not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) CleanupBackups(context.Context, *CleanupBackupsRequest) (*CleanupBackupsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CleanupBackups not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) GetTaskStatus(context.Context, *GetTaskStatusRequest) (*GetTaskStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskStatus not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) CancelDestruction(context.Context, *CancelDestructionRequest) (*CancelDestructionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelDestruction not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) ExpandTargets(context.Context, *ExpandTargetsRequest) (*ExpandTargetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExpandTargets not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) PlanDestruction(context.Context, *PlanDestructionRequest) (*PlanDestructionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlanDestruction not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) GetTaskHistory(context.Context, *GetTaskHistoryRequest) (*GetTaskHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTaskHistory not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[StreamDestructionResponse]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) ScheduleDestruction(context.Context, *ScheduleDestructionRequest) (*ScheduleDestructionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ScheduleDestruction not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSchedule not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) GetMetrics(context.Context, *GetMetricsRequest) (*GetMetricsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMetrics not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) CheckCapabilities(context.Context, *CheckCapabilitiesRequest) (*CheckCapabilitiesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckCapabilities not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) SaveScenario(context.Context, *SaveScenarioRequest) (*SaveScenarioResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveScenario not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) GetScenario(context.Context, *GetScenarioRequest) (*GetScenarioResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetScenario not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) ListScenarios(context.Context, *ListScenariosRequest) (*ListScenariosResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScenarios not implemented")
+}
+func (UnimplementedBurnDeviceServiceServer) DeleteScenario(context.Context, *DeleteScenarioRequest) (*DeleteScenarioResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteScenario not implemented")
 }
 func (UnimplementedBurnDeviceServiceServer) mustEmbedUnimplementedBurnDeviceServiceServer() {}
 func (UnimplementedBurnDeviceServiceServer) testEmbeddedByValue()                           {}
@@ -145,7 +516,7 @@ type UnsafeBurnDeviceServiceServer interface {
 }
 
 func RegisterBurnDeviceServiceServer(s grpc.ServiceRegistrar, srv BurnDeviceServiceServer) {
-	// If the following call pancis, it indicates UnimplementedBurnDeviceServiceServer was
+	// If the following call panics, it indicates UnimplementedBurnDeviceServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
@@ -220,6 +591,359 @@ func _BurnDeviceService_StreamDestruction_Handler(srv interface{}, stream grpc.S
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type BurnDeviceService_StreamDestructionServer = grpc.ServerStreamingServer[StreamDestructionResponse]
 
+func _BurnDeviceService_RestoreDestruction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDestructionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).RestoreDestruction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_RestoreDestruction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).RestoreDestruction(ctx, req.(*RestoreDestructionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_VerifyBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).VerifyBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_VerifyBackup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).VerifyBackup(ctx, req.(*VerifyBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_CleanupBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).CleanupBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_CleanupBackups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).CleanupBackups(ctx, req.(*CleanupBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_GetTaskStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).GetTaskStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_GetTaskStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).GetTaskStatus(ctx, req.(*GetTaskStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_CancelDestruction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelDestructionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).CancelDestruction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_CancelDestruction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).CancelDestruction(ctx, req.(*CancelDestructionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_ExpandTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpandTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).ExpandTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_ExpandTargets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).ExpandTargets(ctx, req.(*ExpandTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_PlanDestruction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanDestructionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).PlanDestruction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_PlanDestruction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).PlanDestruction(ctx, req.(*PlanDestructionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_GetTaskHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).GetTaskHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_GetTaskHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).GetTaskHistory(ctx, req.(*GetTaskHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BurnDeviceServiceServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsRequest, StreamDestructionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BurnDeviceService_SubscribeEventsServer = grpc.ServerStreamingServer[StreamDestructionResponse]
+
+func _BurnDeviceService_ScheduleDestruction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleDestructionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).ScheduleDestruction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_ScheduleDestruction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).ScheduleDestruction(ctx, req.(*ScheduleDestructionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_DeleteSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).DeleteSchedule(ctx, req.(*DeleteScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_GetServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_GetMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).GetMetrics(ctx, req.(*GetMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_CheckCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).CheckCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_CheckCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).CheckCapabilities(ctx, req.(*CheckCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_SaveScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).SaveScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_SaveScenario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).SaveScenario(ctx, req.(*SaveScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_GetScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).GetScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_GetScenario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).GetScenario(ctx, req.(*GetScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_ListScenarios_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScenariosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).ListScenarios(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_ListScenarios_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).ListScenarios(ctx, req.(*ListScenariosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BurnDeviceService_DeleteScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BurnDeviceServiceServer).DeleteScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BurnDeviceService_DeleteScenario_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BurnDeviceServiceServer).DeleteScenario(ctx, req.(*DeleteScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BurnDeviceService_ServiceDesc is the grpc.ServiceDesc for BurnDeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +963,82 @@ var BurnDeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateAttackScenario",
 			Handler:    _BurnDeviceService_GenerateAttackScenario_Handler,
 		},
+		{
+			MethodName: "RestoreDestruction",
+			Handler:    _BurnDeviceService_RestoreDestruction_Handler,
+		},
+		{
+			MethodName: "VerifyBackup",
+			Handler:    _BurnDeviceService_VerifyBackup_Handler,
+		},
+		{
+			MethodName: "CleanupBackups",
+			Handler:    _BurnDeviceService_CleanupBackups_Handler,
+		},
+		{
+			MethodName: "GetTaskStatus",
+			Handler:    _BurnDeviceService_GetTaskStatus_Handler,
+		},
+		{
+			MethodName: "CancelDestruction",
+			Handler:    _BurnDeviceService_CancelDestruction_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _BurnDeviceService_ListTasks_Handler,
+		},
+		{
+			MethodName: "ExpandTargets",
+			Handler:    _BurnDeviceService_ExpandTargets_Handler,
+		},
+		{
+			MethodName: "PlanDestruction",
+			Handler:    _BurnDeviceService_PlanDestruction_Handler,
+		},
+		{
+			MethodName: "GetTaskHistory",
+			Handler:    _BurnDeviceService_GetTaskHistory_Handler,
+		},
+		{
+			MethodName: "ScheduleDestruction",
+			Handler:    _BurnDeviceService_ScheduleDestruction_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _BurnDeviceService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _BurnDeviceService_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _BurnDeviceService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "GetMetrics",
+			Handler:    _BurnDeviceService_GetMetrics_Handler,
+		},
+		{
+			MethodName: "CheckCapabilities",
+			Handler:    _BurnDeviceService_CheckCapabilities_Handler,
+		},
+		{
+			MethodName: "SaveScenario",
+			Handler:    _BurnDeviceService_SaveScenario_Handler,
+		},
+		{
+			MethodName: "GetScenario",
+			Handler:    _BurnDeviceService_GetScenario_Handler,
+		},
+		{
+			MethodName: "ListScenarios",
+			Handler:    _BurnDeviceService_ListScenarios_Handler,
+		},
+		{
+			MethodName: "DeleteScenario",
+			Handler:    _BurnDeviceService_DeleteScenario_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -246,6 +1046,11 @@ var BurnDeviceService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _BurnDeviceService_StreamDestruction_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _BurnDeviceService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "burndevice/v1/service.proto",
 }
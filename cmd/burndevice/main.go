@@ -93,6 +93,10 @@ func newServerCmd() *cobra.Command {
 				cancel()
 			}()
 
+			// SIGUSR1 previews pending config changes without applying
+			// them; SIGHUP applies the security policy changes
+			watchConfigSignals(ctx, configFile, cfg, srv)
+
 			// Start server
 			if err := srv.Start(ctx); err != nil {
 				return fmt.Errorf("server failed: %w", err)
@@ -107,6 +111,52 @@ func newServerCmd() *cobra.Command {
 	return cmd
 }
 
+// previewConfigChanges loads the on-disk configuration and returns how it
+// differs from the running one, without applying anything
+func previewConfigChanges(configFile string, current *config.Config) ([]config.Change, error) {
+	pending, err := config.Load(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return config.Diff(current, pending), nil
+}
+
+// logConfigPreview logs the pending configuration changes
+func logConfigPreview(configFile string, current *config.Config) {
+	changes, err := previewConfigChanges(configFile, current)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to preview configuration changes")
+		return
+	}
+
+	if len(changes) == 0 {
+		logrus.WithField("config", configFile).Info("No pending configuration changes")
+		return
+	}
+
+	for _, change := range changes {
+		logrus.WithField("change", change.String()).Info("Pending configuration change (not applied)")
+	}
+	logrus.WithFields(logrus.Fields{
+		"config":  configFile,
+		"changes": len(changes),
+	}).Info("Configuration preview completed")
+}
+
+// reloadConfig loads the on-disk configuration and applies what it can to
+// the running srv, returning the configuration now in effect. A config
+// that fails to load or validate leaves everything as it was.
+func reloadConfig(configFile string, current *config.Config, srv *server.Server) *config.Config {
+	pending, err := config.Load(configFile)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to reload configuration; keeping the current one")
+		return current
+	}
+
+	return srv.Reload(pending)
+}
+
 func newClientCmd() *cobra.Command {
 	return cli.NewClientCommand()
 }
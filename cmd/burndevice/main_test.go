@@ -3,11 +3,14 @@ package main
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
 )
 
 func TestMain(m *testing.M) {
@@ -312,3 +315,54 @@ func TestGlobalVariables(t *testing.T) {
 		t.Error("Expected date variable to be initialized")
 	}
 }
+
+func TestPreviewConfigChanges(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_config_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	configFile := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(configFile, []byte("security:\n  max_severity: MEDIUM\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	current, err := config.Load(configFile)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Unchanged file has nothing pending
+	changes, err := previewConfigChanges(configFile, current)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no pending changes, got %v", changes)
+	}
+
+	if err := os.WriteFile(configFile, []byte("security:\n  max_severity: HIGH\n"), 0644); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+
+	changes, err = previewConfigChanges(configFile, current)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Field != "security.max_severity" {
+		t.Fatalf("Expected a single max_severity change, got %v", changes)
+	}
+	if changes[0].New != "HIGH" {
+		t.Errorf("Expected pending value HIGH, got %v", changes[0].New)
+	}
+
+	// The preview never applies the change
+	if current.Security.MaxSeverity != "MEDIUM" {
+		t.Errorf("Expected running config to be unchanged, got %s", current.Security.MaxSeverity)
+	}
+}
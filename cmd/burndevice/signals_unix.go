@@ -0,0 +1,36 @@
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/server"
+)
+
+// watchConfigSignals logs a preview of pending config changes whenever the
+// process receives SIGUSR1, and applies them to srv on SIGHUP
+func watchConfigSignals(ctx context.Context, configFile string, current *config.Config, srv *server.Server) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGUSR1, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sigChan)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-sigChan:
+				if sig == syscall.SIGHUP {
+					current = reloadConfig(configFile, current, srv)
+					continue
+				}
+				logConfigPreview(configFile, current)
+			}
+		}
+	}()
+}
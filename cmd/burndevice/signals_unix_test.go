@@ -0,0 +1,105 @@
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/server"
+)
+
+func TestReloadOnSIGHUP(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_reload_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	configFile := filepath.Join(tempDir, "config.yaml")
+	writeConfig := func(port int, blocked ...string) {
+		t.Helper()
+		content := fmt.Sprintf("server:\n  port: %d\nstorage:\n  data_dir: %q\nsecurity:\n  blocked_targets: [%q", port, tempDir, "/etc")
+		for _, target := range blocked {
+			content += fmt.Sprintf(", %q", target)
+		}
+		content += "]\n"
+		if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+	writeConfig(8080)
+
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	srv, err := server.New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchConfigSignals(ctx, configFile, cfg, srv)
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		DryRun:             true,
+	}
+	if resp, err := srv.ExecuteDestruction(context.Background(), req); err != nil || !resp.Success {
+		t.Fatalf("Expected the target to be allowed before the reload, got %v, %v", resp, err)
+	}
+
+	// Block the target, and move the server to a port it can't switch to
+	writeConfig(9090, target)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("Failed to send SIGHUP: %v", err)
+	}
+
+	// Dry runs are checked against the same rules, so poll with them until
+	// the reload lands
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := srv.ExecuteDestruction(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !resp.Success {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the reload to block the target")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	req.DryRun = false
+	resp, err := srv.ExecuteDestruction(context.Background(), req)
+	if err != nil || resp.Success || !strings.Contains(resp.Message, "target is blocked") {
+		t.Errorf("Expected the newly blocked target to be rejected, got %v, %v", resp, err)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("Expected the blocked target to remain, got: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/server"
+)
+
+// watchConfigSignals is a no-op on Windows, which has no SIGUSR1 or SIGHUP
+func watchConfigSignals(ctx context.Context, configFile string, current *config.Config, srv *server.Server) {
+}
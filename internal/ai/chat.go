@@ -0,0 +1,400 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+	"github.com/sirupsen/logrus"
+)
+
+// chatClient holds everything chat completion providers share: prompts,
+// the HTTP transport and parsing of the JSON scenario contract
+type chatClient struct {
+	config     *config.AIConfig
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+// Message represents a chat message
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Choice represents a response choice
+type Choice struct {
+	Index        int     `json:"index"`
+	Message      Message `json:"message"`
+	FinishReason string  `json:"finish_reason"`
+}
+
+// Usage represents token usage information
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// AttackScenario represents a generated attack scenario
+type AttackScenario struct {
+	ID          string       `json:"id"`
+	Description string       `json:"description"`
+	Severity    string       `json:"severity"`
+	Steps       []AttackStep `json:"steps"`
+	Rationale   string       `json:"rationale"`
+	Warnings    []string     `json:"warnings"`
+
+	// TokensUsed is filled in from the provider's usage report
+	TokensUsed int `json:"-"`
+}
+
+// AttackStep represents a single step in an attack scenario
+type AttackStep struct {
+	Order       int      `json:"order"`
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Targets     []string `json:"targets"`
+	Commands    []string `json:"commands,omitempty"`
+	Rationale   string   `json:"rationale"`
+	Risk        string   `json:"risk"`
+	// Severity overrides the scenario's severity for this step
+	Severity string `json:"severity,omitempty"`
+}
+
+// completeFunc asks a provider to answer the prompts with a scenario
+type completeFunc func(ctx context.Context, systemPrompt, userPrompt, model string) (*AttackScenario, error)
+
+// newChatClient creates the shared client state for a provider
+func newChatClient(cfg *config.AIConfig) *chatClient {
+	return &chatClient{
+		config:     cfg,
+		httpClient: &http.Client{},
+		logger:     logrus.New(),
+	}
+}
+
+// generateScenario builds the prompts, asks complete for a scenario and
+// converts it to a protobuf response
+func (c *chatClient) generateScenario(ctx context.Context, req *pb.GenerateAttackScenarioRequest, complete completeFunc) (*pb.GenerateAttackScenarioResponse, error) {
+	c.logger.WithFields(logrus.Fields{
+		"provider":     c.config.Provider,
+		"target":       req.TargetDescription,
+		"max_severity": req.MaxSeverity.String(),
+		"model":        req.AiModel,
+	}).Info("🤖 Generating AI attack scenario")
+
+	// Construct the system prompt for attack scenario generation
+	systemPrompt := c.buildSystemPrompt(req.MaxSeverity, req.AllowedTypes)
+	userPrompt := c.buildUserPrompt(req.TargetDescription, req.MaxSeverity)
+
+	model := req.AiModel
+	if model == "" {
+		model = c.config.Model
+	}
+
+	scenario, err := complete(ctx, systemPrompt, userPrompt, model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate scenario: %w", err)
+	}
+
+	// Add metadata
+	scenario.ID = ids.Scenario()
+
+	// Convert to protobuf response
+	response := &pb.GenerateAttackScenarioResponse{
+		ScenarioId:        scenario.ID,
+		Description:       scenario.Description,
+		EstimatedSeverity: c.parseSeverity(scenario.Severity),
+		Rationale:         scenario.Rationale,
+		Warnings:          scenario.Warnings,
+		TokensUsed:        int64(scenario.TokensUsed),
+	}
+
+	for _, step := range scenario.Steps {
+		// Safe conversion with bounds check
+		if step.Order < 0 || step.Order > 2147483647 {
+			return nil, fmt.Errorf("step order %d is out of int32 range", step.Order)
+		}
+
+		// The prompt asks the model to stay within the allowed types, but
+		// nothing forces it to, so drop any step that strays
+		stepType, known := lookupDestructionType(step.Type)
+		if len(req.AllowedTypes) > 0 && (!known || !typeAllowed(stepType, req.AllowedTypes)) {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("步骤 %d 使用了未允许的破坏类型 %s，已被移除", step.Order, step.Type))
+			continue
+		}
+		if !known {
+			stepType = pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION
+		}
+
+		response.Steps = append(response.Steps, &pb.AttackStep{
+			Order:       int32(step.Order),
+			Description: step.Description,
+			Type:        stepType,
+			Targets:     step.Targets,
+			Rationale:   step.Rationale,
+			Risk:        step.Risk,
+			Commands:    step.Commands,
+		})
+	}
+
+	if len(scenario.Steps) > 0 && len(response.Steps) == 0 {
+		return nil, fmt.Errorf("generated scenario has no steps of the allowed destruction types")
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"scenario_id": scenario.ID,
+		"steps":       len(response.Steps),
+		"severity":    scenario.Severity,
+	}).Info("✅ AI attack scenario generated successfully")
+
+	return response, nil
+}
+
+// postJSON sends body to url as JSON with the given headers and decodes the
+// response into out. The call is bounded by ai.request_timeout as well as
+// by ctx, so a caller cancelling ctx aborts it at once.
+func (c *chatClient) postJSON(ctx context.Context, url string, headers map[string]string, body, out interface{}) error {
+	if c.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.RequestTimeout)
+		defer cancel()
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	// Execute request. The request carries ctx, so cancelling it aborts
+	// the call and any body read still in flight.
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("request cancelled: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.logger.WithError(err).Warn("Failed to close response body")
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("request cancelled: %w", ctx.Err())
+		}
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// scenarioFromChoices parses the scenario out of the first response choice
+func (c *chatClient) scenarioFromChoices(choices []Choice) (*AttackScenario, error) {
+	if len(choices) == 0 {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	scenario, err := c.parseScenarioFromContent(choices[0].Message.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+
+	return scenario, nil
+}
+
+// destructionTypeDescriptions describes each type the model may propose,
+// in prompt order
+var destructionTypeDescriptions = []struct {
+	Type        pb.DestructionType
+	Description string
+}{
+	{pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, "文件删除攻击"},
+	{pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, "服务终止攻击"},
+	{pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION, "内存耗尽攻击"},
+	{pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL, "磁盘填满攻击"},
+	{pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION, "网络中断攻击"},
+	{pb.DestructionType_DESTRUCTION_TYPE_BOOT_CORRUPTION, "引导损坏攻击"},
+	{pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC, "内核崩溃攻击"},
+	{pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION, "文件损坏攻击（随机覆写文件中的字节，文件保留）"},
+	{pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, "CPU 满载攻击（按严重性占用部分核心，持续一段时间）"},
+	{pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION, "inode/文件描述符耗尽（在目录中创建大量小文件，或占用文件描述符）"},
+}
+
+// buildSystemPrompt creates the system prompt for the AI. When allowedTypes
+// is non-empty only those types are offered to the model.
+func (c *chatClient) buildSystemPrompt(maxSeverity pb.DestructionSeverity, allowedTypes []pb.DestructionType) string {
+	var types strings.Builder
+	for _, t := range destructionTypeDescriptions {
+		if len(allowedTypes) > 0 && !typeAllowed(t.Type, allowedTypes) {
+			continue
+		}
+		_, _ = fmt.Fprintf(&types, "- %s: %s\n", strings.TrimPrefix(t.Type.String(), "DESTRUCTION_TYPE_"), t.Description)
+	}
+	if len(allowedTypes) > 0 {
+		types.WriteString("每个步骤只能使用上述破坏类型，不得使用其他类型。\n")
+	}
+
+	return fmt.Sprintf(`你是一个专业的渗透测试专家和系统安全评估师。你的任务是为授权的测试环境生成破坏性测试场景。
+
+重要安全准则：
+1. 所有生成的场景仅用于授权的测试环境
+2. 必须包含明确的安全警告和风险评估
+3. 提供详细的技术实现步骤
+4. 最大严重性级别限制为：%s
+5. 必须考虑可恢复性和系统影响
+
+响应格式要求：
+- 使用JSON格式返回
+- 包含场景ID、描述、严重性、步骤列表
+- 每个步骤包含顺序、类型、描述、目标、原理说明
+- 提供风险评估和警告信息
+
+支持的破坏类型：
+%s
+请确保生成的场景具有教育价值，并能有效测试系统的脆弱性。`, maxSeverity.String(), types.String())
+}
+
+// typeAllowed reports whether t is in allowed
+func typeAllowed(t pb.DestructionType, allowed []pb.DestructionType) bool {
+	for _, a := range allowed {
+		if a == t {
+			return true
+		}
+	}
+	return false
+}
+
+// buildUserPrompt creates the user prompt with specific target description
+func (c *chatClient) buildUserPrompt(targetDescription string, maxSeverity pb.DestructionSeverity) string {
+	return fmt.Sprintf(`请为以下目标系统生成一个破坏性测试场景：
+
+目标描述：%s
+最大严重性：%s
+
+要求：
+1. 分析目标系统的潜在脆弱点
+2. 设计渐进式的攻击步骤
+3. 为每个步骤提供技术实现细节
+4. 评估每个步骤的风险和影响
+5. 提供场景执行的安全建议
+
+请以JSON格式返回完整的攻击场景。`, targetDescription, maxSeverity.String())
+}
+
+// parseScenarioFromContent parses the AI response content into an AttackScenario
+func (c *chatClient) parseScenarioFromContent(content string) (*AttackScenario, error) {
+	// Try to parse as JSON first
+	var scenario AttackScenario
+	if err := json.Unmarshal([]byte(content), &scenario); err == nil {
+		return &scenario, nil
+	}
+
+	// If JSON parsing fails, try to extract JSON from markdown code blocks
+	jsonStart := "```json"
+	jsonEnd := "```"
+
+	startIdx := strings.Index(content, jsonStart)
+	if startIdx == -1 {
+		return nil, fmt.Errorf("no JSON content found in response")
+	}
+
+	startIdx += len(jsonStart)
+	endIdx := strings.Index(content[startIdx:], jsonEnd)
+	if endIdx == -1 {
+		return nil, fmt.Errorf("incomplete JSON content in response")
+	}
+
+	jsonContent := content[startIdx : startIdx+endIdx]
+	if err := json.Unmarshal([]byte(jsonContent), &scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse extracted JSON: %w", err)
+	}
+
+	return &scenario, nil
+}
+
+// parseSeverity converts string severity to protobuf enum
+func (c *chatClient) parseSeverity(severity string) pb.DestructionSeverity {
+	switch strings.ToUpper(severity) {
+	case "LOW":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW
+	case "MEDIUM":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM
+	case "HIGH":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH
+	case "CRITICAL":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL
+	default:
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW
+	}
+}
+
+// parseDestructionType converts string type to protobuf enum, treating
+// unknown types as file deletion
+func (c *chatClient) parseDestructionType(destructionType string) pb.DestructionType {
+	if t, ok := lookupDestructionType(destructionType); ok {
+		return t
+	}
+	return pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION
+}
+
+// lookupDestructionType converts string type to protobuf enum, reporting
+// whether the type is one the prompt offers
+func lookupDestructionType(destructionType string) (pb.DestructionType, bool) {
+	name := strings.TrimPrefix(strings.ToUpper(destructionType), "DESTRUCTION_TYPE_")
+	for _, t := range destructionTypeDescriptions {
+		if strings.TrimPrefix(t.Type.String(), "DESTRUCTION_TYPE_") == name {
+			return t.Type, true
+		}
+	}
+	return pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED, false
+}
+
+// ValidateScenario validates a generated attack scenario
+func (c *chatClient) ValidateScenario(scenario *AttackScenario, maxSeverity pb.DestructionSeverity) error {
+	// Check severity limits
+	scenarioSeverity := c.parseSeverity(scenario.Severity)
+	if scenarioSeverity > maxSeverity {
+		return fmt.Errorf("scenario severity %s exceeds maximum %s", scenario.Severity, maxSeverity.String())
+	}
+
+	// Validate steps
+	if len(scenario.Steps) == 0 {
+		return fmt.Errorf("scenario must have at least one step")
+	}
+
+	// Check for dangerous targets
+	for _, step := range scenario.Steps {
+		for _, target := range step.Targets {
+			if IsDangerousTarget(target) {
+				return fmt.Errorf("scenario targets dangerous system path: %s", target)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// mixedScenario proposes a step of an allowed type alongside ones that
+// should be filtered out
+func mixedScenario(ctx context.Context, systemPrompt, userPrompt, model string) (*AttackScenario, error) {
+	return &AttackScenario{
+		Description: "Mixed scenario",
+		Severity:    "LOW",
+		Steps: []AttackStep{
+			{Order: 1, Type: "FILE_DELETION", Targets: []string{"/tmp/cache"}},
+			{Order: 2, Type: "KERNEL_PANIC", Targets: []string{"kernel"}},
+			{Order: 3, Type: "SERVICE_TERMINATION", Targets: []string{"nginx"}},
+			{Order: 4, Type: "FORK_BOMB", Targets: []string{"shell"}},
+		},
+	}, nil
+}
+
+func TestGenerateScenarioFiltersDisallowedTypes(t *testing.T) {
+	client := newChatClient(&config.AIConfig{Provider: ProviderDeepSeek})
+
+	var prompt string
+	complete := func(ctx context.Context, systemPrompt, userPrompt, model string) (*AttackScenario, error) {
+		prompt = systemPrompt
+		return mixedScenario(ctx, systemPrompt, userPrompt, model)
+	}
+
+	resp, err := client.generateScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "test host",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		AllowedTypes:      []pb.DestructionType{pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION},
+	}, complete)
+	if err != nil {
+		t.Fatalf("generateScenario failed: %v", err)
+	}
+
+	if len(resp.Steps) != 1 {
+		t.Fatalf("Expected only the FILE_DELETION step to remain, got %v", resp.Steps)
+	}
+	for _, step := range resp.Steps {
+		if step.Type != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+			t.Errorf("Expected only FILE_DELETION steps, got %s", step.Type)
+		}
+	}
+	if len(resp.Warnings) != 3 {
+		t.Errorf("Expected a warning for each dropped step, got %v", resp.Warnings)
+	}
+
+	if !strings.Contains(prompt, "FILE_DELETION") || strings.Contains(prompt, "KERNEL_PANIC") {
+		t.Errorf("Expected the prompt to offer only FILE_DELETION, got:\n%s", prompt)
+	}
+}
+
+func TestGenerateScenarioWithoutAllowedSteps(t *testing.T) {
+	client := newChatClient(&config.AIConfig{Provider: ProviderDeepSeek})
+
+	_, err := client.generateScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "test host",
+		AllowedTypes:      []pb.DestructionType{pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL},
+	}, mixedScenario)
+	if err == nil {
+		t.Error("Expected an error when no step uses an allowed type")
+	}
+}
+
+func TestGenerateScenarioWithoutRestriction(t *testing.T) {
+	client := newChatClient(&config.AIConfig{Provider: ProviderDeepSeek})
+
+	resp, err := client.generateScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "test host",
+	}, mixedScenario)
+	if err != nil {
+		t.Fatalf("generateScenario failed: %v", err)
+	}
+
+	// Unknown types keep falling back to file deletion
+	if len(resp.Steps) != 4 || resp.Steps[3].Type != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		t.Errorf("Expected every step to be kept, got %v", resp.Steps)
+	}
+}
@@ -2,6 +2,8 @@ package ai
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -83,6 +85,8 @@ func TestParseDestructionType(t *testing.T) {
 		{"MEMORY_EXHAUSTION", pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION},
 		{"DISK_FILL", pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL},
 		{"NETWORK_DISRUPTION", pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION},
+		{"CPU_BURN", pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN},
+		{"INODE_EXHAUSTION", pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION},
 		{"file_deletion", pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION},
 		{"invalid", pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION},
 		{"", pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION},
@@ -104,7 +108,7 @@ func TestBuildSystemPrompt(t *testing.T) {
 	}
 	client := NewDeepSeekClient(cfg)
 
-	prompt := client.buildSystemPrompt(pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM)
+	prompt := client.buildSystemPrompt(pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, nil)
 
 	if prompt == "" {
 		t.Error("Expected system prompt to be generated")
@@ -252,6 +256,95 @@ func TestValidateScenario(t *testing.T) {
 	}
 }
 
+func TestDeepSeekClientGenerateAttackScenario(t *testing.T) {
+	server := newChatServer(t, func(r *http.Request, body map[string]interface{}) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("Expected path /chat/completions, got %s", r.URL.Path)
+		}
+	})
+
+	client := NewDeepSeekClient(&config.AIConfig{
+		Provider:       "deepseek",
+		APIKey:         "test-key",
+		BaseURL:        server.URL,
+		Model:          "deepseek-chat",
+		RequestTimeout: 5 * time.Second,
+	})
+
+	resp, err := client.GenerateAttackScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "test host",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+	})
+	if err != nil {
+		t.Fatalf("GenerateAttackScenario failed: %v", err)
+	}
+
+	if resp.Rationale != "Check cache recovery" {
+		t.Errorf("Expected scenario rationale, got %q", resp.Rationale)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0] != "Run in a sandbox" {
+		t.Errorf("Expected scenario warnings, got %v", resp.Warnings)
+	}
+	if len(resp.Steps) != 1 {
+		t.Fatalf("Expected 1 step, got %d", len(resp.Steps))
+	}
+	if resp.Steps[0].Risk != "Cache is lost" {
+		t.Errorf("Expected step risk, got %q", resp.Steps[0].Risk)
+	}
+	if len(resp.Steps[0].Commands) != 1 || resp.Steps[0].Commands[0] != "rm -rf /tmp/cache" {
+		t.Errorf("Expected step commands, got %v", resp.Steps[0].Commands)
+	}
+}
+
+func TestDeepSeekClientCancellation(t *testing.T) {
+	// The server never answers on its own; it returns once the client
+	// gives up on the request
+	server := newChatServer(t, func(r *http.Request, body map[string]interface{}) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	})
+
+	tests := []struct {
+		name           string
+		requestTimeout time.Duration
+		ctxTimeout     time.Duration
+	}{
+		{"caller deadline", time.Minute, 100 * time.Millisecond},
+		{"request timeout", 100 * time.Millisecond, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewDeepSeekClient(&config.AIConfig{
+				Provider:       "deepseek",
+				APIKey:         "test-key",
+				BaseURL:        server.URL,
+				Model:          "deepseek-chat",
+				RequestTimeout: tt.requestTimeout,
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.ctxTimeout)
+			defer cancel()
+
+			start := time.Now()
+			_, err := client.GenerateAttackScenario(ctx, &pb.GenerateAttackScenarioRequest{
+				TargetDescription: "test host",
+				MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			})
+			elapsed := time.Since(start)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Expected a deadline exceeded error, got: %v", err)
+			}
+			if elapsed > 2*time.Second {
+				t.Errorf("Expected the call to be aborted promptly, took %v", elapsed)
+			}
+		})
+	}
+}
+
 func TestGenerateAttackScenario_ValidationOnly(t *testing.T) {
 	// Test the request validation part without making actual API calls
 	cfg := &config.AIConfig{
@@ -0,0 +1,117 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultAzureAPIVersion is used for Azure OpenAI when no api_version is set
+const defaultAzureAPIVersion = "2024-02-01"
+
+// OpenAIClient generates attack scenarios through the OpenAI chat
+// completions API. It also serves Azure OpenAI and local OpenAI-compatible
+// endpoints.
+type OpenAIClient struct {
+	*chatClient
+}
+
+// OpenAIRequest represents the request format for the OpenAI chat completions API
+type OpenAIRequest struct {
+	Model       string    `json:"model,omitempty"`
+	Messages    []Message `json:"messages"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Temperature float64   `json:"temperature"`
+}
+
+// OpenAIResponse represents the response from the OpenAI chat completions API
+type OpenAIResponse struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   Usage    `json:"usage"`
+}
+
+// NewOpenAIClient creates a new OpenAI-compatible AI client
+func NewOpenAIClient(cfg *config.AIConfig) *OpenAIClient {
+	return &OpenAIClient{chatClient: newChatClient(cfg)}
+}
+
+// GenerateAttackScenario generates an AI-powered attack scenario
+func (c *OpenAIClient) GenerateAttackScenario(ctx context.Context, req *pb.GenerateAttackScenarioRequest) (*pb.GenerateAttackScenarioResponse, error) {
+	return c.generateScenario(ctx, req, c.callOpenAIAPI)
+}
+
+// callOpenAIAPI makes the actual chat completions call
+func (c *OpenAIClient) callOpenAIAPI(ctx context.Context, systemPrompt, userPrompt, model string) (*AttackScenario, error) {
+	reqData := OpenAIRequest{
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		MaxTokens:   c.config.MaxTokens,
+		Temperature: c.config.Temperature,
+	}
+
+	// Azure selects the model through the deployment in the URL
+	if !c.isAzure() {
+		reqData.Model = model
+	}
+
+	var openAIResp OpenAIResponse
+	if err := c.postJSON(ctx, c.endpoint(model), c.headers(), reqData, &openAIResp); err != nil {
+		return nil, err
+	}
+
+	scenario, err := c.scenarioFromChoices(openAIResp.Choices)
+	if err != nil {
+		return nil, err
+	}
+	scenario.TokensUsed = openAIResp.Usage.TotalTokens
+
+	c.logger.WithFields(logrus.Fields{
+		"tokens_used": openAIResp.Usage.TotalTokens,
+		"model":       openAIResp.Model,
+	}).Debug("OpenAI API call completed")
+
+	return scenario, nil
+}
+
+// isAzure reports whether the client talks to Azure OpenAI
+func (c *OpenAIClient) isAzure() bool {
+	return strings.EqualFold(c.config.Provider, ProviderAzure)
+}
+
+// endpoint returns the chat completions URL for model
+func (c *OpenAIClient) endpoint(model string) string {
+	baseURL := strings.TrimSuffix(c.config.BaseURL, "/")
+	if !c.isAzure() {
+		return baseURL + "/chat/completions"
+	}
+
+	apiVersion := c.config.APIVersion
+	if apiVersion == "" {
+		apiVersion = defaultAzureAPIVersion
+	}
+	return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		baseURL, url.PathEscape(model), url.QueryEscape(apiVersion))
+}
+
+// headers returns the authentication headers. Local endpoints often need
+// no key, so none is sent when it is empty.
+func (c *OpenAIClient) headers() map[string]string {
+	if c.config.APIKey == "" {
+		return nil
+	}
+	if c.isAzure() {
+		return map[string]string{"api-key": c.config.APIKey}
+	}
+	return map[string]string{"Authorization": "Bearer " + c.config.APIKey}
+}
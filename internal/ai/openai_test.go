@@ -0,0 +1,130 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+const testScenarioContent = `{"description":"Delete temp files","severity":"LOW","rationale":"Check cache recovery","warnings":["Run in a sandbox"],"steps":[{"order":1,"type":"FILE_DELETION","description":"Remove cache","targets":["/tmp/cache"],"commands":["rm -rf /tmp/cache"],"rationale":"test","risk":"Cache is lost"}]}`
+
+// newChatServer answers every chat completion with testScenarioContent and
+// passes each request to inspect
+func newChatServer(t *testing.T, inspect func(r *http.Request, body map[string]interface{})) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		inspect(r, body)
+
+		resp := OpenAIResponse{
+			Model:   "test-model",
+			Choices: []Choice{{Message: Message{Role: "assistant", Content: testScenarioContent}}},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("Failed to encode response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestOpenAIClientGenerateAttackScenario(t *testing.T) {
+	server := newChatServer(t, func(r *http.Request, body map[string]interface{}) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("Expected path /v1/chat/completions, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Expected bearer auth, got %q", got)
+		}
+		if body["model"] != "gpt-4o-mini" {
+			t.Errorf("Expected model gpt-4o-mini, got %v", body["model"])
+		}
+	})
+
+	client := NewOpenAIClient(&config.AIConfig{
+		Provider:       ProviderOpenAI,
+		APIKey:         "test-key",
+		BaseURL:        server.URL + "/v1",
+		Model:          "gpt-4o-mini",
+		RequestTimeout: 5 * time.Second,
+	})
+
+	resp, err := client.GenerateAttackScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "test host",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+	})
+	if err != nil {
+		t.Fatalf("GenerateAttackScenario failed: %v", err)
+	}
+
+	if resp.ScenarioId == "" {
+		t.Error("Expected scenario ID to be set")
+	}
+	if len(resp.Steps) != 1 || resp.Steps[0].Type != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		t.Errorf("Unexpected steps: %v", resp.Steps)
+	}
+}
+
+func TestOpenAIClientAzure(t *testing.T) {
+	server := newChatServer(t, func(r *http.Request, body map[string]interface{}) {
+		if r.URL.Path != "/openai/deployments/my-deployment/chat/completions" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("api-version"); got != "2024-06-01" {
+			t.Errorf("Expected api-version 2024-06-01, got %q", got)
+		}
+		if got := r.Header.Get("api-key"); got != "azure-key" {
+			t.Errorf("Expected api-key header, got %q", got)
+		}
+		if _, ok := body["model"]; ok {
+			t.Error("Expected no model in Azure request body")
+		}
+	})
+
+	client := NewOpenAIClient(&config.AIConfig{
+		Provider:       ProviderAzure,
+		APIKey:         "azure-key",
+		BaseURL:        server.URL,
+		Model:          "my-deployment",
+		APIVersion:     "2024-06-01",
+		RequestTimeout: 5 * time.Second,
+	})
+
+	if _, err := client.GenerateAttackScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "test host",
+	}); err != nil {
+		t.Fatalf("GenerateAttackScenario failed: %v", err)
+	}
+}
+
+func TestOpenAIClientWithoutAPIKey(t *testing.T) {
+	server := newChatServer(t, func(r *http.Request, body map[string]interface{}) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Expected no Authorization header, got %q", got)
+		}
+	})
+
+	client := NewOpenAIClient(&config.AIConfig{
+		Provider:       ProviderOpenAICompatible,
+		BaseURL:        server.URL,
+		Model:          "llama3",
+		RequestTimeout: 5 * time.Second,
+	})
+
+	if _, err := client.GenerateAttackScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "test host",
+	}); err != nil {
+		t.Fatalf("GenerateAttackScenario failed: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// Supported AI providers
+const (
+	ProviderDeepSeek         = "deepseek"
+	ProviderOpenAI           = "openai"
+	ProviderAzure            = "azure"
+	ProviderOpenAICompatible = "openai-compatible"
+)
+
+// AIProvider generates attack scenarios with a large language model
+type AIProvider interface {
+	GenerateAttackScenario(ctx context.Context, req *pb.GenerateAttackScenarioRequest) (*pb.GenerateAttackScenarioResponse, error)
+}
+
+// NewProvider creates the AI client for the configured provider
+func NewProvider(cfg *config.AIConfig) (AIProvider, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "", ProviderDeepSeek:
+		return NewDeepSeekClient(cfg), nil
+	case ProviderOpenAI, ProviderAzure, ProviderOpenAICompatible:
+		return NewOpenAIClient(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported AI provider: %s", cfg.Provider)
+	}
+}
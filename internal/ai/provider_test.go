@@ -0,0 +1,45 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		want     string
+	}{
+		{"", "deepseek"},
+		{"deepseek", "deepseek"},
+		{"openai", "openai"},
+		{"Azure", "openai"},
+		{"openai-compatible", "openai"},
+	}
+
+	for _, tt := range tests {
+		provider, err := NewProvider(&config.AIConfig{Provider: tt.provider})
+		if err != nil {
+			t.Errorf("NewProvider(%q) failed: %v", tt.provider, err)
+			continue
+		}
+
+		var got string
+		switch provider.(type) {
+		case *DeepSeekClient:
+			got = "deepseek"
+		case *OpenAIClient:
+			got = "openai"
+		}
+		if got != tt.want {
+			t.Errorf("NewProvider(%q) = %T, want %s client", tt.provider, provider, tt.want)
+		}
+	}
+}
+
+func TestNewProviderUnsupported(t *testing.T) {
+	if _, err := NewProvider(&config.AIConfig{Provider: "unknown"}); err == nil {
+		t.Error("Expected error for unsupported provider")
+	}
+}
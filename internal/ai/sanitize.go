@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"regexp"
+	"strings"
+)
+
+// dangerousTargets are system paths a scenario must never touch
+var dangerousTargets = []string{"/bin", "/usr", "/etc", "/var", "/root", "C:\\Windows", "C:\\System32", "C:\\Program Files"}
+
+// dangerousCommand pairs a pattern with the reason it is flagged
+type dangerousCommand struct {
+	pattern *regexp.Regexp
+	reason  string
+}
+
+// dangerousCommands are command shapes that destroy more than a test
+// target or take the host down
+var dangerousCommands = []dangerousCommand{
+	{regexp.MustCompile(`\brm\s+(-[a-zA-Z]*\s+)*/(\s|\*|$)`), "removes the root filesystem"},
+	{regexp.MustCompile(`\bmkfs(\.\w+)?\b`), "formats a filesystem"},
+	{regexp.MustCompile(`\bdd\b.*\bof=/dev/`), "writes directly to a block device"},
+	{regexp.MustCompile(`>\s*/dev/(sd|nvme|hd|vd|xvd)`), "writes directly to a block device"},
+	{regexp.MustCompile(`:\(\)\s*\{.*\};\s*:`), "fork bomb"},
+	{regexp.MustCompile(`\b(shutdown|reboot|halt|poweroff)\b`), "takes the host down"},
+	{regexp.MustCompile(`/proc/sysrq-trigger`), "triggers a kernel action"},
+	{regexp.MustCompile(`\bchmod\s+(-[a-zA-Z]*\s+)*[0-7]*\s+/(\s|$)`), "changes permissions on the root filesystem"},
+}
+
+// IsDangerousTarget reports whether target is inside a protected system path
+func IsDangerousTarget(target string) bool {
+	for _, dangerous := range dangerousTargets {
+		if strings.HasPrefix(target, dangerous) {
+			return true
+		}
+	}
+	return false
+}
+
+// DangerousCommand reports why command is dangerous, or an empty string if
+// nothing about it was flagged
+func DangerousCommand(command string) string {
+	for _, dangerous := range dangerousCommands {
+		if dangerous.pattern.MatchString(command) {
+			return dangerous.reason
+		}
+	}
+
+	// The program itself commonly lives under /usr or /bin, so only its
+	// arguments are checked
+	fields := strings.Fields(command)
+	for i, field := range fields {
+		if i == 0 {
+			continue
+		}
+		if IsDangerousTarget(strings.Trim(field, `"'`)) {
+			return "touches protected system path " + field
+		}
+	}
+
+	return ""
+}
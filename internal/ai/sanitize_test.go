@@ -0,0 +1,38 @@
+package ai
+
+import "testing"
+
+func TestDangerousCommand(t *testing.T) {
+	tests := []struct {
+		command   string
+		dangerous bool
+	}{
+		{"rm -rf /tmp/burndevice_test", false},
+		{"systemctl stop test-service", false},
+		{"/usr/bin/rm /tmp/file", false},
+		{"rm -rf /", true},
+		{"rm -rf /*", true},
+		{"mkfs.ext4 /dev/sdb1", true},
+		{"dd if=/dev/zero of=/dev/sda bs=1M", true},
+		{":(){ :|:& };:", true},
+		{"shutdown -h now", true},
+		{"echo c > /proc/sysrq-trigger", true},
+		{"rm -rf /etc/nginx", true},
+	}
+
+	for _, tt := range tests {
+		reason := DangerousCommand(tt.command)
+		if (reason != "") != tt.dangerous {
+			t.Errorf("DangerousCommand(%q) = %q, want dangerous=%v", tt.command, reason, tt.dangerous)
+		}
+	}
+}
+
+func TestIsDangerousTarget(t *testing.T) {
+	if !IsDangerousTarget("/etc/passwd") {
+		t.Error("Expected /etc/passwd to be dangerous")
+	}
+	if IsDangerousTarget("/tmp/test") {
+		t.Error("Expected /tmp/test to be safe")
+	}
+}
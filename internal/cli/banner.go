@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// bannerRule frames the connection banner
+var bannerRule = strings.Repeat("=", 60)
+
+// showBanner fetches the server's connection banner and, when there is
+// one, prints it before anything is executed. It goes to stderr so it
+// stands out from the results without mixing into --output json or the
+// result file. Servers too old to report a banner are skipped.
+func showBanner(ctx context.Context, cmd *cobra.Command, client pb.BurnDeviceServiceClient) error {
+	resp, err := client.GetServerInfo(ctx, &pb.GetServerInfoRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get server info: %w", err)
+	}
+
+	printBanner(cmd.ErrOrStderr(), resp.ConnectionBanner)
+	return nil
+}
+
+// printBanner writes banner between rules; an empty banner writes nothing
+func printBanner(w io.Writer, banner string) {
+	banner = strings.TrimSpace(banner)
+	if banner == "" {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s\n📢 %s\n%s\n", bannerRule, banner, bannerRule)
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExecuteShowsBanner(t *testing.T) {
+	banner := "LAB-3: max severity MEDIUM, authorized testers only"
+
+	tests := []struct {
+		name   string
+		banner string
+	}{
+		{name: "with banner", banner: banner},
+		{name: "without banner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startFakeServer(t, fakeServer{banner: tt.banner})
+
+			// Sharing one buffer keeps the order stderr and stdout were written in
+			var combined bytes.Buffer
+			clientCmd := NewClientCommand()
+			clientCmd.SetOut(&combined)
+			clientCmd.SetErr(&combined)
+			clientCmd.SetArgs([]string{
+				"execute",
+				"--server", addr,
+				"--type", "FILE_DELETION",
+				"--targets", "/tmp/test.txt",
+				"--confirm",
+			})
+
+			if err := clientCmd.Execute(); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			output := combined.String()
+			results := strings.Index(output, "Execution completed")
+			if results < 0 {
+				t.Fatalf("Expected execution results, got:\n%s", output)
+			}
+
+			shown := strings.Index(output, banner)
+			if tt.banner == "" {
+				if strings.Contains(output, bannerRule) {
+					t.Errorf("Expected no banner, got:\n%s", output)
+				}
+				return
+			}
+			if shown < 0 {
+				t.Fatalf("Expected the banner to be shown, got:\n%s", output)
+			}
+			if shown > results {
+				t.Errorf("Expected the banner before the results, got:\n%s", output)
+			}
+		})
+	}
+}
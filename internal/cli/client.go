@@ -2,14 +2,22 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
 )
@@ -18,22 +26,47 @@ import (
 func NewClientCommand() *cobra.Command {
 	var serverAddr string
 	var timeout time.Duration
+	var token string
+	var resultFile string
+	var outputFormat string
 
 	cmd := &cobra.Command{
 		Use:   "client",
 		Short: "BurnDevice client commands",
 		Long:  "与 BurnDevice 服务器交互的客户端命令",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutputFormat(outputFormat)
+		},
 	}
 
 	cmd.PersistentFlags().StringVar(&serverAddr, "server", "localhost:8080", "Server address")
 	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Request timeout")
+	cmd.PersistentFlags().StringVar(&token, "token", os.Getenv("BURNDEVICE_TOKEN"), "API token sent to the server (defaults to $BURNDEVICE_TOKEN)")
+	cmd.PersistentFlags().StringVar(&resultFile, "result-file", "", "Also write command results to this file")
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "Output format (text, json, table); streams print one JSON object per event, and table is the default for list-style commands")
 
 	// Add subcommands
 	cmd.AddCommand(
 		newExecuteCommand(),
 		newSystemInfoCommand(),
+		newServerInfoCommand(),
+		newPingCommand(),
+		newDoctorCommand(),
+		newMetricsCommand(),
 		newGenerateScenarioCommand(),
 		newStreamCommand(),
+		newWatchCommand(),
+		newRestoreCommand(),
+		newVerifyBackupCommand(),
+		newCleanupBackupsCommand(),
+		newTaskCommand(),
+		newTasksCommand(),
+		newHistoryCommand(),
+		newScenarioCommand(),
+		newReplayCommand(),
+		newExpandCommand(),
+		newPlanCommand(),
+		newScheduleCommand(),
 	)
 
 	return cmd
@@ -41,11 +74,34 @@ func NewClientCommand() *cobra.Command {
 
 func newExecuteCommand() *cobra.Command {
 	var (
-		destructionType string
-		targets         []string
-		severity        string
-		confirm         bool
-		scenarioID      string
+		destructionType      string
+		targets              []string
+		severity             string
+		confirm              bool
+		confirmPhrase        string
+		scenarioID           string
+		dryRun               bool
+		maxOps               float64
+		maxBytes             int64
+		recursive            bool
+		duration             time.Duration
+		intensity            int32
+		windowOverride       string
+		idempotencyKey       string
+		fileDescriptors      bool
+		yesIKnow             bool
+		quarantine           bool
+		autoRestore          time.Duration
+		failurePolicy        string
+		skipPreflight        bool
+		targetFile           string
+		severityFromScenario bool
+		include              []string
+		exclude              []string
+		olderThan            time.Duration
+		maxSize              int64
+		wipe                 bool
+		plan                 bool
 	)
 
 	cmd := &cobra.Command{
@@ -53,10 +109,15 @@ func newExecuteCommand() *cobra.Command {
 		Short: "Execute a destruction request",
 		Long:  "执行破坏性测试请求",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !confirm {
+			if !confirm && !dryRun {
 				return fmt.Errorf("必须使用 --confirm 标志确认破坏性操作")
 			}
 
+			targets, err := withTargetFile(targets, targetFile)
+			if err != nil {
+				return err
+			}
+
 			client, conn, err := createClient(cmd)
 			if err != nil {
 				return err
@@ -67,14 +128,25 @@ func newExecuteCommand() *cobra.Command {
 				}
 			}()
 
-			// Parse destruction type
-			dtype, err := parseDestructionType(destructionType)
+			out, err := newOutput(cmd)
 			if err != nil {
 				return err
 			}
+			defer out.Close()
 
-			// Parse severity
-			sev, err := parseSeverity(severity)
+			// Parse destruction type; a stored scenario brings its own
+			dtype, err := scenarioDestructionType(destructionType, scenarioID)
+			if err != nil {
+				return err
+			}
+
+			// Parse severity; a scenario may bring its own
+			sev, err := scenarioSeverity(severity, scenarioID, cmd.Flags().Changed("severity"), severityFromScenario)
+			if err != nil {
+				return err
+			}
+
+			policy, err := parseFailurePolicy(failurePolicy)
 			if err != nil {
 				return err
 			}
@@ -84,7 +156,44 @@ func newExecuteCommand() *cobra.Command {
 				Targets:            targets,
 				Severity:           sev,
 				ConfirmDestruction: confirm,
+				ConfirmationText:   confirmPhrase,
 				AiScenarioId:       scenarioID,
+				DryRun:             dryRun,
+				MaxOpsPerSecond:    maxOps,
+				MaxBytesPerSecond:  maxBytes,
+				Recursive:          recursive,
+				Duration:           durationpb.New(duration),
+				Intensity:          intensity,
+				FileDescriptors:    fileDescriptors,
+				Quarantine:         quarantine,
+				AutoRestoreAfter:   durationpb.New(autoRestore),
+				FailurePolicy:      policy,
+				SkipPreflight:      skipPreflight,
+				IncludePatterns:    include,
+				ExcludePatterns:    exclude,
+				MinAge:             durationpb.New(olderThan),
+				MaxFileSize:        maxSize,
+				Wipe:               wipe,
+			}
+			req.WindowOverrideToken = windowOverride
+			req.IdempotencyKey = idempotencyKey
+
+			bannerCtx, cancelBanner := context.WithTimeout(context.Background(), getTimeout(cmd))
+			err = showBanner(bannerCtx, cmd, client)
+			cancelBanner()
+			if err != nil {
+				return err
+			}
+
+			if plan {
+				if err := confirmPlan(cmd, client, dtype, targets, dryRun); err != nil {
+					return err
+				}
+			}
+
+			req.AcknowledgeIrreversible, err = acknowledgeIrreversible(cmd, client, dtype, dryRun, yesIKnow)
+			if err != nil {
+				return err
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
@@ -94,6 +203,7 @@ func newExecuteCommand() *cobra.Command {
 				"type":     destructionType,
 				"targets":  targets,
 				"severity": severity,
+				"dry_run":  dryRun,
 			}).Warn("🔥 Executing destruction request")
 
 			resp, err := client.ExecuteDestruction(ctx, req)
@@ -101,48 +211,171 @@ func newExecuteCommand() *cobra.Command {
 				return fmt.Errorf("execution failed: %w", err)
 			}
 
+			if out.json {
+				if err := out.JSON(resp); err != nil {
+					return err
+				}
+				return incompleteRunError(resp.Success, resp.FailedTargets)
+			}
+
 			// Display results
-			fmt.Printf("✅ Execution completed: %s\n", resp.Message)
-			fmt.Printf("Success: %v\n", resp.Success)
-			fmt.Printf("Results: %d\n", len(resp.Results))
+			out.Printf("✅ Execution completed: %s\n", resp.Message)
+			out.Printf("Success: %v\n", resp.Success)
+			if resp.TaskId != "" {
+				out.Printf("Task ID: %s\n", resp.TaskId)
+			}
+			if resp.StartedAt != nil && resp.CompletedAt != nil {
+				out.Printf("Ran: %s to %s\n", resp.StartedAt.AsTime().Local().Format(time.RFC3339Nano), resp.CompletedAt.AsTime().Local().Format(time.RFC3339Nano))
+			}
+			for _, step := range resp.Steps {
+				switch {
+				case step.Skipped:
+					out.Printf("  ⏭️  Step %d %s: %s\n", step.Order, step.Type.String(), step.Message)
+				case step.Success:
+					out.Printf("  ✅ Step %d %s (task %s): %s\n", step.Order, step.Type.String(), step.TaskId, step.Message)
+				default:
+					out.Printf("  ❌ Step %d %s (task %s): %s\n", step.Order, step.Type.String(), step.TaskId, step.Message)
+				}
+			}
+			out.Printf("Results: %d\n", len(resp.Results))
 
 			for i, result := range resp.Results {
-				fmt.Printf("\nResult %d:\n", i+1)
-				fmt.Printf("  Target: %s\n", result.Target)
-				fmt.Printf("  Success: %v\n", result.Success)
+				out.Printf("\nResult %d:\n", i+1)
+				out.Printf("  Target: %s\n", result.Target)
+				out.Printf("  Success: %v\n", result.Success)
+				if result.Action != "" {
+					out.Printf("  Action: %s\n", result.Action)
+				}
+				if result.BackupPath != "" {
+					out.Printf("  Backup: %s\n", result.BackupPath)
+				}
+				if result.BackupChecksum != "" {
+					out.Printf("  Backup SHA-256: %s\n", result.BackupChecksum)
+				}
+				if result.Attempts > 1 {
+					out.Printf("  Attempts: %d\n", result.Attempts)
+				}
+				if result.StartedAt != nil {
+					out.Printf("  Started: %s\n", result.StartedAt.AsTime().Local().Format(time.RFC3339Nano))
+				}
+				if result.CompletedAt != nil {
+					out.Printf("  Completed: %s\n", result.CompletedAt.AsTime().Local().Format(time.RFC3339Nano))
+				}
+				if result.PreviousState != "" {
+					out.Printf("  Previous state: %s\n", result.PreviousState)
+				}
 				if result.ErrorMessage != "" {
-					fmt.Printf("  Error: %s\n", result.ErrorMessage)
+					out.Printf("  Error: %s\n", result.ErrorMessage)
+				}
+				if result.Output != "" {
+					out.Printf("  Output: %s\n", strings.TrimSpace(result.Output))
+				}
+				if result.Stderr != "" {
+					out.Printf("  Stderr: %s\n", strings.TrimSpace(result.Stderr))
 				}
 				if result.Metrics != nil {
-					fmt.Printf("  Files deleted: %d\n", result.Metrics.FilesDeleted)
-					fmt.Printf("  Bytes destroyed: %d\n", result.Metrics.BytesDestroyed)
-					fmt.Printf("  Execution time: %.2fs\n", result.Metrics.ExecutionTimeSeconds)
+					out.Printf("  Files deleted: %d\n", result.Metrics.FilesDeleted)
+					out.Printf("  Bytes destroyed: %d\n", result.Metrics.BytesDestroyed)
+					if result.Metrics.BytesAllocated > 0 {
+						out.Printf("  Bytes allocated: %d\n", result.Metrics.BytesAllocated)
+					}
+					if result.Metrics.BytesOverwritten > 0 {
+						out.Printf("  Bytes overwritten: %d (%d passes)\n", result.Metrics.BytesOverwritten, result.Metrics.WipePasses)
+					}
+					if result.Metrics.BytesWritten > 0 {
+						out.Printf("  Bytes written: %d\n", result.Metrics.BytesWritten)
+					}
+					if result.Metrics.OffsetsCorrupted > 0 {
+						out.Printf("  Offsets corrupted: %d (%d bytes changed)\n", result.Metrics.OffsetsCorrupted, result.Metrics.BytesCorrupted)
+					}
+					if result.Metrics.CoresBurned > 0 {
+						out.Printf("  Cores burned: %d (%.1f%% average CPU usage)\n", result.Metrics.CoresBurned, result.Metrics.CpuUsagePercent)
+					}
+					if result.Metrics.InodesConsumed > 0 {
+						out.Printf("  Inodes consumed: %d\n", result.Metrics.InodesConsumed)
+					}
+					if result.Metrics.FdsConsumed > 0 {
+						out.Printf("  File descriptors held: %d\n", result.Metrics.FdsConsumed)
+					}
+					out.Printf("  Execution time: %.2fs\n", result.Metrics.ExecutionTimeSeconds)
+				}
+				for _, hook := range result.HookResults {
+					out.Printf("  Hook: %s (success: %v)\n", hook.Command, hook.Success)
+					if hook.ErrorMessage != "" {
+						out.Printf("    Error: %s\n", hook.ErrorMessage)
+					}
+					if hook.Output != "" {
+						out.Printf("    Output: %s\n", strings.TrimSpace(hook.Output))
+					}
 				}
 			}
 
-			return nil
+			for _, hook := range resp.HookResults {
+				out.Printf("\nTask hook %s: %s (exit code %d, success: %v)\n", hook.Phase, hook.Command, hook.ExitCode, hook.Success)
+				if hook.ErrorMessage != "" {
+					out.Printf("  Error: %s\n", hook.ErrorMessage)
+				}
+				if hook.Output != "" {
+					out.Printf("  Output: %s\n", strings.TrimSpace(hook.Output))
+				}
+			}
+
+			if total := resp.TotalMetrics; total != nil && len(resp.Results) > 1 {
+				out.Printf("\nTotal: %d files deleted, %d bytes destroyed in %.2fs\n", total.FilesDeleted, total.BytesDestroyed, total.ExecutionTimeSeconds)
+			}
+			out.Printf("\nSummary: %s\n", summarizeResults(resp.Results))
+
+			return incompleteRunError(resp.Success, resp.FailedTargets)
 		},
 	}
 
-	cmd.Flags().StringVar(&destructionType, "type", "", "Destruction type (required)")
-	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Target paths")
+	cmd.Flags().StringVar(&destructionType, "type", "", "Destruction type (required unless --scenario-id runs a stored scenario)")
+	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Target paths or glob patterns")
+	cmd.Flags().StringVar(&targetFile, "target-file", "", "File of newline-separated targets to add to --targets (blank lines and # comments are ignored)")
 	cmd.Flags().StringVar(&severity, "severity", "LOW", "Destruction severity (LOW, MEDIUM, HIGH, CRITICAL)")
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive operation")
-	cmd.Flags().StringVar(&scenarioID, "scenario-id", "", "AI scenario ID")
-
-	if err := cmd.MarkFlagRequired("type"); err != nil {
-		logrus.WithError(err).Error("Failed to mark type flag as required")
-	}
+	cmd.Flags().StringVar(&confirmPhrase, "confirm-phrase", "", "Confirmation phrase the server requires for high-severity requests")
+	cmd.Flags().StringVar(&scenarioID, "scenario-id", "", "AI scenario ID; without --type and --targets the server runs every step of the stored scenario")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the destruction without changing anything")
+	cmd.Flags().Float64Var(&maxOps, "max-ops-per-second", 0, "Delete at most this many files per second (0 uses the server default)")
+	cmd.Flags().Int64Var(&maxBytes, "max-bytes-per-second", 0, "Delete at most this many bytes per second (0 uses the server default)")
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "Delete every file under directory targets, one by one")
+	cmd.Flags().DurationVar(&duration, "duration", 0, "How long a CPU burn runs, an inode exhaustion holds or a network disruption cuts targets off (0 uses the server default)")
+	cmd.Flags().Int32Var(&intensity, "intensity", 0, "How hard a resource-pressure type pushes, 1-100: percent of memory, disk, inodes or cores consumed, or of packets dropped (0 uses the severity)")
+	cmd.Flags().StringVar(&windowOverride, "window-override-token", "", "Run outside the server's allowed windows with its security.window_override_token; every override is audited")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Key that makes retrying safe: the server answers a repeated request with this key with the first response instead of executing again")
+	cmd.Flags().BoolVar(&fileDescriptors, "file-descriptors", false, "Make inode exhaustion hold open file descriptors instead of creating files")
+	cmd.Flags().BoolVar(&yesIKnow, "yes-i-know", false, "Acknowledge irreversible types (KERNEL_PANIC, BOOT_CORRUPTION) without typing the server hostname")
+	cmd.Flags().BoolVar(&quarantine, "quarantine", false, "Move file deletion targets into the server's quarantine directory instead of deleting them")
+	cmd.Flags().DurationVar(&autoRestore, "auto-restore-after", 0, "Restore backed-up files or restart stopped services automatically after this hold (e.g. 10m)")
+	cmd.Flags().StringVar(&failurePolicy, "failure-policy", "continue", "What a failed target does to the rest of the run (continue, fail-fast)")
+	cmd.Flags().BoolVar(&skipPreflight, "skip-preflight", false, "Don't check that file deletion targets exist and can be removed before running")
+	cmd.Flags().StringSliceVar(&include, "include", []string{}, "File deletion: only delete files matching one of these globs (matched against the file name, or the path when the glob has a separator)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", []string{}, "File deletion: never delete files matching one of these globs (e.g. .keep,*.conf)")
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "File deletion: only delete files last modified at least this long ago")
+	cmd.Flags().Int64Var(&maxSize, "max-size", 0, "File deletion: only delete files of at most this many bytes (0 for no limit)")
+	cmd.Flags().BoolVar(&wipe, "wipe", false, "File deletion: overwrite files with random data (security.wipe_passes times) before deleting them, after any backup")
+	cmd.Flags().BoolVar(&severityFromScenario, "severity-from-scenario", true, "Without --severity, run a --scenario-id request at the scenario's estimated severity (capped at the server maximum)")
+	cmd.Flags().BoolVar(&plan, "plan", false, "Show how many files and bytes the targets hold and ask before destroying them")
 
 	return cmd
 }
 
 func newSystemInfoCommand() *cobra.Command {
+	var format string
+
 	cmd := &cobra.Command{
 		Use:   "system-info",
 		Short: "Get system information",
-		Long:  "获取系统信息",
+		Long:  "获取系统信息；--format 可选 text、json、yaml 或 table（默认与 --output 相同，未指定时为 table）",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			formatSet := cmd.Flags().Changed("format")
+			if formatSet {
+				if err := validateFormat(format); err != nil {
+					return err
+				}
+			}
+
 			client, conn, err := createClient(cmd)
 			if err != nil {
 				return err
@@ -153,6 +386,15 @@ func newSystemInfoCommand() *cobra.Command {
 				}
 			}()
 
+			out, err := newListOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+			if formatSet {
+				out.setFormat(format)
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
 			defer cancel()
 
@@ -161,33 +403,71 @@ func newSystemInfoCommand() *cobra.Command {
 				return fmt.Errorf("failed to get system info: %w", err)
 			}
 
-			// Display system information
-			fmt.Printf("💻 System Information\n")
-			fmt.Printf("OS: %s\n", resp.Os)
-			fmt.Printf("Architecture: %s\n", resp.Architecture)
-			fmt.Printf("Hostname: %s\n", resp.Hostname)
+			if out.json {
+				return out.JSON(resp)
+			}
 
-			if resp.Resources != nil {
-				fmt.Printf("\n📊 Resources:\n")
-				fmt.Printf("  Total Memory: %d GB\n", resp.Resources.TotalMemory/(1024*1024*1024))
-				fmt.Printf("  Available Memory: %d GB\n", resp.Resources.AvailableMemory/(1024*1024*1024))
-				fmt.Printf("  Total Disk: %d GB\n", resp.Resources.TotalDisk/(1024*1024*1024))
-				fmt.Printf("  Available Disk: %d GB\n", resp.Resources.AvailableDisk/(1024*1024*1024))
-				fmt.Printf("  CPU Usage: %.2f%%\n", resp.Resources.CpuUsage)
+			if out.yaml {
+				return out.YAML(resp)
 			}
 
-			if len(resp.CriticalPaths) > 0 {
-				fmt.Printf("\n🚨 Critical Paths:\n")
-				for _, path := range resp.CriticalPaths {
-					fmt.Printf("  - %s\n", path)
-				}
+			if out.table {
+				printSystemInfoTable(out, resp)
+				return nil
 			}
 
-			if len(resp.RunningServices) > 0 {
-				fmt.Printf("\n🔧 Running Services:\n")
-				for _, service := range resp.RunningServices {
-					fmt.Printf("  - %s\n", service)
+			printSystemInfo(out, resp)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "Output format (text, json, yaml, table); overrides --output")
+
+	return cmd
+}
+
+func newServerInfoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "server-info",
+		Short: "Get server process information",
+		Long:  "获取服务器进程信息，包括运行账户和权限级别",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
 				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.GetServerInfo(ctx, &pb.GetServerInfoRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to get server info: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			printBanner(out, resp.ConnectionBanner)
+			out.Printf("🖥️  Server Information\n")
+			out.Printf("Hostname: %s\n", resp.Hostname)
+			out.Printf("OS: %s\n", resp.Os)
+			out.Printf("User: %s (%s)\n", resp.Username, resp.Uid)
+			out.Printf("Privileged: %v\n", resp.Privileged)
+			if resp.Privileged && !resp.AllowRoot {
+				out.Printf("\n⚠️  The server runs privileged without security.allow_root\n")
 			}
 
 			return nil
@@ -202,6 +482,7 @@ func newGenerateScenarioCommand() *cobra.Command {
 		target      string
 		maxSeverity string
 		aiModel     string
+		types       []string
 	)
 
 	cmd := &cobra.Command{
@@ -219,6 +500,12 @@ func newGenerateScenarioCommand() *cobra.Command {
 				}
 			}()
 
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
 			// Parse severity
 			sev, err := parseSeverity(maxSeverity)
 			if err != nil {
@@ -230,6 +517,13 @@ func newGenerateScenarioCommand() *cobra.Command {
 				MaxSeverity:       sev,
 				AiModel:           aiModel,
 			}
+			for _, typeStr := range types {
+				dtype, err := parseDestructionType(typeStr)
+				if err != nil {
+					return err
+				}
+				req.AllowedTypes = append(req.AllowedTypes, dtype)
+			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
 			defer cancel()
@@ -245,25 +539,15 @@ func newGenerateScenarioCommand() *cobra.Command {
 				return fmt.Errorf("scenario generation failed: %w", err)
 			}
 
-			// Display scenario
-			fmt.Printf("🤖 AI Generated Attack Scenario\n")
-			fmt.Printf("ID: %s\n", resp.ScenarioId)
-			fmt.Printf("Description: %s\n", resp.Description)
-			fmt.Printf("Estimated Severity: %s\n", resp.EstimatedSeverity.String())
-			fmt.Printf("\n📋 Steps:\n")
-
-			for _, step := range resp.Steps {
-				fmt.Printf("\n%d. %s\n", step.Order, step.Description)
-				fmt.Printf("   Type: %s\n", step.Type.String())
-				if len(step.Targets) > 0 {
-					fmt.Printf("   Targets: %s\n", strings.Join(step.Targets, ", "))
-				}
-				if step.Rationale != "" {
-					fmt.Printf("   Rationale: %s\n", step.Rationale)
-				}
+			if out.json {
+				return out.JSON(resp)
 			}
 
-			fmt.Printf("\n💡 Use scenario ID '%s' with the execute command\n", resp.ScenarioId)
+			// Display scenario
+			out.Printf("🤖 AI Generated Attack Scenario\n")
+			printScenario(out, resp)
+
+			out.Printf("\n💡 Use scenario ID '%s' with the execute command\n", resp.ScenarioId)
 
 			return nil
 		},
@@ -272,6 +556,7 @@ func newGenerateScenarioCommand() *cobra.Command {
 	cmd.Flags().StringVar(&target, "target", "", "Target description (required)")
 	cmd.Flags().StringVar(&maxSeverity, "max-severity", "MEDIUM", "Maximum severity (LOW, MEDIUM, HIGH, CRITICAL)")
 	cmd.Flags().StringVar(&aiModel, "model", "", "AI model to use")
+	cmd.Flags().StringSliceVar(&types, "types", []string{}, "Destruction types the scenario may use (default: all enabled on the server)")
 
 	if err := cmd.MarkFlagRequired("target"); err != nil {
 		logrus.WithError(err).Error("Failed to mark target flag as required")
@@ -282,11 +567,33 @@ func newGenerateScenarioCommand() *cobra.Command {
 
 func newStreamCommand() *cobra.Command {
 	var (
-		destructionType string
-		targets         []string
-		severity        string
-		confirm         bool
-		scenarioID      string
+		destructionType      string
+		targets              []string
+		severity             string
+		confirm              bool
+		confirmPhrase        string
+		scenarioID           string
+		dryRun               bool
+		maxOps               float64
+		maxBytes             int64
+		recursive            bool
+		duration             time.Duration
+		intensity            int32
+		windowOverride       string
+		fileDescriptors      bool
+		yesIKnow             bool
+		quarantine           bool
+		autoRestore          time.Duration
+		failurePolicy        string
+		skipPreflight        bool
+		targetFile           string
+		severityFromScenario bool
+		include              []string
+		exclude              []string
+		olderThan            time.Duration
+		maxSize              int64
+		wipe                 bool
+		plan                 bool
 	)
 
 	cmd := &cobra.Command{
@@ -294,10 +601,15 @@ func newStreamCommand() *cobra.Command {
 		Short: "Stream destruction progress",
 		Long:  "实时流式监控破坏进度",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !confirm {
+			if !confirm && !dryRun {
 				return fmt.Errorf("必须使用 --confirm 标志确认破坏性操作")
 			}
 
+			targets, err := withTargetFile(targets, targetFile)
+			if err != nil {
+				return err
+			}
+
 			client, conn, err := createClient(cmd)
 			if err != nil {
 				return err
@@ -308,14 +620,25 @@ func newStreamCommand() *cobra.Command {
 				}
 			}()
 
-			// Parse destruction type
-			dtype, err := parseDestructionType(destructionType)
+			out, err := newOutput(cmd)
 			if err != nil {
 				return err
 			}
+			defer out.Close()
 
-			// Parse severity
-			sev, err := parseSeverity(severity)
+			// Parse destruction type; a stored scenario brings its own
+			dtype, err := scenarioDestructionType(destructionType, scenarioID)
+			if err != nil {
+				return err
+			}
+
+			// Parse severity; a scenario may bring its own
+			sev, err := scenarioSeverity(severity, scenarioID, cmd.Flags().Changed("severity"), severityFromScenario)
+			if err != nil {
+				return err
+			}
+
+			policy, err := parseFailurePolicy(failurePolicy)
 			if err != nil {
 				return err
 			}
@@ -325,7 +648,43 @@ func newStreamCommand() *cobra.Command {
 				Targets:            targets,
 				Severity:           sev,
 				ConfirmDestruction: confirm,
+				ConfirmationText:   confirmPhrase,
 				AiScenarioId:       scenarioID,
+				DryRun:             dryRun,
+				MaxOpsPerSecond:    maxOps,
+				MaxBytesPerSecond:  maxBytes,
+				Recursive:          recursive,
+				Duration:           durationpb.New(duration),
+				Intensity:          intensity,
+				FileDescriptors:    fileDescriptors,
+				Quarantine:         quarantine,
+				AutoRestoreAfter:   durationpb.New(autoRestore),
+				FailurePolicy:      policy,
+				SkipPreflight:      skipPreflight,
+				IncludePatterns:    include,
+				ExcludePatterns:    exclude,
+				MinAge:             durationpb.New(olderThan),
+				MaxFileSize:        maxSize,
+				Wipe:               wipe,
+			}
+			req.WindowOverrideToken = windowOverride
+
+			bannerCtx, cancelBanner := context.WithTimeout(context.Background(), getTimeout(cmd))
+			err = showBanner(bannerCtx, cmd, client)
+			cancelBanner()
+			if err != nil {
+				return err
+			}
+
+			if plan {
+				if err := confirmPlan(cmd, client, dtype, targets, dryRun); err != nil {
+					return err
+				}
+			}
+
+			req.AcknowledgeIrreversible, err = acknowledgeIrreversible(cmd, client, dtype, dryRun, yesIKnow)
+			if err != nil {
+				return err
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
@@ -339,95 +698,1112 @@ func newStreamCommand() *cobra.Command {
 			}
 
 			// Stream events
+			var taskID string
+			var failed []string
+			succeeded := true
 			for {
 				event, err := stream.Recv()
 				if err != nil {
 					break
 				}
-
-				timestamp := event.Timestamp.AsTime().Format("15:04:05")
+				if event.TaskId != "" {
+					taskID = event.TaskId
+				}
+				// Every task ends with one of these, so a scenario reports
+				// the failures of each step
 				switch event.Type {
-				case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED:
-					fmt.Printf("[%s] 🚀 Started: %s\n", timestamp, event.Message)
-				case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS:
-					fmt.Printf("[%s] ⏳ Progress: %.1f%% - %s\n", timestamp, event.Progress*100, event.Message)
+				case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR, pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED:
+					succeeded = false
+					failed = append(failed, event.FailedTargets...)
 				case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED:
-					fmt.Printf("[%s] ✅ Completed: %s\n", timestamp, event.Message)
-				case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR:
-					fmt.Printf("[%s] ❌ Error: %s\n", timestamp, event.Message)
-				case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_WARNING:
-					fmt.Printf("[%s] ⚠️  Warning: %s\n", timestamp, event.Message)
+					failed = append(failed, event.FailedTargets...)
+				}
+
+				if out.json {
+					if err := out.JSONLine(event); err != nil {
+						return err
+					}
+					continue
+				}
+				if line := formatEvent(event); line != "" {
+					out.Printf("[%s] %s\n", event.Timestamp.AsTime().Format("15:04:05"), line)
 				}
 			}
 
-			return nil
+			if taskID != "" && !out.json {
+				out.Printf("\nTask ID: %s\n", taskID)
+			}
+			if len(failed) > 0 && !out.json {
+				out.Printf("Failed targets: %s\n", strings.Join(failed, ", "))
+			}
+
+			return incompleteRunError(succeeded, failed)
 		},
 	}
 
-	cmd.Flags().StringVar(&destructionType, "type", "", "Destruction type (required)")
-	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Target paths")
+	cmd.Flags().StringVar(&destructionType, "type", "", "Destruction type (required unless --scenario-id runs a stored scenario)")
+	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Target paths or glob patterns")
+	cmd.Flags().StringVar(&targetFile, "target-file", "", "File of newline-separated targets to add to --targets (blank lines and # comments are ignored)")
 	cmd.Flags().StringVar(&severity, "severity", "LOW", "Destruction severity")
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive operation")
-	cmd.Flags().StringVar(&scenarioID, "scenario-id", "", "AI scenario ID")
-
-	if err := cmd.MarkFlagRequired("type"); err != nil {
-		logrus.WithError(err).Error("Failed to mark type flag as required")
-	}
+	cmd.Flags().StringVar(&confirmPhrase, "confirm-phrase", "", "Confirmation phrase the server requires for high-severity requests")
+	cmd.Flags().StringVar(&scenarioID, "scenario-id", "", "AI scenario ID; without --type and --targets the server runs every step of the stored scenario")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the destruction without changing anything")
+	cmd.Flags().Float64Var(&maxOps, "max-ops-per-second", 0, "Delete at most this many files per second (0 uses the server default)")
+	cmd.Flags().Int64Var(&maxBytes, "max-bytes-per-second", 0, "Delete at most this many bytes per second (0 uses the server default)")
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "Delete every file under directory targets, one by one")
+	cmd.Flags().DurationVar(&duration, "duration", 0, "How long a CPU burn runs, an inode exhaustion holds or a network disruption cuts targets off (0 uses the server default)")
+	cmd.Flags().Int32Var(&intensity, "intensity", 0, "How hard a resource-pressure type pushes, 1-100: percent of memory, disk, inodes or cores consumed, or of packets dropped (0 uses the severity)")
+	cmd.Flags().StringVar(&windowOverride, "window-override-token", "", "Run outside the server's allowed windows with its security.window_override_token; every override is audited")
+	cmd.Flags().BoolVar(&fileDescriptors, "file-descriptors", false, "Make inode exhaustion hold open file descriptors instead of creating files")
+	cmd.Flags().BoolVar(&yesIKnow, "yes-i-know", false, "Acknowledge irreversible types (KERNEL_PANIC, BOOT_CORRUPTION) without typing the server hostname")
+	cmd.Flags().BoolVar(&quarantine, "quarantine", false, "Move file deletion targets into the server's quarantine directory instead of deleting them")
+	cmd.Flags().DurationVar(&autoRestore, "auto-restore-after", 0, "Restore backed-up files or restart stopped services automatically after this hold (e.g. 10m)")
+	cmd.Flags().StringVar(&failurePolicy, "failure-policy", "continue", "What a failed target does to the rest of the run (continue, fail-fast)")
+	cmd.Flags().BoolVar(&skipPreflight, "skip-preflight", false, "Don't check that file deletion targets exist and can be removed before running")
+	cmd.Flags().StringSliceVar(&include, "include", []string{}, "File deletion: only delete files matching one of these globs (matched against the file name, or the path when the glob has a separator)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", []string{}, "File deletion: never delete files matching one of these globs (e.g. .keep,*.conf)")
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "File deletion: only delete files last modified at least this long ago")
+	cmd.Flags().Int64Var(&maxSize, "max-size", 0, "File deletion: only delete files of at most this many bytes (0 for no limit)")
+	cmd.Flags().BoolVar(&wipe, "wipe", false, "File deletion: overwrite files with random data (security.wipe_passes times) before deleting them, after any backup")
+	cmd.Flags().BoolVar(&severityFromScenario, "severity-from-scenario", true, "Without --severity, run a --scenario-id request at the scenario's estimated severity (capped at the server maximum)")
+	cmd.Flags().BoolVar(&plan, "plan", false, "Show how many files and bytes the targets hold and ask before destroying them")
 
 	return cmd
 }
 
-// Helper functions
-func createClient(cmd *cobra.Command) (pb.BurnDeviceServiceClient, *grpc.ClientConn, error) {
-	serverAddr, _ := cmd.Flags().GetString("server")
+func newWatchCommand() *cobra.Command {
+	var taskID string
 
-	// Use the new grpc.NewClient instead of deprecated grpc.Dial
-	conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to server: %w", err)
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch task events live",
+		Long:  "实时查看服务器上所有任务（包括非流式执行）的事件，按 Ctrl+C 退出",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			// Watch until interrupted unless a timeout is asked for
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if cmd.Flags().Changed("timeout") {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, getTimeout(cmd))
+				defer cancel()
+			}
+
+			stream, err := client.SubscribeEvents(ctx, &pb.SubscribeEventsRequest{TaskId: taskID})
+			if err != nil {
+				return fmt.Errorf("failed to subscribe to events: %w", err)
+			}
+
+			logrus.Info("👀 Watching task events...")
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					if ctx.Err() != nil || errors.Is(err, io.EOF) {
+						return nil
+					}
+					return fmt.Errorf("event stream failed: %w", err)
+				}
+
+				if out.json {
+					if err := out.JSONLine(event); err != nil {
+						return err
+					}
+					continue
+				}
+				if line := formatEvent(event); line != "" {
+					out.Printf("[%s] %s %s\n", event.Timestamp.AsTime().Format("15:04:05"), event.TaskId, line)
+				}
+			}
+		},
 	}
 
-	client := pb.NewBurnDeviceServiceClient(conn)
-	return client, conn, nil
+	cmd.Flags().StringVar(&taskID, "task-id", "", "Only show events of this task")
+
+	return cmd
 }
 
-func getTimeout(cmd *cobra.Command) time.Duration {
-	timeout, _ := cmd.Flags().GetDuration("timeout")
-	return timeout
+// summarizeResults counts results by outcome, e.g. "2 succeeded, 1
+// failed, 1 skipped"
+func summarizeResults(results []*pb.DestructionResult) string {
+	var succeeded, failed, skipped int
+	for _, result := range results {
+		switch {
+		case result.Success:
+			succeeded++
+		case result.Skipped:
+			skipped++
+		default:
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d succeeded, %d failed, %d skipped", succeeded, failed, skipped)
 }
 
-func parseDestructionType(typeStr string) (pb.DestructionType, error) {
-	switch strings.ToUpper(typeStr) {
-	case "FILE_DELETION":
-		return pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, nil
-	case "SERVICE_TERMINATION":
-		return pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, nil
-	case "MEMORY_EXHAUSTION":
-		return pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION, nil
-	case "DISK_FILL":
-		return pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL, nil
-	case "NETWORK_DISRUPTION":
-		return pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION, nil
-	case "BOOT_CORRUPTION":
-		return pb.DestructionType_DESTRUCTION_TYPE_BOOT_CORRUPTION, nil
-	case "KERNEL_PANIC":
-		return pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC, nil
-	default:
-		return pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED, fmt.Errorf("unknown destruction type: %s", typeStr)
+// incompleteRunError is the error a destruction command exits with when
+// the run did not fully succeed, or nil when it did. A run the server
+// counts as successful under engine.success_policy "any" still fails the
+// command if a target failed.
+func incompleteRunError(success bool, failed []string) error {
+	switch {
+	case len(failed) > 0:
+		return fmt.Errorf("destruction did not fully succeed: %d targets failed: %s", len(failed), strings.Join(failed, ", "))
+	case !success:
+		return fmt.Errorf("destruction did not succeed")
 	}
+	return nil
 }
 
-func parseSeverity(severityStr string) (pb.DestructionSeverity, error) {
-	switch strings.ToUpper(severityStr) {
-	case "LOW":
-		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, nil
-	case "MEDIUM":
-		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, nil
-	case "HIGH":
-		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH, nil
-	case "CRITICAL":
-		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, nil
+// formatEvent describes a task event on one line, or returns "" for event
+// types the client doesn't know
+func formatEvent(event *pb.StreamDestructionResponse) string {
+	switch event.Type {
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED:
+		return fmt.Sprintf("🚀 Started: %s (task %s)", event.Message, event.TaskId)
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS:
+		return fmt.Sprintf("⏳ Progress: %.1f%% - %s", event.Progress*100, event.Message)
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED:
+		return fmt.Sprintf("✅ Completed: %s", event.Message)
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR:
+		return fmt.Sprintf("❌ Error: %s", event.Message)
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_WARNING:
+		return fmt.Sprintf("⚠️  Warning: %s", event.Message)
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED:
+		return fmt.Sprintf("🛑 Cancelled: %s", event.Message)
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_STARTED:
+		return fmt.Sprintf("▶️  Step %d: %s", event.Step, event.Message)
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_FINISHED:
+		return fmt.Sprintf("⏹️  Step %d: %s", event.Step, event.Message)
 	default:
-		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED, fmt.Errorf("unknown severity: %s", severityStr)
+		return ""
+	}
+}
+
+func newRestoreCommand() *cobra.Command {
+	var (
+		taskID       string
+		targets      []string
+		force        bool
+		removeBackup bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore destroyed targets from backups",
+		Long:  "从备份恢复被破坏的目标",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if taskID == "" && len(targets) == 0 {
+				return fmt.Errorf("必须指定 --task-id 或 --targets")
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			req := &pb.RestoreDestructionRequest{
+				TaskId:       taskID,
+				Targets:      targets,
+				Force:        force,
+				RemoveBackup: removeBackup,
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.RestoreDestruction(ctx, req)
+			if err != nil {
+				return fmt.Errorf("restore failed: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			// Display results
+			out.Printf("♻️  Restore completed: %s\n", resp.Message)
+			out.Printf("Success: %v\n", resp.Success)
+
+			for i, result := range resp.Results {
+				out.Printf("\nResult %d:\n", i+1)
+				out.Printf("  Target: %s\n", result.Target)
+				out.Printf("  Backup: %s\n", result.BackupPath)
+				out.Printf("  Success: %v\n", result.Success)
+				if result.ErrorMessage != "" {
+					out.Printf("  Error: %s\n", result.ErrorMessage)
+				}
+				out.Printf("  Bytes restored: %d\n", result.BytesRestored)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&taskID, "task-id", "", "Restore every target destroyed by this task")
+	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Original target paths to restore")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite targets that already exist")
+	cmd.Flags().BoolVar(&removeBackup, "remove-backup", false, "Remove backups after a successful restore")
+
+	return cmd
+}
+
+func newVerifyBackupCommand() *cobra.Command {
+	var (
+		taskID  string
+		targets []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify-backup",
+		Short: "Check backups against their checksums",
+		Long:  "校验备份文件与备份时记录的 SHA-256 是否一致，不恢复任何内容",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if taskID == "" && len(targets) == 0 {
+				return fmt.Errorf("必须指定 --task-id 或 --targets")
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.VerifyBackup(ctx, &pb.VerifyBackupRequest{
+				TaskId:  taskID,
+				Targets: targets,
+			})
+			if err != nil {
+				return fmt.Errorf("verification failed: %w", err)
+			}
+
+			if out.json {
+				if err := out.JSON(resp); err != nil {
+					return err
+				}
+			} else {
+				out.Printf("🔍 %s\n", resp.Message)
+				for _, result := range resp.Results {
+					if result.Success {
+						out.Printf("  ✅ %s: %d files verified (%s)\n", result.Target, result.FilesVerified, result.BackupPath)
+					} else {
+						out.Printf("  ❌ %s: %s\n", result.Target, result.ErrorMessage)
+					}
+				}
+			}
+
+			if !resp.Success {
+				return fmt.Errorf("backup verification failed: %s", resp.Message)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&taskID, "task-id", "", "Verify every backup taken by this task")
+	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Original target paths whose backups to verify")
+
+	return cmd
+}
+
+func newCleanupBackupsCommand() *cobra.Command {
+	var (
+		dir       string
+		olderThan time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cleanup-backups",
+		Short: "Remove old backups left next to targets",
+		Long:  "删除允许目标目录中超过指定时间的 .burndevice.backup 备份文件及其校验和，不会触碰其他文件",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				return fmt.Errorf("必须指定 --dir")
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.CleanupBackups(ctx, &pb.CleanupBackupsRequest{
+				Dir:       dir,
+				OlderThan: durationpb.New(olderThan),
+			})
+			if err != nil {
+				return fmt.Errorf("cleanup failed: %w", err)
+			}
+
+			if out.json {
+				if err := out.JSON(resp); err != nil {
+					return err
+				}
+			} else {
+				out.Printf("🧹 %s\n", resp.Message)
+				for _, path := range resp.Removed {
+					out.Printf("  🗑️  %s\n", path)
+				}
+			}
+
+			if !resp.Success {
+				return fmt.Errorf("backup cleanup failed: %s", resp.Message)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory to search for backups; it must be an allowed target")
+	cmd.Flags().DurationVar(&olderThan, "older-than", 24*time.Hour, "Only remove backups last modified longer ago than this")
+
+	return cmd
+}
+
+func newTaskCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task",
+		Short: "Inspect and control running tasks",
+		Long:  "查看和控制正在运行的任务",
+	}
+
+	cmd.AddCommand(
+		newTaskStatusCommand(),
+		newTaskCancelCommand(),
+	)
+
+	return cmd
+}
+
+func newTaskStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <task-id>",
+		Short: "Show the status of a running task",
+		Long:  "显示正在运行任务的状态",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.GetTaskStatus(ctx, &pb.GetTaskStatusRequest{TaskId: args[0]})
+			if err != nil {
+				return fmt.Errorf("failed to get task status: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			printTaskStatus(out, resp.Task)
+			return nil
+		},
+	}
+}
+
+func newTaskCancelCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <task-id>",
+		Short: "Cancel a running task",
+		Long:  "取消正在运行的任务",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.CancelDestruction(ctx, &pb.CancelDestructionRequest{TaskId: args[0]})
+			if err != nil {
+				return fmt.Errorf("failed to cancel task: %w", err)
+			}
+
+			if !resp.Found {
+				return fmt.Errorf("%s", resp.Message)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			out.Printf("🛑 %s\n", resp.Message)
+			printTaskStatus(out, resp.Task)
+			return nil
+		},
+	}
+}
+
+func newTasksCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tasks",
+		Short: "List and inspect running tasks",
+		Long:  "列出并查看正在运行的任务",
+		RunE:  listTasks,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "List running tasks",
+			Long:  "列出正在运行的任务",
+			Args:  cobra.NoArgs,
+			RunE:  listTasks,
+		},
+		&cobra.Command{
+			Use:   "get <task-id>",
+			Short: "Show a running task and its results so far",
+			Long:  "显示正在运行任务的详情及已完成目标的结果",
+			Args:  cobra.ExactArgs(1),
+			RunE:  getTask,
+		},
+	)
+
+	return cmd
+}
+
+// listTasks prints every running task as a table
+func listTasks(cmd *cobra.Command, args []string) error {
+	client, conn, err := createClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close connection")
+		}
+	}()
+
+	out, err := newListOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.ListTasks(ctx, &pb.ListTasksRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	if out.json {
+		return out.JSON(resp)
+	}
+
+	if len(resp.Tasks) == 0 {
+		out.Println("No running tasks")
+		return nil
+	}
+
+	if !out.table {
+		for i, task := range resp.Tasks {
+			if i > 0 {
+				out.Println()
+			}
+			printTaskStatus(out, task)
+		}
+		return nil
+	}
+
+	printTaskTable(out, resp.Tasks)
+	return nil
+}
+
+// getTask prints a single task including its per-target results
+func getTask(cmd *cobra.Command, args []string) error {
+	client, conn, err := createClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close connection")
+		}
+	}()
+
+	out, err := newOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.GetTaskStatus(ctx, &pb.GetTaskStatusRequest{TaskId: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to get task status: %w", err)
+	}
+
+	if out.json {
+		return out.JSON(resp)
+	}
+
+	printTaskStatus(out, resp.Task)
+	return nil
+}
+
+func newExpandCommand() *cobra.Command {
+	var (
+		root  string
+		limit int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "expand <pattern>",
+		Short: "Preview which paths a target pattern matches",
+		Long:  "预览目标模式在服务器上匹配到的路径及其策略状态，不执行任何操作",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newListOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.ExpandTargets(ctx, &pb.ExpandTargetsRequest{
+				Pattern: args[0],
+				Root:    root,
+				Limit:   limit,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to expand targets: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			if len(resp.Matches) == 0 {
+				out.Println("No matches")
+				return nil
+			}
+
+			printMatchTable(out, resp.Matches)
+			if resp.Truncated && int(resp.TotalMatches) > len(resp.Matches) {
+				out.Printf("\n⚠️  Showing %d of %d matches\n", len(resp.Matches), resp.TotalMatches)
+			} else if resp.Truncated {
+				out.Printf("\n⚠️  Showing the first %d matches\n", len(resp.Matches))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&root, "root", "", "Treat the pattern as a regular expression matched under this directory")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Maximum number of matches to show (server caps this)")
+
+	return cmd
+}
+
+func newHistoryCommand() *cobra.Command {
+	var (
+		destructionType string
+		since           time.Duration
+		pageSize        int32
+		pageToken       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List finished tasks",
+		Long:  "列出已完成的任务历史（最新的在前）",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &pb.GetTaskHistoryRequest{
+				PageSize:  pageSize,
+				PageToken: pageToken,
+			}
+			if destructionType != "" {
+				dt, err := parseDestructionType(destructionType)
+				if err != nil {
+					return err
+				}
+				req.Type = dt
+			}
+			if since > 0 {
+				req.Since = timestamppb.New(time.Now().Add(-since))
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newListOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.GetTaskHistory(ctx, req)
+			if err != nil {
+				return fmt.Errorf("failed to get task history: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			if len(resp.Tasks) == 0 {
+				out.Println("No task history")
+				return nil
+			}
+
+			if out.table {
+				printHistoryTable(out, resp.Tasks)
+			} else {
+				for i, record := range resp.Tasks {
+					if i > 0 {
+						out.Println()
+					}
+					printTaskRecord(out, record)
+				}
+			}
+			if resp.NextPageToken != "" {
+				out.Printf("\n%d of %d tasks shown; next page: --page-token %s\n", len(resp.Tasks), resp.Total, resp.NextPageToken)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&destructionType, "type", "", "Only show tasks of this destruction type")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show tasks started within this duration (e.g. 24h)")
+	cmd.Flags().Int32Var(&pageSize, "page-size", 0, "Maximum number of tasks to show (server caps this)")
+	cmd.Flags().StringVar(&pageToken, "page-token", "", "Token printed by a previous page")
+
+	return cmd
+}
+
+// printSystemInfo writes system information as labelled lines
+func printSystemInfo(out *output, resp *pb.GetSystemInfoResponse) {
+	out.Printf("💻 System Information\n")
+	out.Printf("OS: %s\n", resp.Os)
+	out.Printf("Architecture: %s\n", resp.Architecture)
+	out.Printf("Hostname: %s\n", resp.Hostname)
+
+	if resp.Resources != nil {
+		out.Printf("\n📊 Resources:\n")
+		out.Printf("  Total Memory: %d GB\n", resp.Resources.TotalMemory/(1024*1024*1024))
+		out.Printf("  Available Memory: %d GB\n", resp.Resources.AvailableMemory/(1024*1024*1024))
+		out.Printf("  Total Disk: %d GB\n", resp.Resources.TotalDisk/(1024*1024*1024))
+		out.Printf("  Available Disk: %d GB\n", resp.Resources.AvailableDisk/(1024*1024*1024))
+		out.Printf("  CPU Usage: %.2f%%\n", resp.Resources.CpuUsage)
+	}
+
+	if len(resp.PathDisks) > 0 {
+		out.Printf("\n💾 Disk Usage by Path:\n")
+		for _, disk := range resp.PathDisks {
+			out.Printf("  %s: %d GB available of %d GB\n", disk.Path, disk.AvailableDisk/(1024*1024*1024), disk.TotalDisk/(1024*1024*1024))
+		}
+	}
+
+	if len(resp.CriticalPaths) > 0 {
+		out.Printf("\n🚨 Critical Paths:\n")
+		for _, path := range resp.CriticalPaths {
+			out.Printf("  - %s\n", path)
+		}
+	}
+
+	if len(resp.RunningServices) > 0 {
+		out.Printf("\n🔧 Running Services:\n")
+		for _, service := range resp.RunningServices {
+			out.Printf("  - %s\n", service)
+		}
+	}
+}
+
+// printSystemInfoTable writes system information as aligned tables, sizes
+// in GB
+func printSystemInfoTable(w io.Writer, resp *pb.GetSystemInfoResponse) {
+	host := newTable("HOSTNAME", "OS", "ARCHITECTURE", "CPU USAGE").alignRight(3)
+	cpu := "-"
+	if resp.Resources != nil {
+		cpu = fmt.Sprintf("%.2f%%", resp.Resources.CpuUsage)
+	}
+	host.addRow(resp.Hostname, resp.Os, resp.Architecture, cpu)
+	host.write(w)
+
+	space := newTable("RESOURCE", "TOTAL GB", "AVAILABLE GB").alignRight(1, 2)
+	if resp.Resources != nil {
+		space.addRow("memory", gigabytes(resp.Resources.TotalMemory), gigabytes(resp.Resources.AvailableMemory))
+		space.addRow("disk", gigabytes(resp.Resources.TotalDisk), gigabytes(resp.Resources.AvailableDisk))
+	}
+	for _, disk := range resp.PathDisks {
+		space.addRow(disk.Path, gigabytes(disk.TotalDisk), gigabytes(disk.AvailableDisk))
+	}
+	if len(space.rows) > 0 {
+		_, _ = fmt.Fprintln(w)
+		space.write(w)
+	}
+
+	if len(resp.CriticalPaths) > 0 {
+		paths := newTable("CRITICAL PATH")
+		for _, path := range resp.CriticalPaths {
+			paths.addRow(path)
+		}
+		_, _ = fmt.Fprintln(w)
+		paths.write(w)
+	}
+
+	if len(resp.RunningServices) > 0 {
+		services := newTable("RUNNING SERVICE")
+		for _, service := range resp.RunningServices {
+			services.addRow(service)
+		}
+		_, _ = fmt.Fprintln(w)
+		services.write(w)
+	}
+}
+
+// gigabytes formats a byte count in whole GB
+func gigabytes(bytes int64) string {
+	return strconv.FormatInt(bytes/(1024*1024*1024), 10)
+}
+
+// printMatchTable writes expanded targets with their policy status
+func printMatchTable(w io.Writer, matches []*pb.TargetMatch) {
+	t := newTable("PATH", "KIND", "SIZE", "POLICY").alignRight(2)
+	for _, match := range matches {
+		kind := "file"
+		if match.IsDir {
+			kind = "dir"
+		}
+
+		policy := "allowed"
+		switch {
+		case match.Blocked:
+			policy = "blocked"
+		case !match.Allowed:
+			policy = "not allowed"
+		}
+
+		t.addRow(match.Path, kind, strconv.FormatInt(match.Size, 10), policy)
+	}
+	t.write(w)
+}
+
+// printTaskTable writes tasks as an aligned table
+func printTaskTable(w io.Writer, tasks []*pb.TaskStatus) {
+	t := newTable("ID", "TYPE", "SEVERITY", "STATE", "PROGRESS", "TARGETS").alignRight(4)
+	for _, task := range tasks {
+		t.addRow(
+			task.TaskId,
+			strings.TrimPrefix(task.Type.String(), "DESTRUCTION_TYPE_"),
+			strings.TrimPrefix(task.Severity.String(), "DESTRUCTION_SEVERITY_"),
+			task.State,
+			fmt.Sprintf("%.1f%%", task.Progress*100),
+			strings.Join(task.Targets, ","))
+	}
+	t.write(w)
+}
+
+// printHistoryTable writes finished tasks as an aligned table
+func printHistoryTable(w io.Writer, records []*pb.TaskRecord) {
+	t := newTable("ID", "TYPE", "SEVERITY", "STATE", "STARTED", "DURATION", "TARGETS").alignRight(5)
+	for _, record := range records {
+		started := record.StartedAt.AsTime()
+		t.addRow(
+			record.TaskId,
+			strings.TrimPrefix(record.Type.String(), "DESTRUCTION_TYPE_"),
+			strings.TrimPrefix(record.Severity.String(), "DESTRUCTION_SEVERITY_"),
+			record.State,
+			started.Local().Format(time.RFC3339),
+			record.FinishedAt.AsTime().Sub(started).Round(time.Millisecond).String(),
+			strings.Join(record.Targets, ","))
+	}
+	t.write(w)
+}
+
+// printTaskRecord writes a finished task as labelled lines
+func printTaskRecord(out *output, record *pb.TaskRecord) {
+	started := record.StartedAt.AsTime()
+	out.Printf("📋 Task %s\n", record.TaskId)
+	if record.Phase != "" {
+		out.Printf("  Phase: %s\n", record.Phase)
+	}
+	out.Printf("  Type: %s\n", record.Type.String())
+	out.Printf("  Severity: %s\n", record.Severity.String())
+	out.Printf("  State: %s\n", record.State)
+	out.Printf("  Targets: %s\n", strings.Join(record.Targets, ", "))
+	out.Printf("  Started: %s\n", started.Local().Format(time.RFC3339))
+	out.Printf("  Duration: %s\n", record.FinishedAt.AsTime().Sub(started).Round(time.Millisecond))
+	if record.AutoRestoreAt != nil {
+		out.Printf("  Auto restore: %s\n", record.AutoRestoreAt.AsTime().Local().Format(time.RFC3339))
+	}
+	if record.IdempotencyKey != "" {
+		out.Printf("  Idempotency key: %s\n", record.IdempotencyKey)
+	}
+	if record.Message != "" {
+		out.Printf("  Message: %s\n", record.Message)
+	}
+}
+
+func printTaskStatus(out *output, task *pb.TaskStatus) {
+	out.Printf("📋 Task %s\n", task.TaskId)
+	out.Printf("  Type: %s\n", task.Type.String())
+	out.Printf("  Severity: %s\n", task.Severity.String())
+	out.Printf("  State: %s\n", task.State)
+	out.Printf("  Progress: %.1f%%\n", task.Progress*100)
+	if task.CurrentTarget != "" {
+		out.Printf("  Current target: %s\n", task.CurrentTarget)
+	}
+	out.Printf("  Targets: %s\n", strings.Join(task.Targets, ", "))
+	if task.StartedAt != nil {
+		out.Printf("  Started: %s\n", task.StartedAt.AsTime().Format(time.RFC3339))
+	}
+	out.Printf("  Processed: %d of %d targets\n", task.TargetsProcessed, len(task.Targets))
+
+	for _, result := range task.Results {
+		status := "✅"
+		if !result.Success {
+			status = "❌"
+		}
+		out.Printf("  %s %s\n", status, result.Target)
+		if result.ErrorMessage != "" {
+			out.Printf("     Error: %s\n", result.ErrorMessage)
+		}
+		if result.Metrics != nil && result.Metrics.FilesDeleted > 0 {
+			out.Printf("     Files deleted: %d (%d bytes)\n", result.Metrics.FilesDeleted, result.Metrics.BytesDestroyed)
+		}
+	}
+}
+
+// Helper functions
+func createClient(cmd *cobra.Command) (pb.BurnDeviceServiceClient, *grpc.ClientConn, error) {
+	serverAddr, _ := cmd.Flags().GetString("server")
+	token, _ := cmd.Flags().GetString("token")
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(token)))
+	}
+
+	// Use the new grpc.NewClient instead of deprecated grpc.Dial
+	conn, err := grpc.NewClient(serverAddr, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to server: %w", err)
+	}
+
+	client := pb.NewBurnDeviceServiceClient(conn)
+	return client, conn, nil
+}
+
+// tokenCredentials attaches the API token to every call as a bearer token
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity is false because the client doesn't dial TLS yet
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func getTimeout(cmd *cobra.Command) time.Duration {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	return timeout
+}
+
+// scenarioDestructionType parses --type, which may be left out when
+// --scenario-id asks the server to run a stored scenario
+func scenarioDestructionType(typeStr, scenarioID string) (pb.DestructionType, error) {
+	if typeStr == "" && scenarioID != "" {
+		return pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED, nil
+	}
+	if typeStr == "" {
+		return pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED, fmt.Errorf("--type is required unless --scenario-id names a stored scenario")
+	}
+	return parseDestructionType(typeStr)
+}
+
+// scenarioSeverity parses --severity. A request for a scenario that
+// doesn't set --severity leaves it unspecified when fromScenario is on, so
+// the server runs it at the scenario's estimate.
+func scenarioSeverity(severityStr, scenarioID string, explicit, fromScenario bool) (pb.DestructionSeverity, error) {
+	if scenarioID != "" && fromScenario && !explicit {
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED, nil
+	}
+	return parseSeverity(severityStr)
+}
+
+func parseDestructionType(typeStr string) (pb.DestructionType, error) {
+	switch strings.ToUpper(typeStr) {
+	case "FILE_DELETION":
+		return pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, nil
+	case "SERVICE_TERMINATION":
+		return pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, nil
+	case "MEMORY_EXHAUSTION":
+		return pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION, nil
+	case "DISK_FILL":
+		return pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL, nil
+	case "NETWORK_DISRUPTION":
+		return pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION, nil
+	case "BOOT_CORRUPTION":
+		return pb.DestructionType_DESTRUCTION_TYPE_BOOT_CORRUPTION, nil
+	case "KERNEL_PANIC":
+		return pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC, nil
+	case "FILE_CORRUPTION":
+		return pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION, nil
+	case "CPU_BURN":
+		return pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, nil
+	case "INODE_EXHAUSTION":
+		return pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION, nil
+	default:
+		return pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED, fmt.Errorf("unknown destruction type: %s", typeStr)
+	}
+}
+
+func parseSeverity(severityStr string) (pb.DestructionSeverity, error) {
+	switch strings.ToUpper(severityStr) {
+	case "LOW":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, nil
+	case "MEDIUM":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, nil
+	case "HIGH":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH, nil
+	case "CRITICAL":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, nil
+	default:
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED, fmt.Errorf("unknown severity: %s", severityStr)
+	}
+}
+
+// withTargetFile returns targets followed by the targets listed in path,
+// one per line. Blank lines and lines starting with # are skipped.
+func withTargetFile(targets []string, path string) ([]string, error) {
+	if path == "" {
+		return targets, nil
+	}
+
+	// #nosec G304 - The operator names the file on the command line
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("target file does not exist: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read target file: %w", err)
+	}
+
+	merged := append([]string{}, targets...)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		merged = append(merged, line)
+	}
+	return merged, nil
+}
+
+func parseFailurePolicy(policyStr string) (pb.FailurePolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(policyStr)) {
+	case "", "continue":
+		return pb.FailurePolicy_FAILURE_POLICY_CONTINUE, nil
+	case "fail-fast", "fail_fast":
+		return pb.FailurePolicy_FAILURE_POLICY_FAIL_FAST, nil
+	default:
+		return pb.FailurePolicy_FAILURE_POLICY_UNSPECIFIED, fmt.Errorf("unknown failure policy: %s", policyStr)
 	}
 }
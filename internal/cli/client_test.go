@@ -2,12 +2,16 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestNewClientCommand(t *testing.T) {
@@ -38,6 +42,22 @@ func TestNewClientCommand(t *testing.T) {
 	if flags.Lookup("timeout") == nil {
 		t.Error("Expected 'timeout' flag to be defined")
 	}
+
+	if flags.Lookup("token") == nil {
+		t.Error("Expected 'token' flag to be defined")
+	}
+}
+
+func TestTokenCredentials(t *testing.T) {
+	creds := tokenCredentials("s3cret")
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if md["authorization"] != "Bearer s3cret" {
+		t.Errorf("Expected bearer authorization header, got %q", md["authorization"])
+	}
 }
 
 func TestParseDestructionType(t *testing.T) {
@@ -53,6 +73,9 @@ func TestParseDestructionType(t *testing.T) {
 		{"NETWORK_DISRUPTION", pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION, false},
 		{"BOOT_CORRUPTION", pb.DestructionType_DESTRUCTION_TYPE_BOOT_CORRUPTION, false},
 		{"KERNEL_PANIC", pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC, false},
+		{"FILE_CORRUPTION", pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION, false},
+		{"CPU_BURN", pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, false},
+		{"INODE_EXHAUSTION", pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION, false},
 		{"file_deletion", pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, false},
 		{"service_termination", pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, false},
 		{"INVALID_TYPE", pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED, true},
@@ -115,6 +138,102 @@ func TestParseSeverity(t *testing.T) {
 	}
 }
 
+func TestScenarioSeverity(t *testing.T) {
+	tests := []struct {
+		name         string
+		scenarioID   string
+		explicit     bool
+		fromScenario bool
+		expected     pb.DestructionSeverity
+	}{
+		{"no scenario", "", false, true, pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH},
+		{"from scenario", "scenario_1", false, true, pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED},
+		{"explicit severity", "scenario_1", true, true, pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH},
+		{"derivation off", "scenario_1", false, false, pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := scenarioSeverity("HIGH", tt.scenarioID, tt.explicit, tt.fromScenario)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := scenarioSeverity("BOGUS", "scenario_1", true, true); err == nil {
+		t.Error("Expected an explicit invalid severity to be rejected")
+	}
+}
+
+func TestParseFailurePolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected pb.FailurePolicy
+		hasError bool
+	}{
+		{"continue", pb.FailurePolicy_FAILURE_POLICY_CONTINUE, false},
+		{"", pb.FailurePolicy_FAILURE_POLICY_CONTINUE, false},
+		{"fail-fast", pb.FailurePolicy_FAILURE_POLICY_FAIL_FAST, false},
+		{"FAIL_FAST", pb.FailurePolicy_FAILURE_POLICY_FAIL_FAST, false},
+		{"retry", pb.FailurePolicy_FAILURE_POLICY_UNSPECIFIED, true},
+	}
+
+	for _, tt := range tests {
+		result, err := parseFailurePolicy(tt.input)
+		if tt.hasError {
+			if err == nil {
+				t.Errorf("Expected error for input '%s', but got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected no error for input '%s', but got: %v", tt.input, err)
+		}
+		if result != tt.expected {
+			t.Errorf("Expected %v for input '%s', got %v", tt.expected, tt.input, result)
+		}
+	}
+}
+
+func TestWithTargetFile(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_target_file_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	path := filepath.Join(tempDir, "targets.txt")
+	content := "# curated targets\n/tmp/a.log\n\n  /tmp/b.log  \r\n   # indented comment\n/tmp/*.tmp\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write target file: %v", err)
+	}
+
+	targets, err := withTargetFile([]string{"/tmp/flag.log"}, path)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected := []string{"/tmp/flag.log", "/tmp/a.log", "/tmp/b.log", "/tmp/*.tmp"}
+	if strings.Join(targets, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected targets %v, got %v", expected, targets)
+	}
+
+	if targets, err := withTargetFile([]string{"/tmp/flag.log"}, ""); err != nil || len(targets) != 1 {
+		t.Errorf("Expected --targets alone without a file, got %v, %v", targets, err)
+	}
+
+	if _, err := withTargetFile(nil, filepath.Join(tempDir, "missing.txt")); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("Expected a missing file to be an error, got: %v", err)
+	}
+}
+
 func TestGetTimeout(t *testing.T) {
 	cmd := &cobra.Command{}
 	cmd.Flags().Duration("timeout", 30*time.Second, "Request timeout")
@@ -165,6 +284,10 @@ func TestNewExecuteCommand(t *testing.T) {
 		t.Error("Expected 'confirm' flag to be defined")
 	}
 
+	if flags.Lookup("dry-run") == nil {
+		t.Error("Expected 'dry-run' flag to be defined")
+	}
+
 	// Basic validation that flags are properly set up
 	// The actual required flag validation is handled by cobra internally
 }
@@ -238,6 +361,182 @@ func TestNewStreamCommand(t *testing.T) {
 	}
 }
 
+func TestNewRestoreCommand(t *testing.T) {
+	cmd := newRestoreCommand()
+	if cmd == nil {
+		t.Fatal("Expected restore command to be created")
+	}
+
+	if cmd.Use != "restore" {
+		t.Errorf("Expected command use 'restore', got '%s'", cmd.Use)
+	}
+
+	expectedFlags := []string{"task-id", "targets", "force", "remove-backup"}
+	for _, flagName := range expectedFlags {
+		if cmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("Expected '%s' flag to be defined", flagName)
+		}
+	}
+
+	// Neither a task ID nor targets should be rejected before connecting
+	if err := cmd.RunE(cmd, []string{}); err == nil {
+		t.Error("Expected error when neither task ID nor targets are given")
+	}
+}
+
+func TestNewVerifyBackupCommand(t *testing.T) {
+	cmd := newVerifyBackupCommand()
+	if cmd.Use != "verify-backup" {
+		t.Errorf("Expected command use 'verify-backup', got '%s'", cmd.Use)
+	}
+
+	for _, flagName := range []string{"task-id", "targets"} {
+		if cmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("Expected '%s' flag to be defined", flagName)
+		}
+	}
+
+	if err := cmd.RunE(cmd, []string{}); err == nil {
+		t.Error("Expected error when neither task ID nor targets are given")
+	}
+}
+
+func TestNewCleanupBackupsCommand(t *testing.T) {
+	cmd := newCleanupBackupsCommand()
+	if cmd.Use != "cleanup-backups" {
+		t.Errorf("Expected command use 'cleanup-backups', got '%s'", cmd.Use)
+	}
+
+	for _, flagName := range []string{"dir", "older-than"} {
+		if cmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("Expected '%s' flag to be defined", flagName)
+		}
+	}
+
+	if err := cmd.RunE(cmd, []string{}); err == nil {
+		t.Error("Expected error when no directory is given")
+	}
+}
+
+func TestNewTaskCommand(t *testing.T) {
+	cmd := newTaskCommand()
+	if cmd == nil {
+		t.Fatal("Expected task command to be created")
+	}
+
+	if cmd.Use != "task" {
+		t.Errorf("Expected command use 'task', got '%s'", cmd.Use)
+	}
+
+	for _, name := range []string{"status", "cancel"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub == cmd {
+			t.Errorf("Expected '%s' subcommand to be defined", name)
+			continue
+		}
+		if err := sub.Args(sub, []string{}); err == nil {
+			t.Errorf("Expected '%s' to require a task ID", name)
+		}
+	}
+}
+
+func TestNewTasksCommand(t *testing.T) {
+	cmd := newTasksCommand()
+	if cmd.Use != "tasks" {
+		t.Errorf("Expected command use 'tasks', got '%s'", cmd.Use)
+	}
+
+	list, _, err := cmd.Find([]string{"list"})
+	if err != nil || list == cmd {
+		t.Fatal("Expected 'list' subcommand to be defined")
+	}
+	if err := list.Args(list, []string{"extra"}); err == nil {
+		t.Error("Expected 'list' to reject arguments")
+	}
+
+	get, _, err := cmd.Find([]string{"get"})
+	if err != nil || get == cmd {
+		t.Fatal("Expected 'get' subcommand to be defined")
+	}
+	if err := get.Args(get, []string{}); err == nil {
+		t.Error("Expected 'get' to require a task ID")
+	}
+}
+
+func TestPrintTaskTable(t *testing.T) {
+	var buf bytes.Buffer
+	printTaskTable(&buf, []*pb.TaskStatus{
+		{
+			TaskId:   "task_1",
+			Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			State:    "running",
+			Progress: 0.5,
+			Targets:  []string{"/tmp/a", "/tmp/b"},
+		},
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected header and one row, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "ID") {
+		t.Errorf("Expected header row, got: %s", lines[0])
+	}
+	for _, want := range []string{"task_1", "FILE_DELETION", "LOW", "running", "50.0%", "/tmp/a,/tmp/b"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("Expected row to contain %q, got: %s", want, lines[1])
+		}
+	}
+}
+
+func TestPrintHistoryTable(t *testing.T) {
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var buf bytes.Buffer
+	printHistoryTable(&buf, []*pb.TaskRecord{
+		{
+			TaskId:     "task_1",
+			Type:       pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL,
+			Severity:   pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+			State:      "completed",
+			Targets:    []string{"/tmp/fill"},
+			StartedAt:  timestamppb.New(started),
+			FinishedAt: timestamppb.New(started.Add(1500 * time.Millisecond)),
+		},
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected header and one row, got %d lines", len(lines))
+	}
+	for _, want := range []string{"task_1", "DISK_FILL", "MEDIUM", "completed", "1.5s", "/tmp/fill"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("Expected row to contain %q, got: %s", want, lines[1])
+		}
+	}
+}
+
+func TestPrintMatchTable(t *testing.T) {
+	var buf bytes.Buffer
+	printMatchTable(&buf, []*pb.TargetMatch{
+		{Path: "/tmp/a.log", Size: 3, Allowed: true},
+		{Path: "/tmp/dir", IsDir: true, Allowed: true},
+		{Path: "/etc/b.log", Blocked: true},
+		{Path: "/srv/c.log", Size: 1},
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected header and four rows, got %d lines", len(lines))
+	}
+	for i, want := range []string{"allowed", "dir", "blocked", "not allowed"} {
+		if !strings.Contains(lines[i+1], want) {
+			t.Errorf("Expected row %d to contain %q, got: %s", i+1, want, lines[i+1])
+		}
+	}
+}
+
 func TestExecuteCommandValidation(t *testing.T) {
 	cmd := newExecuteCommand()
 
@@ -361,7 +660,7 @@ func TestStreamCommandFlags(t *testing.T) {
 	cmd := newStreamCommand()
 
 	// Test all expected flags are present
-	expectedFlags := []string{"type", "targets", "severity", "confirm", "scenario-id"}
+	expectedFlags := []string{"type", "targets", "target-file", "severity", "confirm", "scenario-id", "skip-preflight", "severity-from-scenario", "include", "exclude", "older-than", "max-size", "wipe", "intensity", "plan", "window-override-token"}
 
 	for _, flagName := range expectedFlags {
 		if cmd.Flags().Lookup(flagName) == nil {
@@ -543,3 +842,64 @@ func TestGRPCClientCreation(t *testing.T) {
 		t.Error("If no error, client should not be nil")
 	}
 }
+
+func TestFormatEvent(t *testing.T) {
+	started := formatEvent(&pb.StreamDestructionResponse{
+		Type:    pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED,
+		Message: "Destruction task started",
+		TaskId:  "task_1",
+	})
+	if !strings.Contains(started, "Started") || !strings.Contains(started, "task_1") {
+		t.Errorf("Expected a started line naming the task, got %q", started)
+	}
+
+	progress := formatEvent(&pb.StreamDestructionResponse{
+		Type:     pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
+		Progress: 0.5,
+		Message:  "Destroyed /tmp/a",
+	})
+	if !strings.Contains(progress, "50.0%") {
+		t.Errorf("Expected progress as a percentage, got %q", progress)
+	}
+
+	step := formatEvent(&pb.StreamDestructionResponse{
+		Type:    pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_STARTED,
+		Step:    2,
+		Message: "Scenario scenario_1 step 2 of 3: stop nginx",
+	})
+	if !strings.Contains(step, "Step 2") {
+		t.Errorf("Expected a step line naming the step, got %q", step)
+	}
+
+	if line := formatEvent(&pb.StreamDestructionResponse{}); line != "" {
+		t.Errorf("Expected unknown events to be skipped, got %q", line)
+	}
+}
+
+func TestScheduleCreateNeedsOneTiming(t *testing.T) {
+	for _, timing := range [][]string{
+		{},
+		{"--delay", "10m", "--cron", "0 2 * * *"},
+	} {
+		clientCmd := NewClientCommand()
+		clientCmd.SetOut(&bytes.Buffer{})
+		clientCmd.SetErr(&bytes.Buffer{})
+		clientCmd.SetArgs(append([]string{"schedule", "create", "--type", "FILE_DELETION", "--confirm"}, timing...))
+
+		if err := clientCmd.Execute(); err == nil || !strings.Contains(err.Error(), "exactly one of --delay and --cron") {
+			t.Errorf("%v: expected a timing error, got: %v", timing, err)
+		}
+	}
+}
+
+func TestScenarioDestructionType(t *testing.T) {
+	if dtype, err := scenarioDestructionType("", "scenario_1"); err != nil || dtype != pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED {
+		t.Errorf("Expected a stored scenario to leave the type unset, got %v, %v", dtype, err)
+	}
+	if dtype, err := scenarioDestructionType("FILE_DELETION", "scenario_1"); err != nil || dtype != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		t.Errorf("Expected --type to be parsed, got %v, %v", dtype, err)
+	}
+	if _, err := scenarioDestructionType("", ""); err == nil {
+		t.Error("Expected --type to be required without --scenario-id")
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func newDoctorCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Show which destruction types the server can carry out",
+		Long:  "检查服务器所在主机能执行哪些破坏类型（是否实现、是否启用、缺少哪些命令或权限）",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.CheckCapabilities(ctx, &pb.CheckCapabilitiesRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to check capabilities: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			out.Printf("🩺 Server capabilities\n")
+			out.Printf("Platform: %s\n", resp.Platform)
+			out.Printf("Privileged: %v\n\n", resp.Privileged)
+			printCapabilityTable(out, resp.Capabilities)
+			return nil
+		},
+	}
+}
+
+// printCapabilityTable writes one row per destruction type
+func printCapabilityTable(w io.Writer, capabilities []*pb.Capability) {
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	t := newTable("TYPE", "IMPLEMENTED", "ENABLED", "AVAILABLE", "REASON")
+	for _, capability := range capabilities {
+		reason := capability.Reason
+		if reason == "" {
+			reason = "-"
+		}
+		t.addRow(
+			strings.TrimPrefix(capability.Type.String(), "DESTRUCTION_TYPE_"),
+			yesNo(capability.Implemented),
+			yesNo(capability.Enabled),
+			yesNo(capability.Available),
+			reason)
+	}
+	t.write(w)
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func TestNewDoctorCommand(t *testing.T) {
+	cmd := newDoctorCommand()
+	if cmd.Use != "doctor" {
+		t.Errorf("Expected command use 'doctor', got '%s'", cmd.Use)
+	}
+	if err := cmd.Args(cmd, []string{"extra"}); err == nil {
+		t.Error("Expected doctor to take no arguments")
+	}
+}
+
+func TestPrintCapabilityTable(t *testing.T) {
+	var buf bytes.Buffer
+	printCapabilityTable(&buf, []*pb.Capability{
+		{
+			Type:        pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Implemented: true,
+			Enabled:     true,
+			Available:   true,
+		},
+		{
+			Type:        pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
+			Implemented: true,
+			Enabled:     true,
+			Reason:      "not running as root, cannot stop systemd units",
+		},
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected header and two rows, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "TYPE") {
+		t.Errorf("Expected header row, got: %s", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); len(fields) != 5 || fields[0] != "FILE_DELETION" || fields[3] != "yes" || fields[4] != "-" {
+		t.Errorf("Expected an available file deletion row, got: %s", lines[1])
+	}
+	if !strings.Contains(lines[2], "SERVICE_TERMINATION") || !strings.Contains(lines[2], "not running as root") {
+		t.Errorf("Expected the reason service termination is unavailable, got: %s", lines[2])
+	}
+}
@@ -3,11 +3,14 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
 )
 
 // NewGenerateCommand creates the generate command
@@ -21,6 +24,7 @@ func NewGenerateCommand() *cobra.Command {
 	cmd.AddCommand(
 		newGenerateConfigCommand(),
 		newGenerateExampleCommand(),
+		newGenerateFuzzCommand(),
 	)
 
 	return cmd
@@ -189,3 +193,161 @@ func newGenerateExampleCommand() *cobra.Command {
 
 	return cmd
 }
+
+// weightedChoice is an option picked with probability proportional to its
+// weight
+type weightedChoice struct {
+	value  string
+	weight int
+}
+
+// fuzzTypes are the implemented destruction types fuzz scenarios draw from
+var fuzzTypes = []weightedChoice{
+	{value: "FILE_DELETION", weight: 3},
+	{value: "MEMORY_EXHAUSTION", weight: 1},
+}
+
+// fuzzSeverities favour lower severities
+var fuzzSeverities = []weightedChoice{
+	{value: "LOW", weight: 4},
+	{value: "MEDIUM", weight: 3},
+	{value: "HIGH", weight: 2},
+	{value: "CRITICAL", weight: 1},
+}
+
+func pickWeighted(rng *rand.Rand, choices []weightedChoice) string {
+	total := 0
+	for _, choice := range choices {
+		total += choice.weight
+	}
+
+	n := rng.Intn(total)
+	for _, choice := range choices {
+		if n < choice.weight {
+			return choice.value
+		}
+		n -= choice.weight
+	}
+	return choices[len(choices)-1].value
+}
+
+// severitiesUpTo returns the fuzz severities not above maxSeverity
+func severitiesUpTo(maxSeverity string) []weightedChoice {
+	var allowed []weightedChoice
+	for _, choice := range fuzzSeverities {
+		allowed = append(allowed, choice)
+		if choice.value == maxSeverity {
+			break
+		}
+	}
+	return allowed
+}
+
+// generateFuzzScenarios builds count randomized scenarios whose targets all
+// lie within allowedDirs and whose severity never exceeds maxSeverity. The
+// same seed always yields the same scenarios.
+func generateFuzzScenarios(seed int64, count int, allowedDirs []string, maxSeverity string) []map[string]interface{} {
+	// #nosec G404 - Reproducible test data, not security sensitive
+	rng := rand.New(rand.NewSource(seed))
+	severities := severitiesUpTo(maxSeverity)
+
+	scenarios := make([]map[string]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		severity := pickWeighted(rng, severities)
+
+		var steps []map[string]interface{}
+		stepCount := 1 + rng.Intn(3)
+		for order := 1; order <= stepCount; order++ {
+			destructionType := pickWeighted(rng, fuzzTypes)
+			dir := allowedDirs[rng.Intn(len(allowedDirs))]
+
+			var targets []string
+			switch destructionType {
+			case "FILE_DELETION":
+				targetCount := 1 + rng.Intn(3)
+				for t := 0; t < targetCount; t++ {
+					targets = append(targets, filepath.Join(dir, fmt.Sprintf("fuzz_%04d_%d_%d.txt", i+1, order, t)))
+				}
+			default:
+				targets = []string{dir}
+			}
+
+			steps = append(steps, map[string]interface{}{
+				"order":       order,
+				"type":        destructionType,
+				"description": fmt.Sprintf("Fuzz step %d: %s", order, destructionType),
+				"targets":     targets,
+				"rationale":   "Randomized input for soak-testing validation and execution",
+			})
+		}
+
+		scenarios = append(scenarios, map[string]interface{}{
+			"id":          fmt.Sprintf("fuzz_%d_%04d", seed, i+1),
+			"description": fmt.Sprintf("Fuzz scenario %d (seed %d)", i+1, seed),
+			"severity":    severity,
+			"steps":       steps,
+		})
+	}
+
+	return scenarios
+}
+
+func newGenerateFuzzCommand() *cobra.Command {
+	var (
+		outputDir  string
+		configFile string
+		count      int
+		seed       int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "fuzz",
+		Short: "Generate randomized scenarios for soak testing",
+		Long:  "根据配置的允许目录和最高严重级别生成可复现的随机测试场景",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(configFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if len(cfg.Security.AllowedTargets) == 0 {
+				return fmt.Errorf("no allowed_targets configured to draw fuzz targets from")
+			}
+			if count < 1 {
+				return fmt.Errorf("count must be at least 1")
+			}
+
+			if err := os.MkdirAll(outputDir, 0750); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+
+			scenarios := generateFuzzScenarios(seed, count, cfg.Security.AllowedTargets, cfg.Security.MaxSeverity)
+			for _, scenario := range scenarios {
+				path := filepath.Join(outputDir, fmt.Sprintf("scenario_%s.json", scenario["id"]))
+
+				data, err := json.MarshalIndent(scenario, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal scenario %s: %w", scenario["id"], err)
+				}
+
+				if err := os.WriteFile(path, data, 0600); err != nil {
+					return fmt.Errorf("failed to write scenario %s: %w", scenario["id"], err)
+				}
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"seed":  seed,
+				"count": len(scenarios),
+			}).Info("Generated fuzz scenarios")
+
+			fmt.Printf("✅ Generated %d fuzz scenarios (seed %d) in %s\n", len(scenarios), seed, outputDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&outputDir, "output", "fuzz", "Output directory for scenarios")
+	cmd.Flags().StringVarP(&configFile, "config", "c", "config.yaml", "Configuration file providing allowed targets and max severity")
+	cmd.Flags().IntVar(&count, "count", 100, "Number of scenarios to generate")
+	cmd.Flags().Int64Var(&seed, "seed", 1, "Random seed; the same seed reproduces the same scenarios")
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateFuzzScenariosReproducible(t *testing.T) {
+	allowed := []string{"/tmp/burndevice_test", "/srv/test"}
+
+	first := generateFuzzScenarios(42, 25, allowed, "HIGH")
+	second := generateFuzzScenarios(42, 25, allowed, "HIGH")
+
+	firstJSON, err := json.Marshal(first)
+	if err != nil {
+		t.Fatalf("Failed to marshal scenarios: %v", err)
+	}
+	secondJSON, err := json.Marshal(second)
+	if err != nil {
+		t.Fatalf("Failed to marshal scenarios: %v", err)
+	}
+	if string(firstJSON) != string(secondJSON) {
+		t.Error("Expected the same seed to generate identical scenarios")
+	}
+
+	other := generateFuzzScenarios(43, 25, allowed, "HIGH")
+	if reflect.DeepEqual(first, other) {
+		t.Error("Expected a different seed to generate different scenarios")
+	}
+}
+
+func TestGenerateFuzzScenariosRespectPolicy(t *testing.T) {
+	allowed := []string{"/tmp/burndevice_test", "/srv/test"}
+	scenarios := generateFuzzScenarios(7, 200, allowed, "MEDIUM")
+
+	if len(scenarios) != 200 {
+		t.Fatalf("Expected 200 scenarios, got %d", len(scenarios))
+	}
+
+	for _, scenario := range scenarios {
+		severity := scenario["severity"].(string)
+		if severity != "LOW" && severity != "MEDIUM" {
+			t.Errorf("Scenario %s exceeds max severity: %s", scenario["id"], severity)
+		}
+
+		for _, step := range scenario["steps"].([]map[string]interface{}) {
+			stepType := step["type"].(string)
+			if stepType != "FILE_DELETION" && stepType != "MEMORY_EXHAUSTION" {
+				t.Errorf("Scenario %s uses unimplemented type %s", scenario["id"], stepType)
+			}
+
+			for _, target := range step["targets"].([]string) {
+				within := false
+				for _, dir := range allowed {
+					if target == dir || strings.HasPrefix(target, dir+string(filepath.Separator)) {
+						within = true
+						break
+					}
+				}
+				if !within {
+					t.Errorf("Scenario %s target %s is outside allowed dirs", scenario["id"], target)
+				}
+			}
+		}
+	}
+}
+
+func TestNewGenerateFuzzCommand(t *testing.T) {
+	cmd := newGenerateFuzzCommand()
+	if cmd.Use != "fuzz" {
+		t.Errorf("Expected command use 'fuzz', got '%s'", cmd.Use)
+	}
+
+	for _, name := range []string{"seed", "count", "output", "config"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected '%s' flag to be defined", name)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+)
+
+// acknowledgeIrreversible reports whether a request of type t should carry
+// acknowledge_irreversible. For types that cannot be undone the operator
+// must type the server's hostname first, unless yes skips the prompt; a
+// wrong answer aborts. Dry runs and other types need nothing. The prompt
+// runs before the request's own timeout starts.
+func acknowledgeIrreversible(cmd *cobra.Command, client pb.BurnDeviceServiceClient, t pb.DestructionType, dryRun, yes bool) (bool, error) {
+	if dryRun || !policy.IrreversibleType(t) {
+		return false, nil
+	}
+	if yes {
+		return true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+	info, err := client.GetServerInfo(ctx, &pb.GetServerInfoRequest{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get server hostname: %w", err)
+	}
+
+	prompt := cmd.ErrOrStderr()
+	_, _ = fmt.Fprintf(prompt, "⚠️  %s cannot be undone. Type the server hostname (%s) to continue: ",
+		strings.TrimPrefix(t.String(), "DESTRUCTION_TYPE_"), info.Hostname)
+
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("no hostname entered; aborting")
+	}
+	if strings.TrimSpace(answer) != info.Hostname {
+		return false, fmt.Errorf("hostname did not match %s; aborting", info.Hostname)
+	}
+	return true, nil
+}
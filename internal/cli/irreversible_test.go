@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// ackServer records the irreversible acknowledgement of each request
+type ackServer struct {
+	fakeServer
+
+	mu    sync.Mutex
+	acked []bool
+}
+
+func (s *ackServer) ExecuteDestruction(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	s.mu.Lock()
+	s.acked = append(s.acked, req.AcknowledgeIrreversible)
+	s.mu.Unlock()
+	return &pb.ExecuteDestructionResponse{Success: true, Message: "Destruction completed"}, nil
+}
+
+func (s *ackServer) requests() []bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]bool(nil), s.acked...)
+}
+
+func TestExecuteIrreversibleAcknowledgement(t *testing.T) {
+	tests := []struct {
+		name      string
+		dtype     string
+		input     string
+		extra     []string
+		expectErr bool
+		// acked is the acknowledgement sent, or nil when nothing is sent
+		acked  []bool
+		prompt bool
+	}{
+		{name: "hostname typed", dtype: "KERNEL_PANIC", input: "fake\n", acked: []bool{true}, prompt: true},
+		{name: "wrong hostname", dtype: "KERNEL_PANIC", input: "other\n", expectErr: true, prompt: true},
+		{name: "no input", dtype: "BOOT_CORRUPTION", expectErr: true, prompt: true},
+		{name: "yes-i-know", dtype: "KERNEL_PANIC", extra: []string{"--yes-i-know"}, acked: []bool{true}},
+		{name: "dry run", dtype: "KERNEL_PANIC", extra: []string{"--dry-run"}, acked: []bool{false}},
+		{name: "reversible type", dtype: "FILE_DELETION", acked: []bool{false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &ackServer{}
+			addr := startFakeServer(t, server)
+
+			var stderr bytes.Buffer
+			clientCmd := NewClientCommand()
+			clientCmd.SetOut(&bytes.Buffer{})
+			clientCmd.SetErr(&stderr)
+			clientCmd.SetIn(strings.NewReader(tt.input))
+			clientCmd.SetArgs(append([]string{
+				"execute",
+				"--server", addr,
+				"--type", tt.dtype,
+				"--severity", "CRITICAL",
+				"--targets", "node-1",
+				"--confirm",
+			}, tt.extra...))
+
+			err := clientCmd.Execute()
+			if tt.expectErr && err == nil {
+				t.Error("Expected an error, got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prompted := strings.Contains(stderr.String(), "cannot be undone"); prompted != tt.prompt {
+				t.Errorf("Expected prompt %v, got stderr:\n%s", tt.prompt, stderr.String())
+			}
+
+			acked := server.requests()
+			if len(acked) != len(tt.acked) {
+				t.Fatalf("Expected %d requests, got %d", len(tt.acked), len(acked))
+			}
+			for i := range acked {
+				if acked[i] != tt.acked[i] {
+					t.Errorf("Expected acknowledge_irreversible %v, got %v", tt.acked[i], acked[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func newMetricsCommand() *cobra.Command {
+	var watch time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Show server metrics, or watch their rates",
+		Long:  "显示服务器启动以来的累计指标；使用 --watch 定期轮询并显示每个间隔内的增量和速率，按 Ctrl+C 退出",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if watch < 0 {
+				return fmt.Errorf("--watch interval cannot be negative")
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			fetch := func() (*pb.GetMetricsResponse, error) {
+				reqCtx, cancel := context.WithTimeout(ctx, getTimeout(cmd))
+				defer cancel()
+
+				resp, err := client.GetMetrics(reqCtx, &pb.GetMetricsRequest{})
+				if err != nil {
+					return nil, fmt.Errorf("failed to get metrics: %w", err)
+				}
+				return resp, nil
+			}
+
+			snapshot, err := fetch()
+			if err != nil {
+				return err
+			}
+
+			if watch == 0 {
+				if out.json {
+					return out.JSON(snapshot)
+				}
+				printMetrics(out, snapshot)
+				return nil
+			}
+
+			// Snapshots are printed as JSON lines so consumers can compute
+			// their own rates
+			if out.json {
+				if err := out.JSONLine(snapshot); err != nil {
+					return err
+				}
+			} else {
+				printMetrics(out, snapshot)
+				out.Println()
+			}
+
+			ticker := time.NewTicker(watch)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+
+				next, err := fetch()
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil
+					}
+					return err
+				}
+
+				if out.json {
+					if err := out.JSONLine(next); err != nil {
+						return err
+					}
+				} else {
+					out.Println(metricsDeltaBetween(snapshot, next).String())
+				}
+				snapshot = next
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&watch, "watch", 0, "Poll at this interval and show rates since the previous poll (e.g. 5s)")
+
+	return cmd
+}
+
+// printMetrics writes a metrics snapshot
+func printMetrics(out *output, metrics *pb.GetMetricsResponse) {
+	out.Printf("📈 Server Metrics (since %s)\n", metrics.StartedAt.AsTime().Local().Format(time.RFC3339))
+	out.Printf("Destructions: %d (%d failed)\n", metrics.DestructionsTotal, metrics.DestructionsFailed)
+	out.Printf("Files deleted: %d\n", metrics.FilesDeletedTotal)
+	out.Printf("Bytes destroyed: %d\n", metrics.BytesDestroyedTotal)
+	out.Printf("Scenarios generated: %d\n", metrics.ScenariosGeneratedTotal)
+	out.Printf("AI tokens: %d\n", metrics.AiTokensTotal)
+}
+
+// metricsDelta is how much the server's counters grew between two polls
+type metricsDelta struct {
+	At      time.Time
+	Elapsed time.Duration
+	// Reset is set when the server restarted between the polls
+	Reset bool
+
+	Destructions int64
+	Bytes        int64
+	Tokens       int64
+}
+
+// metricsDeltaBetween returns the growth from prev to cur. A changed start
+// time or a counter going down means the server restarted, so cur's totals
+// are the growth since the restart.
+func metricsDeltaBetween(prev, cur *pb.GetMetricsResponse) metricsDelta {
+	d := metricsDelta{
+		At:      cur.Timestamp.AsTime(),
+		Elapsed: cur.Timestamp.AsTime().Sub(prev.Timestamp.AsTime()),
+	}
+
+	d.Reset = !cur.StartedAt.AsTime().Equal(prev.StartedAt.AsTime()) ||
+		cur.DestructionsTotal < prev.DestructionsTotal ||
+		cur.BytesDestroyedTotal < prev.BytesDestroyedTotal ||
+		cur.AiTokensTotal < prev.AiTokensTotal
+
+	if d.Reset {
+		d.Destructions = cur.DestructionsTotal
+		d.Bytes = cur.BytesDestroyedTotal
+		d.Tokens = cur.AiTokensTotal
+		if uptime := cur.Timestamp.AsTime().Sub(cur.StartedAt.AsTime()); uptime > 0 && uptime < d.Elapsed {
+			d.Elapsed = uptime
+		}
+		return d
+	}
+
+	d.Destructions = cur.DestructionsTotal - prev.DestructionsTotal
+	d.Bytes = cur.BytesDestroyedTotal - prev.BytesDestroyedTotal
+	d.Tokens = cur.AiTokensTotal - prev.AiTokensTotal
+	return d
+}
+
+// rate returns n per second over the delta's interval
+func (d metricsDelta) rate(n int64) float64 {
+	if d.Elapsed <= 0 {
+		return 0
+	}
+	return float64(n) / d.Elapsed.Seconds()
+}
+
+func (d metricsDelta) String() string {
+	line := fmt.Sprintf("[%s] destructions +%d (%.2f/s)  bytes +%d (%.0f/s)  tokens +%d (%.1f/s)",
+		d.At.Local().Format("15:04:05"),
+		d.Destructions, d.rate(d.Destructions),
+		d.Bytes, d.rate(d.Bytes),
+		d.Tokens, d.rate(d.Tokens))
+	if d.Reset {
+		line += "  ⚠️  server restarted, counting from the restart"
+	}
+	return line
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func TestMetricsDeltaBetween(t *testing.T) {
+	started := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := &pb.GetMetricsResponse{
+		Timestamp:           timestamppb.New(started.Add(time.Minute)),
+		StartedAt:           timestamppb.New(started),
+		DestructionsTotal:   4,
+		BytesDestroyedTotal: 1000,
+		AiTokensTotal:       500,
+	}
+	cur := &pb.GetMetricsResponse{
+		Timestamp:           timestamppb.New(started.Add(time.Minute + 10*time.Second)),
+		StartedAt:           timestamppb.New(started),
+		DestructionsTotal:   9,
+		BytesDestroyedTotal: 6000,
+		AiTokensTotal:       1500,
+	}
+
+	delta := metricsDeltaBetween(prev, cur)
+	if delta.Reset {
+		t.Error("Expected no reset between snapshots of the same server")
+	}
+	if delta.Elapsed != 10*time.Second {
+		t.Errorf("Expected 10s elapsed, got %v", delta.Elapsed)
+	}
+	if delta.Destructions != 5 || delta.Bytes != 5000 || delta.Tokens != 1000 {
+		t.Errorf("Unexpected deltas: %+v", delta)
+	}
+	if rate := delta.rate(delta.Destructions); rate != 0.5 {
+		t.Errorf("Expected 0.5 destructions/s, got %v", rate)
+	}
+	if rate := delta.rate(delta.Bytes); rate != 500 {
+		t.Errorf("Expected 500 bytes/s, got %v", rate)
+	}
+	if rate := delta.rate(delta.Tokens); rate != 100 {
+		t.Errorf("Expected 100 tokens/s, got %v", rate)
+	}
+}
+
+func TestMetricsDeltaBetweenDetectsReset(t *testing.T) {
+	started := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := &pb.GetMetricsResponse{
+		Timestamp:         timestamppb.New(started.Add(time.Minute)),
+		StartedAt:         timestamppb.New(started),
+		DestructionsTotal: 10,
+		AiTokensTotal:     800,
+	}
+
+	restarted := started.Add(time.Minute + 6*time.Second)
+	tests := []struct {
+		name string
+		cur  *pb.GetMetricsResponse
+	}{
+		{"new start time", &pb.GetMetricsResponse{
+			Timestamp:         timestamppb.New(restarted.Add(4 * time.Second)),
+			StartedAt:         timestamppb.New(restarted),
+			DestructionsTotal: 2,
+			AiTokensTotal:     100,
+		}},
+		{"counter decreased", &pb.GetMetricsResponse{
+			Timestamp:         timestamppb.New(started.Add(time.Minute + 10*time.Second)),
+			StartedAt:         timestamppb.New(started),
+			DestructionsTotal: 2,
+			AiTokensTotal:     100,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := metricsDeltaBetween(prev, tt.cur)
+			if !delta.Reset {
+				t.Fatal("Expected a reset to be detected")
+			}
+			if delta.Destructions != 2 || delta.Tokens != 100 {
+				t.Errorf("Expected the totals since the restart, got %+v", delta)
+			}
+			if delta.Destructions < 0 || delta.rate(delta.Tokens) < 0 {
+				t.Errorf("Expected non-negative rates, got %+v", delta)
+			}
+		})
+	}
+
+	delta := metricsDeltaBetween(prev, tests[0].cur)
+	if delta.Elapsed != 4*time.Second {
+		t.Errorf("Expected the interval to start at the restart, got %v", delta.Elapsed)
+	}
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Output formats accepted by --output. yaml is only offered by commands
+// with their own --format flag.
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputTable = "table"
+	outputYAML  = "yaml"
+)
+
+// output writes command results to the command's stdout and, when
+// --result-file is set, to that file as well. Like fmt.Printf it ignores
+// write errors. json is set when --output json asks for machine-readable
+// results; table is set when --output table asks for aligned columns and
+// yaml when a command's --format asks for YAML.
+type output struct {
+	io.Writer
+	file  *os.File
+	json  bool
+	table bool
+	yaml  bool
+}
+
+// newOutput opens the result file, creating its parent directories. The
+// caller must Close the returned output.
+func newOutput(cmd *cobra.Command) (*output, error) {
+	format, _ := cmd.Flags().GetString("output")
+	out := &output{json: format == outputJSON, table: format == outputTable}
+
+	path, _ := cmd.Flags().GetString("result-file")
+	if path == "" {
+		out.Writer = cmd.OutOrStdout()
+		return out, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create result file directory: %w", err)
+	}
+	// #nosec G304 - Path is supplied by the operator
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open result file: %w", err)
+	}
+
+	out.Writer = io.MultiWriter(cmd.OutOrStdout(), file)
+	out.file = file
+	return out, nil
+}
+
+// newListOutput is newOutput for list-style commands, which print a table
+// unless --output is given explicitly
+func newListOutput(cmd *cobra.Command) (*output, error) {
+	out, err := newOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if !cmd.Flags().Changed("output") {
+		out.table = true
+	}
+	return out, nil
+}
+
+// setFormat switches the output to format, as a command's own --format
+// flag does over --output
+func (o *output) setFormat(format string) {
+	o.json = format == outputJSON
+	o.table = format == outputTable
+	o.yaml = format == outputYAML
+}
+
+// Printf formats according to format and writes the result
+func (o *output) Printf(format string, a ...interface{}) {
+	_, _ = fmt.Fprintf(o, format, a...)
+}
+
+// Println writes its operands followed by a newline
+func (o *output) Println(a ...interface{}) {
+	_, _ = fmt.Fprintln(o, a...)
+}
+
+// JSON writes msg as indented JSON followed by a newline
+func (o *output) JSON(msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	_, _ = o.Write(append(data, '\n'))
+	return nil
+}
+
+// JSONLine writes msg as a single line of JSON, so streams of events can be
+// read as JSON lines
+func (o *output) JSONLine(msg proto.Message) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	_, _ = o.Write(append(data, '\n'))
+	return nil
+}
+
+// YAML writes msg as YAML, with the field names and value encodings of
+// its JSON form and the fields in the same order
+func (o *output) YAML(msg proto.Message) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	// JSON is YAML, so parsing it keeps the field order; clearing the
+	// styles turns its flow mappings and quoted strings into block YAML
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	clearYAMLStyle(&doc)
+
+	encoder := yaml.NewEncoder(o)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	return encoder.Close()
+}
+
+// clearYAMLStyle resets the style of node and everything below it, so the
+// encoder picks block style and only quotes strings that need it
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// Close closes the result file, if any
+func (o *output) Close() {
+	if o.file == nil {
+		return
+	}
+	if err := o.file.Close(); err != nil {
+		logrus.WithError(err).Warn("Failed to close result file")
+	}
+}
+
+// validateOutputFormat rejects anything but text, json and table
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputText, outputJSON, outputTable:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format: %s (expected text, json or table)", format)
+	}
+}
+
+// validateFormat rejects anything but text, json, yaml and table, the
+// choices of a command's --format flag
+func validateFormat(format string) error {
+	switch format {
+	case outputText, outputJSON, outputYAML, outputTable:
+		return nil
+	default:
+		return fmt.Errorf("unknown format: %s (expected text, json, yaml or table)", format)
+	}
+}
@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// fakeServer answers ExecuteDestruction, StreamDestruction, GetServerInfo
+// and GetSystemInfo with canned responses
+type fakeServer struct {
+	pb.UnimplementedBurnDeviceServiceServer
+
+	banner string
+}
+
+func (s fakeServer) GetServerInfo(ctx context.Context, req *pb.GetServerInfoRequest) (*pb.GetServerInfoResponse, error) {
+	return &pb.GetServerInfoResponse{Hostname: "fake", ConnectionBanner: s.banner}, nil
+}
+
+func (fakeServer) GetSystemInfo(ctx context.Context, req *pb.GetSystemInfoRequest) (*pb.GetSystemInfoResponse, error) {
+	return &pb.GetSystemInfoResponse{
+		Os:            "linux",
+		Architecture:  "amd64",
+		Hostname:      "fake",
+		Resources:     &pb.SystemResources{TotalMemory: 8 << 30, AvailableMemory: 4 << 30, CpuUsage: 12.5},
+		CriticalPaths: []string{"/etc", "/boot"},
+	}, nil
+}
+
+func (fakeServer) ExecuteDestruction(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	return &pb.ExecuteDestructionResponse{
+		Success: true,
+		Message: "Destruction completed",
+		TaskId:  "task_1",
+		Results: []*pb.DestructionResult{{
+			Target:  req.Targets[0],
+			Success: true,
+			Metrics: &pb.DestructionMetrics{FilesDeleted: 1, BytesDestroyed: 4},
+		}},
+	}, nil
+}
+
+func (fakeServer) StreamDestruction(req *pb.StreamDestructionRequest, stream pb.BurnDeviceService_StreamDestructionServer) error {
+	for _, eventType := range []pb.DestructionEventType{
+		pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED,
+		pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED,
+	} {
+		if err := stream.Send(&pb.StreamDestructionResponse{
+			Timestamp: timestamppb.Now(),
+			Type:      eventType,
+			TaskId:    "task_1",
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startFakeServer(t *testing.T, fake pb.BurnDeviceServiceServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	server := grpc.NewServer()
+	pb.RegisterBurnDeviceServiceServer(server, fake)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestResultFileMatchesStdout(t *testing.T) {
+	addr := startFakeServer(t, fakeServer{})
+
+	tempDir, err := os.MkdirTemp("", "burndevice_output_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	for _, format := range []string{outputText, outputJSON} {
+		// Parent directories are created on demand
+		resultFile := filepath.Join(tempDir, format, "nested", "result.out")
+
+		var stdout bytes.Buffer
+		clientCmd := NewClientCommand()
+		clientCmd.SetOut(&stdout)
+		clientCmd.SetArgs([]string{
+			"execute",
+			"--server", addr,
+			"--type", "FILE_DELETION",
+			"--targets", "/tmp/test.txt",
+			"--confirm",
+			"--output", format,
+			"--result-file", resultFile,
+		})
+
+		if err := clientCmd.Execute(); err != nil {
+			t.Fatalf("%s: expected no error, got: %v", format, err)
+		}
+
+		// #nosec G304 - Test file path
+		written, err := os.ReadFile(resultFile)
+		if err != nil {
+			t.Fatalf("%s: failed to read result file: %v", format, err)
+		}
+		if stdout.Len() == 0 {
+			t.Fatalf("%s: expected results on stdout", format)
+		}
+		if !bytes.Equal(written, stdout.Bytes()) {
+			t.Errorf("%s: expected result file to match stdout\nfile:\n%s\nstdout:\n%s", format, written, stdout.String())
+		}
+
+		if format == outputJSON {
+			resp := &pb.ExecuteDestructionResponse{}
+			if err := protojson.Unmarshal(written, resp); err != nil {
+				t.Fatalf("Expected valid JSON in the result file, got: %v", err)
+			}
+			if resp.TaskId != "task_1" || len(resp.Results) != 1 {
+				t.Errorf("Expected the server's response, got %v", resp)
+			}
+		}
+	}
+}
+
+func TestStreamJSONLines(t *testing.T) {
+	addr := startFakeServer(t, fakeServer{})
+
+	var stdout bytes.Buffer
+	clientCmd := NewClientCommand()
+	clientCmd.SetOut(&stdout)
+	clientCmd.SetArgs([]string{
+		"stream",
+		"--server", addr,
+		"--type", "FILE_DELETION",
+		"--targets", "/tmp/test.txt",
+		"--confirm",
+		"-o", "json",
+	})
+
+	if err := clientCmd.Execute(); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one line per event, got %d:\n%s", len(lines), stdout.String())
+	}
+	for i, line := range lines {
+		event := &pb.StreamDestructionResponse{}
+		if err := protojson.Unmarshal([]byte(line), event); err != nil {
+			t.Errorf("Expected line %d to be a JSON event, got: %v", i+1, err)
+		}
+	}
+}
+
+func TestUnknownOutputFormatRejected(t *testing.T) {
+	clientCmd := NewClientCommand()
+	clientCmd.SetOut(&bytes.Buffer{})
+	clientCmd.SetErr(&bytes.Buffer{})
+	clientCmd.SetArgs([]string{"tasks", "--output", "yaml"})
+
+	if err := clientCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown output format") {
+		t.Errorf("Expected an unknown output format error, got: %v", err)
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range []string{outputText, outputJSON, outputTable} {
+		if err := validateOutputFormat(format); err != nil {
+			t.Errorf("Expected %s to be accepted, got: %v", format, err)
+		}
+	}
+	if err := validateOutputFormat("yaml"); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestSystemInfoFormat(t *testing.T) {
+	addr := startFakeServer(t, fakeServer{})
+
+	run := func(args ...string) (string, error) {
+		var stdout bytes.Buffer
+		clientCmd := NewClientCommand()
+		clientCmd.SetOut(&stdout)
+		clientCmd.SetErr(&bytes.Buffer{})
+		clientCmd.SetArgs(append([]string{"system-info", "--server", addr}, args...))
+		err := clientCmd.Execute()
+		return stdout.String(), err
+	}
+
+	got, err := run("--format", "yaml")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := `os: linux
+architecture: amd64
+hostname: fake
+criticalPaths:
+  - /etc
+  - /boot
+resources:
+  totalMemory: "8589934592"
+  availableMemory: "4294967296"
+  cpuUsage: 12.5
+`
+	if got != want {
+		t.Errorf("Expected YAML in field order:\n%s\ngot:\n%s", want, got)
+	}
+
+	// --format wins over --output
+	got, err = run("--format", "json", "--output", "table")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	resp := &pb.GetSystemInfoResponse{}
+	if err := protojson.Unmarshal([]byte(got), resp); err != nil || resp.Hostname != "fake" {
+		t.Errorf("Expected the response as JSON, got %q: %v", got, err)
+	}
+
+	got, err = run("--format", "text")
+	if err != nil || !strings.Contains(got, "Hostname: fake") {
+		t.Errorf("Expected the text layout, got %q: %v", got, err)
+	}
+
+	got, err = run()
+	if err != nil || !strings.Contains(got, "CRITICAL PATH") {
+		t.Errorf("Expected tables by default, got %q: %v", got, err)
+	}
+
+	if _, err := run("--format", "xml"); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Expected an unknown format error, got: %v", err)
+	}
+}
+
+// partialFailureServer reports one succeeded, one failed and one skipped
+// target, succeeding overall as under engine.success_policy "any"
+type partialFailureServer struct {
+	fakeServer
+}
+
+func (partialFailureServer) ExecuteDestruction(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	return &pb.ExecuteDestructionResponse{
+		Success: true,
+		Message: "Destruction completed successfully; targets: 1 succeeded, 1 failed, 1 skipped",
+		TaskId:  "task_1",
+		Results: []*pb.DestructionResult{
+			{Target: "/tmp/a.txt", Success: true, Metrics: &pb.DestructionMetrics{}},
+			{Target: "/tmp/b.txt", ErrorMessage: "failed to stat file", Metrics: &pb.DestructionMetrics{}},
+			{Target: "/tmp/c.txt", ErrorMessage: "skipped", Skipped: true, Metrics: &pb.DestructionMetrics{}},
+		},
+		FailedTargets: []string{"/tmp/b.txt", "/tmp/c.txt"},
+	}, nil
+}
+
+func (partialFailureServer) StreamDestruction(req *pb.StreamDestructionRequest, stream pb.BurnDeviceService_StreamDestructionServer) error {
+	return stream.Send(&pb.StreamDestructionResponse{
+		Timestamp:     timestamppb.Now(),
+		Type:          pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR,
+		TaskId:        "task_1",
+		FailedTargets: []string{"/tmp/b.txt"},
+	})
+}
+
+func TestPartialFailureExitsNonZero(t *testing.T) {
+	addr := startFakeServer(t, partialFailureServer{})
+
+	for _, command := range []string{"execute", "stream"} {
+		var stdout bytes.Buffer
+		clientCmd := NewClientCommand()
+		clientCmd.SetOut(&stdout)
+		clientCmd.SetErr(&bytes.Buffer{})
+		clientCmd.SetArgs([]string{
+			command,
+			"--server", addr,
+			"--type", "FILE_DELETION",
+			"--targets", "/tmp/a.txt,/tmp/b.txt,/tmp/c.txt",
+			"--confirm",
+		})
+
+		err := clientCmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), "/tmp/b.txt") {
+			t.Errorf("%s: expected an error naming the failed target, got: %v", command, err)
+		}
+		if command == "execute" && !strings.Contains(stdout.String(), "Summary: 1 succeeded, 1 failed, 1 skipped") {
+			t.Errorf("%s: expected a summary line, got:\n%s", command, stdout.String())
+		}
+		if command == "stream" && !strings.Contains(stdout.String(), "Failed targets: /tmp/b.txt") {
+			t.Errorf("%s: expected the failed targets, got:\n%s", command, stdout.String())
+		}
+	}
+}
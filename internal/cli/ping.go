@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func newPingCommand() *cobra.Command {
+	var service string
+
+	cmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Check that the server is up and serving",
+		Long:  "通过标准 gRPC 健康检查服务（grpc.health.v1.Health）确认服务器是否在线并可处理请求",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			start := time.Now()
+			resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+			if err != nil {
+				return fmt.Errorf("failed to check server health: %w", err)
+			}
+			latency := time.Since(start)
+
+			if out.json {
+				if err := out.JSON(resp); err != nil {
+					return err
+				}
+			} else if resp.Status == healthpb.HealthCheckResponse_SERVING {
+				out.Printf("✅ Server is %s (%s)\n", resp.Status, latency.Round(time.Millisecond))
+			} else {
+				out.Printf("❌ Server is %s\n", resp.Status)
+			}
+
+			if resp.Status != healthpb.HealthCheckResponse_SERVING {
+				return fmt.Errorf("server is not serving: %s", resp.Status)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&service, "service", "", "Service to check (default: the server as a whole)")
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestPingCommand(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	healthServer := health.NewServer()
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	ping := func() (string, error) {
+		var stdout bytes.Buffer
+		clientCmd := NewClientCommand()
+		clientCmd.SetOut(&stdout)
+		clientCmd.SetErr(&bytes.Buffer{})
+		clientCmd.SetArgs([]string{"ping", "--server", listener.Addr().String()})
+		err := clientCmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := ping()
+	if err != nil {
+		t.Fatalf("Expected ping to succeed, got: %v", err)
+	}
+	if !strings.Contains(output, "Server is SERVING") {
+		t.Errorf("Expected SERVING in output, got: %s", output)
+	}
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	if _, err := ping(); err == nil || !strings.Contains(err.Error(), "NOT_SERVING") {
+		t.Errorf("Expected an error for a server that is not serving, got: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func newPlanCommand() *cobra.Command {
+	var (
+		targets    []string
+		targetFile string
+		largest    int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Estimate how many files and bytes the targets hold",
+		Long:  "在服务器上展开并扫描目标，统计文件数、总字节数、最大的文件以及策略检查结果，不执行任何操作",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets, err := withTargetFile(targets, targetFile)
+			if err != nil {
+				return err
+			}
+			if len(targets) == 0 {
+				return fmt.Errorf("at least one target is required")
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newListOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.PlanDestruction(ctx, &pb.PlanDestructionRequest{
+				Targets:      targets,
+				LargestFiles: largest,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to plan destruction: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			printPlan(out, resp)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Target paths or glob patterns")
+	cmd.Flags().StringVar(&targetFile, "target-file", "", "File of newline-separated targets to add to --targets (blank lines and # comments are ignored)")
+	cmd.Flags().Int32Var(&largest, "largest", 0, "How many of each directory's largest files to list (0 uses the server default)")
+
+	return cmd
+}
+
+// printPlan writes a plan's per-target table, each directory's largest
+// files and the totals
+func printPlan(w io.Writer, resp *pb.PlanDestructionResponse) {
+	t := newTable("TARGET", "KIND", "FILES", "BYTES", "POLICY").alignRight(2, 3)
+	for _, plan := range resp.Targets {
+		kind := "file"
+		if plan.IsDir {
+			kind = "dir"
+		}
+
+		status := "allowed"
+		switch {
+		case !plan.Allowed:
+			status = plan.ErrorMessage
+		case plan.ErrorMessage != "":
+			status = "error: " + plan.ErrorMessage
+		}
+
+		t.addRow(plan.Target, kind, strconv.FormatInt(plan.Files, 10), strconv.FormatInt(plan.Bytes, 10), status)
+	}
+	t.write(w)
+
+	for _, plan := range resp.Targets {
+		if len(plan.LargestFiles) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\nLargest files in %s:\n", plan.Target)
+		for _, file := range plan.LargestFiles {
+			_, _ = fmt.Fprintf(w, "  %12d  %s\n", file.Size, file.Path)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%s\n", planSummary(resp))
+}
+
+// planSummary says how much a plan would destroy, e.g. "This will destroy
+// 14302 files totalling 3328599654 bytes"
+func planSummary(resp *pb.PlanDestructionResponse) string {
+	summary := fmt.Sprintf("This will destroy %d files totalling %d bytes", resp.TotalFiles, resp.TotalBytes)
+	if resp.RefusedTargets > 0 {
+		summary += fmt.Sprintf(" (%d of %d targets refused)", resp.RefusedTargets, len(resp.Targets))
+	}
+	return summary
+}
+
+// confirmPlan plans targets on the server, shows the plan and asks the
+// operator whether to continue; anything but "y" or "yes" aborts. Dry runs
+// and types without file targets are not planned. Like the irreversible
+// prompt it runs before the request's own timeout starts.
+func confirmPlan(cmd *cobra.Command, client pb.BurnDeviceServiceClient, t pb.DestructionType, targets []string, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+	if t != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION && t != pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION {
+		return fmt.Errorf("--plan only applies to FILE_DELETION and FILE_CORRUPTION")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+	resp, err := client.PlanDestruction(ctx, &pb.PlanDestructionRequest{Targets: targets})
+	if err != nil {
+		return fmt.Errorf("failed to plan destruction: %w", err)
+	}
+
+	prompt := cmd.ErrOrStderr()
+	printPlan(prompt, resp)
+	_, _ = fmt.Fprint(prompt, "⚠️  Continue? [y/N]: ")
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("destruction not confirmed; aborting")
+	}
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// planServer answers plans with a fixed estimate and counts executions
+type planServer struct {
+	fakeServer
+
+	mu       sync.Mutex
+	executed int
+}
+
+func (s *planServer) PlanDestruction(ctx context.Context, req *pb.PlanDestructionRequest) (*pb.PlanDestructionResponse, error) {
+	return &pb.PlanDestructionResponse{
+		Targets: []*pb.TargetPlan{
+			{
+				Target:  "/tmp/data",
+				IsDir:   true,
+				Files:   14302,
+				Bytes:   3328599654,
+				Allowed: true,
+				LargestFiles: []*pb.FileSize{
+					{Path: "/tmp/data/big.bin", Size: 1073741824},
+				},
+			},
+			{Target: "/etc", ErrorMessage: "Target is in blocked list"},
+		},
+		TotalFiles:     14302,
+		TotalBytes:     3328599654,
+		RefusedTargets: 1,
+	}, nil
+}
+
+func (s *planServer) ExecuteDestruction(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	s.mu.Lock()
+	s.executed++
+	s.mu.Unlock()
+	return &pb.ExecuteDestructionResponse{Success: true, Message: "Destruction completed"}, nil
+}
+
+func (s *planServer) executions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.executed
+}
+
+func TestPlanCommand(t *testing.T) {
+	addr := startFakeServer(t, &planServer{})
+
+	var stdout bytes.Buffer
+	clientCmd := NewClientCommand()
+	clientCmd.SetOut(&stdout)
+	clientCmd.SetErr(&bytes.Buffer{})
+	clientCmd.SetArgs([]string{"plan", "--server", addr, "--targets", "/tmp/data,/etc"})
+	if err := clientCmd.Execute(); err != nil {
+		t.Fatalf("Expected plan to succeed, got: %v", err)
+	}
+
+	output := stdout.String()
+	for _, want := range []string{
+		"/tmp/data",
+		"14302",
+		"Target is in blocked list",
+		"Largest files in /tmp/data:",
+		"/tmp/data/big.bin",
+		"This will destroy 14302 files totalling 3328599654 bytes (1 of 2 targets refused)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestExecuteWithPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		extra     []string
+		expectErr bool
+		executed  int
+		prompt    bool
+	}{
+		{name: "confirmed", input: "y\n", executed: 1, prompt: true},
+		{name: "declined", input: "n\n", expectErr: true, prompt: true},
+		{name: "no input", expectErr: true, prompt: true},
+		{name: "dry run", extra: []string{"--dry-run"}, executed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &planServer{}
+			addr := startFakeServer(t, server)
+
+			var stderr bytes.Buffer
+			clientCmd := NewClientCommand()
+			clientCmd.SetOut(&bytes.Buffer{})
+			clientCmd.SetErr(&stderr)
+			clientCmd.SetIn(strings.NewReader(tt.input))
+			clientCmd.SetArgs(append([]string{
+				"execute",
+				"--server", addr,
+				"--type", "FILE_DELETION",
+				"--targets", "/tmp/data",
+				"--confirm",
+				"--plan",
+			}, tt.extra...))
+
+			err := clientCmd.Execute()
+			if tt.expectErr && err == nil {
+				t.Error("Expected an error, got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if prompted := strings.Contains(stderr.String(), "This will destroy 14302 files"); prompted != tt.prompt {
+				t.Errorf("Expected prompt %v, got stderr:\n%s", tt.prompt, stderr.String())
+			}
+			if executed := server.executions(); executed != tt.executed {
+				t.Errorf("Expected %d executions, got %d", tt.executed, executed)
+			}
+		})
+	}
+}
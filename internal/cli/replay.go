@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/ai"
+)
+
+// replayStep is a scenario step resolved into the request that runs it
+type replayStep struct {
+	step *ai.AttackStep
+	req  *pb.ExecuteDestructionRequest
+}
+
+func newReplayCommand() *cobra.Command {
+	var (
+		scenarioFile    string
+		maxSeverity     string
+		confirm         bool
+		confirmPhrase   string
+		dryRun          bool
+		continueOnError bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Execute every step of a saved scenario in order",
+		Long:  "按顺序执行已保存场景（generate examples 生成的 JSON 格式）中的每个步骤；默认在第一个失败的步骤处停止，所有步骤的严重性都会先与 --max-severity 比较",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !confirm && !dryRun {
+				return fmt.Errorf("必须使用 --confirm 标志确认破坏性操作")
+			}
+
+			maxSev, err := parseSeverity(maxSeverity)
+			if err != nil {
+				return err
+			}
+
+			scenario, err := loadScenarioFile(scenarioFile)
+			if err != nil {
+				return err
+			}
+
+			// Every step is checked before any runs, so a scenario is never
+			// abandoned halfway over a step it could not have run
+			steps, err := replaySteps(scenario, maxSev)
+			if err != nil {
+				return err
+			}
+			for _, s := range steps {
+				s.req.ConfirmDestruction = confirm
+				s.req.ConfirmationText = confirmPhrase
+				s.req.DryRun = dryRun
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			bannerCtx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			err = showBanner(bannerCtx, cmd, client)
+			cancel()
+			if err != nil {
+				return err
+			}
+
+			logrus.WithFields(logrus.Fields{
+				"scenario": scenario.ID,
+				"steps":    len(steps),
+				"dry_run":  dryRun,
+			}).Warn("🔥 Replaying scenario")
+
+			failed := 0
+			for i, s := range steps {
+				if !out.json {
+					out.Printf("▶️  Step %d/%d: %s (%s) on %s\n", i+1, len(steps), s.step.Type,
+						strings.TrimPrefix(s.req.Severity.String(), "DESTRUCTION_SEVERITY_"), strings.Join(s.req.Targets, ", "))
+				}
+
+				resp, err := executeReplayStep(cmd, client, s.req)
+				if err != nil {
+					resp = &pb.ExecuteDestructionResponse{Message: err.Error()}
+				}
+
+				if out.json {
+					if err := out.JSONLine(resp); err != nil {
+						return err
+					}
+				} else if resp.Success {
+					out.Printf("   ✅ %s\n", resp.Message)
+				} else {
+					out.Printf("   ❌ %s\n", resp.Message)
+				}
+
+				if resp.Success {
+					continue
+				}
+				failed++
+				if !continueOnError {
+					return fmt.Errorf("step %d of %d failed: %s", i+1, len(steps), resp.Message)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d steps failed", failed, len(steps))
+			}
+			if !out.json {
+				out.Printf("✅ Replayed %d steps of scenario %s\n", len(steps), scenario.ID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&scenarioFile, "scenario-file", "", "Scenario JSON file to replay (required)")
+	cmd.Flags().StringVar(&maxSeverity, "max-severity", "MEDIUM", "Refuse scenarios with any step above this severity (LOW, MEDIUM, HIGH, CRITICAL)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive operation")
+	cmd.Flags().StringVar(&confirmPhrase, "confirm-phrase", "", "Confirmation phrase the server requires for high-severity requests")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview every step without changing anything")
+	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Keep going after a step fails")
+
+	if err := cmd.MarkFlagRequired("scenario-file"); err != nil {
+		logrus.WithError(err).Error("Failed to mark scenario-file flag as required")
+	}
+
+	return cmd
+}
+
+// replaySteps resolves the steps of scenario into requests in execution
+// order, refusing any step whose severity is above maxSeverity. Steps
+// without their own severity use the scenario's.
+func replaySteps(scenario *ai.AttackScenario, maxSeverity pb.DestructionSeverity) ([]replayStep, error) {
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("scenario %s has no steps", scenario.ID)
+	}
+
+	ordered := make([]*ai.AttackStep, len(scenario.Steps))
+	for i := range scenario.Steps {
+		ordered[i] = &scenario.Steps[i]
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Order < ordered[j].Order
+	})
+
+	steps := make([]replayStep, 0, len(ordered))
+	for _, step := range ordered {
+		dtype, err := parseDestructionType(step.Type)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", step.Order, err)
+		}
+
+		severity := step.Severity
+		if severity == "" {
+			severity = scenario.Severity
+		}
+		sev, err := parseSeverity(severity)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", step.Order, err)
+		}
+		if sev > maxSeverity {
+			return nil, fmt.Errorf("step %d severity %s exceeds --max-severity %s", step.Order, sev, maxSeverity)
+		}
+
+		steps = append(steps, replayStep{
+			step: step,
+			req: &pb.ExecuteDestructionRequest{
+				Type:         dtype,
+				Targets:      step.Targets,
+				Severity:     sev,
+				AiScenarioId: scenario.ID,
+			},
+		})
+	}
+
+	return steps, nil
+}
+
+// executeReplayStep runs one step with its own timeout
+func executeReplayStep(cmd *cobra.Command, client pb.BurnDeviceServiceClient, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.ExecuteDestruction(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("execution failed: %w", err)
+	}
+	return resp, nil
+}
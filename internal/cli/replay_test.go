@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// replayServer records the steps it executes and fails those of one type
+type replayServer struct {
+	fakeServer
+
+	mu       sync.Mutex
+	executed []pb.DestructionType
+	failType pb.DestructionType
+}
+
+func (s *replayServer) ExecuteDestruction(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	s.mu.Lock()
+	s.executed = append(s.executed, req.Type)
+	s.mu.Unlock()
+
+	if req.Type == s.failType {
+		return &pb.ExecuteDestructionResponse{Message: "Destruction failed"}, nil
+	}
+	return &pb.ExecuteDestructionResponse{Success: true, Message: "Destruction completed"}, nil
+}
+
+func (s *replayServer) types() []pb.DestructionType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]pb.DestructionType(nil), s.executed...)
+}
+
+// writeReplayScenario writes a scenario whose steps are listed out of order
+func writeReplayScenario(t *testing.T, severity string) string {
+	tempDir, err := os.MkdirTemp("", "burndevice_replay_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	})
+
+	scenario := `{
+  "id": "replay_test",
+  "severity": "` + severity + `",
+  "steps": [
+    {"order": 3, "type": "DISK_FILL", "targets": ["/tmp"]},
+    {"order": 1, "type": "FILE_DELETION", "targets": ["/tmp/a.txt"]},
+    {"order": 2, "type": "MEMORY_EXHAUSTION", "targets": ["system_memory"]}
+  ]
+}`
+	path := filepath.Join(tempDir, "scenario.json")
+	if err := os.WriteFile(path, []byte(scenario), 0600); err != nil {
+		t.Fatalf("Failed to write scenario: %v", err)
+	}
+	return path
+}
+
+func TestReplay(t *testing.T) {
+	ordered := []pb.DestructionType{
+		pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+		pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL,
+	}
+
+	tests := []struct {
+		name      string
+		severity  string
+		failType  pb.DestructionType
+		args      []string
+		expectErr bool
+		executed  []pb.DestructionType
+	}{
+		{
+			name:     "all steps in order",
+			severity: "LOW",
+			executed: ordered,
+		},
+		{
+			name:      "stops on the first failure",
+			severity:  "LOW",
+			failType:  pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+			expectErr: true,
+			executed:  ordered[:2],
+		},
+		{
+			name:      "continues on error when asked",
+			severity:  "LOW",
+			failType:  pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+			args:      []string{"--continue-on-error"},
+			expectErr: true,
+			executed:  ordered,
+		},
+		{
+			name:      "severity above the maximum runs nothing",
+			severity:  "HIGH",
+			args:      []string{"--max-severity", "MEDIUM"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &replayServer{failType: tt.failType}
+			addr := startFakeServer(t, server)
+
+			var stdout bytes.Buffer
+			clientCmd := NewClientCommand()
+			clientCmd.SetOut(&stdout)
+			clientCmd.SetErr(&stdout)
+			clientCmd.SetArgs(append([]string{
+				"replay",
+				"--server", addr,
+				"--scenario-file", writeReplayScenario(t, tt.severity),
+				"--confirm",
+			}, tt.args...))
+
+			err := clientCmd.Execute()
+			if tt.expectErr && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+
+			executed := server.types()
+			if len(executed) != len(tt.executed) {
+				t.Fatalf("Expected %d steps executed, got %v", len(tt.executed), executed)
+			}
+			for i := range executed {
+				if executed[i] != tt.executed[i] {
+					t.Errorf("Expected step %d to be %s, got %s", i+1, tt.executed[i], executed[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReplayStepSeverity(t *testing.T) {
+	path := writeReplayScenario(t, "LOW")
+	scenario, err := loadScenarioFile(path)
+	if err != nil {
+		t.Fatalf("Failed to load scenario: %v", err)
+	}
+
+	// A step may raise its own severity above the scenario's
+	scenario.Steps[0].Severity = "CRITICAL"
+	if _, err := replaySteps(scenario, pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("Expected the step's own severity to be refused, got: %v", err)
+	}
+
+	steps, err := replaySteps(scenario, pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	for _, s := range steps {
+		if s.req.AiScenarioId != "replay_test" {
+			t.Errorf("Expected every step to carry the scenario ID, got %q", s.req.AiScenarioId)
+		}
+	}
+}
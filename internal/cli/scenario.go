@@ -0,0 +1,419 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/ai"
+)
+
+// reviewBanner heads every exported script
+const reviewBanner = "REVIEW ONLY — DO NOT RUN"
+
+func newScenarioCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scenario",
+		Short: "Work with AI attack scenarios",
+		Long:  "处理 AI 生成的攻击场景",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "list",
+			Short: "List scenarios stored on the server",
+			Long:  "列出服务器上保存的攻击场景",
+			Args:  cobra.NoArgs,
+			RunE:  listScenarios,
+		},
+		&cobra.Command{
+			Use:   "get <scenario-id>",
+			Short: "Show a stored scenario",
+			Long:  "显示服务器上保存的攻击场景",
+			Args:  cobra.ExactArgs(1),
+			RunE:  getScenario,
+		},
+		&cobra.Command{
+			Use:   "delete <scenario-id>",
+			Short: "Delete a stored scenario",
+			Long:  "删除服务器上保存的攻击场景",
+			Args:  cobra.ExactArgs(1),
+			RunE:  deleteScenario,
+		},
+		newScenarioExportCommand(),
+		newScenarioExportScriptCommand(),
+	)
+
+	return cmd
+}
+
+// listScenarios prints every stored scenario as a table
+func listScenarios(cmd *cobra.Command, args []string) error {
+	client, conn, err := createClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close connection")
+		}
+	}()
+
+	out, err := newListOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.ListScenarios(ctx, &pb.ListScenariosRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list scenarios: %w", err)
+	}
+
+	if out.json {
+		return out.JSON(resp)
+	}
+
+	if len(resp.Scenarios) == 0 {
+		out.Println("No stored scenarios")
+		return nil
+	}
+
+	printScenarioTable(out, resp.Scenarios)
+	return nil
+}
+
+// getScenario prints a stored scenario
+func getScenario(cmd *cobra.Command, args []string) error {
+	scenario, err := fetchScenario(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	out, err := newOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if out.json {
+		return out.JSON(scenario)
+	}
+	printScenario(out, scenario)
+	return nil
+}
+
+// deleteScenario removes a stored scenario
+func deleteScenario(cmd *cobra.Command, args []string) error {
+	client, conn, err := createClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close connection")
+		}
+	}()
+
+	out, err := newOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.DeleteScenario(ctx, &pb.DeleteScenarioRequest{ScenarioId: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to delete scenario: %w", err)
+	}
+
+	if out.json {
+		return out.JSON(resp)
+	}
+
+	if !resp.Deleted {
+		return fmt.Errorf("no stored scenario with ID %s", args[0])
+	}
+
+	out.Printf("🗑️  Scenario %s deleted\n", args[0])
+	return nil
+}
+
+func newScenarioExportCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export <scenario-id>",
+		Short: "Save a stored scenario to a local JSON file for review",
+		Long:  "将服务器上保存的场景导出为本地 JSON 文件，便于执行前审阅；导出的文件可直接用于 export-script",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scenario, err := fetchScenario(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(scenarioFile(scenario), "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode scenario: %w", err)
+			}
+
+			path := output
+			if path == "" {
+				path = scenario.ScenarioId + ".json"
+			}
+			if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+				return fmt.Errorf("failed to write scenario: %w", err)
+			}
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			logrus.WithField("file", path).Info("Exported scenario")
+			out.Printf("✅ Scenario %s written to %s\n", scenario.ScenarioId, path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "", "Output file (default <scenario-id>.json)")
+
+	return cmd
+}
+
+// fetchScenario gets a stored scenario from the server
+func fetchScenario(cmd *cobra.Command, id string) (*pb.GenerateAttackScenarioResponse, error) {
+	client, conn, err := createClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close connection")
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.GetScenario(ctx, &pb.GetScenarioRequest{ScenarioId: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scenario: %w", err)
+	}
+	return resp.Scenario, nil
+}
+
+// scenarioFile converts a stored scenario to the JSON shape the AI returns,
+// which loadScenarioFile reads back
+func scenarioFile(scenario *pb.GenerateAttackScenarioResponse) *ai.AttackScenario {
+	file := &ai.AttackScenario{
+		ID:          scenario.ScenarioId,
+		Description: scenario.Description,
+		Severity:    strings.TrimPrefix(scenario.EstimatedSeverity.String(), "DESTRUCTION_SEVERITY_"),
+		Rationale:   scenario.Rationale,
+		Warnings:    scenario.Warnings,
+	}
+	for _, step := range scenario.Steps {
+		file.Steps = append(file.Steps, ai.AttackStep{
+			Order:       int(step.Order),
+			Type:        strings.TrimPrefix(step.Type.String(), "DESTRUCTION_TYPE_"),
+			Description: step.Description,
+			Targets:     step.Targets,
+			Commands:    step.Commands,
+			Rationale:   step.Rationale,
+			Risk:        step.Risk,
+		})
+	}
+	return file
+}
+
+// printScenario describes a scenario and each of its steps
+func printScenario(w io.Writer, scenario *pb.GenerateAttackScenarioResponse) {
+	fmt.Fprintf(w, "ID: %s\n", scenario.ScenarioId)
+	fmt.Fprintf(w, "Description: %s\n", scenario.Description)
+	fmt.Fprintf(w, "Estimated Severity: %s\n", scenario.EstimatedSeverity.String())
+	if scenario.CreatedAt != nil {
+		fmt.Fprintf(w, "Created: %s\n", scenario.CreatedAt.AsTime().Local().Format(time.RFC3339))
+	}
+	if scenario.Rationale != "" {
+		fmt.Fprintf(w, "Rationale: %s\n", scenario.Rationale)
+	}
+	if len(scenario.Warnings) > 0 {
+		fmt.Fprintf(w, "\n⚠️  Warnings:\n")
+		for _, warning := range scenario.Warnings {
+			fmt.Fprintf(w, "  - %s\n", warning)
+		}
+	}
+	fmt.Fprintf(w, "\n📋 Steps:\n")
+
+	for _, step := range scenario.Steps {
+		fmt.Fprintf(w, "\n%d. %s\n", step.Order, step.Description)
+		fmt.Fprintf(w, "   Type: %s\n", step.Type.String())
+		if len(step.Targets) > 0 {
+			fmt.Fprintf(w, "   Targets: %s\n", strings.Join(step.Targets, ", "))
+		}
+		if step.Rationale != "" {
+			fmt.Fprintf(w, "   Rationale: %s\n", step.Rationale)
+		}
+		if step.Risk != "" {
+			fmt.Fprintf(w, "   Risk: %s\n", step.Risk)
+		}
+		for _, command := range step.Commands {
+			fmt.Fprintf(w, "   $ %s\n", command)
+		}
+	}
+}
+
+// printScenarioTable writes scenario summaries as an aligned table
+func printScenarioTable(w io.Writer, scenarios []*pb.ScenarioSummary) {
+	t := newTable("ID", "SEVERITY", "STEPS", "CREATED", "DESCRIPTION")
+	for _, scenario := range scenarios {
+		t.addRow(
+			scenario.ScenarioId,
+			strings.TrimPrefix(scenario.EstimatedSeverity.String(), "DESTRUCTION_SEVERITY_"),
+			fmt.Sprintf("%d", scenario.Steps),
+			scenario.CreatedAt.AsTime().Local().Format(time.RFC3339),
+			scenario.Description)
+	}
+	t.write(w)
+}
+
+func newScenarioExportScriptCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export-script <scenario-file>",
+		Short: "Export a scenario as an annotated review-only shell script",
+		Long:  "将场景导出为带注释的 shell 脚本，仅供审阅，所有命令均被注释，不会执行",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scenario, err := loadScenarioFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			script := renderReviewScript(scenario)
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			if output == "" {
+				out.Printf("%s", script)
+				return nil
+			}
+
+			if err := os.WriteFile(output, []byte(script), 0600); err != nil {
+				return fmt.Errorf("failed to write script: %w", err)
+			}
+
+			logrus.WithField("file", output).Info("Exported review script")
+			out.Printf("✅ Review script written to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "", "Output file (default stdout)")
+
+	return cmd
+}
+
+// loadScenarioFile reads a scenario in the JSON shape the AI returns
+func loadScenarioFile(path string) (*ai.AttackScenario, error) {
+	// #nosec G304 - Path is supplied by the operator
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var scenario ai.AttackScenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+
+	return &scenario, nil
+}
+
+// renderReviewScript renders scenario as a shell script in which every
+// command is commented out and annotated. The script exits before reaching
+// any of them should someone run it anyway.
+func renderReviewScript(scenario *ai.AttackScenario) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "#!/bin/sh\n")
+	fmt.Fprintf(&b, "# %s\n", strings.Repeat("=", 60))
+	fmt.Fprintf(&b, "# %s\n", reviewBanner)
+	fmt.Fprintf(&b, "# This script documents the commands implied by BurnDevice scenario\n")
+	fmt.Fprintf(&b, "# %q. Every command is commented out; nothing here is executed.\n", scenario.ID)
+	fmt.Fprintf(&b, "# %s\n", strings.Repeat("=", 60))
+	fmt.Fprintf(&b, "echo '%s' >&2\n", reviewBanner)
+	fmt.Fprintf(&b, "exit 1\n\n")
+
+	writeComment(&b, "Description: ", scenario.Description)
+	writeComment(&b, "Severity: ", scenario.Severity)
+	if scenario.Rationale != "" {
+		writeComment(&b, "Rationale: ", scenario.Rationale)
+	}
+	for _, warning := range scenario.Warnings {
+		writeComment(&b, "Warning: ", warning)
+	}
+
+	flagged := 0
+	for _, step := range scenario.Steps {
+		fmt.Fprintf(&b, "\n# --- Step %d: %s ---\n", step.Order, step.Type)
+		writeComment(&b, "", step.Description)
+		if len(step.Targets) > 0 {
+			writeComment(&b, "Targets: ", strings.Join(step.Targets, ", "))
+		}
+		if step.Rationale != "" {
+			writeComment(&b, "Rationale: ", step.Rationale)
+		}
+		if step.Risk != "" {
+			writeComment(&b, "Risk: ", step.Risk)
+		}
+
+		if len(step.Commands) == 0 {
+			fmt.Fprintf(&b, "# (no commands provided)\n")
+			continue
+		}
+
+		for _, command := range step.Commands {
+			if reason := ai.DangerousCommand(command); reason != "" {
+				flagged++
+				fmt.Fprintf(&b, "# ⚠️  DANGEROUS: %s\n", reason)
+			}
+			writeComment(&b, "$ ", command)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n# %d command(s) flagged as dangerous\n", flagged)
+
+	return b.String()
+}
+
+// writeComment writes text as shell comment lines, prefixing the first
+func writeComment(b *strings.Builder, prefix, text string) {
+	for i, line := range strings.Split(text, "\n") {
+		if i == 0 {
+			line = prefix + line
+		}
+		fmt.Fprintf(b, "# %s\n", line)
+	}
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/ai"
+)
+
+func TestRenderReviewScript(t *testing.T) {
+	scenario := &ai.AttackScenario{
+		ID:          "scenario_1",
+		Description: "Delete test files",
+		Severity:    "LOW",
+		Warnings:    []string{"Only run in a sandbox"},
+		Steps: []ai.AttackStep{
+			{
+				Order:       1,
+				Type:        "FILE_DELETION",
+				Description: "Remove cache",
+				Targets:     []string{"/tmp/cache"},
+				Commands:    []string{"rm -rf /tmp/cache"},
+				Rationale:   "Check cache rebuild",
+				Risk:        "Cache is lost",
+			},
+			{
+				Order:       2,
+				Type:        "DISK_FILL",
+				Description: "Wipe the disk",
+				Commands:    []string{"dd if=/dev/zero of=/dev/sda"},
+			},
+		},
+	}
+
+	script := renderReviewScript(scenario)
+
+	if !strings.Contains(script, "# "+reviewBanner) {
+		t.Error("Expected script to carry the review-only banner")
+	}
+
+	for _, want := range []string{
+		"# $ rm -rf /tmp/cache",
+		"# $ dd if=/dev/zero of=/dev/sda",
+		"# Rationale: Check cache rebuild",
+		"# Risk: Cache is lost",
+		"# Warning: Only run in a sandbox",
+		"# ⚠️  DANGEROUS: writes directly to a block device",
+		"# 1 command(s) flagged as dangerous",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected script to contain %q", want)
+		}
+	}
+
+	// Nothing after the exit guard may be an uncommented command
+	body := script[strings.Index(script, "exit 1\n")+len("exit 1\n"):]
+	for _, line := range strings.Split(body, "\n") {
+		if line != "" && !strings.HasPrefix(line, "#") {
+			t.Errorf("Expected only comments after exit guard, got %q", line)
+		}
+	}
+}
+
+func TestLoadScenarioFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "burndevice_scenario_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	path := filepath.Join(dir, "scenario.json")
+	data := `{"id":"s1","severity":"LOW","steps":[{"order":1,"type":"FILE_DELETION","commands":["rm /tmp/x"]}]}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write scenario: %v", err)
+	}
+
+	scenario, err := loadScenarioFile(path)
+	if err != nil {
+		t.Fatalf("loadScenarioFile failed: %v", err)
+	}
+	if scenario.ID != "s1" || len(scenario.Steps) != 1 || scenario.Steps[0].Commands[0] != "rm /tmp/x" {
+		t.Errorf("Unexpected scenario: %+v", scenario)
+	}
+
+	if _, err := loadScenarioFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestScenarioFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "burndevice_scenario_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	stored := &pb.GenerateAttackScenarioResponse{
+		ScenarioId:        "scenario_1",
+		Description:       "Stop the web tier",
+		EstimatedSeverity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		Steps: []*pb.AttackStep{{
+			Order:    1,
+			Type:     pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
+			Targets:  []string{"nginx"},
+			Commands: []string{"systemctl stop nginx"},
+		}},
+	}
+
+	// An exported scenario reads back as the AI's own JSON
+	data, err := json.Marshal(scenarioFile(stored))
+	if err != nil {
+		t.Fatalf("Failed to encode scenario: %v", err)
+	}
+	path := filepath.Join(dir, "scenario_1.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write scenario: %v", err)
+	}
+	scenario, err := loadScenarioFile(path)
+	if err != nil {
+		t.Fatalf("loadScenarioFile failed: %v", err)
+	}
+	if scenario.ID != "scenario_1" || scenario.Severity != "MEDIUM" {
+		t.Errorf("Unexpected scenario: %+v", scenario)
+	}
+	if len(scenario.Steps) != 1 || scenario.Steps[0].Type != "SERVICE_TERMINATION" || scenario.Steps[0].Commands[0] != "systemctl stop nginx" {
+		t.Errorf("Unexpected steps: %+v", scenario.Steps)
+	}
+}
+
+func TestPrintScenarioTable(t *testing.T) {
+	var buf bytes.Buffer
+	printScenarioTable(&buf, []*pb.ScenarioSummary{{
+		ScenarioId:        "scenario_1",
+		Description:       "Stop the web tier",
+		EstimatedSeverity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		CreatedAt:         timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Steps:             3,
+	}})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") {
+		t.Fatalf("Expected a header and one row, got %q", buf.String())
+	}
+	for _, want := range []string{"scenario_1", "HIGH", "3", "Stop the web tier"} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("Expected the row to contain %q, got %q", want, lines[1])
+		}
+	}
+}
+
+func TestScenarioCommands(t *testing.T) {
+	cmd := newScenarioCommand()
+	for _, name := range []string{"list", "get", "delete", "export", "export-script"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("Expected a %q subcommand, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func newScheduleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Schedule destructions to run later",
+		Long:  "计划在延迟之后或按 cron 表达式执行破坏性测试",
+	}
+
+	cmd.AddCommand(
+		newScheduleCreateCommand(),
+		&cobra.Command{
+			Use:   "list",
+			Short: "List pending schedules",
+			Long:  "列出待执行的计划",
+			Args:  cobra.NoArgs,
+			RunE:  listSchedules,
+		},
+		&cobra.Command{
+			Use:   "delete <schedule-id>",
+			Short: "Delete a pending schedule",
+			Long:  "删除待执行的计划",
+			Args:  cobra.ExactArgs(1),
+			RunE:  deleteSchedule,
+		},
+	)
+
+	return cmd
+}
+
+func newScheduleCreateCommand() *cobra.Command {
+	var (
+		destructionType string
+		targets         []string
+		severity        string
+		confirm         bool
+		confirmPhrase   string
+		dryRun          bool
+		recursive       bool
+		duration        time.Duration
+		intensity       int32
+		fileDescriptors bool
+		yesIKnow        bool
+		quarantine      bool
+		autoRestore     time.Duration
+		failurePolicy   string
+		skipPreflight   bool
+		delay           time.Duration
+		cronExpr        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Schedule a destruction after a delay or on a cron expression",
+		Long:  "计划一次破坏性测试：--delay 在延迟后执行一次，--cron 按 cron 表达式（服务器本地时间）重复执行",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !confirm && !dryRun {
+				return fmt.Errorf("必须使用 --confirm 标志确认破坏性操作")
+			}
+			if (delay == 0) == (cronExpr == "") {
+				return fmt.Errorf("exactly one of --delay and --cron must be set")
+			}
+
+			dtype, err := parseDestructionType(destructionType)
+			if err != nil {
+				return err
+			}
+
+			sev, err := parseSeverity(severity)
+			if err != nil {
+				return err
+			}
+
+			policy, err := parseFailurePolicy(failurePolicy)
+			if err != nil {
+				return err
+			}
+
+			client, conn, err := createClient(cmd)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := conn.Close(); err != nil {
+					logrus.WithError(err).Warn("Failed to close connection")
+				}
+			}()
+
+			out, err := newOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer out.Close()
+
+			req := &pb.ScheduleDestructionRequest{
+				Request: &pb.ExecuteDestructionRequest{
+					Type:               dtype,
+					Targets:            targets,
+					Severity:           sev,
+					ConfirmDestruction: confirm,
+					ConfirmationText:   confirmPhrase,
+					DryRun:             dryRun,
+					Recursive:          recursive,
+					Duration:           durationpb.New(duration),
+					Intensity:          intensity,
+					FileDescriptors:    fileDescriptors,
+					Quarantine:         quarantine,
+					AutoRestoreAfter:   durationpb.New(autoRestore),
+					FailurePolicy:      policy,
+					SkipPreflight:      skipPreflight,
+				},
+				Cron: cronExpr,
+			}
+			if delay != 0 {
+				req.Delay = durationpb.New(delay)
+			}
+
+			req.Request.AcknowledgeIrreversible, err = acknowledgeIrreversible(cmd, client, dtype, dryRun, yesIKnow)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+			defer cancel()
+
+			resp, err := client.ScheduleDestruction(ctx, req)
+			if err != nil {
+				return fmt.Errorf("failed to schedule destruction: %w", err)
+			}
+
+			if out.json {
+				return out.JSON(resp)
+			}
+
+			out.Printf("⏰ Destruction scheduled\n")
+			out.Printf("Schedule ID: %s\n", resp.Schedule.ScheduleId)
+			out.Printf("Next run: %s\n", resp.Schedule.NextRun.AsTime().Local().Format(time.RFC3339))
+			if resp.Schedule.Cron != "" {
+				out.Printf("Cron: %s\n", resp.Schedule.Cron)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&destructionType, "type", "", "Destruction type (required)")
+	cmd.Flags().StringSliceVar(&targets, "targets", []string{}, "Target paths or glob patterns, expanded when the schedule runs")
+	cmd.Flags().StringVar(&severity, "severity", "LOW", "Destruction severity (LOW, MEDIUM, HIGH, CRITICAL)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive operation")
+	cmd.Flags().StringVar(&confirmPhrase, "confirm-phrase", "", "Confirmation phrase the server requires for high-severity requests")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Schedule a preview instead of a real destruction")
+	cmd.Flags().BoolVar(&recursive, "recursive", false, "Delete every file under directory targets, one by one")
+	cmd.Flags().DurationVar(&duration, "duration", 0, "How long a CPU burn runs, an inode exhaustion holds or a network disruption cuts targets off (0 uses the server default)")
+	cmd.Flags().Int32Var(&intensity, "intensity", 0, "How hard a resource-pressure type pushes, 1-100: percent of memory, disk, inodes or cores consumed, or of packets dropped (0 uses the severity)")
+	cmd.Flags().BoolVar(&fileDescriptors, "file-descriptors", false, "Make inode exhaustion hold open file descriptors instead of creating files")
+	cmd.Flags().BoolVar(&yesIKnow, "yes-i-know", false, "Acknowledge irreversible types (KERNEL_PANIC, BOOT_CORRUPTION) without typing the server hostname")
+	cmd.Flags().BoolVar(&quarantine, "quarantine", false, "Move file deletion targets into the server's quarantine directory instead of deleting them")
+	cmd.Flags().DurationVar(&autoRestore, "auto-restore-after", 0, "Restore backed-up files or restart stopped services automatically after this hold (e.g. 10m)")
+	cmd.Flags().StringVar(&failurePolicy, "failure-policy", "continue", "What a failed target does to the rest of the run (continue, fail-fast)")
+	cmd.Flags().BoolVar(&skipPreflight, "skip-preflight", false, "Don't check that file deletion targets exist and can be removed before running")
+	cmd.Flags().DurationVar(&delay, "delay", 0, "Run once after this delay (e.g. 30m)")
+	cmd.Flags().StringVar(&cronExpr, "cron", "", "Run on this cron expression (e.g. \"0 2 * * *\")")
+
+	if err := cmd.MarkFlagRequired("type"); err != nil {
+		logrus.WithError(err).Error("Failed to mark type flag as required")
+	}
+
+	return cmd
+}
+
+// listSchedules prints every pending schedule as a table
+func listSchedules(cmd *cobra.Command, args []string) error {
+	client, conn, err := createClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close connection")
+		}
+	}()
+
+	out, err := newListOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.ListSchedules(ctx, &pb.ListSchedulesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	if out.json {
+		return out.JSON(resp)
+	}
+
+	if len(resp.Schedules) == 0 {
+		out.Println("No pending schedules")
+		return nil
+	}
+
+	printScheduleTable(out, resp.Schedules)
+	return nil
+}
+
+// deleteSchedule removes a pending schedule
+func deleteSchedule(cmd *cobra.Command, args []string) error {
+	client, conn, err := createClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logrus.WithError(err).Warn("Failed to close connection")
+		}
+	}()
+
+	out, err := newOutput(cmd)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), getTimeout(cmd))
+	defer cancel()
+
+	resp, err := client.DeleteSchedule(ctx, &pb.DeleteScheduleRequest{ScheduleId: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+
+	if out.json {
+		return out.JSON(resp)
+	}
+
+	if !resp.Deleted {
+		return fmt.Errorf("no pending schedule with ID %s", args[0])
+	}
+
+	out.Printf("🗑️  Schedule %s deleted\n", args[0])
+	return nil
+}
+
+// printScheduleTable writes schedules as an aligned table
+func printScheduleTable(w io.Writer, schedules []*pb.Schedule) {
+	t := newTable("ID", "TYPE", "SEVERITY", "NEXT RUN", "CRON", "LAST TASK", "TARGETS")
+	for _, schedule := range schedules {
+		cronExpr := schedule.Cron
+		if cronExpr == "" {
+			cronExpr = "-"
+		}
+		lastTask := schedule.LastTaskId
+		if lastTask == "" {
+			lastTask = "-"
+		}
+
+		t.addRow(
+			schedule.ScheduleId,
+			strings.TrimPrefix(schedule.Request.GetType().String(), "DESTRUCTION_TYPE_"),
+			strings.TrimPrefix(schedule.Request.GetSeverity().String(), "DESTRUCTION_SEVERITY_"),
+			schedule.NextRun.AsTime().Local().Format(time.RFC3339),
+			cronExpr,
+			lastTask,
+			strings.Join(schedule.Request.GetTargets(), ","))
+	}
+	t.write(w)
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// table collects rows and writes them as aligned columns under a header.
+// tabwriter only aligns left, so cells of right-aligned columns are padded
+// to the column's width before they reach it.
+type table struct {
+	headers []string
+	right   []bool
+	rows    [][]string
+}
+
+// newTable returns an empty table with the given column headers
+func newTable(headers ...string) *table {
+	return &table{
+		headers: headers,
+		right:   make([]bool, len(headers)),
+	}
+}
+
+// alignRight right-aligns the columns at the given indexes, which is how
+// numeric columns are shown
+func (t *table) alignRight(columns ...int) *table {
+	for _, c := range columns {
+		t.right[c] = true
+	}
+	return t
+}
+
+// addRow appends a row; missing cells are left empty
+func (t *table) addRow(cells ...string) {
+	row := make([]string, len(t.headers))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// write renders the header and every row to w
+func (t *table) write(w io.Writer) {
+	widths := make([]int, len(t.headers))
+	for _, row := range append([][]string{t.headers}, t.rows...) {
+		for c, cell := range row {
+			if t.right[c] && len([]rune(cell)) > widths[c] {
+				widths[c] = len([]rune(cell))
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range append([][]string{t.headers}, t.rows...) {
+		cells := make([]string, len(row))
+		for c, cell := range row {
+			if t.right[c] {
+				cell = strings.Repeat(" ", widths[c]-len([]rune(cell))) + cell
+			}
+			cells[c] = cell
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	_ = tw.Flush()
+}
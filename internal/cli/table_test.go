@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func TestTableAlignment(t *testing.T) {
+	tbl := newTable("NAME", "SIZE", "STATE").alignRight(1)
+	tbl.addRow("a", "5", "ok")
+	tbl.addRow("longer-name", "12345", "failed")
+	tbl.addRow("mid", "42", "ok")
+
+	var buf bytes.Buffer
+	tbl.write(&buf)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header and three rows, got %d lines:\n%s", len(lines), buf.String())
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "NAME SIZE STATE" {
+		t.Errorf("Expected the header row, got: %s", lines[0])
+	}
+
+	// Left-aligned columns start at the same offset on every line
+	state := strings.Index(lines[0], "STATE")
+	for _, line := range lines[1:] {
+		if cell := strings.TrimSpace(line[state:]); cell != "ok" && cell != "failed" {
+			t.Errorf("Expected STATE to start at column %d, got: %q", state, line)
+		}
+	}
+
+	// Right-aligned columns end at the same offset on every line
+	sizeEnd := strings.Index(lines[0], "SIZE") + len("SIZE")
+	for i, want := range []string{"5", "12345", "42"} {
+		line := lines[i+1]
+		if !strings.HasSuffix(line[:sizeEnd], want) {
+			t.Errorf("Expected %s to end at column %d, got: %q", want, sizeEnd, line)
+		}
+	}
+}
+
+// tasksServer lists a fixed set of running tasks
+type tasksServer struct {
+	fakeServer
+}
+
+func (tasksServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	return &pb.ListTasksResponse{Tasks: []*pb.TaskStatus{
+		{TaskId: "task_1", Type: pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, State: "running", Progress: 0.05},
+		{TaskId: "task_22", Type: pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL, State: "running", Progress: 1},
+	}}, nil
+}
+
+func TestTasksDefaultToTable(t *testing.T) {
+	addr := startFakeServer(t, tasksServer{})
+
+	tests := []struct {
+		name  string
+		args  []string
+		table bool
+	}{
+		{name: "default", table: true},
+		{name: "explicit table", args: []string{"--output", "table"}, table: true},
+		{name: "text", args: []string{"--output", "text"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			clientCmd := NewClientCommand()
+			clientCmd.SetOut(&buf)
+			clientCmd.SetErr(&buf)
+			clientCmd.SetArgs(append([]string{"tasks", "--server", addr}, tt.args...))
+
+			if err := clientCmd.Execute(); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			output := buf.String()
+			if table := strings.HasPrefix(output, "ID "); table != tt.table {
+				t.Errorf("Expected table output %v, got:\n%s", tt.table, output)
+			}
+			if tt.table && !strings.Contains(output, "   5.0%") {
+				t.Errorf("Expected PROGRESS to be right-aligned, got:\n%s", output)
+			}
+			if !tt.table && !strings.Contains(output, "📋 Task task_22") {
+				t.Errorf("Expected one block per task, got:\n%s", output)
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/ai"
 	"github.com/BurnDevice/BurnDevice/internal/config"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +22,7 @@ func NewValidateCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		newValidateConfigCommand(),
+		newValidateScenarioCommand(),
 	)
 
 	return cmd
@@ -93,3 +98,158 @@ func newValidateConfigCommand() *cobra.Command {
 
 	return cmd
 }
+
+func newValidateScenarioCommand() *cobra.Command {
+	var (
+		scenarioFile string
+		maxSeverity  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scenario",
+		Short: "Validate a scenario JSON file",
+		Long:  "检查场景文件的结构（必填字段、步骤顺序、破坏类型），并按最大严重程度执行与 AI 生成场景相同的安全校验",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			severity, err := parseSeverity(maxSeverity)
+			if err != nil {
+				return err
+			}
+
+			scenario, err := loadScenarioFile(scenarioFile)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			problems := printScenarioChecks(out, scenario, severity)
+
+			client := ai.NewDeepSeekClient(&config.AIConfig{Provider: "deepseek"})
+			if err := client.ValidateScenario(scenario, severity); err != nil {
+				_, _ = fmt.Fprintf(out, "\n❌ Safety check failed: %v\n", err)
+				problems++
+			}
+
+			if problems > 0 {
+				return fmt.Errorf("scenario validation failed: %d problems found in %s", problems, scenarioFile)
+			}
+
+			_, _ = fmt.Fprintf(out, "\n✅ Scenario is valid for max severity %s: %s\n", strings.ToUpper(maxSeverity), scenarioFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&scenarioFile, "file", "", "Scenario JSON file to validate")
+	cmd.Flags().StringVar(&maxSeverity, "max-severity", "MEDIUM", "Maximum severity the scenario may use (LOW, MEDIUM, HIGH, CRITICAL)")
+	if err := cmd.MarkFlagRequired("file"); err != nil {
+		// Log error but don't fail, as this is during command setup
+		fmt.Printf("Warning: Failed to mark file flag as required: %v\n", err)
+	}
+
+	return cmd
+}
+
+// printScenarioChecks writes the structural check of scenario and of each
+// of its steps, followed by the scenario's own warnings. It returns how
+// many problems it found.
+func printScenarioChecks(w io.Writer, scenario *ai.AttackScenario, maxSeverity pb.DestructionSeverity) int {
+	errs := scenarioErrors(scenario)
+	_, _ = fmt.Fprintf(w, "📋 Scenario %s: %s (severity %s, %d steps)\n", scenario.ID, scenario.Description, scenario.Severity, len(scenario.Steps))
+	for _, msg := range errs {
+		_, _ = fmt.Fprintf(w, "  ❌ %s\n", msg)
+	}
+	problems := len(errs)
+
+	lastOrder := 0
+	for i, step := range scenario.Steps {
+		stepErrs, warnings := stepErrors(step, lastOrder, maxSeverity)
+		if step.Order > lastOrder {
+			lastOrder = step.Order
+		}
+
+		label := fmt.Sprintf("Step %d (order %d) %s", i+1, step.Order, step.Type)
+		if len(stepErrs) == 0 {
+			_, _ = fmt.Fprintf(w, "  ✅ %s: %s\n", label, step.Description)
+		} else {
+			_, _ = fmt.Fprintf(w, "  ❌ %s: %s\n", label, strings.Join(stepErrs, "; "))
+		}
+		for _, warning := range warnings {
+			_, _ = fmt.Fprintf(w, "     ⚠️  %s\n", warning)
+		}
+		problems += len(stepErrs)
+	}
+
+	if len(scenario.Warnings) > 0 {
+		_, _ = fmt.Fprintf(w, "\n⚠️  Warnings:\n")
+		for _, warning := range scenario.Warnings {
+			_, _ = fmt.Fprintf(w, "  - %s\n", warning)
+		}
+	}
+
+	return problems
+}
+
+// scenarioErrors checks the fields every scenario needs
+func scenarioErrors(scenario *ai.AttackScenario) []string {
+	var errs []string
+	if scenario.ID == "" {
+		errs = append(errs, "id is required")
+	}
+	if scenario.Description == "" {
+		errs = append(errs, "description is required")
+	}
+	if scenario.Severity == "" {
+		errs = append(errs, "severity is required")
+	} else if _, err := parseSeverity(scenario.Severity); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(scenario.Steps) == 0 {
+		errs = append(errs, "at least one step is required")
+	}
+	return errs
+}
+
+// stepErrors checks one step. Its order must be above lastOrder, the
+// highest order of the steps before it, and its own severity may not
+// exceed maxSeverity.
+func stepErrors(step ai.AttackStep, lastOrder int, maxSeverity pb.DestructionSeverity) (errs, warnings []string) {
+	if step.Order <= lastOrder {
+		errs = append(errs, fmt.Sprintf("order %d must be greater than %d", step.Order, lastOrder))
+	}
+	if step.Type == "" {
+		errs = append(errs, "type is required")
+	} else if _, err := parseDestructionType(step.Type); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if step.Description == "" {
+		errs = append(errs, "description is required")
+	}
+	if len(step.Targets) == 0 {
+		errs = append(errs, "at least one target is required")
+	}
+	if step.Severity != "" {
+		severity, err := parseSeverity(step.Severity)
+		switch {
+		case err != nil:
+			errs = append(errs, err.Error())
+		case severity > maxSeverity:
+			errs = append(errs, fmt.Sprintf("severity %s exceeds maximum %s", step.Severity, maxSeverity))
+		}
+	}
+
+	for _, target := range step.Targets {
+		if ai.IsDangerousTarget(target) {
+			warnings = append(warnings, fmt.Sprintf("target %s is a dangerous system path", target))
+		}
+	}
+	for _, command := range step.Commands {
+		if reason := ai.DangerousCommand(command); reason != "" {
+			warnings = append(warnings, fmt.Sprintf("command %q %s", command, reason))
+		}
+	}
+	if len(step.Commands) > 0 {
+		warnings = append(warnings, "commands are for review only and are never run")
+	}
+
+	return errs, warnings
+}
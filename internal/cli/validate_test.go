@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateScenarioCommand(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_validate_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	tests := []struct {
+		name        string
+		scenario    string
+		maxSeverity string
+		expectError bool
+		contains    []string
+	}{
+		{
+			name: "valid scenario",
+			scenario: `{"id":"s1","description":"Clean temp","severity":"MEDIUM","warnings":["test only"],"steps":[
+				{"order":1,"type":"FILE_DELETION","description":"Delete files","targets":["/tmp/a"]},
+				{"order":2,"type":"cpu_burn","description":"Burn CPU","targets":["cpu"],"commands":["stress","reboot"]}]}`,
+			maxSeverity: "HIGH",
+			contains:    []string{"✅ Step 1", "✅ Step 2", "never run", "takes the host down", "test only", "Scenario is valid"},
+		},
+		{
+			name: "orders not increasing",
+			scenario: `{"id":"s2","description":"Bad order","severity":"LOW","steps":[
+				{"order":2,"type":"FILE_DELETION","description":"First","targets":["/tmp/a"]},
+				{"order":2,"type":"FILE_DELETION","description":"Second","targets":["/tmp/b"]}]}`,
+			maxSeverity: "HIGH",
+			expectError: true,
+			contains:    []string{"✅ Step 1", "❌ Step 2", "order 2 must be greater than 2"},
+		},
+		{
+			name: "unknown type and missing fields",
+			scenario: `{"severity":"LOW","steps":[
+				{"order":1,"type":"MELT_CPU","targets":[]}]}`,
+			maxSeverity: "HIGH",
+			expectError: true,
+			contains:    []string{"id is required", "unknown destruction type: MELT_CPU", "at least one target is required"},
+		},
+		{
+			name: "step severity above maximum",
+			scenario: `{"id":"s4","description":"Too hot","severity":"LOW","steps":[
+				{"order":1,"type":"FILE_DELETION","description":"Delete","targets":["/tmp/a"],"severity":"CRITICAL"}]}`,
+			maxSeverity: "HIGH",
+			expectError: true,
+			contains:    []string{"severity CRITICAL exceeds maximum"},
+		},
+		{
+			name: "scenario severity above maximum",
+			scenario: `{"id":"s5","description":"Too hot","severity":"CRITICAL","steps":[
+				{"order":1,"type":"FILE_DELETION","description":"Delete","targets":["/tmp/a"]}]}`,
+			maxSeverity: "HIGH",
+			expectError: true,
+			contains:    []string{"Safety check failed", "exceeds maximum"},
+		},
+		{
+			name: "dangerous target",
+			scenario: `{"id":"s6","description":"Boot","severity":"LOW","steps":[
+				{"order":1,"type":"FILE_DELETION","description":"Delete","targets":["/etc/passwd"]}]}`,
+			maxSeverity: "HIGH",
+			expectError: true,
+			contains:    []string{"dangerous system path"},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, "scenario"+string(rune('a'+i))+".json")
+			if err := os.WriteFile(path, []byte(tt.scenario), 0600); err != nil {
+				t.Fatalf("Failed to write scenario: %v", err)
+			}
+
+			var out bytes.Buffer
+			cmd := NewValidateCommand()
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs([]string{"scenario", "--file", path, "--max-severity", tt.maxSeverity})
+
+			err := cmd.Execute()
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error, got output: %s", out.String())
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got: %v\n%s", err, out.String())
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Expected output to contain %q, got: %s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestValidateScenarioMissingFile(t *testing.T) {
+	cmd := NewValidateCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"scenario", "--file", filepath.Join(os.TempDir(), "burndevice-no-such-scenario.json")})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "failed to read scenario") {
+		t.Errorf("Expected read error, got: %v", err)
+	}
+}
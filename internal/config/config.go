@@ -2,10 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
@@ -14,6 +17,10 @@ type Config struct {
 	Server   ServerConfig   `mapstructure:"server"`
 	AI       AIConfig       `mapstructure:"ai"`
 	Security SecurityConfig `mapstructure:"security"`
+	Storage  StorageConfig  `mapstructure:"storage"`
+	Engine   EngineConfig   `mapstructure:"engine"`
+	Hooks    HooksConfig    `mapstructure:"hooks"`
+	Metrics  MetricsConfig  `mapstructure:"metrics"`
 	LogLevel string         `mapstructure:"log_level"`
 }
 
@@ -23,7 +30,29 @@ type ServerConfig struct {
 	Port         int           `mapstructure:"port"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
 	TLS          TLSConfig     `mapstructure:"tls"`
+	// ShutdownTimeout is how long shutdown waits for running tasks to
+	// finish before cancelling them (0 cancels them at once)
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	// MetricsPort serves Prometheus metrics over HTTP at /metrics on Host
+	// (0 disables the endpoint). Deprecated: use Metrics, which takes
+	// precedence when enabled.
+	MetricsPort int `mapstructure:"metrics_port"`
+	// ConnectionBanner is shown by clients before they execute anything,
+	// e.g. the rules of a shared lab server
+	ConnectionBanner string `mapstructure:"connection_banner"`
+	// IdempotencyTTL is how long the response to a request with an
+	// idempotency key is kept to answer retries with the same key (0
+	// disables the cache, so every retry executes again)
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+}
+
+// MetricsConfig contains the Prometheus metrics endpoint configuration
+type MetricsConfig struct {
+	// Enabled serves Prometheus metrics over HTTP at /metrics on Address
+	Enabled bool   `mapstructure:"enabled"`
+	Address string `mapstructure:"address"`
 }
 
 // TLSConfig contains TLS configuration
@@ -42,16 +71,328 @@ type AIConfig struct {
 	MaxTokens      int           `mapstructure:"max_tokens"`
 	Temperature    float64       `mapstructure:"temperature"`
 	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	// APIVersion is only used by the azure provider
+	APIVersion string `mapstructure:"api_version"`
 }
 
 // SecurityConfig contains security-related configuration
 type SecurityConfig struct {
-	RequireConfirmation bool     `mapstructure:"require_confirmation"`
-	AllowedTargets      []string `mapstructure:"allowed_targets"`
-	BlockedTargets      []string `mapstructure:"blocked_targets"`
-	MaxSeverity         string   `mapstructure:"max_severity"`
-	EnableSafeMode      bool     `mapstructure:"enable_safe_mode"`
-	AuditLog            bool     `mapstructure:"audit_log"`
+	RequireConfirmation bool        `mapstructure:"require_confirmation"`
+	AllowedTargets      []string    `mapstructure:"allowed_targets"`
+	BlockedTargets      []string    `mapstructure:"blocked_targets"`
+	MaxSeverity         string      `mapstructure:"max_severity"`
+	EnableSafeMode      bool        `mapstructure:"enable_safe_mode"`
+	AuditLog            bool        `mapstructure:"audit_log"`
+	PerClientDailyQuota QuotaConfig `mapstructure:"per_client_daily_quota"`
+	BackupDir           string      `mapstructure:"backup_dir"`
+	BackupRetention     int         `mapstructure:"backup_retention"`
+	OverwriteBackups    bool        `mapstructure:"overwrite_backups"`
+	MaxCommandOutput    int         `mapstructure:"max_command_output"`
+	ShredPasses         int         `mapstructure:"shred_passes"`
+	RateLimitPerMinute  int         `mapstructure:"rate_limit_per_minute"`
+	AuthToken           string      `mapstructure:"auth_token"`
+
+	// QuarantineDir receives file deletion targets moved aside instead of
+	// deleted, mirroring their absolute paths. Quarantine makes that the
+	// default for every file deletion.
+	QuarantineDir string `mapstructure:"quarantine_dir"`
+	Quarantine    bool   `mapstructure:"quarantine"`
+
+	// WipePasses is how many times file deletion requests asking for a
+	// wipe overwrite each file; 0 uses ShredPasses
+	WipePasses int `mapstructure:"wipe_passes"`
+
+	// MaxDuration is the longest duration a request may ask a CPU burn,
+	// inode exhaustion or network disruption to hold for; 0 for no limit
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+
+	// ProtectedServices may never be stopped by service termination, in
+	// addition to the built-in critical services. AllowCriticalServices
+	// lifts the built-in list but never ProtectedServices.
+	ProtectedServices     []string `mapstructure:"protected_services"`
+	AllowCriticalServices bool     `mapstructure:"allow_critical_services"`
+
+	// EnabledTypes limits which destruction types may be executed or
+	// proposed by AI scenarios, by name without the DESTRUCTION_TYPE_
+	// prefix. Empty enables every type.
+	EnabledTypes []string `mapstructure:"enabled_types"`
+
+	// ConfirmationPhrase must be echoed by requests at or above
+	// ConfirmationPhraseSeverity in addition to confirming them. Empty
+	// disables the check.
+	ConfirmationPhrase         string `mapstructure:"confirmation_phrase"`
+	ConfirmationPhraseSeverity string `mapstructure:"confirmation_phrase_severity"`
+
+	// MaxBytesPerTask and MaxFilesPerTask cap what a single destruction
+	// may destroy; MaxBytesPerDay caps the bytes destroyed per UTC day
+	// across all tasks. 0 means unlimited.
+	MaxBytesPerTask int64 `mapstructure:"max_bytes_per_task"`
+	MaxFilesPerTask int64 `mapstructure:"max_files_per_task"`
+	MaxBytesPerDay  int64 `mapstructure:"max_bytes_per_day"`
+
+	// MaxExecutionTime bounds how long a destruction may run when its
+	// caller sets no deadline; targets not reached in time are skipped.
+	// 0 means unlimited.
+	MaxExecutionTime time.Duration `mapstructure:"max_execution_time"`
+
+	// TargetCooldown rejects destroying a target again this soon after it
+	// was last destroyed, e.g. by a script retrying in a tight loop. 0
+	// disables the cooldown.
+	TargetCooldown time.Duration `mapstructure:"target_cooldown"`
+
+	// MaxConcurrency is how many targets of a file deletion are deleted at
+	// once. 0 and 1 delete them one after another.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+
+	// MinFreeMemoryBytes and MinFreeDiskBytes refuse to start a memory
+	// exhaustion or disk fill while available memory or disk space is
+	// already below them, so a test doesn't take down a host that is
+	// short to begin with. 0 disables the floor.
+	MinFreeMemoryBytes int64 `mapstructure:"min_free_memory_bytes"`
+	MinFreeDiskBytes   int64 `mapstructure:"min_free_disk_bytes"`
+
+	// AllowRoot acknowledges running as root or an elevated Administrator.
+	// Without it the server still starts but warns loudly.
+	AllowRoot bool `mapstructure:"allow_root"`
+
+	// AllowIrreversible lets requests for types that cannot be undone, such
+	// as KERNEL_PANIC and BOOT_CORRUPTION, run at all. Each request must
+	// also acknowledge it.
+	AllowIrreversible bool `mapstructure:"allow_irreversible"`
+
+	// AllowEmptyBlocklist lets the server start with no blocked targets.
+	// Without it an empty blocklist, e.g. from a stray environment
+	// override, refuses to load.
+	AllowEmptyBlocklist bool `mapstructure:"allow_empty_blocklist"`
+
+	// AllowedWindows are the weekly periods destructions may run in. A
+	// request outside all of them is rejected, and due schedules wait for
+	// the next one. Empty allows destructions at any time.
+	AllowedWindows []TimeWindow `mapstructure:"allowed_windows"`
+	// WindowOverrideToken lets a request that carries it run outside
+	// AllowedWindows, e.g. for an emergency. Empty allows no override.
+	WindowOverrideToken string `mapstructure:"window_override_token"`
+
+	DeletionBehaviors map[string]DeletionBehavior `mapstructure:"deletion_behaviors"`
+
+	// CorruptionPercentages maps a severity to the percentage of a file's
+	// bytes file corruption overwrites. Severities left out use the
+	// built-in defaults.
+	CorruptionPercentages map[string]float64 `mapstructure:"corruption_percentages"`
+}
+
+// TimeWindow is a weekly period, e.g. 22:00 to 06:00 on weekdays. Start
+// and End are HH:MM; a window whose End is not after its Start runs past
+// midnight into the next day, and one whose End equals its Start lasts a
+// whole day. Days are the days the window opens on, such as "mon" or
+// "friday"; empty means every day. Timezone is an IANA name such as
+// "Europe/Berlin"; empty uses the server's local time.
+type TimeWindow struct {
+	Days     []string `mapstructure:"days"`
+	Start    string   `mapstructure:"start"`
+	End      string   `mapstructure:"end"`
+	Timezone string   `mapstructure:"timezone"`
+}
+
+// Weekdays returns the days w opens on, all seven when Days is empty
+func (w TimeWindow) Weekdays() ([]time.Weekday, error) {
+	if len(w.Days) == 0 {
+		return []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}, nil
+	}
+
+	days := make([]time.Weekday, 0, len(w.Days))
+	for _, name := range w.Days {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			full := strings.ToLower(day.String())
+			if strings.EqualFold(name, full) || strings.EqualFold(name, full[:3]) {
+				days = append(days, day)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+	}
+	return days, nil
+}
+
+// Clock returns Start and End as offsets from midnight
+func (w TimeWindow) Clock() (start, end time.Duration, err error) {
+	if start, err = parseClock(w.Start); err != nil {
+		return 0, 0, fmt.Errorf("invalid start: %w", err)
+	}
+	if end, err = parseClock(w.End); err != nil {
+		return 0, 0, fmt.Errorf("invalid end: %w", err)
+	}
+	return start, end, nil
+}
+
+// Location returns the time zone w is given in
+func (w TimeWindow) Location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// parseClock parses an HH:MM time of day as an offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// DeletionBehavior controls how file deletion treats its targets at one
+// severity. WipePasses is the number of random overwrites before each file
+// is unlinked; zero deletes without overwriting. FilesOnly rejects
+// directory targets.
+type DeletionBehavior struct {
+	Backup     bool `mapstructure:"backup"`
+	WipePasses int  `mapstructure:"wipe_passes"`
+	FilesOnly  bool `mapstructure:"files_only"`
+}
+
+// DeletionBehaviorFor returns the configured behavior for severity (e.g.
+// "HIGH"). Keys are matched case-insensitively because viper lowercases them.
+func (s *SecurityConfig) DeletionBehaviorFor(severity string) (DeletionBehavior, bool) {
+	for key, behavior := range s.DeletionBehaviors {
+		if strings.EqualFold(key, severity) {
+			return behavior, true
+		}
+	}
+	return DeletionBehavior{}, false
+}
+
+// CorruptionPercentFor returns the configured corruption percentage for
+// severity, matching keys case-insensitively like DeletionBehaviorFor
+func (s *SecurityConfig) CorruptionPercentFor(severity string) (float64, bool) {
+	for key, percent := range s.CorruptionPercentages {
+		if strings.EqualFold(key, severity) {
+			return percent, true
+		}
+	}
+	return 0, false
+}
+
+// StorageConfig controls where state that outlives the process is kept
+type StorageConfig struct {
+	// DataDir holds persistent state such as task history, pending
+	// schedules and the daily destruction budget. When empty, all of it is
+	// kept in memory only.
+	DataDir string `mapstructure:"data_dir"`
+	// HistoryRetention drops history entries older than this many days
+	// (0 keeps them forever)
+	HistoryRetention int `mapstructure:"history_retention"`
+	// ScenarioDir holds saved attack scenarios, one JSON file each. When
+	// empty they go in a scenarios directory inside DataDir.
+	ScenarioDir string `mapstructure:"scenario_dir"`
+}
+
+// EngineConfig tunes how destruction runs are carried out
+type EngineConfig struct {
+	// MaxOpsPerSecond and MaxBytesPerSecond pace file deletion so a run
+	// over many files doesn't saturate the disk (0 disables pacing).
+	// Requests may override them.
+	MaxOpsPerSecond   float64 `mapstructure:"max_ops_per_second"`
+	MaxBytesPerSecond int64   `mapstructure:"max_bytes_per_second"`
+	// CPUBurnUtilization is the percentage of each burned core a CPU burn
+	// keeps busy (0 means 100)
+	CPUBurnUtilization float64 `mapstructure:"cpu_burn_utilization"`
+	// MaxFDFraction is the largest share of the process's open file limit
+	// an inode exhaustion may fill with descriptors (0 means 0.9)
+	MaxFDFraction float64 `mapstructure:"max_fd_fraction"`
+	// TargetRetries is how many times a failed file deletion is retried
+	// before the target is recorded as failed, waiting RetryBackoff before
+	// the first retry and twice as long before each one after it
+	TargetRetries int           `mapstructure:"target_retries"`
+	RetryBackoff  time.Duration `mapstructure:"retry_backoff"`
+	// SuccessPolicy decides when a multi-target run succeeds: "all" (the
+	// default) needs every target to succeed, "any" at least one
+	SuccessPolicy string `mapstructure:"success_policy"`
+}
+
+// QuotaConfig caps how much a single client may destroy per day
+type QuotaConfig struct {
+	MaxBytes      int64 `mapstructure:"max_bytes"`
+	MaxOperations int   `mapstructure:"max_operations"`
+	ResetHour     int   `mapstructure:"reset_hour"`
+}
+
+// HookConfig describes a hook command. For post_target hooks, run after
+// each successful target, Args may contain the placeholders {target},
+// {backup_path}, {task_id}, {type}, {bytes_destroyed} and {files_deleted}.
+// FailDestruction only applies to post_target hooks.
+type HookConfig struct {
+	Command         string        `mapstructure:"command"`
+	Args            []string      `mapstructure:"args"`
+	Timeout         time.Duration `mapstructure:"timeout"`
+	FailDestruction bool          `mapstructure:"fail_destruction"`
+}
+
+// MaxHookTimeout is the longest a hook may run
+const MaxHookTimeout = 5 * time.Minute
+
+// HooksConfig describes commands run once around each task, e.g. to
+// snapshot a database before its data directory is deleted, and after each
+// target it destroys. Hooks get the task in BURNDEVICE_TASK_ID,
+// BURNDEVICE_TYPE, BURNDEVICE_SEVERITY and BURNDEVICE_TARGETS (one per
+// line), post execute hooks its outcome in BURNDEVICE_SUCCESS, and post
+// target hooks their target in BURNDEVICE_TARGET. A failing pre execute
+// hook aborts the task; post execute hooks run whatever the outcome.
+type HooksConfig struct {
+	PreExecute  []HookConfig `mapstructure:"pre_execute"`
+	PostExecute []HookConfig `mapstructure:"post_execute"`
+	// PostTarget runs after each target destroyed successfully, except on
+	// dry runs. security.post_hooks, where these were configured before
+	// hooks had a section of their own, is still read into it.
+	PostTarget []HookConfig `mapstructure:"post_target"`
+	// Types adds hooks for single destruction types, keyed by the names
+	// enabled_types uses, run after the hooks above
+	Types map[string]TypeHooks `mapstructure:"types"`
+}
+
+// TypeHooks are the hooks for a single destruction type
+type TypeHooks struct {
+	PreExecute  []HookConfig `mapstructure:"pre_execute"`
+	PostExecute []HookConfig `mapstructure:"post_execute"`
+}
+
+// For returns the pre and post execute hooks for destruction type t (e.g.
+// "FILE_DELETION"), matching keys case-insensitively like
+// DeletionBehaviorFor
+func (h *HooksConfig) For(t string) (pre, post []HookConfig) {
+	pre = append(pre, h.PreExecute...)
+	post = append(post, h.PostExecute...)
+	for key, hooks := range h.Types {
+		if strings.EqualFold(key, t) {
+			pre = append(pre, hooks.PreExecute...)
+			post = append(post, hooks.PostExecute...)
+		}
+	}
+	return pre, post
+}
+
+// DestructionTypes names every type enabled_types may list
+var DestructionTypes = []string{
+	"FILE_DELETION",
+	"SERVICE_TERMINATION",
+	"MEMORY_EXHAUSTION",
+	"DISK_FILL",
+	"NETWORK_DISRUPTION",
+	"BOOT_CORRUPTION",
+	"KERNEL_PANIC",
+	"FILE_CORRUPTION",
+	"CPU_BURN",
+	"INODE_EXHAUSTION",
+	"REGISTRY_CORRUPTION",
 }
 
 // Load loads configuration from file and environment variables
@@ -82,20 +423,126 @@ func Load(configFile string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if err := migrateLegacyPostHooks(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := expandEnv(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// Validate configuration
 	if err := validate(&cfg); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	if err := checkBlocklist(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	return &cfg, nil
 }
 
+// migrateLegacyPostHooks moves hooks configured under security.post_hooks
+// ahead of hooks.post_target, which replaced it
+func migrateLegacyPostHooks(cfg *Config) error {
+	if !viper.IsSet("security.post_hooks") {
+		return nil
+	}
+	var legacy []HookConfig
+	if err := viper.UnmarshalKey("security.post_hooks", &legacy); err != nil {
+		return err
+	}
+	cfg.Hooks.PostTarget = append(legacy, cfg.Hooks.PostTarget...)
+	return nil
+}
+
+// expandEnv substitutes ${VAR} and $VAR in target lists and TLS file paths
+// so they can be templated per environment. An entry that uses a variable
+// which is unset or empty is dropped rather than kept: "${TEST_DIR}" would
+// otherwise become "", a prefix of every path, and "${TEST_DIR}/data" the
+// unrelated "/data". TLS files must resolve when TLS is enabled.
+func expandEnv(cfg *Config) error {
+	cfg.Security.AllowedTargets = expandTargets("allowed_targets", cfg.Security.AllowedTargets)
+	cfg.Security.BlockedTargets = expandTargets("blocked_targets", cfg.Security.BlockedTargets)
+
+	for _, file := range []struct {
+		key   string
+		value *string
+	}{
+		{"tls.cert_file", &cfg.Server.TLS.CertFile},
+		{"tls.key_file", &cfg.Server.TLS.KeyFile},
+	} {
+		expanded, missing := expandPath(*file.value)
+		if len(missing) > 0 && cfg.Server.TLS.Enabled {
+			return fmt.Errorf("%s uses unset environment variables: %s", file.key, strings.Join(missing, ", "))
+		}
+		*file.value = expanded
+	}
+	return nil
+}
+
+// expandTargets expands every entry of a target list, dropping those that
+// use unset variables
+func expandTargets(key string, targets []string) []string {
+	expanded := make([]string, 0, len(targets))
+	for _, target := range targets {
+		value, missing := expandPath(target)
+		if len(missing) > 0 {
+			logrus.WithFields(logrus.Fields{
+				"entry":     target,
+				"variables": missing,
+			}).Warnf("⚠️  Dropping %s entry that uses unset environment variables", key)
+			continue
+		}
+		expanded = append(expanded, value)
+	}
+	return expanded
+}
+
+// expandPath expands the environment variables in value and returns the
+// names of those that are unset or empty
+func expandPath(value string) (string, []string) {
+	var missing []string
+	expanded := os.Expand(value, func(name string) string {
+		v := os.Getenv(name)
+		if v == "" {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	return expanded, missing
+}
+
+// checkBlocklist refuses a blocklist with no usable entries. Environment
+// overrides replace lists wholesale, so BURNDEVICE_SECURITY_BLOCKED_TARGETS=" "
+// would otherwise silently drop every default protection.
+func checkBlocklist(cfg *Config) error {
+	for _, target := range cfg.Security.BlockedTargets {
+		if strings.TrimSpace(target) != "" {
+			return nil
+		}
+	}
+
+	if !cfg.Security.AllowEmptyBlocklist {
+		return fmt.Errorf("blocked_targets is empty; set allow_empty_blocklist to run without a blocklist")
+	}
+
+	logrus.Warn("⚠️  blocked_targets is empty: system paths are NOT protected from destruction")
+	return nil
+}
+
 func setDefaults() {
 	// Server defaults
 	viper.SetDefault("server.host", "localhost")
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("server.read_timeout", 30*time.Second)
 	viper.SetDefault("server.write_timeout", 30*time.Second)
+	viper.SetDefault("server.idle_timeout", 0)
+	viper.SetDefault("server.shutdown_timeout", 30*time.Second)
+	viper.SetDefault("server.metrics_port", 0)
+	viper.SetDefault("server.connection_banner", "")
+	viper.SetDefault("server.idempotency_ttl", 10*time.Minute)
 	viper.SetDefault("server.tls.enabled", false)
 
 	// AI defaults
@@ -109,8 +556,39 @@ func setDefaults() {
 	// Security defaults
 	viper.SetDefault("security.require_confirmation", true)
 	viper.SetDefault("security.max_severity", "MEDIUM")
+	viper.SetDefault("security.confirmation_phrase", "")
+	viper.SetDefault("security.confirmation_phrase_severity", "HIGH")
 	viper.SetDefault("security.enable_safe_mode", true)
 	viper.SetDefault("security.audit_log", true)
+	viper.SetDefault("security.per_client_daily_quota.max_bytes", 0)
+	viper.SetDefault("security.per_client_daily_quota.max_operations", 0)
+	viper.SetDefault("security.per_client_daily_quota.reset_hour", 0)
+	viper.SetDefault("security.backup_dir", "")
+	viper.SetDefault("security.backup_retention", 0)
+	viper.SetDefault("security.overwrite_backups", false)
+	viper.SetDefault("security.quarantine_dir", "")
+	viper.SetDefault("security.quarantine", false)
+	viper.SetDefault("security.max_command_output", 4096)
+	viper.SetDefault("security.shred_passes", 3)
+	viper.SetDefault("security.wipe_passes", 0)
+	viper.SetDefault("security.max_duration", time.Hour)
+	viper.SetDefault("security.rate_limit_per_minute", 0)
+	viper.SetDefault("security.auth_token", "")
+	viper.SetDefault("security.protected_services", []string{})
+	viper.SetDefault("security.allow_critical_services", false)
+	viper.SetDefault("security.enabled_types", []string{})
+	viper.SetDefault("security.max_bytes_per_task", 0)
+	viper.SetDefault("security.max_files_per_task", 0)
+	viper.SetDefault("security.max_bytes_per_day", 0)
+	viper.SetDefault("security.max_execution_time", 0)
+	viper.SetDefault("security.target_cooldown", 0)
+	viper.SetDefault("security.max_concurrency", 1)
+	viper.SetDefault("security.min_free_memory_bytes", 0)
+	viper.SetDefault("security.min_free_disk_bytes", 0)
+	viper.SetDefault("security.allow_empty_blocklist", false)
+	viper.SetDefault("security.window_override_token", "")
+	viper.SetDefault("security.allow_root", false)
+	viper.SetDefault("security.allow_irreversible", false)
 	viper.SetDefault("security.blocked_targets", []string{
 		"/",
 		"/bin",
@@ -124,6 +602,24 @@ func setDefaults() {
 		"C:\\Users",
 	})
 
+	// Storage defaults
+	viper.SetDefault("storage.data_dir", "")
+	viper.SetDefault("storage.history_retention", 30)
+	viper.SetDefault("storage.scenario_dir", "")
+
+	// Engine defaults
+	viper.SetDefault("engine.max_ops_per_second", 0)
+	viper.SetDefault("engine.max_bytes_per_second", 0)
+	viper.SetDefault("engine.cpu_burn_utilization", 100)
+	viper.SetDefault("engine.max_fd_fraction", 0.9)
+	viper.SetDefault("engine.target_retries", 0)
+	viper.SetDefault("engine.retry_backoff", 500*time.Millisecond)
+	viper.SetDefault("engine.success_policy", "all")
+
+	// Metrics defaults
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.address", "localhost:9090")
+
 	// Logging defaults
 	viper.SetDefault("log_level", "info")
 }
@@ -134,6 +630,34 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("invalid server port: %d", cfg.Server.Port)
 	}
 
+	if cfg.Server.IdleTimeout < 0 {
+		return fmt.Errorf("server idle_timeout cannot be negative")
+	}
+
+	if cfg.Server.ShutdownTimeout < 0 {
+		return fmt.Errorf("server shutdown_timeout cannot be negative")
+	}
+
+	if cfg.Server.IdempotencyTTL < 0 {
+		return fmt.Errorf("server idempotency_ttl cannot be negative")
+	}
+
+	if cfg.Server.MetricsPort < 0 || cfg.Server.MetricsPort > 65535 {
+		return fmt.Errorf("invalid metrics port: %d", cfg.Server.MetricsPort)
+	}
+	if cfg.Server.MetricsPort == cfg.Server.Port {
+		return fmt.Errorf("metrics port must differ from the server port")
+	}
+	if cfg.Metrics.Enabled {
+		_, port, err := net.SplitHostPort(cfg.Metrics.Address)
+		if err != nil {
+			return fmt.Errorf("invalid metrics address %q: %w", cfg.Metrics.Address, err)
+		}
+		if port == strconv.Itoa(cfg.Server.Port) {
+			return fmt.Errorf("metrics address must use a different port from the server")
+		}
+	}
+
 	// Validate TLS configuration
 	if cfg.Server.TLS.Enabled {
 		if cfg.Server.TLS.CertFile == "" || cfg.Server.TLS.KeyFile == "" {
@@ -159,5 +683,198 @@ func validate(cfg *Config) error {
 		return fmt.Errorf("invalid max_severity: %s", cfg.Security.MaxSeverity)
 	}
 
+	if cfg.Security.ConfirmationPhrase != "" {
+		validPhraseSeverity := false
+		for _, s := range validSeverities {
+			if cfg.Security.ConfirmationPhraseSeverity == s {
+				validPhraseSeverity = true
+				break
+			}
+		}
+		if !validPhraseSeverity {
+			return fmt.Errorf("invalid confirmation_phrase_severity: %s", cfg.Security.ConfirmationPhraseSeverity)
+		}
+	}
+
+	for _, name := range cfg.Security.EnabledTypes {
+		known := false
+		for _, t := range DestructionTypes {
+			if strings.EqualFold(name, t) {
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Errorf("enabled_types: unknown destruction type %s", name)
+		}
+	}
+
+	quota := cfg.Security.PerClientDailyQuota
+	if quota.MaxBytes < 0 || quota.MaxOperations < 0 {
+		return fmt.Errorf("per_client_daily_quota limits cannot be negative")
+	}
+	if quota.ResetHour < 0 || quota.ResetHour > 23 {
+		return fmt.Errorf("invalid per_client_daily_quota.reset_hour: %d", quota.ResetHour)
+	}
+
+	if cfg.Security.BackupRetention < 0 {
+		return fmt.Errorf("backup_retention cannot be negative")
+	}
+
+	if cfg.Security.Quarantine && cfg.Security.QuarantineDir == "" {
+		return fmt.Errorf("quarantine requires quarantine_dir")
+	}
+
+	if cfg.Security.MaxCommandOutput < 0 {
+		return fmt.Errorf("max_command_output cannot be negative")
+	}
+
+	if cfg.Security.ShredPasses < 0 {
+		return fmt.Errorf("shred_passes cannot be negative")
+	}
+
+	if cfg.Security.WipePasses < 0 {
+		return fmt.Errorf("wipe_passes cannot be negative")
+	}
+
+	if cfg.Security.MaxDuration < 0 {
+		return fmt.Errorf("max_duration cannot be negative")
+	}
+
+	if cfg.Security.MaxBytesPerTask < 0 || cfg.Security.MaxFilesPerTask < 0 || cfg.Security.MaxBytesPerDay < 0 {
+		return fmt.Errorf("destruction budget limits cannot be negative")
+	}
+
+	if cfg.Security.MinFreeMemoryBytes < 0 || cfg.Security.MinFreeDiskBytes < 0 {
+		return fmt.Errorf("min_free_memory_bytes and min_free_disk_bytes cannot be negative")
+	}
+
+	if cfg.Security.RateLimitPerMinute < 0 {
+		return fmt.Errorf("rate_limit_per_minute cannot be negative")
+	}
+
+	if cfg.Security.MaxExecutionTime < 0 {
+		return fmt.Errorf("max_execution_time cannot be negative")
+	}
+
+	if cfg.Security.TargetCooldown < 0 {
+		return fmt.Errorf("target_cooldown cannot be negative")
+	}
+
+	if cfg.Security.MaxConcurrency < 0 {
+		return fmt.Errorf("max_concurrency cannot be negative")
+	}
+
+	for i, window := range cfg.Security.AllowedWindows {
+		if _, err := window.Weekdays(); err != nil {
+			return fmt.Errorf("allowed_windows[%d]: %w", i, err)
+		}
+		if _, _, err := window.Clock(); err != nil {
+			return fmt.Errorf("allowed_windows[%d]: %w", i, err)
+		}
+		if _, err := window.Location(); err != nil {
+			return fmt.Errorf("allowed_windows[%d]: %w", i, err)
+		}
+	}
+
+	for severity, behavior := range cfg.Security.DeletionBehaviors {
+		known := false
+		for _, s := range validSeverities {
+			if strings.EqualFold(severity, s) {
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Errorf("deletion_behaviors: invalid severity %s", severity)
+		}
+		if behavior.WipePasses < 0 {
+			return fmt.Errorf("deletion_behaviors.%s: wipe_passes cannot be negative", severity)
+		}
+	}
+
+	for severity, percent := range cfg.Security.CorruptionPercentages {
+		known := false
+		for _, s := range validSeverities {
+			if strings.EqualFold(severity, s) {
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Errorf("corruption_percentages: invalid severity %s", severity)
+		}
+		if percent <= 0 || percent > 100 {
+			return fmt.Errorf("corruption_percentages.%s: must be greater than 0 and at most 100", severity)
+		}
+	}
+
+	if cfg.Engine.MaxOpsPerSecond < 0 || cfg.Engine.MaxBytesPerSecond < 0 {
+		return fmt.Errorf("engine throttling limits cannot be negative")
+	}
+
+	if cfg.Engine.CPUBurnUtilization < 0 || cfg.Engine.CPUBurnUtilization > 100 {
+		return fmt.Errorf("engine.cpu_burn_utilization must be between 0 and 100")
+	}
+
+	if cfg.Engine.MaxFDFraction < 0 || cfg.Engine.MaxFDFraction > 1 {
+		return fmt.Errorf("engine.max_fd_fraction must be between 0 and 1")
+	}
+
+	if cfg.Engine.TargetRetries < 0 {
+		return fmt.Errorf("engine.target_retries cannot be negative")
+	}
+
+	if cfg.Engine.RetryBackoff < 0 {
+		return fmt.Errorf("engine.retry_backoff cannot be negative")
+	}
+
+	switch cfg.Engine.SuccessPolicy {
+	case "", "all", "any":
+	default:
+		return fmt.Errorf("engine.success_policy must be all or any, got %q", cfg.Engine.SuccessPolicy)
+	}
+
+	if cfg.Storage.HistoryRetention < 0 {
+		return fmt.Errorf("history_retention cannot be negative")
+	}
+
+	if err := validateHooks("hooks.pre_execute", cfg.Hooks.PreExecute); err != nil {
+		return err
+	}
+	if err := validateHooks("hooks.post_execute", cfg.Hooks.PostExecute); err != nil {
+		return err
+	}
+	if err := validateHooks("hooks.post_target", cfg.Hooks.PostTarget); err != nil {
+		return err
+	}
+	for name, hooks := range cfg.Hooks.Types {
+		known := false
+		for _, t := range DestructionTypes {
+			if strings.EqualFold(name, t) {
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Errorf("hooks.types: unknown destruction type %s", name)
+		}
+		if err := validateHooks("hooks.types."+name+".pre_execute", hooks.PreExecute); err != nil {
+			return err
+		}
+		if err := validateHooks("hooks.types."+name+".post_execute", hooks.PostExecute); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateHooks checks the hooks configured under name
+func validateHooks(name string, hooks []HookConfig) error {
+	for i, hook := range hooks {
+		if hook.Command == "" {
+			return fmt.Errorf("%s[%d]: command not specified", name, i)
+		}
+		if hook.Timeout < 0 || hook.Timeout > MaxHookTimeout {
+			return fmt.Errorf("%s[%d]: timeout must be between 0 and %s", name, i, MaxHookTimeout)
+		}
+	}
 	return nil
 }
@@ -2,8 +2,12 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/spf13/viper"
 )
 
 func TestLoad(t *testing.T) {
@@ -105,6 +109,458 @@ func TestValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name: "invalid quota reset hour",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+					PerClientDailyQuota: QuotaConfig{
+						MaxBytes:  1024,
+						ResetHour: 24,
+					},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "post target hook without command",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				Hooks: HooksConfig{
+					PostTarget: []HookConfig{
+						{Args: []string{"{target}"}},
+					},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "post target hook timeout too long",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				Hooks: HooksConfig{
+					PostTarget: []HookConfig{
+						{Command: "echo", Timeout: time.Hour},
+					},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "pre execute hook without command",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				Hooks: HooksConfig{
+					PreExecute: []HookConfig{{Args: []string{"snapshot"}}},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "hooks for unknown type",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				Hooks: HooksConfig{
+					Types: map[string]TypeHooks{
+						"FILE_SHREDDING": {PostExecute: []HookConfig{{Command: "echo"}}},
+					},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "deletion behavior for unknown severity",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+					DeletionBehaviors: map[string]DeletionBehavior{
+						"extreme": {Backup: false},
+					},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative wipe passes",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+					DeletionBehaviors: map[string]DeletionBehavior{
+						"high": {Backup: true, WipePasses: -1},
+					},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative max duration",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+					MaxDuration: -time.Second,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "unknown enabled type",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:  "MEDIUM",
+					EnabledTypes: []string{"file_deletion", "FORK_BOMB"},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative max concurrency",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:    "MEDIUM",
+					MaxConcurrency: -1,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative free memory floor",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:        "MEDIUM",
+					MinFreeMemoryBytes: -1,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative budget",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:    "MEDIUM",
+					MaxBytesPerDay: -1,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "confirmation phrase without valid severity",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:        "MEDIUM",
+					ConfirmationPhrase: "destroy it",
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative idempotency ttl",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host:           "localhost",
+					Port:           8080,
+					IdempotencyTTL: -time.Minute,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "metrics port same as server port",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host:        "localhost",
+					Port:        8080,
+					MetricsPort: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "metrics address same port as server",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+				Metrics: MetricsConfig{Enabled: true, Address: "0.0.0.0:8080"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "metrics address without port",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+				Metrics: MetricsConfig{Enabled: true, Address: "localhost"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "metrics address",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+				Metrics: MetricsConfig{Enabled: true, Address: ":9090"},
+			},
+			expectErr: false,
+		},
+		{
+			name: "allowed window with unknown weekday",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:    "MEDIUM",
+					AllowedWindows: []TimeWindow{{Days: []string{"someday"}, Start: "22:00", End: "06:00"}},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "allowed window with unknown timezone",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:    "MEDIUM",
+					AllowedWindows: []TimeWindow{{Start: "22:00", End: "06:00", Timezone: "Mars/Olympus"}},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "allowed window",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity:    "MEDIUM",
+					AllowedWindows: []TimeWindow{{Days: []string{"MON", "friday"}, Start: "22:00", End: "06:00", Timezone: "UTC"}},
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "cpu burn utilization above 100",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				Engine: EngineConfig{
+					CPUBurnUtilization: 150,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "fd fraction above 1",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				Engine: EngineConfig{
+					MaxFDFraction: 1.5,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative target retries",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+				},
+				Engine: EngineConfig{
+					TargetRetries: -1,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "quarantine without directory",
+			cfg: &Config{
+				Server: ServerConfig{
+					Host: "localhost",
+					Port: 8080,
+				},
+				Security: SecurityConfig{
+					MaxSeverity: "MEDIUM",
+					Quarantine:  true,
+				},
+				AI: AIConfig{
+					Provider: "deepseek",
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -205,4 +661,147 @@ func TestTimeoutDefaults(t *testing.T) {
 	if cfg.AI.RequestTimeout != expectedTimeout {
 		t.Errorf("Expected AI request timeout %v, got %v", expectedTimeout, cfg.AI.RequestTimeout)
 	}
+
+	if cfg.Security.MaxDuration != time.Hour {
+		t.Errorf("Expected max duration %v, got %v", time.Hour, cfg.Security.MaxDuration)
+	}
+
+	if cfg.Server.ShutdownTimeout != expectedTimeout {
+		t.Errorf("Expected shutdown timeout %v, got %v", expectedTimeout, cfg.Server.ShutdownTimeout)
+	}
+
+	if cfg.Server.IdempotencyTTL != 10*time.Minute {
+		t.Errorf("Expected idempotency TTL %v, got %v", 10*time.Minute, cfg.Server.IdempotencyTTL)
+	}
+}
+
+func TestEmptyBlocklistFromEnvironment(t *testing.T) {
+	// Viper ignores empty variables, but whitespace replaces the whole list
+	if err := os.Setenv("BURNDEVICE_SECURITY_BLOCKED_TARGETS", " "); err != nil {
+		t.Fatalf("Failed to set env var: %v", err)
+	}
+	defer func() {
+		for _, key := range []string{"BURNDEVICE_SECURITY_BLOCKED_TARGETS", "BURNDEVICE_SECURITY_ALLOW_EMPTY_BLOCKLIST"} {
+			if err := os.Unsetenv(key); err != nil {
+				t.Errorf("Failed to unset %s: %v", key, err)
+			}
+		}
+	}()
+
+	_, err := Load("")
+	if err == nil || !strings.Contains(err.Error(), "blocked_targets is empty") {
+		t.Errorf("Expected an empty blocklist to be refused, got: %v", err)
+	}
+
+	if err := os.Setenv("BURNDEVICE_SECURITY_ALLOW_EMPTY_BLOCKLIST", "true"); err != nil {
+		t.Fatalf("Failed to set env var: %v", err)
+	}
+	if _, err := Load(""); err != nil {
+		t.Errorf("Expected allow_empty_blocklist to permit an empty blocklist, got: %v", err)
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("BURNDEVICE_TEST_DIR", "/srv/chaos")
+	t.Setenv("BURNDEVICE_TEST_EMPTY", "")
+
+	cfg := &Config{
+		Server: ServerConfig{TLS: TLSConfig{
+			Enabled:  true,
+			CertFile: "${BURNDEVICE_TEST_DIR}/tls/server.crt",
+			KeyFile:  "$BURNDEVICE_TEST_DIR/tls/server.key",
+		}},
+		Security: SecurityConfig{
+			AllowedTargets: []string{"${BURNDEVICE_TEST_DIR}/data", "${BURNDEVICE_TEST_UNSET}", "${BURNDEVICE_TEST_EMPTY}/data", "/tmp"},
+			BlockedTargets: []string{"/etc", "${BURNDEVICE_TEST_DIR}/keep", "${BURNDEVICE_TEST_UNSET}"},
+		},
+	}
+	if err := expandEnv(cfg); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Entries using unset or empty variables are dropped, never kept as ""
+	// or as a path outside the templated directory
+	if got := strings.Join(cfg.Security.AllowedTargets, ","); got != "/srv/chaos/data,/tmp" {
+		t.Errorf("Expected allowed targets /srv/chaos/data,/tmp, got %s", got)
+	}
+	if got := strings.Join(cfg.Security.BlockedTargets, ","); got != "/etc,/srv/chaos/keep" {
+		t.Errorf("Expected blocked targets /etc,/srv/chaos/keep, got %s", got)
+	}
+	if cfg.Server.TLS.CertFile != "/srv/chaos/tls/server.crt" || cfg.Server.TLS.KeyFile != "/srv/chaos/tls/server.key" {
+		t.Errorf("Expected TLS files to be expanded, got %s and %s", cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile)
+	}
+
+	cfg.Server.TLS.CertFile = "${BURNDEVICE_TEST_UNSET}/server.crt"
+	if err := expandEnv(cfg); err == nil || !strings.Contains(err.Error(), "BURNDEVICE_TEST_UNSET") {
+		t.Errorf("Expected an unset variable in an enabled TLS file to be rejected, got: %v", err)
+	}
+	cfg.Server.TLS.Enabled = false
+	if err := expandEnv(cfg); err != nil {
+		t.Errorf("Expected TLS files to be ignored while TLS is disabled, got: %v", err)
+	}
+}
+
+func TestLoadExpandsTargets(t *testing.T) {
+	t.Setenv("BURNDEVICE_TEST_DIR", "/srv/chaos")
+	t.Setenv("BURNDEVICE_SECURITY_BLOCKED_TARGETS", "${BURNDEVICE_TEST_DIR}/keep")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if got := strings.Join(cfg.Security.BlockedTargets, ","); got != "/srv/chaos/keep" {
+		t.Errorf("Expected the blocked target to be expanded, got %s", got)
+	}
+}
+
+func TestHooksFor(t *testing.T) {
+	hooks := HooksConfig{
+		PreExecute:  []HookConfig{{Command: "snapshot-all"}},
+		PostExecute: []HookConfig{{Command: "health-check"}},
+		Types: map[string]TypeHooks{
+			// Viper lowercases map keys
+			"file_deletion": {PreExecute: []HookConfig{{Command: "snapshot-db"}}},
+		},
+	}
+
+	pre, post := hooks.For("FILE_DELETION")
+	if len(pre) != 2 || pre[0].Command != "snapshot-all" || pre[1].Command != "snapshot-db" {
+		t.Errorf("Expected shared then type pre hooks, got %+v", pre)
+	}
+	if len(post) != 1 || post[0].Command != "health-check" {
+		t.Errorf("Expected the shared post hook, got %+v", post)
+	}
+
+	pre, _ = hooks.For("CPU_BURN")
+	if len(pre) != 1 {
+		t.Errorf("Expected only the shared pre hook for other types, got %+v", pre)
+	}
+}
+
+func TestLoadMigratesLegacyPostHooks(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `security:
+  post_hooks:
+    - command: notify
+      args: ["{target}"]
+      timeout: 10s
+hooks:
+  post_target:
+    - command: audit
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	hooks := cfg.Hooks.PostTarget
+	if len(hooks) != 2 || hooks[0].Command != "notify" || hooks[0].Timeout != 10*time.Second || hooks[1].Command != "audit" {
+		t.Errorf("Expected security.post_hooks ahead of hooks.post_target, got %+v", hooks)
+	}
 }
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// redactedFields are never printed in a diff
+var redactedFields = map[string]bool{
+	"ai.api_key":                     true,
+	"security.auth_token":            true,
+	"security.window_override_token": true,
+}
+
+// Change is a single setting that differs between two configurations
+type Change struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+func (c Change) String() string {
+	if redactedFields[c.Field] {
+		return fmt.Sprintf("%s: <redacted> -> <redacted>", c.Field)
+	}
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Old, c.New)
+}
+
+// Diff returns the settings that differ between old and new, named by
+// their config file keys (e.g. "security.max_severity")
+func Diff(old, new *Config) []Change {
+	var changes []Change
+	diffStruct("", reflect.ValueOf(*old), reflect.ValueOf(*new), &changes)
+	return changes
+}
+
+func diffStruct(prefix string, old, new reflect.Value, changes *[]Change) {
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		oldValue, newValue := old.Field(i), new.Field(i)
+		if oldValue.Kind() == reflect.Struct {
+			diffStruct(name, oldValue, newValue, changes)
+			continue
+		}
+
+		if !reflect.DeepEqual(oldValue.Interface(), newValue.Interface()) {
+			*changes = append(*changes, Change{
+				Field: name,
+				Old:   oldValue.Interface(),
+				New:   newValue.Interface(),
+			})
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Config{
+		Server: ServerConfig{Port: 8080, ReadTimeout: 30 * time.Second},
+		AI:     AIConfig{APIKey: "old-secret"},
+		Security: SecurityConfig{
+			MaxSeverity:    "MEDIUM",
+			BlockedTargets: []string{"/etc"},
+		},
+	}
+	new := &Config{
+		Server: ServerConfig{Port: 8080, ReadTimeout: time.Minute},
+		AI:     AIConfig{APIKey: "new-secret"},
+		Security: SecurityConfig{
+			MaxSeverity:    "HIGH",
+			BlockedTargets: []string{"/etc", "/usr"},
+		},
+	}
+
+	changes := Diff(old, new)
+
+	byField := make(map[string]Change)
+	for _, change := range changes {
+		byField[change.Field] = change
+	}
+
+	expected := []string{"server.read_timeout", "ai.api_key", "security.max_severity", "security.blocked_targets"}
+	if len(changes) != len(expected) {
+		t.Errorf("Expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for _, field := range expected {
+		if _, ok := byField[field]; !ok {
+			t.Errorf("Expected change for %s", field)
+		}
+	}
+
+	if got := byField["security.max_severity"].String(); got != "security.max_severity: MEDIUM -> HIGH" {
+		t.Errorf("Unexpected change description: %s", got)
+	}
+	if got := byField["ai.api_key"].String(); strings.Contains(got, "secret") {
+		t.Errorf("Expected API key to be redacted, got: %s", got)
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	cfg := &Config{Security: SecurityConfig{AllowedTargets: []string{"/tmp"}}}
+	if changes := Diff(cfg, cfg); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
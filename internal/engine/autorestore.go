@@ -0,0 +1,418 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+	"github.com/BurnDevice/BurnDevice/internal/store"
+)
+
+const (
+	// autoRestoresFileName is the pending automatic restore file inside the
+	// data directory
+	autoRestoresFileName = "auto_restores.jsonl"
+	// autoRestorePhase marks the history record of an automatic restore
+	autoRestorePhase = "auto_restore"
+)
+
+// autoRestoreStore holds the automatic restores waiting for their hold to
+// end. When path is set the whole set is rewritten to a JSON-lines file on
+// every change so a restart during the hold doesn't lose them.
+type autoRestoreStore struct {
+	mu      sync.Mutex
+	path    string
+	pending map[string]*pb.AutoRestore
+	logger  *logrus.Logger
+}
+
+// newAutoRestoreStore creates an empty store. An empty dataDir keeps
+// pending restores in memory only.
+func newAutoRestoreStore(dataDir string, logger *logrus.Logger) *autoRestoreStore {
+	s := &autoRestoreStore{
+		pending: make(map[string]*pb.AutoRestore),
+		logger:  logger,
+	}
+	if dataDir != "" {
+		s.path = filepath.Join(dataDir, autoRestoresFileName)
+	}
+	return s
+}
+
+// load reads the pending restore file, skipping unreadable lines. Restores
+// that came due while the server was down run on the scheduler's first
+// pass.
+func (s *autoRestoreStore) load() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	restores, skipped, err := store.ReadJSONLines(s.path, func() *pb.AutoRestore { return &pb.AutoRestore{} },
+		func(restore *pb.AutoRestore) bool { return restore.TaskId != "" })
+	if err != nil {
+		return fmt.Errorf("failed to read pending restores: %w", err)
+	}
+	for _, restore := range restores {
+		s.pending[restore.TaskId] = restore
+	}
+
+	if skipped > 0 {
+		s.logger.WithField("skipped", skipped).Warn("Skipped unreadable pending restores")
+	}
+	if len(s.pending) > 0 {
+		s.logger.WithField("pending", len(s.pending)).Info("♻️ Automatic restores pending from before the restart")
+	}
+	return nil
+}
+
+// rewrite replaces the pending restore file with the restores held in
+// memory. Callers must hold s.mu.
+func (s *autoRestoreStore) rewrite() error {
+	if s.path == "" {
+		return nil
+	}
+
+	ids := make([]string, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	restores := make([]*pb.AutoRestore, 0, len(ids))
+	for _, id := range ids {
+		restores = append(restores, s.pending[id])
+	}
+	if err := store.WriteJSONLines(s.path, restores); err != nil {
+		return fmt.Errorf("failed to write pending restores: %w", err)
+	}
+	return nil
+}
+
+// add stores a pending restore and persists the set
+func (s *autoRestoreStore) add(restore *pb.AutoRestore) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending[restore.TaskId] = restore
+	return s.rewrite()
+}
+
+// take removes and returns the pending restore of a task, or nil
+func (s *autoRestoreStore) take(taskID string) (*pb.AutoRestore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	restore, ok := s.pending[taskID]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.pending, taskID)
+	return restore, s.rewrite()
+}
+
+// takeDue removes and returns the restores due at now. They are removed
+// before they run, like one-off schedules, so a crash mid-restore never
+// repeats one.
+func (s *autoRestoreStore) takeDue(now time.Time) ([]*pb.AutoRestore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*pb.AutoRestore
+	for id, restore := range s.pending {
+		if restore.RestoreAt.AsTime().After(now) {
+			continue
+		}
+		due = append(due, restore)
+		delete(s.pending, id)
+	}
+
+	if len(due) == 0 {
+		return nil, nil
+	}
+	return due, s.rewrite()
+}
+
+// heldBackups returns the absolute paths of the backups pending automatic
+// restores will put back
+func (e *DestructionEngine) heldBackups() map[string]bool {
+	e.autoRestores.mu.Lock()
+	var targets []string
+	for _, restore := range e.autoRestores.pending {
+		if restore.Type == pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+			targets = append(targets, restore.Targets...)
+		}
+	}
+	e.autoRestores.mu.Unlock()
+
+	held := make(map[string]bool)
+	for _, target := range targets {
+		_, backupPath, _ := e.locateBackup(target)
+		if path, err := filepath.Abs(backupPath); err == nil {
+			held[path] = true
+		}
+	}
+	return held
+}
+
+// checkAutoRestore rejects an auto_restore_after that can't be honoured:
+// only file deletion that keeps backups or quarantines, and service
+// termination, can be put back
+func (e *DestructionEngine) checkAutoRestore(t pb.DestructionType, severity pb.DestructionSeverity, quarantine bool, after time.Duration) error {
+	if after < 0 {
+		return fmt.Errorf("auto_restore_after cannot be negative")
+	}
+	if after == 0 {
+		return nil
+	}
+
+	switch t {
+	case pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION:
+		if e.quarantining(quarantine) {
+			return nil
+		}
+		if behavior, _ := e.deletionBehavior(severity); !behavior.Backup {
+			return fmt.Errorf("auto restore needs backups, which %s file deletion does not take", policy.SeverityName(severity))
+		}
+		return nil
+	case pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION:
+		return nil
+	default:
+		return fmt.Errorf("auto restore is not supported for %s", t.String())
+	}
+}
+
+// planAutoRestore returns the restore that puts back what a finished task
+// destroyed, due after the hold, or nil when there is nothing to restore.
+// A cancelled task is due at once.
+func (e *DestructionEngine) planAutoRestore(task *DestructionTask, results []*pb.DestructionResult, err error, after time.Duration) *pb.AutoRestore {
+	if after <= 0 {
+		return nil
+	}
+
+	var targets []string
+	for _, result := range results {
+		if restorable(task.Type, result) {
+			targets = append(targets, result.Target)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	restoreAt := time.Now().Add(after)
+	if e.stoppedByCancel(task, err) {
+		restoreAt = time.Now()
+	}
+	task.AutoRestoreAt = restoreAt
+
+	return &pb.AutoRestore{
+		TaskId:    task.ID,
+		Type:      task.Type,
+		Targets:   targets,
+		RestoreAt: timestamppb.New(restoreAt),
+	}
+}
+
+// restorable reports whether an automatic restore can put result's target
+// back: a file that was backed up or quarantined, or a service that was
+// actually stopped
+func restorable(t pb.DestructionType, result *pb.DestructionResult) bool {
+	if !result.Success {
+		return false
+	}
+	switch t {
+	case pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION:
+		return result.BackupPath != ""
+	case pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION:
+		return result.Action == fmt.Sprintf("stopped service %s", result.Target)
+	}
+	return false
+}
+
+// autoRestoreNote describes when restore puts the targets back
+func autoRestoreNote(restore *pb.AutoRestore) string {
+	if restore == nil {
+		return ""
+	}
+	return fmt.Sprintf("; %d targets restore automatically at %s",
+		len(restore.Targets), restore.RestoreAt.AsTime().Format(time.RFC3339))
+}
+
+// queueAutoRestore stores restore until it is due, or runs it straight
+// away when the task was cancelled. send, when set, also receives the
+// events of a restore run straight away.
+func (e *DestructionEngine) queueAutoRestore(task *DestructionTask, restore *pb.AutoRestore, err error, send func(*pb.StreamDestructionResponse)) {
+	if restore == nil {
+		return
+	}
+
+	if e.stoppedByCancel(task, err) {
+		e.runAutoRestore(context.Background(), restore, send)
+		return
+	}
+
+	if err := e.autoRestores.add(restore); err != nil {
+		e.logger.WithError(err).WithField("task_id", task.ID).Warn("Failed to persist pending restore")
+	}
+	e.logger.WithFields(logrus.Fields{
+		"task_id":    task.ID,
+		"targets":    restore.Targets,
+		"restore_at": restore.RestoreAt.AsTime().Format(time.RFC3339),
+	}).Info("♻️ Automatic restore scheduled")
+}
+
+// restoreNow starts the pending restore of taskID straight away and returns
+// it, or nil when there is none. It backs CancelDestruction for tasks that
+// have finished but whose targets are still held.
+func (e *DestructionEngine) restoreNow(taskID string) *pb.AutoRestore {
+	restore, err := e.autoRestores.take(taskID)
+	if err != nil {
+		e.logger.WithError(err).WithField("task_id", taskID).Warn("Failed to persist pending restores")
+	}
+	if restore == nil {
+		return nil
+	}
+
+	e.scheduleRuns.Add(1)
+	go func() {
+		defer e.scheduleRuns.Done()
+		e.runAutoRestore(context.Background(), restore, nil)
+	}()
+	return restore
+}
+
+// runDueRestores starts every automatic restore due at now in its own
+// goroutine
+func (e *DestructionEngine) runDueRestores(ctx context.Context, now time.Time) {
+	due, err := e.autoRestores.takeDue(now)
+	if err != nil {
+		e.logger.WithError(err).Warn("Failed to persist pending restores")
+	}
+
+	for _, restore := range due {
+		e.scheduleRuns.Add(1)
+		go func(restore *pb.AutoRestore) {
+			defer e.scheduleRuns.Done()
+			e.runAutoRestore(ctx, restore, nil)
+		}(restore)
+	}
+}
+
+// runAutoRestore puts back every target of restore, publishing its
+// progress as events of the original task and recording it in the task
+// history as that task's auto_restore phase
+func (e *DestructionEngine) runAutoRestore(ctx context.Context, restore *pb.AutoRestore, send func(*pb.StreamDestructionResponse)) {
+	// Events and history belong to the task that destroyed the targets
+	task := &DestructionTask{ID: restore.TaskId, Type: restore.Type, Targets: restore.Targets}
+	emit := func(eventType pb.DestructionEventType, target string, progress float64, message string) {
+		event := &pb.StreamDestructionResponse{
+			Timestamp: timestamppb.New(time.Now()),
+			Type:      eventType,
+			Target:    target,
+			Progress:  progress,
+			Message:   message,
+			TaskId:    restore.TaskId,
+		}
+		e.publishEvent(task, event)
+		if send != nil {
+			send(event)
+		}
+	}
+
+	logger := e.logger.WithField("task_id", restore.TaskId)
+	logger.WithField("targets", restore.Targets).Warn("♻️ Running automatic restore")
+	emit(pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS, "", 0, fmt.Sprintf("Auto restore started for %d targets", len(restore.Targets)))
+
+	startedAt := time.Now()
+	var results []*pb.DestructionResult
+	restored := 0
+	for i, target := range restore.Targets {
+		if err := ctx.Err(); err != nil {
+			// The server is stopping; what is left restores after the restart
+			remaining := &pb.AutoRestore{
+				TaskId:    restore.TaskId,
+				Type:      restore.Type,
+				Targets:   restore.Targets[i:],
+				RestoreAt: timestamppb.Now(),
+			}
+			if err := e.autoRestores.add(remaining); err != nil {
+				logger.WithError(err).Warn("Failed to persist pending restore")
+			}
+			return
+		}
+
+		result := e.autoRestoreTarget(restore.Type, target)
+		results = append(results, result)
+		message := fmt.Sprintf("Auto restore: %s", result.Action)
+		if result.Success {
+			restored++
+		} else {
+			message = fmt.Sprintf("Auto restore failed for %s: %s", target, result.ErrorMessage)
+		}
+		emit(pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS, target, float64(i+1)/float64(len(restore.Targets)), message)
+	}
+
+	message := fmt.Sprintf("Auto restore finished: %d of %d targets restored", restored, len(restore.Targets))
+	state, eventType := TaskStateCompleted, pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS
+	if restored < len(restore.Targets) {
+		state, eventType = TaskStateFailed, pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_WARNING
+	}
+	emit(eventType, "", 1.0, message)
+	logger.WithField("restored", restored).Info(message)
+
+	record := &pb.TaskRecord{
+		TaskId:     restore.TaskId,
+		Type:       restore.Type,
+		Targets:    restore.Targets,
+		State:      state,
+		Success:    state == TaskStateCompleted,
+		Message:    message,
+		StartedAt:  timestamppb.New(startedAt),
+		FinishedAt: timestamppb.New(time.Now()),
+		Results:    results,
+		Phase:      autoRestorePhase,
+	}
+	if err := e.history.add(record); err != nil {
+		logger.WithError(err).Warn("Failed to persist task history")
+	}
+}
+
+// autoRestoreTarget puts back a single target: a file from its backup or
+// quarantine, or a service by starting it
+func (e *DestructionEngine) autoRestoreTarget(t pb.DestructionType, target string) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  target,
+		Metrics: &pb.DestructionMetrics{},
+	}
+
+	if t == pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION {
+		if err := e.runCommand(context.Background(), result, "systemctl", "start", target); err != nil {
+			result.ErrorMessage = err.Error()
+			return result
+		}
+		result.Success = true
+		result.Action = fmt.Sprintf("started service %s", target)
+		return result
+	}
+
+	// Whatever appeared at the target during the hold is replaced
+	restored := e.restoreTarget(target, true, false)
+	result.Success = restored.Success
+	result.ErrorMessage = restored.ErrorMessage
+	result.BackupPath = restored.BackupPath
+	if restored.Success {
+		result.Action = fmt.Sprintf("restored %s from %s", target, restored.BackupPath)
+	}
+	return result
+}
@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func newAutoRestoreEngine(dataDir string) *DestructionEngine {
+	return NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+		Storage:  config.StorageConfig{DataDir: dataDir},
+	})
+}
+
+func deleteWithAutoRestore(t *testing.T, engine *DestructionEngine, target string, after time.Duration) *pb.ExecuteDestructionResponse {
+	t.Helper()
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		AutoRestoreAfter:   durationpb.New(after),
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Failed to execute destruction: %v, %+v", err, resp)
+	}
+	return resp
+}
+
+// historyPhases returns the phase of every recorded run of taskID
+func historyPhases(t *testing.T, engine *DestructionEngine, taskID string) []string {
+	t.Helper()
+	history, err := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("GetTaskHistory failed: %v", err)
+	}
+
+	var phases []string
+	for _, record := range history.Tasks {
+		if record.TaskId == taskID {
+			phases = append(phases, record.Phase)
+		}
+	}
+	return phases
+}
+
+func TestAutoRestoreValidation(t *testing.T) {
+	engine := newAutoRestoreEngine("")
+
+	tests := []struct {
+		name   string
+		req    *pb.ExecuteDestructionRequest
+		errMsg string
+	}{
+		{
+			name: "negative hold",
+			req: &pb.ExecuteDestructionRequest{
+				Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+				Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			},
+			errMsg: "cannot be negative",
+		},
+		{
+			name: "deletion without backups",
+			req: &pb.ExecuteDestructionRequest{
+				Type:                    pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+				Severity:                pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+				AcknowledgeIrreversible: true,
+			},
+			errMsg: "needs backups",
+		},
+		{
+			name: "type that can't be restored",
+			req: &pb.ExecuteDestructionRequest{
+				Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION,
+				Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			},
+			errMsg: "not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Targets = []string{"/tmp/burndevice_auto_restore"}
+			tt.req.ConfirmDestruction = true
+			tt.req.AutoRestoreAfter = durationpb.New(time.Minute)
+			if tt.name == "negative hold" {
+				tt.req.AutoRestoreAfter = durationpb.New(-time.Minute)
+			}
+
+			_, err := engine.ExecuteDestruction(context.Background(), tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestAutoRestoreSurvivesRestart(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_auto_restore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	dataDir := filepath.Join(tempDir, "data")
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("held for a while"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := newAutoRestoreEngine(dataDir)
+	resp := deleteWithAutoRestore(t, engine, target, time.Minute)
+	if !strings.Contains(resp.Message, "restore automatically") {
+		t.Errorf("Expected the response to announce the restore, got %q", resp.Message)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Fatalf("Expected the target to be deleted during the hold")
+	}
+
+	history, err := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil || len(history.Tasks) != 1 || history.Tasks[0].AutoRestoreAt == nil {
+		t.Fatalf("Expected the task record to carry the restore time, got %v, %v", history, err)
+	}
+
+	// A restarted server still restores once the hold is over
+	restarted := newAutoRestoreEngine(dataDir)
+	restarted.runDueRestores(context.Background(), time.Now())
+	restarted.scheduleRuns.Wait()
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Fatalf("Expected nothing to be restored before the hold is over")
+	}
+
+	restarted.runDueRestores(context.Background(), time.Now().Add(2*time.Minute))
+	restarted.scheduleRuns.Wait()
+	content, err := os.ReadFile(target)
+	if err != nil || string(content) != "held for a while" {
+		t.Fatalf("Expected the target to be restored, got %q, %v", content, err)
+	}
+
+	phases := historyPhases(t, restarted, resp.TaskId)
+	if len(phases) != 2 || phases[0] != autoRestorePhase || phases[1] != "" {
+		t.Errorf("Expected the restore to be recorded as a second phase, got %v", phases)
+	}
+
+	// The restore ran once and is gone from the store
+	if reloaded := newAutoRestoreEngine(dataDir); len(reloaded.autoRestores.pending) != 0 {
+		t.Errorf("Expected no pending restores left, got %v", reloaded.autoRestores.pending)
+	}
+}
+
+func TestCancelHeldTaskRestoresNow(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_auto_restore_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("cancel me"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := newAutoRestoreEngine("")
+	events, unsubscribe := engine.events.subscribe()
+	defer unsubscribe()
+
+	resp := deleteWithAutoRestore(t, engine, target, time.Hour)
+
+	status, err := engine.CancelDestruction(resp.TaskId)
+	if err != nil {
+		t.Fatalf("Expected cancelling the hold to succeed, got: %v", err)
+	}
+	if status.State != TaskStateCancelled {
+		t.Errorf("Expected the hold to report cancelled, got %s", status.State)
+	}
+	engine.scheduleRuns.Wait()
+
+	if _, err := os.Stat(target); err != nil {
+		t.Fatalf("Expected the target to be restored at once: %v", err)
+	}
+
+	restored := false
+	for len(events) > 0 {
+		event := <-events
+		if strings.Contains(event.Message, "Auto restore finished: 1 of 1") {
+			restored = true
+		}
+	}
+	if !restored {
+		t.Error("Expected the restore to be published as an event")
+	}
+
+	if _, err := engine.CancelDestruction(resp.TaskId); err == nil {
+		t.Error("Expected nothing left to cancel after the restore")
+	}
+}
+
+func TestAutoRestoreRestartsServices(t *testing.T) {
+	engine, runner := newServiceEngine(config.SecurityConfig{})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
+		Targets:            []string{"nginx", "cron"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		AutoRestoreAfter:   durationpb.New(time.Minute),
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Failed to execute destruction: %v, %+v", err, resp)
+	}
+
+	engine.runDueRestores(context.Background(), time.Now().Add(2*time.Minute))
+	engine.scheduleRuns.Wait()
+
+	// cron was already down, so only nginx is started again
+	if len(runner.started) != 1 || runner.started[0] != "nginx" {
+		t.Errorf("Expected only the stopped service to be started, got %v", runner.started)
+	}
+}
@@ -0,0 +1,328 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/store"
+)
+
+// budgetFileName is the daily destruction budget file inside the data
+// directory
+const budgetFileName = "budget.json"
+
+// budgetSkippedMessage is the error reported for targets skipped because
+// the budget ran out mid-run
+const budgetSkippedMessage = "skipped: destruction budget exhausted"
+
+// BudgetExceededError is returned when a request would destroy more than
+// the configured per-task or daily budget allows
+type BudgetExceededError struct {
+	Reason string
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("destruction budget exceeded: %s", e.Reason)
+}
+
+// budgetState is the persisted daily counter
+type budgetState struct {
+	Day   string `json:"day"`
+	Bytes int64  `json:"bytes"`
+}
+
+// dailyBudget counts bytes destroyed per UTC day against
+// max_bytes_per_day. When path is set the counter is rewritten after every
+// change so restarts don't reset it.
+type dailyBudget struct {
+	mu     sync.Mutex
+	path   string
+	limit  int64
+	now    func() time.Time
+	state  budgetState
+	logger *logrus.Logger
+}
+
+// newDailyBudget creates an empty counter. A limit of 0 disables the daily
+// budget; an empty dataDir keeps the counter in memory only.
+func newDailyBudget(dataDir string, limit int64, logger *logrus.Logger) *dailyBudget {
+	b := &dailyBudget{
+		limit:  limit,
+		now:    time.Now,
+		logger: logger,
+	}
+	if dataDir != "" {
+		b.path = filepath.Join(dataDir, budgetFileName)
+	}
+	return b
+}
+
+func (b *dailyBudget) enabled() bool {
+	return b.limit > 0
+}
+
+// load reads the persisted counter
+func (b *dailyBudget) load() error {
+	if b.path == "" || !b.enabled() {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// #nosec G304 - Path comes from the server configuration
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read destruction budget: %w", err)
+	}
+	if err := json.Unmarshal(data, &b.state); err != nil {
+		return fmt.Errorf("failed to decode destruction budget: %w", err)
+	}
+	return nil
+}
+
+// currentLocked returns today's counter, starting a new one at UTC
+// midnight. Callers must hold b.mu.
+func (b *dailyBudget) currentLocked() *budgetState {
+	day := b.now().UTC().Format(time.DateOnly)
+	if b.state.Day != day {
+		b.state = budgetState{Day: day}
+	}
+	return &b.state
+}
+
+// remaining returns the bytes left today, or -1 when there is no daily
+// budget
+func (b *dailyBudget) remaining() int64 {
+	if !b.enabled() {
+		return -1
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	left := b.limit - b.currentLocked().Bytes
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// resetAt returns when the daily counter next starts over
+func (b *dailyBudget) resetAt() time.Time {
+	now := b.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+}
+
+// record charges destroyed bytes against today's budget and persists the
+// counter
+func (b *dailyBudget) record(bytes int64) error {
+	if !b.enabled() || bytes == 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.currentLocked().Bytes += bytes
+	if b.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(b.state)
+	if err != nil {
+		return fmt.Errorf("failed to encode destruction budget: %w", err)
+	}
+	if err := store.WriteFile(b.path, data); err != nil {
+		return fmt.Errorf("failed to write destruction budget: %w", err)
+	}
+	return nil
+}
+
+// taskBudget is what a single task may still destroy. maxFiles and
+// maxBytes of 0 and dayBytes of -1 are unlimited.
+type taskBudget struct {
+	maxFiles int64
+	maxBytes int64
+	dayBytes int64
+
+	// mu guards the counts, which include what targets still being
+	// deleted are expected to destroy
+	mu    sync.Mutex
+	files int64
+	bytes int64
+}
+
+// exceededBy describes how destroying files and bytes more would exceed
+// the budget, or returns "" when it fits
+func (b *taskBudget) exceededBy(files, bytes int64) string {
+	files += b.files
+	bytes += b.bytes
+
+	switch {
+	case b.maxFiles > 0 && files > b.maxFiles:
+		return fmt.Sprintf("%d files exceeds max_files_per_task of %d", files, b.maxFiles)
+	case b.maxBytes > 0 && bytes > b.maxBytes:
+		return fmt.Sprintf("%d bytes exceeds max_bytes_per_task of %d", bytes, b.maxBytes)
+	case b.dayBytes >= 0 && bytes > b.dayBytes:
+		return fmt.Sprintf("%d bytes exceeds the %d bytes left of max_bytes_per_day", bytes, b.dayBytes)
+	}
+	return ""
+}
+
+// reserve holds files and bytes against the budget until the target they
+// were measured for is charged, or describes how they would exceed it
+func (b *taskBudget) reserve(files, bytes int64) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if reason := b.exceededBy(files, bytes); reason != "" {
+		return reason
+	}
+	b.files += files
+	b.bytes += bytes
+	return ""
+}
+
+// charge counts what a processed target destroyed in place of what was
+// reserved for it
+func (b *taskBudget) charge(reserved, metrics *pb.DestructionMetrics) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if reserved != nil {
+		b.files -= reserved.FilesDeleted
+		b.bytes -= reserved.BytesDestroyed
+	}
+	b.files += metrics.FilesDeleted
+	b.bytes += metrics.BytesDestroyed
+}
+
+// checkBudget rejects requests once the daily budget is used up and file
+// deletions whose targets already add up to more than the budget allows.
+// It returns the budget the task must stay within, or nil when no limit is
+// configured.
+func (e *DestructionEngine) checkBudget(destructionType pb.DestructionType, targets []string, recursive bool, filter *fileFilter, severity pb.DestructionSeverity) (*taskBudget, error) {
+	security := e.config.Security
+
+	dayBytes := e.budget.remaining()
+	if dayBytes == 0 {
+		return nil, &BudgetExceededError{
+			Reason: fmt.Sprintf("max_bytes_per_day of %d is used up, resets at %s",
+				security.MaxBytesPerDay, e.budget.resetAt().Format(time.RFC3339)),
+		}
+	}
+
+	if security.MaxFilesPerTask <= 0 && security.MaxBytesPerTask <= 0 && dayBytes < 0 {
+		return nil, nil
+	}
+	budget := &taskBudget{
+		maxFiles: security.MaxFilesPerTask,
+		maxBytes: security.MaxBytesPerTask,
+		dayBytes: dayBytes,
+	}
+
+	// Only file deletion can be sized up front
+	if destructionType != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		return budget, nil
+	}
+
+	files, bytes, err := e.measureDeletion(targets, recursive, filter, severity)
+	if err != nil {
+		return nil, err
+	}
+	if reason := budget.exceededBy(files, bytes); reason != "" {
+		return nil, &BudgetExceededError{Reason: reason}
+	}
+
+	return budget, nil
+}
+
+// measureDeletion returns how many files and bytes deleting targets at
+// severity would destroy
+func (e *DestructionEngine) measureDeletion(targets []string, recursive bool, filter *fileFilter, severity pb.DestructionSeverity) (files, bytes int64, err error) {
+	targets, _, err = expandFileTargets(targets, recursive, filter)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, target := range targets {
+		// Wiping first doesn't change what a deletion destroys
+		plan := e.planFileDeletion(target, severity, false)
+		if plan.Success {
+			files += plan.Metrics.FilesDeleted
+			bytes += plan.Metrics.BytesDestroyed
+		}
+	}
+	return files, bytes, nil
+}
+
+// reserveBudget measures target before it is deleted and holds what
+// deleting it will destroy against the task's budget, returning the
+// reservation to charge once it is deleted. It reports why deleting it
+// would exceed the budget instead, or neither when the task has no budget.
+func (e *DestructionEngine) reserveBudget(task *DestructionTask, target string) (*pb.DestructionMetrics, string) {
+	if task.budget == nil {
+		return nil, ""
+	}
+
+	plan := e.planFileDeletion(target, task.Severity, task.Wipe)
+	if !plan.Success {
+		// Deletion reports why the target can't be processed
+		return nil, ""
+	}
+	if reason := task.budget.reserve(plan.Metrics.FilesDeleted, plan.Metrics.BytesDestroyed); reason != "" {
+		return nil, reason
+	}
+	return plan.Metrics, ""
+}
+
+// skipRemaining records every target from index on as skipped because the
+// budget ran out, returning the results and an error naming them
+func (e *DestructionEngine) skipRemaining(task *DestructionTask, results []*pb.DestructionResult, index int, reason string) ([]*pb.DestructionResult, error) {
+	skipped := task.Targets[index:]
+	for _, target := range skipped {
+		result := &pb.DestructionResult{
+			Target:       target,
+			ErrorMessage: budgetSkippedMessage,
+			Metrics:      &pb.DestructionMetrics{},
+			Skipped:      true,
+		}
+		results = append(results, result)
+		e.targetProcessed(task, result)
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"skipped": skipped,
+	}).Warn("Destruction budget exhausted mid-run")
+
+	return results, &BudgetExceededError{
+		Reason: fmt.Sprintf("%s; skipped %d targets: %s", reason, len(skipped), strings.Join(skipped, ", ")),
+	}
+}
+
+// recordBudget charges a finished task against the daily budget
+func (e *DestructionEngine) recordBudget(results []*pb.DestructionResult) {
+	var bytes int64
+	for _, result := range results {
+		if result.Metrics != nil {
+			bytes += result.Metrics.BytesDestroyed
+		}
+	}
+
+	if err := e.budget.record(bytes); err != nil {
+		e.logger.WithError(err).Warn("Failed to persist destruction budget")
+	}
+}
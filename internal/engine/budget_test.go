@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+)
+
+func budgetRequest(targets ...string) *pb.ExecuteDestructionRequest {
+	return &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+	}
+}
+
+func TestBudgetRejectsOversizedRequest(t *testing.T) {
+	tempDir, files := newTestFiles(t, 3, strings.Repeat("x", 10))
+
+	tests := []struct {
+		name     string
+		security config.SecurityConfig
+	}{
+		{"files per task", config.SecurityConfig{MaxFilesPerTask: 2}},
+		{"bytes per task", config.SecurityConfig{MaxBytesPerTask: 25}},
+		{"bytes per day", config.SecurityConfig{MaxBytesPerDay: 25}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.security.MaxSeverity = "HIGH"
+			engine := NewDestructionEngine(&config.Config{Security: tt.security})
+
+			_, err := engine.ExecuteDestruction(context.Background(), budgetRequest(tempDir))
+			var budgetErr *BudgetExceededError
+			if !errors.As(err, &budgetErr) {
+				t.Fatalf("Expected a BudgetExceededError, got: %v", err)
+			}
+
+			for _, file := range files {
+				if _, err := os.Stat(file); err != nil {
+					t.Errorf("Expected %s to be left alone, got: %v", file, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBudgetStopsMidRun(t *testing.T) {
+	_, files := newTestFiles(t, 3, strings.Repeat("x", 100))
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+
+	// Only the first file fits, as if the targets grew after the request
+	// was sized up front
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	task := &DestructionTask{
+		ID:       ids.Task(),
+		Targets:  files,
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		Context:  ctx,
+		Cancel:   cancel,
+		engine:   engine,
+		budget:   &taskBudget{maxBytes: 150, dayBytes: -1},
+	}
+
+	results, err := engine.executeFileDeletion(task)
+	var budgetErr *BudgetExceededError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("Expected a BudgetExceededError, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), files[1]) || !strings.Contains(err.Error(), files[2]) {
+		t.Errorf("Expected the error to name the skipped targets, got: %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected a result for every target, got %d", len(results))
+	}
+	if !results[0].Success {
+		t.Errorf("Expected the first target to be deleted, got: %s", results[0].ErrorMessage)
+	}
+	for _, result := range results[1:] {
+		if result.Success || result.ErrorMessage != budgetSkippedMessage {
+			t.Errorf("Expected %s to be skipped, got: %+v", result.Target, result)
+		}
+		if _, err := os.Stat(result.Target); err != nil {
+			t.Errorf("Expected skipped target %s to remain, got: %v", result.Target, err)
+		}
+	}
+}
+
+func TestDailyBudgetPersistsAcrossRestarts(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_budget_data")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	_, files := newTestFiles(t, 2, strings.Repeat("x", 100))
+	cfg := &config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH", MaxBytesPerDay: 100},
+		Storage:  config.StorageConfig{DataDir: dataDir},
+	}
+
+	resp, err := NewDestructionEngine(cfg).ExecuteDestruction(context.Background(), budgetRequest(files[0]))
+	if err != nil || !resp.Success {
+		t.Fatalf("Expected the first deletion to fit the budget, got %v, %v", resp, err)
+	}
+
+	reloaded := NewDestructionEngine(cfg)
+	_, err = reloaded.ExecuteDestruction(context.Background(), budgetRequest(files[1]))
+	if err == nil || !strings.Contains(err.Error(), "used up") {
+		t.Fatalf("Expected the daily budget to stay used up after a restart, got: %v", err)
+	}
+
+	// The counter starts over the next UTC day
+	reloaded.budget.now = func() time.Time { return time.Now().Add(24 * time.Hour) }
+	if remaining := reloaded.budget.remaining(); remaining != 100 {
+		t.Errorf("Expected the full budget the next day, got %d", remaining)
+	}
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// checksumSuffix is appended to a backup's path to name the file, or the
+// tree mirroring a directory backup, holding its SHA-256 checksums
+const checksumSuffix = ".sha256"
+
+// checksumDirName is the tree inside security.backup_dir holding the
+// checksums of the backups there. mirrorPath never produces it, so no
+// backup lands in it.
+const checksumDirName = ".burndevice" + checksumSuffix
+
+// copyWriter wraps the destination of every file copy. Tests replace it to
+// simulate short writes.
+var copyWriter = func(w io.Writer) io.Writer { return w }
+
+// checksumPathFor returns where the checksum of the file at path, part of
+// the backup rooted at root, is stored. Checksums are kept apart from the
+// backups, so no file a backup holds is ever taken for one: beside a
+// target they mirror the backup in a tree next to it, and inside
+// security.backup_dir they mirror the whole directory in a tree of their
+// own.
+func (e *DestructionEngine) checksumPathFor(root, path string) string {
+	if backupDir := e.config.Security.BackupDir; backupDir != "" {
+		absDir, dirErr := filepath.Abs(backupDir)
+		absPath, pathErr := filepath.Abs(path)
+		if dirErr == nil && pathErr == nil && withinDir(absDir, absPath) {
+			if rel, err := filepath.Rel(absDir, absPath); err == nil {
+				return filepath.Join(absDir, checksumDirName, rel)
+			}
+		}
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = filepath.Base(path)
+	}
+	return filepath.Join(root+checksumSuffix, rel)
+}
+
+// backupTime returns when the backup at path, described by info, was
+// taken. A file backup carries its source's modification time so restores
+// can put it back, so its age comes from the checksum written with it;
+// symlinks, directories and backups without a checksum are dated by their
+// own modification time.
+func (e *DestructionEngine) backupTime(path string, info fs.FileInfo) time.Time {
+	if info.Mode().IsRegular() {
+		if checksum, err := os.Lstat(e.checksumPathFor(path, path)); err == nil && checksum.Mode().IsRegular() {
+			return checksum.ModTime()
+		}
+	}
+	return info.ModTime()
+}
+
+// fileChecksum returns the hex SHA-256 of the file at path
+func fileChecksum(path string) (string, error) {
+	// #nosec G304 - Callers only hash files they have just written or validated
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeChecksum stores checksum at path, creating the directories leading
+// to it with dirPerm
+func writeChecksum(path, checksum string, dirPerm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return fmt.Errorf("failed to write checksum: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(checksum+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write checksum: %w", err)
+	}
+	return nil
+}
+
+// readChecksum returns the checksum stored at path, or "" when the backup
+// was made without one
+func readChecksum(path string) (string, error) {
+	// #nosec G304 - Checksum paths are derived from the server configuration
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// backupChecksum returns the checksum of a file backup for its result, or
+// "" for directories and backups without one
+func (e *DestructionEngine) backupChecksum(backupPath string) string {
+	checksum, err := readChecksum(e.checksumPathFor(backupPath, backupPath))
+	if err != nil {
+		e.logger.WithError(err).WithField("backup", backupPath).Warn("Failed to read backup checksum")
+	}
+	return checksum
+}
+
+// verifyBackup checks every file of the backup at backupPath, a single
+// file or a mirrored tree, against its stored checksum and returns how
+// many matched. Files backed up without a checksum, such as symlinks, are
+// skipped.
+func (e *DestructionEngine) verifyBackup(backupPath string) (int64, error) {
+	var verified int64
+	err := filepath.WalkDir(backupPath, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+
+		expected, err := readChecksum(e.checksumPathFor(backupPath, path))
+		if err != nil || expected == "" {
+			return err
+		}
+		actual, err := fileChecksum(path)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+		if actual != expected {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", path, expected, actual)
+		}
+		verified++
+		return nil
+	})
+	return verified, err
+}
@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// truncatingWriter claims to write everything but drops the second half
+// of each write
+type truncatingWriter struct {
+	w io.Writer
+}
+
+func (t truncatingWriter) Write(p []byte) (int, error) {
+	if _, err := t.w.Write(p[:len(p)/2]); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func TestBackupEntryChecksum(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_checksum_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	content := []byte("content worth verifying")
+	src := filepath.Join(tempDir, "source.txt")
+	if err := os.WriteFile(src, content, 0644); err != nil {
+		t.Fatalf("Failed to create source file: %v", err)
+	}
+	info, err := os.Lstat(src)
+	if err != nil {
+		t.Fatalf("Failed to stat source file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	backup := filepath.Join(tempDir, "source.txt.backup")
+	if err := engine.backupEntry(src, backup, backup, info); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	sum := sha256.Sum256(content)
+	expected := hex.EncodeToString(sum[:])
+
+	stored, err := os.ReadFile(engine.checksumPathFor(backup, backup))
+	if err != nil {
+		t.Fatalf("Expected checksum file to be written: %v", err)
+	}
+	if strings.TrimSpace(string(stored)) != expected {
+		t.Errorf("Expected stored checksum %s, got %s", expected, stored)
+	}
+
+	actual, err := fileChecksum(backup)
+	if err != nil {
+		t.Fatalf("Failed to hash backup: %v", err)
+	}
+	if actual != expected {
+		t.Errorf("Expected backup checksum %s, got %s", expected, actual)
+	}
+}
+
+func TestCopyFileDetectsTruncatedWrite(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_checksum_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	src := filepath.Join(tempDir, "source.txt")
+	if err := os.WriteFile(src, []byte("this copy will come up short"), 0644); err != nil {
+		t.Fatalf("Failed to create source file: %v", err)
+	}
+
+	original := copyWriter
+	copyWriter = func(w io.Writer) io.Writer { return truncatingWriter{w: w} }
+	defer func() { copyWriter = original }()
+
+	engine := NewDestructionEngine(&config.Config{})
+	err = engine.copyFile(src, filepath.Join(tempDir, "dest.txt"))
+	if err == nil {
+		t.Fatal("Expected error for truncated copy")
+	}
+	if !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected checksum mismatch error, got: %v", err)
+	}
+}
+
+func TestVerifyBackupBeforeRestore(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_checksum_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	content := []byte("content worth verifying")
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+	ctx := context.Background()
+
+	resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Failed to execute destruction: %v, %+v", err, resp)
+	}
+
+	sum := sha256.Sum256(content)
+	if got := resp.Results[0].BackupChecksum; got != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected the result to carry the backup checksum, got %q", got)
+	}
+
+	verified, err := engine.VerifyBackup(ctx, &pb.VerifyBackupRequest{TaskId: resp.TaskId})
+	if err != nil {
+		t.Fatalf("Expected no error verifying, got: %v", err)
+	}
+	if !verified.Success || len(verified.Results) != 1 || verified.Results[0].FilesVerified != 1 {
+		t.Fatalf("Expected one verified file, got: %+v", verified)
+	}
+
+	// A backup cut short after it was taken fails verification and is
+	// never restored
+	backup := resp.Results[0].BackupPath
+	if err := os.WriteFile(backup, content[:5], 0600); err != nil {
+		t.Fatalf("Failed to truncate backup: %v", err)
+	}
+
+	verified, err = engine.VerifyBackup(ctx, &pb.VerifyBackupRequest{Targets: []string{target}})
+	if err != nil {
+		t.Fatalf("Expected no error verifying, got: %v", err)
+	}
+	if verified.Success || !strings.Contains(verified.Results[0].ErrorMessage, "checksum mismatch") {
+		t.Errorf("Expected a checksum mismatch, got: %+v", verified.Results[0])
+	}
+
+	restored, err := engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{Targets: []string{target}})
+	if err != nil {
+		t.Fatalf("Expected no error restoring, got: %v", err)
+	}
+	if restored.Success || !strings.Contains(restored.Results[0].ErrorMessage, "failed verification") {
+		t.Errorf("Expected the restore to be refused, got: %+v", restored.Results[0])
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be restored from a damaged backup, got: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// CleanupBackups removes the backups left next to targets beneath dir that
+// were last modified longer than olderThan ago, together with their
+// checksums. Nothing but backups is touched, and backups an automatic
+// restore still needs are kept. Backups in security.backup_dir are left to
+// backup_retention.
+func (e *DestructionEngine) CleanupBackups(dir string, olderThan time.Duration) (*pb.CleanupBackupsResponse, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("validation failed: a directory must be provided")
+	}
+	if olderThan < 0 {
+		return nil, fmt.Errorf("validation failed: older_than cannot be negative")
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: invalid directory %s: %w", dir, err)
+	}
+	if err := e.policy.CheckTarget(root); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("validation failed: %s is not a directory", root)
+	}
+
+	held := e.heldBackups()
+	cutoff := time.Now().Add(-olderThan)
+	response := &pb.CleanupBackupsResponse{Success: true}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		// Checksums go with the backup they belong to
+		if strings.HasSuffix(d.Name(), backupSuffix+checksumSuffix) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), backupSuffix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !e.backupTime(path, info).Before(cutoff) || held[path] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		bytes, err := backupBytes(path, info)
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+		checksum := e.checksumPathFor(path, path)
+		if checksumInfo, err := os.Lstat(checksum); err == nil {
+			checksumBytes, err := backupBytes(checksum, checksumInfo)
+			if err != nil {
+				return err
+			}
+			if err := os.RemoveAll(checksum); err != nil {
+				return err
+			}
+			bytes += checksumBytes
+		}
+		e.forgetBackup(path)
+
+		response.Removed = append(response.Removed, path)
+		response.BytesFreed += bytes
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		// What was removed before the failure stays reported
+		response.Success = false
+		response.Message = fmt.Sprintf("Removed %d backups (%d bytes) before failing: %v", len(response.Removed), response.BytesFreed, err)
+		return response, nil
+	}
+
+	response.Message = fmt.Sprintf("Removed %d backups, freeing %d bytes", len(response.Removed), response.BytesFreed)
+	e.logger.WithFields(logrus.Fields{
+		"dir":         root,
+		"older_than":  olderThan,
+		"removed":     len(response.Removed),
+		"bytes_freed": response.BytesFreed,
+	}).Info("🧹 Cleaned up backups")
+
+	return response, nil
+}
+
+// backupBytes returns the bytes held by the backup at path: its size, or
+// the size of every file in it when it is a directory
+func backupBytes(path string, info fs.FileInfo) (int64, error) {
+	if !info.IsDir() {
+		return info.Size(), nil
+	}
+
+	var bytes int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		bytes += info.Size()
+		return nil
+	})
+	return bytes, err
+}
+
+// forgetBackup drops the record of the backup at backupPath so a restore
+// no longer expects it
+func (e *DestructionEngine) forgetBackup(backupPath string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for target, record := range e.backups {
+		if record.BackupPath == backupPath && !record.Quarantined {
+			delete(e.backups, target)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestCleanupBackups(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_cleanup_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	allowedDir := filepath.Join(tempDir, "allowed")
+	nestedDir := filepath.Join(allowedDir, "nested")
+	outsideDir := filepath.Join(tempDir, "outside")
+	for _, dir := range []string{nestedDir, outsideDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+
+	// Every file gets an mtime of its own; only the backups older than a
+	// day are expected to go
+	old := time.Now().Add(-72 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	files := map[string]time.Time{
+		filepath.Join(allowedDir, "old.txt"+backupSuffix):                      old,
+		filepath.Join(allowedDir, "old.txt"+backupSuffix+checksumSuffix):       old,
+		filepath.Join(nestedDir, "older.log"+backupSuffix):                     old.Add(-72 * time.Hour),
+		filepath.Join(allowedDir, "recent.txt"+backupSuffix):                   recent,
+		filepath.Join(allowedDir, "held.txt"+backupSuffix):                     old,
+		filepath.Join(allowedDir, "old.txt"):                                   old,
+		filepath.Join(allowedDir, "notes.backup"):                              old,
+		filepath.Join(allowedDir, "tree"+backupSuffix, "a.txt"):                old,
+		filepath.Join(allowedDir, "tree"+backupSuffix+checksumSuffix, "a.txt"): old,
+		filepath.Join(outsideDir, "stray.txt"+backupSuffix):                    old,
+		filepath.Join(allowedDir, "recent.txt"+backupSuffix+checksumSuffix):    recent,
+	}
+	for path, mtime := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("0123456789"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("Failed to set mtime: %v", err)
+		}
+	}
+	treeBackup := filepath.Join(allowedDir, "tree"+backupSuffix)
+	if err := os.Chtimes(treeBackup, old, old); err != nil {
+		t.Fatalf("Failed to set mtime: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{AllowedTargets: []string{allowedDir}},
+	})
+	// A backup waiting for an automatic restore must survive
+	if err := engine.autoRestores.add(&pb.AutoRestore{
+		TaskId:    "task_1",
+		Type:      pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:   []string{filepath.Join(allowedDir, "held.txt")},
+		RestoreAt: timestamppb.New(time.Now().Add(time.Hour)),
+	}); err != nil {
+		t.Fatalf("Failed to add pending restore: %v", err)
+	}
+
+	resp, err := engine.CleanupBackups(allowedDir, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success || len(resp.Removed) != 3 {
+		t.Fatalf("Expected the three old backups to be removed, got %+v", resp)
+	}
+	// Two plain backups and their checksum, and the tree with its own
+	if resp.BytesFreed != 50 {
+		t.Errorf("Expected 50 bytes freed, got %d", resp.BytesFreed)
+	}
+
+	gone := map[string]bool{
+		filepath.Join(allowedDir, "old.txt"+backupSuffix):                true,
+		filepath.Join(allowedDir, "old.txt"+backupSuffix+checksumSuffix): true,
+		filepath.Join(nestedDir, "older.log"+backupSuffix):               true,
+		treeBackup: true,
+		filepath.Join(allowedDir, "tree"+backupSuffix+checksumSuffix, "a.txt"): true,
+	}
+	for path := range files {
+		if strings.HasPrefix(path, treeBackup+string(filepath.Separator)) {
+			continue
+		}
+		_, err := os.Lstat(path)
+		if gone[path] && !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got: %v", path, err)
+		}
+		if !gone[path] && err != nil {
+			t.Errorf("Expected %s to be kept: %v", path, err)
+		}
+	}
+	if _, err := os.Lstat(treeBackup); !os.IsNotExist(err) {
+		t.Errorf("Expected the directory backup to be removed, got: %v", err)
+	}
+
+	if _, err := engine.CleanupBackups(outsideDir, 24*time.Hour); err == nil || !strings.Contains(err.Error(), "not in allowed list") {
+		t.Errorf("Expected a directory outside the allowed targets to be refused, got: %v", err)
+	}
+	if _, err := engine.CleanupBackups(allowedDir, -time.Hour); err == nil {
+		t.Error("Expected a negative age to be rejected")
+	}
+	if _, err := engine.CleanupBackups(filepath.Join(allowedDir, "old.txt"), time.Hour); err == nil {
+		t.Error("Expected a file to be rejected as the directory")
+	}
+}
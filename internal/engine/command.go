@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// defaultMaxCommandOutput caps captured output when no limit is configured
+const defaultMaxCommandOutput = 4096
+
+// CommandRunner runs external commands on behalf of the engine
+type CommandRunner interface {
+	Run(ctx context.Context, name string, args ...string) (stdout, stderr []byte, err error)
+}
+
+// envRunner is a CommandRunner that can add variables to the command's
+// environment, which task hooks use to pass the task's metadata
+type envRunner interface {
+	RunEnv(ctx context.Context, env []string, name string, args ...string) (stdout, stderr []byte, err error)
+}
+
+// execRunner runs commands with os/exec
+type execRunner struct{}
+
+func (r execRunner) Run(ctx context.Context, name string, args ...string) ([]byte, []byte, error) {
+	return r.RunEnv(ctx, nil, name, args...)
+}
+
+func (execRunner) RunEnv(ctx context.Context, env []string, name string, args ...string) ([]byte, []byte, error) {
+	var stdout, stderr bytes.Buffer
+	// #nosec G204 - Commands are fixed by the engine or configured by the operator
+	cmd := exec.CommandContext(ctx, name, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// runEnv runs a command with env added to the server's environment.
+// Runners that can't pass environment variables run it without them.
+func (e *DestructionEngine) runEnv(ctx context.Context, env []string, name string, args ...string) ([]byte, []byte, error) {
+	if runner, ok := e.runner.(envRunner); ok {
+		return runner.RunEnv(ctx, env, name, args...)
+	}
+	return e.runner.Run(ctx, name, args...)
+}
+
+// exitCode returns a command's exit status from the error running it
+// returned: 0 on success, -1 when it could not be started or was killed
+func exitCode(err error) int32 {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return int32(exitErr.ExitCode())
+	}
+	return -1
+}
+
+// runCommand runs a command for a destruction and appends its captured
+// output to result, so failures can be diagnosed by the client
+func (e *DestructionEngine) runCommand(ctx context.Context, result *pb.DestructionResult, name string, args ...string) error {
+	stdout, stderr, err := e.runner.Run(ctx, name, args...)
+
+	result.Output = e.appendOutput(result.Output, stdout)
+	result.Stderr = e.appendOutput(result.Stderr, stderr)
+
+	if err != nil {
+		e.logger.WithError(err).WithFields(logrus.Fields{
+			"command": name,
+			"args":    args,
+			"stderr":  strings.TrimSpace(string(stderr)),
+		}).Warn("Command failed")
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+
+	return nil
+}
+
+// appendOutput adds output to existing captured output, keeping the total
+// within the configured cap
+func (e *DestructionEngine) appendOutput(existing string, output []byte) string {
+	if len(output) == 0 {
+		return existing
+	}
+	return e.truncateOutput(append([]byte(existing), output...))
+}
+
+// truncateOutput caps output at the configured maximum size
+func (e *DestructionEngine) truncateOutput(output []byte) string {
+	limit := e.config.Security.MaxCommandOutput
+	if limit <= 0 {
+		limit = defaultMaxCommandOutput
+	}
+
+	if len(output) > limit {
+		return string(output[:limit]) + "... (truncated)"
+	}
+	return string(output)
+}
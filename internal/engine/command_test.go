@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// outputRunner returns fixed stdout and stderr
+type outputRunner struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+func (r *outputRunner) Run(ctx context.Context, name string, args ...string) ([]byte, []byte, error) {
+	return []byte(r.stdout), []byte(r.stderr), r.err
+}
+
+func TestRunCommandCapturesOutput(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	engine.runner = &outputRunner{
+		stdout: "stopping nginx\n",
+		stderr: "Failed to stop nginx.service: Access denied\n",
+		err:    errors.New("exit status 1"),
+	}
+
+	result := &pb.DestructionResult{Target: "nginx"}
+	err := engine.runCommand(context.Background(), result, "systemctl", "stop", "nginx")
+	if err == nil {
+		t.Fatal("Expected error from failing command")
+	}
+	if !strings.Contains(err.Error(), "systemctl") {
+		t.Errorf("Expected error to name the command, got: %v", err)
+	}
+
+	if result.Output != "stopping nginx\n" {
+		t.Errorf("Expected stdout to be captured, got %q", result.Output)
+	}
+	if !strings.Contains(result.Stderr, "Access denied") {
+		t.Errorf("Expected stderr to be captured, got %q", result.Stderr)
+	}
+}
+
+func TestRunCommandTruncatesOutput(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxCommandOutput: 16},
+	})
+	engine.runner = &outputRunner{stdout: strings.Repeat("x", 64)}
+
+	result := &pb.DestructionResult{}
+	if err := engine.runCommand(context.Background(), result, "true"); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if !strings.HasPrefix(result.Output, strings.Repeat("x", 16)) || !strings.HasSuffix(result.Output, "(truncated)") {
+		t.Errorf("Expected output truncated to 16 bytes, got %q", result.Output)
+	}
+	if result.Stderr != "" {
+		t.Errorf("Expected empty stderr, got %q", result.Stderr)
+	}
+}
+
+func TestTruncateOutputDefaultCap(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+
+	if short := engine.truncateOutput([]byte("ok")); short != "ok" {
+		t.Errorf("Expected short output unchanged, got %q", short)
+	}
+
+	long := engine.truncateOutput([]byte(strings.Repeat("x", defaultMaxCommandOutput+10)))
+	if !strings.HasSuffix(long, "(truncated)") {
+		t.Error("Expected long output to be truncated")
+	}
+}
+
+func TestRunEnvExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Requires sh")
+	}
+
+	stdout, _, err := execRunner{}.RunEnv(context.Background(), []string{"BURNDEVICE_TASK_ID=task-1"}, "sh", "-c", "echo $BURNDEVICE_TASK_ID; exit 3")
+	if strings.TrimSpace(string(stdout)) != "task-1" {
+		t.Errorf("Expected the added variable in the environment, got %q", stdout)
+	}
+	if code := exitCode(err); code != 3 {
+		t.Errorf("Expected exit code 3, got %d (%v)", code, err)
+	}
+
+	if code := exitCode(nil); code != 0 {
+		t.Errorf("Expected exit code 0 without error, got %d", code)
+	}
+	_, _, err = execRunner{}.Run(context.Background(), "burndevice-no-such-command")
+	if code := exitCode(err); code != -1 {
+		t.Errorf("Expected exit code -1 for a command that can't start, got %d", code)
+	}
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// targetCooldowns remembers until when each recently destroyed target is
+// cooling down, keyed by its cleaned absolute path
+type targetCooldowns struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	until map[string]time.Time
+}
+
+func newTargetCooldowns() *targetCooldowns {
+	return &targetCooldowns{
+		now:   time.Now,
+		until: make(map[string]time.Time),
+	}
+}
+
+// cooldownKey returns the key target is tracked under, so different
+// spellings of one path share a cooldown
+func cooldownKey(target string) string {
+	if abs, err := filepath.Abs(target); err == nil {
+		return abs
+	}
+	return filepath.Clean(target)
+}
+
+// remaining returns how long target still cools down, or 0
+func (c *targetCooldowns) remaining(target string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cooldownKey(target)
+	until, ok := c.until[key]
+	if !ok {
+		return 0
+	}
+	left := until.Sub(c.now())
+	if left <= 0 {
+		delete(c.until, key)
+		return 0
+	}
+	return left
+}
+
+// start begins a cooldown of d for target, dropping expired entries so the
+// map only holds targets still cooling down
+func (c *targetCooldowns) start(target string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, until := range c.until {
+		if !until.After(now) {
+			delete(c.until, key)
+		}
+	}
+	c.until[cooldownKey(target)] = now.Add(d)
+}
+
+// checkCooldown rejects targets destroyed within security.target_cooldown
+func (e *DestructionEngine) checkCooldown(targets []string) error {
+	if e.config.Security.TargetCooldown <= 0 {
+		return nil
+	}
+	for _, target := range targets {
+		if left := e.cooldowns.remaining(target); left > 0 {
+			return fmt.Errorf("target %s was recently destroyed, cooling down for another %s",
+				target, left.Round(time.Millisecond))
+		}
+	}
+	return nil
+}
+
+// startCooldowns starts the cooldown of every target a task destroyed
+func (e *DestructionEngine) startCooldowns(results []*pb.DestructionResult) {
+	if e.config.Security.TargetCooldown <= 0 {
+		return
+	}
+	for _, result := range results {
+		if result.Success && result.Target != "" {
+			e.cooldowns.start(result.Target, e.config.Security.TargetCooldown)
+		}
+	}
+}
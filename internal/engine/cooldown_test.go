@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestTargetCooldown(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_cooldown_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "LOW",
+			TargetCooldown: 30 * time.Second,
+		},
+	})
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	engine.cooldowns.now = func() time.Time { return now }
+
+	target := filepath.Join(tempDir, "target.txt")
+	deleteTarget := func(path string) (*pb.ExecuteDestructionResponse, error) {
+		if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		return engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{path},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			ConfirmDestruction: true,
+		})
+	}
+
+	resp, err := deleteTarget(target)
+	if err != nil || !resp.Success {
+		t.Fatalf("Expected the first deletion to succeed, got %v, %v", resp, err)
+	}
+
+	// Another spelling of the same path shares the cooldown
+	now = now.Add(10 * time.Second)
+	_, err = deleteTarget(filepath.Join(tempDir, ".", "target.txt"))
+	if err == nil || !strings.Contains(err.Error(), "recently destroyed, cooling down") {
+		t.Fatalf("Expected the second deletion to be rejected during the cooldown, got: %v", err)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("Expected the target to survive the rejected deletion: %v", err)
+	}
+
+	now = now.Add(21 * time.Second)
+	resp, err = deleteTarget(target)
+	if err != nil || !resp.Success {
+		t.Fatalf("Expected deletion to be allowed after the cooldown, got %v, %v", resp, err)
+	}
+
+	// Zero disables the cooldown
+	engine.config.Security.TargetCooldown = 0
+	resp, err = deleteTarget(target)
+	if err != nil || !resp.Success {
+		t.Errorf("Expected no cooldown when it is disabled, got %v, %v", resp, err)
+	}
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func corruptionRequest(target string, severity pb.DestructionSeverity) *pb.ExecuteDestructionRequest {
+	return &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION,
+		Targets:            []string{target},
+		Severity:           severity,
+		ConfirmDestruction: true,
+	}
+}
+
+func TestFileCorruption(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 1000))})
+	testFile := filepath.Join(tempDir, "secret.bin")
+	original := bytes.Repeat([]byte{0xAA}, 1000)
+	if err := os.WriteFile(testFile, original, 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "MEDIUM"},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), corruptionRequest(testFile, pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	result := resp.Results[0]
+	if !result.Success {
+		t.Fatalf("Expected corruption to succeed, got: %s", result.ErrorMessage)
+	}
+	if result.Metrics.OffsetsCorrupted != 50 {
+		t.Errorf("Expected 5%% of 1000 offsets to be corrupted, got %d", result.Metrics.OffsetsCorrupted)
+	}
+
+	// #nosec G304 - Test file path
+	corrupted, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Expected the file to remain: %v", err)
+	}
+	if len(corrupted) != len(original) {
+		t.Fatalf("Expected the size to stay %d, got %d", len(original), len(corrupted))
+	}
+	var changed int64
+	for i := range corrupted {
+		if corrupted[i] != original[i] {
+			changed++
+		}
+	}
+	if changed != result.Metrics.BytesCorrupted || changed == 0 {
+		t.Errorf("Expected %d changed bytes to be reported, got %d", changed, result.Metrics.BytesCorrupted)
+	}
+
+	// MEDIUM keeps a backup of the intact file
+	// #nosec G304 - Test file path
+	backup, err := os.ReadFile(result.BackupPath)
+	if err != nil || !bytes.Equal(backup, original) {
+		t.Errorf("Expected an intact backup at %q, got %v", result.BackupPath, err)
+	}
+}
+
+func TestFileCorruptionConfiguredPercent(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 256))})
+	testFile := filepath.Join(tempDir, "secret.bin")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:           "HIGH",
+			CorruptionPercentages: map[string]float64{"high": 100},
+		},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), corruptionRequest(testFile, pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	result := resp.Results[0]
+	if !result.Success || result.Metrics.OffsetsCorrupted != 256 {
+		t.Errorf("Expected every offset to be corrupted, got %+v", result)
+	}
+	if result.BackupPath != "" {
+		t.Errorf("Expected HIGH to skip the backup, got %q", result.BackupPath)
+	}
+}
+
+func TestFileCorruptionRejectsDirectories(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 16))})
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), corruptionRequest(tempDir, pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Results[0].Success {
+		t.Error("Expected a directory target to be rejected")
+	}
+}
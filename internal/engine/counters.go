@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"sync"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// Counters are totals over the destructions finished since the engine was
+// created. Dry runs are not counted.
+type Counters struct {
+	Destructions   int64
+	Failed         int64
+	FilesDeleted   int64
+	BytesDestroyed int64
+}
+
+// taskCounters accumulates Counters as tasks finish and passes each
+// finished task on to observers
+type taskCounters struct {
+	mu        sync.Mutex
+	totals    Counters
+	observers []func(record *pb.TaskRecord)
+}
+
+// add counts a finished task
+func (c *taskCounters) add(record *pb.TaskRecord) {
+	c.mu.Lock()
+	c.count(record)
+	observers := c.observers
+	c.mu.Unlock()
+
+	for _, observe := range observers {
+		observe(record)
+	}
+}
+
+// count adds record to the totals. Callers must hold c.mu.
+func (c *taskCounters) count(record *pb.TaskRecord) {
+	c.totals.Destructions++
+	if !record.Success {
+		c.totals.Failed++
+	}
+	for _, result := range record.Results {
+		if result.Metrics != nil {
+			c.totals.FilesDeleted += result.Metrics.FilesDeleted
+			c.totals.BytesDestroyed += result.Metrics.BytesDestroyed
+		}
+	}
+}
+
+// Counters returns the totals over every destruction finished so far
+func (e *DestructionEngine) Counters() Counters {
+	e.counters.mu.Lock()
+	defer e.counters.mu.Unlock()
+
+	return e.counters.totals
+}
+
+// OnTaskFinished registers observe to be called with the record of every
+// destruction that finishes, e.g. to export metrics. Dry runs are not
+// reported.
+func (e *DestructionEngine) OnTaskFinished(observe func(record *pb.TaskRecord)) {
+	e.counters.mu.Lock()
+	defer e.counters.mu.Unlock()
+
+	e.counters.observers = append(e.counters.observers, observe)
+}
+
+// TotalMetrics adds up the metrics of results. Counters and execution
+// times are summed, WipePasses is the most passes any target got and
+// CPUUsagePercent, an average per target, is left unset.
+func TotalMetrics(results []*pb.DestructionResult) *pb.DestructionMetrics {
+	total := &pb.DestructionMetrics{}
+	for _, result := range results {
+		m := result.GetMetrics()
+		if m == nil {
+			continue
+		}
+		total.FilesDeleted += m.FilesDeleted
+		total.BytesDestroyed += m.BytesDestroyed
+		total.ExecutionTimeSeconds += m.ExecutionTimeSeconds
+		total.BytesAllocated += m.BytesAllocated
+		total.BytesOverwritten += m.BytesOverwritten
+		total.BytesWritten += m.BytesWritten
+		total.OffsetsCorrupted += m.OffsetsCorrupted
+		total.BytesCorrupted += m.BytesCorrupted
+		total.CoresBurned += m.CoresBurned
+		total.InodesConsumed += m.InodesConsumed
+		total.FdsConsumed += m.FdsConsumed
+		if m.WipePasses > total.WipePasses {
+			total.WipePasses = m.WipePasses
+		}
+	}
+	return total
+}
@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+const (
+	// defaultCPUBurnDuration is how long a CPU burn runs when the request
+	// doesn't say
+	defaultCPUBurnDuration = 30 * time.Second
+	// safeModeCPUBurnCap is the longest a CPU burn runs while safe mode is
+	// enabled
+	safeModeCPUBurnCap = time.Minute
+	// cpuBurnPeriod is the duty cycle each burning core is paced over
+	cpuBurnPeriod = 100 * time.Millisecond
+)
+
+// cpuSampleInterval is how often CPU usage is sampled during a burn
+var cpuSampleInterval = time.Second
+
+// cpuSampler reads the current CPU usage percentage
+type cpuSampler interface {
+	CPUUsage() (float64, error)
+}
+
+// cpuBurnCores returns how many cores a CPU burn keeps busy at severity or
+// intensity, using the same fractions as the other exhaustion types
+func cpuBurnCores(severity pb.DestructionSeverity, intensity int32) int {
+	fraction := exhaustionFraction(severity, intensity)
+
+	cores := int(math.Ceil(float64(runtime.NumCPU()) * fraction))
+	if cores < 1 {
+		return 1
+	}
+	return cores
+}
+
+// cpuBurnDuration returns how long a CPU burn asked to run for requested
+// actually runs
+func (e *DestructionEngine) cpuBurnDuration(requested time.Duration) time.Duration {
+	duration := requested
+	if duration <= 0 {
+		duration = defaultCPUBurnDuration
+	}
+	if e.config.Security.EnableSafeMode && duration > safeModeCPUBurnCap {
+		duration = safeModeCPUBurnCap
+	}
+	return duration
+}
+
+// cpuBurnUtilization returns the percentage of each burned core kept busy
+func (e *DestructionEngine) cpuBurnUtilization() float64 {
+	utilization := e.config.Engine.CPUBurnUtilization
+	if utilization <= 0 || utilization > 100 {
+		return 100
+	}
+	return utilization
+}
+
+// executeCPUBurn keeps a severity-dependent share of the cores busy for
+// the task's duration, sampling CPU usage as it goes. Cancellation stops
+// every core immediately.
+func (e *DestructionEngine) executeCPUBurn(task *DestructionTask) ([]*pb.DestructionResult, error) {
+	result := &pb.DestructionResult{
+		Target:  strings.Join(task.Targets, ","),
+		Metrics: &pb.DestructionMetrics{},
+	}
+	start := time.Now()
+	defer finishTiming(result, start)
+
+	cores := cpuBurnCores(task.Severity, task.Intensity)
+	duration := e.cpuBurnDuration(task.Duration)
+	utilization := e.cpuBurnUtilization()
+	result.Metrics.CoresBurned = int64(cores)
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id":     task.ID,
+		"cores":       cores,
+		"utilization": utilization,
+		"duration":    duration,
+	}).Warn("🔥 Starting CPU burn")
+
+	burnCtx, stop := context.WithTimeout(task.Context, duration)
+	defer stop()
+
+	var workers sync.WaitGroup
+	for i := 0; i < cores; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			burnCore(burnCtx, utilization)
+		}()
+	}
+
+	// Start the sampler from now so readings cover the burn only
+	if _, err := e.cpu.CPUUsage(); err != nil {
+		e.logger.WithError(err).Warn("Failed to sample CPU usage")
+	}
+
+	var total float64
+	var samples int
+	ticker := time.NewTicker(cpuSampleInterval)
+	defer ticker.Stop()
+sampling:
+	for {
+		select {
+		case <-burnCtx.Done():
+			break sampling
+		case <-ticker.C:
+		}
+
+		usage, err := e.cpu.CPUUsage()
+		if err != nil {
+			e.logger.WithError(err).Debug("Failed to sample CPU usage")
+			continue
+		}
+		total += usage
+		samples++
+
+		elapsed := time.Since(start)
+		task.ReportProgress(math.Min(elapsed.Seconds()/duration.Seconds(), 1), result.Target,
+			fmt.Sprintf("Burning %d cores: %.1f%% CPU (%s of %s)", cores, usage, elapsed.Round(time.Second), duration))
+	}
+	workers.Wait()
+
+	if samples > 0 {
+		result.Metrics.CpuUsagePercent = total / float64(samples)
+	}
+
+	if err := task.Context.Err(); err != nil {
+		result.ErrorMessage = fmt.Sprintf("CPU burn cancelled after %s", time.Since(start).Round(time.Second))
+		return []*pb.DestructionResult{result}, fmt.Errorf("CPU burn cancelled: %w", err)
+	}
+
+	result.Success = true
+	result.Action = fmt.Sprintf("kept %d of %d cores %g%% busy for %s", cores, runtime.NumCPU(), utilization, duration)
+	e.logger.WithFields(logrus.Fields{
+		"task_id":   task.ID,
+		"cpu_usage": result.Metrics.CpuUsagePercent,
+	}).Info("CPU burn completed")
+
+	return []*pb.DestructionResult{result}, nil
+}
+
+// burnCore spins on its own OS thread, busy for utilization percent of
+// every cpuBurnPeriod, until ctx is done
+func burnCore(ctx context.Context, utilization float64) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	busy := time.Duration(float64(cpuBurnPeriod) * utilization / 100)
+	idle := cpuBurnPeriod - busy
+	for {
+		for start := time.Now(); time.Since(start) < busy; {
+		}
+
+		if idle <= 0 {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(idle):
+		}
+	}
+}
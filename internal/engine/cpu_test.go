@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+)
+
+// fakeCPUSampler always reports usage
+type fakeCPUSampler struct {
+	usage float64
+}
+
+func (f fakeCPUSampler) CPUUsage() (float64, error) {
+	return f.usage, nil
+}
+
+func newCPUBurnTask(engine *DestructionEngine, duration time.Duration) *DestructionTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DestructionTask{
+		ID:       ids.Task(),
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN,
+		Targets:  []string{"cpu"},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Context:  ctx,
+		Cancel:   cancel,
+		Duration: duration,
+		engine:   engine,
+	}
+}
+
+func TestCPUBurnCores(t *testing.T) {
+	low := cpuBurnCores(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, 0)
+	critical := cpuBurnCores(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 0)
+
+	if low < 1 || critical > runtime.NumCPU() {
+		t.Errorf("Expected between 1 and %d cores, got %d (LOW) and %d (CRITICAL)", runtime.NumCPU(), low, critical)
+	}
+	if critical < low {
+		t.Errorf("Expected CRITICAL to burn at least as many cores as LOW, got %d < %d", critical, low)
+	}
+}
+
+func TestCPUBurnDuration(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	if got := engine.cpuBurnDuration(0); got != defaultCPUBurnDuration {
+		t.Errorf("Expected the default duration, got %v", got)
+	}
+	if got := engine.cpuBurnDuration(time.Hour); got != time.Hour {
+		t.Errorf("Expected the requested duration, got %v", got)
+	}
+
+	safe := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{EnableSafeMode: true},
+	})
+	if got := safe.cpuBurnDuration(time.Hour); got != safeModeCPUBurnCap {
+		t.Errorf("Expected safe mode to cap the duration at %v, got %v", safeModeCPUBurnCap, got)
+	}
+}
+
+func TestCPUBurn(t *testing.T) {
+	interval := cpuSampleInterval
+	cpuSampleInterval = 20 * time.Millisecond
+	defer func() { cpuSampleInterval = interval }()
+
+	engine := NewDestructionEngine(&config.Config{
+		Engine: config.EngineConfig{CPUBurnUtilization: 10},
+	})
+	engine.cpu = fakeCPUSampler{usage: 42}
+
+	task := newCPUBurnTask(engine, 200*time.Millisecond)
+	defer task.Cancel()
+
+	var mu sync.Mutex
+	var progress []float64
+	task.progress = func(p float64, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress = append(progress, p)
+	}
+
+	results, err := engine.executeCPUBurn(task)
+	if err != nil {
+		t.Fatalf("executeCPUBurn failed: %v", err)
+	}
+
+	result := results[0]
+	if !result.Success {
+		t.Fatalf("Expected the burn to succeed, got: %s", result.ErrorMessage)
+	}
+	if result.Metrics.CoresBurned != int64(cpuBurnCores(task.Severity, 0)) {
+		t.Errorf("Expected %d cores burned, got %d", cpuBurnCores(task.Severity, 0), result.Metrics.CoresBurned)
+	}
+	if result.Metrics.CpuUsagePercent != 42 {
+		t.Errorf("Expected the sampled CPU usage to be averaged, got %v", result.Metrics.CpuUsagePercent)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(progress) == 0 {
+		t.Fatal("Expected periodic progress while burning")
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i] < progress[i-1] || progress[i] > 1 {
+			t.Errorf("Expected progress to rise towards 1, got %v", progress)
+			break
+		}
+	}
+}
+
+func TestCPUBurnCancellation(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Engine: config.EngineConfig{CPUBurnUtilization: 10},
+	})
+	engine.cpu = fakeCPUSampler{usage: 42}
+
+	task := newCPUBurnTask(engine, time.Minute)
+	time.AfterFunc(50*time.Millisecond, task.Cancel)
+
+	start := time.Now()
+	results, err := engine.executeCPUBurn(task)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the burn to be cancelled, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to stop the burn promptly, took %v", elapsed)
+	}
+	if results[0].Success {
+		t.Error("Expected a cancelled burn not to succeed")
+	}
+}
@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// timeoutSkippedMessage is the error reported for targets skipped because
+// the task ran out of time
+const timeoutSkippedMessage = "skipped: execution time limit exceeded"
+
+// taskContext derives the context a task runs under from ctx. When ctx has
+// no deadline of its own, security.max_execution_time sets one.
+func (e *DestructionEngine) taskContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); !ok && e.config.Security.MaxExecutionTime > 0 {
+		return context.WithTimeout(ctx, e.config.Security.MaxExecutionTime)
+	}
+	return context.WithCancel(ctx)
+}
+
+// timedOut reports whether err ended the task because its deadline passed
+func (e *DestructionEngine) timedOut(task *DestructionTask, err error) bool {
+	return err != nil && errors.Is(task.Context.Err(), context.DeadlineExceeded)
+}
+
+// skipTimedOut records every target the task ran out of time before
+// reaching as skipped, returning the results and an error naming them
+func (e *DestructionEngine) skipTimedOut(task *DestructionTask, results []*pb.DestructionResult) ([]*pb.DestructionResult, error) {
+	results, skipped := e.skipUnreached(task, results, timeoutSkippedMessage)
+
+	elapsed := time.Since(task.StartedAt).Round(time.Millisecond)
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"elapsed": elapsed,
+		"skipped": skipped,
+	}).Warn("Destruction ran out of time")
+
+	if len(skipped) == 0 {
+		return results, fmt.Errorf("execution time limit exceeded after %s", elapsed)
+	}
+	return results, fmt.Errorf("execution time limit exceeded after %s; skipped %d targets: %s",
+		elapsed, len(skipped), strings.Join(skipped, ", "))
+}
+
+// skipUnreached adds a result failed with message for every target of the
+// task that has none yet, returning the results and the skipped targets
+func (e *DestructionEngine) skipUnreached(task *DestructionTask, results []*pb.DestructionResult, message string) ([]*pb.DestructionResult, []string) {
+	reached := make(map[string]bool, len(results))
+	for _, result := range results {
+		reached[result.Target] = true
+	}
+
+	var skipped []string
+	for _, target := range task.Targets {
+		if reached[target] {
+			continue
+		}
+		result := &pb.DestructionResult{
+			Target:       target,
+			ErrorMessage: message,
+			Metrics:      &pb.DestructionMetrics{},
+			Skipped:      true,
+		}
+		results = append(results, result)
+		e.targetProcessed(task, result)
+		skipped = append(skipped, target)
+	}
+	return results, skipped
+}
@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func newDeadlineEngine() *DestructionEngine {
+	return NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:      "LOW",
+			MaxExecutionTime: 100 * time.Millisecond,
+		},
+	})
+}
+
+func TestMaxExecutionTime(t *testing.T) {
+	_, targets := newTestFiles(t, 4, "data")
+	engine := newDeadlineEngine()
+
+	// At one file per second the wait after the second target lasts a
+	// full second, which the deadline must cut short
+	start := time.Now()
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		MaxOpsPerSecond:    1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("Expected the deadline to interrupt the run, took %s", elapsed)
+	}
+
+	if resp.Success {
+		t.Error("Expected a timed out destruction to fail")
+	}
+	if !strings.Contains(resp.Message, "execution time limit exceeded") || !strings.Contains(resp.Message, "skipped 2 targets") {
+		t.Errorf("Expected the message to report the timeout and skipped targets, got %q", resp.Message)
+	}
+
+	if len(resp.Results) != len(targets) {
+		t.Fatalf("Expected a result for every target, got %d", len(resp.Results))
+	}
+	for _, result := range resp.Results[:2] {
+		if !result.Success {
+			t.Errorf("Expected %s to be deleted, got %q", result.Target, result.ErrorMessage)
+		}
+	}
+	for _, result := range resp.Results[2:] {
+		if result.Success || result.ErrorMessage != timeoutSkippedMessage {
+			t.Errorf("Expected %s to be skipped, got %+v", result.Target, result)
+		}
+	}
+	for _, target := range targets[2:] {
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("Expected %s to survive the timeout: %v", target, err)
+		}
+	}
+
+	// A deadline set by the caller takes precedence
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	taskCtx, taskCancel := engine.taskContext(ctx)
+	defer taskCancel()
+	if deadline, _ := taskCtx.Deadline(); time.Until(deadline) < time.Second {
+		t.Errorf("Expected the caller's deadline to be kept, got %s", time.Until(deadline))
+	}
+}
+
+func TestStreamMaxExecutionTime(t *testing.T) {
+	_, targets := newTestFiles(t, 3, "data")
+	engine := newDeadlineEngine()
+	stream := &recordingStream{}
+
+	err := engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		MaxOpsPerSecond:    1,
+	}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(stream.events) < 2 {
+		t.Fatalf("Expected a warning and a final event, got %d events", len(stream.events))
+	}
+	warning := stream.events[len(stream.events)-2]
+	if warning.Type != pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_WARNING ||
+		!strings.Contains(warning.Message, "execution time limit exceeded") {
+		t.Errorf("Expected a timeout warning before the final event, got %s: %q", warning.Type, warning.Message)
+	}
+	final := stream.events[len(stream.events)-1]
+	if final.Type != pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR {
+		t.Errorf("Expected a final ERROR event, got %s", final.Type)
+	}
+}
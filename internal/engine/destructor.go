@@ -2,9 +2,13 @@ package engine
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -15,15 +19,53 @@ import (
 
 	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
 	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+	"github.com/BurnDevice/BurnDevice/internal/system"
 )
 
 // DestructionEngine handles the execution of destructive operations
 type DestructionEngine struct {
 	config  *config.Config
+	policy  *policy.Policy
 	logger  *logrus.Logger
 	mu      sync.RWMutex
 	running map[string]*DestructionTask
-	eventCh chan *pb.StreamDestructionResponse
+	backups map[string]*backupRecord
+	quota   *quotaTracker
+	budget  *dailyBudget
+	sysInfo resourceCollector
+	cpu     cpuSampler
+	limits  fileLimits
+	runner  CommandRunner
+	history *taskHistory
+	events  *eventBus
+
+	counters taskCounters
+	metrics  *Metrics
+
+	// cooldowns tracks recently destroyed targets for
+	// security.target_cooldown
+	cooldowns *targetCooldowns
+
+	// lookPath and privileged let preflight checks find binaries and
+	// tell whether the server runs as root
+	lookPath   func(file string) (string, error)
+	privileged func() bool
+
+	// runningState persists the registered tasks; interrupted holds the
+	// tasks it found left over from the last server stop
+	runningState *runningStore
+	interrupted  []*pb.TaskStatus
+
+	schedules    *scheduleStore
+	scheduleRuns sync.WaitGroup
+
+	// autoRestores holds the restores waiting for their hold to end
+	autoRestores *autoRestoreStore
+
+	// draining is set once Shutdown has begun
+	draining bool
 }
 
 // DestructionTask represents a running destruction task
@@ -38,16 +80,116 @@ type DestructionTask struct {
 	Progress float64
 	Status   string
 	Results  []*pb.DestructionResult
+
+	CurrentTarget string
+	StartedAt     time.Time
+	// Recursive expands directory targets of a file deletion into their
+	// files
+	Recursive bool
+	// Duration is how long a CPU burn runs, an inode exhaustion holds or a
+	// network disruption keeps its rules (0 uses the default)
+	Duration time.Duration
+	// Intensity is the percentage of a resource a resource-pressure type
+	// consumes, or of packets a network disruption drops (0 uses the
+	// severity's default)
+	Intensity int32
+	// FileDescriptors makes inode exhaustion hold open file descriptors
+	// instead of creating files
+	FileDescriptors bool
+	// Quarantine makes file deletion move targets into the quarantine
+	// directory instead of deleting them
+	Quarantine bool
+	// Wipe makes file deletion overwrite every file before deleting it,
+	// whatever the severity
+	Wipe bool
+	// AutoRestoreAt is when the targets the task destroyed are put back
+	// automatically (zero when they aren't)
+	AutoRestoreAt time.Time
+	// FailurePolicy decides whether a failed target stops the task
+	FailurePolicy pb.FailurePolicy
+	// IdempotencyKey is the key the request was sent with, kept in the
+	// task's history record
+	IdempotencyKey string
+
+	// engine runs the task; stream and progress are only set for streaming
+	// requests and throttle only when file deletion is paced
+	engine   *DestructionEngine
+	stream   pb.BurnDeviceService_StreamDestructionServer
+	progress progressFunc
+	throttle *throttle
+	budget   *taskBudget
+	// filter decides which files a file deletion touches; filtered counts
+	// the files its target expansion left out
+	filter   *fileFilter
+	filtered int64
+	// firstFailure is the failed target that stopped a FAIL_FAST task
+	firstFailure *pb.DestructionResult
+	// hookResults are the task's pre and post execute hooks, in the order
+	// they ran
+	hookResults []*pb.HookResult
 }
 
 // NewDestructionEngine creates a new destruction engine
 func NewDestructionEngine(cfg *config.Config) *DestructionEngine {
-	return &DestructionEngine{
+	sysInfo := system.NewSystemInfo()
+	e := &DestructionEngine{
 		config:  cfg,
+		policy:  policy.New(&cfg.Security),
 		logger:  logrus.New(),
 		running: make(map[string]*DestructionTask),
-		eventCh: make(chan *pb.StreamDestructionResponse, 1000),
+		backups: make(map[string]*backupRecord),
+		quota:   newQuotaTracker(cfg.Security.PerClientDailyQuota),
+		sysInfo: sysInfo,
+		cpu:     sysInfo,
+		limits:  sysInfo,
+		runner:  execRunner{},
+		events:  newEventBus(),
+
+		cooldowns: newTargetCooldowns(),
+
+		lookPath: exec.LookPath,
+		privileged: func() bool {
+			return system.CurrentPrivilege().Privileged
+		},
+	}
+	e.metrics = newMetrics(e.runningCount)
+	e.OnTaskFinished(e.metrics.taskFinished)
+
+	if err := e.pruneBackups(); err != nil {
+		e.logger.WithError(err).Warn("Failed to prune expired backups")
+	}
+
+	e.history = newTaskHistory(cfg.Storage.DataDir, cfg.Storage.HistoryRetention, e.logger)
+	if err := e.history.load(); err != nil {
+		e.logger.WithError(err).Warn("Failed to load task history")
+	}
+
+	e.runningState = newRunningStore(cfg.Storage.DataDir, e.logger)
+	e.recoverInterrupted()
+
+	e.budget = newDailyBudget(cfg.Storage.DataDir, cfg.Security.MaxBytesPerDay, e.logger)
+	if err := e.budget.load(); err != nil {
+		e.logger.WithError(err).Warn("Failed to load destruction budget")
+	}
+
+	e.schedules = newScheduleStore(cfg.Storage.DataDir, e.logger)
+	if err := e.schedules.load(); err != nil {
+		e.logger.WithError(err).Warn("Failed to load schedules")
 	}
+
+	e.autoRestores = newAutoRestoreStore(cfg.Storage.DataDir, e.logger)
+	if err := e.autoRestores.load(); err != nil {
+		e.logger.WithError(err).Warn("Failed to load pending restores")
+	}
+
+	return e
+}
+
+// UpdateSecurity makes the engine's policy checks apply security from now
+// on, e.g. after a config reload. Settings the engine reads outside the
+// policy, such as quotas and cooldowns, keep their startup values.
+func (e *DestructionEngine) UpdateSecurity(security *config.SecurityConfig) {
+	e.policy.Update(security)
 }
 
 // ExecuteDestruction executes a destruction request
@@ -63,54 +205,119 @@ func (e *DestructionEngine) ExecuteDestruction(ctx context.Context, req *pb.Exec
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	destructor, err := destructorFor(req.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := newFileFilter(req.IncludePatterns, req.ExcludePatterns, req.MinAge.AsDuration(), req.MaxFileSize)
+
+	// The reservation is given back unless the task runs and records it
+	reservation, err := e.reserveQuota(ctx, req.Type, req.Targets, req.Recursive, filter, req.Severity)
+	if err != nil {
+		return nil, err
+	}
+	defer e.quota.release(reservation)
+
+	if req.DryRun {
+		return e.dryRun(req), nil
+	}
+
+	if err := e.PreflightCheck(req.Type, preflightTargets(req.Targets, req.SkipPreflight)); err != nil {
+		return nil, err
+	}
+
+	budget, err := e.checkBudget(req.Type, req.Targets, req.Recursive, filter, req.Severity)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create task
-	taskCtx, cancel := context.WithCancel(ctx)
+	taskCtx, cancel := e.taskContext(ctx)
 	task := &DestructionTask{
-		ID:       generateTaskID(),
+		ID:       ids.Task(),
 		Type:     req.Type,
 		Targets:  req.Targets,
 		Severity: req.Severity,
 		Confirm:  req.ConfirmDestruction,
 		Context:  taskCtx,
 		Cancel:   cancel,
-		Status:   "running",
+		Status:   TaskStateRunning,
 		Results:  make([]*pb.DestructionResult, 0),
-	}
 
-	// Register task
-	e.mu.Lock()
-	e.running[task.ID] = task
-	e.mu.Unlock()
+		StartedAt:       time.Now(),
+		Recursive:       req.Recursive,
+		Duration:        req.Duration.AsDuration(),
+		Intensity:       req.Intensity,
+		FileDescriptors: req.FileDescriptors,
+		Quarantine:      e.quarantining(req.Quarantine),
+		Wipe:            req.Wipe,
+		FailurePolicy:   req.FailurePolicy,
+		IdempotencyKey:  req.IdempotencyKey,
 
-	defer func() {
-		e.mu.Lock()
-		delete(e.running, task.ID)
-		e.mu.Unlock()
-	}()
-
-	// Execute based on type
-	var results []*pb.DestructionResult
-	var err error
-
-	switch req.Type {
-	case pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION:
-		results, err = e.executeFileDeletion(task)
-	default:
-		results, err = e.executeBasicDestruction(task)
+		engine:   e,
+		throttle: e.throttleFor(req.MaxOpsPerSecond, req.MaxBytesPerSecond),
+		budget:   budget,
+		filter:   filter,
 	}
 
-	response := &pb.ExecuteDestructionResponse{
-		Success: err == nil,
-		Results: results,
-	}
+	// Register task
+	if err := e.registerTask(task); err != nil {
+		cancel()
+		return nil, err
+	}
+	defer e.unregisterTask(task)
+	e.publishEvent(task, startEvent())
+
+	// Execute with the destructor registered for the type, unless a pre
+	// execute hook aborts the task
+	results, err := e.runTask(taskCtx, destructor, task)
+	if e.timedOut(task, err) {
+		results, err = e.skipTimedOut(task, results)
+		e.publishEvent(task, warningEvent("", 1.0, err.Error()))
+	}
+	e.runPostTargetHooks(task, results)
+	results, err = e.applyFailurePolicy(task, results, err)
+	e.runPostExecuteHooks(task, err)
+	e.quota.record(reservation, results)
+	e.recordBudget(results)
+	e.startCooldowns(results)
 
-	if err != nil {
+	response := &pb.ExecuteDestructionResponse{
+		Success:       err == nil,
+		Results:       results,
+		TaskId:        task.ID,
+		FilesFiltered: task.filtered,
+		FailedTargets: failedTargets(results),
+		TotalMetrics:  TotalMetrics(results),
+		StartedAt:     timestamppb.New(task.StartedAt),
+		CompletedAt:   timestamppb.Now(),
+		HookResults:   task.hookResults,
+	}
+
+	if e.stoppedByCancel(task, err) {
+		response.Message = fmt.Sprintf("Destruction cancelled after %d of %d targets", len(results), len(task.Targets))
+		e.logger.WithField("task_id", task.ID).Warn("Destruction execution cancelled")
+	} else if err != nil {
 		response.Message = err.Error()
 		e.logger.WithError(err).Error("Destruction execution failed")
 	} else {
 		response.Message = "Destruction completed successfully"
-		e.logger.Info("Destruction execution completed")
-	}
+		e.logger.WithField("task_id", task.ID).Info("Destruction execution completed")
+	}
+	response.Message += "; " + targetSummary(results)
+	if req.Type == pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		response.Message = fmt.Sprintf("%s (file deletion at %s)", response.Message, e.describeDeletion(req.Severity, req.Wipe))
+		response.Message += filteredNote(task.filtered)
+	}
+	response.Message += e.safeModeNote(task.Type, task.Severity)
+	restore := e.planAutoRestore(task, results, err, req.AutoRestoreAfter.AsDuration())
+	response.Message += autoRestoreNote(restore)
+	e.recordHistory(task, results, err, response.Message)
+	final := e.finalEvent(task, results, err)
+	final.Message += autoRestoreNote(restore)
+	e.publishEvent(task, final)
+	e.queueAutoRestore(task, restore, err, nil)
 
 	return response, nil
 }
@@ -128,119 +335,230 @@ func (e *DestructionEngine) StreamDestruction(ctx context.Context, req *pb.Strea
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	destructor, err := destructorFor(req.Type)
+	if err != nil {
+		return err
+	}
+
+	filter := newFileFilter(req.IncludePatterns, req.ExcludePatterns, req.MinAge.AsDuration(), req.MaxFileSize)
+
+	// The reservation is given back unless the task runs and records it
+	reservation, err := e.reserveQuota(ctx, req.Type, req.Targets, req.Recursive, filter, req.Severity)
+	if err != nil {
+		return err
+	}
+	defer e.quota.release(reservation)
+
+	if req.DryRun {
+		return e.streamDryRun(req, stream)
+	}
+
+	if err := e.PreflightCheck(req.Type, preflightTargets(req.Targets, req.SkipPreflight)); err != nil {
+		return err
+	}
+
+	budget, err := e.checkBudget(req.Type, req.Targets, req.Recursive, filter, req.Severity)
+	if err != nil {
+		return err
+	}
+
 	// Create task
-	taskCtx, cancel := context.WithCancel(ctx)
+	taskCtx, cancel := e.taskContext(ctx)
 	defer cancel()
 
 	task := &DestructionTask{
-		ID:       generateTaskID(),
+		ID:       ids.Task(),
 		Type:     req.Type,
 		Targets:  req.Targets,
 		Severity: req.Severity,
 		Confirm:  req.ConfirmDestruction,
 		Context:  taskCtx,
 		Cancel:   cancel,
-		Status:   "running",
+		Status:   TaskStateRunning,
 		Results:  make([]*pb.DestructionResult, 0),
-	}
 
-	// Send start event
-	startEvent := &pb.StreamDestructionResponse{
-		Timestamp: timestamppb.New(time.Now()),
-		Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED,
-		Message:   "Destruction task started",
-		Progress:  0.0,
+		StartedAt:       time.Now(),
+		Recursive:       req.Recursive,
+		Duration:        req.Duration.AsDuration(),
+		Intensity:       req.Intensity,
+		FileDescriptors: req.FileDescriptors,
+		Quarantine:      e.quarantining(req.Quarantine),
+		Wipe:            req.Wipe,
+		FailurePolicy:   req.FailurePolicy,
+
+		engine:   e,
+		throttle: e.throttleFor(req.MaxOpsPerSecond, req.MaxBytesPerSecond),
+		budget:   budget,
+		filter:   filter,
 	}
-	if err := stream.Send(startEvent); err != nil {
+
+	if err := e.registerTask(task); err != nil {
 		return err
 	}
+	defer e.unregisterTask(task)
 
-	// Execute destruction with progress streaming
-	var results []*pb.DestructionResult
-	var err error
+	// Every event from here on carries the task ID
+	stream = &taskStream{BurnDeviceService_StreamDestructionServer: stream, taskID: task.ID}
+	task.stream = stream
+	task.progress = e.streamProgress(stream, strings.Join(task.Targets, ","))
 
-	switch req.Type {
-	case pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION:
-		results, err = e.executeFileDeletionStreaming(task, stream)
-	default:
-		results, err = e.executeBasicDestruction(task)
+	// Send start event
+	started := startEvent()
+	e.publishEvent(task, started)
+	if err := stream.Send(started); err != nil {
+		return err
 	}
 
-	// Send completion or error event
-	var finalEvent *pb.StreamDestructionResponse
-	if err != nil {
-		finalEvent = &pb.StreamDestructionResponse{
-			Timestamp: timestamppb.New(time.Now()),
-			Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR,
-			Message:   fmt.Sprintf("Destruction failed: %s", err.Error()),
-			Progress:  1.0,
-		}
-	} else {
-		finalEvent = &pb.StreamDestructionResponse{
-			Timestamp: timestamppb.New(time.Now()),
-			Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED,
-			Message:   fmt.Sprintf("Destruction completed successfully. %d targets processed.", len(results)),
-			Progress:  1.0,
+	// Execute destruction with progress streaming
+	results, err := e.runTask(taskCtx, destructor, task)
+	if e.timedOut(task, err) {
+		results, err = e.skipTimedOut(task, results)
+		warning := warningEvent("", 1.0, err.Error())
+		e.publishEvent(task, warning)
+		if sendErr := stream.Send(warning); sendErr != nil {
+			e.logger.WithError(sendErr).Warn("Failed to send timeout warning")
 		}
 	}
+	e.runPostTargetHooks(task, results)
+	results, err = e.applyFailurePolicy(task, results, err)
+	e.runPostExecuteHooks(task, err)
+	e.quota.record(reservation, results)
+	e.recordBudget(results)
+	e.startCooldowns(results)
+
+	// A cancelled task restores before its final event, a held one after
+	restore := e.planAutoRestore(task, results, err, req.AutoRestoreAfter.AsDuration())
+	e.queueAutoRestore(task, restore, err, func(event *pb.StreamDestructionResponse) {
+		if sendErr := stream.Send(event); sendErr != nil {
+			e.logger.WithError(sendErr).Debug("Failed to stream auto restore event")
+		}
+	})
+
+	// Send completion or error event
+	final := e.finalEvent(task, results, err)
+	final.Message += autoRestoreNote(restore)
+	e.recordHistory(task, results, err, final.Message)
+	e.publishEvent(task, final)
 
-	return stream.Send(finalEvent)
+	return stream.Send(final)
 }
 
-// executeFileDeletion performs file deletion attacks
+// executeFileDeletion performs file deletion attacks, deleting up to
+// security.max_concurrency targets at once. Results keep the order of the
+// targets, and no target is started once the task is cancelled.
 func (e *DestructionEngine) executeFileDeletion(task *DestructionTask) ([]*pb.DestructionResult, error) {
-	var results []*pb.DestructionResult
+	if err := e.expandTaskTargets(task); err != nil {
+		return nil, err
+	}
+
+	workers := e.config.Security.MaxConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	slots := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	results := make([]*pb.DestructionResult, len(task.Targets))
+
+	for i, target := range task.Targets {
+		// A slot is taken before the context is checked, so a target that
+		// stopped the task is always noticed before the next one starts
+		select {
+		case slots <- struct{}{}:
+		case <-task.Context.Done():
+		}
+		if err := task.Context.Err(); err != nil {
+			wg.Wait()
+			return results[:i], fmt.Errorf("destruction cancelled: %w", err)
+		}
+		progress := float64(i) / float64(len(task.Targets))
+		e.setProgress(task, progress, target)
 
-	for _, target := range task.Targets {
 		result := &pb.DestructionResult{
 			Target:  target,
 			Metrics: &pb.DestructionMetrics{},
 		}
+		results[i] = result
 
-		start := time.Now()
-
-		// Check if target is blocked
-		if e.isBlockedTarget(target) {
+		// Expanded targets are checked against the policy one by one
+		if message := e.targetPolicyError(target); message != "" {
+			<-slots
 			result.Success = false
-			result.ErrorMessage = "Target is in blocked list"
-			results = append(results, result)
+			result.ErrorMessage = message
+			e.targetProcessed(task, result)
 			continue
 		}
 
-		// Perform deletion based on severity (simplified)
-		var err error
-		switch task.Severity {
-		case pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW:
-			err = e.safeDeletion(target, result.Metrics)
-		default:
-			err = e.safeDeletion(target, result.Metrics)
+		reserved, reason := e.reserveBudget(task, target)
+		if reason != "" {
+			<-slots
+			wg.Wait()
+			return e.skipRemaining(task, results[:i], i, reason)
 		}
 
-		result.Success = err == nil
-		if err != nil {
-			result.ErrorMessage = err.Error()
+		// Warnings have no client to go to, only event subscribers
+		warn := func(message string) {
+			e.publishEvent(task, warningEvent(target, progress, message))
 		}
-		result.Metrics.ExecutionTimeSeconds = time.Since(start).Seconds()
-		results = append(results, result)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			e.destroyFileTarget(task, result, reserved, nil, warn)
+		}()
 	}
 
+	wg.Wait()
 	return results, nil
 }
 
+// destroyFileTarget deletes result's target, records its backup or
+// quarantine and charges what it destroyed against the task's budget in
+// place of reserved. onFile and warn are passed on to deleteTarget.
+func (e *DestructionEngine) destroyFileTarget(task *DestructionTask, result *pb.DestructionResult, reserved *pb.DestructionMetrics, onFile fileDeletedFunc, warn func(message string)) {
+	target := result.Target
+	start := time.Now()
+
+	backupPath, err := e.deleteTarget(task, result, onFile, warn)
+	result.Success = err == nil
+	if err != nil {
+		result.ErrorMessage = err.Error()
+	} else if task.Quarantine {
+		result.BackupPath = e.quarantinePathFor(target)
+		e.recordQuarantine(task.ID, target, result.Metrics.BytesDestroyed)
+	} else if backupPath != "" {
+		result.BackupPath = backupPath
+		result.BackupChecksum = e.backupChecksum(backupPath)
+		e.recordBackup(task.ID, target, backupPath, result.Metrics.BytesDestroyed)
+	}
+	finishTiming(result, start)
+	if task.budget != nil {
+		task.budget.charge(reserved, result.Metrics)
+	}
+	e.targetProcessed(task, result)
+}
+
 // executeFileDeletionStreaming performs file deletion with streaming updates
 func (e *DestructionEngine) executeFileDeletionStreaming(task *DestructionTask, stream pb.BurnDeviceService_StreamDestructionServer) ([]*pb.DestructionResult, error) {
+	if err := e.expandTaskTargets(task); err != nil {
+		return nil, err
+	}
+
 	var results []*pb.DestructionResult
 
 	for i, target := range task.Targets {
+		if err := task.Context.Err(); err != nil {
+			return results, fmt.Errorf("destruction cancelled: %w", err)
+		}
+
 		result := &pb.DestructionResult{
 			Target:  target,
 			Metrics: &pb.DestructionMetrics{},
 		}
 
-		start := time.Now()
-
 		// Send progress event
 		progress := float64(i) / float64(len(task.Targets))
+		e.setProgress(task, progress, target)
 		progressEvent := &pb.StreamDestructionResponse{
 			Timestamp: timestamppb.New(time.Now()),
 			Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
@@ -252,21 +570,48 @@ func (e *DestructionEngine) executeFileDeletionStreaming(task *DestructionTask,
 			return results, err
 		}
 
-		// Check if target is blocked
-		if e.isBlockedTarget(target) {
+		// Expanded targets are checked against the policy one by one
+		if message := e.targetPolicyError(target); message != "" {
 			result.Success = false
-			result.ErrorMessage = "Target is in blocked list"
+			result.ErrorMessage = message
 			results = append(results, result)
+			e.targetProcessed(task, result)
 			continue
 		}
 
-		// Perform deletion
-		err := e.safeDeletion(target, result.Metrics)
-		result.Success = err == nil
-		if err != nil {
-			result.ErrorMessage = err.Error()
+		reserved, reason := e.reserveBudget(task, target)
+		if reason != "" {
+			return e.skipRemaining(task, results, i, reason)
+		}
+
+		// Directories report per-file progress within the target's share
+		var onFile fileDeletedFunc
+		if total := e.countFiles(target); total > 0 {
+			onFile = func(path string, done int64) {
+				fileProgress := (float64(i) + float64(done)/float64(total)) / float64(len(task.Targets))
+				e.setProgress(task, fileProgress, path)
+				fileEvent := &pb.StreamDestructionResponse{
+					Timestamp: timestamppb.New(time.Now()),
+					Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
+					Target:    path,
+					Progress:  fileProgress,
+					Message:   fmt.Sprintf("Deleted file %d of %d: %s", done, total, path),
+				}
+				if err := stream.Send(fileEvent); err != nil {
+					e.logger.WithError(err).Warn("Failed to send file progress event")
+				}
+			}
 		}
-		result.Metrics.ExecutionTimeSeconds = time.Since(start).Seconds()
+
+		// Perform deletion, warning the client if shredding was downgraded
+		warn := func(message string) {
+			warning := warningEvent(target, progress, message)
+			e.publishEvent(task, warning)
+			if err := stream.Send(warning); err != nil {
+				e.logger.WithError(err).Warn("Failed to send warning event")
+			}
+		}
+		e.destroyFileTarget(task, result, reserved, onFile, warn)
 		results = append(results, result)
 
 		// Send completion event for this target
@@ -285,46 +630,85 @@ func (e *DestructionEngine) executeFileDeletionStreaming(task *DestructionTask,
 	return results, nil
 }
 
-// executeBasicDestruction handles other destruction types
-func (e *DestructionEngine) executeBasicDestruction(task *DestructionTask) ([]*pb.DestructionResult, error) {
-	result := &pb.DestructionResult{
-		Target:  strings.Join(task.Targets, ","),
-		Success: true,
-		Metrics: &pb.DestructionMetrics{
-			ExecutionTimeSeconds: 1.0,
-		},
-	}
+// taskStream stamps the task ID on every event sent through it
+type taskStream struct {
+	pb.BurnDeviceService_StreamDestructionServer
+	taskID string
+}
+
+func (s *taskStream) Send(event *pb.StreamDestructionResponse) error {
+	event.TaskId = s.taskID
+	return s.BurnDeviceService_StreamDestructionServer.Send(event)
+}
 
-	e.logger.WithField("type", task.Type).Info("Basic destruction simulation completed")
-	return []*pb.DestructionResult{result}, nil
+// streamProgress returns a progressFunc that forwards updates to the stream
+// as PROGRESS events
+func (e *DestructionEngine) streamProgress(stream pb.BurnDeviceService_StreamDestructionServer, target string) progressFunc {
+	return func(progress float64, message string) {
+		event := &pb.StreamDestructionResponse{
+			Timestamp: timestamppb.New(time.Now()),
+			Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
+			Target:    target,
+			Progress:  progress,
+			Message:   message,
+		}
+		if err := stream.Send(event); err != nil {
+			e.logger.WithError(err).Warn("Failed to send progress event")
+		}
+	}
 }
 
 // File operation helpers
-func (e *DestructionEngine) safeDeletion(target string, metrics *pb.DestructionMetrics) error {
+
+// fileDeletedFunc is called after each file removed by safeDeletion with the
+// file's path and the running count of files deleted for the target.
+type fileDeletedFunc func(path string, done int64)
+
+// safeDeletion backs up target to backupPath and removes it. Directories
+// are walked recursively: every entry is mirrored into a backup tree before it
+// is removed, and ctx is checked between files so large trees can be
+// cancelled. Symlinks are backed up as links and never followed. Regular
+// files are overwritten passes times once their backup exists.
+func (e *DestructionEngine) safeDeletion(ctx context.Context, target, backupPath string, passes int, metrics *pb.DestructionMetrics, onFile fileDeletedFunc) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	// Get file info for metrics
-	info, err := os.Stat(target)
+	info, err := os.Lstat(target)
 	if err != nil {
 		return fmt.Errorf("failed to stat file: %w", err)
 	}
 
 	if info.IsDir() {
-		return fmt.Errorf("target is a directory, not supported in safe mode")
+		return e.safeDirectoryDeletion(ctx, target, backupPath, passes, metrics, onFile)
+	}
+	if !backupable(info) {
+		return fmt.Errorf("cannot back up special file %s (%s)", target, info.Mode().Type())
 	}
 
 	// Create backup before deletion
-	backupPath := target + ".burndevice.backup"
-	if err := e.copyFile(target, backupPath); err != nil {
+	if err := os.MkdirAll(filepath.Dir(backupPath), e.backupDirPerm()); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	if err := e.backupEntry(target, backupPath, backupPath, info); err != nil {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
-
-	metrics.BytesDestroyed = info.Size()
-	metrics.FilesDeleted = 1
+	if err := e.wipeEntry(ctx, target, info, passes, metrics); err != nil {
+		return err
+	}
 
 	// Remove original file
 	if err := os.Remove(target); err != nil {
 		return fmt.Errorf("failed to remove file: %w", err)
 	}
 
+	metrics.BytesDestroyed = info.Size()
+	metrics.FilesDeleted = 1
+	if onFile != nil {
+		onFile(target, 1)
+	}
+
 	e.logger.WithFields(logrus.Fields{
 		"target": target,
 		"backup": backupPath,
@@ -333,124 +717,262 @@ func (e *DestructionEngine) safeDeletion(target string, metrics *pb.DestructionM
 	return nil
 }
 
-// Validation helpers
-func (e *DestructionEngine) validateExecuteRequest(req *pb.ExecuteDestructionRequest) error {
-	if !req.ConfirmDestruction && e.config.Security.RequireConfirmation {
-		return fmt.Errorf("destruction must be confirmed")
+// safeDirectoryDeletion mirrors the tree rooted at target into backupRoot,
+// removing each file once its backup exists, then removes the emptied tree.
+// Trees holding FIFOs, sockets or devices are refused before anything is
+// touched, since those can't be backed up.
+func (e *DestructionEngine) safeDirectoryDeletion(ctx context.Context, target, backupRoot string, passes int, metrics *pb.DestructionMetrics, onFile fileDeletedFunc) error {
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
-	maxSeverity := e.getSeverityLevel(e.config.Security.MaxSeverity)
-	if int32(req.Severity) > maxSeverity {
-		return fmt.Errorf("requested severity exceeds maximum allowed (%s)", e.config.Security.MaxSeverity)
+	special, err := findSpecialFile(target)
+	if err != nil {
+		return fmt.Errorf("failed to scan directory: %w", err)
+	}
+	if special != "" {
+		return fmt.Errorf("cannot back up special file %s; remove it first or delete the directory's files individually", special)
 	}
 
-	for _, target := range req.Targets {
-		if e.isBlockedTarget(target) {
-			return fmt.Errorf("target is blocked: %s", target)
+	err = filepath.WalkDir(target, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(target, path)
+		if err != nil {
+			return err
+		}
+		backupPath := filepath.Join(backupRoot, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(backupPath, e.backupDirPerm())
 		}
 
-		if len(e.config.Security.AllowedTargets) > 0 && !e.isAllowedTarget(target) {
-			return fmt.Errorf("target is not in allowed list: %s", target)
+		// The tree was scanned, but a special file may have appeared since
+		if !backupable(info) {
+			return fmt.Errorf("cannot back up special file %s", path)
+		}
+
+		if err := e.backupEntry(path, backupRoot, backupPath, info); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		if err := e.wipeEntry(ctx, path, info, passes, metrics); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
 		}
+
+		metrics.FilesDeleted++
+		if info.Mode().IsRegular() {
+			metrics.BytesDestroyed += info.Size()
+		}
+		if onFile != nil {
+			onFile(path, metrics.FilesDeleted)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("directory deletion interrupted after %d files: %w", metrics.FilesDeleted, err)
+	}
+
+	// Only empty directories remain at this point
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove directory: %w", err)
 	}
 
+	e.logger.WithFields(logrus.Fields{
+		"target": target,
+		"backup": backupRoot,
+		"files":  metrics.FilesDeleted,
+	}).Info("Safe directory deletion completed")
+
 	return nil
 }
 
-func (e *DestructionEngine) validateStreamRequest(req *pb.StreamDestructionRequest) error {
-	if !req.ConfirmDestruction && e.config.Security.RequireConfirmation {
-		return fmt.Errorf("destruction must be confirmed")
+// backupEntry copies a regular file to backupPath, part of the backup
+// rooted at backupRoot, storing its checksum apart from it, or recreates a
+// symlink there without following it.
+func (e *DestructionEngine) backupEntry(path, backupRoot, backupPath string, info fs.FileInfo) error {
+	// An earlier backup may be read-only, so it is removed rather than
+	// written over
+	if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace existing backup: %w", err)
 	}
 
-	maxSeverity := e.getSeverityLevel(e.config.Security.MaxSeverity)
-	if int32(req.Severity) > maxSeverity {
-		return fmt.Errorf("requested severity exceeds maximum allowed (%s)", e.config.Security.MaxSeverity)
+	if info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(path)
+		if err != nil {
+			return fmt.Errorf("failed to read symlink: %w", err)
+		}
+		return os.Symlink(link, backupPath)
 	}
 
-	for _, target := range req.Targets {
-		if e.isBlockedTarget(target) {
-			return fmt.Errorf("target is blocked: %s", target)
-		}
+	checksum, err := e.copyFileWithChecksum(path, backupPath)
+	if err != nil {
+		return err
+	}
+	return writeChecksum(e.checksumPathFor(backupRoot, backupPath), checksum, e.backupDirPerm())
+}
 
-		if len(e.config.Security.AllowedTargets) > 0 && !e.isAllowedTarget(target) {
-			return fmt.Errorf("target is not in allowed list: %s", target)
-		}
+// countFiles returns the number of files and symlinks under root, the
+// entries a directory deletion removes one by one, or 0 when root is not a
+// directory.
+func (e *DestructionEngine) countFiles(root string) int64 {
+	info, err := os.Lstat(root)
+	if err != nil || !info.IsDir() {
+		return 0
 	}
 
-	return nil
+	var count int64
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && (d.Type().IsRegular() || d.Type()&fs.ModeSymlink != 0) {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// backupable reports whether a backup can be taken of the entry described
+// by info: regular files are copied and symlinks recreated, while FIFOs,
+// sockets and devices can't be
+func backupable(info fs.FileInfo) bool {
+	return info.Mode().IsRegular() || info.Mode()&fs.ModeSymlink != 0
 }
 
-// Helper methods
-func (e *DestructionEngine) isBlockedTarget(target string) bool {
-	for _, blocked := range e.config.Security.BlockedTargets {
-		if strings.HasPrefix(target, blocked) {
-			return true
+// findSpecialFile returns the first entry under root that can't be backed
+// up, or "" when there is none
+func findSpecialFile(root string) (string, error) {
+	var special string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !backupable(info) {
+			special = path
+			return filepath.SkipAll
 		}
+		return nil
+	})
+	return special, err
+}
+
+// Validation helpers
+func (e *DestructionEngine) validateExecuteRequest(req *pb.ExecuteDestructionRequest) error {
+	err := e.policy.ValidateRequest(req)
+	if err == nil {
+		err = e.validateRequestState(req.Type, req.Targets, req.Severity, req.DryRun, req.Quarantine, req.AutoRestoreAfter.AsDuration())
+	}
+	if err != nil {
+		e.metrics.requestRejected(req.Type, err)
 	}
-	return false
+	return err
 }
 
-func (e *DestructionEngine) isAllowedTarget(target string) bool {
-	for _, allowed := range e.config.Security.AllowedTargets {
-		if strings.HasPrefix(target, allowed) {
-			return true
+func (e *DestructionEngine) validateStreamRequest(req *pb.StreamDestructionRequest) error {
+	err := e.policy.ValidateRequest(req)
+	if err == nil {
+		err = e.validateRequestState(req.Type, req.Targets, req.Severity, req.DryRun, req.Quarantine, req.AutoRestoreAfter.AsDuration())
+	}
+	if err != nil {
+		e.metrics.requestRejected(req.Type, err)
+	}
+	return err
+}
+
+// validateRequestState runs the checks that depend on what the engine has
+// done so far rather than on configuration alone
+func (e *DestructionEngine) validateRequestState(t pb.DestructionType, targets []string, severity pb.DestructionSeverity, dryRun, quarantine bool, autoRestoreAfter time.Duration) error {
+	if !dryRun {
+		if err := e.checkCooldown(targets); err != nil {
+			return policy.Reject("cooldown", err)
+		}
+		if err := e.checkResourceFloor(t); err != nil {
+			return policy.Reject("resource_floor", err)
 		}
 	}
-	return false
+
+	return policy.Reject("auto_restore", e.checkAutoRestore(t, severity, quarantine, autoRestoreAfter))
 }
 
-func (e *DestructionEngine) getSeverityLevel(severity string) int32 {
-	switch severity {
-	case "LOW":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW)
-	case "MEDIUM":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM)
-	case "HIGH":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH)
-	case "CRITICAL":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL)
-	default:
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW)
+// targetPolicyError returns why target may not be destroyed under the
+// blocked and allowed lists, or "" when it may
+func (e *DestructionEngine) targetPolicyError(target string) string {
+	if e.policy.IsBlocked(target) {
+		return "Target is in blocked list"
+	}
+	if !e.policy.IsAllowed(target) {
+		return "Target is not in allowed list"
 	}
+	return ""
 }
 
+// copyFile copies src to dst and verifies the copy against the source
 func (e *DestructionEngine) copyFile(src, dst string) error {
+	_, err := e.copyFileWithChecksum(src, dst)
+	return err
+}
+
+// copyFileWithChecksum copies src to dst, hashing the source as it is read
+// and the destination once written, and returns the hex SHA-256 of the
+// content. A mismatch means the copy is incomplete and is an error. dst
+// keeps src's permission bits and modification time.
+func (e *DestructionEngine) copyFileWithChecksum(src, dst string) (string, error) {
 	// Validate and clean file paths to prevent directory traversal
 	cleanSrc := filepath.Clean(src)
 	cleanDst := filepath.Clean(dst)
 
 	// Check for directory traversal attempts
 	if strings.Contains(cleanSrc, "..") || strings.Contains(cleanDst, "..") {
-		return fmt.Errorf("path traversal detected in file paths")
+		return "", fmt.Errorf("path traversal detected in file paths")
 	}
 
 	// Ensure paths are absolute to avoid relative path issues
 	absSrc, err := filepath.Abs(cleanSrc)
 	if err != nil {
-		return fmt.Errorf("failed to resolve source path: %w", err)
+		return "", fmt.Errorf("failed to resolve source path: %w", err)
 	}
 
 	absDst, err := filepath.Abs(cleanDst)
 	if err != nil {
-		return fmt.Errorf("failed to resolve destination path: %w", err)
+		return "", fmt.Errorf("failed to resolve destination path: %w", err)
 	}
 
-	// Additional validation: ensure we're not accessing system critical paths
-	if e.isBlockedTarget(absSrc) || e.isBlockedTarget(absDst) {
-		return fmt.Errorf("access to blocked path is not allowed")
+	// Additional validation: ensure we're not accessing system critical paths.
+	// The configured backup and quarantine directories are exempt so they
+	// can live outside the targets under test.
+	srcBackup := e.inBackupDir(absSrc) || e.inQuarantineDir(absSrc)
+	dstBackup := e.inBackupDir(absDst) || e.inQuarantineDir(absDst)
+	if (!srcBackup && e.policy.IsBlocked(absSrc)) || (!dstBackup && e.policy.IsBlocked(absDst)) {
+		return "", fmt.Errorf("access to blocked path is not allowed")
 	}
 
 	// Final security check: ensure paths are within allowed directories
-	if len(e.config.Security.AllowedTargets) > 0 {
-		if !e.isAllowedTarget(absSrc) || !e.isAllowedTarget(absDst) {
-			return fmt.Errorf("paths are not within allowed target directories")
-		}
+	if (!srcBackup && !e.policy.IsAllowed(absSrc)) || (!dstBackup && !e.policy.IsAllowed(absDst)) {
+		return "", fmt.Errorf("paths are not within allowed target directories")
 	}
 
 	// #nosec G304 - Path is validated and sanitized above
 	sourceFile, err := os.Open(absSrc)
 	if err != nil {
-		return fmt.Errorf("failed to open source file: %w", err)
+		return "", fmt.Errorf("failed to open source file: %w", err)
 	}
 	defer func() {
 		if err := sourceFile.Close(); err != nil {
@@ -461,22 +983,40 @@ func (e *DestructionEngine) copyFile(src, dst string) error {
 	// #nosec G304 - Path is validated and sanitized above
 	destFile, err := os.Create(absDst)
 	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
+		return "", fmt.Errorf("failed to create destination file: %w", err)
 	}
-	defer func() {
-		if err := destFile.Close(); err != nil {
-			e.logger.WithError(err).Warn("Failed to close destination file")
-		}
-	}()
 
-	_, err = io.Copy(destFile, sourceFile)
+	sourceHash := sha256.New()
+	_, err = io.Copy(copyWriter(destFile), io.TeeReader(sourceFile, sourceHash))
+	if closeErr := destFile.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
 	if err != nil {
-		return fmt.Errorf("failed to copy file content: %w", err)
+		return "", fmt.Errorf("failed to copy file content: %w", err)
 	}
+	checksum := hex.EncodeToString(sourceHash.Sum(nil))
 
-	return nil
-}
+	written, err := fileChecksum(absDst)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify copy: %w", err)
+	}
+	if written != checksum {
+		return "", fmt.Errorf("checksum mismatch after copy: source %s, destination %s", checksum, written)
+	}
+
+	// Applied last: the permission bits may not allow the copy to be read
+	// back for verification
+	srcInfo, err := sourceFile.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat source file: %w", err)
+	}
+	if err := os.Chmod(absDst, srcInfo.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to copy file mode: %w", err)
+	}
+	// Backups are aged by their checksum sidecar, not this time
+	if err := os.Chtimes(absDst, time.Time{}, srcInfo.ModTime()); err != nil {
+		return "", fmt.Errorf("failed to copy modification time: %w", err)
+	}
 
-func generateTaskID() string {
-	return fmt.Sprintf("task_%d", time.Now().UnixNano())
+	return checksum, nil
 }
@@ -2,23 +2,46 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
 	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+	"github.com/BurnDevice/BurnDevice/internal/system"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestMain(m *testing.M) {
 	// 设置测试环境
 	logrus.SetLevel(logrus.FatalLevel) // 减少测试期间的日志输出
+	memoryHoldDuration = 0             // 测试期间不保持已分配的内存
+	diskFillHoldDuration = 0           // 测试期间不保持填充文件
 	code := m.Run()
 	os.Exit(code)
 }
 
+// fakeCollector reports fixed resource readings
+type fakeCollector struct {
+	info *system.Info
+	err  error
+}
+
+func (f *fakeCollector) Collect() (*system.Info, error) {
+	return f.info, f.err
+}
+
+func newFakeCollector(availableMemory int64) *fakeCollector {
+	return &fakeCollector{info: &system.Info{
+		Resources: system.Resources{AvailableMemory: availableMemory},
+	}}
+}
+
 func TestNewDestructionEngine(t *testing.T) {
 	cfg := &config.Config{
 		Security: config.SecurityConfig{
@@ -44,8 +67,8 @@ func TestNewDestructionEngine(t *testing.T) {
 		t.Error("Expected running tasks map to be initialized")
 	}
 
-	if engine.eventCh == nil {
-		t.Error("Expected event channel to be initialized")
+	if engine.events == nil {
+		t.Error("Expected event bus to be initialized")
 	}
 
 	// Test initial state
@@ -165,45 +188,6 @@ func TestExecuteDestructionValidation(t *testing.T) {
 	}
 }
 
-func TestExecuteBasicDestruction(t *testing.T) {
-	cfg := &config.Config{
-		Security: config.SecurityConfig{
-			MaxSeverity: "HIGH",
-		},
-	}
-
-	engine := NewDestructionEngine(cfg)
-
-	// Create a basic destruction task
-	task := &DestructionTask{
-		ID:       "test-task",
-		Type:     pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
-		Targets:  []string{"test-service"},
-		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
-		Confirm:  true,
-		Status:   "running",
-		Results:  make([]*pb.DestructionResult, 0),
-	}
-
-	results, err := engine.executeBasicDestruction(task)
-	if err != nil {
-		t.Errorf("Expected no error from basic destruction, got: %v", err)
-	}
-
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
-	}
-
-	if results[0].Target != "test-service" {
-		t.Errorf("Expected target 'test-service', got '%s'", results[0].Target)
-	}
-
-	// Basic destruction should always succeed in test mode
-	if !results[0].Success {
-		t.Error("Expected basic destruction to succeed")
-	}
-}
-
 func TestSafeDeletion(t *testing.T) {
 	// Create temporary directory for test
 	tempDir, err := os.MkdirTemp("", "burndevice_test")
@@ -229,7 +213,7 @@ func TestSafeDeletion(t *testing.T) {
 	metrics := &pb.DestructionMetrics{}
 
 	// Test safe deletion
-	err = engine.safeDeletion(testFile, metrics)
+	err = engine.safeDeletion(context.Background(), testFile, engine.backupPathFor(testFile), 0, metrics, nil)
 	if err != nil {
 		t.Errorf("Expected no error from safe deletion, got: %v", err)
 	}
@@ -275,7 +259,7 @@ func TestSafeDeletionNonExistentFile(t *testing.T) {
 	nonExistentFile := "/tmp/non_existent_file_12345.txt"
 
 	// Test deletion of non-existent file
-	err := engine.safeDeletion(nonExistentFile, metrics)
+	err := engine.safeDeletion(context.Background(), nonExistentFile, engine.backupPathFor(nonExistentFile), 0, metrics, nil)
 	if err == nil {
 		t.Error("Expected error when deleting non-existent file")
 	}
@@ -283,6 +267,305 @@ func TestSafeDeletionNonExistentFile(t *testing.T) {
 	// Note: ExecutionTimeSeconds is set by the caller, not by safeDeletion itself
 }
 
+func TestSafeDeletionDirectory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	// Build a small tree with a nested directory and a symlink escaping the root
+	target := filepath.Join(tempDir, "tree")
+	if err := os.MkdirAll(filepath.Join(target, "nested"), 0755); err != nil {
+		t.Fatalf("Failed to create tree: %v", err)
+	}
+	outside := filepath.Join(tempDir, "outside.txt")
+	if err := os.WriteFile(outside, []byte("must survive"), 0644); err != nil {
+		t.Fatalf("Failed to create outside file: %v", err)
+	}
+	files := map[string]string{
+		"a.txt":        "alpha",
+		"nested/b.txt": "bravo!",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(target, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(target, "link")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	metrics := &pb.DestructionMetrics{}
+
+	var seen []string
+	err = engine.safeDeletion(context.Background(), target, engine.backupPathFor(target), 0, metrics, func(path string, done int64) {
+		seen = append(seen, path)
+	})
+	if err != nil {
+		t.Fatalf("Expected no error from directory deletion, got: %v", err)
+	}
+
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Error("Expected directory to be removed")
+	}
+
+	// The symlink must not have been followed
+	if content, err := os.ReadFile(outside); err != nil || string(content) != "must survive" {
+		t.Errorf("Expected file outside the target root to be untouched, got %q (%v)", content, err)
+	}
+
+	backupRoot := target + ".burndevice.backup"
+	for name, content := range files {
+		backup, err := os.ReadFile(filepath.Join(backupRoot, name))
+		if err != nil {
+			t.Errorf("Expected backup of %s: %v", name, err)
+			continue
+		}
+		if string(backup) != content {
+			t.Errorf("Expected backup content %q for %s, got %q", content, name, backup)
+		}
+	}
+	if link, err := os.Readlink(filepath.Join(backupRoot, "link")); err != nil || link != outside {
+		t.Errorf("Expected symlink to be backed up as a link to %s, got %q (%v)", outside, link, err)
+	}
+
+	if metrics.FilesDeleted != 3 {
+		t.Errorf("Expected 3 files deleted, got %d", metrics.FilesDeleted)
+	}
+	if metrics.BytesDestroyed != int64(len("alpha")+len("bravo!")) {
+		t.Errorf("Expected %d bytes destroyed, got %d", len("alpha")+len("bravo!"), metrics.BytesDestroyed)
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 per-file callbacks, got %d", len(seen))
+	}
+}
+
+func TestSafeDeletionDirectoryCancelled(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "tree")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatalf("Failed to create tree: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(filepath.Join(target, fmt.Sprintf("file%d.txt", i)), []byte("data"), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	metrics := &pb.DestructionMetrics{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = engine.safeDeletion(ctx, target, engine.backupPathFor(target), 0, metrics, func(path string, done int64) {
+		if done == 2 {
+			cancel()
+		}
+	})
+	if err == nil {
+		t.Fatal("Expected error when directory deletion is cancelled")
+	}
+
+	if metrics.FilesDeleted != 2 {
+		t.Errorf("Expected deletion to stop after 2 files, got %d", metrics.FilesDeleted)
+	}
+
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		t.Fatalf("Expected directory to remain after cancellation: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("Expected 3 remaining files, got %d", len(entries))
+	}
+}
+
+func TestFileDeletionConcurrency(t *testing.T) {
+	tempDir, targets := newTestFiles(t, 100, "data")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:     "HIGH",
+			AllowedTargets:  []string{tempDir},
+			MaxConcurrency:  8,
+			MaxFilesPerTask: 100,
+		},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if !resp.Success || len(resp.Results) != len(targets) {
+		t.Fatalf("Expected every target to be deleted, got %d results: %s", len(resp.Results), resp.Message)
+	}
+
+	var files, bytes int64
+	for i, result := range resp.Results {
+		if result.Target != targets[i] {
+			t.Errorf("Expected result %d to be for %s, got %s", i, targets[i], result.Target)
+		}
+		if !result.Success || result.BackupPath == "" {
+			t.Errorf("Expected %s to be deleted with a backup, got %+v", result.Target, result)
+		}
+		files += result.Metrics.FilesDeleted
+		bytes += result.Metrics.BytesDestroyed
+	}
+	if files != 100 || bytes != 400 {
+		t.Errorf("Expected 100 files and 400 bytes, got %d files and %d bytes", files, bytes)
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(target); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted, got: %v", target, err)
+		}
+	}
+}
+
+func TestExecuteDestructionTotalMetrics(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_total_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	var targets []string
+	for i, size := range []int{10, 20, 30} {
+		target := filepath.Join(tempDir, fmt.Sprintf("file%d.txt", i))
+		if err := os.WriteFile(target, make([]byte, size), 0600); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		targets = append(targets, target)
+	}
+	targets = append(targets, filepath.Join(tempDir, "missing.txt"))
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+		},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		ConfirmDestruction: true,
+		SkipPreflight:      true,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if len(resp.Results) != len(targets) {
+		t.Fatalf("Expected a result per target, got %d: %s", len(resp.Results), resp.Message)
+	}
+
+	total := resp.TotalMetrics
+	if total == nil {
+		t.Fatal("Expected total metrics in the response")
+	}
+	if total.FilesDeleted != 3 || total.BytesDestroyed != 60 {
+		t.Errorf("Expected 3 files and 60 bytes in total, got %d files and %d bytes", total.FilesDeleted, total.BytesDestroyed)
+	}
+
+	var seconds float64
+	for _, result := range resp.Results {
+		seconds += result.Metrics.ExecutionTimeSeconds
+	}
+	if total.ExecutionTimeSeconds != seconds {
+		t.Errorf("Expected total execution time %f, got %f", seconds, total.ExecutionTimeSeconds)
+	}
+}
+
+func TestFileDeletionConcurrencyStopsDispatching(t *testing.T) {
+	tempDir, targets := newTestFiles(t, 20, "data")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+			BlockedTargets: []string{targets[0]},
+			MaxConcurrency: 4,
+		},
+	})
+
+	// The first match fails the policy check, which stops a fail-fast task
+	// before any other target is handed to a worker
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{filepath.Join(tempDir, "*.txt")},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		ConfirmDestruction: true,
+		FailurePolicy:      pb.FailurePolicy_FAILURE_POLICY_FAIL_FAST,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if resp.Success || len(resp.Results) != len(targets) {
+		t.Fatalf("Expected a result per target, got %+v", resp)
+	}
+	for i, result := range resp.Results[1:] {
+		if result.Target != targets[i+1] || result.ErrorMessage != failFastSkippedMessage {
+			t.Errorf("Expected %s to be skipped, got %+v", targets[i+1], result)
+		}
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("Expected %s to be left in place: %v", target, err)
+		}
+	}
+}
+
+func BenchmarkFileDeletion(b *testing.B) {
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tempDir, targets := newTestFiles(b, 100, "data")
+				engine := NewDestructionEngine(&config.Config{
+					Security: config.SecurityConfig{
+						MaxSeverity:    "HIGH",
+						AllowedTargets: []string{tempDir},
+						MaxConcurrency: workers,
+					},
+				})
+				b.StartTimer()
+
+				resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+					Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+					Targets:            targets,
+					Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+					ConfirmDestruction: true,
+				})
+				if err != nil || !resp.Success {
+					b.Fatalf("ExecuteDestruction failed: %v %v", err, resp)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateExecuteRequest(t *testing.T) {
 	cfg := &config.Config{
 		Security: config.SecurityConfig{
@@ -332,123 +615,203 @@ func TestValidateExecuteRequest(t *testing.T) {
 	}
 }
 
-func TestValidateStreamRequest(t *testing.T) {
+func TestValidateEnabledTypes(t *testing.T) {
 	cfg := &config.Config{
 		Security: config.SecurityConfig{
-			MaxSeverity:         "MEDIUM",
-			AllowedTargets:      []string{"/tmp"},
-			BlockedTargets:      []string{"/etc"},
-			RequireConfirmation: true,
+			MaxSeverity:  "MEDIUM",
+			EnabledTypes: []string{"file_deletion"},
 		},
 	}
 
 	engine := NewDestructionEngine(cfg)
 
-	// Test valid request
-	req := &pb.StreamDestructionRequest{
+	req := &pb.ExecuteDestructionRequest{
 		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
 		Targets:            []string{"/tmp/test.txt"},
 		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
 		ConfirmDestruction: true,
 	}
-
-	err := engine.validateStreamRequest(req)
-	if err != nil {
-		t.Errorf("Expected no error for valid request, got: %v", err)
+	if err := engine.validateExecuteRequest(req); err != nil {
+		t.Errorf("Expected an enabled type to be accepted, got: %v", err)
 	}
 
-	// Test request without confirmation
-	req.ConfirmDestruction = false
-	err = engine.validateStreamRequest(req)
-	if err == nil {
-		t.Error("Expected error for request without confirmation")
+	req.Type = pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC
+	if err := engine.validateExecuteRequest(req); err == nil || !strings.Contains(err.Error(), "not enabled") {
+		t.Errorf("Expected a disabled type to be rejected, got: %v", err)
 	}
 }
 
-func TestIsBlockedTarget(t *testing.T) {
+func TestValidateConfirmationPhrase(t *testing.T) {
 	cfg := &config.Config{
 		Security: config.SecurityConfig{
-			BlockedTargets: []string{"/etc", "/var/log", "/usr/bin"},
+			MaxSeverity:                "CRITICAL",
+			RequireConfirmation:        true,
+			ConfirmationPhrase:         "burn it down",
+			ConfirmationPhraseSeverity: "HIGH",
 		},
 	}
 
 	engine := NewDestructionEngine(cfg)
 
+	// Below the threshold a bare confirmation is enough
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/test.txt"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		ConfirmDestruction: true,
+	}
+	if err := engine.validateExecuteRequest(req); err != nil {
+		t.Errorf("Expected MEDIUM to need no phrase, got: %v", err)
+	}
+
+	req.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL
+	if err := engine.validateExecuteRequest(req); err == nil || !strings.Contains(err.Error(), "confirmation phrase") {
+		t.Errorf("Expected a bare confirmation to be rejected at CRITICAL, got: %v", err)
+	}
+
+	req.ConfirmationText = "burn it"
+	if err := engine.validateExecuteRequest(req); err == nil {
+		t.Error("Expected a wrong phrase to be rejected")
+	}
+
+	req.ConfirmationText = "burn it down"
+	if err := engine.validateExecuteRequest(req); err != nil {
+		t.Errorf("Expected the configured phrase to be accepted, got: %v", err)
+	}
+
+	// Dry runs only preview, so they need neither confirmation nor phrase
+	stream := &pb.StreamDestructionRequest{
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:  []string{"/tmp/test.txt"},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		DryRun:   true,
+	}
+	if err := engine.validateStreamRequest(stream); err != nil {
+		t.Errorf("Expected a dry run to need no phrase, got: %v", err)
+	}
+
+	stream.DryRun = false
+	stream.ConfirmDestruction = true
+	if err := engine.validateStreamRequest(stream); err == nil {
+		t.Error("Expected a streamed HIGH request without the phrase to be rejected")
+	}
+}
+
+func TestValidateIrreversible(t *testing.T) {
+	acknowledged := &pb.ExecuteDestructionRequest{
+		Type:                    pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC,
+		Targets:                 []string{"node-1"},
+		Severity:                pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL,
+		ConfirmDestruction:      true,
+		AcknowledgeIrreversible: true,
+	}
+
 	tests := []struct {
-		target   string
-		expected bool
+		name              string
+		allowIrreversible bool
+		modify            func(req *pb.ExecuteDestructionRequest)
+		expectErr         bool
 	}{
-		{"/etc/passwd", true},
-		{"/var/log/messages", true},
-		{"/usr/bin/bash", true},
-		{"/tmp/test.txt", false},
-		{"/home/user/file.txt", false},
-		{"", false},
+		{name: "fully acknowledged", allowIrreversible: true},
+		{name: "not allowed by config", expectErr: true},
+		{
+			name:              "below CRITICAL",
+			allowIrreversible: true,
+			modify: func(req *pb.ExecuteDestructionRequest) {
+				req.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH
+			},
+			expectErr: true,
+		},
+		{
+			name:              "not acknowledged",
+			allowIrreversible: true,
+			modify: func(req *pb.ExecuteDestructionRequest) {
+				req.AcknowledgeIrreversible = false
+			},
+			expectErr: true,
+		},
+		{
+			name:              "boot corruption not acknowledged",
+			allowIrreversible: true,
+			modify: func(req *pb.ExecuteDestructionRequest) {
+				req.Type = pb.DestructionType_DESTRUCTION_TYPE_BOOT_CORRUPTION
+				req.AcknowledgeIrreversible = false
+			},
+			expectErr: true,
+		},
+		{
+			name: "dry run",
+			modify: func(req *pb.ExecuteDestructionRequest) {
+				req.DryRun = true
+				req.AcknowledgeIrreversible = false
+			},
+		},
+		{
+			name: "reversible type",
+			modify: func(req *pb.ExecuteDestructionRequest) {
+				req.Type = pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION
+				req.Targets = []string{"/tmp/test.txt"}
+				req.AcknowledgeIrreversible = false
+			},
+		},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.target, func(t *testing.T) {
-			result := engine.isBlockedTarget(tt.target)
-			if result != tt.expected {
-				t.Errorf("Expected isBlocked %v for '%s', got %v", tt.expected, tt.target, result)
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewDestructionEngine(&config.Config{
+				Security: config.SecurityConfig{
+					MaxSeverity:         "CRITICAL",
+					RequireConfirmation: true,
+					AllowIrreversible:   tt.allowIrreversible,
+				},
+			})
+
+			req := proto.Clone(acknowledged).(*pb.ExecuteDestructionRequest)
+			if tt.modify != nil {
+				tt.modify(req)
+			}
+
+			err := engine.validateExecuteRequest(req)
+			if tt.expectErr && !errors.Is(err, policy.ErrIrreversibleNotAcknowledged) {
+				t.Errorf("Expected ErrIrreversibleNotAcknowledged, got: %v", err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
 			}
 		})
 	}
 }
 
-func TestIsAllowedTarget(t *testing.T) {
+func TestValidateStreamRequest(t *testing.T) {
 	cfg := &config.Config{
 		Security: config.SecurityConfig{
-			AllowedTargets: []string{"/tmp", "/var/tmp", "/home/user"},
+			MaxSeverity:         "MEDIUM",
+			AllowedTargets:      []string{"/tmp"},
+			BlockedTargets:      []string{"/etc"},
+			RequireConfirmation: true,
 		},
 	}
 
 	engine := NewDestructionEngine(cfg)
 
-	tests := []struct {
-		target   string
-		expected bool
-	}{
-		{"/tmp/test.txt", true},
-		{"/var/tmp/file.log", true},
-		{"/home/user/document.txt", true},
-		{"/etc/passwd", false},
-		{"/usr/bin/bash", false},
-		{"", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.target, func(t *testing.T) {
-			result := engine.isAllowedTarget(tt.target)
-			if result != tt.expected {
-				t.Errorf("Expected isAllowed %v for '%s', got %v", tt.expected, tt.target, result)
-			}
-		})
+	// Test valid request
+	req := &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/test.txt"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
 	}
-}
-
-func TestGetSeverityLevel(t *testing.T) {
-	engine := &DestructionEngine{}
 
-	tests := []struct {
-		severity string
-		expected int32
-	}{
-		{"LOW", 1},
-		{"MEDIUM", 2},
-		{"HIGH", 3},
-		{"CRITICAL", 4},
-		{"INVALID", 1}, // Default to LOW for invalid input
-		{"", 1},        // Default to LOW for empty input
+	err := engine.validateStreamRequest(req)
+	if err != nil {
+		t.Errorf("Expected no error for valid request, got: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.severity, func(t *testing.T) {
-			result := engine.getSeverityLevel(tt.severity)
-			if result != tt.expected {
-				t.Errorf("Expected severity level %d for '%s', got %d", tt.expected, tt.severity, result)
-			}
-		})
+	// Test request without confirmation
+	req.ConfirmDestruction = false
+	err = engine.validateStreamRequest(req)
+	if err == nil {
+		t.Error("Expected error for request without confirmation")
 	}
 }
 
@@ -513,30 +876,6 @@ func TestCopyFile(t *testing.T) {
 	}
 }
 
-func TestGenerateTaskID(t *testing.T) {
-	// Test that task IDs are generated
-	id1 := generateTaskID()
-	id2 := generateTaskID()
-
-	if id1 == "" {
-		t.Error("Expected task ID to be generated")
-	}
-
-	if id2 == "" {
-		t.Error("Expected task ID to be generated")
-	}
-
-	// Task IDs should be different
-	if id1 == id2 {
-		t.Error("Expected different task IDs")
-	}
-
-	// Task IDs should have reasonable length
-	if len(id1) < 10 {
-		t.Error("Expected task ID to have reasonable length")
-	}
-}
-
 func TestDestructionTaskManagement(t *testing.T) {
 	cfg := &config.Config{
 		Security: config.SecurityConfig{
@@ -558,6 +897,7 @@ func TestDestructionTaskManagement(t *testing.T) {
 	// Test task registration during execution
 	// We can't easily test the internal task management without exposing internals,
 	// but we can test that execution completes properly
+	registerNoopDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION)
 	req := &pb.ExecuteDestructionRequest{
 		Type:               pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
 		Targets:            []string{"test-service"},
@@ -638,15 +978,16 @@ func TestExecuteDestructionTypes(t *testing.T) {
 	}
 
 	engine := NewDestructionEngine(cfg)
+	engine.sysInfo = &fakeCollector{info: &system.Info{
+		Resources: system.Resources{AvailableMemory: 8 << 20, AvailableDisk: 8 << 20},
+	}}
 	ctx := context.Background()
 
 	// Test different destruction types
 	destructionTypes := []pb.DestructionType{
 		pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
-		pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
 		pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
 		pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL,
-		pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION,
 	}
 
 	for _, dtype := range destructionTypes {
@@ -659,6 +1000,8 @@ func TestExecuteDestructionTypes(t *testing.T) {
 					t.Fatalf("Failed to create test file: %v", err)
 				}
 				targets = []string{testFile}
+			} else if dtype == pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL {
+				targets = []string{tempDir}
 			} else {
 				targets = []string{"test-target"}
 			}
@@ -685,6 +1028,21 @@ func TestExecuteDestructionTypes(t *testing.T) {
 			}
 		})
 	}
+
+	// Types without a destructor are rejected rather than reported as done
+	for _, dtype := range []pb.DestructionType{
+		pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION,
+	} {
+		_, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+			Type:               dtype,
+			Targets:            []string{"test-target"},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			ConfirmDestruction: true,
+		})
+		if !errors.Is(err, ErrNotImplemented) {
+			t.Errorf("Expected ErrNotImplemented for destruction type %s, got: %v", dtype.String(), err)
+		}
+	}
 }
 
 func TestEngineWithMinimalConfig(t *testing.T) {
@@ -697,6 +1055,7 @@ func TestEngineWithMinimalConfig(t *testing.T) {
 	}
 
 	// Test basic functionality with minimal config
+	registerNoopDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION)
 	ctx := context.Background()
 	req := &pb.ExecuteDestructionRequest{
 		Type:               pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
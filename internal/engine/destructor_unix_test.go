@@ -0,0 +1,66 @@
+//go:build !windows
+
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestSafeDeletionRefusesSpecialFiles(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tree := filepath.Join(tempDir, "tree")
+	if err := os.MkdirAll(filepath.Join(tree, "nested"), 0755); err != nil {
+		t.Fatalf("Failed to create tree: %v", err)
+	}
+	file := filepath.Join(tree, "a.txt")
+	if err := os.WriteFile(file, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	fifo := filepath.Join(tree, "nested", "pipe")
+	if err := syscall.Mkfifo(fifo, 0644); err != nil {
+		t.Fatalf("Failed to create FIFO: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+		},
+	})
+	if count := engine.countFiles(tree); count != 1 {
+		t.Errorf("Expected only the regular file to be counted, got %d", count)
+	}
+
+	for _, target := range []string{tree, fifo} {
+		resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{target},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+			ConfirmDestruction: true,
+			SkipPreflight:      true,
+		})
+		if err != nil {
+			t.Fatalf("Expected a failed result rather than an error, got: %v", err)
+		}
+		result := resp.Results[0]
+		if result.Success || !strings.Contains(result.ErrorMessage, "cannot back up special file") {
+			t.Errorf("Expected %s to be refused, got: %+v", target, result)
+		}
+	}
+
+	// Nothing was deleted without a backup
+	for _, path := range []string{file, fifo} {
+		if _, err := os.Lstat(path); err != nil {
+			t.Errorf("Expected %s to remain: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+const (
+	// diskFillChunkSize is the size of each write while filling
+	diskFillChunkSize = 4 << 20
+	// safeModeDiskCap is the most disk space filled while safe mode is enabled
+	safeModeDiskCap = 1 << 30
+)
+
+// diskFillHoldDuration is how long fill files are kept before removal
+var diskFillHoldDuration = 10 * time.Second
+
+// diskCeiling returns how many bytes a disk fill may write in total
+func (e *DestructionEngine) diskCeiling(severity pb.DestructionSeverity, intensity int32) (int64, error) {
+	info, err := e.sysInfo.Collect()
+	if err != nil {
+		return 0, fmt.Errorf("failed to collect system info: %w", err)
+	}
+
+	available := info.Resources.AvailableDisk
+	if available <= 0 {
+		return 0, fmt.Errorf("available disk space could not be determined")
+	}
+
+	fraction := exhaustionFraction(severity, intensity)
+
+	ceiling := int64(float64(available) * fraction)
+	if e.config.Security.EnableSafeMode && ceiling > safeModeDiskCap {
+		ceiling = safeModeDiskCap
+	}
+
+	return ceiling, nil
+}
+
+// fillFilePath returns the file a task fills inside dir
+func fillFilePath(dir, taskID string) string {
+	return filepath.Join(dir, fmt.Sprintf("burndevice_fill_%s.dat", taskID))
+}
+
+// executeDiskFill writes a fill file into every target directory, sharing
+// the severity ceiling between them, holds them, then removes them.
+// Cancellation removes the files immediately.
+func (e *DestructionEngine) executeDiskFill(task *DestructionTask, progress progressFunc) ([]*pb.DestructionResult, error) {
+	if len(task.Targets) == 0 {
+		return nil, fmt.Errorf("disk fill requires at least one target directory")
+	}
+
+	ceiling, err := e.diskCeiling(task.Severity, task.Intensity)
+	if err != nil {
+		return nil, err
+	}
+	share := ceiling / int64(len(task.Targets))
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"ceiling": ceiling,
+		"targets": task.Targets,
+	}).Warn("🔥 Starting disk fill")
+
+	var results []*pb.DestructionResult
+	var fillFiles []string
+	defer func() {
+		for _, path := range fillFiles {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				e.logger.WithError(err).WithField("file", path).Warn("Failed to remove fill file")
+			}
+		}
+	}()
+
+	// Progress is reported once per percent so large fills don't flood
+	// the stream
+	var written int64
+	lastPercent := -1
+	report := func(target string) {
+		e.setProgress(task, float64(written)/float64(ceiling), target)
+		percent := int(written * 100 / ceiling)
+		if progress == nil || percent == lastPercent {
+			return
+		}
+		lastPercent = percent
+		progress(float64(written)/float64(ceiling),
+			fmt.Sprintf("Wrote %d of %d bytes", written, ceiling))
+	}
+
+	for _, target := range task.Targets {
+		result := &pb.DestructionResult{
+			Target:  target,
+			Metrics: &pb.DestructionMetrics{},
+		}
+		start := time.Now()
+
+		path := fillFilePath(target, task.ID)
+		err := e.fillFile(task, path, share, &fillFiles, func(n int64) {
+			written += n
+			result.Metrics.BytesWritten += n
+			report(target)
+		})
+		finishTiming(result, start)
+		if err != nil {
+			result.ErrorMessage = err.Error()
+			results = append(results, result)
+			e.targetProcessed(task, result)
+			if task.Context.Err() != nil {
+				return results, fmt.Errorf("disk fill cancelled: %w", task.Context.Err())
+			}
+			continue
+		}
+
+		result.Success = true
+		result.Action = fmt.Sprintf("filled %s with %d bytes", path, result.Metrics.BytesWritten)
+		results = append(results, result)
+		e.targetProcessed(task, result)
+	}
+
+	// Hold the fill so the system stays under pressure
+	select {
+	case <-task.Context.Done():
+		return results, fmt.Errorf("disk fill cancelled: %w", task.Context.Err())
+	case <-time.After(diskFillHoldDuration):
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"written": written,
+	}).Info("Disk fill completed, removing fill files")
+
+	return results, nil
+}
+
+// fillFile writes size bytes to a new file at path, calling onWrite after
+// every chunk. The path is added to created once the file exists so the
+// caller can remove it.
+func (e *DestructionEngine) fillFile(task *DestructionTask, path string, size int64, created *[]string, onWrite func(n int64)) error {
+	info, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("failed to stat target directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("disk fill target is not a directory: %s", filepath.Dir(path))
+	}
+
+	// #nosec G304 - Target directory has passed engine validation
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create fill file: %w", err)
+	}
+	*created = append(*created, path)
+	defer func() {
+		if err := file.Close(); err != nil {
+			e.logger.WithError(err).Warn("Failed to close fill file")
+		}
+	}()
+
+	buf := make([]byte, diskFillChunkSize)
+	for remaining := size; remaining > 0; {
+		if err := task.Context.Err(); err != nil {
+			return fmt.Errorf("disk fill cancelled: %w", err)
+		}
+
+		chunk := buf
+		if remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		n, err := file.Write(chunk)
+		remaining -= int64(n)
+		onWrite(int64(n))
+		if err != nil {
+			return fmt.Errorf("failed to write fill file: %w", err)
+		}
+	}
+
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync fill file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/system"
+)
+
+// newDiskCollector reports a fixed amount of available disk space
+func newDiskCollector(availableDisk int64) *fakeCollector {
+	return &fakeCollector{info: &system.Info{
+		Resources: system.Resources{AvailableDisk: availableDisk},
+	}}
+}
+
+func TestDiskCeiling(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	engine.sysInfo = newDiskCollector(1000)
+
+	ceiling, err := engine.diskCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if ceiling != 500 {
+		t.Errorf("Expected ceiling 500, got %d", ceiling)
+	}
+
+	engine.config.Security.EnableSafeMode = true
+	engine.sysInfo = newDiskCollector(64 << 30)
+	ceiling, err = engine.diskCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if ceiling != safeModeDiskCap {
+		t.Errorf("Expected safe mode to cap ceiling at %d, got %d", safeModeDiskCap, ceiling)
+	}
+
+	engine.sysInfo = newDiskCollector(0)
+	if _, err := engine.diskCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, 0); err == nil {
+		t.Error("Expected error when available disk space is unknown")
+	}
+}
+
+func TestExecuteDiskFill(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_disk_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	dirA := filepath.Join(tempDir, "a")
+	dirB := filepath.Join(tempDir, "b")
+	for _, dir := range []string{dirA, dirB} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	// LOW fills a quarter of 40 MiB, split between two targets
+	engine.sysInfo = newDiskCollector(40 << 20)
+
+	task := &DestructionTask{
+		ID:       "task_fill",
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL,
+		Targets:  []string{dirA, dirB},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Context:  context.Background(),
+	}
+
+	var updates []float64
+	results, err := engine.executeDiskFill(task, func(progress float64, message string) {
+		updates = append(updates, progress)
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		if !result.Success {
+			t.Errorf("Expected %s to succeed: %s", result.Target, result.ErrorMessage)
+		}
+		if result.Metrics.BytesWritten != 5<<20 {
+			t.Errorf("Expected 5 MiB written to %s, got %d", result.Target, result.Metrics.BytesWritten)
+		}
+		if _, err := os.Stat(fillFilePath(result.Target, task.ID)); !os.IsNotExist(err) {
+			t.Errorf("Expected fill file in %s to be removed, got: %v", result.Target, err)
+		}
+	}
+
+	if len(updates) == 0 || updates[len(updates)-1] != 1.0 {
+		t.Errorf("Expected progress to reach 1.0, got %v", updates)
+	}
+	for i := 1; i < len(updates); i++ {
+		if updates[i] <= updates[i-1] {
+			t.Errorf("Expected increasing progress, got %v", updates)
+			break
+		}
+	}
+}
+
+func TestExecuteDiskFillNotDirectory(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "burndevice_disk_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		t.Fatalf("Failed to close temp file: %v", err)
+	}
+	defer func() {
+		if err := os.Remove(tempFile.Name()); err != nil {
+			t.Errorf("Failed to remove temp file: %v", err)
+		}
+	}()
+
+	engine := NewDestructionEngine(&config.Config{})
+	engine.sysInfo = newDiskCollector(1 << 20)
+
+	results, err := engine.executeDiskFill(&DestructionTask{
+		ID:       "task_fill",
+		Targets:  []string{tempFile.Name()},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Context:  context.Background(),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Expected per-target failure, got error: %v", err)
+	}
+	if len(results) != 1 || results[0].Success {
+		t.Errorf("Expected failed result for non-directory target, got %+v", results)
+	}
+}
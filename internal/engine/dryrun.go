@@ -0,0 +1,409 @@
+package engine
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// dryRun describes what a destruction request would do without touching
+// the filesystem or creating backups
+func (e *DestructionEngine) dryRun(req *pb.ExecuteDestructionRequest) *pb.ExecuteDestructionResponse {
+	var results []*pb.DestructionResult
+	var filtered int64
+
+	switch req.Type {
+	case pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION:
+		filter := newFileFilter(req.IncludePatterns, req.ExcludePatterns, req.MinAge.AsDuration(), req.MaxFileSize)
+		var targets []string
+		var err error
+		targets, filtered, err = expandFileTargets(req.Targets, req.Recursive, filter)
+		if err != nil {
+			results = append(results, &pb.DestructionResult{
+				Target:       strings.Join(req.Targets, ","),
+				ErrorMessage: err.Error(),
+				Metrics:      &pb.DestructionMetrics{},
+			})
+			break
+		}
+		for _, target := range targets {
+			plan := e.planFileDeletion(target, req.Severity, req.Wipe)
+			if plan.Success && e.quarantining(req.Quarantine) {
+				plan.Action = fmt.Sprintf("would move %d files to quarantine at %s", plan.Metrics.FilesDeleted, e.quarantinePathFor(target))
+			}
+			results = append(results, plan)
+		}
+	case pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION:
+		for _, target := range req.Targets {
+			results = append(results, e.planFileCorruption(target, req.Severity))
+		}
+	case pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION:
+		results = append(results, e.planMemoryExhaustion(req))
+	case pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL:
+		results = append(results, e.planDiskFill(req)...)
+	case pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN:
+		results = append(results, e.planCPUBurn(req))
+	case pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION:
+		results = append(results, e.planInodeExhaustion(req)...)
+	case pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION:
+		for _, target := range req.Targets {
+			results = append(results, e.planServiceTermination(target))
+		}
+	case pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION:
+		for _, target := range req.Targets {
+			results = append(results, e.planNetworkDisruption(target, req.Duration.AsDuration(), req.Intensity))
+		}
+	default:
+		results = append(results, &pb.DestructionResult{
+			Target:  strings.Join(req.Targets, ","),
+			Success: true,
+			Action:  fmt.Sprintf("would run %s with its registered destructor", req.Type.String()),
+			Metrics: &pb.DestructionMetrics{},
+		})
+	}
+
+	var files, bytes int64
+	affected := 0
+	success := true
+	for _, result := range results {
+		if !result.Success {
+			success = false
+			continue
+		}
+		affected++
+		files += result.Metrics.FilesDeleted
+		bytes += result.Metrics.BytesDestroyed
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"type":    req.Type.String(),
+		"targets": req.Targets,
+		"files":   files,
+		"bytes":   bytes,
+	}).Info("Dry run completed")
+
+	return &pb.ExecuteDestructionResponse{
+		Success: success,
+		Message: fmt.Sprintf("DRY RUN: %d of %d targets would be destroyed (%d files, %d bytes); no changes were made",
+			affected, len(results), files, bytes) + filteredNote(filtered),
+		Results:       results,
+		FilesFiltered: filtered,
+		FailedTargets: failedTargets(results),
+		TotalMetrics:  TotalMetrics(results),
+	}
+}
+
+// planFileDeletion estimates what deleting target at severity would
+// remove, wiping it first when wipe is set
+func (e *DestructionEngine) planFileDeletion(target string, severity pb.DestructionSeverity, wipe bool) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  target,
+		Metrics: &pb.DestructionMetrics{},
+	}
+
+	if message := e.targetPolicyError(target); message != "" {
+		result.ErrorMessage = message
+		return result
+	}
+
+	info, err := os.Lstat(target)
+	if err != nil {
+		result.ErrorMessage = fmt.Sprintf("failed to stat file: %v", err)
+		return result
+	}
+
+	behavior, _ := e.deletionBehavior(severity)
+	if err := checkFilesOnly(behavior, severity, info); err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	if !info.IsDir() {
+		result.Metrics.FilesDeleted = 1
+		if info.Mode().IsRegular() {
+			result.Metrics.BytesDestroyed = info.Size()
+		}
+		result.Success = true
+		result.Action = e.planAction(target, severity, wipe, "")
+		return result
+	}
+
+	err = filepath.WalkDir(target, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		entryInfo, err := d.Info()
+		if err != nil {
+			return err
+		}
+		result.Metrics.FilesDeleted++
+		if entryInfo.Mode().IsRegular() {
+			result.Metrics.BytesDestroyed += entryInfo.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		result.ErrorMessage = fmt.Sprintf("failed to scan directory: %v", err)
+		return result
+	}
+
+	result.Success = true
+	result.Action = e.planAction(target, severity, wipe, fmt.Sprintf(" %d files", result.Metrics.FilesDeleted))
+	return result
+}
+
+// planFileCorruption estimates how many bytes corrupting target at
+// severity would overwrite
+func (e *DestructionEngine) planFileCorruption(target string, severity pb.DestructionSeverity) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  target,
+		Metrics: &pb.DestructionMetrics{},
+	}
+
+	if e.policy.IsBlocked(target) {
+		result.ErrorMessage = "Target is in blocked list"
+		return result
+	}
+
+	info, err := os.Lstat(target)
+	if err != nil {
+		result.ErrorMessage = fmt.Sprintf("failed to stat file: %v", err)
+		return result
+	}
+	if !info.Mode().IsRegular() {
+		result.ErrorMessage = "file corruption only supports regular files"
+		return result
+	}
+
+	percent := e.corruptionPercent(severity)
+	result.Metrics.OffsetsCorrupted = corruptionOffsets(info.Size(), percent)
+	result.Success = true
+	result.Action = fmt.Sprintf("would overwrite %d of %d bytes (%g%%) with random data", result.Metrics.OffsetsCorrupted, info.Size(), percent)
+	if behavior, _ := e.deletionBehavior(severity); behavior.Backup {
+		result.Action += fmt.Sprintf(" after backing it up to %s", e.backupPathFor(target))
+	}
+	return result
+}
+
+// planAction describes how target would be deleted at severity
+func (e *DestructionEngine) planAction(target string, severity pb.DestructionSeverity, wipe bool, files string) string {
+	behavior, downgraded := e.requestDeletionBehavior(severity, wipe)
+
+	if !behavior.Backup {
+		if behavior.WipePasses == 0 {
+			return fmt.Sprintf("would delete%s without backup", files)
+		}
+		return fmt.Sprintf("would overwrite%s with random data (%d passes) and delete without backup", files, behavior.WipePasses)
+	}
+
+	action := fmt.Sprintf("would back up to %s and delete%s", e.backupPathFor(target), files)
+	if behavior.WipePasses > 0 {
+		action = fmt.Sprintf("would back up to %s, overwrite%s with random data (%d passes) and delete", e.backupPathFor(target), files, behavior.WipePasses)
+	}
+	if downgraded {
+		action += " (" + shredDowngradeMessage + ")"
+	}
+	return action
+}
+
+// planServiceTermination reports whether service would be stopped
+func (e *DestructionEngine) planServiceTermination(service string) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  service,
+		Metrics: &pb.DestructionMetrics{},
+	}
+
+	if reason := e.serviceProtection(service); reason != "" {
+		result.ErrorMessage = reason
+		return result
+	}
+
+	result.Success = true
+	result.Action = fmt.Sprintf("would stop service %s with systemctl", service)
+	return result
+}
+
+// planMemoryExhaustion reports how much memory would be allocated
+func (e *DestructionEngine) planMemoryExhaustion(req *pb.ExecuteDestructionRequest) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  strings.Join(req.Targets, ","),
+		Metrics: &pb.DestructionMetrics{},
+	}
+
+	ceiling, err := e.memoryCeiling(req.Severity, req.Intensity)
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	result.Success = true
+	result.Metrics.BytesAllocated = ceiling
+	result.Action = fmt.Sprintf("would allocate %d bytes", ceiling)
+	return result
+}
+
+// planCPUBurn reports how many cores a CPU burn would keep busy, and for
+// how long
+func (e *DestructionEngine) planCPUBurn(req *pb.ExecuteDestructionRequest) *pb.DestructionResult {
+	cores := cpuBurnCores(req.Severity, req.Intensity)
+
+	return &pb.DestructionResult{
+		Target:  strings.Join(req.Targets, ","),
+		Success: true,
+		Action: fmt.Sprintf("would keep %d of %d cores %g%% busy for %s",
+			cores, runtime.NumCPU(), e.cpuBurnUtilization(), e.cpuBurnDuration(req.Duration.AsDuration())),
+		Metrics: &pb.DestructionMetrics{CoresBurned: int64(cores)},
+	}
+}
+
+// planDiskFill reports how much each target directory would be filled
+func (e *DestructionEngine) planDiskFill(req *pb.ExecuteDestructionRequest) []*pb.DestructionResult {
+	if len(req.Targets) == 0 {
+		return []*pb.DestructionResult{{
+			Metrics:      &pb.DestructionMetrics{},
+			ErrorMessage: "disk fill requires at least one target directory",
+		}}
+	}
+
+	ceiling, err := e.diskCeiling(req.Severity, req.Intensity)
+	share := ceiling / int64(len(req.Targets))
+
+	var results []*pb.DestructionResult
+	for _, target := range req.Targets {
+		result := &pb.DestructionResult{
+			Target:  target,
+			Metrics: &pb.DestructionMetrics{},
+		}
+		results = append(results, result)
+
+		if err != nil {
+			result.ErrorMessage = err.Error()
+			continue
+		}
+		if info, statErr := os.Stat(target); statErr != nil || !info.IsDir() {
+			result.ErrorMessage = fmt.Sprintf("disk fill target is not a directory: %s", target)
+			continue
+		}
+
+		result.Success = true
+		result.Metrics.BytesWritten = share
+		result.Action = fmt.Sprintf("would write %d bytes to %s", share, fillFilePath(target, "<task>"))
+	}
+
+	return results
+}
+
+// planInodeExhaustion reports how many files each target directory would
+// get, or how many file descriptors would be held
+func (e *DestructionEngine) planInodeExhaustion(req *pb.ExecuteDestructionRequest) []*pb.DestructionResult {
+	hold := e.inodeHoldDuration(req.Duration.AsDuration())
+
+	if req.FileDescriptors {
+		result := &pb.DestructionResult{
+			Target:  fdTarget,
+			Metrics: &pb.DestructionMetrics{},
+		}
+		ceiling, limit, err := e.fdCeiling(req.Severity, req.Intensity)
+		if err != nil {
+			result.ErrorMessage = err.Error()
+			return []*pb.DestructionResult{result}
+		}
+		result.Success = true
+		result.Metrics.FdsConsumed = ceiling
+		result.Action = fmt.Sprintf("would hold %d file descriptors (limit %d) for %s", ceiling, limit, hold)
+		return []*pb.DestructionResult{result}
+	}
+
+	if len(req.Targets) == 0 {
+		return []*pb.DestructionResult{{
+			Metrics:      &pb.DestructionMetrics{},
+			ErrorMessage: "inode exhaustion requires at least one target directory",
+		}}
+	}
+
+	var results []*pb.DestructionResult
+	for _, target := range req.Targets {
+		result := &pb.DestructionResult{
+			Target:  target,
+			Metrics: &pb.DestructionMetrics{},
+		}
+		results = append(results, result)
+
+		if info, err := os.Stat(target); err != nil || !info.IsDir() {
+			result.ErrorMessage = fmt.Sprintf("inode exhaustion target is not a directory: %s", target)
+			continue
+		}
+		ceiling, err := e.inodeCeiling(target, req.Severity, req.Intensity)
+		if err != nil {
+			result.ErrorMessage = err.Error()
+			continue
+		}
+
+		result.Success = true
+		result.Metrics.InodesConsumed = ceiling
+		result.Action = fmt.Sprintf("would create %d files in %s for %s", ceiling, inodeDirPath(target, "<task>"), hold)
+	}
+
+	return results
+}
+
+// streamDryRun sends the dry-run plan for req as one progress event per
+// result followed by a completion event carrying the summary
+func (e *DestructionEngine) streamDryRun(req *pb.StreamDestructionRequest, stream pb.BurnDeviceService_StreamDestructionServer) error {
+	plan := e.dryRun(&pb.ExecuteDestructionRequest{
+		Type:            req.Type,
+		Targets:         req.Targets,
+		Severity:        req.Severity,
+		DryRun:          true,
+		Recursive:       req.Recursive,
+		Duration:        req.Duration,
+		Intensity:       req.Intensity,
+		FileDescriptors: req.FileDescriptors,
+		Quarantine:      req.Quarantine,
+		IncludePatterns: req.IncludePatterns,
+		ExcludePatterns: req.ExcludePatterns,
+		MinAge:          req.MinAge,
+		MaxFileSize:     req.MaxFileSize,
+		Wipe:            req.Wipe,
+	})
+
+	for i, result := range plan.Results {
+		message := result.Action
+		if !result.Success {
+			message = "would fail: " + result.ErrorMessage
+		}
+
+		event := &pb.StreamDestructionResponse{
+			Timestamp: timestamppb.New(time.Now()),
+			Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
+			Target:    result.Target,
+			Progress:  float64(i+1) / float64(len(plan.Results)),
+			Message:   message,
+		}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+
+	return stream.Send(&pb.StreamDestructionResponse{
+		Timestamp:     timestamppb.New(time.Now()),
+		Type:          pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED,
+		Message:       plan.Message,
+		Progress:      1.0,
+		FilesFiltered: plan.FilesFiltered,
+		FailedTargets: plan.FailedTargets,
+		Results:       plan.Results,
+	})
+}
@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestDryRunFileDeletion(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_dryrun_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("12345"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	testDir := filepath.Join(tempDir, "tree")
+	if err := os.MkdirAll(filepath.Join(testDir, "nested"), 0755); err != nil {
+		t.Fatalf("Failed to create test dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testDir, "a.txt"), []byte("abc"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(testDir, "nested", "b.txt"), []byte("defg"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			RequireConfirmation: true,
+			MaxSeverity:         "HIGH",
+		},
+	}
+	engine := NewDestructionEngine(cfg)
+
+	// Dry runs don't need confirmation; LOW only takes single files
+	req := &pb.ExecuteDestructionRequest{
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:  []string{testFile, testDir},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		DryRun:   true,
+	}
+
+	resp, err := engine.ExecuteDestruction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error from dry run, got: %v", err)
+	}
+
+	if !resp.Success {
+		t.Errorf("Expected dry run to succeed, got message: %s", resp.Message)
+	}
+	if !strings.HasPrefix(resp.Message, "DRY RUN") {
+		t.Errorf("Expected message to indicate DRY RUN, got: %s", resp.Message)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(resp.Results))
+	}
+
+	if resp.Results[0].Metrics.BytesDestroyed != 5 {
+		t.Errorf("Expected 5 bytes estimated for file, got %d", resp.Results[0].Metrics.BytesDestroyed)
+	}
+	if resp.Results[1].Metrics.BytesDestroyed != 7 {
+		t.Errorf("Expected 7 bytes estimated for directory, got %d", resp.Results[1].Metrics.BytesDestroyed)
+	}
+	if resp.Results[1].Metrics.FilesDeleted != 2 {
+		t.Errorf("Expected 2 files estimated for directory, got %d", resp.Results[1].Metrics.FilesDeleted)
+	}
+	for _, result := range resp.Results {
+		if result.Action == "" {
+			t.Errorf("Expected an action description for %s", result.Target)
+		}
+	}
+
+	// Nothing may be touched
+	for _, path := range []string{testFile, filepath.Join(testDir, "nested", "b.txt")} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to remain after dry run: %v", path, err)
+		}
+	}
+	for _, target := range req.Targets {
+		if _, err := os.Lstat(engine.backupPathFor(target)); !os.IsNotExist(err) {
+			t.Errorf("Expected no backup for %s after dry run", target)
+		}
+	}
+	if len(engine.backups) != 0 {
+		t.Errorf("Expected no recorded backups, got %d", len(engine.backups))
+	}
+}
+
+func TestDryRunMissingTarget(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:  []string{"/non/existent/file.txt"},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		DryRun:   true,
+	}
+
+	resp, err := engine.ExecuteDestruction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error from dry run, got: %v", err)
+	}
+	if resp.Success {
+		t.Error("Expected dry run to report failure for missing target")
+	}
+	if resp.Results[0].ErrorMessage == "" {
+		t.Error("Expected error message for missing target")
+	}
+}
+
+func TestDryRunMemoryExhaustion(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+	engine.sysInfo = newFakeCollector(8 << 20)
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+		Targets:  []string{"memory"},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		DryRun:   true,
+	}
+
+	resp, err := engine.ExecuteDestruction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error from dry run, got: %v", err)
+	}
+	if got := resp.Results[0].Metrics.BytesAllocated; got != 4<<20 {
+		t.Errorf("Expected %d bytes planned, got %d", 4<<20, got)
+	}
+}
+
+// recordingStream records every event sent to it
+type recordingStream struct {
+	grpc.ServerStream
+	events []*pb.StreamDestructionResponse
+}
+
+func (s *recordingStream) Send(event *pb.StreamDestructionResponse) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *recordingStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamDryRun(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_dryrun_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("12345"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			RequireConfirmation: true,
+			MaxSeverity:         "HIGH",
+		},
+	})
+
+	stream := &recordingStream{}
+	req := &pb.StreamDestructionRequest{
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:  []string{testFile},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		DryRun:   true,
+	}
+	if err := engine.StreamDestruction(context.Background(), req, stream); err != nil {
+		t.Fatalf("Expected no error from streaming dry run, got: %v", err)
+	}
+
+	if _, err := os.Stat(testFile); err != nil {
+		t.Errorf("Expected file to survive dry run: %v", err)
+	}
+
+	if len(stream.events) != 2 {
+		t.Fatalf("Expected progress and completion events, got %d", len(stream.events))
+	}
+	if stream.events[0].Target != testFile || !strings.Contains(stream.events[0].Message, "would back up") {
+		t.Errorf("Unexpected progress event: %+v", stream.events[0])
+	}
+	last := stream.events[1]
+	if last.Type != pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED || !strings.HasPrefix(last.Message, "DRY RUN") {
+		t.Errorf("Unexpected final event: %+v", last)
+	}
+}
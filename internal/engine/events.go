@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// it is dropped
+const subscriberBuffer = 256
+
+// eventBus fans task events out to any number of subscribers. Publishing
+// never blocks: a subscriber whose buffer is full is dropped and its channel
+// closed, so a slow observer can't stall destruction.
+type eventBus struct {
+	mu          sync.Mutex
+	nextID      int
+	subscribers map[int]chan *pb.StreamDestructionResponse
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: make(map[int]chan *pb.StreamDestructionResponse)}
+}
+
+// subscribe returns a channel receiving every event published from now on
+// and a function that ends the subscription
+func (b *eventBus) subscribe() (<-chan *pb.StreamDestructionResponse, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan *pb.StreamDestructionResponse, subscriberBuffer)
+	b.subscribers[id] = ch
+
+	return ch, func() { b.drop(id) }
+}
+
+// drop removes a subscriber and closes its channel, once
+func (b *eventBus) drop(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ch, ok := b.subscribers[id]; ok {
+		delete(b.subscribers, id)
+		close(ch)
+	}
+}
+
+// publish delivers event to every subscriber that has room for it
+func (b *eventBus) publish(event *pb.StreamDestructionResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+// SubscribeEvents returns a channel receiving the lifecycle events of every
+// task and a function that ends the subscription. The channel is closed if
+// the subscriber falls too far behind.
+func (e *DestructionEngine) SubscribeEvents() (<-chan *pb.StreamDestructionResponse, func()) {
+	return e.events.subscribe()
+}
+
+// publishEvent stamps event with the task and hands subscribers their own
+// copy, so streams that go on to modify event don't race with them
+func (e *DestructionEngine) publishEvent(task *DestructionTask, event *pb.StreamDestructionResponse) {
+	published := proto.Clone(event).(*pb.StreamDestructionResponse)
+	published.TaskId = task.ID
+	if published.Timestamp == nil {
+		published.Timestamp = timestamppb.New(time.Now())
+	}
+	e.events.publish(published)
+}
+
+// startEvent announces that task has begun
+func startEvent() *pb.StreamDestructionResponse {
+	return &pb.StreamDestructionResponse{
+		Timestamp: timestamppb.New(time.Now()),
+		Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED,
+		Message:   "Destruction task started",
+		Progress:  0.0,
+	}
+}
+
+// warningEvent flags something about target that subscribers should know
+// but that didn't fail it, such as a downgraded shred or a timeout
+func warningEvent(target string, progress float64, message string) *pb.StreamDestructionResponse {
+	return &pb.StreamDestructionResponse{
+		Timestamp: timestamppb.New(time.Now()),
+		Type:      pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_WARNING,
+		Target:    target,
+		Progress:  progress,
+		Message:   message,
+	}
+}
+
+// finalEvent reports how task ended
+func (e *DestructionEngine) finalEvent(task *DestructionTask, results []*pb.DestructionResult, err error) *pb.StreamDestructionResponse {
+	event := &pb.StreamDestructionResponse{
+		Timestamp: timestamppb.New(time.Now()),
+		Progress:  1.0,
+	}
+
+	switch {
+	case e.stoppedByCancel(task, err):
+		event.Type = pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED
+		event.Message = fmt.Sprintf("Destruction cancelled after %d of %d targets", len(results), len(task.Targets))
+	case err != nil:
+		event.Type = pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR
+		event.Message = fmt.Sprintf("Destruction failed: %s", err.Error())
+	default:
+		event.Type = pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED
+		event.Message = "Destruction completed successfully"
+	}
+	event.Message += "; " + targetSummary(results)
+	event.Message += filteredNote(task.filtered)
+	event.Message += e.safeModeNote(task.Type, task.Severity)
+	event.FilesFiltered = task.filtered
+	event.FailedTargets = failedTargets(results)
+	event.Results = results
+	event.StartedAt = timestamppb.New(task.StartedAt)
+	event.CompletedAt = event.Timestamp
+	event.HookResults = task.hookResults
+
+	return event
+}
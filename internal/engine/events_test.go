@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestSubscribeEventsSeesUnaryExecution(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_events_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+	events, unsubscribe := engine.SubscribeEvents()
+	defer unsubscribe()
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := []pb.DestructionEventType{
+		pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED,
+		pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
+		pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED,
+	}
+	for i, eventType := range want {
+		var event *pb.StreamDestructionResponse
+		select {
+		case event = <-events:
+		default:
+			t.Fatalf("Expected %d events, got %d", len(want), i)
+		}
+
+		if event.Type != eventType {
+			t.Errorf("Expected event %d to be %s, got %s", i, eventType, event.Type)
+		}
+		if event.TaskId != resp.TaskId {
+			t.Errorf("Expected event %d for task %s, got %s", i, resp.TaskId, event.TaskId)
+		}
+		if event.Timestamp == nil {
+			t.Errorf("Expected event %d to be timestamped", i)
+		}
+	}
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	bus := newEventBus()
+	slow, unsubscribeSlow := bus.subscribe()
+	fast, unsubscribeFast := bus.subscribe()
+	defer unsubscribeFast()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		bus.publish(&pb.StreamDestructionResponse{Message: "event"})
+		select {
+		case _, ok := <-fast:
+			if !ok {
+				t.Fatalf("Expected the fast subscriber to be kept, dropped after %d events", i)
+			}
+		default:
+			t.Fatalf("Expected the fast subscriber to receive event %d", i)
+		}
+	}
+
+	buffered := 0
+	for range slow {
+		buffered++
+	}
+	if buffered != subscriberBuffer {
+		t.Errorf("Expected the slow subscriber to keep %d events before being dropped, got %d", subscriberBuffer, buffered)
+	}
+
+	// Unsubscribing after being dropped is harmless
+	unsubscribeSlow()
+}
+
+func TestSubscribeEventsSeesWarnings(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_events_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// Safe mode downgrades a CRITICAL deletion, which a unary request can
+	// only report to subscribers
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "CRITICAL",
+			EnableSafeMode: true,
+			BackupDir:      filepath.Join(tempDir, "backups"),
+		},
+	})
+	events, unsubscribe := engine.SubscribeEvents()
+	defer unsubscribe()
+
+	if _, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL,
+		ConfirmDestruction: true,
+	}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for {
+		var event *pb.StreamDestructionResponse
+		select {
+		case event = <-events:
+		default:
+			t.Fatal("Expected a warning event")
+		}
+
+		if event.Type == pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_WARNING {
+			if event.Target != testFile || event.Message != shredDowngradeMessage {
+				t.Errorf("Expected the shred downgrade warning for %s, got %+v", testFile, event)
+			}
+			return
+		}
+	}
+}
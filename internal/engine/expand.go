@@ -0,0 +1,239 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+)
+
+// maxExpandedTargets caps how many matches a pattern expansion returns
+const maxExpandedTargets = 1000
+
+// ErrNoMatches is returned when a glob target matches nothing
+var ErrNoMatches = errors.New("target pattern matched no files")
+
+// expandGlob resolves a glob pattern to its matches, sorted
+func expandGlob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", policy.ErrInvalidPattern, err)
+	}
+	return matches, nil
+}
+
+// walkPattern returns the paths under root matching the regular expression
+// pattern, sorted. The walk stops once more than limit paths have matched,
+// reporting that the matches were cut short.
+func walkPattern(ctx context.Context, pattern, root string, limit int) ([]string, bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", policy.ErrInvalidPattern, err)
+	}
+
+	var matches []string
+	truncated := false
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if path != root && re.MatchString(path) {
+			if len(matches) == limit {
+				truncated = true
+				return filepath.SkipAll
+			}
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	sort.Strings(matches)
+	return matches, truncated, nil
+}
+
+// isGlob reports whether target contains glob metacharacters
+func isGlob(target string) bool {
+	return strings.ContainsAny(target, "*?[")
+}
+
+// expandFileTargets resolves glob targets to their matches and, when
+// recursive is set or filter isn't nil, directories to every file beneath
+// them. Literal targets are kept as they are, and a path reached twice is
+// only returned once. Files filter leaves out are counted rather than
+// returned.
+func expandFileTargets(targets []string, recursive bool, filter *fileFilter) ([]string, int64, error) {
+	var expanded []string
+	var filtered int64
+	seen := make(map[string]bool)
+	add := func(path string, info fs.FileInfo) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		if info != nil && !filter.allows(path, info) {
+			filtered++
+			return
+		}
+		expanded = append(expanded, path)
+	}
+	recursive = recursive || filter != nil
+
+	for _, target := range targets {
+		paths := []string{target}
+		if isGlob(target) {
+			matches, err := expandGlob(target)
+			if err != nil {
+				return nil, 0, err
+			}
+			if len(matches) == 0 {
+				return nil, 0, fmt.Errorf("%w: %s", ErrNoMatches, target)
+			}
+			paths = matches
+		}
+
+		for _, path := range paths {
+			info, err := os.Lstat(path)
+			if err != nil {
+				// Deletion reports targets that can't be stat'ed
+				add(path, nil)
+				continue
+			}
+			if !recursive || !info.IsDir() {
+				add(path, info)
+				continue
+			}
+
+			// Symlinks are listed, never followed
+			err = filepath.WalkDir(path, func(entry string, d fs.DirEntry, walkErr error) error {
+				if walkErr != nil {
+					return walkErr
+				}
+				if d.IsDir() {
+					return nil
+				}
+				entryInfo, err := d.Info()
+				if err != nil {
+					return err
+				}
+				add(entry, entryInfo)
+				return nil
+			})
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to walk %s: %w", path, err)
+			}
+		}
+	}
+
+	if len(expanded) == 0 && filtered > 0 {
+		return nil, filtered, fmt.Errorf("%w (%d files filtered)", ErrAllFiltered, filtered)
+	}
+	return expanded, filtered, nil
+}
+
+// expandTaskTargets replaces a file deletion task's targets with their
+// expansion, so progress and status count the files actually matched
+func (e *DestructionEngine) expandTaskTargets(task *DestructionTask) error {
+	targets, filtered, err := expandFileTargets(task.Targets, task.Recursive, task.filter)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) != len(task.Targets) || filtered > 0 {
+		e.logger.WithFields(logrus.Fields{
+			"task_id":  task.ID,
+			"targets":  len(task.Targets),
+			"expanded": len(targets),
+			"filtered": filtered,
+		}).Info("Expanded file deletion targets")
+	}
+	e.mu.Lock()
+	task.Targets = targets
+	task.filtered = filtered
+	e.mu.Unlock()
+	return nil
+}
+
+// filteredNote describes the files a file deletion's filters left in
+// place, for messages
+func filteredNote(filtered int64) string {
+	if filtered == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d files left in place by the filters)", filtered)
+}
+
+// ExpandTargets previews what a target pattern resolves to and whether each
+// match would pass the blocked and allowed lists. Nothing is modified. A
+// regular expression is only matched under a root the policy allows, and
+// the walk stops at the limit, so the total is then a lower bound.
+func (e *DestructionEngine) ExpandTargets(ctx context.Context, req *pb.ExpandTargetsRequest) (*pb.ExpandTargetsResponse, error) {
+	limit := maxExpandedTargets
+	if req.Limit > 0 && int(req.Limit) < limit {
+		limit = int(req.Limit)
+	}
+
+	var matches []string
+	truncated := false
+	if req.Root == "" {
+		globbed, err := expandGlob(req.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		matches = globbed
+	} else {
+		root, err := filepath.Abs(req.Root)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: invalid root %s: %w", req.Root, err)
+		}
+		if err := e.policy.CheckTarget(root); err != nil {
+			return nil, policy.Reject("target", fmt.Errorf("validation failed: %w", err))
+		}
+		walked, cut, err := walkPattern(ctx, req.Pattern, root, limit)
+		if err != nil {
+			return nil, err
+		}
+		matches, truncated = walked, cut
+	}
+
+	response := &pb.ExpandTargetsResponse{
+		TotalMatches: int32(len(matches)),
+		Truncated:    truncated,
+	}
+	if len(matches) > limit {
+		matches = matches[:limit]
+		response.Truncated = true
+	}
+
+	for _, path := range matches {
+		match := &pb.TargetMatch{
+			Path:    path,
+			Blocked: e.policy.IsBlocked(path),
+			Allowed: e.policy.IsAllowed(path),
+		}
+		if info, err := os.Lstat(path); err == nil {
+			match.IsDir = info.IsDir()
+			if info.Mode().IsRegular() {
+				match.Size = info.Size()
+			}
+		}
+		response.Matches = append(response.Matches, match)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,290 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+)
+
+func TestExpandTargets(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_expand_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	allowedDir := filepath.Join(tempDir, "allowed")
+	blockedDir := filepath.Join(tempDir, "blocked")
+	for _, dir := range []string{allowedDir, blockedDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(allowedDir, "a.log"):    "aaa",
+		filepath.Join(allowedDir, "b.log"):    "bb",
+		filepath.Join(allowedDir, "c.txt"):    "c",
+		filepath.Join(blockedDir, "d.log"):    "dddd",
+		filepath.Join(tempDir, "outside.log"): "e",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			AllowedTargets: []string{allowedDir, blockedDir},
+			BlockedTargets: []string{blockedDir},
+		},
+	})
+
+	resp, err := engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: filepath.Join(tempDir, "*", "*.log")})
+	if err != nil {
+		t.Fatalf("ExpandTargets failed: %v", err)
+	}
+
+	want := []struct {
+		path    string
+		size    int64
+		blocked bool
+		allowed bool
+	}{
+		{filepath.Join(allowedDir, "a.log"), 3, false, true},
+		{filepath.Join(allowedDir, "b.log"), 2, false, true},
+		{filepath.Join(blockedDir, "d.log"), 4, true, true},
+	}
+	if len(resp.Matches) != len(want) || resp.TotalMatches != int32(len(want)) || resp.Truncated {
+		t.Fatalf("Expected %d matches, got %+v", len(want), resp)
+	}
+	for i, w := range want {
+		match := resp.Matches[i]
+		if match.Path != w.path || match.Size != w.size || match.Blocked != w.blocked || match.Allowed != w.allowed {
+			t.Errorf("Match %d = %+v, want %+v", i, match, w)
+		}
+	}
+
+	// Paths outside the allowed list are annotated, not dropped
+	resp, err = engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: filepath.Join(tempDir, "*.log")})
+	if err != nil {
+		t.Fatalf("ExpandTargets failed: %v", err)
+	}
+	if len(resp.Matches) != 1 || resp.Matches[0].Allowed {
+		t.Errorf("Expected outside.log to be reported as not allowed, got %+v", resp.Matches)
+	}
+
+	// The limit caps matches but reports the total
+	resp, err = engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: filepath.Join(allowedDir, "*"), Limit: 2})
+	if err != nil {
+		t.Fatalf("ExpandTargets failed: %v", err)
+	}
+	if len(resp.Matches) != 2 || resp.TotalMatches != 3 || !resp.Truncated {
+		t.Errorf("Expected 2 of 3 matches and truncation, got %d of %d (truncated %v)",
+			len(resp.Matches), resp.TotalMatches, resp.Truncated)
+	}
+
+	// Regular expressions are matched under root
+	resp, err = engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: `\.txt$`, Root: allowedDir})
+	if err != nil {
+		t.Fatalf("ExpandTargets failed: %v", err)
+	}
+	if len(resp.Matches) != 1 || resp.Matches[0].Path != filepath.Join(allowedDir, "c.txt") {
+		t.Errorf("Expected regex to match c.txt, got %+v", resp.Matches)
+	}
+
+	// The walk stops one match past the limit
+	resp, err = engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: `\.log$`, Root: allowedDir, Limit: 1})
+	if err != nil {
+		t.Fatalf("ExpandTargets failed: %v", err)
+	}
+	if len(resp.Matches) != 1 || !resp.Truncated {
+		t.Errorf("Expected 1 match and truncation, got %d (truncated %v)", len(resp.Matches), resp.Truncated)
+	}
+
+	// A root outside the allowed list is never walked
+	_, err = engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: `\.log$`, Root: tempDir})
+	if policy.RejectionReason(err) != "target" {
+		t.Errorf("Expected root outside the allowed list to be rejected, got: %v", err)
+	}
+	_, err = engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: `\.log$`, Root: blockedDir})
+	if policy.RejectionReason(err) != "target" {
+		t.Errorf("Expected blocked root to be rejected, got: %v", err)
+	}
+
+	// Nothing was touched
+	for path := range files {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to survive expansion: %v", path, err)
+		}
+	}
+}
+
+func TestExpandTargetsInvalidPattern(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+
+	if _, err := engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: "[", Root: ""}); !errors.Is(err, policy.ErrInvalidPattern) {
+		t.Errorf("Expected ErrInvalidPattern for bad glob, got: %v", err)
+	}
+	if _, err := engine.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: "(", Root: os.TempDir()}); !errors.Is(err, policy.ErrInvalidPattern) {
+		t.Errorf("Expected ErrInvalidPattern for bad regex, got: %v", err)
+	}
+}
+
+func TestExpandTargetsCancelled(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"a.log": "a"})
+	engine := NewDestructionEngine(&config.Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := engine.ExpandTargets(ctx, &pb.ExpandTargetsRequest{Pattern: `\.log$`, Root: tempDir})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected walk to stop on a cancelled context, got: %v", err)
+	}
+}
+
+func TestFileDeletionGlobTargets(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{
+		"a.log":      "aaa",
+		"b.log":      "bb",
+		"c.txt":      "c",
+		"keep/d.log": "dddd",
+	})
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+			BlockedTargets: []string{filepath.Join(tempDir, "keep")},
+		},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{filepath.Join(tempDir, "*.log"), filepath.Join(tempDir, "k*", "*.log")},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+
+	if len(resp.Results) != 3 {
+		t.Fatalf("Expected a result per matched file, got %+v", resp.Results)
+	}
+
+	var files, bytes int64
+	for _, result := range resp.Results[:2] {
+		if !result.Success {
+			t.Errorf("Expected %s to be deleted, got: %s", result.Target, result.ErrorMessage)
+		}
+		files += result.Metrics.FilesDeleted
+		bytes += result.Metrics.BytesDestroyed
+	}
+	if files != 2 || bytes != 5 {
+		t.Errorf("Expected 2 files and 5 bytes across the matches, got %d files and %d bytes", files, bytes)
+	}
+
+	// Each match is checked against the blocked list on its own
+	blocked := resp.Results[2]
+	if blocked.Target != filepath.Join(tempDir, "keep", "d.log") || blocked.Success {
+		t.Errorf("Expected the match under the blocked dir to fail, got %+v", blocked)
+	}
+
+	for name, exists := range map[string]bool{"a.log": false, "b.log": false, "c.txt": true, "keep/d.log": true} {
+		_, err := os.Stat(filepath.Join(tempDir, name))
+		if exists != (err == nil) {
+			t.Errorf("Expected %s to exist: %v, got: %v", name, exists, err)
+		}
+	}
+}
+
+func TestFileDeletionGlobMatchingNothing(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"a.txt": "a"})
+	pattern := filepath.Join(tempDir, "*.log")
+
+	if _, _, err := expandFileTargets([]string{pattern}, false, nil); !errors.Is(err, ErrNoMatches) {
+		t.Errorf("Expected ErrNoMatches, got: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{pattern},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Message, pattern) {
+		t.Errorf("Expected the request to fail naming the pattern, got %+v", resp)
+	}
+}
+
+func TestFileDeletionRecursive(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{
+		"logs/a.log":        "aaa",
+		"logs/nested/b.log": "bb",
+	})
+	logs := filepath.Join(tempDir, "logs")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity: "HIGH",
+			DeletionBehaviors: map[string]config.DeletionBehavior{
+				"HIGH": {FilesOnly: true},
+			},
+		},
+	})
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{logs},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+	}
+
+	// Without --recursive the directory itself is the target, which a
+	// files-only severity refuses
+	resp, err := engine.ExecuteDestruction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Success {
+		t.Fatalf("Expected the directory target to be refused, got %+v", resp.Results)
+	}
+
+	req.Recursive = true
+	resp, err = engine.ExecuteDestruction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected a result per file, got %+v", resp.Results)
+	}
+	for _, result := range resp.Results {
+		if !result.Success {
+			t.Errorf("Expected %s to be deleted, got: %s", result.Target, result.ErrorMessage)
+		}
+		if _, err := os.Stat(result.Target); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be gone, got: %v", result.Target, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(logs, "nested")); err != nil {
+		t.Errorf("Expected directories to be left in place, got: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// failFastSkippedMessage is the error reported for targets a FAIL_FAST
+// task skipped after an earlier target failed
+const failFastSkippedMessage = "skipped: an earlier target failed (fail fast)"
+
+// successPolicyAny is the engine.success_policy under which a run succeeds
+// once any of its targets has
+const successPolicyAny = "any"
+
+// stopOnFailure ends a FAIL_FAST task once one of its targets has failed.
+// The task is stopped through its context, so every destructor halts at
+// its next cancellation check. Callers must hold the engine lock.
+func (task *DestructionTask) stopOnFailure(result *pb.DestructionResult) {
+	if result.Success || task.FailurePolicy != pb.FailurePolicy_FAILURE_POLICY_FAIL_FAST || task.firstFailure != nil {
+		return
+	}
+	task.firstFailure = result
+	if task.Cancel != nil {
+		task.Cancel()
+	}
+}
+
+// applyFailurePolicy turns failed targets into the task's error. A
+// FAIL_FAST task that stopped at a failure skips the targets it didn't
+// reach; otherwise failed targets fail the task with a count of the
+// failures, unless engine.success_policy is "any" and one target
+// succeeded. An error the task already ended with is kept.
+func (e *DestructionEngine) applyFailurePolicy(task *DestructionTask, results []*pb.DestructionResult, err error) ([]*pb.DestructionResult, error) {
+	e.mu.RLock()
+	first := task.firstFailure
+	e.mu.RUnlock()
+
+	if first != nil && !e.isCancelled(task) && !e.timedOut(task, err) {
+		results, skipped := e.skipUnreached(task, results, failFastSkippedMessage)
+		e.logger.WithFields(logrus.Fields{
+			"task_id": task.ID,
+			"target":  first.Target,
+			"skipped": skipped,
+		}).Warn("Destruction stopped at the first failed target")
+
+		if len(skipped) == 0 {
+			return results, fmt.Errorf("target %s failed: %s", first.Target, first.ErrorMessage)
+		}
+		return results, fmt.Errorf("target %s failed: %s; skipped %d targets: %s",
+			first.Target, first.ErrorMessage, len(skipped), strings.Join(skipped, ", "))
+	}
+
+	if err != nil {
+		return results, err
+	}
+
+	failed := failedTargets(results)
+	if len(failed) == 0 {
+		return results, nil
+	}
+	if e.config.Engine.SuccessPolicy == successPolicyAny && len(failed) < len(results) {
+		e.logger.WithFields(logrus.Fields{
+			"task_id": task.ID,
+			"failed":  failed,
+		}).Warn("Some targets failed; the task succeeds under the any success policy")
+		return results, nil
+	}
+	return results, fmt.Errorf("%d of %d targets failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+}
+
+// failedTargets lists the targets of results that did not succeed,
+// skipped ones included
+func failedTargets(results []*pb.DestructionResult) []string {
+	var failed []string
+	for _, result := range results {
+		if !result.Success {
+			failed = append(failed, result.Target)
+		}
+	}
+	return failed
+}
+
+// targetSummary counts results by outcome, e.g. "targets: 2 succeeded, 1
+// failed, 1 skipped"
+func targetSummary(results []*pb.DestructionResult) string {
+	var succeeded, failed, skipped int
+	for _, result := range results {
+		switch {
+		case result.Success:
+			succeeded++
+		case result.Skipped:
+			skipped++
+		default:
+			failed++
+		}
+	}
+	return fmt.Sprintf("targets: %d succeeded, %d failed, %d skipped", succeeded, failed, skipped)
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// newFailureTargets creates a mix of targets: a file, a missing file, a
+// pattern whose only match is blocked and another file
+func newFailureTargets(t *testing.T) (*DestructionEngine, []string) {
+	t.Helper()
+	tempDir := newTestTree(t, map[string]string{
+		"first.txt":        "content",
+		"blocked/keep.txt": "content",
+		"last.txt":         "content",
+	})
+	blockedDir := filepath.Join(tempDir, "blocked")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "LOW",
+			BlockedTargets: []string{filepath.Join(blockedDir, "keep.txt")},
+		},
+	})
+	return engine, []string{
+		filepath.Join(tempDir, "first.txt"),
+		filepath.Join(tempDir, "missing.txt"),
+		filepath.Join(blockedDir, "*.txt"),
+		filepath.Join(tempDir, "last.txt"),
+	}
+}
+
+func executeWithPolicy(t *testing.T, engine *DestructionEngine, targets []string, policy pb.FailurePolicy) *pb.ExecuteDestructionResponse {
+	t.Helper()
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		FailurePolicy:      policy,
+		SkipPreflight:      true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	return resp
+}
+
+func TestFailurePolicyContinue(t *testing.T) {
+	for _, policy := range []pb.FailurePolicy{
+		pb.FailurePolicy_FAILURE_POLICY_UNSPECIFIED,
+		pb.FailurePolicy_FAILURE_POLICY_CONTINUE,
+	} {
+		t.Run(policy.String(), func(t *testing.T) {
+			engine, targets := newFailureTargets(t)
+			resp := executeWithPolicy(t, engine, targets, policy)
+
+			if resp.Success {
+				t.Error("Expected failed targets to fail the response")
+			}
+			if !strings.Contains(resp.Message, "2 of 4 targets failed") {
+				t.Errorf("Expected a count of the failures, got %q", resp.Message)
+			}
+			if len(resp.Results) != 4 {
+				t.Fatalf("Expected every target to be processed, got %d results", len(resp.Results))
+			}
+			if !resp.Results[0].Success || !resp.Results[3].Success {
+				t.Errorf("Expected the valid targets to be deleted, got %+v", resp.Results)
+			}
+			if _, err := os.Stat(targets[3]); !os.IsNotExist(err) {
+				t.Error("Expected the run to continue past the failures")
+			}
+
+			history, err := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+			if err != nil || len(history.Tasks) != 1 || history.Tasks[0].State != TaskStateFailed {
+				t.Errorf("Expected the task to be recorded as failed, got %v, %v", history, err)
+			}
+		})
+	}
+}
+
+func TestFailurePolicyFailFast(t *testing.T) {
+	engine, targets := newFailureTargets(t)
+	resp := executeWithPolicy(t, engine, targets, pb.FailurePolicy_FAILURE_POLICY_FAIL_FAST)
+
+	if resp.Success {
+		t.Error("Expected the response to be unsuccessful")
+	}
+	if !strings.Contains(resp.Message, "missing.txt failed") || !strings.Contains(resp.Message, "skipped 2 targets") {
+		t.Errorf("Expected the message to name the failure and the skipped targets, got %q", resp.Message)
+	}
+	if len(resp.Results) != 4 {
+		t.Fatalf("Expected a result for every target, got %d", len(resp.Results))
+	}
+	if !resp.Results[0].Success || resp.Results[1].Success {
+		t.Errorf("Expected the first target deleted and the second failed, got %+v", resp.Results[:2])
+	}
+	for _, result := range resp.Results[2:] {
+		if result.Success || !result.Skipped || result.ErrorMessage != failFastSkippedMessage {
+			t.Errorf("Expected %s to be skipped, got %+v", result.Target, result)
+		}
+	}
+	if !strings.Contains(resp.Message, "targets: 1 succeeded, 1 failed, 2 skipped") {
+		t.Errorf("Expected the message to count the outcomes, got %q", resp.Message)
+	}
+	if len(resp.FailedTargets) != 3 || resp.FailedTargets[0] != targets[1] {
+		t.Errorf("Expected the failed and skipped targets, got %v", resp.FailedTargets)
+	}
+	if _, err := os.Stat(targets[3]); err != nil {
+		t.Errorf("Expected the run to stop before the last target: %v", err)
+	}
+
+	history, err := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil || len(history.Tasks) != 1 || history.Tasks[0].State != TaskStateFailed {
+		t.Errorf("Expected a failed rather than cancelled task, got %v, %v", history, err)
+	}
+}
+
+func TestStreamFailurePolicyFailFast(t *testing.T) {
+	engine, targets := newFailureTargets(t)
+	stream := &recordingStream{}
+
+	err := engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		FailurePolicy:      pb.FailurePolicy_FAILURE_POLICY_FAIL_FAST,
+		SkipPreflight:      true,
+	}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	final := stream.events[len(stream.events)-1]
+	if final.Type != pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR || !strings.Contains(final.Message, "missing.txt failed") {
+		t.Errorf("Expected a final ERROR event naming the failure, got %s: %q", final.Type, final.Message)
+	}
+	if _, err := os.Stat(targets[3]); err != nil {
+		t.Errorf("Expected the stream to stop before the last target: %v", err)
+	}
+}
+
+func TestSuccessPolicyAny(t *testing.T) {
+	engine, targets := newFailureTargets(t)
+	engine.config.Engine.SuccessPolicy = "any"
+
+	resp := executeWithPolicy(t, engine, targets, pb.FailurePolicy_FAILURE_POLICY_CONTINUE)
+	if !resp.Success {
+		t.Errorf("Expected one succeeded target to be enough, got %q", resp.Message)
+	}
+	if !strings.Contains(resp.Message, "targets: 2 succeeded, 2 failed, 0 skipped") {
+		t.Errorf("Expected the message to count the outcomes, got %q", resp.Message)
+	}
+	if len(resp.FailedTargets) != 2 || resp.FailedTargets[0] != targets[1] {
+		t.Errorf("Expected the failed targets to be listed, got %v", resp.FailedTargets)
+	}
+
+	// With nothing succeeding the run still fails
+	resp = executeWithPolicy(t, engine, targets[1:3], pb.FailurePolicy_FAILURE_POLICY_CONTINUE)
+	if resp.Success {
+		t.Errorf("Expected a run with no succeeded targets to fail, got %q", resp.Message)
+	}
+}
@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrAllFiltered is returned when the filters of a file deletion leave
+// none of the files its targets matched
+var ErrAllFiltered = errors.New("the filters excluded every file the targets matched")
+
+// fileFilter decides which files a file deletion may touch. A nil filter
+// lets every file through.
+type fileFilter struct {
+	include []string
+	exclude []string
+	minAge  time.Duration
+	maxSize int64
+}
+
+// newFileFilter builds the filter for a request's filters, returning nil
+// when it sets none. The filters must already have passed
+// policy.CheckFileFilters.
+func newFileFilter(include, exclude []string, minAge time.Duration, maxSize int64) *fileFilter {
+	if len(include) == 0 && len(exclude) == 0 && minAge == 0 && maxSize == 0 {
+		return nil
+	}
+
+	return &fileFilter{
+		include: include,
+		exclude: exclude,
+		minAge:  minAge,
+		maxSize: maxSize,
+	}
+}
+
+// allows reports whether the file at path, described by info, passes
+// every filter
+func (f *fileFilter) allows(path string, info fs.FileInfo) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchesAny(f.include, path) {
+		return false
+	}
+	if matchesAny(f.exclude, path) {
+		return false
+	}
+	if f.minAge > 0 && time.Since(info.ModTime()) < f.minAge {
+		return false
+	}
+	if f.maxSize > 0 && info.Mode().IsRegular() && info.Size() > f.maxSize {
+		return false
+	}
+	return true
+}
+
+// matchesAny reports whether path matches one of patterns. A pattern with
+// a separator is matched against the whole path, any other against the
+// file name.
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		name := filepath.Base(path)
+		if strings.ContainsAny(pattern, "/"+string(filepath.Separator)) {
+			name = path
+		}
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
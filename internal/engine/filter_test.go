@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestExpandFileTargetsFilters(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{
+		".keep":          "",
+		"app.conf":       "conf",
+		"old.log":        "old",
+		"new.log":        "new",
+		"big.log":        "0123456789",
+		"nested/old.log": "old",
+		"nested/app.log": "app",
+	})
+	old := time.Now().Add(-48 * time.Hour)
+	for _, name := range []string{".keep", "app.conf", "old.log", "big.log", "nested/old.log"} {
+		if err := os.Chtimes(filepath.Join(tempDir, name), old, old); err != nil {
+			t.Fatalf("Failed to set mtime: %v", err)
+		}
+	}
+
+	// The filters compose: a log, not under nested, at least a day old and
+	// no bigger than 5 bytes
+	filter := newFileFilter([]string{"*.log"}, []string{filepath.Join(tempDir, "nested", "*")}, 24*time.Hour, 5)
+	targets, filtered, err := expandFileTargets([]string{tempDir}, false, filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(targets) != 1 || targets[0] != filepath.Join(tempDir, "old.log") {
+		t.Errorf("Expected only old.log to be kept, got %v", targets)
+	}
+	if filtered != 6 {
+		t.Errorf("Expected 6 files to be filtered, got %d", filtered)
+	}
+
+	// Literal targets are filtered too
+	filter = newFileFilter(nil, []string{".keep", "*.conf"}, 0, 0)
+	_, filtered, err = expandFileTargets([]string{filepath.Join(tempDir, ".keep"), filepath.Join(tempDir, "app.conf")}, false, filter)
+	if !errors.Is(err, ErrAllFiltered) || filtered != 2 {
+		t.Errorf("Expected ErrAllFiltered with 2 files filtered, got %d: %v", filtered, err)
+	}
+}
+
+func TestFileDeletionFilters(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{
+		"data/.keep":      "",
+		"data/app.conf":   "conf",
+		"data/a.log":      "aaa",
+		"data/sub/b.log":  "bb",
+		"data/sub/c.conf": "c",
+	})
+	dataDir := filepath.Join(tempDir, "data")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+		},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{dataDir},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+		ExcludePatterns:    []string{".keep", "*.conf"},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if !resp.Success || len(resp.Results) != 2 {
+		t.Fatalf("Expected the two logs to be deleted, got %+v", resp)
+	}
+	if resp.FilesFiltered != 3 || !strings.Contains(resp.Message, "3 files left in place by the filters") {
+		t.Errorf("Expected 3 filtered files to be reported, got %d: %s", resp.FilesFiltered, resp.Message)
+	}
+
+	for name, exists := range map[string]bool{"data/.keep": true, "data/app.conf": true, "data/sub/c.conf": true, "data/a.log": false, "data/sub/b.log": false} {
+		_, err := os.Stat(filepath.Join(tempDir, name))
+		if exists != (err == nil) {
+			t.Errorf("Expected %s to exist: %v, got: %v", name, exists, err)
+		}
+	}
+
+	// Nothing left to delete once every remaining file is filtered
+	resp, err = engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{dataDir},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+		MinAge:             durationpb.New(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ExecuteDestruction failed: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Message, ErrAllFiltered.Error()) {
+		t.Errorf("Expected the request to report that everything was filtered, got %+v", resp)
+	}
+}
@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"fmt"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// checkResourceFloor refuses a memory exhaustion or disk fill while the
+// resource it consumes is already below security.min_free_memory_bytes or
+// security.min_free_disk_bytes. Other types, and unset floors, pass.
+func (e *DestructionEngine) checkResourceFloor(t pb.DestructionType) error {
+	var resource, setting string
+	var floor int64
+	switch t {
+	case pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION:
+		resource, setting, floor = "memory", "min_free_memory_bytes", e.config.Security.MinFreeMemoryBytes
+	case pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL:
+		resource, setting, floor = "disk space", "min_free_disk_bytes", e.config.Security.MinFreeDiskBytes
+	default:
+		return nil
+	}
+	if floor <= 0 {
+		return nil
+	}
+
+	info, err := e.sysInfo.Collect()
+	if err != nil {
+		return fmt.Errorf("failed to collect system info to check security.%s: %w", setting, err)
+	}
+
+	available := info.Resources.AvailableMemory
+	if t == pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL {
+		available = info.Resources.AvailableDisk
+	}
+	if available < floor {
+		return fmt.Errorf("available %s is %d bytes, below the %d bytes security.%s requires to be left free",
+			resource, available, floor, setting)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/system"
+)
+
+func TestCheckResourceFloor(t *testing.T) {
+	memory := pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION
+	disk := pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL
+
+	tests := []struct {
+		name      string
+		t         pb.DestructionType
+		minMemory int64
+		minDisk   int64
+		wantErr   string
+	}{
+		{"memory above floor", memory, 1000, 0, ""},
+		{"memory below floor", memory, 3000, 0, "available memory is 2000 bytes, below the 3000 bytes security.min_free_memory_bytes"},
+		{"disk above floor", disk, 0, 4000, ""},
+		{"disk below floor", disk, 0, 5000, "available disk space is 4000 bytes, below the 5000 bytes security.min_free_disk_bytes"},
+		{"disk ignores the memory floor", disk, 3000, 0, ""},
+		{"floors disabled", memory, 0, 0, ""},
+		{"other types unaffected", pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, 3000, 5000, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewDestructionEngine(&config.Config{
+				Security: config.SecurityConfig{
+					MinFreeMemoryBytes: tt.minMemory,
+					MinFreeDiskBytes:   tt.minDisk,
+				},
+			})
+			engine.sysInfo = &fakeCollector{info: &system.Info{
+				Resources: system.Resources{AvailableMemory: 2000, AvailableDisk: 4000},
+			}}
+
+			err := engine.checkResourceFloor(tt.t)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// A floor that can't be checked refuses rather than risking the host
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MinFreeMemoryBytes: 1},
+	})
+	engine.sysInfo = &fakeCollector{err: errors.New("collector failed")}
+	if err := engine.checkResourceFloor(memory); err == nil {
+		t.Error("Expected an error when system info can't be collected")
+	}
+}
+
+func TestMemoryExhaustionRefusedBelowFloor(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:        "LOW",
+			MinFreeMemoryBytes: 1 << 30,
+		},
+	})
+	engine.sysInfo = newFakeCollector(512 << 20)
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+	_, err := engine.ExecuteDestruction(context.Background(), req)
+	if err == nil || !strings.Contains(err.Error(), "validation failed: available memory is 536870912 bytes") {
+		t.Fatalf("Expected the memory floor to refuse the request, got: %v", err)
+	}
+	if len(engine.ListTasks()) != 0 {
+		t.Error("Expected no task to be started")
+	}
+
+	// A dry run only previews, so it isn't refused
+	req.DryRun = true
+	if _, err := engine.ExecuteDestruction(context.Background(), req); err != nil {
+		t.Errorf("Expected a dry run to pass the floor, got: %v", err)
+	}
+}
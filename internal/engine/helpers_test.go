@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestTree creates files, keyed by their slash-separated path, in a
+// temporary directory removed when the test ends, and returns the directory
+func newTestTree(tb testing.TB, files map[string]string) string {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			tb.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	return dir
+}
+
+// newTestFiles creates n files holding content in a temporary directory
+// removed when the test ends, and returns the directory and the files
+func newTestFiles(tb testing.TB, n int, content string) (string, []string) {
+	tb.Helper()
+
+	names := make([]string, n)
+	files := make(map[string]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("file%03d.txt", i)
+		files[names[i]] = content
+	}
+
+	dir := newTestTree(tb, files)
+	for i, name := range names {
+		names[i] = filepath.Join(dir, name)
+	}
+	return dir, names
+}
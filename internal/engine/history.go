@@ -0,0 +1,247 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/store"
+)
+
+const (
+	// historyFileName is the task history file inside the data directory
+	historyFileName = "task_history.jsonl"
+	// defaultHistoryPageSize is used when a request doesn't set a page size
+	defaultHistoryPageSize = 50
+	// maxHistoryPageSize caps how many tasks a single page may return
+	maxHistoryPageSize = 500
+)
+
+// ErrInvalidPageToken is returned when a history page token can't be parsed
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// taskHistory keeps finished tasks oldest first. When path is set every
+// record is also appended to a JSON-lines file so history survives restarts.
+type taskHistory struct {
+	mu        sync.Mutex
+	path      string
+	retention time.Duration
+	records   []*pb.TaskRecord
+	logger    *logrus.Logger
+}
+
+// newTaskHistory creates an empty history. An empty dataDir keeps history
+// in memory only; retentionDays of 0 keeps records forever.
+func newTaskHistory(dataDir string, retentionDays int, logger *logrus.Logger) *taskHistory {
+	h := &taskHistory{
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		logger:    logger,
+	}
+	if dataDir != "" {
+		h.path = filepath.Join(dataDir, historyFileName)
+	}
+	return h
+}
+
+// load reads the history file, dropping expired and unreadable lines. The
+// file is rewritten when anything was dropped.
+func (h *taskHistory) load() error {
+	if h.path == "" {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	records, skipped, err := store.ReadJSONLines(h.path, func() *pb.TaskRecord { return &pb.TaskRecord{} }, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read task history: %w", err)
+	}
+	h.records = append(h.records, records...)
+
+	if skipped > 0 {
+		h.logger.WithField("skipped", skipped).Warn("Skipped unreadable task history entries")
+	}
+
+	if h.prune(time.Now()) > 0 || skipped > 0 {
+		return h.rewrite()
+	}
+	return nil
+}
+
+// add records a finished task and persists it
+func (h *taskHistory) add(record *pb.TaskRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, record)
+	if h.path == "" {
+		return nil
+	}
+
+	if h.prune(time.Now()) > 0 {
+		return h.rewrite()
+	}
+	return h.appendRecord(record)
+}
+
+// prune drops records that finished before the retention window and
+// returns how many were dropped. Callers must hold h.mu.
+func (h *taskHistory) prune(now time.Time) int {
+	if h.retention <= 0 {
+		return 0
+	}
+
+	cutoff := now.Add(-h.retention)
+	kept := h.records[:0]
+	for _, record := range h.records {
+		if record.FinishedAt.AsTime().After(cutoff) {
+			kept = append(kept, record)
+		}
+	}
+	pruned := len(h.records) - len(kept)
+	h.records = kept
+	return pruned
+}
+
+// appendRecord writes one record to the end of the history file. Callers
+// must hold h.mu.
+func (h *taskHistory) appendRecord(record *pb.TaskRecord) error {
+	line, err := protojson.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode task record: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0750); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	// #nosec G304 - Path comes from the server configuration
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open task history: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write task history: %w", err)
+	}
+	return file.Close()
+}
+
+// rewrite replaces the history file with the records held in memory.
+// Callers must hold h.mu.
+func (h *taskHistory) rewrite() error {
+	if err := store.WriteJSONLines(h.path, h.records); err != nil {
+		return fmt.Errorf("failed to write task history: %w", err)
+	}
+	return nil
+}
+
+// query returns one page of records matching req, newest first. The page
+// token is the offset into the filtered results.
+func (h *taskHistory) query(req *pb.GetTaskHistoryRequest) (*pb.GetTaskHistoryResponse, error) {
+	offset := 0
+	if req.PageToken != "" {
+		parsed, err := strconv.Atoi(req.PageToken)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPageToken, req.PageToken)
+		}
+		offset = parsed
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultHistoryPageSize
+	}
+	if pageSize > maxHistoryPageSize {
+		pageSize = maxHistoryPageSize
+	}
+
+	h.mu.Lock()
+	var matches []*pb.TaskRecord
+	for i := len(h.records) - 1; i >= 0; i-- {
+		if historyMatches(h.records[i], req) {
+			matches = append(matches, h.records[i])
+		}
+	}
+	h.mu.Unlock()
+
+	response := &pb.GetTaskHistoryResponse{Total: int32(len(matches))}
+	if offset >= len(matches) {
+		return response, nil
+	}
+
+	end := offset + pageSize
+	if end < len(matches) {
+		response.NextPageToken = strconv.Itoa(end)
+	} else {
+		end = len(matches)
+	}
+	response.Tasks = matches[offset:end]
+
+	return response, nil
+}
+
+// historyMatches reports whether record passes the filters in req
+func historyMatches(record *pb.TaskRecord, req *pb.GetTaskHistoryRequest) bool {
+	if req.Type != pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED && record.Type != req.Type {
+		return false
+	}
+
+	started := record.StartedAt.AsTime()
+	if req.Since != nil && started.Before(req.Since.AsTime()) {
+		return false
+	}
+	if req.Until != nil && !started.Before(req.Until.AsTime()) {
+		return false
+	}
+	return true
+}
+
+// GetTaskHistory returns finished tasks, newest first
+func (e *DestructionEngine) GetTaskHistory(req *pb.GetTaskHistoryRequest) (*pb.GetTaskHistoryResponse, error) {
+	return e.history.query(req)
+}
+
+// recordHistory stores the outcome of a finished task. Failing to persist
+// it is logged but never fails the task.
+func (e *DestructionEngine) recordHistory(task *DestructionTask, results []*pb.DestructionResult, err error, message string) {
+	state := TaskStateCompleted
+	switch {
+	case e.stoppedByCancel(task, err):
+		state = TaskStateCancelled
+	case err != nil:
+		state = TaskStateFailed
+	}
+
+	record := &pb.TaskRecord{
+		TaskId:         task.ID,
+		Type:           task.Type,
+		Severity:       task.Severity,
+		Targets:        task.Targets,
+		State:          state,
+		Success:        err == nil,
+		Message:        message,
+		StartedAt:      timestamppb.New(task.StartedAt),
+		FinishedAt:     timestamppb.New(time.Now()),
+		Results:        results,
+		HookResults:    task.hookResults,
+		IdempotencyKey: task.IdempotencyKey,
+	}
+	if !task.AutoRestoreAt.IsZero() {
+		record.AutoRestoreAt = timestamppb.New(task.AutoRestoreAt)
+	}
+	e.counters.add(record)
+
+	if err := e.history.add(record); err != nil {
+		e.logger.WithError(err).WithField("task_id", task.ID).Warn("Failed to persist task history")
+	}
+}
@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+)
+
+func newHistoryRecord(id string, destructionType pb.DestructionType, finished time.Time) *pb.TaskRecord {
+	return &pb.TaskRecord{
+		TaskId:     id,
+		Type:       destructionType,
+		State:      TaskStateCompleted,
+		Success:    true,
+		StartedAt:  timestamppb.New(finished.Add(-time.Second)),
+		FinishedAt: timestamppb.New(finished),
+	}
+}
+
+func TestTaskHistoryPersistence(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_history_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	now := time.Now()
+	history := newTaskHistory(dataDir, 0, logrus.New())
+	for _, id := range []string{"task_1", "task_2"} {
+		if err := history.add(newHistoryRecord(id, pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, now)); err != nil {
+			t.Fatalf("Failed to add record: %v", err)
+		}
+	}
+
+	// A torn final line, e.g. from a crash mid-write, is skipped on load
+	file, err := os.OpenFile(filepath.Join(dataDir, historyFileName), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("Failed to open history file: %v", err)
+	}
+	if _, err := file.WriteString(`{"taskId":"task_3"`); err != nil {
+		t.Fatalf("Failed to write history file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Failed to close history file: %v", err)
+	}
+
+	reloaded := newTaskHistory(dataDir, 0, logrus.New())
+	if err := reloaded.load(); err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+
+	resp, err := reloaded.query(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Total != 2 || len(resp.Tasks) != 2 {
+		t.Fatalf("Expected 2 tasks after reload, got %d", resp.Total)
+	}
+	if resp.Tasks[0].TaskId != "task_2" {
+		t.Errorf("Expected newest task first, got %s", resp.Tasks[0].TaskId)
+	}
+}
+
+func TestTaskHistoryRetention(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_history_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	history := newTaskHistory(dataDir, 0, logrus.New())
+	old := newHistoryRecord("task_old", pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, time.Now().AddDate(0, 0, -10))
+	recent := newHistoryRecord("task_recent", pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, time.Now())
+	for _, record := range []*pb.TaskRecord{old, recent} {
+		if err := history.add(record); err != nil {
+			t.Fatalf("Failed to add record: %v", err)
+		}
+	}
+
+	// Reloading with a 7-day retention drops the old entry from the file too
+	pruned := newTaskHistory(dataDir, 7, logrus.New())
+	if err := pruned.load(); err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+
+	reloaded := newTaskHistory(dataDir, 0, logrus.New())
+	if err := reloaded.load(); err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	resp, err := reloaded.query(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(resp.Tasks) != 1 || resp.Tasks[0].TaskId != "task_recent" {
+		t.Errorf("Expected only the recent task to be kept, got %v", resp.Tasks)
+	}
+}
+
+func TestTaskHistoryQuery(t *testing.T) {
+	history := newTaskHistory("", 0, logrus.New())
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		destructionType := pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION
+		if i%2 == 1 {
+			destructionType = pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL
+		}
+		record := newHistoryRecord(ids.Task(), destructionType, base.Add(time.Duration(i)*time.Minute))
+		if err := history.add(record); err != nil {
+			t.Fatalf("Failed to add record: %v", err)
+		}
+	}
+
+	// Paging walks every record exactly once
+	seen := 0
+	req := &pb.GetTaskHistoryRequest{PageSize: 2}
+	for {
+		resp, err := history.query(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		seen += len(resp.Tasks)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if seen != 5 {
+		t.Errorf("Expected 5 tasks across pages, got %d", seen)
+	}
+
+	resp, err := history.query(&pb.GetTaskHistoryRequest{Type: pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Total != 2 {
+		t.Errorf("Expected 2 disk fill tasks, got %d", resp.Total)
+	}
+
+	// Records start one second before they finish
+	resp, err = history.query(&pb.GetTaskHistoryRequest{
+		Since: timestamppb.New(base.Add(time.Minute - time.Second)),
+		Until: timestamppb.New(base.Add(3*time.Minute - time.Second)),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Total != 2 {
+		t.Errorf("Expected 2 tasks in time range, got %d", resp.Total)
+	}
+
+	if _, err := history.query(&pb.GetTaskHistoryRequest{PageToken: "-1"}); err == nil {
+		t.Error("Expected error for invalid page token")
+	}
+}
+
+func TestExecuteDestructionRecordsHistory(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_history_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "victim.txt")
+	if err := os.WriteFile(testFile, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "MEDIUM"},
+		Storage:  config.StorageConfig{DataDir: filepath.Join(tempDir, "data")},
+	}
+	engine := NewDestructionEngine(cfg)
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// A fresh engine sees the task recorded by the first one
+	history, err := NewDestructionEngine(cfg).GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(history.Tasks) != 1 {
+		t.Fatalf("Expected 1 task in history, got %d", len(history.Tasks))
+	}
+
+	record := history.Tasks[0]
+	if record.TaskId != resp.TaskId || record.State != TaskStateCompleted || !record.Success {
+		t.Errorf("Unexpected record: %v", record)
+	}
+	if len(record.Results) != 1 || record.Results[0].Target != testFile {
+		t.Errorf("Expected the per-target result to be recorded, got %v", record.Results)
+	}
+	if record.FinishedAt.AsTime().Before(record.StartedAt.AsTime()) {
+		t.Error("Expected finish time after start time")
+	}
+}
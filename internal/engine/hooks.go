@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+)
+
+// defaultHookTimeout bounds hooks that don't configure a timeout
+const defaultHookTimeout = 30 * time.Second
+
+// Phases of the hooks run once per task
+const (
+	hookPhasePreExecute  = "pre_execute"
+	hookPhasePostExecute = "post_execute"
+)
+
+// runPostTargetHooks runs the post target hooks for every successful
+// result and attaches their outcome. A failing hook only fails the result
+// when the hook sets fail_destruction.
+func (e *DestructionEngine) runPostTargetHooks(task *DestructionTask, results []*pb.DestructionResult) {
+	hooks := e.config.Hooks.PostTarget
+	if len(hooks) == 0 {
+		return
+	}
+
+	for _, result := range results {
+		if !result.Success {
+			continue
+		}
+
+		for _, hook := range hooks {
+			hookResult := e.runPostTargetHook(task, hook, result)
+			result.HookResults = append(result.HookResults, hookResult)
+
+			if !hookResult.Success && hook.FailDestruction {
+				result.Success = false
+				result.ErrorMessage = fmt.Sprintf("post target hook %s failed: %s", hook.Command, hookResult.ErrorMessage)
+			}
+		}
+	}
+}
+
+// runPostTargetHook runs a single post target hook with placeholders
+// substituted from result, and the target in BURNDEVICE_TARGET
+func (e *DestructionEngine) runPostTargetHook(task *DestructionTask, hook config.HookConfig, result *pb.DestructionResult) *pb.HookResult {
+	var bytesDestroyed, filesDeleted int64
+	if result.Metrics != nil {
+		bytesDestroyed = result.Metrics.BytesDestroyed
+		filesDeleted = result.Metrics.FilesDeleted
+	}
+
+	replacer := strings.NewReplacer(
+		"{target}", result.Target,
+		"{backup_path}", result.BackupPath,
+		"{task_id}", task.ID,
+		"{type}", task.Type.String(),
+		"{bytes_destroyed}", strconv.FormatInt(bytesDestroyed, 10),
+		"{files_deleted}", strconv.FormatInt(filesDeleted, 10),
+	)
+	args := make([]string, len(hook.Args))
+	for i, arg := range hook.Args {
+		args[i] = replacer.Replace(arg)
+	}
+
+	env := append(taskHookEnv(task), "BURNDEVICE_TARGET="+result.Target)
+	return e.runHook(task.Context, task, hook, "", args, env, logrus.Fields{"target": result.Target})
+}
+
+// taskHooks returns the pre and post execute hooks for the task's type
+func (e *DestructionEngine) taskHooks(task *DestructionTask) (pre, post []config.HookConfig) {
+	return e.config.Hooks.For(strings.TrimPrefix(task.Type.String(), "DESTRUCTION_TYPE_"))
+}
+
+// runTask runs the task with destructor once its pre execute hooks pass.
+// When one fails no target is attempted, and every target is recorded as
+// skipped.
+func (e *DestructionEngine) runTask(ctx context.Context, destructor Destructor, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	if err := e.runPreExecuteHooks(task); err != nil {
+		results, _ := e.skipUnreached(task, nil, err.Error())
+		return results, err
+	}
+	return runDestructor(ctx, destructor, task)
+}
+
+// runPreExecuteHooks runs the pre execute hooks for the task's type in
+// order, recording each on the task. The first to fail stops the rest, and
+// its error aborts the task.
+func (e *DestructionEngine) runPreExecuteHooks(task *DestructionTask) error {
+	pre, _ := e.taskHooks(task)
+	for _, hook := range pre {
+		hookResult := e.runTaskHook(task, hook, hookPhasePreExecute)
+		task.hookResults = append(task.hookResults, hookResult)
+		if !hookResult.Success {
+			return fmt.Errorf("pre execute hook %s failed: %s", hook.Command, hookResult.ErrorMessage)
+		}
+	}
+	return nil
+}
+
+// runPostExecuteHooks runs every post execute hook for the task's type,
+// whatever err says about the task's outcome, recording each on the task
+func (e *DestructionEngine) runPostExecuteHooks(task *DestructionTask, err error) {
+	_, post := e.taskHooks(task)
+	for _, hook := range post {
+		task.hookResults = append(task.hookResults,
+			e.runTaskHook(task, hook, hookPhasePostExecute, "BURNDEVICE_SUCCESS="+strconv.FormatBool(err == nil)))
+	}
+}
+
+// runTaskHook runs a single task hook with the task's metadata, and env,
+// in its environment. Hooks outlive a cancelled task so post execute hooks
+// still see it through.
+func (e *DestructionEngine) runTaskHook(task *DestructionTask, hook config.HookConfig, phase string, env ...string) *pb.HookResult {
+	return e.runHook(context.Background(), task, hook, phase, hook.Args, append(taskHookEnv(task), env...), nil)
+}
+
+// taskHookEnv is the task's metadata every hook gets in its environment
+func taskHookEnv(task *DestructionTask) []string {
+	return []string{
+		"BURNDEVICE_TASK_ID=" + task.ID,
+		"BURNDEVICE_TYPE=" + strings.TrimPrefix(task.Type.String(), "DESTRUCTION_TYPE_"),
+		"BURNDEVICE_SEVERITY=" + policy.SeverityName(task.Severity),
+		"BURNDEVICE_TARGETS=" + strings.Join(task.Targets, "\n"),
+	}
+}
+
+// runHook runs hook with args and env until parent ends or the hook's
+// timeout passes, and returns its outcome. Both the hooks run once per
+// task and those run per target go through here.
+func (e *DestructionEngine) runHook(parent context.Context, task *DestructionTask, hook config.HookConfig, phase string,
+	args, env []string, fields logrus.Fields) *pb.HookResult {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	stdout, stderr, err := e.runEnv(ctx, env, hook.Command, args...)
+
+	hookResult := &pb.HookResult{
+		Command:  strings.Join(append([]string{hook.Command}, args...), " "),
+		Success:  err == nil,
+		Output:   e.truncateOutput(append(stdout, stderr...)),
+		Phase:    phase,
+		ExitCode: exitCode(err),
+	}
+	logger := e.logger.WithFields(logrus.Fields{
+		"task_id":   task.ID,
+		"phase":     phase,
+		"command":   hook.Command,
+		"exit_code": hookResult.ExitCode,
+	}).WithFields(fields)
+	if err != nil {
+		hookResult.ErrorMessage = err.Error()
+		logger.WithError(err).Warn("Hook failed")
+	} else {
+		logger.Info("Hook completed")
+	}
+
+	return hookResult
+}
@@ -0,0 +1,313 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// fakeRunner records the commands it is asked to run, and the environment
+// added to each
+type fakeRunner struct {
+	calls  [][]string
+	envs   [][]string
+	output string
+	err    error
+}
+
+func (f *fakeRunner) Run(ctx context.Context, name string, args ...string) ([]byte, []byte, error) {
+	return f.RunEnv(ctx, nil, name, args...)
+}
+
+func (f *fakeRunner) RunEnv(ctx context.Context, env []string, name string, args ...string) ([]byte, []byte, error) {
+	f.calls = append(f.calls, append([]string{name}, args...))
+	f.envs = append(f.envs, env)
+	return []byte(f.output), nil, f.err
+}
+
+func newHookEngine(t *testing.T, hook config.HookConfig) (*DestructionEngine, *fakeRunner, string) {
+	tempDir, err := os.MkdirTemp("", "burndevice_hooks_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	})
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity: "HIGH",
+		},
+		Hooks: config.HooksConfig{
+			PostTarget: []config.HookConfig{hook},
+		},
+	})
+	runner := &fakeRunner{output: "notified"}
+	engine.runner = runner
+
+	return engine, runner, tempDir
+}
+
+func TestPostTargetHooksRunOnSuccess(t *testing.T) {
+	engine, runner, tempDir := newHookEngine(t, config.HookConfig{
+		Command: "notify",
+		Args:    []string{"--target={target}", "{bytes_destroyed}", "{files_deleted}", "{type}"},
+		Timeout: time.Second,
+	})
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile, filepath.Join(tempDir, "missing.txt")},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		SkipPreflight:      true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Only the successfully deleted target triggers the hook
+	if len(runner.calls) != 1 {
+		t.Fatalf("Expected 1 hook call, got %d: %v", len(runner.calls), runner.calls)
+	}
+	want := []string{"notify", "--target=" + testFile, "5", "1", "DESTRUCTION_TYPE_FILE_DELETION"}
+	if strings.Join(runner.calls[0], " ") != strings.Join(want, " ") {
+		t.Errorf("Expected hook call %v, got %v", want, runner.calls[0])
+	}
+	env := strings.Join(runner.envs[0], "\n")
+	if !strings.Contains(env, "BURNDEVICE_TARGET="+testFile) || !strings.Contains(env, "BURNDEVICE_TYPE=FILE_DELETION") {
+		t.Errorf("Expected the target and task in the hook's environment, got %v", runner.envs[0])
+	}
+
+	hooks := resp.Results[0].HookResults
+	if len(hooks) != 1 || !hooks[0].Success || hooks[0].Output != "notified" {
+		t.Errorf("Expected successful hook result with output, got %v", hooks)
+	}
+	if len(resp.Results[1].HookResults) != 0 {
+		t.Error("Expected no hook results for failed target")
+	}
+}
+
+func TestPostTargetHooksSkippedOnDryRun(t *testing.T) {
+	engine, runner, tempDir := newHookEngine(t, config.HookConfig{Command: "notify"})
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	_, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:  []string{testFile},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		DryRun:   true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(runner.calls) != 0 {
+		t.Errorf("Expected no hook calls on dry run, got %v", runner.calls)
+	}
+}
+
+func TestPostTargetHookFailure(t *testing.T) {
+	tests := []struct {
+		name            string
+		failDestruction bool
+		expectSuccess   bool
+	}{
+		{name: "reported only", failDestruction: false, expectSuccess: true},
+		{name: "fails destruction", failDestruction: true, expectSuccess: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, runner, tempDir := newHookEngine(t, config.HookConfig{
+				Command:         "notify",
+				FailDestruction: tt.failDestruction,
+			})
+			runner.err = errors.New("exit status 1")
+
+			testFile := filepath.Join(tempDir, "test.txt")
+			if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+				Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+				Targets:            []string{testFile},
+				Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+				ConfirmDestruction: true,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			result := resp.Results[0]
+			if result.Success != tt.expectSuccess {
+				t.Errorf("Expected result success %v, got %v", tt.expectSuccess, result.Success)
+			}
+			if len(result.HookResults) != 1 || result.HookResults[0].Success {
+				t.Errorf("Expected a failed hook result, got %v", result.HookResults)
+			}
+			if result.HookResults[0].ErrorMessage == "" {
+				t.Error("Expected hook error message")
+			}
+		})
+	}
+}
+
+// newTaskHookEngine creates an engine running hooks as its task hooks
+func newTaskHookEngine(t *testing.T, hooks config.HooksConfig) (*DestructionEngine, *fakeRunner, string) {
+	tempDir, err := os.MkdirTemp("", "burndevice_hooks_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	})
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+		Hooks:    hooks,
+	})
+	runner := &fakeRunner{output: "ok"}
+	engine.runner = runner
+
+	return engine, runner, tempDir
+}
+
+func TestTaskHooks(t *testing.T) {
+	engine, runner, tempDir := newTaskHookEngine(t, config.HooksConfig{
+		PreExecute:  []config.HookConfig{{Command: "snapshot"}},
+		PostExecute: []config.HookConfig{{Command: "health-check"}},
+		Types: map[string]config.TypeHooks{
+			"file_deletion":     {PostExecute: []config.HookConfig{{Command: "notify"}}},
+			"MEMORY_EXHAUSTION": {PreExecute: []config.HookConfig{{Command: "never"}}},
+		},
+	})
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Expected success, got: %s", resp.Message)
+	}
+
+	var commands []string
+	for _, call := range runner.calls {
+		commands = append(commands, call[0])
+	}
+	if strings.Join(commands, ",") != "snapshot,health-check,notify" {
+		t.Fatalf("Expected snapshot, health-check, notify, got %v", commands)
+	}
+
+	env := strings.Join(runner.envs[0], "\n")
+	for _, want := range []string{
+		"BURNDEVICE_TASK_ID=" + resp.TaskId,
+		"BURNDEVICE_TYPE=FILE_DELETION",
+		"BURNDEVICE_SEVERITY=LOW",
+		"BURNDEVICE_TARGETS=" + testFile,
+	} {
+		if !strings.Contains(env, want) {
+			t.Errorf("Expected %s in the pre execute environment, got:\n%s", want, env)
+		}
+	}
+	if strings.Contains(env, "BURNDEVICE_SUCCESS") {
+		t.Error("Expected no outcome in the pre execute environment")
+	}
+	if post := strings.Join(runner.envs[1], "\n"); !strings.Contains(post, "BURNDEVICE_SUCCESS=true") {
+		t.Errorf("Expected the outcome in the post execute environment, got:\n%s", post)
+	}
+
+	phases := []string{hookPhasePreExecute, hookPhasePostExecute, hookPhasePostExecute}
+	if len(resp.HookResults) != len(phases) {
+		t.Fatalf("Expected %d hook results, got %v", len(phases), resp.HookResults)
+	}
+	for i, hook := range resp.HookResults {
+		if hook.Phase != phases[i] || !hook.Success || hook.ExitCode != 0 || hook.Output != "ok" {
+			t.Errorf("Expected successful %s hook, got %+v", phases[i], hook)
+		}
+	}
+
+	// Exit codes are kept in history
+	history, err := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(history.Tasks) != 1 || len(history.Tasks[0].HookResults) != len(phases) {
+		t.Errorf("Expected the hook results in history, got %v", history.Tasks)
+	}
+}
+
+func TestPreExecuteHookFailureAborts(t *testing.T) {
+	engine, runner, tempDir := newTaskHookEngine(t, config.HooksConfig{
+		PreExecute:  []config.HookConfig{{Command: "snapshot"}, {Command: "never"}},
+		PostExecute: []config.HookConfig{{Command: "health-check"}},
+	})
+	runner.err = errors.New("exit status 1")
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if resp.Success || !strings.Contains(resp.Message, "pre execute hook snapshot failed") {
+		t.Errorf("Expected the failed pre execute hook to abort the task, got: %s", resp.Message)
+	}
+	if _, err := os.Stat(testFile); err != nil {
+		t.Errorf("Expected target to be untouched: %v", err)
+	}
+	if len(resp.Results) != 1 || !resp.Results[0].Skipped {
+		t.Errorf("Expected the target to be skipped, got %v", resp.Results)
+	}
+
+	// The second pre execute hook never runs; the post execute hook does
+	if len(runner.calls) != 2 || runner.calls[1][0] != "health-check" {
+		t.Fatalf("Expected snapshot then health-check, got %v", runner.calls)
+	}
+	if post := strings.Join(runner.envs[1], "\n"); !strings.Contains(post, "BURNDEVICE_SUCCESS=false") {
+		t.Errorf("Expected the failure in the post execute environment, got:\n%s", post)
+	}
+	if len(resp.HookResults) != 2 || resp.HookResults[0].ExitCode != -1 {
+		t.Errorf("Expected 2 hook results with an unknown exit code, got %v", resp.HookResults)
+	}
+}
@@ -0,0 +1,327 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+const (
+	// inodeFilePrefix starts the name of everything inode exhaustion
+	// creates, so leftovers are easy to recognize
+	inodeFilePrefix = "burndevice_inode_"
+	// defaultInodeHoldDuration is how long inode exhaustion holds what it
+	// consumed when the request doesn't say
+	defaultInodeHoldDuration = 10 * time.Second
+	// safeModeInodeHoldCap is the longest inode exhaustion holds while safe
+	// mode is enabled
+	safeModeInodeHoldCap = time.Minute
+	// safeModeInodeCap is the most files created per target directory while
+	// safe mode is enabled
+	safeModeInodeCap = 100000
+	// safeModeFDCap is the most file descriptors held while safe mode is
+	// enabled
+	safeModeFDCap = 10000
+	// defaultMaxFDFraction is used when engine.max_fd_fraction is unset
+	defaultMaxFDFraction = 0.9
+	// fdTarget is the result target of file descriptor exhaustion
+	fdTarget = "file descriptors"
+)
+
+// fileLimits reads the limits inode exhaustion is sized against
+type fileLimits interface {
+	FreeInodes(path string) (int64, error)
+	OpenFiles() (limit, open int64, err error)
+}
+
+// inodeDirPath returns the directory a task creates its files in inside dir
+func inodeDirPath(dir, taskID string) string {
+	return filepath.Join(dir, inodeFilePrefix+taskID)
+}
+
+// inodeCeiling returns how many inodes inode exhaustion may consume on the
+// filesystem holding dir
+func (e *DestructionEngine) inodeCeiling(dir string, severity pb.DestructionSeverity, intensity int32) (int64, error) {
+	free, err := e.limits.FreeInodes(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read free inodes: %w", err)
+	}
+	if free <= 0 {
+		return 0, fmt.Errorf("free inodes could not be determined")
+	}
+
+	fraction := exhaustionFraction(severity, intensity)
+
+	ceiling := int64(float64(free) * fraction)
+	if e.config.Security.EnableSafeMode && ceiling > safeModeInodeCap {
+		ceiling = safeModeInodeCap
+	}
+	return ceiling, nil
+}
+
+// fdCeiling returns how many more file descriptors inode exhaustion may
+// hold, along with the process limit. Severity or intensity picks how
+// close to the limit the process gets, never past engine.max_fd_fraction of it.
+func (e *DestructionEngine) fdCeiling(severity pb.DestructionSeverity, intensity int32) (ceiling, limit int64, err error) {
+	limit, open, err := e.limits.OpenFiles()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fraction := exhaustionFraction(severity, intensity)
+	maxFraction := e.config.Engine.MaxFDFraction
+	if maxFraction <= 0 || maxFraction > 1 {
+		maxFraction = defaultMaxFDFraction
+	}
+
+	ceiling = int64(float64(limit)*math.Min(fraction, maxFraction)) - open
+	if ceiling <= 0 {
+		return 0, limit, fmt.Errorf("%d of %d file descriptors are already open", open, limit)
+	}
+	if e.config.Security.EnableSafeMode && ceiling > safeModeFDCap {
+		ceiling = safeModeFDCap
+	}
+	return ceiling, limit, nil
+}
+
+// inodeHoldDuration returns how long inode exhaustion asked to hold for
+// requested actually holds
+func (e *DestructionEngine) inodeHoldDuration(requested time.Duration) time.Duration {
+	duration := requested
+	if duration <= 0 {
+		duration = defaultInodeHoldDuration
+	}
+	if e.config.Security.EnableSafeMode && duration > safeModeInodeHoldCap {
+		duration = safeModeInodeHoldCap
+	}
+	return duration
+}
+
+// isResourceExhausted reports whether err means the filesystem or process
+// ran out of what inode exhaustion consumes, which ends it early rather
+// than failing it
+func isResourceExhausted(err error) bool {
+	return errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE) || errors.Is(err, syscall.ENFILE)
+}
+
+// executeInodeExhaustion creates empty files in every target directory, or
+// holds open file descriptors when the task asks for them, up to the
+// severity ceiling. What it consumed is held for the task's duration and
+// then released; cancellation releases it immediately.
+func (e *DestructionEngine) executeInodeExhaustion(task *DestructionTask) ([]*pb.DestructionResult, error) {
+	if task.FileDescriptors {
+		return e.exhaustFileDescriptors(task)
+	}
+	if len(task.Targets) == 0 {
+		return nil, fmt.Errorf("inode exhaustion requires at least one target directory")
+	}
+
+	var results []*pb.DestructionResult
+	var created []string
+	defer func() {
+		for _, dir := range created {
+			if err := os.RemoveAll(dir); err != nil {
+				e.logger.WithError(err).WithField("dir", dir).Warn("Failed to remove inode exhaustion files")
+			}
+		}
+	}()
+
+	for i, target := range task.Targets {
+		if err := task.Context.Err(); err != nil {
+			return results, fmt.Errorf("inode exhaustion cancelled: %w", err)
+		}
+
+		result := e.exhaustInodes(task, target, float64(i)/float64(len(task.Targets)), 1/float64(len(task.Targets)), &created)
+		results = append(results, result)
+		e.targetProcessed(task, result)
+		if err := task.Context.Err(); err != nil {
+			return results, fmt.Errorf("inode exhaustion cancelled: %w", err)
+		}
+	}
+
+	if err := e.holdExhaustion(task); err != nil {
+		return results, err
+	}
+
+	e.logger.WithField("task_id", task.ID).Info("Inode exhaustion completed, removing files")
+	return results, nil
+}
+
+// exhaustInodes fills one target directory with empty files. The
+// directory holding them is added to created once it exists so the caller
+// can remove it. Progress is reported within [base, base+span].
+func (e *DestructionEngine) exhaustInodes(task *DestructionTask, target string, base, span float64, created *[]string) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  target,
+		Metrics: &pb.DestructionMetrics{},
+	}
+	start := time.Now()
+	defer finishTiming(result, start)
+
+	info, err := os.Stat(target)
+	if err != nil {
+		result.ErrorMessage = fmt.Sprintf("failed to stat target directory: %v", err)
+		return result
+	}
+	if !info.IsDir() {
+		result.ErrorMessage = fmt.Sprintf("inode exhaustion target is not a directory: %s", target)
+		return result
+	}
+
+	ceiling, err := e.inodeCeiling(target, task.Severity, task.Intensity)
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"target":  target,
+		"ceiling": ceiling,
+	}).Warn("🔥 Starting inode exhaustion")
+
+	dir := inodeDirPath(target, task.ID)
+	if err := os.Mkdir(dir, 0750); err != nil {
+		result.ErrorMessage = fmt.Sprintf("failed to create inode exhaustion directory: %v", err)
+		return result
+	}
+	*created = append(*created, dir)
+	result.Metrics.InodesConsumed = 1
+
+	// Progress is reported once per percent so large runs don't flood the
+	// stream
+	lastPercent := -1
+	exhausted := false
+	for result.Metrics.InodesConsumed < ceiling {
+		if err := task.Context.Err(); err != nil {
+			result.ErrorMessage = fmt.Sprintf("inode exhaustion cancelled: %v", err)
+			return result
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("%s%d", inodeFilePrefix, result.Metrics.InodesConsumed))
+		// #nosec G304 - Target directory has passed engine validation
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			if isResourceExhausted(err) {
+				exhausted = true
+				break
+			}
+			result.ErrorMessage = fmt.Sprintf("failed to create file: %v", err)
+			return result
+		}
+		if err := file.Close(); err != nil {
+			result.ErrorMessage = fmt.Sprintf("failed to close file: %v", err)
+			return result
+		}
+		result.Metrics.InodesConsumed++
+
+		if percent := int(result.Metrics.InodesConsumed * 100 / ceiling); percent != lastPercent {
+			lastPercent = percent
+			task.ReportProgress(base+span*float64(result.Metrics.InodesConsumed)/float64(ceiling), target,
+				fmt.Sprintf("Created %d of %d files in %s", result.Metrics.InodesConsumed, ceiling, target))
+		}
+	}
+
+	result.Success = true
+	result.Action = fmt.Sprintf("created %d files in %s", result.Metrics.InodesConsumed, dir)
+	if exhausted {
+		result.Action += " before the filesystem ran out of inodes"
+	}
+	return result
+}
+
+// exhaustFileDescriptors opens the null device until the process holds
+// the severity ceiling of file descriptors, then holds them
+func (e *DestructionEngine) exhaustFileDescriptors(task *DestructionTask) ([]*pb.DestructionResult, error) {
+	result := &pb.DestructionResult{
+		Target:  fdTarget,
+		Metrics: &pb.DestructionMetrics{},
+	}
+	start := time.Now()
+	defer finishTiming(result, start)
+
+	ceiling, limit, err := e.fdCeiling(task.Severity, task.Intensity)
+	if err != nil {
+		return nil, err
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"ceiling": ceiling,
+		"limit":   limit,
+	}).Warn("🔥 Starting file descriptor exhaustion")
+
+	var files []*os.File
+	defer func() {
+		for _, file := range files {
+			if err := file.Close(); err != nil {
+				e.logger.WithError(err).Warn("Failed to close held file descriptor")
+			}
+		}
+	}()
+
+	lastPercent := -1
+	exhausted := false
+	for int64(len(files)) < ceiling {
+		if err := task.Context.Err(); err != nil {
+			result.Metrics.FdsConsumed = int64(len(files))
+			result.ErrorMessage = fmt.Sprintf("file descriptor exhaustion cancelled: %v", err)
+			return []*pb.DestructionResult{result}, fmt.Errorf("inode exhaustion cancelled: %w", err)
+		}
+
+		file, err := os.Open(os.DevNull)
+		if err != nil {
+			if isResourceExhausted(err) {
+				exhausted = true
+				break
+			}
+			result.Metrics.FdsConsumed = int64(len(files))
+			result.ErrorMessage = fmt.Sprintf("failed to open file descriptor: %v", err)
+			return []*pb.DestructionResult{result}, nil
+		}
+		files = append(files, file)
+
+		if percent := len(files) * 100 / int(ceiling); percent != lastPercent {
+			lastPercent = percent
+			task.ReportProgress(float64(len(files))/float64(ceiling), result.Target,
+				fmt.Sprintf("Holding %d of %d file descriptors", len(files), ceiling))
+		}
+	}
+	result.Metrics.FdsConsumed = int64(len(files))
+
+	if err := e.holdExhaustion(task); err != nil {
+		result.ErrorMessage = err.Error()
+		return []*pb.DestructionResult{result}, err
+	}
+
+	result.Success = true
+	result.Action = fmt.Sprintf("held %d file descriptors (limit %d)", len(files), limit)
+	if exhausted {
+		result.Action += " until the process ran out of descriptors"
+	}
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"held":    len(files),
+	}).Info("File descriptor exhaustion completed, closing descriptors")
+
+	return []*pb.DestructionResult{result}, nil
+}
+
+// holdExhaustion keeps what inode exhaustion consumed for the task's
+// duration, returning early when the task is cancelled
+func (e *DestructionEngine) holdExhaustion(task *DestructionTask) error {
+	select {
+	case <-task.Context.Done():
+		return fmt.Errorf("inode exhaustion cancelled: %w", task.Context.Err())
+	case <-time.After(e.inodeHoldDuration(task.Duration)):
+		return nil
+	}
+}
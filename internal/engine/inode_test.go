@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+)
+
+// fakeLimits reports fixed inode and file descriptor limits
+type fakeLimits struct {
+	free        int64
+	limit, open int64
+}
+
+func (f fakeLimits) FreeInodes(path string) (int64, error) {
+	return f.free, nil
+}
+
+func (f fakeLimits) OpenFiles() (limit, open int64, err error) {
+	return f.limit, f.open, nil
+}
+
+func newInodeTask(engine *DestructionEngine, targets []string, duration time.Duration) *DestructionTask {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DestructionTask{
+		ID:       ids.Task(),
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION,
+		Targets:  targets,
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Context:  ctx,
+		Cancel:   cancel,
+		Duration: duration,
+		engine:   engine,
+	}
+}
+
+func TestInodeCeilings(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	engine.limits = fakeLimits{free: 1000, limit: 1000, open: 100}
+
+	ceiling, err := engine.inodeCeiling("/tmp", pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if ceiling != 500 {
+		t.Errorf("Expected inode ceiling 500, got %d", ceiling)
+	}
+
+	// CRITICAL asks for 90% of the limit, less what is already open
+	ceiling, limit, err := engine.fdCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if ceiling != 800 || limit != 1000 {
+		t.Errorf("Expected fd ceiling 800 of 1000, got %d of %d", ceiling, limit)
+	}
+
+	// engine.max_fd_fraction caps every severity
+	engine.config.Engine.MaxFDFraction = 0.5
+	if ceiling, _, _ := engine.fdCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 0); ceiling != 400 {
+		t.Errorf("Expected max_fd_fraction to cap the fd ceiling at 400, got %d", ceiling)
+	}
+
+	engine.limits = fakeLimits{limit: 1000, open: 600}
+	if _, _, err := engine.fdCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, 0); err == nil {
+		t.Error("Expected an error when more descriptors are open than the severity allows")
+	}
+
+	engine.config.Security.EnableSafeMode = true
+	engine.limits = fakeLimits{free: 1 << 30}
+	if ceiling, _ := engine.inodeCeiling("/tmp", pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 0); ceiling != safeModeInodeCap {
+		t.Errorf("Expected safe mode to cap the inode ceiling at %d, got %d", safeModeInodeCap, ceiling)
+	}
+}
+
+func TestExecuteInodeExhaustion(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_inode_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	engine := NewDestructionEngine(&config.Config{})
+	// LOW consumes a quarter of 40 free inodes in each target
+	engine.limits = fakeLimits{free: 40}
+
+	task := newInodeTask(engine, []string{tempDir}, 10*time.Millisecond)
+	defer task.Cancel()
+
+	var counted int
+	task.progress = func(progress float64, message string) {
+		entries, err := os.ReadDir(inodeDirPath(tempDir, task.ID))
+		if err == nil {
+			counted = len(entries)
+		}
+	}
+
+	results, err := engine.executeInodeExhaustion(task)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("Expected a successful result, got %+v", results)
+	}
+	// The directory holding the files counts as one inode
+	if got := results[0].Metrics.InodesConsumed; got != 10 {
+		t.Errorf("Expected 10 inodes consumed, got %d", got)
+	}
+	if counted != 9 {
+		t.Errorf("Expected 9 prefixed files while running, got %d", counted)
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to read temp dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected every created file to be removed, found %d entries", len(entries))
+	}
+}
+
+func TestInodeExhaustionCancelled(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_inode_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	engine := NewDestructionEngine(&config.Config{})
+	engine.limits = fakeLimits{free: 40}
+
+	task := newInodeTask(engine, []string{tempDir}, time.Minute)
+	time.AfterFunc(50*time.Millisecond, task.Cancel)
+
+	start := time.Now()
+	results, err := engine.executeInodeExhaustion(task)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a cancellation error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to end the hold promptly, took %v", elapsed)
+	}
+	if len(results) != 1 || results[0].Metrics.InodesConsumed != 10 {
+		t.Errorf("Expected the consumed inodes to be reported, got %+v", results)
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, inodeFilePrefix+task.ID)); !os.IsNotExist(err) {
+		t.Errorf("Expected the files to be removed on cancellation, got: %v", err)
+	}
+}
+
+func TestExecuteFileDescriptorExhaustion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("/dev/fd is not available on Windows")
+	}
+
+	countOpen := func() int {
+		entries, err := os.ReadDir("/dev/fd")
+		if err != nil {
+			t.Fatalf("Failed to count open files: %v", err)
+		}
+		return len(entries)
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	// MEDIUM fills half of the limit, less what is already open
+	engine.limits = fakeLimits{limit: 200, open: 10}
+
+	task := newInodeTask(engine, nil, 10*time.Millisecond)
+	defer task.Cancel()
+	task.FileDescriptors = true
+	task.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM
+
+	var peak int
+	task.progress = func(progress float64, message string) {
+		if progress == 1 {
+			peak = countOpen()
+		}
+	}
+
+	before := countOpen()
+	results, err := engine.executeInodeExhaustion(task)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("Expected a successful result, got %+v", results)
+	}
+	if got := results[0].Metrics.FdsConsumed; got != 90 {
+		t.Errorf("Expected 90 file descriptors held, got %d", got)
+	}
+	if peak < before+90 {
+		t.Errorf("Expected at least %d open files while holding, got %d", before+90, peak)
+	}
+	if after := countOpen(); after != before {
+		t.Errorf("Expected every held descriptor to be closed, %d open before and %d after", before, after)
+	}
+}
@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/system"
+)
+
+const (
+	// memoryChunkSize is the size of each allocation step
+	memoryChunkSize = 64 << 20
+	// safeModeMemoryCap is the most memory held while safe mode is enabled
+	safeModeMemoryCap = 1 << 30
+	// pageSize is the stride used to touch allocated memory
+	pageSize = 4096
+)
+
+// memoryHoldDuration is how long allocated memory is held before release
+var memoryHoldDuration = 10 * time.Second
+
+// exhaustionFractions is the share of an available resource consumed per
+// severity
+var exhaustionFractions = map[pb.DestructionSeverity]float64{
+	pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW:      0.25,
+	pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM:   0.50,
+	pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH:     0.75,
+	pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL: 0.90,
+}
+
+// exhaustionFraction returns the share of an available resource consumed
+// at intensity percent, or at severity when no intensity is given
+func exhaustionFraction(severity pb.DestructionSeverity, intensity int32) float64 {
+	if intensity > 0 {
+		return float64(intensity) / 100
+	}
+	fraction, ok := exhaustionFractions[severity]
+	if !ok {
+		fraction = exhaustionFractions[pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW]
+	}
+	return fraction
+}
+
+// resourceCollector provides the resource readings exhaustion types are
+// sized against
+type resourceCollector interface {
+	Collect() (*system.Info, error)
+}
+
+// progressFunc reports progress (0.0-1.0) of a running destruction
+type progressFunc func(progress float64, message string)
+
+// memoryCeiling returns how many bytes a memory exhaustion may allocate
+func (e *DestructionEngine) memoryCeiling(severity pb.DestructionSeverity, intensity int32) (int64, error) {
+	info, err := e.sysInfo.Collect()
+	if err != nil {
+		return 0, fmt.Errorf("failed to collect system info: %w", err)
+	}
+
+	available := info.Resources.AvailableMemory
+	if available <= 0 {
+		return 0, fmt.Errorf("available memory could not be determined")
+	}
+
+	fraction := exhaustionFraction(severity, intensity)
+
+	ceiling := int64(float64(available) * fraction)
+	if e.config.Security.EnableSafeMode && ceiling > safeModeMemoryCap {
+		ceiling = safeModeMemoryCap
+	}
+
+	return ceiling, nil
+}
+
+// executeMemoryExhaustion allocates memory in chunks up to the severity
+// ceiling, holds it, then releases it. Cancellation frees everything
+// immediately.
+func (e *DestructionEngine) executeMemoryExhaustion(task *DestructionTask, progress progressFunc) ([]*pb.DestructionResult, error) {
+	result := &pb.DestructionResult{
+		Target:  strings.Join(task.Targets, ","),
+		Metrics: &pb.DestructionMetrics{},
+	}
+	start := time.Now()
+	defer finishTiming(result, start)
+
+	ceiling, err := e.memoryCeiling(task.Severity, task.Intensity)
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return []*pb.DestructionResult{result}, err
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id": task.ID,
+		"ceiling": ceiling,
+	}).Warn("🔥 Starting memory exhaustion")
+
+	var chunks [][]byte
+	release := func() {
+		chunks = nil
+		debug.FreeOSMemory()
+	}
+	defer release()
+
+	var allocated int64
+	for allocated < ceiling {
+		if err := task.Context.Err(); err != nil {
+			result.ErrorMessage = fmt.Sprintf("memory exhaustion cancelled after %d bytes", allocated)
+			return []*pb.DestructionResult{result}, fmt.Errorf("memory exhaustion cancelled: %w", err)
+		}
+
+		size := int64(memoryChunkSize)
+		if remaining := ceiling - allocated; remaining < size {
+			size = remaining
+		}
+
+		chunk := make([]byte, size)
+		// Touch every page so the allocation is actually resident
+		for i := 0; i < len(chunk); i += pageSize {
+			chunk[i] = 1
+		}
+		chunks = append(chunks, chunk)
+		allocated += size
+		result.Metrics.BytesAllocated = allocated
+		e.setProgress(task, float64(allocated)/float64(ceiling), result.Target)
+
+		if progress != nil {
+			progress(float64(allocated)/float64(ceiling),
+				fmt.Sprintf("Allocated %d of %d bytes", allocated, ceiling))
+		}
+	}
+
+	// Hold the allocation so the system stays under pressure
+	select {
+	case <-task.Context.Done():
+		result.ErrorMessage = fmt.Sprintf("memory exhaustion cancelled after %d bytes", allocated)
+		return []*pb.DestructionResult{result}, fmt.Errorf("memory exhaustion cancelled: %w", task.Context.Err())
+	case <-time.After(memoryHoldDuration):
+	}
+
+	result.Success = true
+	e.logger.WithFields(logrus.Fields{
+		"task_id":   task.ID,
+		"allocated": allocated,
+	}).Info("Memory exhaustion completed, releasing memory")
+
+	return []*pb.DestructionResult{result}, nil
+}
@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestMemoryCeiling(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	engine.sysInfo = newFakeCollector(1000)
+
+	tests := []struct {
+		severity pb.DestructionSeverity
+		expected int64
+	}{
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, 250},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, 500},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH, 750},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 900},
+	}
+
+	for _, tt := range tests {
+		ceiling, err := engine.memoryCeiling(tt.severity, 0)
+		if err != nil {
+			t.Errorf("Expected no error for %s, got: %v", tt.severity, err)
+		}
+		if ceiling != tt.expected {
+			t.Errorf("Expected ceiling %d for %s, got %d", tt.expected, tt.severity, ceiling)
+		}
+	}
+
+	// An intensity overrides the severity
+	ceiling, err := engine.memoryCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if ceiling != 100 {
+		t.Errorf("Expected intensity 10 to allocate 100 bytes, got %d", ceiling)
+	}
+}
+
+func TestMemoryCeilingSafeModeCap(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{EnableSafeMode: true},
+	}
+	engine := NewDestructionEngine(cfg)
+	engine.sysInfo = newFakeCollector(64 << 30)
+
+	ceiling, err := engine.memoryCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if ceiling != safeModeMemoryCap {
+		t.Errorf("Expected safe mode to cap ceiling at %d, got %d", safeModeMemoryCap, ceiling)
+	}
+}
+
+func TestMemoryCeilingUnknownMemory(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+
+	engine.sysInfo = newFakeCollector(0)
+	if _, err := engine.memoryCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, 0); err == nil {
+		t.Error("Expected error when available memory is unknown")
+	}
+
+	engine.sysInfo = &fakeCollector{err: errors.New("collector failed")}
+	if _, err := engine.memoryCeiling(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, 0); err == nil {
+		t.Error("Expected error when collection fails")
+	}
+}
+
+func TestExecuteMemoryExhaustion(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	// 200 MiB available at LOW severity allocates 50 MiB across chunks
+	engine.sysInfo = newFakeCollector(200 << 20)
+
+	task := &DestructionTask{
+		ID:       "test-task",
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+		Targets:  []string{"system_memory"},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Context:  context.Background(),
+	}
+
+	var updates []float64
+	results, err := engine.executeMemoryExhaustion(task, func(progress float64, message string) {
+		updates = append(updates, progress)
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(results) != 1 || !results[0].Success {
+		t.Fatalf("Expected one successful result, got: %+v", results)
+	}
+	if results[0].Metrics.BytesAllocated != 50<<20 {
+		t.Errorf("Expected %d bytes allocated, got %d", 50<<20, results[0].Metrics.BytesAllocated)
+	}
+	if len(updates) == 0 || updates[len(updates)-1] != 1.0 {
+		t.Errorf("Expected progress updates ending at 1.0, got %v", updates)
+	}
+}
+
+func TestExecuteMemoryExhaustionCancelled(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	engine.sysInfo = newFakeCollector(1 << 30)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	task := &DestructionTask{
+		ID:       "test-task",
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+		Targets:  []string{"system_memory"},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Context:  ctx,
+	}
+
+	// Cancel after the first chunk has been allocated
+	results, err := engine.executeMemoryExhaustion(task, func(progress float64, message string) {
+		cancel()
+	})
+	if err == nil {
+		t.Fatal("Expected error when memory exhaustion is cancelled")
+	}
+	if results[0].Success {
+		t.Error("Expected cancelled result to be unsuccessful")
+	}
+	if results[0].Metrics.BytesAllocated != memoryChunkSize {
+		t.Errorf("Expected allocation to stop after one chunk, got %d bytes", results[0].Metrics.BytesAllocated)
+	}
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+)
+
+// Metrics holds the Prometheus collectors for destruction activity. Each
+// engine has its own registry so several engines can run in one process;
+// the server registers its own collectors alongside.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	tasks      *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	files      *prometheus.CounterVec
+	bytes      *prometheus.CounterVec
+	rejections *prometheus.CounterVec
+}
+
+// newMetrics creates the collectors, reading the number of running tasks
+// from running on every scrape
+func newMetrics(running func() int) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		tasks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "burndevice_tasks_total",
+			Help: "Destruction tasks finished, by type, severity and final state.",
+		}, []string{"type", "severity", "state"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "burndevice_task_duration_seconds",
+			Help:    "How long destruction tasks ran, by type.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 10),
+		}, []string{"type"}),
+		files: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "burndevice_files_deleted_total",
+			Help: "Files deleted by finished tasks, by type.",
+		}, []string{"type"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "burndevice_bytes_destroyed_total",
+			Help: "Bytes destroyed by finished tasks, by type.",
+		}, []string{"type"}),
+		rejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "burndevice_validation_rejections_total",
+			Help: "Destruction requests rejected by validation, by type and reason.",
+		}, []string{"type", "reason"}),
+	}
+
+	runningTasks := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "burndevice_running_tasks",
+		Help: "Destruction tasks currently running.",
+	}, func() float64 {
+		return float64(running())
+	})
+
+	m.registry.MustRegister(m.tasks, m.duration, m.files, m.bytes, m.rejections, runningTasks)
+	return m
+}
+
+// MustRegister adds collectors to the registry served by Handler
+func (m *Metrics) MustRegister(collectors ...prometheus.Collector) {
+	m.registry.MustRegister(collectors...)
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// taskFinished records a finished destruction task
+func (m *Metrics) taskFinished(record *pb.TaskRecord) {
+	destructionType := typeName(record.Type)
+
+	m.tasks.WithLabelValues(destructionType, policy.SeverityName(record.Severity), record.State).Inc()
+	if record.StartedAt != nil && record.FinishedAt != nil {
+		m.duration.WithLabelValues(destructionType).Observe(record.FinishedAt.AsTime().Sub(record.StartedAt.AsTime()).Seconds())
+	}
+
+	var files, bytes int64
+	for _, result := range record.Results {
+		if result.Metrics != nil {
+			files += result.Metrics.FilesDeleted
+			bytes += result.Metrics.BytesDestroyed
+		}
+	}
+	m.files.WithLabelValues(destructionType).Add(float64(files))
+	m.bytes.WithLabelValues(destructionType).Add(float64(bytes))
+}
+
+// requestRejected records a request that failed validation with err
+func (m *Metrics) requestRejected(t pb.DestructionType, err error) {
+	m.rejections.WithLabelValues(typeName(t), policy.RejectionReason(err)).Inc()
+}
+
+// Metrics returns the engine's Prometheus collectors
+func (e *DestructionEngine) Metrics() *Metrics {
+	return e.metrics
+}
+
+// RequestRejected counts a request of type t that failed validation before
+// reaching the engine, e.g. in the server's own policy check
+func (e *DestructionEngine) RequestRejected(t pb.DestructionType, err error) {
+	e.metrics.requestRejected(t, err)
+}
+
+// runningCount returns how many tasks are registered
+func (e *DestructionEngine) runningCount() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.running)
+}
+
+// typeName returns the config name of t, e.g. "FILE_DELETION"
+func typeName(t pb.DestructionType) string {
+	return strings.TrimPrefix(t.String(), "DESTRUCTION_TYPE_")
+}
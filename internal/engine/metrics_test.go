@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// scrapeMetrics returns what the engine's metrics handler serves
+func scrapeMetrics(t *testing.T, engine *DestructionEngine) string {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	engine.Metrics().Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_metrics_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:         "HIGH",
+			RequireConfirmation: true,
+			TargetCooldown:      time.Minute,
+		},
+	})
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+	}
+	if resp, err := engine.ExecuteDestruction(context.Background(), req); err != nil || !resp.Success {
+		t.Fatalf("Expected the destruction to succeed, got %v, %v", resp, err)
+	}
+
+	// The target is cooling down now, and the engine counts why it refused
+	if _, err := engine.ExecuteDestruction(context.Background(), req); err == nil {
+		t.Fatal("Expected the cooling down target to be rejected")
+	}
+	req.ConfirmDestruction = false
+	if _, err := engine.ExecuteDestruction(context.Background(), req); err == nil {
+		t.Fatal("Expected the unconfirmed request to be rejected")
+	}
+
+	body := scrapeMetrics(t, engine)
+	for _, want := range []string{
+		`burndevice_tasks_total{severity="HIGH",state="completed",type="FILE_DELETION"} 1`,
+		`burndevice_task_duration_seconds_count{type="FILE_DELETION"} 1`,
+		`burndevice_files_deleted_total{type="FILE_DELETION"} 1`,
+		`burndevice_bytes_destroyed_total{type="FILE_DELETION"} 10`,
+		`burndevice_validation_rejections_total{reason="cooldown",type="FILE_DELETION"} 1`,
+		`burndevice_validation_rejections_total{reason="unconfirmed",type="FILE_DELETION"} 1`,
+		`burndevice_running_tasks 0`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the scrape to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetricsRunningTasks(t *testing.T) {
+	engine := newShutdownEngine()
+	done := startCPUBurn(t, engine, time.Minute)
+
+	if body := scrapeMetrics(t, engine); !strings.Contains(body, "burndevice_running_tasks 1") {
+		t.Errorf("Expected one running task, got:\n%s", body)
+	}
+
+	for _, task := range engine.ListTasks() {
+		if _, err := engine.CancelDestruction(task.TaskId); err != nil {
+			t.Fatalf("Failed to cancel task: %v", err)
+		}
+	}
+	<-done
+
+	body := scrapeMetrics(t, engine)
+	for _, want := range []string{
+		"burndevice_running_tasks 0",
+		`burndevice_tasks_total{severity="LOW",state="cancelled",type="CPU_BURN"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the scrape to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestRequestRejected(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+	engine.RequestRejected(pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, context.Canceled)
+
+	want := `burndevice_validation_rejections_total{reason="other",type="CPU_BURN"} 1`
+	if body := scrapeMetrics(t, engine); !strings.Contains(body, want) {
+		t.Errorf("Expected the scrape to contain %q, got:\n%s", want, body)
+	}
+}
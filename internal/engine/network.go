@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// defaultNetworkDisruptionDuration is how long network disruption holds
+// its rules when the request doesn't say
+const defaultNetworkDisruptionDuration = 30 * time.Second
+
+// networkRuleComment tags every rule network disruption adds, so leftovers
+// can be found with iptables -S
+const networkRuleComment = "burndevice"
+
+var (
+	// lookupIPAddrs resolves target host names; tests replace it
+	lookupIPAddrs = net.DefaultResolver.LookupIPAddr
+	// interfaceAddrs lists the host's own addresses; tests replace it
+	interfaceAddrs = net.InterfaceAddrs
+)
+
+// networkRule drops outgoing TCP traffic to one address and port, or a
+// random share of it. Each rule carries the ID of the task that added it.
+type networkRule struct {
+	taskID string
+	ip     net.IP
+	port   int
+	// share is the fraction of packets dropped; 0 drops them all
+	share float64
+}
+
+// command returns the firewall command for the rule's address family
+func (r networkRule) command() string {
+	if r.ip.To4() == nil {
+		return "ip6tables"
+	}
+	return "iptables"
+}
+
+// args returns the arguments that insert (-I) or delete (-D) the rule
+func (r networkRule) args(action string) []string {
+	args := []string{
+		action, "OUTPUT",
+		"-d", r.ip.String(),
+		"-p", "tcp", "--dport", strconv.Itoa(r.port),
+	}
+	if r.share > 0 && r.share < 1 {
+		args = append(args, "-m", "statistic", "--mode", "random", "--probability", strconv.FormatFloat(r.share, 'f', 2, 64))
+	}
+	return append(args,
+		"-m", "comment", "--comment", networkRuleComment+":"+r.taskID,
+		"-j", "DROP",
+	)
+}
+
+// describe returns the command line that applies action to the rule
+func (r networkRule) describe(action string) string {
+	return r.command() + " " + strings.Join(r.args(action), " ")
+}
+
+// networkDisruptionDuration returns how long a network disruption asked
+// to hold for requested actually holds
+func networkDisruptionDuration(requested time.Duration) time.Duration {
+	if requested <= 0 {
+		return defaultNetworkDisruptionDuration
+	}
+	return requested
+}
+
+// networkRules validates a host:port target and returns the rules that cut
+// it off, one for each address the host resolves to, dropping intensity
+// percent of its packets (0 for all of them). Loopback addresses and the
+// server's own management address are refused so the server can't cut
+// itself off.
+func (e *DestructionEngine) networkRules(ctx context.Context, taskID, target string, intensity int32) ([]networkRule, error) {
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil || host == "" {
+		return nil, fmt.Errorf("invalid network target %q: expected host:port", target)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid port in network target %q", target)
+	}
+	if strings.EqualFold(host, "localhost") {
+		return nil, fmt.Errorf("network target %s is a loopback address", target)
+	}
+
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := lookupIPAddrs(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("%s resolves to no addresses", host)
+	}
+
+	management, err := e.managementAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]networkRule, 0, len(ips))
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsUnspecified() {
+			return nil, fmt.Errorf("network target %s is a loopback address", target)
+		}
+		for _, own := range management {
+			if own.Equal(ip) {
+				return nil, fmt.Errorf("network target %s is the server's management address %s", target, ip)
+			}
+		}
+		rules = append(rules, networkRule{taskID: taskID, ip: ip, port: port, share: float64(intensity) / 100})
+	}
+	return rules, nil
+}
+
+// managementAddresses returns the addresses clients reach the server on:
+// server.host when it names one, or every address of the host when the
+// server listens on all of them
+func (e *DestructionEngine) managementAddresses() ([]net.IP, error) {
+	if ip := net.ParseIP(e.config.Server.Host); ip != nil && !ip.IsUnspecified() {
+		return []net.IP{ip}, nil
+	}
+
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the server's addresses: %w", err)
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			ips = append(ips, ipNet.IP)
+		}
+	}
+	return ips, nil
+}
+
+// planNetworkDisruption reports the rules network disruption would add for
+// target
+func (e *DestructionEngine) planNetworkDisruption(target string, duration time.Duration, intensity int32) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  target,
+		Metrics: &pb.DestructionMetrics{},
+	}
+
+	rules, err := e.networkRules(context.Background(), "dry-run", target, intensity)
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	result.Success = true
+	result.Action = fmt.Sprintf("would drop TCP traffic to %s for %s: %s",
+		target, networkDisruptionDuration(duration), describeRules(rules, "-I"))
+	return result
+}
+
+// describeRules joins the command lines that apply action to rules
+func describeRules(rules []networkRule, action string) string {
+	lines := make([]string, len(rules))
+	for i, rule := range rules {
+		lines[i] = rule.describe(action)
+	}
+	return strings.Join(lines, "; ")
+}
@@ -0,0 +1,126 @@
+//go:build linux
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// executeNetworkDisruption drops outgoing TCP traffic to each host:port
+// target with iptables for the task's duration, then removes every rule it
+// added, also when the task is cancelled. In safe mode nothing is added
+// and the results list the rules that would have been.
+func (e *DestructionEngine) executeNetworkDisruption(task *DestructionTask) ([]*pb.DestructionResult, error) {
+	duration := networkDisruptionDuration(task.Duration)
+
+	var (
+		results []*pb.DestructionResult
+		// applied holds every rule added so far and owners the result
+		// of the target it was added for
+		applied []networkRule
+		owners  []*pb.DestructionResult
+		held    []*pb.DestructionResult
+	)
+
+	// finish removes the added rules and records the held results, which
+	// only succeed once their rules are gone again
+	finish := func() {
+		e.removeNetworkRules(task, applied, owners)
+		for _, result := range held {
+			e.targetProcessed(task, result)
+		}
+	}
+
+	for i, target := range task.Targets {
+		if err := task.Context.Err(); err != nil {
+			finish()
+			return results, fmt.Errorf("network disruption cancelled: %w", err)
+		}
+		task.ReportProgress(float64(i)/float64(len(task.Targets)), target, fmt.Sprintf("Cutting off %s", target))
+
+		result := &pb.DestructionResult{
+			Target:    target,
+			Metrics:   &pb.DestructionMetrics{},
+			StartedAt: timestamppb.Now(),
+		}
+		results = append(results, result)
+
+		rules, err := e.networkRules(task.Context, task.ID, target, task.Intensity)
+		if err != nil {
+			result.ErrorMessage = err.Error()
+			e.targetProcessed(task, result)
+			continue
+		}
+
+		if e.config.Security.EnableSafeMode {
+			result.Success = true
+			result.Action = fmt.Sprintf("would drop TCP traffic to %s for %s (safe mode is enabled; no rules were added): %s",
+				target, duration, describeRules(rules, "-I"))
+			e.targetProcessed(task, result)
+			continue
+		}
+
+		result.Success = true
+		for _, rule := range rules {
+			if err := e.runCommand(task.Context, result, rule.command(), rule.args("-I")...); err != nil {
+				result.Success = false
+				result.ErrorMessage = err.Error()
+				break
+			}
+			applied = append(applied, rule)
+			owners = append(owners, result)
+		}
+		if result.Success {
+			result.Action = fmt.Sprintf("dropped TCP traffic to %s for %s: %s", target, duration, describeRules(rules, "-I"))
+		}
+		held = append(held, result)
+	}
+
+	if len(applied) == 0 {
+		finish()
+		return results, nil
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id":  task.ID,
+		"rules":    len(applied),
+		"duration": duration,
+	}).Warn("🔥 Network disruption rules added")
+	task.ReportProgress(1.0, "", fmt.Sprintf("Holding %d firewall rules for %s", len(applied), duration))
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		finish()
+		return results, nil
+	case <-task.Context.Done():
+		finish()
+		return results, fmt.Errorf("network disruption cancelled: %w", task.Context.Err())
+	}
+}
+
+// removeNetworkRules deletes rules in reverse order. A rule that can't be
+// removed fails the result it was added for, which then names the command
+// that reverts it by hand.
+func (e *DestructionEngine) removeNetworkRules(task *DestructionTask, rules []networkRule, owners []*pb.DestructionResult) {
+	for i := len(rules) - 1; i >= 0; i-- {
+		rule, result := rules[i], owners[i]
+		// The task context may already be done; the rules must go anyway
+		if err := e.runCommand(context.Background(), result, rule.command(), rule.args("-D")...); err != nil {
+			result.Success = false
+			result.ErrorMessage = fmt.Sprintf("failed to remove firewall rule, revert with: %s: %v", rule.describe("-D"), err)
+			e.logger.WithError(err).WithFields(logrus.Fields{
+				"task_id": task.ID,
+				"rule":    rule.describe("-D"),
+			}).Error("Failed to remove network disruption rule")
+		}
+	}
+}
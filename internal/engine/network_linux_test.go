@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// iptablesRunner records every firewall command it is asked to run
+type iptablesRunner struct {
+	mu       sync.Mutex
+	commands []string
+}
+
+func (r *iptablesRunner) Run(ctx context.Context, name string, args ...string) ([]byte, []byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commands = append(r.commands, name+" "+strings.Join(args, " "))
+	return nil, nil, nil
+}
+
+func (r *iptablesRunner) count(action string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, command := range r.commands {
+		if strings.Contains(command, " "+action+" OUTPUT ") {
+			n++
+		}
+	}
+	return n
+}
+
+func newNetworkEngine(t *testing.T, safeMode bool) (*DestructionEngine, *iptablesRunner) {
+	fakeNetwork(t, nil, "198.51.100.5")
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW", EnableSafeMode: safeMode},
+	})
+	runner := &iptablesRunner{}
+	engine.runner = runner
+	fakeHost(engine, true, "iptables")
+	return engine, runner
+}
+
+func executeNetworkDisruption(t *testing.T, ctx context.Context, engine *DestructionEngine, duration time.Duration, targets ...string) *pb.ExecuteDestructionResponse {
+	resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		Duration:           durationpb.New(duration),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	return resp
+}
+
+func TestExecuteNetworkDisruption(t *testing.T) {
+	engine, runner := newNetworkEngine(t, false)
+
+	resp := executeNetworkDisruption(t, context.Background(), engine, 10*time.Millisecond,
+		"192.0.2.10:80", "127.0.0.1:22", "198.51.100.5:8080")
+	if len(resp.Results) != 3 {
+		t.Fatalf("Expected a result for every target, got %d", len(resp.Results))
+	}
+	if !resp.Results[0].Success || !strings.Contains(resp.Results[0].Action, "dropped TCP traffic to 192.0.2.10:80") {
+		t.Errorf("Expected the first target to be cut off, got %+v", resp.Results[0])
+	}
+	for _, result := range resp.Results[1:] {
+		if result.Success {
+			t.Errorf("Expected %s to be refused", result.Target)
+		}
+	}
+
+	if runner.count("-I") != 1 || runner.count("-D") != 1 {
+		t.Errorf("Expected one rule added and removed again, got %v", runner.commands)
+	}
+	if last := runner.commands[len(runner.commands)-1]; !strings.HasPrefix(last, "iptables -D OUTPUT -d 192.0.2.10 ") {
+		t.Errorf("Expected the rule to be removed last, got %q", last)
+	}
+}
+
+func TestNetworkDisruptionSafeMode(t *testing.T) {
+	engine, runner := newNetworkEngine(t, true)
+
+	resp := executeNetworkDisruption(t, context.Background(), engine, time.Minute, "192.0.2.10:80")
+	if !resp.Success || !strings.Contains(resp.Results[0].Action, "iptables -I OUTPUT -d 192.0.2.10") {
+		t.Errorf("Expected safe mode to describe the rule, got %+v", resp.Results[0])
+	}
+	if len(runner.commands) != 0 {
+		t.Errorf("Expected safe mode to run nothing, got %v", runner.commands)
+	}
+}
+
+func TestNetworkDisruptionCancelled(t *testing.T) {
+	engine, runner := newNetworkEngine(t, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	resp := executeNetworkDisruption(t, ctx, engine, time.Minute, "192.0.2.10:80", "192.0.2.11:443")
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancellation to end the hold promptly, took %s", elapsed)
+	}
+	if resp.Success {
+		t.Error("Expected a cancelled disruption to report failure")
+	}
+	if runner.count("-I") != 2 || runner.count("-D") != 2 {
+		t.Errorf("Expected every rule to be removed on cancellation, got %v", runner.commands)
+	}
+}
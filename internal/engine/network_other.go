@@ -0,0 +1,15 @@
+//go:build !linux
+
+package engine
+
+import (
+	"fmt"
+	"runtime"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// executeNetworkDisruption needs iptables, which only Linux has
+func (e *DestructionEngine) executeNetworkDisruption(task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return nil, fmt.Errorf("network disruption is only supported on Linux, not %s", runtime.GOOS)
+}
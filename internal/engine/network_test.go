@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// fakeNetwork replaces name resolution and the host's addresses for the
+// duration of a test
+func fakeNetwork(t *testing.T, hosts map[string][]string, own ...string) {
+	originalLookup, originalAddrs := lookupIPAddrs, interfaceAddrs
+	t.Cleanup(func() {
+		lookupIPAddrs, interfaceAddrs = originalLookup, originalAddrs
+	})
+
+	lookupIPAddrs = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		var addrs []net.IPAddr
+		for _, ip := range hosts[host] {
+			addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+		}
+		if len(addrs) == 0 {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return addrs, nil
+	}
+	interfaceAddrs = func() ([]net.Addr, error) {
+		var addrs []net.Addr
+		for _, ip := range own {
+			addrs = append(addrs, &net.IPNet{IP: net.ParseIP(ip), Mask: net.CIDRMask(24, 32)})
+		}
+		return addrs, nil
+	}
+}
+
+func TestNetworkRules(t *testing.T) {
+	fakeNetwork(t, map[string][]string{
+		"db.example":    {"192.0.2.10", "2001:db8::10"},
+		"local.example": {"127.0.1.1"},
+		"self.example":  {"198.51.100.5"},
+	}, "198.51.100.5")
+
+	engine := NewDestructionEngine(&config.Config{})
+
+	rules, err := engine.networkRules(context.Background(), "task_1", "db.example:5432", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("Expected a rule for each resolved address, got %d", len(rules))
+	}
+	expected := "iptables -I OUTPUT -d 192.0.2.10 -p tcp --dport 5432 -m comment --comment burndevice:task_1 -j DROP"
+	if got := rules[0].describe("-I"); got != expected {
+		t.Errorf("Expected rule %q, got %q", expected, got)
+	}
+	if rules[1].command() != "ip6tables" {
+		t.Errorf("Expected IPv6 addresses to use ip6tables, got %s", rules[1].command())
+	}
+
+	// An intensity drops a random share of packets
+	rules, err = engine.networkRules(context.Background(), "task_1", "192.0.2.10:5432", 25)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	expected = "iptables -I OUTPUT -d 192.0.2.10 -p tcp --dport 5432 -m statistic --mode random --probability 0.25 -m comment --comment burndevice:task_1 -j DROP"
+	if got := rules[0].describe("-I"); got != expected {
+		t.Errorf("Expected rule %q, got %q", expected, got)
+	}
+
+	tests := []struct {
+		target string
+		errMsg string
+	}{
+		{"192.0.2.10", "expected host:port"},
+		{":80", "expected host:port"},
+		{"192.0.2.10:0", "invalid port"},
+		{"192.0.2.10:http", "invalid port"},
+		{"localhost:80", "loopback"},
+		{"127.0.0.1:22", "loopback"},
+		{"[::1]:22", "loopback"},
+		{"0.0.0.0:22", "loopback"},
+		{"local.example:80", "loopback"},
+		{"self.example:8080", "management address"},
+		{"198.51.100.5:22", "management address"},
+		{"missing.example:80", "failed to resolve"},
+	}
+	for _, tt := range tests {
+		_, err := engine.networkRules(context.Background(), "task_1", tt.target, 0)
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("Expected %s to be rejected with %q, got: %v", tt.target, tt.errMsg, err)
+		}
+	}
+
+	// A server bound to one address only protects that one
+	engine.config.Server.Host = "192.0.2.1"
+	if _, err := engine.networkRules(context.Background(), "task_1", "198.51.100.5:22", 0); err != nil {
+		t.Errorf("Expected other host addresses to be allowed, got: %v", err)
+	}
+	if _, err := engine.networkRules(context.Background(), "task_1", "192.0.2.1:22", 0); err == nil {
+		t.Error("Expected the bound management address to be rejected")
+	}
+}
@@ -0,0 +1,145 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+const (
+	// defaultLargestFiles is how many of a directory's largest files a plan
+	// lists when the request doesn't say
+	defaultLargestFiles = 5
+	// maxLargestFiles caps how many largest files a plan lists per target
+	maxLargestFiles = 100
+)
+
+// PlanDestruction reports how many files and bytes destroying req's targets
+// would touch, and whether each passes the blocked and allowed lists. Glob
+// targets are expanded and directories walked; refused targets are not
+// scanned. Nothing is modified.
+func (e *DestructionEngine) PlanDestruction(ctx context.Context, req *pb.PlanDestructionRequest) (*pb.PlanDestructionResponse, error) {
+	largest := defaultLargestFiles
+	if req.LargestFiles > 0 {
+		largest = int(req.LargestFiles)
+	}
+	if largest > maxLargestFiles {
+		largest = maxLargestFiles
+	}
+
+	response := &pb.PlanDestructionResponse{}
+	add := func(plan *pb.TargetPlan) {
+		response.Targets = append(response.Targets, plan)
+		if !plan.Allowed || plan.ErrorMessage != "" {
+			response.RefusedTargets++
+			return
+		}
+		response.TotalFiles += plan.Files
+		response.TotalBytes += plan.Bytes
+	}
+
+	for _, target := range req.Targets {
+		paths := []string{target}
+		if isGlob(target) {
+			matches, err := expandGlob(target)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				add(&pb.TargetPlan{
+					Target:       target,
+					ErrorMessage: fmt.Sprintf("%v: %s", ErrNoMatches, target),
+				})
+				continue
+			}
+			paths = matches
+		}
+
+		for _, path := range paths {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			add(e.planTarget(ctx, path, largest))
+		}
+	}
+
+	return response, nil
+}
+
+// planTarget scans a single path, keeping its largest regular files when
+// it is a directory
+func (e *DestructionEngine) planTarget(ctx context.Context, target string, largest int) *pb.TargetPlan {
+	plan := &pb.TargetPlan{Target: target}
+
+	if message := e.targetPolicyError(target); message != "" {
+		plan.ErrorMessage = message
+		return plan
+	}
+	plan.Allowed = true
+
+	info, err := os.Lstat(target)
+	if err != nil {
+		plan.ErrorMessage = fmt.Sprintf("failed to stat file: %v", err)
+		return plan
+	}
+
+	if !info.IsDir() {
+		plan.Files = 1
+		if info.Mode().IsRegular() {
+			plan.Bytes = info.Size()
+		}
+		return plan
+	}
+	plan.IsDir = true
+
+	// Symlinks are counted, never followed
+	err = filepath.WalkDir(target, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		entryInfo, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		plan.Files++
+		if entryInfo.Mode().IsRegular() {
+			plan.Bytes += entryInfo.Size()
+			plan.LargestFiles = keepLargest(plan.LargestFiles, &pb.FileSize{Path: path, Size: entryInfo.Size()}, largest)
+		}
+		return nil
+	})
+	if err != nil {
+		plan.ErrorMessage = fmt.Sprintf("failed to scan directory: %v", err)
+	}
+
+	return plan
+}
+
+// keepLargest adds file to files, which are sorted largest first, and drops
+// whatever falls beyond the first n
+func keepLargest(files []*pb.FileSize, file *pb.FileSize, n int) []*pb.FileSize {
+	i := sort.Search(len(files), func(i int) bool { return files[i].Size < file.Size })
+	if i >= n {
+		return files
+	}
+
+	files = append(files, nil)
+	copy(files[i+1:], files[i:])
+	files[i] = file
+	if len(files) > n {
+		files = files[:n]
+	}
+	return files
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+)
+
+func TestPlanDestruction(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_plan_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	allowedDir := filepath.Join(tempDir, "allowed")
+	blockedDir := filepath.Join(tempDir, "blocked")
+	for _, dir := range []string{filepath.Join(allowedDir, "nested"), blockedDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(allowedDir, "a.log"):           "aaaa",
+		filepath.Join(allowedDir, "b.log"):           "bb",
+		filepath.Join(allowedDir, "nested", "c.txt"): "cccccc",
+		filepath.Join(blockedDir, "d.log"):           "dddd",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			AllowedTargets: []string{allowedDir, blockedDir},
+			BlockedTargets: []string{blockedDir},
+		},
+	})
+
+	resp, err := engine.PlanDestruction(context.Background(), &pb.PlanDestructionRequest{
+		Targets:      []string{allowedDir, blockedDir, filepath.Join(allowedDir, "*.log"), filepath.Join(tempDir, "*.none")},
+		LargestFiles: 2,
+	})
+	if err != nil {
+		t.Fatalf("PlanDestruction failed: %v", err)
+	}
+
+	if len(resp.Targets) != 5 {
+		t.Fatalf("Expected 5 target plans, got %d", len(resp.Targets))
+	}
+
+	dir := resp.Targets[0]
+	if !dir.Allowed || !dir.IsDir || dir.Files != 3 || dir.Bytes != 12 {
+		t.Errorf("Expected allowed dir with 3 files and 12 bytes, got %+v", dir)
+	}
+	if len(dir.LargestFiles) != 2 ||
+		dir.LargestFiles[0].Path != filepath.Join(allowedDir, "nested", "c.txt") ||
+		dir.LargestFiles[1].Path != filepath.Join(allowedDir, "a.log") {
+		t.Errorf("Expected c.txt then a.log as the largest files, got %v", dir.LargestFiles)
+	}
+
+	blocked := resp.Targets[1]
+	if blocked.Allowed || blocked.Files != 0 || !strings.Contains(blocked.ErrorMessage, "blocked") {
+		t.Errorf("Expected blocked dir to be refused without scanning, got %+v", blocked)
+	}
+
+	// The glob expands to its matches, each planned on its own
+	if resp.Targets[2].Target != filepath.Join(allowedDir, "a.log") || resp.Targets[2].Files != 1 || resp.Targets[2].Bytes != 4 {
+		t.Errorf("Expected a.log with 4 bytes, got %+v", resp.Targets[2])
+	}
+	if len(resp.Targets[2].LargestFiles) != 0 {
+		t.Errorf("Expected no largest files for a file target, got %v", resp.Targets[2].LargestFiles)
+	}
+
+	if !strings.Contains(resp.Targets[4].ErrorMessage, ErrNoMatches.Error()) {
+		t.Errorf("Expected no-match error for unmatched glob, got %+v", resp.Targets[4])
+	}
+
+	if resp.TotalFiles != 5 || resp.TotalBytes != 18 {
+		t.Errorf("Expected 5 files and 18 bytes in total, got %d files and %d bytes", resp.TotalFiles, resp.TotalBytes)
+	}
+	if resp.RefusedTargets != 2 {
+		t.Errorf("Expected 2 refused targets, got %d", resp.RefusedTargets)
+	}
+
+	// Nothing was touched
+	for path := range files {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to remain: %v", path, err)
+		}
+	}
+
+	_, err = engine.PlanDestruction(context.Background(), &pb.PlanDestructionRequest{Targets: []string{"["}})
+	if !errors.Is(err, policy.ErrInvalidPattern) {
+		t.Errorf("Expected ErrInvalidPattern, got: %v", err)
+	}
+}
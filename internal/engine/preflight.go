@@ -0,0 +1,203 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// PreflightDestructor is implemented by destructors that can tell before
+// running whether this host lets them. Preflight returns an actionable
+// error for the first thing missing; without targets only the host itself
+// is checked.
+type PreflightDestructor interface {
+	Destructor
+	Preflight(e *DestructionEngine, targets []string) error
+}
+
+// PreflightCheck verifies the host can carry out destruction type t
+// against targets before anything is touched
+func (e *DestructionEngine) PreflightCheck(t pb.DestructionType, targets []string) error {
+	d, err := destructorFor(t)
+	if err != nil {
+		return err
+	}
+
+	checker, ok := d.(PreflightDestructor)
+	if !ok {
+		return nil
+	}
+	if err := checker.Preflight(e, targets); err != nil {
+		return fmt.Errorf("preflight check failed: %w", err)
+	}
+	return nil
+}
+
+// preflightTargets returns the targets a preflight check should look at:
+// none when the request skips target checks, so only the host is checked
+func preflightTargets(targets []string, skip bool) []string {
+	if skip {
+		return nil
+	}
+	return targets
+}
+
+// CheckCapabilities reports, for every destruction type, whether the
+// server implements it, allows it and has what it needs on this host
+func (e *DestructionEngine) CheckCapabilities() *pb.CheckCapabilitiesResponse {
+	resp := &pb.CheckCapabilitiesResponse{
+		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+		Privileged: e.privileged(),
+	}
+
+	for number := range pb.DestructionType_name {
+		t := pb.DestructionType(number)
+		if t == pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED {
+			continue
+		}
+		capability := &pb.Capability{
+			Type:    t,
+			Enabled: e.policy.TypeEnabled(t),
+		}
+
+		err := e.PreflightCheck(t, nil)
+		switch {
+		case errors.Is(err, ErrNotImplemented):
+			capability.Reason = "not implemented"
+		case err != nil:
+			capability.Implemented = true
+			capability.Reason = strings.TrimPrefix(err.Error(), "preflight check failed: ")
+		default:
+			capability.Implemented = true
+			capability.Available = true
+		}
+		resp.Capabilities = append(resp.Capabilities, capability)
+	}
+
+	sort.Slice(resp.Capabilities, func(i, j int) bool {
+		return resp.Capabilities[i].Type < resp.Capabilities[j].Type
+	})
+	return resp
+}
+
+// requireBinary fails when name can't be found in PATH
+func (e *DestructionEngine) requireBinary(name string) error {
+	if _, err := e.lookPath(name); err != nil {
+		return fmt.Errorf("%s binary not found in PATH", name)
+	}
+	return nil
+}
+
+// requirePrivilege fails unless the server runs as root, naming what it
+// then can't do
+func (e *DestructionEngine) requirePrivilege(action string) error {
+	if !e.privileged() {
+		return fmt.Errorf("not running as root, cannot %s", action)
+	}
+	return nil
+}
+
+// checkFileTargets stats every literal file deletion target and returns
+// one error listing each that is missing or whose directory doesn't allow
+// removing it. Patterns are left to expansion, which reports their own
+// failures.
+func checkFileTargets(targets []string) error {
+	var problems []string
+	for _, target := range targets {
+		if isGlob(target) {
+			continue
+		}
+
+		if _, err := os.Lstat(target); err != nil {
+			if os.IsNotExist(err) {
+				problems = append(problems, fmt.Sprintf("%s (does not exist)", target))
+			} else {
+				problems = append(problems, fmt.Sprintf("%s (%v)", target, err))
+			}
+			continue
+		}
+
+		dir := filepath.Dir(filepath.Clean(target))
+		if err := checkWritableDir(dir); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (directory %s is not writable)", target, dir))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d targets can't be deleted: %s", len(problems), strings.Join(problems, "; "))
+}
+
+// requireWritableDir fails unless dir is a directory a file can be
+// created in. The probe file is removed again straight away.
+func requireWritableDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("target directory %s is not accessible: %v", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("target %s is not a directory", dir)
+	}
+
+	probe, err := os.CreateTemp(dir, ".burndevice_preflight_*")
+	if err != nil {
+		return fmt.Errorf("no write access to %s", dir)
+	}
+	if err := probe.Close(); err != nil {
+		return fmt.Errorf("no write access to %s: %v", dir, err)
+	}
+	if err := os.Remove(probe.Name()); err != nil {
+		return fmt.Errorf("failed to remove preflight probe %s: %v", probe.Name(), err)
+	}
+	return nil
+}
+
+// Preflight checks every literal target exists and can be removed from
+// its directory
+func (fileDeletionDestructor) Preflight(e *DestructionEngine, targets []string) error {
+	return checkFileTargets(targets)
+}
+
+// Preflight checks systemctl is available and, outside safe mode, that
+// units may be stopped
+func (serviceTerminationDestructor) Preflight(e *DestructionEngine, targets []string) error {
+	if err := e.requireBinary("systemctl"); err != nil {
+		return err
+	}
+	if e.config.Security.EnableSafeMode {
+		return nil
+	}
+	return e.requirePrivilege("stop systemd units")
+}
+
+// Preflight checks for Linux and, outside safe mode, for iptables and the
+// privileges to change firewall rules
+func (networkDisruptionDestructor) Preflight(e *DestructionEngine, targets []string) error {
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("network disruption is only supported on Linux, not %s", runtime.GOOS)
+	}
+	if e.config.Security.EnableSafeMode {
+		return nil
+	}
+	if err := e.requireBinary("iptables"); err != nil {
+		return err
+	}
+	return e.requirePrivilege("change iptables rules")
+}
+
+// Preflight checks every target directory can be written to
+func (diskFillDestructor) Preflight(e *DestructionEngine, targets []string) error {
+	for _, target := range targets {
+		if err := requireWritableDir(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
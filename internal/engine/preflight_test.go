@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// fakeHost makes the engine see only the given binaries in PATH and run
+// with or without root
+func fakeHost(engine *DestructionEngine, privileged bool, binaries ...string) {
+	engine.lookPath = func(file string) (string, error) {
+		for _, binary := range binaries {
+			if binary == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", fmt.Errorf("executable file not found in $PATH")
+	}
+	engine.privileged = func() bool { return privileged }
+}
+
+func TestPreflightCheck(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+
+	fakeHost(engine, false)
+	err := engine.PreflightCheck(pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, []string{"nginx"})
+	if err == nil || !strings.Contains(err.Error(), "systemctl binary not found in PATH") {
+		t.Errorf("Expected a missing systemctl to be reported, got: %v", err)
+	}
+
+	fakeHost(engine, false, "systemctl")
+	err = engine.PreflightCheck(pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, []string{"nginx"})
+	if err == nil || !strings.Contains(err.Error(), "not running as root, cannot stop systemd units") {
+		t.Errorf("Expected missing privileges to be reported, got: %v", err)
+	}
+
+	// Safe mode stops nothing, so it needs no privileges
+	engine.config.Security.EnableSafeMode = true
+	if err := engine.PreflightCheck(pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, []string{"nginx"}); err != nil {
+		t.Errorf("Expected safe mode to pass without root, got: %v", err)
+	}
+	engine.config.Security.EnableSafeMode = false
+
+	// Types with nothing to check always pass
+	if err := engine.PreflightCheck(pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, nil); err != nil {
+		t.Errorf("Expected CPU burn to need nothing, got: %v", err)
+	}
+
+	// The failure stops execution before anything runs
+	_, err = engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
+		Targets:            []string{"nginx"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "preflight check failed") {
+		t.Errorf("Expected execution to fail the preflight check, got: %v", err)
+	}
+	if history, _ := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{}); history.Total != 0 {
+		t.Errorf("Expected no task to run, got %d", history.Total)
+	}
+}
+
+func TestDiskFillPreflight(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_preflight_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	file := filepath.Join(tempDir, "file.txt")
+	if err := os.WriteFile(file, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	if err := engine.PreflightCheck(pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL, []string{tempDir}); err != nil {
+		t.Errorf("Expected a writable directory to pass, got: %v", err)
+	}
+	entries, err := os.ReadDir(tempDir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected the probe file to be removed, got %v, %v", entries, err)
+	}
+
+	for _, target := range []string{filepath.Join(tempDir, "missing"), file} {
+		if err := engine.PreflightCheck(pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL, []string{target}); err == nil {
+			t.Errorf("Expected %s to fail the preflight check", target)
+		}
+	}
+}
+
+func TestCheckFileTargets(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_preflight_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	file := filepath.Join(tempDir, "file.txt")
+	if err := os.WriteFile(file, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	missing := []string{filepath.Join(tempDir, "first.txt"), filepath.Join(tempDir, "second.txt")}
+
+	if err := checkFileTargets([]string{file, filepath.Join(tempDir, "*.log")}); err != nil {
+		t.Errorf("Expected an existing file and a glob to pass, got: %v", err)
+	}
+	err = checkFileTargets(append([]string{file}, missing...))
+	if err == nil || !strings.Contains(err.Error(), "2 targets can't be deleted") {
+		t.Fatalf("Expected both missing targets to be reported, got: %v", err)
+	}
+	for _, target := range missing {
+		if !strings.Contains(err.Error(), target+" (does not exist)") {
+			t.Errorf("Expected %s to be named, got: %v", target, err)
+		}
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            append([]string{file}, missing...),
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+	if _, err := engine.ExecuteDestruction(context.Background(), req); err == nil {
+		t.Fatal("Expected missing targets to be rejected before running")
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Errorf("Expected nothing to be deleted when the check fails: %v", err)
+	}
+
+	// Skipping the check lets the run reach the missing targets
+	req.SkipPreflight = true
+	resp, err := engine.ExecuteDestruction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success || !resp.Results[0].Success || resp.Results[1].Success {
+		t.Errorf("Expected only the existing target to be deleted, got %+v", resp.Results)
+	}
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{EnabledTypes: []string{"FILE_DELETION", "SERVICE_TERMINATION"}},
+	})
+	fakeHost(engine, true)
+
+	resp := engine.CheckCapabilities()
+	if resp.Platform != runtime.GOOS+"/"+runtime.GOARCH || !resp.Privileged {
+		t.Errorf("Expected the platform and privileges to be reported, got %s, %v", resp.Platform, resp.Privileged)
+	}
+	if len(resp.Capabilities) != len(pb.DestructionType_name)-1 {
+		t.Fatalf("Expected every destruction type, got %d", len(resp.Capabilities))
+	}
+
+	capabilities := make(map[pb.DestructionType]*pb.Capability)
+	for i, capability := range resp.Capabilities {
+		if i > 0 && capability.Type < resp.Capabilities[i-1].Type {
+			t.Errorf("Expected capabilities in type order, got %s after %s", capability.Type, resp.Capabilities[i-1].Type)
+		}
+		capabilities[capability.Type] = capability
+	}
+
+	if c := capabilities[pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION]; !c.Implemented || !c.Enabled || !c.Available {
+		t.Errorf("Expected file deletion to be fully available, got %+v", c)
+	}
+	if c := capabilities[pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION]; c.Available || !strings.Contains(c.Reason, "systemctl") {
+		t.Errorf("Expected service termination to lack systemctl, got %+v", c)
+	}
+	if c := capabilities[pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN]; c.Enabled {
+		t.Errorf("Expected CPU burn to be disabled by enabled_types, got %+v", c)
+	}
+	if c := capabilities[pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION]; c.Implemented || c.Reason != "not implemented" {
+		t.Errorf("Expected registry corruption to be unimplemented, got %+v", c)
+	}
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// renameFile moves a path within a filesystem; tests replace it to
+// simulate moves across filesystems
+var renameFile = os.Rename
+
+// quarantinePathFor returns where target is moved when it is quarantined,
+// mirroring its absolute path beneath the quarantine directory. It is
+// empty when no quarantine directory is configured.
+func (e *DestructionEngine) quarantinePathFor(target string) string {
+	if e.config.Security.QuarantineDir == "" {
+		return ""
+	}
+	return mirrorPath(e.config.Security.QuarantineDir, target)
+}
+
+// inQuarantineDir reports whether path lies inside the configured
+// quarantine directory
+func (e *DestructionEngine) inQuarantineDir(path string) bool {
+	return withinDir(e.config.Security.QuarantineDir, path)
+}
+
+// quarantining reports whether a file deletion that asked for quarantine
+// or not moves its targets aside; security.quarantine turns it on for all
+func (e *DestructionEngine) quarantining(requested bool) bool {
+	return requested || e.config.Security.Quarantine
+}
+
+// recordQuarantine registers that a task moved target into quarantine
+func (e *DestructionEngine) recordQuarantine(taskID, target string, bytes int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.backups[target] = &backupRecord{
+		TaskID:      taskID,
+		Target:      target,
+		BackupPath:  e.quarantinePathFor(target),
+		Bytes:       bytes,
+		Quarantined: true,
+	}
+}
+
+// quarantineDeletion moves target into the quarantine directory instead of
+// deleting it. Anything quarantined earlier from the same path is
+// replaced. Files and bytes moved count as deleted.
+func (e *DestructionEngine) quarantineDeletion(ctx context.Context, target string, metrics *pb.DestructionMetrics, onFile fileDeletedFunc) error {
+	if _, err := os.Lstat(target); err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	quarantinePath := e.quarantinePathFor(target)
+	if quarantinePath == "" {
+		return fmt.Errorf("quarantine requires security.quarantine_dir")
+	}
+	if err := os.RemoveAll(quarantinePath); err != nil {
+		return fmt.Errorf("failed to replace earlier quarantine: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(quarantinePath), 0750); err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+
+	if err := e.moveTree(ctx, target, quarantinePath, metrics, onFile); err != nil {
+		return fmt.Errorf("failed to quarantine: %w", err)
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"target":     target,
+		"quarantine": quarantinePath,
+		"files":      metrics.FilesDeleted,
+	}).Info("Target quarantined")
+
+	return nil
+}
+
+// restoreQuarantined moves a quarantined target back to where it came
+// from and returns the bytes restored
+func (e *DestructionEngine) restoreQuarantined(quarantinePath, target string) (int64, error) {
+	if err := os.RemoveAll(target); err != nil {
+		return 0, fmt.Errorf("failed to replace existing target: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return 0, fmt.Errorf("failed to create parent directory: %w", err)
+	}
+
+	metrics := &pb.DestructionMetrics{}
+	err := e.moveTree(context.Background(), quarantinePath, target, metrics, nil)
+	return metrics.BytesDestroyed, err
+}
+
+// moveTree moves src, a file or a directory tree, to dst, counting every
+// file in metrics. A rename moves it in one step; when that fails, as it
+// does across filesystems, each entry is copied and then removed.
+// Symlinks are moved as links and never followed.
+func (e *DestructionEngine) moveTree(ctx context.Context, src, dst string, metrics *pb.DestructionMetrics, onFile fileDeletedFunc) error {
+	var files, bytes int64
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files++
+		if info.Mode().IsRegular() {
+			bytes += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	renameErr := renameFile(src, dst)
+	if renameErr == nil {
+		metrics.FilesDeleted += files
+		metrics.BytesDestroyed += bytes
+		if onFile != nil && files > 0 {
+			onFile(src, metrics.FilesDeleted)
+		}
+		return nil
+	}
+	e.logger.WithError(renameErr).WithField("path", src).Debug("Rename failed, copying instead")
+
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(dst, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0750)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && info.Mode()&fs.ModeSymlink == 0 {
+			e.logger.WithField("path", path).Warn("Skipping non-regular file while moving")
+			return nil
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			link, err := os.Readlink(path)
+			if err != nil {
+				return fmt.Errorf("failed to read symlink: %w", err)
+			}
+			if err := os.Symlink(link, dest); err != nil {
+				return fmt.Errorf("failed to recreate symlink: %w", err)
+			}
+		} else if err := e.copyFile(path, dest); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", path, err)
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+
+		metrics.FilesDeleted++
+		if info.Mode().IsRegular() {
+			metrics.BytesDestroyed += info.Size()
+		}
+		if onFile != nil {
+			onFile(path, metrics.FilesDeleted)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("move interrupted after %d files: %w", metrics.FilesDeleted, err)
+	}
+
+	// Only empty directories (and skipped special files) remain
+	return os.RemoveAll(src)
+}
@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// newQuarantineTree creates a target directory holding two files, returning
+// the target, its files and the quarantine directory
+func newQuarantineTree(t *testing.T) (target string, files map[string]string, quarantineDir string) {
+	tempDir := newTestTree(t, map[string]string{
+		"data/a.txt":        "first file",
+		"data/nested/b.txt": "second",
+	})
+
+	target = filepath.Join(tempDir, "data")
+	files = map[string]string{
+		filepath.Join(target, "a.txt"):        "first file",
+		filepath.Join(target, "nested/b.txt"): "second",
+	}
+	return target, files, filepath.Join(tempDir, "quarantine")
+}
+
+func TestQuarantineAndRestore(t *testing.T) {
+	for _, crossDevice := range []bool{false, true} {
+		name := "rename"
+		if crossDevice {
+			name = "across filesystems"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			target, files, quarantineDir := newQuarantineTree(t)
+
+			renamed := 0
+			if crossDevice {
+				renameFile = func(oldpath, newpath string) error {
+					renamed++
+					return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+				}
+				defer func() { renameFile = os.Rename }()
+			}
+
+			engine := NewDestructionEngine(&config.Config{
+				Security: config.SecurityConfig{
+					MaxSeverity:   "HIGH",
+					QuarantineDir: quarantineDir,
+				},
+			})
+			ctx := context.Background()
+
+			resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+				Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+				Targets:            []string{target},
+				Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+				ConfirmDestruction: true,
+				Quarantine:         true,
+			})
+			if err != nil {
+				t.Fatalf("Failed to execute destruction: %v", err)
+			}
+			if len(resp.Results) != 1 || !resp.Results[0].Success {
+				t.Fatalf("Expected one successful result, got: %+v", resp.Results)
+			}
+
+			result := resp.Results[0]
+			quarantinePath := mirrorPath(quarantineDir, target)
+			if result.BackupPath != quarantinePath {
+				t.Errorf("Expected the quarantine path %s, got %s", quarantinePath, result.BackupPath)
+			}
+			if result.Metrics.FilesDeleted != 2 || result.Metrics.BytesDestroyed != 16 {
+				t.Errorf("Expected 2 files and 16 bytes, got %d files and %d bytes", result.Metrics.FilesDeleted, result.Metrics.BytesDestroyed)
+			}
+			if crossDevice && renamed == 0 {
+				t.Error("Expected a rename to be attempted first")
+			}
+
+			if _, err := os.Lstat(target); !os.IsNotExist(err) {
+				t.Errorf("Expected the target to be moved away, got: %v", err)
+			}
+			for path, content := range files {
+				rel, _ := filepath.Rel(target, path)
+				got, err := os.ReadFile(filepath.Join(quarantinePath, rel))
+				if err != nil || string(got) != content {
+					t.Errorf("Expected %s in quarantine with %q, got %q (%v)", rel, content, got, err)
+				}
+			}
+			// Nothing but the moved files lands in quarantine
+			_ = filepath.WalkDir(quarantinePath, func(path string, d fs.DirEntry, err error) error {
+				if err == nil && !d.IsDir() && !strings.HasSuffix(path, ".txt") {
+					t.Errorf("Unexpected file in quarantine: %s", path)
+				}
+				return nil
+			})
+
+			restored, err := engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{TaskId: resp.TaskId})
+			if err != nil {
+				t.Fatalf("Expected no error restoring, got: %v", err)
+			}
+			if !restored.Success || restored.Results[0].BytesRestored != 16 {
+				t.Fatalf("Expected 16 bytes restored, got: %+v", restored.Results)
+			}
+			for path, content := range files {
+				got, err := os.ReadFile(path)
+				if err != nil || string(got) != content {
+					t.Errorf("Expected %s restored with %q, got %q (%v)", path, content, got, err)
+				}
+			}
+			if _, err := os.Lstat(quarantinePath); !os.IsNotExist(err) {
+				t.Errorf("Expected the quarantine to be emptied by the restore, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestRestoreQuarantineWithoutRecord(t *testing.T) {
+	target, files, quarantineDir := newQuarantineTree(t)
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:   "HIGH",
+			QuarantineDir: quarantineDir,
+			Quarantine:    true,
+		},
+	}
+
+	// security.quarantine applies without the request asking
+	_, err := NewDestructionEngine(cfg).ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to execute destruction: %v", err)
+	}
+
+	// A restarted engine has no record but still finds the quarantine
+	restarted := NewDestructionEngine(cfg)
+	resp, err := restarted.RestoreDestruction(context.Background(), &pb.RestoreDestructionRequest{Targets: []string{target}})
+	if err != nil {
+		t.Fatalf("Expected no error restoring, got: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Expected the restore to succeed, got: %+v", resp.Results)
+	}
+	for path := range files {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to be restored, got: %v", path, err)
+		}
+	}
+}
+
+func TestQuarantineRequiresDirectory(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{Security: config.SecurityConfig{MaxSeverity: "HIGH"}})
+
+	_, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/burndevice_quarantine_missing"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		Quarantine:         true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "quarantine_dir") {
+		t.Errorf("Expected quarantine without a directory to be rejected, got: %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+type clientIdentityKey struct{}
+
+// WithClientIdentity returns a context carrying the identity of the client
+// on whose behalf a destruction runs
+func WithClientIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, clientIdentityKey{}, identity)
+}
+
+// ClientIdentityFromContext returns the client identity stored in ctx
+func ClientIdentityFromContext(ctx context.Context) string {
+	if identity, ok := ctx.Value(clientIdentityKey{}).(string); ok && identity != "" {
+		return identity
+	}
+	return "anonymous"
+}
+
+// QuotaExceededError is returned when a client has used up its daily quota
+type QuotaExceededError struct {
+	Client  string
+	Reason  string
+	ResetAt time.Time
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("daily quota exceeded for client %s (%s), resets at %s",
+		e.Client, e.Reason, e.ResetAt.Format(time.RFC3339))
+}
+
+// quotaUsage is a client's consumption within the current window
+type quotaUsage struct {
+	windowStart time.Time
+	bytes       int64
+	operations  int
+}
+
+// quotaTracker enforces the per-client daily destruction quota
+type quotaTracker struct {
+	mu    sync.Mutex
+	cfg   config.QuotaConfig
+	now   func() time.Time
+	usage map[string]*quotaUsage
+}
+
+func newQuotaTracker(cfg config.QuotaConfig) *quotaTracker {
+	return &quotaTracker{
+		cfg:   cfg,
+		now:   time.Now,
+		usage: make(map[string]*quotaUsage),
+	}
+}
+
+func (q *quotaTracker) enabled() bool {
+	return q.cfg.MaxBytes > 0 || q.cfg.MaxOperations > 0
+}
+
+// windowStart returns the most recent reset boundary at or before t
+func (q *quotaTracker) windowStart(t time.Time) time.Time {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), t.Day(), q.cfg.ResetHour, 0, 0, 0, time.UTC)
+	if start.After(t) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// current returns the client's usage, starting a new window when the
+// previous one has elapsed. Callers must hold q.mu.
+func (q *quotaTracker) current(client string) *quotaUsage {
+	start := q.windowStart(q.now())
+	usage, ok := q.usage[client]
+	if !ok || usage.windowStart.Before(start) {
+		usage = &quotaUsage{windowStart: start}
+		q.usage[client] = usage
+	}
+	return usage
+}
+
+// quotaReservation is what a running operation holds against its client's
+// quota until it is recorded or released
+type quotaReservation struct {
+	usage *quotaUsage
+	bytes int64
+	done  bool
+}
+
+// check reserves an operation and bytes, what the operation is expected to
+// destroy, against the client's quota, or returns an error when they don't
+// fit. The reservation holds until it is recorded or released, so
+// operations running at once can't overrun the quota between them.
+func (q *quotaTracker) check(client string, bytes int64) (*quotaReservation, error) {
+	if !q.enabled() {
+		return nil, nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	usage := q.current(client)
+	resetAt := usage.windowStart.AddDate(0, 0, 1)
+
+	if q.cfg.MaxOperations > 0 && usage.operations >= q.cfg.MaxOperations {
+		return nil, &QuotaExceededError{
+			Client:  client,
+			Reason:  fmt.Sprintf("%d of %d operations used", usage.operations, q.cfg.MaxOperations),
+			ResetAt: resetAt,
+		}
+	}
+	if q.cfg.MaxBytes > 0 && (usage.bytes >= q.cfg.MaxBytes || usage.bytes+bytes > q.cfg.MaxBytes) {
+		reason := fmt.Sprintf("%d of %d bytes used", usage.bytes, q.cfg.MaxBytes)
+		if bytes > 0 {
+			reason += fmt.Sprintf(", %d more requested", bytes)
+		}
+		return nil, &QuotaExceededError{Client: client, Reason: reason, ResetAt: resetAt}
+	}
+
+	usage.operations++
+	usage.bytes += bytes
+	return &quotaReservation{usage: usage, bytes: bytes}, nil
+}
+
+// release gives back a reservation for an operation that didn't run. It
+// does nothing once the reservation is recorded or released.
+func (q *quotaTracker) release(reservation *quotaReservation) {
+	if reservation == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if reservation.done {
+		return
+	}
+	reservation.done = true
+	reservation.usage.operations--
+	reservation.usage.bytes -= reservation.bytes
+}
+
+// record charges a completed operation against the client's quota,
+// replacing the bytes reserved for it with those it destroyed
+func (q *quotaTracker) record(reservation *quotaReservation, results []*pb.DestructionResult) {
+	if reservation == nil {
+		return
+	}
+
+	var bytes int64
+	for _, result := range results {
+		if result.Metrics != nil {
+			bytes += result.Metrics.BytesDestroyed
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if reservation.done {
+		return
+	}
+	reservation.done = true
+	reservation.usage.bytes += bytes - reservation.bytes
+}
+
+// reserveQuota reserves a request against its client's quota. File
+// deletions are sized up first, so one large request can't overrun the
+// byte quota either.
+func (e *DestructionEngine) reserveQuota(ctx context.Context, destructionType pb.DestructionType, targets []string, recursive bool, filter *fileFilter, severity pb.DestructionSeverity) (*quotaReservation, error) {
+	var bytes int64
+	if e.quota.cfg.MaxBytes > 0 && destructionType == pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		_, measured, err := e.measureDeletion(targets, recursive, filter, severity)
+		if err != nil {
+			return nil, err
+		}
+		bytes = measured
+	}
+	return e.quota.check(ClientIdentityFromContext(ctx), bytes)
+}
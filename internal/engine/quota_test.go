@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestClientIdentityFromContext(t *testing.T) {
+	if id := ClientIdentityFromContext(context.Background()); id != "anonymous" {
+		t.Errorf("Expected 'anonymous' without identity, got '%s'", id)
+	}
+
+	ctx := WithClientIdentity(context.Background(), "cn:tester")
+	if id := ClientIdentityFromContext(ctx); id != "cn:tester" {
+		t.Errorf("Expected 'cn:tester', got '%s'", id)
+	}
+}
+
+func TestQuotaWindowStart(t *testing.T) {
+	q := newQuotaTracker(config.QuotaConfig{MaxBytes: 1, ResetHour: 6})
+
+	before := time.Date(2026, 3, 10, 5, 59, 0, 0, time.UTC)
+	if got := q.windowStart(before); !got.Equal(time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected window to start the previous day, got %s", got)
+	}
+
+	after := time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC)
+	if got := q.windowStart(after); !got.Equal(after) {
+		t.Errorf("Expected window to start at the reset boundary, got %s", got)
+	}
+}
+
+func TestPerClientByteQuota(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+			PerClientDailyQuota: config.QuotaConfig{
+				MaxBytes: 10,
+			},
+		},
+	}
+	engine := NewDestructionEngine(cfg)
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	engine.quota.now = func() time.Time { return now }
+
+	alice := WithClientIdentity(context.Background(), "cn:alice")
+	bob := WithClientIdentity(context.Background(), "cn:bob")
+
+	execute := func(ctx context.Context, name string) error {
+		target := filepath.Join(tempDir, name)
+		if err := os.WriteFile(target, []byte("12345678"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		_, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{target},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			ConfirmDestruction: true,
+		})
+		return err
+	}
+
+	// 8 bytes fit the 10 byte quota; 8 more would cross it, so the second
+	// run is turned away before it deletes anything
+	if err := execute(alice, "a1"); err != nil {
+		t.Fatalf("Expected first execution to pass, got: %v", err)
+	}
+
+	err = execute(alice, "a2")
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected quota exceeded error, got: %v", err)
+	}
+	if !quotaErr.ResetAt.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected reset at next midnight UTC, got %s", quotaErr.ResetAt)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "a2")); err != nil {
+		t.Errorf("Expected rejected target to be kept, got: %v", err)
+	}
+
+	// Other clients are unaffected
+	if err := execute(bob, "b1"); err != nil {
+		t.Errorf("Expected other client to pass, got: %v", err)
+	}
+
+	// Usage resets once the boundary passes
+	now = now.Add(12 * time.Hour)
+	if err := execute(alice, "a4"); err != nil {
+		t.Errorf("Expected quota to reset after the boundary, got: %v", err)
+	}
+}
+
+func TestPerClientOperationQuota(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity: "HIGH",
+			PerClientDailyQuota: config.QuotaConfig{
+				MaxOperations: 1,
+			},
+		},
+	}
+	engine := NewDestructionEngine(cfg)
+	ctx := WithClientIdentity(context.Background(), "addr:10.0.0.1")
+	registerNoopDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION)
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
+		Targets:            []string{"test-service"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+
+	if _, err := engine.ExecuteDestruction(ctx, req); err != nil {
+		t.Fatalf("Expected first operation to pass, got: %v", err)
+	}
+
+	var quotaErr *QuotaExceededError
+	if _, err := engine.ExecuteDestruction(ctx, req); !errors.As(err, &quotaErr) {
+		t.Errorf("Expected operation quota to be exceeded, got: %v", err)
+	}
+}
+
+func TestQuotaReservation(t *testing.T) {
+	q := newQuotaTracker(config.QuotaConfig{MaxBytes: 10, MaxOperations: 2})
+
+	first, err := q.check("cn:alice", 6)
+	if err != nil {
+		t.Fatalf("Expected first reservation to pass, got: %v", err)
+	}
+
+	// The first reservation holds its bytes while it runs
+	var quotaErr *QuotaExceededError
+	if _, err := q.check("cn:alice", 6); !errors.As(err, &quotaErr) {
+		t.Fatalf("Expected reserved bytes to count against the quota, got: %v", err)
+	}
+
+	// A request that fails gives its reservation back
+	q.release(first)
+	second, err := q.check("cn:alice", 6)
+	if err != nil {
+		t.Fatalf("Expected released reservation to free the quota, got: %v", err)
+	}
+
+	// Recording replaces the reserved bytes with those destroyed, and a
+	// later release leaves it alone
+	q.record(second, []*pb.DestructionResult{{Metrics: &pb.DestructionMetrics{BytesDestroyed: 4}}})
+	q.release(second)
+
+	usage := q.current("cn:alice")
+	if usage.bytes != 4 || usage.operations != 1 {
+		t.Errorf("Expected 4 bytes in 1 operation used, got %d bytes in %d", usage.bytes, usage.operations)
+	}
+}
@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// ErrNotImplemented is returned for destruction types with no registered
+// destructor
+var ErrNotImplemented = errors.New("destruction type not implemented")
+
+// Destructor carries out one destruction type. Execute should stop promptly
+// once ctx is cancelled, report progress through task.ReportProgress and
+// return one result per target it touched.
+type Destructor interface {
+	Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error)
+}
+
+// StreamingDestructor is implemented by destructors that send their own
+// events to streaming clients instead of relying on task.ReportProgress.
+// ExecuteStreaming is used in place of Execute for streaming requests.
+type StreamingDestructor interface {
+	Destructor
+	ExecuteStreaming(ctx context.Context, task *DestructionTask, stream pb.BurnDeviceService_StreamDestructionServer) ([]*pb.DestructionResult, error)
+}
+
+// DestructorFunc adapts an ordinary function to the Destructor interface
+type DestructorFunc func(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error)
+
+// Execute calls f(ctx, task)
+func (f DestructorFunc) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return f(ctx, task)
+}
+
+var (
+	destructorsMu sync.RWMutex
+	destructors   = make(map[pb.DestructionType]Destructor)
+)
+
+// RegisterDestructor makes d handle every request of type t, replacing any
+// earlier registration including a built-in one. It is meant to be called
+// from an init function.
+func RegisterDestructor(t pb.DestructionType, d Destructor) {
+	destructorsMu.Lock()
+	defer destructorsMu.Unlock()
+
+	destructors[t] = d
+}
+
+// destructorFor returns the destructor registered for t
+func destructorFor(t pb.DestructionType) (Destructor, error) {
+	destructorsMu.RLock()
+	defer destructorsMu.RUnlock()
+
+	d, ok := destructors[t]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotImplemented, t.String())
+	}
+	return d, nil
+}
+
+// runDestructor executes task with d, using its streaming method when the
+// task is streamed and d provides one
+func runDestructor(ctx context.Context, d Destructor, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	if streaming, ok := d.(StreamingDestructor); ok && task.stream != nil {
+		return streaming.ExecuteStreaming(ctx, task, task.stream)
+	}
+	return d.Execute(ctx, task)
+}
+
+// fileDeletionDestructor deletes files and directories, backing them up
+// according to the severity's deletion behavior
+type fileDeletionDestructor struct{}
+
+func (fileDeletionDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeFileDeletion(task)
+}
+
+func (fileDeletionDestructor) ExecuteStreaming(ctx context.Context, task *DestructionTask, stream pb.BurnDeviceService_StreamDestructionServer) ([]*pb.DestructionResult, error) {
+	return task.engine.executeFileDeletionStreaming(task, stream)
+}
+
+// memoryExhaustionDestructor allocates memory up to the severity's ceiling
+type memoryExhaustionDestructor struct{}
+
+func (memoryExhaustionDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeMemoryExhaustion(task, task.progress)
+}
+
+// diskFillDestructor fills the target directories up to the severity's
+// ceiling
+type diskFillDestructor struct{}
+
+func (diskFillDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeDiskFill(task, task.progress)
+}
+
+// serviceTerminationDestructor stops services with systemctl
+type serviceTerminationDestructor struct{}
+
+func (serviceTerminationDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeServiceTermination(task)
+}
+
+// fileCorruptionDestructor overwrites random bytes of files in place
+type fileCorruptionDestructor struct{}
+
+func (fileCorruptionDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeFileCorruption(task)
+}
+
+// cpuBurnDestructor keeps a share of the cores busy for the requested
+// duration
+type cpuBurnDestructor struct{}
+
+func (cpuBurnDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeCPUBurn(task)
+}
+
+// inodeExhaustionDestructor creates files until inodes run short, or holds
+// open file descriptors
+type inodeExhaustionDestructor struct{}
+
+func (inodeExhaustionDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeInodeExhaustion(task)
+}
+
+// networkDisruptionDestructor drops traffic to host:port targets with
+// firewall rules for the requested duration
+type networkDisruptionDestructor struct{}
+
+func (networkDisruptionDestructor) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	return task.engine.executeNetworkDisruption(task)
+}
+
+func init() {
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, fileDeletionDestructor{})
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION, memoryExhaustionDestructor{})
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL, diskFillDestructor{})
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, serviceTerminationDestructor{})
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION, fileCorruptionDestructor{})
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, cpuBurnDestructor{})
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION, inodeExhaustionDestructor{})
+	RegisterDestructor(pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION, networkDisruptionDestructor{})
+}
+
+// ReportProgress records how far the task has got (0.0-1.0), publishes it
+// to event subscribers and, for streaming requests, sends message to the
+// client as a PROGRESS event
+func (t *DestructionTask) ReportProgress(progress float64, target, message string) {
+	if t.progress != nil {
+		t.progress(progress, message)
+	}
+	if t.engine == nil {
+		return
+	}
+
+	t.engine.setProgress(t, progress, target)
+	t.engine.publishEvent(t, &pb.StreamDestructionResponse{
+		Type:     pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
+		Target:   target,
+		Progress: progress,
+		Message:  message,
+	})
+}
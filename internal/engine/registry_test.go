@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// registerTestDestructor registers d for t until the test finishes
+func registerTestDestructor(t *testing.T, destructionType pb.DestructionType, d Destructor) {
+	destructorsMu.RLock()
+	previous, hadPrevious := destructors[destructionType]
+	destructorsMu.RUnlock()
+
+	RegisterDestructor(destructionType, d)
+	t.Cleanup(func() {
+		destructorsMu.Lock()
+		defer destructorsMu.Unlock()
+		if hadPrevious {
+			destructors[destructionType] = previous
+		} else {
+			delete(destructors, destructionType)
+		}
+	})
+}
+
+// registerNoopDestructor registers a destructor for destructionType that
+// succeeds without touching anything, until the test finishes
+func registerNoopDestructor(t *testing.T, destructionType pb.DestructionType) {
+	registerTestDestructor(t, destructionType, DestructorFunc(
+		func(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+			return []*pb.DestructionResult{{
+				Target:  strings.Join(task.Targets, ","),
+				Success: true,
+				Metrics: &pb.DestructionMetrics{},
+			}}, nil
+		}))
+}
+
+func TestExecuteDestructionDispatchesToRegisteredDestructor(t *testing.T) {
+	var got *DestructionTask
+	registerTestDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION, DestructorFunc(
+		func(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+			got = task
+			return []*pb.DestructionResult{{
+				Target:  task.Targets[0],
+				Success: true,
+				Action:  "fake panic",
+				Metrics: &pb.DestructionMetrics{},
+			}}, nil
+		}))
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION,
+		Targets:            []string{"node-1"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if got == nil {
+		t.Fatal("Expected the registered destructor to be called")
+	}
+	if got.ID != resp.TaskId {
+		t.Errorf("Expected destructor to receive task %s, got %s", resp.TaskId, got.ID)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Action != "fake panic" {
+		t.Errorf("Expected the fake destructor's result, got %v", resp.Results)
+	}
+
+	// Other types keep their own destructor
+	got = nil
+	registerNoopDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION)
+	if _, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION,
+		Targets:            []string{"eth0"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got != nil {
+		t.Error("Expected other types not to reach the fake destructor")
+	}
+}
+
+func TestStreamDestructionDispatchesToRegisteredDestructor(t *testing.T) {
+	registerTestDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION, DestructorFunc(
+		func(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+			task.ReportProgress(0.5, task.Targets[0], "halfway")
+			return []*pb.DestructionResult{{Target: task.Targets[0], Success: true}}, nil
+		}))
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+
+	stream := &recordingStream{}
+	err := engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION,
+		Targets:            []string{"test-target"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	found := false
+	for _, event := range stream.events {
+		if event.Type == pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS && event.Message == "halfway" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the destructor's progress event, got %v", stream.events)
+	}
+}
+
+// streamingFake records which of its methods ran
+type streamingFake struct {
+	streamed bool
+	unary    bool
+}
+
+func (f *streamingFake) Execute(ctx context.Context, task *DestructionTask) ([]*pb.DestructionResult, error) {
+	f.unary = true
+	return nil, nil
+}
+
+func (f *streamingFake) ExecuteStreaming(ctx context.Context, task *DestructionTask, stream pb.BurnDeviceService_StreamDestructionServer) ([]*pb.DestructionResult, error) {
+	f.streamed = true
+	return nil, stream.Send(&pb.StreamDestructionResponse{Message: "custom event"})
+}
+
+func TestStreamingDestructorUsedForStreams(t *testing.T) {
+	fake := &streamingFake{}
+	registerTestDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION, fake)
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+	req := &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION,
+		Targets:            []string{"HKLM\\Software\\Test"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+
+	stream := &recordingStream{}
+	if err := engine.StreamDestruction(context.Background(), req, stream); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !fake.streamed || fake.unary {
+		t.Errorf("Expected only the streaming method to run, got streamed=%v unary=%v", fake.streamed, fake.unary)
+	}
+	if len(stream.events) < 2 || stream.events[1].Message != "custom event" || stream.events[1].TaskId == "" {
+		t.Errorf("Expected the destructor's own event stamped with the task ID, got %v", stream.events)
+	}
+
+	// Unary requests use Execute
+	fake.streamed = false
+	if _, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               req.Type,
+		Targets:            req.Targets,
+		Severity:           req.Severity,
+		ConfirmDestruction: true,
+	}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !fake.unary || fake.streamed {
+		t.Errorf("Expected only the unary method to run, got streamed=%v unary=%v", fake.streamed, fake.unary)
+	}
+}
+
+func TestUnregisteredTypeNotImplemented(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+
+	_, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION,
+		Targets:            []string{"node-1"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected ErrNotImplemented, got: %v", err)
+	}
+
+	// Dry runs are refused too rather than previewing a fake success
+	_, err = engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:    pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED,
+		Targets: []string{"node-1"},
+		DryRun:  true,
+	})
+	if !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected ErrNotImplemented for a dry run, got: %v", err)
+	}
+
+	stream := &recordingStream{}
+	err = engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION,
+		Targets:            []string{"test-target"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}, stream)
+	if !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected ErrNotImplemented for a stream, got: %v", err)
+	}
+	if len(stream.events) != 0 {
+		t.Errorf("Expected no events before rejection, got %d", len(stream.events))
+	}
+
+	for _, destructionType := range []pb.DestructionType{
+		pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION,
+		pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL,
+	} {
+		if _, err := destructorFor(destructionType); err != nil {
+			t.Errorf("Expected %s to be registered at init, got: %v", destructionType, err)
+		}
+	}
+}
@@ -0,0 +1,526 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// backupSuffix is appended to a target's path to name its backup
+const backupSuffix = ".burndevice.backup"
+
+// backupRecord remembers where a destroyed target was backed up, or
+// moved to when it was quarantined
+type backupRecord struct {
+	TaskID      string
+	Target      string
+	BackupPath  string
+	Bytes       int64
+	Quarantined bool
+}
+
+// backupPathFor returns the backup location for target. Without a backup
+// directory the backup is a sidecar next to target; otherwise target's
+// absolute path is mirrored beneath the backup directory.
+func (e *DestructionEngine) backupPathFor(target string) string {
+	backupDir := e.config.Security.BackupDir
+	if backupDir == "" {
+		return target + backupSuffix
+	}
+	return mirrorPath(backupDir, target)
+}
+
+// backupDirPerm returns the permissions for directories created to hold
+// backups. Inside security.backup_dir they are private to the server, as
+// the backups there gather copies of everything destroyed in one place.
+func (e *DestructionEngine) backupDirPerm() os.FileMode {
+	if e.config.Security.BackupDir != "" {
+		return 0700
+	}
+	return 0750
+}
+
+// newBackupPath returns where task taskID backs target up. An earlier
+// backup at the usual location is kept unless security.overwrite_backups
+// is set: the new one is named after the task instead, or after the task
+// and the current time should that be taken too.
+func (e *DestructionEngine) newBackupPath(target, taskID string) string {
+	path := e.backupPathFor(target)
+	if e.config.Security.OverwriteBackups || !pathExists(path) {
+		return path
+	}
+
+	// Keep the suffix last so the backup is still recognised as one
+	base, suffix := path, ""
+	if strings.HasSuffix(path, backupSuffix) {
+		base, suffix = strings.TrimSuffix(path, backupSuffix), backupSuffix
+	}
+	path = fmt.Sprintf("%s.%s%s", base, taskID, suffix)
+	if !pathExists(path) {
+		return path
+	}
+	return fmt.Sprintf("%s.%s.%d%s", base, taskID, time.Now().UnixNano(), suffix)
+}
+
+// pathExists reports whether anything, even a dangling symlink, is at path
+func pathExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// mirrorPath returns where target's absolute path lands beneath dir
+func mirrorPath(dir, target string) string {
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		abs = filepath.Clean(target)
+	}
+	// Fold the volume name (e.g. "C:") into an ordinary path element
+	if volume := filepath.VolumeName(abs); volume != "" {
+		abs = filepath.Join(strings.TrimSuffix(volume, ":"), abs[len(volume):])
+	}
+
+	// The checksum tree's name is reserved, so a top-level element that
+	// could be taken for it gains a "~"
+	first, rest, _ := strings.Cut(strings.TrimLeft(abs, string(filepath.Separator)), string(filepath.Separator))
+	if strings.TrimRight(first, "~") == checksumDirName {
+		first += "~"
+	}
+	return filepath.Join(dir, first, rest)
+}
+
+// inBackupDir reports whether path lies inside the configured backup
+// directory
+func (e *DestructionEngine) inBackupDir(path string) bool {
+	return withinDir(e.config.Security.BackupDir, path)
+}
+
+// withinDir reports whether the absolute path lies inside dir; an empty
+// dir contains nothing
+func withinDir(dir, path string) bool {
+	if dir == "" {
+		return false
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// pruneBackups removes backups in the backup directory that are older than
+// the configured retention, along with directories left empty
+func (e *DestructionEngine) pruneBackups() error {
+	backupDir := e.config.Security.BackupDir
+	retention := e.config.Security.BackupRetention
+	if backupDir == "" || retention <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -retention)
+	var dirs []string
+	pruned := 0
+
+	err := filepath.WalkDir(backupDir, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if os.IsNotExist(walkErr) && path == backupDir {
+				return filepath.SkipDir
+			}
+			return walkErr
+		}
+		if d.IsDir() {
+			if path != backupDir {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if e.backupTime(path, info).Before(cutoff) {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			pruned++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune backups: %w", err)
+	}
+
+	// Deepest directories come last in walk order
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			_ = os.Remove(dirs[i])
+		}
+	}
+
+	if pruned > 0 {
+		e.logger.WithFields(logrus.Fields{
+			"backup_dir": backupDir,
+			"pruned":     pruned,
+		}).Info("Pruned expired backups")
+	}
+
+	return nil
+}
+
+// recordBackup registers the backup at backupPath taken for target by a
+// task
+func (e *DestructionEngine) recordBackup(taskID, target, backupPath string, bytes int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.backups[target] = &backupRecord{
+		TaskID:     taskID,
+		Target:     target,
+		BackupPath: backupPath,
+		Bytes:      bytes,
+	}
+}
+
+// RestoreDestruction copies backups back to their original locations
+func (e *DestructionEngine) RestoreDestruction(ctx context.Context, req *pb.RestoreDestructionRequest) (*pb.RestoreDestructionResponse, error) {
+	e.logger.WithFields(logrus.Fields{
+		"task_id": req.TaskId,
+		"targets": req.Targets,
+		"force":   req.Force,
+	}).Warn("♻️ Restoring destroyed targets")
+
+	targets, err := e.backupTargets(req.TaskId, req.Targets)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	response := &pb.RestoreDestructionResponse{
+		Success:   true,
+		Timestamp: timestamppb.New(time.Now()),
+	}
+
+	restored := 0
+	for _, target := range targets {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("restore cancelled: %w", err)
+		}
+
+		result := e.restoreTarget(target, req.Force, req.RemoveBackup)
+		if result.Success {
+			restored++
+		} else {
+			response.Success = false
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	response.Message = fmt.Sprintf("Restored %d of %d targets", restored, len(targets))
+	e.logger.WithField("restored", restored).Info("Restore completed")
+
+	return response, nil
+}
+
+// backupTargets resolves the targets a restore or verify request refers
+// to: those backed up by taskID, followed by targets
+func (e *DestructionEngine) backupTargets(taskID string, requested []string) ([]string, error) {
+	var targets []string
+
+	if taskID != "" {
+		e.mu.RLock()
+		for target, record := range e.backups {
+			if record.TaskID == taskID {
+				targets = append(targets, target)
+			}
+		}
+		e.mu.RUnlock()
+
+		if len(targets) == 0 {
+			return nil, fmt.Errorf("no backups recorded for task: %s", taskID)
+		}
+	}
+	targets = append(targets, requested...)
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("either a task ID or targets must be provided")
+	}
+
+	for _, target := range targets {
+		if err := e.policy.CheckTarget(target); err != nil {
+			return nil, err
+		}
+	}
+
+	return targets, nil
+}
+
+// locateBackup returns the record kept for target, if any, and where its
+// backup is and whether that is a quarantined original
+func (e *DestructionEngine) locateBackup(target string) (*backupRecord, string, bool) {
+	e.mu.RLock()
+	record := e.backups[target]
+	e.mu.RUnlock()
+
+	if record != nil {
+		return record, record.BackupPath, record.Quarantined
+	}
+
+	backupPath := e.backupPathFor(target)
+	if quarantinePath := e.quarantinePathFor(target); quarantinePath != "" {
+		// Without a record, a target with no backup may still be in
+		// quarantine from before a restart
+		if _, err := os.Lstat(backupPath); os.IsNotExist(err) {
+			if _, err := os.Lstat(quarantinePath); err == nil {
+				return nil, quarantinePath, true
+			}
+		}
+	}
+	return nil, backupPath, false
+}
+
+// restoreTarget restores a single target from its backup
+func (e *DestructionEngine) restoreTarget(target string, force, removeBackup bool) *pb.RestoreResult {
+	record, backupPath, quarantined := e.locateBackup(target)
+
+	result := &pb.RestoreResult{
+		Target:     target,
+		BackupPath: backupPath,
+	}
+
+	backupInfo, err := os.Lstat(backupPath)
+	if err != nil {
+		result.ErrorMessage = fmt.Sprintf("backup not found: %s", backupPath)
+		return result
+	}
+
+	// A damaged backup must not replace anything. Quarantined targets were
+	// moved rather than copied, so there is nothing to check them against.
+	if !quarantined {
+		if _, err := e.verifyBackup(backupPath); err != nil {
+			result.ErrorMessage = fmt.Sprintf("backup failed verification: %v", err)
+			return result
+		}
+	}
+
+	// The backup must hold everything that was destroyed before it
+	// replaces anything
+	if record != nil {
+		held, err := restoreBytes(backupPath, backupInfo)
+		if err != nil {
+			result.ErrorMessage = fmt.Sprintf("failed to read backup: %v", err)
+			return result
+		}
+		if held != record.Bytes {
+			result.ErrorMessage = fmt.Sprintf("backup holds %d bytes but %d were destroyed", held, record.Bytes)
+			return result
+		}
+	}
+
+	if targetInfo, err := os.Lstat(target); err == nil {
+		if !force {
+			result.ErrorMessage = "target already exists, use force to overwrite"
+			return result
+		}
+		// Forcing replaces a file with a file or merges a tree into a
+		// directory, but never swaps one kind for the other. Quarantined
+		// originals replace whatever is there.
+		if !quarantined && targetInfo.IsDir() != backupInfo.IsDir() {
+			if targetInfo.IsDir() {
+				result.ErrorMessage = "target is a directory but its backup is a file; remove the directory before restoring"
+			} else {
+				result.ErrorMessage = "target is a file but its backup is a directory; remove the file before restoring"
+			}
+			return result
+		}
+	}
+
+	var bytes int64
+	switch {
+	case quarantined:
+		bytes, err = e.restoreQuarantined(backupPath, target)
+	case backupInfo.IsDir():
+		bytes, err = e.restoreDirectory(backupPath, target)
+	default:
+		bytes, err = e.restoreEntry(backupPath, target, backupInfo)
+	}
+	if err != nil {
+		result.ErrorMessage = fmt.Sprintf("failed to restore: %v", err)
+		return result
+	}
+	result.BytesRestored = bytes
+
+	// A quarantined target was moved back, so nothing is left to remove
+	if quarantined {
+		e.mu.Lock()
+		delete(e.backups, target)
+		e.mu.Unlock()
+	}
+
+	if removeBackup && !quarantined {
+		for _, path := range []string{backupPath, e.checksumPathFor(backupPath, backupPath)} {
+			if err := os.RemoveAll(path); err != nil {
+				e.logger.WithError(err).WithField("backup", path).Warn("Failed to remove backup after restore")
+			}
+		}
+		e.mu.Lock()
+		delete(e.backups, target)
+		e.mu.Unlock()
+	}
+
+	result.Success = true
+	e.logger.WithFields(logrus.Fields{
+		"target": target,
+		"backup": backupPath,
+		"bytes":  bytes,
+	}).Info("Target restored")
+
+	return result
+}
+
+// VerifyBackup checks the backups of the requested targets against the
+// checksums taken when they were made, without restoring anything
+func (e *DestructionEngine) VerifyBackup(ctx context.Context, req *pb.VerifyBackupRequest) (*pb.VerifyBackupResponse, error) {
+	targets, err := e.backupTargets(req.TaskId, req.Targets)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	response := &pb.VerifyBackupResponse{Success: true}
+	verified := 0
+	for _, target := range targets {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("verify cancelled: %w", err)
+		}
+
+		result := e.verifyTarget(target)
+		if result.Success {
+			verified++
+		} else {
+			response.Success = false
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	response.Message = fmt.Sprintf("Verified %d of %d backups", verified, len(targets))
+	return response, nil
+}
+
+// verifyTarget checks the backup of a single target
+func (e *DestructionEngine) verifyTarget(target string) *pb.VerifyBackupResult {
+	_, backupPath, quarantined := e.locateBackup(target)
+	result := &pb.VerifyBackupResult{
+		Target:     target,
+		BackupPath: backupPath,
+	}
+
+	if _, err := os.Lstat(backupPath); err != nil {
+		result.ErrorMessage = fmt.Sprintf("backup not found: %s", backupPath)
+		return result
+	}
+	// A quarantined target is the original itself, kept without checksums
+	if quarantined {
+		result.Success = true
+		return result
+	}
+
+	files, err := e.verifyBackup(backupPath)
+	result.FilesVerified = files
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+	result.Success = true
+	return result
+}
+
+// restoreDirectory recreates the tree at target from a mirrored backup
+func (e *DestructionEngine) restoreDirectory(backupRoot, target string) (int64, error) {
+	var bytes int64
+
+	err := filepath.WalkDir(backupRoot, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		rel, err := filepath.Rel(backupRoot, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(target, rel)
+
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0750)
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		n, err := e.restoreEntry(path, dest, info)
+		bytes += n
+		return err
+	})
+
+	return bytes, err
+}
+
+// restoreBytes returns the bytes a restore from the backup at path writes
+// back: the size of every regular file in it
+func restoreBytes(path string, info fs.FileInfo) (int64, error) {
+	if !info.IsDir() {
+		if !info.Mode().IsRegular() {
+			return 0, nil
+		}
+		return info.Size(), nil
+	}
+
+	var bytes int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		bytes += info.Size()
+		return nil
+	})
+	return bytes, err
+}
+
+// restoreEntry restores one file or symlink and returns the bytes written
+func (e *DestructionEngine) restoreEntry(backupPath, target string, info fs.FileInfo) (int64, error) {
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to replace existing target: %w", err)
+	}
+
+	if info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(backupPath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read symlink backup: %w", err)
+		}
+		return 0, os.Symlink(link, target)
+	}
+
+	if err := e.copyFile(backupPath, target); err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,634 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestRestoreDestruction(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	testContent := "content to restore"
+	if err := os.WriteFile(testFile, []byte(testContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+		},
+	}
+	engine := NewDestructionEngine(cfg)
+	ctx := context.Background()
+
+	_, err = engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to execute destruction: %v", err)
+	}
+
+	// Restore by task ID
+	taskID := engine.backups[testFile].TaskID
+	resp, err := engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{TaskId: taskID})
+	if err != nil {
+		t.Fatalf("Expected no error restoring, got: %v", err)
+	}
+	if !resp.Success || len(resp.Results) != 1 {
+		t.Fatalf("Expected one successful result, got: %+v", resp)
+	}
+	if resp.Results[0].BytesRestored != int64(len(testContent)) {
+		t.Errorf("Expected %d bytes restored, got %d", len(testContent), resp.Results[0].BytesRestored)
+	}
+
+	content, err := os.ReadFile(testFile)
+	if err != nil || string(content) != testContent {
+		t.Errorf("Expected restored content %q, got %q (%v)", testContent, content, err)
+	}
+
+	// Restoring again must not overwrite the existing file without force
+	resp, err = engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{Targets: []string{testFile}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Results[0].ErrorMessage, "already exists") {
+		t.Errorf("Expected existing target to be refused, got: %+v", resp.Results[0])
+	}
+
+	// With force, the backup is copied over and then removed
+	resp, err = engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{
+		Targets:      []string{testFile},
+		Force:        true,
+		RemoveBackup: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success {
+		t.Errorf("Expected forced restore to succeed, got: %+v", resp.Results[0])
+	}
+	if _, err := os.Stat(engine.backupPathFor(testFile)); !os.IsNotExist(err) {
+		t.Error("Expected backup to be removed")
+	}
+}
+
+func TestRestoreDestructionMissingBackup(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	engine := NewDestructionEngine(&config.Config{})
+	missing := filepath.Join(tempDir, "missing.txt")
+
+	resp, err := engine.RestoreDestruction(context.Background(), &pb.RestoreDestructionRequest{Targets: []string{missing}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success {
+		t.Error("Expected restore without a backup to fail")
+	}
+	if !strings.Contains(resp.Results[0].ErrorMessage, "backup not found") {
+		t.Errorf("Expected backup not found error, got: %s", resp.Results[0].ErrorMessage)
+	}
+
+	// Unknown task IDs and empty requests are rejected up front
+	if _, err := engine.RestoreDestruction(context.Background(), &pb.RestoreDestructionRequest{TaskId: "unknown"}); err == nil {
+		t.Error("Expected error for unknown task ID")
+	}
+	if _, err := engine.RestoreDestruction(context.Background(), &pb.RestoreDestructionRequest{}); err == nil {
+		t.Error("Expected error for empty restore request")
+	}
+}
+
+func TestRestoreDestructionSizeMismatch(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "tree")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatalf("Failed to create tree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "a.txt"), []byte("original"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	metrics := &pb.DestructionMetrics{}
+	if err := engine.safeDeletion(context.Background(), target, engine.backupPathFor(target), 0, metrics, nil); err != nil {
+		t.Fatalf("Failed to delete directory: %v", err)
+	}
+	engine.recordBackup("task_1", target, engine.backupPathFor(target), metrics.BytesDestroyed)
+
+	// Truncate the backup so its length no longer matches. Without its
+	// checksum only the length gives the truncation away.
+	backup := filepath.Join(engine.backupPathFor(target), "a.txt")
+	if err := os.WriteFile(backup, []byte("orig"), 0644); err != nil {
+		t.Fatalf("Failed to tamper with backup: %v", err)
+	}
+	if err := os.Remove(engine.checksumPathFor(engine.backupPathFor(target), backup)); err != nil {
+		t.Fatalf("Failed to remove backup checksum: %v", err)
+	}
+
+	resp, err := engine.RestoreDestruction(context.Background(), &pb.RestoreDestructionRequest{TaskId: "task_1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success {
+		t.Error("Expected restore with mismatched length to fail")
+	}
+	if !strings.Contains(resp.Results[0].ErrorMessage, "were destroyed") {
+		t.Errorf("Expected length mismatch error, got: %s", resp.Results[0].ErrorMessage)
+	}
+	// The mismatch is found before anything is written
+	if _, err := os.Lstat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected the target not to be restored from a short backup, got: %v", err)
+	}
+}
+
+func TestRestoreDestructionKindMismatch(t *testing.T) {
+	tempDir := t.TempDir()
+
+	target := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(target, []byte("original"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{})
+	metrics := &pb.DestructionMetrics{}
+	if err := engine.safeDeletion(context.Background(), target, engine.backupPathFor(target), 0, metrics, nil); err != nil {
+		t.Fatalf("Failed to delete file: %v", err)
+	}
+	engine.recordBackup("task_1", target, engine.backupPathFor(target), metrics.BytesDestroyed)
+
+	// A non-empty directory now stands where the file was
+	if err := os.MkdirAll(filepath.Join(target, "nested"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	resp, err := engine.RestoreDestruction(context.Background(), &pb.RestoreDestructionRequest{TaskId: "task_1", Force: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Results[0].ErrorMessage, "target is a directory but its backup is a file") {
+		t.Errorf("Expected the directory to be refused, got: %+v", resp.Results[0])
+	}
+	if _, err := os.Stat(filepath.Join(target, "nested")); err != nil {
+		t.Errorf("Expected the directory to be left alone: %v", err)
+	}
+}
+
+func TestRestoreDirectoryHoldingChecksumNames(t *testing.T) {
+	for _, inBackupDir := range []bool{false, true} {
+		name := "beside the target"
+		if inBackupDir {
+			name = "in backup_dir"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			// The tree's own .sha256 files are data like any other, and
+			// release.txt's backup must not be checked against release.sha256
+			files := map[string]string{
+				"release/release.txt":               "release",
+				"release/release.sha256":            "0123456789\n",
+				"release/release.txt.sha256":        "not a checksum",
+				"release/nested/.burndevice.sha256": "reserved name",
+			}
+			tempDir := newTestTree(t, files)
+			target := filepath.Join(tempDir, "release")
+
+			security := config.SecurityConfig{MaxSeverity: "HIGH", AllowedTargets: []string{tempDir}}
+			if inBackupDir {
+				security.BackupDir = filepath.Join(tempDir, "backups")
+			}
+			engine := NewDestructionEngine(&config.Config{Security: security})
+
+			resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+				Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+				Targets:            []string{target},
+				Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+				ConfirmDestruction: true,
+			})
+			if err != nil || !resp.Success {
+				t.Fatalf("Expected deletion to succeed, got: %+v (%v)", resp, err)
+			}
+			if metrics := resp.Results[0].Metrics; metrics.FilesDeleted != 4 {
+				t.Errorf("Expected 4 files deleted, got %d", metrics.FilesDeleted)
+			}
+
+			verified, err := engine.VerifyBackup(context.Background(), &pb.VerifyBackupRequest{TaskId: resp.TaskId})
+			if err != nil || !verified.Success || verified.Results[0].FilesVerified != 4 {
+				t.Errorf("Expected all 4 files to verify, got: %+v (%v)", verified, err)
+			}
+
+			restored, err := engine.RestoreDestruction(context.Background(), &pb.RestoreDestructionRequest{TaskId: resp.TaskId})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !restored.Success {
+				t.Fatalf("Expected restore to succeed, got: %+v", restored.Results[0])
+			}
+			for name, content := range files {
+				data, err := os.ReadFile(filepath.Join(tempDir, filepath.FromSlash(name)))
+				if err != nil || string(data) != content {
+					t.Errorf("Expected %s to be restored with %q, got %q (%v)", name, content, data, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMirrorPathReservesChecksumTree(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Top-level elements are volume names on Windows")
+	}
+
+	tests := map[string]string{
+		"/srv/data":                 "/backups/srv/data",
+		"/.burndevice.sha256/a":     "/backups/.burndevice.sha256~/a",
+		"/.burndevice.sha256~":      "/backups/.burndevice.sha256~~",
+		"/srv/.burndevice.sha256/a": "/backups/srv/.burndevice.sha256/a",
+	}
+	for target, expected := range tests {
+		if got := mirrorPath("/backups", target); got != expected {
+			t.Errorf("Expected %s to mirror to %s, got %s", target, expected, got)
+		}
+	}
+}
+
+func TestBackupDir(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	targetDir := filepath.Join(tempDir, "target")
+	backupDir := filepath.Join(tempDir, "backups")
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		t.Fatalf("Failed to create target dir: %v", err)
+	}
+	testFile := filepath.Join(targetDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("backed up elsewhere"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+			BackupDir:      backupDir,
+		},
+	})
+	ctx := context.Background()
+
+	resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	result := resp.Results[0]
+	if !result.Success {
+		t.Fatalf("Expected deletion to succeed, got: %s", result.ErrorMessage)
+	}
+	if !strings.HasPrefix(result.BackupPath, backupDir) {
+		t.Errorf("Expected backup path under %s, got %s", backupDir, result.BackupPath)
+	}
+	if _, err := os.Stat(result.BackupPath); err != nil {
+		t.Errorf("Expected backup at %s: %v", result.BackupPath, err)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(backupDir)
+		if err != nil {
+			t.Fatalf("Expected backup dir to be created: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0700 {
+			t.Errorf("Expected backup dir mode 0700, got %o", perm)
+		}
+	}
+
+	// Backups are never targets themselves, even inside allowed_targets
+	_, err = engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{result.BackupPath},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "blocked") {
+		t.Errorf("Expected target inside backup dir to be blocked, got: %v", err)
+	}
+
+	// The directory under test is left empty
+	entries, err := os.ReadDir(targetDir)
+	if err != nil {
+		t.Fatalf("Failed to read target dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected target dir to be empty, found %d entries", len(entries))
+	}
+
+	restoreResp, err := engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{Targets: []string{testFile}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !restoreResp.Success {
+		t.Errorf("Expected restore to succeed, got: %+v", restoreResp.Results[0])
+	}
+	if restoreResp.Results[0].BackupPath != result.BackupPath {
+		t.Errorf("Expected restore to use %s, got %s", result.BackupPath, restoreResp.Results[0].BackupPath)
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	backupDir, err := os.MkdirTemp("", "burndevice_backups")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(backupDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	oldFile := filepath.Join(backupDir, "tmp", "old", "a.txt")
+	newFile := filepath.Join(backupDir, "tmp", "new", "b.txt")
+	for _, path := range []string{oldFile, newFile} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create backup tree: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("backup"), 0644); err != nil {
+			t.Fatalf("Failed to create backup: %v", err)
+		}
+	}
+	old := time.Now().AddDate(0, 0, -10)
+	if err := os.Chtimes(oldFile, old, old); err != nil {
+		t.Fatalf("Failed to age backup: %v", err)
+	}
+
+	// Pruning happens when the engine starts
+	NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			BackupDir:       backupDir,
+			BackupRetention: 7,
+		},
+	})
+
+	if _, err := os.Stat(filepath.Dir(oldFile)); !os.IsNotExist(err) {
+		t.Error("Expected expired backup and its empty directory to be pruned")
+	}
+	if _, err := os.Stat(newFile); err != nil {
+		t.Errorf("Expected recent backup to be kept: %v", err)
+	}
+}
+
+func TestBackupCollision(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+		},
+	})
+	ctx := context.Background()
+
+	var backupPaths []string
+	for _, content := range []string{"first run", "second run"} {
+		if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{testFile},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			ConfirmDestruction: true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if !resp.Results[0].Success {
+			t.Fatalf("Expected deletion to succeed, got: %s", resp.Results[0].ErrorMessage)
+		}
+		backupPaths = append(backupPaths, resp.Results[0].BackupPath)
+	}
+
+	if backupPaths[0] != engine.backupPathFor(testFile) {
+		t.Errorf("Expected first backup at %s, got %s", engine.backupPathFor(testFile), backupPaths[0])
+	}
+	if backupPaths[1] == backupPaths[0] || !strings.HasSuffix(backupPaths[1], backupSuffix) {
+		t.Errorf("Expected second backup beside the first, got %s", backupPaths[1])
+	}
+	if content, err := os.ReadFile(backupPaths[0]); err != nil || string(content) != "first run" {
+		t.Errorf("Expected first backup to be kept, got %q (%v)", content, err)
+	}
+
+	// Restore follows the backup the latest task reported
+	resp, err := engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{Targets: []string{testFile}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success || resp.Results[0].BackupPath != backupPaths[1] {
+		t.Fatalf("Expected restore from %s, got: %+v", backupPaths[1], resp.Results[0])
+	}
+	if content, err := os.ReadFile(testFile); err != nil || string(content) != "second run" {
+		t.Errorf("Expected second run restored, got %q (%v)", content, err)
+	}
+
+	// With overwrite_backups the usual path is reused
+	engine.config.Security.OverwriteBackups = true
+	if path := engine.newBackupPath(testFile, "task_3"); path != backupPaths[0] {
+		t.Errorf("Expected existing backup path to be reused, got %s", path)
+	}
+}
+
+func TestBackupPreservesMetadata(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("metadata"), 0600); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	modTime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(testFile, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set modification time: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+		},
+	})
+	ctx := context.Background()
+
+	resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil || !resp.Results[0].Success {
+		t.Fatalf("Expected deletion to succeed, got: %+v (%v)", resp, err)
+	}
+
+	check := func(path string) {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", path, err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("Expected %s to keep mode 0600, got %o", path, info.Mode().Perm())
+		}
+		if !info.ModTime().Equal(modTime) {
+			t.Errorf("Expected %s to keep modification time %v, got %v", path, modTime, info.ModTime())
+		}
+	}
+	check(resp.Results[0].BackupPath)
+
+	restoreResp, err := engine.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{Targets: []string{testFile}})
+	if err != nil || !restoreResp.Success {
+		t.Fatalf("Expected restore to succeed, got: %+v (%v)", restoreResp, err)
+	}
+	check(testFile)
+}
+
+func TestBackupAgeIgnoresSourceModTime(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	targetDir := filepath.Join(tempDir, "targets")
+	backupDir := filepath.Join(tempDir, "backups")
+	if err := os.MkdirAll(targetDir, 0755); err != nil {
+		t.Fatalf("Failed to create target dir: %v", err)
+	}
+
+	// Both files were last edited a year ago, but are backed up today
+	yearAgo := time.Now().AddDate(-1, 0, 0)
+	deleteOldFile := func(engine *DestructionEngine, name string) string {
+		t.Helper()
+		path := filepath.Join(targetDir, name)
+		if err := os.WriteFile(path, []byte("old data"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		if err := os.Chtimes(path, yearAgo, yearAgo); err != nil {
+			t.Fatalf("Failed to set modification time: %v", err)
+		}
+		resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{path},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			ConfirmDestruction: true,
+		})
+		if err != nil || !resp.Results[0].Success {
+			t.Fatalf("Expected deletion to succeed, got: %+v (%v)", resp, err)
+		}
+		return resp.Results[0].BackupPath
+	}
+
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:     "HIGH",
+			AllowedTargets:  []string{targetDir},
+			BackupDir:       backupDir,
+			BackupRetention: 7,
+		},
+	}
+	pruner := NewDestructionEngine(cfg)
+	pruned := deleteOldFile(pruner, "pruned.txt")
+
+	// Pruning happens when the engine starts
+	NewDestructionEngine(cfg)
+	if _, err := os.Stat(pruned); err != nil {
+		t.Errorf("Expected today's backup to survive pruning: %v", err)
+	}
+
+	cleaned := deleteOldFile(NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH", AllowedTargets: []string{targetDir}},
+	}), "cleaned.txt")
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{AllowedTargets: []string{targetDir}},
+	})
+	resp, err := engine.CleanupBackups(targetDir, 24*time.Hour)
+	if err != nil || len(resp.Removed) != 0 {
+		t.Errorf("Expected cleanup to keep today's backup, got: %+v (%v)", resp, err)
+	}
+	if _, err := os.Stat(cleaned); err != nil {
+		t.Errorf("Expected today's backup to survive cleanup: %v", err)
+	}
+
+	// Once the backup itself is old enough it goes
+	old := time.Now().AddDate(0, 0, -10)
+	if err := os.Chtimes(pruner.checksumPathFor(pruned, pruned), old, old); err != nil {
+		t.Fatalf("Failed to age checksum: %v", err)
+	}
+	NewDestructionEngine(cfg)
+	if _, err := os.Stat(pruned); !os.IsNotExist(err) {
+		t.Errorf("Expected the expired backup to be pruned, got: %v", err)
+	}
+}
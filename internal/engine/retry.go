@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultRetryBackoff is used when engine.retry_backoff is unset
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// retryDeletion runs deletion, retrying a failure up to
+// engine.target_retries times with a doubling backoff, and returns how many
+// attempts it made along with the last error. Waiting between attempts
+// stops when ctx is done. A deletion that wipes is never retried: it may
+// already have overwritten the original, which a retry would then back up
+// over the real backup.
+func (e *DestructionEngine) retryDeletion(ctx context.Context, target string, passes int, deletion func() error) (int32, error) {
+	retries := e.config.Engine.TargetRetries
+	if passes > 0 {
+		retries = 0
+	}
+	backoff := e.config.Engine.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	var attempts int32
+	for {
+		attempts++
+		err := deletion()
+		if err == nil || int(attempts) > retries || !retryableDeletionError(err) {
+			return attempts, err
+		}
+
+		e.logger.WithError(err).WithFields(logrus.Fields{
+			"target":  target,
+			"attempt": attempts,
+			"backoff": backoff,
+		}).Warn("File deletion failed, retrying")
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempts, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// retryableDeletionError reports whether a failed deletion may succeed on
+// a later attempt. Missing targets and cancellation never do.
+func retryableDeletionError(err error) bool {
+	return !errors.Is(err, fs.ErrNotExist) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func newRetryEngine(retries int) *DestructionEngine {
+	return NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+		Engine:   config.EngineConfig{TargetRetries: retries, RetryBackoff: time.Millisecond},
+	})
+}
+
+// flakyDeletion fails with err for the first failures calls
+func flakyDeletion(failures int, err error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= failures {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestRetryDeletion(t *testing.T) {
+	busy := fmt.Errorf("failed to remove file: %w", &fs.PathError{Op: "remove", Path: "/tmp/x", Err: syscall.EBUSY})
+
+	tests := []struct {
+		name     string
+		failures int
+		err      error
+		passes   int
+		attempts int32
+		success  bool
+	}{
+		{"succeeds after transient failures", 2, busy, 0, 3, true},
+		{"gives up after the configured retries", 5, busy, 0, 3, false},
+		{"missing targets are not retried", 5, fmt.Errorf("failed to stat file: %w", fs.ErrNotExist), 0, 1, false},
+		{"wiping deletions are not retried", 5, busy, 1, 1, false},
+	}
+
+	engine := newRetryEngine(2)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletion, calls := flakyDeletion(tt.failures, tt.err)
+			attempts, err := engine.retryDeletion(context.Background(), "/tmp/x", tt.passes, deletion)
+			if attempts != tt.attempts || int(attempts) != *calls {
+				t.Errorf("Expected %d attempts, got %d (%d calls)", tt.attempts, attempts, *calls)
+			}
+			if (err == nil) != tt.success {
+				t.Errorf("Expected success %v, got: %v", tt.success, err)
+			}
+		})
+	}
+
+	// Without retries configured a failure is final
+	deletion, _ := flakyDeletion(1, busy)
+	if attempts, err := newRetryEngine(0).retryDeletion(context.Background(), "/tmp/x", 0, deletion); attempts != 1 || err == nil {
+		t.Errorf("Expected a single failed attempt, got %d, %v", attempts, err)
+	}
+}
+
+func TestRetryDeletionCancelled(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Engine: config.EngineConfig{TargetRetries: 3, RetryBackoff: time.Minute},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	deletion, _ := flakyDeletion(5, errors.New("resource busy"))
+	start := time.Now()
+	attempts, err := engine.retryDeletion(ctx, "/tmp/x", 0, deletion)
+	if time.Since(start) > 5*time.Second {
+		t.Error("Expected cancellation to cut the backoff short")
+	}
+	if attempts != 1 || err == nil {
+		t.Errorf("Expected the failed attempt to be reported, got %d, %v", attempts, err)
+	}
+}
+
+func TestDeletionReportsAttempts(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_retry_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	resp, err := newRetryEngine(2).ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Failed to execute destruction: %v, %+v", err, resp)
+	}
+	if attempts := resp.Results[0].Attempts; attempts != 1 {
+		t.Errorf("Expected the target to be deleted on the first attempt, got %d attempts", attempts)
+	}
+}
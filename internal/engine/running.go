@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/store"
+)
+
+// runningFileName is the running task file inside the data directory
+const runningFileName = "running.jsonl"
+
+// interruptedMessage is recorded for tasks the server stopped in the middle
+// of
+const interruptedMessage = "Server stopped before the task finished"
+
+// runningStore mirrors the registered tasks to a JSON-lines file, rewritten
+// whenever a task is registered, changes state or finishes, so tasks cut
+// short by a restart can be reported afterwards. Without a path nothing is
+// persisted.
+type runningStore struct {
+	mu     sync.Mutex
+	path   string
+	logger *logrus.Logger
+}
+
+// newRunningStore creates a store. An empty dataDir disables it.
+func newRunningStore(dataDir string, logger *logrus.Logger) *runningStore {
+	s := &runningStore{logger: logger}
+	if dataDir != "" {
+		s.path = filepath.Join(dataDir, runningFileName)
+	}
+	return s
+}
+
+// takeLeftover returns the tasks the previous server left registered and
+// removes the file, so each is only reported once
+func (s *runningStore) takeLeftover() ([]*pb.TaskStatus, error) {
+	if s.path == "" {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks, skipped, err := store.ReadJSONLines(s.path, func() *pb.TaskStatus { return &pb.TaskStatus{} },
+		func(task *pb.TaskStatus) bool { return task.TaskId != "" })
+	if err != nil {
+		return nil, fmt.Errorf("failed to read running tasks: %w", err)
+	}
+	if skipped > 0 {
+		s.logger.WithField("skipped", skipped).Warn("Skipped unreadable running tasks")
+	}
+
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return tasks, fmt.Errorf("failed to remove running tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// write replaces the file with tasks. Callers must hold s.mu.
+func (s *runningStore) write(tasks []*pb.TaskStatus) error {
+	if err := store.WriteJSONLines(s.path, tasks); err != nil {
+		return fmt.Errorf("failed to write running tasks: %w", err)
+	}
+	return nil
+}
+
+// persistRunning rewrites the running task file from the registered tasks.
+// Only what identifies each task is kept, not its progress or results.
+func (e *DestructionEngine) persistRunning() {
+	running := e.runningState
+	if running == nil || running.path == "" {
+		return
+	}
+
+	// Holding the store lock across the snapshot keeps concurrent
+	// rewrites from landing out of order
+	running.mu.Lock()
+	defer running.mu.Unlock()
+
+	e.mu.RLock()
+	tasks := make([]*pb.TaskStatus, 0, len(e.running))
+	for _, task := range e.running {
+		tasks = append(tasks, &pb.TaskStatus{
+			TaskId:    task.ID,
+			Type:      task.Type,
+			Severity:  task.Severity,
+			Targets:   task.Targets,
+			State:     task.Status,
+			StartedAt: timestamppb.New(task.StartedAt),
+		})
+	}
+	e.mu.RUnlock()
+
+	if err := running.write(tasks); err != nil {
+		e.logger.WithError(err).Warn("Failed to persist running tasks")
+	}
+}
+
+// recoverInterrupted reports the tasks the previous server was running
+// when it stopped as interrupted, both in history and alongside the
+// running tasks
+func (e *DestructionEngine) recoverInterrupted() {
+	tasks, err := e.runningState.takeLeftover()
+	if err != nil {
+		e.logger.WithError(err).Warn("Failed to load running tasks")
+	}
+	if len(tasks) == 0 {
+		return
+	}
+
+	now := timestamppb.New(time.Now())
+	for _, task := range tasks {
+		task.State = TaskStateInterrupted
+		e.interrupted = append(e.interrupted, task)
+
+		record := &pb.TaskRecord{
+			TaskId:     task.TaskId,
+			Type:       task.Type,
+			Severity:   task.Severity,
+			Targets:    task.Targets,
+			State:      TaskStateInterrupted,
+			Message:    interruptedMessage,
+			StartedAt:  task.StartedAt,
+			FinishedAt: now,
+		}
+		if err := e.history.add(record); err != nil {
+			e.logger.WithError(err).WithField("task_id", task.TaskId).Warn("Failed to persist task history")
+		}
+	}
+
+	e.logger.WithField("tasks", len(tasks)).Warn("⚠️  Tasks were interrupted by the last server stop")
+}
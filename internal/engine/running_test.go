@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+)
+
+func TestInterruptedTasksAfterRestart(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_running_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: dataDir}}
+	engine := NewDestructionEngine(cfg)
+
+	newTask := func() *DestructionTask {
+		ctx, cancel := context.WithCancel(context.Background())
+		return &DestructionTask{
+			ID:        ids.Task(),
+			Type:      pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL,
+			Targets:   []string{"/tmp/fill"},
+			Severity:  pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+			Context:   ctx,
+			Cancel:    cancel,
+			Status:    TaskStateRunning,
+			StartedAt: time.Now(),
+		}
+	}
+
+	// One task finishes normally, the other is still running when the
+	// server stops
+	finished := newTask()
+	engine.registerTask(finished)
+	engine.unregisterTask(finished)
+	running := newTask()
+	engine.registerTask(running)
+	defer running.Cancel()
+
+	restarted := NewDestructionEngine(cfg)
+
+	tasks := restarted.ListTasks()
+	if len(tasks) != 1 || tasks[0].TaskId != running.ID || tasks[0].State != TaskStateInterrupted {
+		t.Fatalf("Expected only the running task to be listed as interrupted, got %v", tasks)
+	}
+	if tasks[0].Type != running.Type || tasks[0].Severity != running.Severity || tasks[0].Targets[0] != running.Targets[0] {
+		t.Errorf("Expected the task's request to be kept, got %v", tasks[0])
+	}
+
+	status, err := restarted.GetTaskStatus(running.ID)
+	if err != nil || status.State != TaskStateInterrupted {
+		t.Errorf("Expected the interrupted task's status, got %v, %v", status, err)
+	}
+
+	history, err := restarted.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("GetTaskHistory failed: %v", err)
+	}
+	if history.Total != 1 || history.Tasks[0].State != TaskStateInterrupted || history.Tasks[0].Success {
+		t.Fatalf("Expected the interrupted task in history, got %v", history.Tasks)
+	}
+
+	// Each interrupted task is only reported once, but stays in history
+	again := NewDestructionEngine(cfg)
+	if tasks := again.ListTasks(); len(tasks) != 0 {
+		t.Errorf("Expected no interrupted tasks after a second restart, got %v", tasks)
+	}
+	if history, _ := again.GetTaskHistory(&pb.GetTaskHistoryRequest{}); history.Total != 1 {
+		t.Errorf("Expected the interrupted task to remain in history, got %d tasks", history.Total)
+	}
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"fmt"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// safeModeNote tells the caller how safe mode limited a request of type t
+// at severity, or returns "" when safe mode is off or leaves t unchanged
+func (e *DestructionEngine) safeModeNote(t pb.DestructionType, severity pb.DestructionSeverity) string {
+	if !e.config.Security.EnableSafeMode {
+		return ""
+	}
+
+	var limit string
+	switch t {
+	case pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION:
+		if _, downgraded := e.deletionBehavior(severity); !downgraded {
+			return ""
+		}
+		limit = "backups kept before changing files"
+	case pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION:
+		limit = "services checked but not stopped"
+	case pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION:
+		limit = "no firewall rules added"
+	case pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION:
+		limit = fmt.Sprintf("memory held limited to %d bytes", safeModeMemoryCap)
+	case pb.DestructionType_DESTRUCTION_TYPE_DISK_FILL:
+		limit = fmt.Sprintf("disk fill limited to %d bytes", safeModeDiskCap)
+	case pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN:
+		limit = fmt.Sprintf("CPU burn limited to %s", safeModeCPUBurnCap)
+	case pb.DestructionType_DESTRUCTION_TYPE_INODE_EXHAUSTION:
+		limit = fmt.Sprintf("limited to %d files per directory and %d file descriptors, held for at most %s",
+			safeModeInodeCap, safeModeFDCap, safeModeInodeHoldCap)
+	default:
+		return ""
+	}
+	return fmt.Sprintf("; safe mode downgraded the operation: %s", limit)
+}
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestSafeModeDowngradesHighShred(t *testing.T) {
+	for _, safeMode := range []bool{true, false} {
+		tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 1024))})
+		testFile := filepath.Join(tempDir, "secret.bin")
+		content := bytes.Repeat([]byte("x"), 1024)
+		if err := os.WriteFile(testFile, content, 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+
+		engine := NewDestructionEngine(&config.Config{
+			Security: config.SecurityConfig{
+				MaxSeverity:    "HIGH",
+				EnableSafeMode: safeMode,
+				DeletionBehaviors: map[string]config.DeletionBehavior{
+					"HIGH": {Backup: false, WipePasses: 2},
+				},
+			},
+		})
+
+		resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{testFile},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+			ConfirmDestruction: true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		result := resp.Results[0]
+		if !result.Success {
+			t.Fatalf("Expected deletion to succeed, got: %s", result.ErrorMessage)
+		}
+		if _, err := os.Stat(testFile); !os.IsNotExist(err) {
+			t.Error("Expected file to be removed")
+		}
+
+		if !safeMode {
+			if result.BackupPath != "" {
+				t.Errorf("Expected no backup outside safe mode, got %s", result.BackupPath)
+			}
+			if strings.Contains(resp.Message, "safe mode") {
+				t.Errorf("Expected no safe mode note outside safe mode, got: %s", resp.Message)
+			}
+			continue
+		}
+
+		// Safe mode keeps a backup of the original contents, taken before
+		// the wipe passes
+		backup, err := os.ReadFile(result.BackupPath)
+		if err != nil {
+			t.Fatalf("Expected a backup under safe mode: %v", err)
+		}
+		if !bytes.Equal(backup, content) {
+			t.Error("Expected the backup to hold the original contents")
+		}
+		if !strings.Contains(resp.Message, "safe mode downgraded the operation") {
+			t.Errorf("Expected the message to report the downgrade, got: %s", resp.Message)
+		}
+	}
+}
+
+func TestSafeModeNote(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{EnableSafeMode: true},
+	})
+
+	high := pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH
+	low := pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW
+	if note := engine.safeModeNote(pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, low); !strings.Contains(note, "not stopped") {
+		t.Errorf("Expected services to be reported as not stopped, got %q", note)
+	}
+	if note := engine.safeModeNote(pb.DestructionType_DESTRUCTION_TYPE_MEMORY_EXHAUSTION, high); !strings.Contains(note, "memory held limited") {
+		t.Errorf("Expected the memory cap to be reported, got %q", note)
+	}
+	// LOW keeps a backup anyway, so safe mode changes nothing
+	if note := engine.safeModeNote(pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, low); note != "" {
+		t.Errorf("Expected no note for LOW file deletion, got %q", note)
+	}
+
+	engine.config.Security.EnableSafeMode = false
+	if note := engine.safeModeNote(pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, low); note != "" {
+		t.Errorf("Expected no note outside safe mode, got %q", note)
+	}
+}
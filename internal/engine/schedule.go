@@ -0,0 +1,365 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+	"github.com/BurnDevice/BurnDevice/internal/store"
+)
+
+// schedulesFileName is the pending schedule file inside the data directory
+const schedulesFileName = "schedules.jsonl"
+
+// schedulerInterval is how often the scheduler looks for due schedules
+var schedulerInterval = time.Second
+
+// ErrInvalidSchedule is returned when a schedule request can't be accepted
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// scheduleStore holds pending schedules. When path is set the whole set is
+// rewritten to a JSON-lines file on every change so schedules survive
+// restarts.
+type scheduleStore struct {
+	mu        sync.Mutex
+	path      string
+	schedules map[string]*pb.Schedule
+	logger    *logrus.Logger
+}
+
+// newScheduleStore creates an empty store. An empty dataDir keeps
+// schedules in memory only.
+func newScheduleStore(dataDir string, logger *logrus.Logger) *scheduleStore {
+	s := &scheduleStore{
+		schedules: make(map[string]*pb.Schedule),
+		logger:    logger,
+	}
+	if dataDir != "" {
+		s.path = filepath.Join(dataDir, schedulesFileName)
+	}
+	return s
+}
+
+// load reads the schedule file, skipping unreadable lines. Schedules that
+// came due while the server was down run on the scheduler's first pass.
+func (s *scheduleStore) load() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules, skipped, err := store.ReadJSONLines(s.path, func() *pb.Schedule { return &pb.Schedule{} },
+		func(schedule *pb.Schedule) bool { return schedule.Request != nil })
+	if err != nil {
+		return fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	overdue := 0
+	now := time.Now()
+	for _, schedule := range schedules {
+		s.schedules[schedule.ScheduleId] = schedule
+		if schedule.NextRun.AsTime().Before(now) {
+			overdue++
+		}
+	}
+
+	if skipped > 0 {
+		s.logger.WithField("skipped", skipped).Warn("Skipped unreadable schedules")
+	}
+	if overdue > 0 {
+		s.logger.WithField("overdue", overdue).Warn("⏰ Schedules came due while the server was down and will run now")
+	}
+	return nil
+}
+
+// rewrite replaces the schedule file with the schedules held in memory.
+// Callers must hold s.mu.
+func (s *scheduleStore) rewrite() error {
+	if s.path == "" {
+		return nil
+	}
+
+	if err := store.WriteJSONLines(s.path, s.sortedLocked()); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	return nil
+}
+
+// sortedLocked returns the schedules soonest first. Callers must hold s.mu.
+func (s *scheduleStore) sortedLocked() []*pb.Schedule {
+	schedules := make([]*pb.Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		a, b := schedules[i].NextRun.AsTime(), schedules[j].NextRun.AsTime()
+		if a.Equal(b) {
+			return schedules[i].ScheduleId < schedules[j].ScheduleId
+		}
+		return a.Before(b)
+	})
+	return schedules
+}
+
+// add stores a new schedule and persists the set
+func (s *scheduleStore) add(schedule *pb.Schedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedules[schedule.ScheduleId] = schedule
+	return s.rewrite()
+}
+
+// remove deletes a schedule, reporting whether it existed
+func (s *scheduleStore) remove(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.schedules[id]; !ok {
+		return false, nil
+	}
+	delete(s.schedules, id)
+	return true, s.rewrite()
+}
+
+// list returns copies of the pending schedules, soonest first
+func (s *scheduleStore) list() []*pb.Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules := s.sortedLocked()
+	for i, schedule := range schedules {
+		schedules[i] = proto.Clone(schedule).(*pb.Schedule)
+	}
+	return schedules
+}
+
+// takeDue returns copies of the schedules due at now. One-off schedules are
+// removed and recurring ones moved to their next run before anything runs,
+// so a crash mid-run never repeats a destruction.
+func (s *scheduleStore) takeDue(now time.Time) ([]*pb.Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*pb.Schedule
+	for id, schedule := range s.schedules {
+		if schedule.NextRun.AsTime().After(now) {
+			continue
+		}
+		due = append(due, proto.Clone(schedule).(*pb.Schedule))
+
+		if schedule.Cron == "" {
+			delete(s.schedules, id)
+			continue
+		}
+		next, err := nextCronRun(schedule.Cron, now)
+		if err != nil {
+			// Only valid expressions are stored, but a hand-edited file
+			// could hold anything
+			s.logger.WithError(err).WithField("schedule_id", id).Error("Dropping schedule with invalid cron expression")
+			delete(s.schedules, id)
+			continue
+		}
+		schedule.NextRun = timestamppb.New(next)
+	}
+
+	if len(due) == 0 {
+		return nil, nil
+	}
+	return due, s.rewrite()
+}
+
+// recordRun notes the outcome of a recurring schedule's latest run
+func (s *scheduleStore) recordRun(id string, ranAt time.Time, taskID, errMessage string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return nil
+	}
+	schedule.LastRun = timestamppb.New(ranAt)
+	schedule.LastTaskId = taskID
+	schedule.LastError = errMessage
+	return s.rewrite()
+}
+
+// nextCronRun returns the first time after now matching expr
+func nextCronRun(expr string, now time.Time) (time.Time, error) {
+	sched, err := cron.ParseStandard(expr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	return sched.Next(now), nil
+}
+
+// ScheduleDestruction validates req and stores it to run later on behalf of
+// the client in ctx. The request is checked now as well as when it runs, so
+// mistakes surface immediately.
+func (e *DestructionEngine) ScheduleDestruction(ctx context.Context, req *pb.ScheduleDestructionRequest) (*pb.Schedule, error) {
+	if req.Request == nil {
+		return nil, fmt.Errorf("%w: request is required", ErrInvalidSchedule)
+	}
+	if (req.Delay == nil) == (req.Cron == "") {
+		return nil, fmt.Errorf("%w: exactly one of delay and cron must be set", ErrInvalidSchedule)
+	}
+	if err := e.validateExecuteRequest(req.Request); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err.Error())
+	}
+	if _, err := destructorFor(req.Request.Type); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var next time.Time
+	if req.Delay != nil {
+		if err := req.Delay.CheckValid(); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err.Error())
+		}
+		delay := req.Delay.AsDuration()
+		if delay <= 0 {
+			return nil, fmt.Errorf("%w: delay must be positive", ErrInvalidSchedule)
+		}
+		next = now.Add(delay)
+	} else {
+		var err error
+		if next, err = nextCronRun(req.Cron, now); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err.Error())
+		}
+	}
+
+	schedule := &pb.Schedule{
+		ScheduleId: ids.Schedule(),
+		Request:    storedScheduleRequest(req.Request),
+		Cron:       req.Cron,
+		NextRun:    timestamppb.New(next),
+		CreatedAt:  timestamppb.New(now),
+		Client:     ClientIdentityFromContext(ctx),
+	}
+	if err := e.schedules.add(schedule); err != nil {
+		return nil, fmt.Errorf("failed to persist schedule: %w", err)
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"schedule_id": schedule.ScheduleId,
+		"type":        schedule.Request.Type.String(),
+		"next_run":    next.Format(time.RFC3339),
+		"cron":        schedule.Cron,
+	}).Warn("⏰ Destruction scheduled")
+
+	return proto.Clone(schedule).(*pb.Schedule), nil
+}
+
+// storedScheduleRequest copies req for storing. A window override token is
+// dropped rather than written to disk; scheduled runs always wait for an
+// allowed window.
+func storedScheduleRequest(req *pb.ExecuteDestructionRequest) *pb.ExecuteDestructionRequest {
+	stored := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	stored.WindowOverrideToken = ""
+	return stored
+}
+
+// ListSchedules returns the pending schedules, soonest first
+func (e *DestructionEngine) ListSchedules() []*pb.Schedule {
+	return e.schedules.list()
+}
+
+// DeleteSchedule removes a pending schedule, reporting whether it existed
+func (e *DestructionEngine) DeleteSchedule(id string) (bool, error) {
+	deleted, err := e.schedules.remove(id)
+	if err != nil {
+		return deleted, fmt.Errorf("failed to persist schedules: %w", err)
+	}
+	if deleted {
+		e.logger.WithField("schedule_id", id).Info("Schedule deleted")
+	}
+	return deleted, nil
+}
+
+// RunScheduler runs due schedules and automatic restores until ctx is
+// done, then waits for the runs it started. Runs in progress are cancelled
+// along with ctx.
+func (e *DestructionEngine) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	defer e.scheduleRuns.Wait()
+
+	for {
+		now := time.Now()
+		e.runDueSchedules(ctx, now)
+		e.runDueRestores(ctx, now)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDueSchedules starts every schedule due at now in its own goroutine, so
+// a long destruction doesn't hold up the others
+func (e *DestructionEngine) runDueSchedules(ctx context.Context, now time.Time) {
+	// Schedules may be created at any time but only fire inside
+	// security.allowed_windows, so due ones wait for the next window
+	if err := e.policy.CheckWindow(now); err != nil {
+		e.logger.WithError(err).Debug("Holding due schedules until the next window")
+		return
+	}
+
+	due, err := e.schedules.takeDue(now)
+	if err != nil {
+		e.logger.WithError(err).Warn("Failed to persist schedules")
+	}
+
+	for _, schedule := range due {
+		e.scheduleRuns.Add(1)
+		go func(schedule *pb.Schedule) {
+			defer e.scheduleRuns.Done()
+			e.runSchedule(ctx, schedule)
+		}(schedule)
+	}
+}
+
+// runSchedule executes a schedule's request through the normal execution
+// path, which validates it again and records it in task history
+func (e *DestructionEngine) runSchedule(ctx context.Context, schedule *pb.Schedule) {
+	logger := e.logger.WithFields(logrus.Fields{
+		"schedule_id": schedule.ScheduleId,
+		"type":        schedule.Request.Type.String(),
+	})
+	logger.Warn("⏰ Running scheduled destruction")
+
+	ranAt := time.Now()
+	resp, err := e.ExecuteDestruction(WithClientIdentity(ctx, schedule.Client), schedule.Request)
+
+	var taskID, errMessage string
+	switch {
+	case err != nil:
+		errMessage = err.Error()
+		logger.WithError(err).Error("Scheduled destruction failed")
+	case !resp.Success:
+		taskID, errMessage = resp.TaskId, resp.Message
+		logger.WithField("task_id", resp.TaskId).Error("Scheduled destruction failed")
+	default:
+		taskID = resp.TaskId
+		logger.WithField("task_id", resp.TaskId).Info("Scheduled destruction completed")
+	}
+
+	if err := e.schedules.recordRun(schedule.ScheduleId, ranAt, taskID, errMessage); err != nil {
+		logger.WithError(err).Warn("Failed to persist schedules")
+	}
+}
@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func newScheduleTestEngine(t *testing.T, dataDir string) *DestructionEngine {
+	t.Helper()
+	registerNoopDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION)
+
+	return NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:         "MEDIUM",
+			RequireConfirmation: true,
+		},
+		Storage: config.StorageConfig{DataDir: dataDir},
+	})
+}
+
+func scheduledRequest() *pb.ExecuteDestructionRequest {
+	return &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_NETWORK_DISRUPTION,
+		Targets:            []string{"eth0"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+}
+
+func TestScheduleDestructionValidation(t *testing.T) {
+	engine := newScheduleTestEngine(t, "")
+	ctx := context.Background()
+
+	unconfirmed := scheduledRequest()
+	unconfirmed.ConfirmDestruction = false
+
+	tests := []struct {
+		name string
+		req  *pb.ScheduleDestructionRequest
+	}{
+		{"missing request", &pb.ScheduleDestructionRequest{Cron: "0 2 * * *"}},
+		{"no timing", &pb.ScheduleDestructionRequest{Request: scheduledRequest()}},
+		{"delay and cron", &pb.ScheduleDestructionRequest{Request: scheduledRequest(), Delay: durationpb.New(time.Minute), Cron: "0 2 * * *"}},
+		{"negative delay", &pb.ScheduleDestructionRequest{Request: scheduledRequest(), Delay: durationpb.New(-time.Minute)}},
+		{"invalid cron", &pb.ScheduleDestructionRequest{Request: scheduledRequest(), Cron: "at two"}},
+		{"unconfirmed", &pb.ScheduleDestructionRequest{Request: unconfirmed, Delay: durationpb.New(time.Minute)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := engine.ScheduleDestruction(ctx, tt.req); !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("Expected ErrInvalidSchedule, got: %v", err)
+			}
+		})
+	}
+
+	unsupported := scheduledRequest()
+	unsupported.Type = pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION
+	_, err := engine.ScheduleDestruction(ctx, &pb.ScheduleDestructionRequest{Request: unsupported, Delay: durationpb.New(time.Minute)})
+	if !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("Expected ErrNotImplemented, got: %v", err)
+	}
+
+	if schedules := engine.ListSchedules(); len(schedules) != 0 {
+		t.Errorf("Expected rejected requests not to be stored, got %v", schedules)
+	}
+}
+
+func TestSchedulesPersistAcrossRestarts(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_schedule_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	engine := newScheduleTestEngine(t, dataDir)
+	ctx := WithClientIdentity(context.Background(), "cn:tester")
+
+	later, err := engine.ScheduleDestruction(ctx, &pb.ScheduleDestructionRequest{Request: scheduledRequest(), Delay: durationpb.New(time.Hour)})
+	if err != nil {
+		t.Fatalf("Failed to schedule: %v", err)
+	}
+	nightly, err := engine.ScheduleDestruction(ctx, &pb.ScheduleDestructionRequest{Request: scheduledRequest(), Cron: "0 2 * * *"})
+	if err != nil {
+		t.Fatalf("Failed to schedule: %v", err)
+	}
+	if later.Client != "cn:tester" {
+		t.Errorf("Expected the schedule to remember its client, got %q", later.Client)
+	}
+
+	reloaded := newScheduleTestEngine(t, dataDir)
+	schedules := reloaded.ListSchedules()
+	if len(schedules) != 2 {
+		t.Fatalf("Expected both schedules after reload, got %v", schedules)
+	}
+
+	deleted, err := reloaded.DeleteSchedule(later.ScheduleId)
+	if err != nil || !deleted {
+		t.Fatalf("Expected the schedule to be deleted, got %v, %v", deleted, err)
+	}
+	if deleted, _ := reloaded.DeleteSchedule(later.ScheduleId); deleted {
+		t.Error("Expected deleting twice to report nothing deleted")
+	}
+
+	schedules = newScheduleTestEngine(t, dataDir).ListSchedules()
+	if len(schedules) != 1 || schedules[0].ScheduleId != nightly.ScheduleId {
+		t.Errorf("Expected only the cron schedule to remain, got %v", schedules)
+	}
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	engine := newScheduleTestEngine(t, "")
+	ctx := context.Background()
+
+	once, err := engine.ScheduleDestruction(ctx, &pb.ScheduleDestructionRequest{Request: scheduledRequest(), Delay: durationpb.New(time.Minute)})
+	if err != nil {
+		t.Fatalf("Failed to schedule: %v", err)
+	}
+	hourly, err := engine.ScheduleDestruction(ctx, &pb.ScheduleDestructionRequest{Request: scheduledRequest(), Cron: "@hourly"})
+	if err != nil {
+		t.Fatalf("Failed to schedule: %v", err)
+	}
+
+	// Nothing is due yet
+	engine.runDueSchedules(ctx, time.Now())
+	engine.scheduleRuns.Wait()
+	if history, _ := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{}); history.Total != 0 {
+		t.Fatalf("Expected nothing to run yet, got %d tasks", history.Total)
+	}
+
+	now := time.Now().Add(2 * time.Hour)
+	engine.runDueSchedules(ctx, now)
+	engine.scheduleRuns.Wait()
+
+	history, err := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("GetTaskHistory failed: %v", err)
+	}
+	if history.Total != 2 {
+		t.Errorf("Expected both schedules to run and be recorded in history, got %d tasks", history.Total)
+	}
+
+	schedules := engine.ListSchedules()
+	if len(schedules) != 1 || schedules[0].ScheduleId != hourly.ScheduleId {
+		t.Fatalf("Expected the one-off schedule %s to be removed, got %v", once.ScheduleId, schedules)
+	}
+	if !schedules[0].NextRun.AsTime().After(now) {
+		t.Errorf("Expected the cron schedule to move past %v, got %v", now, schedules[0].NextRun.AsTime())
+	}
+	if schedules[0].LastTaskId == "" || schedules[0].LastError != "" {
+		t.Errorf("Expected the cron schedule to record a successful run, got %v", schedules[0])
+	}
+}
+
+func TestSchedulesWaitForWindow(t *testing.T) {
+	engine := newScheduleTestEngine(t, "")
+	engine.config.Security.WindowOverrideToken = "break-glass"
+	ctx := context.Background()
+
+	// The schedule is due by then, but the only window opens an hour
+	// later
+	later := time.Now().Add(2 * time.Hour).UTC()
+	window := func(from, to time.Duration) []config.TimeWindow {
+		return []config.TimeWindow{{Start: later.Add(from).Format("15:04"), End: later.Add(to).Format("15:04"), Timezone: "UTC"}}
+	}
+	engine.config.Security.AllowedWindows = window(time.Hour, 2*time.Hour)
+
+	req := scheduledRequest()
+	req.WindowOverrideToken = "break-glass"
+	schedule, err := engine.ScheduleDestruction(ctx, &pb.ScheduleDestructionRequest{Request: req, Delay: durationpb.New(time.Minute)})
+	if err != nil {
+		t.Fatalf("Expected scheduling outside the window to be allowed, got: %v", err)
+	}
+	if schedule.Request.WindowOverrideToken != "" {
+		t.Error("Expected the override token not to be stored with the schedule")
+	}
+
+	engine.runDueSchedules(ctx, later)
+	engine.scheduleRuns.Wait()
+	if len(engine.ListSchedules()) != 1 {
+		t.Fatal("Expected the due schedule to wait for the window")
+	}
+	if history, _ := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{}); history.Total != 0 {
+		t.Fatalf("Expected nothing to run outside the window, got %d tasks", history.Total)
+	}
+
+	engine.config.Security.AllowedWindows = window(-time.Hour, time.Hour)
+	engine.runDueSchedules(ctx, later)
+	engine.scheduleRuns.Wait()
+	if len(engine.ListSchedules()) != 0 {
+		t.Error("Expected the schedule to run once the window opens")
+	}
+	if history, _ := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{}); history.Total != 1 {
+		t.Errorf("Expected the schedule to be recorded in history, got %d tasks", history.Total)
+	}
+}
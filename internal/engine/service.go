@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// criticalServices keep the host reachable and manageable. They are refused
+// unless allow_critical_services is set. Patterns use path.Match syntax and
+// are matched without the ".service" suffix.
+var criticalServices = []string{
+	"sshd",
+	"ssh",
+	"systemd-*",
+	"dbus",
+	"dbus-broker",
+	"polkit",
+	"NetworkManager",
+	"networking",
+	"getty@*",
+	"burndevice",
+}
+
+// serviceName strips the unit suffix so "nginx" and "nginx.service" match
+// the same patterns
+func serviceName(unit string) string {
+	return strings.TrimSuffix(unit, ".service")
+}
+
+// matchService reports whether service matches pattern
+func matchService(pattern, service string) bool {
+	matched, err := path.Match(serviceName(pattern), serviceName(service))
+	return err == nil && matched
+}
+
+// serviceProtection returns why service may not be stopped, or "" when it
+// may. The configured protected list always applies; the built-in critical
+// list can be lifted with allow_critical_services.
+func (e *DestructionEngine) serviceProtection(service string) string {
+	if service == "" || strings.HasPrefix(service, "-") {
+		return fmt.Sprintf("invalid service name: %q", service)
+	}
+
+	for _, pattern := range e.config.Security.ProtectedServices {
+		if matchService(pattern, service) {
+			return fmt.Sprintf("service %s is protected", service)
+		}
+	}
+
+	if !e.config.Security.AllowCriticalServices {
+		for _, pattern := range criticalServices {
+			if matchService(pattern, service) {
+				return fmt.Sprintf("service %s is critical to the host; set allow_critical_services to stop it", service)
+			}
+		}
+	}
+
+	return ""
+}
+
+// executeServiceTermination stops each target service with systemctl,
+// recording the state it was in so it can be restarted later. In safe mode
+// nothing is stopped and the results describe what would have happened.
+func (e *DestructionEngine) executeServiceTermination(task *DestructionTask) ([]*pb.DestructionResult, error) {
+	if runtime.GOOS != "linux" {
+		return nil, fmt.Errorf("service termination is only supported on Linux, not %s", runtime.GOOS)
+	}
+
+	var results []*pb.DestructionResult
+	for i, service := range task.Targets {
+		if err := task.Context.Err(); err != nil {
+			return results, fmt.Errorf("service termination cancelled: %w", err)
+		}
+		task.ReportProgress(float64(i)/float64(len(task.Targets)), service, fmt.Sprintf("Stopping service %s", service))
+
+		result := e.stopService(task, service)
+		results = append(results, result)
+		e.targetProcessed(task, result)
+	}
+
+	return results, nil
+}
+
+// stopService stops a single service, refusing protected ones
+func (e *DestructionEngine) stopService(task *DestructionTask, service string) *pb.DestructionResult {
+	result := &pb.DestructionResult{
+		Target:  service,
+		Metrics: &pb.DestructionMetrics{},
+	}
+	start := time.Now()
+	defer finishTiming(result, start)
+
+	if reason := e.serviceProtection(service); reason != "" {
+		result.ErrorMessage = reason
+		return result
+	}
+
+	// is-active exits non-zero for anything but "active" while still
+	// printing the state, so only the output matters here
+	stdout, _, _ := e.runner.Run(task.Context, "systemctl", "is-active", service)
+	result.PreviousState = strings.TrimSpace(string(stdout))
+	if result.PreviousState == "" {
+		result.PreviousState = "unknown"
+	}
+
+	switch result.PreviousState {
+	case "active", "activating", "reloading":
+	default:
+		result.Success = true
+		result.Action = fmt.Sprintf("service %s was already %s", service, result.PreviousState)
+		return result
+	}
+
+	if e.config.Security.EnableSafeMode {
+		result.Success = true
+		result.Action = fmt.Sprintf("would stop service %s (safe mode is enabled; nothing was stopped)", service)
+		return result
+	}
+
+	if err := e.runCommand(task.Context, result, "systemctl", "stop", service); err != nil {
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	result.Success = true
+	result.Action = fmt.Sprintf("stopped service %s", service)
+	e.logger.WithFields(logrus.Fields{
+		"task_id":        task.ID,
+		"service":        service,
+		"previous_state": result.PreviousState,
+	}).Warn("🔥 Service stopped")
+
+	return result
+}
@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// systemctlRunner fakes systemctl for a fixed set of service states
+type systemctlRunner struct {
+	states  map[string]string
+	stopErr error
+	stopped []string
+	started []string
+}
+
+func (r *systemctlRunner) Run(ctx context.Context, name string, args ...string) ([]byte, []byte, error) {
+	if name != "systemctl" || len(args) != 2 {
+		return nil, nil, errors.New("unexpected command")
+	}
+
+	switch args[0] {
+	case "is-active":
+		state, ok := r.states[serviceName(args[1])]
+		if !ok {
+			return []byte("inactive\n"), nil, errors.New("exit status 3")
+		}
+		return []byte(state + "\n"), nil, nil
+	case "stop":
+		if r.stopErr != nil {
+			return nil, []byte("Access denied\n"), r.stopErr
+		}
+		r.stopped = append(r.stopped, args[1])
+		return nil, nil, nil
+	case "start":
+		r.started = append(r.started, args[1])
+		return nil, nil, nil
+	}
+	return nil, nil, errors.New("unexpected command")
+}
+
+func newServiceEngine(security config.SecurityConfig) (*DestructionEngine, *systemctlRunner) {
+	security.MaxSeverity = "HIGH"
+	engine := NewDestructionEngine(&config.Config{Security: security})
+	runner := &systemctlRunner{states: map[string]string{
+		"nginx": "active",
+		"sshd":  "active",
+		"redis": "active",
+		"cron":  "failed",
+	}}
+	engine.runner = runner
+	fakeHost(engine, true, "systemctl")
+	return engine, runner
+}
+
+func executeServiceTermination(t *testing.T, engine *DestructionEngine, services ...string) *pb.ExecuteDestructionResponse {
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
+		Targets:            services,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(resp.Results) != len(services) {
+		t.Fatalf("Expected %d results, got %d", len(services), len(resp.Results))
+	}
+	return resp
+}
+
+func TestServiceTermination(t *testing.T) {
+	engine, runner := newServiceEngine(config.SecurityConfig{})
+
+	resp := executeServiceTermination(t, engine, "nginx.service", "cron", "sshd")
+
+	stopped := resp.Results[0]
+	if !stopped.Success || stopped.PreviousState != "active" {
+		t.Errorf("Expected nginx to be stopped from active, got %+v", stopped)
+	}
+	if len(runner.stopped) != 1 || runner.stopped[0] != "nginx.service" {
+		t.Errorf("Expected only nginx to be stopped, got %v", runner.stopped)
+	}
+
+	// Already stopped services are left alone
+	if idle := resp.Results[1]; !idle.Success || idle.PreviousState != "failed" || !strings.Contains(idle.Action, "already") {
+		t.Errorf("Expected the failed service to be left alone, got %+v", idle)
+	}
+
+	// Critical services are refused without the override
+	if critical := resp.Results[2]; critical.Success || !strings.Contains(critical.ErrorMessage, "allow_critical_services") {
+		t.Errorf("Expected sshd to be refused, got %+v", critical)
+	}
+}
+
+func TestServiceTerminationProtection(t *testing.T) {
+	engine, runner := newServiceEngine(config.SecurityConfig{
+		ProtectedServices:     []string{"red*"},
+		AllowCriticalServices: true,
+	})
+
+	resp := executeServiceTermination(t, engine, "sshd", "redis", "--force")
+
+	if !resp.Results[0].Success {
+		t.Errorf("Expected the override to allow sshd, got: %s", resp.Results[0].ErrorMessage)
+	}
+	if resp.Results[1].Success || !strings.Contains(resp.Results[1].ErrorMessage, "protected") {
+		t.Errorf("Expected the configured list to apply despite the override, got %+v", resp.Results[1])
+	}
+	if resp.Results[2].Success || !strings.Contains(resp.Results[2].ErrorMessage, "invalid service name") {
+		t.Errorf("Expected option-like names to be refused, got %+v", resp.Results[2])
+	}
+	if len(runner.stopped) != 1 || runner.stopped[0] != "sshd" {
+		t.Errorf("Expected only sshd to be stopped, got %v", runner.stopped)
+	}
+}
+
+func TestServiceTerminationSafeMode(t *testing.T) {
+	engine, runner := newServiceEngine(config.SecurityConfig{EnableSafeMode: true})
+
+	resp := executeServiceTermination(t, engine, "nginx")
+
+	result := resp.Results[0]
+	if !result.Success || result.PreviousState != "active" || !strings.Contains(result.Action, "would stop") {
+		t.Errorf("Expected a simulated stop, got %+v", result)
+	}
+	if len(runner.stopped) != 0 {
+		t.Errorf("Expected nothing to be stopped in safe mode, got %v", runner.stopped)
+	}
+}
+
+func TestServiceTerminationStopFailure(t *testing.T) {
+	engine, runner := newServiceEngine(config.SecurityConfig{})
+	runner.stopErr = errors.New("exit status 1")
+
+	resp := executeServiceTermination(t, engine, "nginx")
+
+	result := resp.Results[0]
+	if result.Success || !strings.Contains(result.Stderr, "Access denied") {
+		t.Errorf("Expected the failure and its stderr, got %+v", result)
+	}
+}
@@ -0,0 +1,326 @@
+package engine
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+)
+
+const (
+	// defaultShredPasses is used when no pass count is configured
+	defaultShredPasses = 3
+	// shredBufferSize is the size of each random write, so large files are
+	// never held in memory
+	shredBufferSize = 1 << 20
+)
+
+// shredDowngradeMessage explains why a deletion without backup fell back to
+// keeping one
+const shredDowngradeMessage = "deletion without backup is disabled while safe mode is enabled; keeping a backup"
+
+// shredPasses returns the number of overwrite passes per file
+func (e *DestructionEngine) shredPasses() int {
+	if e.config.Security.ShredPasses > 0 {
+		return e.config.Security.ShredPasses
+	}
+	return defaultShredPasses
+}
+
+// wipePasses returns the number of overwrite passes per file for requests
+// asking for a wipe
+func (e *DestructionEngine) wipePasses() int {
+	if e.config.Security.WipePasses > 0 {
+		return e.config.Security.WipePasses
+	}
+	return e.shredPasses()
+}
+
+// defaultDeletionBehavior is used for severities missing from
+// deletion_behaviors. Each level goes one step further: LOW deletes single
+// files with a backup, MEDIUM also takes directories, HIGH skips the backup
+// and CRITICAL overwrites before deleting.
+func (e *DestructionEngine) defaultDeletionBehavior(severity pb.DestructionSeverity) config.DeletionBehavior {
+	switch {
+	case severity >= pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL:
+		return config.DeletionBehavior{Backup: false, WipePasses: e.shredPasses()}
+	case severity == pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH:
+		return config.DeletionBehavior{Backup: false}
+	case severity == pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM:
+		return config.DeletionBehavior{Backup: true}
+	default:
+		return config.DeletionBehavior{Backup: true, FilesOnly: true}
+	}
+}
+
+// describeDeletion summarises how file deletion treats targets at severity,
+// e.g. "LOW: single files only, backup kept"
+func (e *DestructionEngine) describeDeletion(severity pb.DestructionSeverity, wipe bool) string {
+	behavior, downgraded := e.requestDeletionBehavior(severity, wipe)
+
+	parts := []string{"files and directories"}
+	if behavior.FilesOnly {
+		parts[0] = "single files only"
+	}
+	if behavior.WipePasses > 0 {
+		parts = append(parts, fmt.Sprintf("overwritten %d times before deletion", behavior.WipePasses))
+	}
+	switch {
+	case downgraded:
+		parts = append(parts, "backup kept because safe mode is enabled")
+	case behavior.Backup:
+		parts = append(parts, "backup kept")
+	default:
+		parts = append(parts, "no backup")
+	}
+
+	return fmt.Sprintf("%s: %s", policy.SeverityName(severity), strings.Join(parts, ", "))
+}
+
+// checkFilesOnly rejects directory targets when behavior only deletes
+// single files
+func checkFilesOnly(behavior config.DeletionBehavior, severity pb.DestructionSeverity, info os.FileInfo) error {
+	if behavior.FilesOnly && info.IsDir() {
+		return fmt.Errorf("%s severity deletes single files only; use a higher severity for directories", policy.SeverityName(severity))
+	}
+	return nil
+}
+
+// deletionBehavior returns how file deletion treats targets at severity.
+// Skipping the backup is gated twice: the request has already passed the
+// max-severity check, and safe mode forces a backup regardless of the
+// mapping, in which case downgraded is true.
+func (e *DestructionEngine) deletionBehavior(severity pb.DestructionSeverity) (behavior config.DeletionBehavior, downgraded bool) {
+	behavior, ok := e.config.Security.DeletionBehaviorFor(policy.SeverityName(severity))
+	if !ok {
+		behavior = e.defaultDeletionBehavior(severity)
+	}
+
+	if !behavior.Backup && e.config.Security.EnableSafeMode {
+		behavior.Backup = true
+		downgraded = true
+	}
+	return behavior, downgraded
+}
+
+// requestDeletionBehavior is deletionBehavior for a request that may ask
+// for a wipe. A wipe overwrites files wipe_passes times when the severity
+// alone would not overwrite them at all.
+func (e *DestructionEngine) requestDeletionBehavior(severity pb.DestructionSeverity, wipe bool) (config.DeletionBehavior, bool) {
+	behavior, downgraded := e.deletionBehavior(severity)
+	if wipe && behavior.WipePasses == 0 {
+		behavior.WipePasses = e.wipePasses()
+	}
+	return behavior, downgraded
+}
+
+// deleteTarget removes result's target according to the deletion behavior
+// for the task's severity, or moves it into quarantine when the task asks
+// for it, pacing each file by the task's throttle and filling in result's
+// metrics and attempts. Files are overwritten first when the severity or
+// the task asks for a wipe. warn is called when a deletion without backup is
+// downgraded. It returns where the target was backed up, or "" when no
+// backup was taken.
+func (e *DestructionEngine) deleteTarget(task *DestructionTask, result *pb.DestructionResult, onFile fileDeletedFunc, warn func(message string)) (string, error) {
+	target, metrics := result.Target, result.Metrics
+
+	behavior, downgraded := e.requestDeletionBehavior(task.Severity, task.Wipe)
+
+	if downgraded {
+		e.logger.WithFields(logrus.Fields{
+			"task_id": task.ID,
+			"target":  target,
+		}).Warn(shredDowngradeMessage)
+		if warn != nil {
+			warn(shredDowngradeMessage)
+		}
+	}
+
+	if behavior.FilesOnly {
+		info, err := os.Lstat(target)
+		if err != nil {
+			return "", fmt.Errorf("failed to stat file: %w", err)
+		}
+		if err := checkFilesOnly(behavior, task.Severity, info); err != nil {
+			return "", err
+		}
+	}
+
+	onFile = e.throttled(task, metrics, onFile)
+
+	// Quarantined files stay recoverable, so they are neither backed up
+	// nor wiped
+	if task.Quarantine {
+		return "", e.quarantineDeletion(task.Context, target, metrics, onFile)
+	}
+
+	metrics.WipePasses = int64(behavior.WipePasses)
+
+	if !behavior.Backup {
+		return "", e.shredDeletion(task.Context, target, behavior.WipePasses, metrics, onFile)
+	}
+
+	// Chosen once, so a retry replaces its own backup rather than an
+	// earlier task's
+	backupPath := e.newBackupPath(target, task.ID)
+	attempts, err := e.retryDeletion(task.Context, target, behavior.WipePasses, func() error {
+		return e.safeDeletion(task.Context, target, backupPath, behavior.WipePasses, metrics, onFile)
+	})
+	result.Attempts = attempts
+	return backupPath, err
+}
+
+// shredDeletion overwrites every file under target with random data passes
+// times and unlinks it without taking a backup. Symlinks are removed, never
+// followed.
+func (e *DestructionEngine) shredDeletion(ctx context.Context, target string, passes int, metrics *pb.DestructionMetrics, onFile fileDeletedFunc) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	info, err := os.Lstat(target)
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	if !info.IsDir() {
+		if err := e.shredEntry(ctx, target, info, passes, metrics); err != nil {
+			return err
+		}
+		if onFile != nil {
+			onFile(target, metrics.FilesDeleted)
+		}
+		return nil
+	}
+
+	err = filepath.WalkDir(target, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		entryInfo, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if err := e.shredEntry(ctx, path, entryInfo, passes, metrics); err != nil {
+			return err
+		}
+		if onFile != nil {
+			onFile(path, metrics.FilesDeleted)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("directory shred interrupted after %d files: %w", metrics.FilesDeleted, err)
+	}
+
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove directory: %w", err)
+	}
+
+	e.logger.WithFields(logrus.Fields{
+		"target": target,
+		"files":  metrics.FilesDeleted,
+	}).Warn("🔥 Secure shred completed")
+
+	return nil
+}
+
+// shredEntry overwrites a regular file and unlinks it. Other entries are
+// unlinked without being opened.
+func (e *DestructionEngine) shredEntry(ctx context.Context, path string, info fs.FileInfo, passes int, metrics *pb.DestructionMetrics) error {
+	if info.Mode().IsRegular() {
+		if err := e.wipeEntry(ctx, path, info, passes, metrics); err != nil {
+			return err
+		}
+		metrics.BytesDestroyed += info.Size()
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	metrics.FilesDeleted++
+
+	return nil
+}
+
+// wipeEntry overwrites a regular file passes times in place. Other entries
+// and zero passes are left alone.
+func (e *DestructionEngine) wipeEntry(ctx context.Context, path string, info fs.FileInfo, passes int, metrics *pb.DestructionMetrics) error {
+	if passes <= 0 || !info.Mode().IsRegular() {
+		return nil
+	}
+
+	overwritten, err := e.overwriteFile(ctx, path, info.Size(), passes)
+	metrics.BytesOverwritten += overwritten
+	if err != nil {
+		return fmt.Errorf("failed to overwrite %s: %w", path, err)
+	}
+	return nil
+}
+
+// overwriteFile writes size bytes of random data over path once per pass,
+// syncing after each pass, and returns the total bytes written
+func (e *DestructionEngine) overwriteFile(ctx context.Context, path string, size int64, passes int) (int64, error) {
+	// #nosec G304 - Target has passed engine validation
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			e.logger.WithError(err).Warn("Failed to close shredded file")
+		}
+	}()
+
+	buf := make([]byte, shredBufferSize)
+	var written int64
+
+	for pass := 0; pass < passes; pass++ {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return written, err
+		}
+
+		for remaining := size; remaining > 0; {
+			if err := ctx.Err(); err != nil {
+				return written, err
+			}
+
+			chunk := buf
+			if remaining < int64(len(chunk)) {
+				chunk = chunk[:remaining]
+			}
+			if _, err := rand.Read(chunk); err != nil {
+				return written, fmt.Errorf("failed to generate random data: %w", err)
+			}
+			n, err := file.Write(chunk)
+			written += int64(n)
+			if err != nil {
+				return written, err
+			}
+			remaining -= int64(n)
+		}
+
+		if err := file.Sync(); err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
@@ -0,0 +1,360 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestShredDeletion(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, shredBufferSize+100))})
+	testFile := filepath.Join(tempDir, "secret.bin")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "CRITICAL",
+			EnableSafeMode: false,
+			ShredPasses:    2,
+		},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	result := resp.Results[0]
+	if !result.Success {
+		t.Fatalf("Expected shred to succeed, got: %s", result.ErrorMessage)
+	}
+	if _, err := os.Stat(testFile); !os.IsNotExist(err) {
+		t.Error("Expected file to be removed")
+	}
+	if _, err := os.Stat(engine.backupPathFor(testFile)); !os.IsNotExist(err) {
+		t.Error("Expected no backup for shredded file")
+	}
+	if result.BackupPath != "" {
+		t.Errorf("Expected no backup path, got %s", result.BackupPath)
+	}
+
+	size := int64(shredBufferSize + 100)
+	if result.Metrics.BytesOverwritten != size*2 {
+		t.Errorf("Expected %d bytes overwritten, got %d", size*2, result.Metrics.BytesOverwritten)
+	}
+	if result.Metrics.BytesDestroyed != size {
+		t.Errorf("Expected %d bytes destroyed, got %d", size, result.Metrics.BytesDestroyed)
+	}
+}
+
+func TestShredDowngradedInSafeMode(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 128))})
+	testFile := filepath.Join(tempDir, "secret.bin")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "CRITICAL",
+			EnableSafeMode: true,
+		},
+	})
+
+	task := &DestructionTask{
+		ID:       "task_1",
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL,
+		Context:  context.Background(),
+	}
+	var warnings []string
+	metrics := &pb.DestructionMetrics{}
+	result := &pb.DestructionResult{Target: testFile, Metrics: metrics}
+
+	backupPath, err := engine.deleteTarget(task, result, nil, func(message string) {
+		warnings = append(warnings, message)
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if backupPath != engine.backupPathFor(testFile) {
+		t.Errorf("Expected safe deletion with backup at %s, got %q", engine.backupPathFor(testFile), backupPath)
+	}
+	if _, err := os.Stat(backupPath); err != nil {
+		t.Errorf("Expected backup to exist: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "safe mode") {
+		t.Errorf("Expected a safe mode downgrade warning, got %v", warnings)
+	}
+	// The wipe still happens; only the missing backup is downgraded
+	if metrics.BytesOverwritten != 128*defaultShredPasses {
+		t.Errorf("Expected %d bytes overwritten, got %d", 128*defaultShredPasses, metrics.BytesOverwritten)
+	}
+}
+
+func TestDefaultDeletionBehavior(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{ShredPasses: 5},
+	})
+
+	tests := []struct {
+		severity pb.DestructionSeverity
+		expected config.DeletionBehavior
+	}{
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, config.DeletionBehavior{Backup: true, FilesOnly: true}},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, config.DeletionBehavior{Backup: true}},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH, config.DeletionBehavior{Backup: false}},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, config.DeletionBehavior{Backup: false, WipePasses: 5}},
+	}
+
+	for _, tt := range tests {
+		behavior, downgraded := engine.deletionBehavior(tt.severity)
+		if behavior != tt.expected {
+			t.Errorf("%s: expected %+v, got %+v", tt.severity, tt.expected, behavior)
+		}
+		if downgraded {
+			t.Errorf("%s: expected no downgrade with safe mode off", tt.severity)
+		}
+	}
+
+	engine.config.Security.EnableSafeMode = true
+	behavior, downgraded := engine.deletionBehavior(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL)
+	if !behavior.Backup || !downgraded {
+		t.Errorf("Expected safe mode to force a backup for CRITICAL, got %+v (downgraded %v)", behavior, downgraded)
+	}
+}
+
+func TestDirectoryDeletionNeedsMediumSeverity(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 64))})
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "MEDIUM",
+			EnableSafeMode: false,
+		},
+	})
+
+	execute := func(severity pb.DestructionSeverity) *pb.ExecuteDestructionResponse {
+		resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{tempDir},
+			Severity:           severity,
+			ConfirmDestruction: true,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", severity, err)
+		}
+		return resp
+	}
+
+	low := execute(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW)
+	if low.Results[0].Success || !strings.Contains(low.Results[0].ErrorMessage, "single files only") {
+		t.Errorf("Expected LOW to reject a directory, got: %+v", low.Results[0])
+	}
+	if _, err := os.Stat(tempDir); err != nil {
+		t.Fatalf("Expected the directory to survive LOW, got: %v", err)
+	}
+	if !strings.Contains(low.Message, "LOW: single files only, backup kept") {
+		t.Errorf("Expected the message to describe the LOW mapping, got %q", low.Message)
+	}
+
+	medium := execute(pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM)
+	if !medium.Results[0].Success {
+		t.Fatalf("Expected MEDIUM to delete a directory, got: %s", medium.Results[0].ErrorMessage)
+	}
+	if medium.Results[0].BackupPath == "" {
+		t.Error("Expected MEDIUM to keep a backup")
+	}
+	if _, err := os.Stat(tempDir); !os.IsNotExist(err) {
+		t.Error("Expected the directory to be removed")
+	}
+	if !strings.Contains(medium.Message, "MEDIUM: files and directories, backup kept") {
+		t.Errorf("Expected the message to describe the MEDIUM mapping, got %q", medium.Message)
+	}
+}
+
+func TestConfiguredDeletionBehaviors(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "CRITICAL",
+			EnableSafeMode: false,
+			DeletionBehaviors: map[string]config.DeletionBehavior{
+				// viper lowercases keys, so lookups must ignore case
+				"low":      {Backup: true, WipePasses: 2},
+				"MEDIUM":   {Backup: false, WipePasses: 0},
+				"high":     {Backup: true, WipePasses: 0},
+				"Critical": {Backup: false, WipePasses: 1},
+			},
+		},
+	})
+
+	tests := []struct {
+		severity    pb.DestructionSeverity
+		backup      bool
+		overwritten int64
+	}{
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, true, 2 * 256},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM, false, 0},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH, true, 0},
+		{pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL, false, 256},
+	}
+
+	for _, tt := range tests {
+		tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 256))})
+		testFile := filepath.Join(tempDir, "secret.bin")
+
+		resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{testFile},
+			Severity:           tt.severity,
+			ConfirmDestruction: true,
+		})
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", tt.severity, err)
+		}
+
+		result := resp.Results[0]
+		if !result.Success {
+			t.Fatalf("%s: expected deletion to succeed, got: %s", tt.severity, result.ErrorMessage)
+		}
+		if _, err := os.Stat(testFile); !os.IsNotExist(err) {
+			t.Errorf("%s: expected file to be removed", tt.severity)
+		}
+
+		_, statErr := os.Stat(engine.backupPathFor(testFile))
+		if tt.backup && (statErr != nil || result.BackupPath == "") {
+			t.Errorf("%s: expected a backup, got path %q (%v)", tt.severity, result.BackupPath, statErr)
+		}
+		if !tt.backup && (statErr == nil || result.BackupPath != "") {
+			t.Errorf("%s: expected no backup, got path %q", tt.severity, result.BackupPath)
+		}
+		if result.Metrics.BytesOverwritten != tt.overwritten {
+			t.Errorf("%s: expected %d bytes overwritten, got %d", tt.severity, tt.overwritten, result.Metrics.BytesOverwritten)
+		}
+	}
+}
+
+func TestWipedBackupMatchesOriginal(t *testing.T) {
+	original := []byte("recoverable content")
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(original)})
+	testFile := filepath.Join(tempDir, "secret.bin")
+
+	engine := NewDestructionEngine(&config.Config{})
+	metrics := &pb.DestructionMetrics{}
+	if err := engine.safeDeletion(context.Background(), testFile, engine.backupPathFor(testFile), 1, metrics, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// The backup is taken before the original is overwritten
+	backup, err := os.ReadFile(engine.backupPathFor(testFile))
+	if err != nil {
+		t.Fatalf("Failed to read backup: %v", err)
+	}
+	if !bytes.Equal(backup, original) {
+		t.Errorf("Expected backup to hold the original content, got %q", backup)
+	}
+	if metrics.BytesOverwritten != int64(len(original)) {
+		t.Errorf("Expected %d bytes overwritten, got %d", len(original), metrics.BytesOverwritten)
+	}
+}
+
+func TestOverwriteFile(t *testing.T) {
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(make([]byte, 4096))})
+	testFile := filepath.Join(tempDir, "secret.bin")
+
+	engine := NewDestructionEngine(&config.Config{})
+	written, err := engine.overwriteFile(context.Background(), testFile, 4096, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if written != 3*4096 {
+		t.Errorf("Expected %d bytes written, got %d", 3*4096, written)
+	}
+
+	content, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if len(content) != 4096 {
+		t.Errorf("Expected file size to be unchanged, got %d", len(content))
+	}
+	if bytes.Equal(content, make([]byte, 4096)) {
+		t.Error("Expected file content to be overwritten")
+	}
+
+	// Cancellation stops the overwrite
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := engine.overwriteFile(ctx, testFile, 4096, 1); err == nil {
+		t.Error("Expected error when context is cancelled")
+	}
+}
+
+func TestWipeRequest(t *testing.T) {
+	original := bytes.Repeat([]byte("wipe me "), 512)
+	tempDir := newTestTree(t, map[string]string{"secret.bin": string(original)})
+	testFile := filepath.Join(tempDir, "secret.bin")
+
+	// A hard link shares the file's data, so it shows what was on disk
+	// when the file was removed
+	witness := filepath.Join(tempDir, "witness.bin")
+	if err := os.Link(testFile, witness); err != nil {
+		t.Skipf("Hard links not supported: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{tempDir},
+			WipePasses:     2,
+		},
+	})
+
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		Wipe:               true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	result := resp.Results[0]
+	if !result.Success {
+		t.Fatalf("Expected deletion to succeed, got: %s", result.ErrorMessage)
+	}
+	if !strings.Contains(resp.Message, "overwritten 2 times") {
+		t.Errorf("Expected message to mention the wipe, got: %s", resp.Message)
+	}
+
+	wiped, err := os.ReadFile(witness)
+	if err != nil {
+		t.Fatalf("Failed to read witness: %v", err)
+	}
+	if len(wiped) != len(original) || bytes.Equal(wiped, original) {
+		t.Error("Expected the file's bytes to be overwritten before removal")
+	}
+	if result.Metrics.WipePasses != 2 {
+		t.Errorf("Expected 2 wipe passes, got %d", result.Metrics.WipePasses)
+	}
+	if result.Metrics.BytesOverwritten != 2*int64(len(original)) {
+		t.Errorf("Expected %d bytes overwritten, got %d", 2*len(original), result.Metrics.BytesOverwritten)
+	}
+
+	// LOW keeps a backup, taken before the wipe, so restore still works
+	backup, err := os.ReadFile(result.BackupPath)
+	if err != nil {
+		t.Fatalf("Failed to read backup: %v", err)
+	}
+	if !bytes.Equal(backup, original) {
+		t.Error("Expected backup to hold the original content")
+	}
+}
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrShuttingDown is returned for tasks started after Shutdown began
+var ErrShuttingDown = errors.New("server is shutting down")
+
+const (
+	// shutdownPollInterval is how often Shutdown checks whether the
+	// running tasks have finished
+	shutdownPollInterval = 50 * time.Millisecond
+	// shutdownCancelGrace is how long Shutdown waits for cancelled tasks
+	// to reach their next cancellation check
+	shutdownCancelGrace = 10 * time.Second
+)
+
+// Shutdown stops new tasks from starting and waits for the running ones to
+// finish, so none is cut off halfway through a directory or left holding
+// memory, files or firewall rules. Tasks still running when ctx is done
+// are cancelled as by CancelDestruction and given a short grace to stop.
+// It returns an error naming the tasks that didn't.
+func (e *DestructionEngine) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	e.draining = true
+	running := e.runningIDs()
+	e.mu.Unlock()
+
+	if len(running) == 0 {
+		return nil
+	}
+	for _, id := range running {
+		e.logger.WithField("task_id", id).Warn("⏳ Waiting for task to finish before shutdown")
+	}
+
+	if e.waitForTasks(ctx) {
+		e.logger.WithField("tasks", len(running)).Info("All tasks finished before shutdown")
+		return nil
+	}
+
+	e.cancelRunning()
+
+	graceCtx, cancel := context.WithTimeout(context.Background(), shutdownCancelGrace)
+	defer cancel()
+	if e.waitForTasks(graceCtx) {
+		return nil
+	}
+
+	e.mu.RLock()
+	running = e.runningIDs()
+	e.mu.RUnlock()
+	e.logger.WithFields(logrus.Fields{
+		"tasks": running,
+		"grace": shutdownCancelGrace,
+	}).Error("Tasks still running after cancellation")
+	return fmt.Errorf("%d tasks still running after cancellation: %v", len(running), running)
+}
+
+// cancelRunning cancels every registered task, as CancelDestruction does
+// for one. Finished tasks held for an automatic restore are left alone.
+func (e *DestructionEngine) cancelRunning() {
+	e.mu.Lock()
+	tasks := make([]*DestructionTask, 0, len(e.running))
+	for _, task := range e.running {
+		task.Status = TaskStateCancelled
+		tasks = append(tasks, task)
+	}
+	e.mu.Unlock()
+
+	for _, task := range tasks {
+		task.Cancel()
+		e.logger.WithFields(logrus.Fields{
+			"task_id": task.ID,
+			"targets": task.Targets,
+		}).Warn("🛑 Task cancelled for shutdown")
+	}
+	e.persistRunning()
+}
+
+// waitForTasks reports whether every registered task finished before ctx
+// was done
+func (e *DestructionEngine) waitForTasks(ctx context.Context) bool {
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for {
+		e.mu.RLock()
+		idle := len(e.running) == 0
+		e.mu.RUnlock()
+		if idle {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// runningIDs returns the IDs of the registered tasks. Callers must hold the
+// engine lock.
+func (e *DestructionEngine) runningIDs() []string {
+	taskIDs := make([]string, 0, len(e.running))
+	for id := range e.running {
+		taskIDs = append(taskIDs, id)
+	}
+	sort.Strings(taskIDs)
+	return taskIDs
+}
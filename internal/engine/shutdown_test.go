@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// startCPUBurn runs a CPU burn of duration in the background and waits for
+// it to register
+func startCPUBurn(t *testing.T, engine *DestructionEngine, duration time.Duration) <-chan *pb.ExecuteDestructionResponse {
+	t.Helper()
+
+	done := make(chan *pb.ExecuteDestructionResponse, 1)
+	go func() {
+		resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN,
+			Targets:            []string{"cpu"},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			ConfirmDestruction: true,
+			Duration:           durationpb.New(duration),
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		done <- resp
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(engine.ListTasks()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the CPU burn to register")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return done
+}
+
+func newShutdownEngine() *DestructionEngine {
+	engine := NewDestructionEngine(&config.Config{
+		Engine: config.EngineConfig{CPUBurnUtilization: 10},
+	})
+	engine.cpu = fakeCPUSampler{usage: 42}
+	return engine
+}
+
+func TestShutdownCancelsRunningTasks(t *testing.T) {
+	engine := newShutdownEngine()
+	done := startCPUBurn(t, engine, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := engine.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected the cancelled task to stop, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected shutdown to cancel the burn promptly, took %v", elapsed)
+	}
+
+	resp := <-done
+	if resp == nil || resp.Success || !strings.Contains(resp.Message, "cancelled") {
+		t.Errorf("Expected the burn to be cancelled, got: %+v", resp)
+	}
+	if tasks := engine.ListTasks(); len(tasks) != 0 {
+		t.Errorf("Expected no running tasks after shutdown, got %d", len(tasks))
+	}
+
+	// Nothing new starts once shutdown has begun
+	_, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN,
+		Targets:            []string{"cpu"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("Expected ErrShuttingDown, got: %v", err)
+	}
+}
+
+func TestShutdownWaitsForRunningTasks(t *testing.T) {
+	engine := newShutdownEngine()
+
+	// Without running tasks there is nothing to wait for
+	if err := engine.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	engine = newShutdownEngine()
+	done := startCPUBurn(t, engine, 200*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := engine.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	resp := <-done
+	if resp == nil || !resp.Success {
+		t.Errorf("Expected the burn to finish undisturbed, got: %+v", resp)
+	}
+}
@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// Task states
+const (
+	TaskStateRunning   = "running"
+	TaskStateCancelled = "cancelled"
+	TaskStateCompleted = "completed"
+	TaskStateFailed    = "failed"
+	// TaskStateInterrupted marks tasks that were running when the server
+	// last stopped
+	TaskStateInterrupted = "interrupted"
+)
+
+// ErrTaskNotFound is returned when a task ID doesn't match a running task
+var ErrTaskNotFound = errors.New("task not found")
+
+// GetTaskStatus returns the status of a running task, or of a task the
+// last server stop interrupted
+func (e *DestructionEngine) GetTaskStatus(taskID string) (*pb.TaskStatus, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	task, ok := e.running[taskID]
+	if !ok {
+		for _, interrupted := range e.interrupted {
+			if interrupted.TaskId == taskID {
+				return proto.Clone(interrupted).(*pb.TaskStatus), nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+
+	return task.status(), nil
+}
+
+// ListTasks returns the status of every running task, and of the tasks the
+// last server stop interrupted, oldest first
+func (e *DestructionEngine) ListTasks() []*pb.TaskStatus {
+	e.mu.RLock()
+	tasks := make([]*pb.TaskStatus, 0, len(e.running)+len(e.interrupted))
+	for _, task := range e.running {
+		tasks = append(tasks, task.status())
+	}
+	for _, task := range e.interrupted {
+		tasks = append(tasks, proto.Clone(task).(*pb.TaskStatus))
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].StartedAt.AsTime().Before(tasks[j].StartedAt.AsTime())
+	})
+
+	return tasks
+}
+
+// CancelDestruction aborts a running task. The task stops at its next
+// cancellation check and reports itself as cancelled. A finished task
+// whose targets are held for an automatic restore is restored at once.
+func (e *DestructionEngine) CancelDestruction(taskID string) (*pb.TaskStatus, error) {
+	e.mu.Lock()
+	task, ok := e.running[taskID]
+	if !ok {
+		e.mu.Unlock()
+		if restore := e.restoreNow(taskID); restore != nil {
+			e.logger.WithField("task_id", taskID).Warn("🛑 Hold cancelled, restoring now")
+			return &pb.TaskStatus{
+				TaskId:   restore.TaskId,
+				Type:     restore.Type,
+				Targets:  restore.Targets,
+				State:    TaskStateCancelled,
+				Progress: 1.0,
+			}, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	task.Status = TaskStateCancelled
+	status := task.status()
+	e.mu.Unlock()
+
+	task.Cancel()
+	e.persistRunning()
+
+	e.logger.WithFields(logrus.Fields{
+		"task_id": taskID,
+		"targets": task.Targets,
+	}).Warn("🛑 Task cancelled")
+
+	return status, nil
+}
+
+// registerTask makes a task visible to status and cancel requests. Once
+// Shutdown has begun no new task is registered.
+func (e *DestructionEngine) registerTask(task *DestructionTask) error {
+	e.mu.Lock()
+	if e.draining {
+		e.mu.Unlock()
+		return ErrShuttingDown
+	}
+	e.running[task.ID] = task
+	e.mu.Unlock()
+
+	e.persistRunning()
+	return nil
+}
+
+// unregisterTask removes a finished task
+func (e *DestructionEngine) unregisterTask(task *DestructionTask) {
+	e.mu.Lock()
+	delete(e.running, task.ID)
+	e.mu.Unlock()
+
+	e.persistRunning()
+}
+
+// setProgress records how far a task has got and what it is working on
+func (e *DestructionEngine) setProgress(task *DestructionTask, progress float64, target string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	task.Progress = progress
+	task.CurrentTarget = target
+}
+
+// finishTiming records that result's target was worked on from start
+// until now
+func finishTiming(result *pb.DestructionResult, start time.Time) {
+	now := time.Now()
+	result.StartedAt = timestamppb.New(start)
+	result.CompletedAt = timestamppb.New(now)
+	result.Metrics.ExecutionTimeSeconds = now.Sub(start).Seconds()
+}
+
+// targetProcessed records the result of a target the task has finished
+// with. A copy is kept because post hooks still update the original.
+func (e *DestructionEngine) targetProcessed(task *DestructionTask, result *pb.DestructionResult) {
+	if result.CompletedAt == nil && !result.Skipped {
+		result.CompletedAt = timestamppb.Now()
+	}
+	snapshot := proto.Clone(result).(*pb.DestructionResult)
+
+	e.mu.Lock()
+	task.Results = append(task.Results, snapshot)
+	processed := len(task.Results)
+	task.stopOnFailure(result)
+	e.mu.Unlock()
+
+	message := fmt.Sprintf("Destroyed %s", result.Target)
+	if !result.Success {
+		message = fmt.Sprintf("Failed to destroy %s: %s", result.Target, result.ErrorMessage)
+	}
+	e.publishEvent(task, &pb.StreamDestructionResponse{
+		Type:     pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_PROGRESS,
+		Target:   result.Target,
+		Progress: float64(processed) / float64(len(task.Targets)),
+		Message:  message,
+	})
+}
+
+// isCancelled reports whether the task was cancelled on request
+func (e *DestructionEngine) isCancelled(task *DestructionTask) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return task.Status == TaskStateCancelled
+}
+
+// stoppedByCancel reports whether err ended the task because it was
+// cancelled, either on request or by its caller going away
+func (e *DestructionEngine) stoppedByCancel(task *DestructionTask, err error) bool {
+	if err == nil {
+		return false
+	}
+	return e.isCancelled(task) || errors.Is(err, context.Canceled)
+}
+
+// status snapshots the task. Callers must hold the engine lock.
+func (t *DestructionTask) status() *pb.TaskStatus {
+	return &pb.TaskStatus{
+		TaskId:        t.ID,
+		Type:          t.Type,
+		Severity:      t.Severity,
+		Targets:       t.Targets,
+		State:         t.Status,
+		Progress:      t.Progress,
+		CurrentTarget: t.CurrentTarget,
+		StartedAt:     timestamppb.New(t.StartedAt),
+
+		TargetsProcessed: int32(len(t.Results)),
+		Results:          append([]*pb.DestructionResult(nil), t.Results...),
+	}
+}
@@ -0,0 +1,454 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestGetTaskStatus(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+
+	if _, err := engine.GetTaskStatus("task_missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	task := &DestructionTask{
+		ID:        "task_1",
+		Type:      pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:   []string{"/tmp/a", "/tmp/b"},
+		Context:   ctx,
+		Cancel:    cancel,
+		Status:    TaskStateRunning,
+		StartedAt: time.Now(),
+	}
+	engine.registerTask(task)
+	engine.setProgress(task, 0.5, "/tmp/b")
+
+	status, err := engine.GetTaskStatus("task_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if status.State != TaskStateRunning {
+		t.Errorf("Expected state %s, got %s", TaskStateRunning, status.State)
+	}
+	if status.Progress != 0.5 {
+		t.Errorf("Expected progress 0.5, got %f", status.Progress)
+	}
+	if status.CurrentTarget != "/tmp/b" {
+		t.Errorf("Expected current target /tmp/b, got %s", status.CurrentTarget)
+	}
+
+	result := &pb.DestructionResult{Target: "/tmp/a", Success: true}
+	engine.targetProcessed(task, result)
+	result.Success = false
+
+	status, err = engine.GetTaskStatus("task_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if status.TargetsProcessed != 1 || len(status.Results) != 1 {
+		t.Fatalf("Expected 1 processed result, got %d/%d", status.TargetsProcessed, len(status.Results))
+	}
+	if status.Results[0].Target != "/tmp/a" || !status.Results[0].Success {
+		t.Errorf("Expected snapshot of result for /tmp/a, got %+v", status.Results[0])
+	}
+
+	engine.unregisterTask(task)
+	if _, err := engine.GetTaskStatus("task_1"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound after unregister, got: %v", err)
+	}
+}
+
+func TestCancelDestruction(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{})
+
+	if _, err := engine.CancelDestruction("task_missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Expected ErrTaskNotFound, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	task := &DestructionTask{
+		ID:       "task_1",
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:  []string{"/tmp/a", "/tmp/b"},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Context:  ctx,
+		Cancel:   cancel,
+		Status:   TaskStateRunning,
+	}
+	engine.registerTask(task)
+
+	status, err := engine.CancelDestruction("task_1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if status.State != TaskStateCancelled {
+		t.Errorf("Expected state %s, got %s", TaskStateCancelled, status.State)
+	}
+	if ctx.Err() == nil {
+		t.Error("Expected task context to be cancelled")
+	}
+
+	// A cancelled task stops before touching further targets
+	results, err := engine.executeFileDeletion(task)
+	if err == nil {
+		t.Error("Expected cancelled task to return an error")
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results from cancelled task, got %d", len(results))
+	}
+}
+
+func TestListTasks(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+
+	if tasks := engine.ListTasks(); len(tasks) != 0 {
+		t.Errorf("Expected no tasks, got %d", len(tasks))
+	}
+
+	now := time.Now()
+	older := &DestructionTask{ID: "task_old", Status: TaskStateRunning, StartedAt: now.Add(-time.Minute)}
+	newer := &DestructionTask{ID: "task_new", Status: TaskStateRunning, StartedAt: now}
+	engine.registerTask(newer)
+	engine.registerTask(older)
+
+	tasks := engine.ListTasks()
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+	if tasks[0].TaskId != "task_old" || tasks[1].TaskId != "task_new" {
+		t.Errorf("Expected tasks oldest first, got %s, %s", tasks[0].TaskId, tasks[1].TaskId)
+	}
+
+	engine.unregisterTask(older)
+	engine.unregisterTask(newer)
+
+	// Finished executions don't linger in the list
+	registerNoopDestructor(t, pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION)
+	_, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION,
+		Targets:            []string{"test-service"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if tasks := engine.ListTasks(); len(tasks) != 0 {
+		t.Errorf("Expected finished task to be removed, got %d tasks", len(tasks))
+	}
+}
+
+// cancellingStream cancels the task as soon as it starts and records events
+type cancellingStream struct {
+	grpc.ServerStream
+	engine *DestructionEngine
+	events []*pb.StreamDestructionResponse
+}
+
+func (s *cancellingStream) Send(event *pb.StreamDestructionResponse) error {
+	s.events = append(s.events, event)
+	if event.Type == pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STARTED {
+		if _, err := s.engine.CancelDestruction(event.TaskId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *cancellingStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestStreamDestructionCancelled(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	var targets []string
+	for _, name := range []string{"a.txt", "b.txt"} {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte("keep me"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		targets = append(targets, path)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+	stream := &cancellingStream{engine: engine}
+
+	err = engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	final := stream.events[len(stream.events)-1]
+	if final.Type != pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED {
+		t.Errorf("Expected final CANCELLED event, got %s", final.Type)
+	}
+
+	for _, target := range targets {
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("Expected %s to survive cancellation: %v", target, err)
+		}
+	}
+}
+
+// midwayCancellingStream cancels the task once the first target completes
+type midwayCancellingStream struct {
+	cancellingStream
+	cancelled bool
+}
+
+func (s *midwayCancellingStream) Send(event *pb.StreamDestructionResponse) error {
+	s.events = append(s.events, event)
+	if strings.HasPrefix(event.Message, "Target completed") && !s.cancelled {
+		s.cancelled = true
+		if _, err := s.engine.CancelDestruction(event.TaskId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamDestructionCancelledMidway(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	var targets []string
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		targets = append(targets, path)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+	stream := &midwayCancellingStream{cancellingStream: cancellingStream{engine: engine}}
+
+	err = engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            targets,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	final := stream.events[len(stream.events)-1]
+	if final.Type != pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED {
+		t.Fatalf("Expected final CANCELLED event, got %s", final.Type)
+	}
+	if !strings.Contains(final.Message, "1 of 3 targets") {
+		t.Errorf("Expected final event to report 1 of 3 targets, got: %s", final.Message)
+	}
+
+	if _, err := os.Stat(targets[0]); !os.IsNotExist(err) {
+		t.Error("Expected the first target to be deleted")
+	}
+	for _, target := range targets[1:] {
+		if _, err := os.Stat(target); err != nil {
+			t.Errorf("Expected %s to be untouched: %v", target, err)
+		}
+	}
+
+	history, err := engine.GetTaskHistory(&pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(history.Tasks) != 1 || history.Tasks[0].State != TaskStateCancelled || len(history.Tasks[0].Results) != 1 {
+		t.Errorf("Expected a cancelled history entry with one result, got %v", history.Tasks)
+	}
+}
+
+func TestExecuteDestructionCallerCancelled(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "keep.txt")
+	if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+
+	// A caller that has already gone away gets partial (here empty) results
+	// and a cancellation message rather than a generic failure
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Message, "cancelled after 0 of 1") {
+		t.Errorf("Expected a cancellation message, got: %s", resp.Message)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("Expected target to be untouched: %v", err)
+	}
+}
+
+func TestStreamDestructionTaskID(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "a.txt")
+	if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+	stream := &recordingStream{}
+
+	err = engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(stream.events) < 3 {
+		t.Fatalf("Expected start, progress and completion events, got %d", len(stream.events))
+	}
+	taskID := stream.events[0].TaskId
+	if taskID == "" {
+		t.Fatal("Expected start event to carry a task ID")
+	}
+	for i, event := range stream.events {
+		if event.TaskId != taskID {
+			t.Errorf("Event %d (%s) has task ID %q, want %q", i, event.Type, event.TaskId, taskID)
+		}
+	}
+}
+
+func TestResultTimestamps(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+
+	before := time.Now()
+	target := filepath.Join(tempDir, "a.txt")
+	if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.StartedAt == nil || resp.CompletedAt == nil {
+		t.Fatal("Expected the response to carry start and completion times")
+	}
+	result := resp.Results[0]
+	if result.StartedAt == nil || result.CompletedAt == nil {
+		t.Fatal("Expected the result to carry start and completion times")
+	}
+	started, completed := result.StartedAt.AsTime(), result.CompletedAt.AsTime()
+	if started.Before(before) || completed.Before(started) || resp.CompletedAt.AsTime().Before(completed) {
+		t.Errorf("Expected %s <= %s <= %s <= %s", before, started, completed, resp.CompletedAt.AsTime())
+	}
+
+	// The final stream event carries the same data
+	if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	stream := &recordingStream{}
+	err = engine.StreamDestruction(context.Background(), &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}, stream)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	final := stream.events[len(stream.events)-1]
+	if final.Type != pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED {
+		t.Fatalf("Expected a completion event last, got %s", final.Type)
+	}
+	if len(final.Results) != 1 || final.Results[0].Target != target || final.Results[0].CompletedAt == nil {
+		t.Errorf("Expected the completion event to carry the timed result, got: %v", final.Results)
+	}
+	if final.StartedAt == nil || final.CompletedAt == nil {
+		t.Error("Expected the completion event to carry start and completion times")
+	}
+}
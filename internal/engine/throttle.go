@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// pacer is a token bucket that refills at rate per second and holds at most
+// one second's worth of tokens
+type pacer struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newPacer(rate float64, now time.Time) *pacer {
+	return &pacer{rate: rate, tokens: math.Max(rate, 1), last: now}
+}
+
+// reserve takes n tokens, going into debt if needed, and returns how long
+// the caller must wait before the debt is repaid
+func (p *pacer) reserve(now time.Time, n float64) time.Duration {
+	p.tokens = math.Min(math.Max(p.rate, 1), p.tokens+now.Sub(p.last).Seconds()*p.rate)
+	p.last = now
+
+	p.tokens -= n
+	if p.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-p.tokens / p.rate * float64(time.Second))
+}
+
+// throttle paces file deletion by operations and bytes per second. A nil
+// throttle never waits.
+type throttle struct {
+	mu    sync.Mutex
+	ops   *pacer
+	bytes *pacer
+	now   func() time.Time
+}
+
+// newThrottle returns a throttle for the given limits, or nil when neither
+// is set
+func newThrottle(opsPerSecond float64, bytesPerSecond int64) *throttle {
+	if opsPerSecond <= 0 && bytesPerSecond <= 0 {
+		return nil
+	}
+
+	t := &throttle{now: time.Now}
+	now := t.now()
+	if opsPerSecond > 0 {
+		t.ops = newPacer(opsPerSecond, now)
+	}
+	if bytesPerSecond > 0 {
+		t.bytes = newPacer(float64(bytesPerSecond), now)
+	}
+	return t
+}
+
+// throttleFor returns the throttle for a request, preferring the request's
+// own limits over the configured ones
+func (e *DestructionEngine) throttleFor(opsPerSecond float64, bytesPerSecond int64) *throttle {
+	if opsPerSecond <= 0 {
+		opsPerSecond = e.config.Engine.MaxOpsPerSecond
+	}
+	if bytesPerSecond <= 0 {
+		bytesPerSecond = e.config.Engine.MaxBytesPerSecond
+	}
+	return newThrottle(opsPerSecond, bytesPerSecond)
+}
+
+// delay accounts for one operation of n bytes and returns how long to wait
+func (t *throttle) delay(n int64) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var wait time.Duration
+	if t.ops != nil {
+		wait = t.ops.reserve(now, 1)
+	}
+	if t.bytes != nil && n > 0 {
+		if bytesWait := t.bytes.reserve(now, float64(n)); bytesWait > wait {
+			wait = bytesWait
+		}
+	}
+	return wait
+}
+
+// wait blocks until one more operation of n bytes fits within the limits.
+// It returns early with ctx's error when ctx is cancelled.
+func (t *throttle) wait(ctx context.Context, n int64) error {
+	if t == nil {
+		return nil
+	}
+
+	wait := t.delay(n)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throttled wraps onFile so that every deleted file is paced by the task's
+// throttle. The byte count comes from the growth of metrics since the last
+// file.
+func (e *DestructionEngine) throttled(task *DestructionTask, metrics *pb.DestructionMetrics, onFile fileDeletedFunc) fileDeletedFunc {
+	if task.throttle == nil {
+		return onFile
+	}
+
+	lastBytes := metrics.BytesDestroyed
+	return func(path string, done int64) {
+		if onFile != nil {
+			onFile(path, done)
+		}
+
+		bytes := metrics.BytesDestroyed - lastBytes
+		lastBytes = metrics.BytesDestroyed
+		// A cancelled wait is noticed by the deletion's own context check
+		_ = task.throttle.wait(task.Context, bytes)
+	}
+}
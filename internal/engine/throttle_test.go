@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestPacerReserve(t *testing.T) {
+	now := time.Now()
+	p := newPacer(2, now)
+
+	// A full bucket absorbs one second's worth of work
+	if wait := p.reserve(now, 1); wait != 0 {
+		t.Errorf("Expected no wait for the first op, got %s", wait)
+	}
+	if wait := p.reserve(now, 1); wait != 0 {
+		t.Errorf("Expected no wait for the second op, got %s", wait)
+	}
+	if wait := p.reserve(now, 1); wait != 500*time.Millisecond {
+		t.Errorf("Expected 500ms wait for the third op, got %s", wait)
+	}
+
+	// Debt is repaid over time
+	if wait := p.reserve(now.Add(time.Second), 1); wait != 0 {
+		t.Errorf("Expected no wait once the debt is repaid, got %s", wait)
+	}
+
+	if newThrottle(0, 0) != nil {
+		t.Error("Expected no throttle without limits")
+	}
+}
+
+func TestThrottleFor(t *testing.T) {
+	engine := NewDestructionEngine(&config.Config{
+		Engine: config.EngineConfig{MaxOpsPerSecond: 5},
+	})
+
+	throttle := engine.throttleFor(0, 0)
+	if throttle == nil || throttle.ops.rate != 5 || throttle.bytes != nil {
+		t.Fatalf("Expected the configured ops limit, got %+v", throttle)
+	}
+
+	throttle = engine.throttleFor(50, 1024)
+	if throttle.ops.rate != 50 || throttle.bytes.rate != 1024 {
+		t.Errorf("Expected request limits to override the config, got ops %v bytes %v", throttle.ops.rate, throttle.bytes.rate)
+	}
+}
+
+func TestThrottledFileDeletion(t *testing.T) {
+	files := make(map[string]string)
+	for i := 0; i < 13; i++ {
+		files[fmt.Sprintf("many/file%02d.txt", i)] = string(make([]byte, 100))
+	}
+	target := filepath.Join(newTestTree(t, files), "many")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "MEDIUM"},
+	})
+
+	// 1300 bytes at 1000 bytes/s: the first second's worth is free, the
+	// remaining 300 bytes take about 300ms
+	start := time.Now()
+	resp, err := engine.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		ConfirmDestruction: true,
+		MaxBytesPerSecond:  1000,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if !resp.Success || !resp.Results[0].Success {
+		t.Fatalf("Expected throttled deletion to succeed, got: %s", resp.Message)
+	}
+	if resp.Results[0].Metrics.FilesDeleted != 13 {
+		t.Errorf("Expected 13 files deleted, got %d", resp.Results[0].Metrics.FilesDeleted)
+	}
+	if elapsed < 250*time.Millisecond {
+		t.Errorf("Expected deletion to be paced, finished in %s", elapsed)
+	}
+}
+
+func TestThrottleWaitInterruptedByCancel(t *testing.T) {
+	files := make(map[string]string)
+	for i := 0; i < 5; i++ {
+		files[fmt.Sprintf("many/file%02d.txt", i)] = string(make([]byte, 10))
+	}
+	target := filepath.Join(newTestTree(t, files), "many")
+
+	engine := NewDestructionEngine(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "LOW"},
+	})
+
+	// At one file per second the second file waits a full second, which
+	// cancellation must cut short
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	resp, err := engine.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		MaxOpsPerSecond:    1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("Expected cancellation to interrupt the throttle wait, took %s", elapsed)
+	}
+	if resp.Results[0].Success {
+		t.Error("Expected the cancelled target to fail")
+	}
+
+	remaining, err := os.ReadDir(target)
+	if err != nil {
+		t.Fatalf("Failed to read target dir: %v", err)
+	}
+	if len(remaining) == 0 {
+		t.Error("Expected files after the cancellation to be untouched")
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package engine
+
+import "os"
+
+// checkWritableDir reports whether entries can be created in and removed
+// from dir. Windows has no access(2), so a probe file is created and
+// removed again.
+func checkWritableDir(dir string) error {
+	probe, err := os.CreateTemp(dir, ".burndevice_preflight_*")
+	if err != nil {
+		return err
+	}
+	if err := probe.Close(); err != nil {
+		return err
+	}
+	return os.Remove(probe.Name())
+}
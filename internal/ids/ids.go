@@ -0,0 +1,34 @@
+// Package ids generates the identifiers of tasks, scenarios and schedules
+package ids
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// Task returns a new task ID, e.g. task_9f1c0e7a-…
+func Task() string {
+	return newID("task")
+}
+
+// Scenario returns a new attack scenario ID
+func Scenario() string {
+	return newID("scenario")
+}
+
+// Schedule returns a new schedule ID
+func Schedule() string {
+	return newID("schedule")
+}
+
+// newID returns prefix and a random (version 4) UUID joined by "_". Unlike
+// the clock, random IDs can't collide when two requests arrive within the
+// same tick, and they don't reveal when they were made.
+func newID(prefix string) string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%s_%x-%x-%x-%x-%x", prefix, b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,52 @@
+package ids
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestIDFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		prefix string
+	}{
+		{"task", Task(), "task"},
+		{"scenario", Scenario(), "scenario"},
+		{"schedule", Schedule(), "schedule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := regexp.MustCompile(`^` + tt.prefix + `_[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+			if !pattern.MatchString(tt.id) {
+				t.Errorf("Expected %s to be %s_ and a version 4 UUID", tt.id, tt.prefix)
+			}
+		})
+	}
+}
+
+func TestIDsUnique(t *testing.T) {
+	const workers, perWorker = 8, 1000
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[string]bool, workers*perWorker)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id := Task()
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("Duplicate ID %s", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// TargetWithin reports whether target is rule or lies beneath it, as used
+// by security.blocked_targets and security.allowed_targets. Both are made
+// absolute and have their symlinks resolved first, so ".." and links can't
+// step around a rule, and only whole path components match: /etc holds
+// /etc/passwd but not /etcfoo. On Windows the match ignores case and
+// accepts both separators. Targets without a separator, such as service
+// names, are compared as they are.
+func TargetWithin(target, rule string) bool {
+	if rule == "" {
+		return false
+	}
+	return pathWithin(resolveTarget(target), resolveTarget(rule), runtime.GOOS == "windows")
+}
+
+// pathWithin reports whether target is rule or beneath it, comparing
+// whole components. windows makes the comparison case-insensitive and
+// treats \ as a separator.
+func pathWithin(target, rule string, windows bool) bool {
+	if windows {
+		target = strings.ToLower(strings.ReplaceAll(target, `\`, "/"))
+		rule = strings.ToLower(strings.ReplaceAll(rule, `\`, "/"))
+	} else {
+		target = filepath.ToSlash(target)
+		rule = filepath.ToSlash(rule)
+	}
+	target, rule = path.Clean(target), path.Clean(rule)
+
+	if target == rule {
+		return true
+	}
+	// A root such as / or C:/ already ends in a separator
+	if !strings.HasSuffix(rule, "/") {
+		rule += "/"
+	}
+	return strings.HasPrefix(target, rule)
+}
+
+// resolveTarget returns target as an absolute path with its symlinks
+// resolved. The part of the path that doesn't exist yet is kept as
+// written beneath its deepest existing parent. Names without a separator
+// aren't paths and are returned unchanged.
+func resolveTarget(target string) string {
+	if !filepath.IsAbs(target) && !strings.ContainsAny(target, `/\`) {
+		return target
+	}
+
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return filepath.Clean(target)
+	}
+
+	// Walk up to the deepest parent that exists, resolve it and put the
+	// rest back
+	existing, rest := abs, ""
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return abs
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return abs
+	}
+	return filepath.Join(resolved, rest)
+}
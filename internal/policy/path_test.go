@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestPathWithin(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		rule    string
+		windows bool
+		want    bool
+	}{
+		{"file inside rule", "/etc/passwd", "/etc", false, true},
+		{"rule itself", "/etc", "/etc", false, true},
+		{"sibling sharing a prefix", "/etcfoo", "/etc", false, false},
+		{"sibling file sharing a prefix", "/etcetera/passwd", "/etc", false, false},
+		{"rule with trailing separator", "/etc/passwd", "/etc/", false, true},
+		{"traversal out of the rule", "/tmp/../etc/passwd", "/tmp", false, false},
+		{"traversal into the rule", "/tmp/../etc/passwd", "/etc", false, true},
+		{"root holds everything", "/tmp/file", "/", false, true},
+		{"case matters off windows", "/ETC/passwd", "/etc", false, false},
+		{"windows ignores case", `C:\WINDOWS\System32\drivers`, `C:\Windows`, true, true},
+		{"windows accepts both separators", "C:/Windows/System32", `C:\Windows`, true, true},
+		{"windows sibling sharing a prefix", `C:\WindowsApps\app.exe`, `C:\Windows`, true, false},
+		{"windows traversal", `C:\Temp\..\Windows\win.ini`, `C:\Windows`, true, true},
+		{"windows drive root", `D:\data\file`, `D:\`, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pathWithin(tt.target, tt.rule, tt.windows); got != tt.want {
+				t.Errorf("pathWithin(%q, %q) = %v, want %v", tt.target, tt.rule, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetWithin(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_pathmatch_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	allowedDir := filepath.Join(tempDir, "allowed")
+	blockedDir := filepath.Join(tempDir, "blocked")
+	for _, dir := range []string{allowedDir, blockedDir} {
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(blockedDir, "secret.txt"), []byte("secret"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	type withinCase struct {
+		name   string
+		target string
+		rule   string
+		want   bool
+	}
+	tests := []withinCase{
+		{"file inside rule", filepath.Join(blockedDir, "secret.txt"), blockedDir, true},
+		{"file that doesn't exist yet", filepath.Join(blockedDir, "new", "file.txt"), blockedDir, true},
+		{"traversal into the rule", filepath.Join(allowedDir, "..", "blocked", "secret.txt"), blockedDir, true},
+		{"traversal out of the rule", allowedDir + string(filepath.Separator) + filepath.Join("..", "blocked", "secret.txt"), allowedDir, false},
+		{"sibling sharing a prefix", blockedDir + "-other", blockedDir, false},
+		{"service name", "nginx", string(filepath.Separator), false},
+		{"empty rule", allowedDir, "", false},
+	}
+
+	// Links into the rule are followed; creating them needs privileges on
+	// Windows
+	if runtime.GOOS != "windows" {
+		link := filepath.Join(allowedDir, "link")
+		if err := os.Symlink(blockedDir, link); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+		tests = append(tests,
+			withinCase{"symlink into the rule", filepath.Join(link, "secret.txt"), blockedDir, true},
+			withinCase{"symlink out of the rule", filepath.Join(link, "secret.txt"), allowedDir, false},
+		)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TargetWithin(tt.target, tt.rule); got != tt.want {
+				t.Errorf("TargetWithin(%q, %q) = %v, want %v", tt.target, tt.rule, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+// Package policy holds the security rules every destruction request is
+// checked against, so the server and the engine accept and reject
+// requests the same way and with the same messages.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// ErrIrreversibleNotAcknowledged is returned for requests of a type that
+// cannot be undone which are missing one of the safeguards it requires
+var ErrIrreversibleNotAcknowledged = errors.New("irreversible destruction not acknowledged")
+
+// ErrInvalidPattern is returned when a target or filter pattern can't be
+// parsed
+var ErrInvalidPattern = errors.New("invalid target pattern")
+
+// RejectedError is a validation failure together with a short, fixed
+// reason for it, such as "severity" or "target", for counting rejections
+type RejectedError struct {
+	Reason string
+	Err    error
+}
+
+func (e *RejectedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RejectedError) Unwrap() error {
+	return e.Err
+}
+
+// Reject wraps err, when it isn't nil, with reason
+func Reject(reason string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RejectedError{Reason: reason, Err: err}
+}
+
+// RejectionReason returns the reason err was rejected with, or "other"
+func RejectionReason(err error) string {
+	var rejected *RejectedError
+	if errors.As(err, &rejected) {
+		return rejected.Reason
+	}
+	return "other"
+}
+
+// irreversibleTypes cannot be recovered from once they run
+var irreversibleTypes = map[pb.DestructionType]bool{
+	pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC:    true,
+	pb.DestructionType_DESTRUCTION_TYPE_BOOT_CORRUPTION: true,
+}
+
+// Request is the part of an execute or stream destruction request the
+// policy checks
+type Request interface {
+	GetType() pb.DestructionType
+	GetTargets() []string
+	GetSeverity() pb.DestructionSeverity
+	GetConfirmDestruction() bool
+	GetConfirmationText() string
+	GetDryRun() bool
+	GetAcknowledgeIrreversible() bool
+	GetQuarantine() bool
+	GetDuration() *durationpb.Duration
+	GetIncludePatterns() []string
+	GetExcludePatterns() []string
+	GetMinAge() *durationpb.Duration
+	GetMaxFileSize() int64
+	GetWipe() bool
+	GetIntensity() int32
+}
+
+// Policy applies the security section of the configuration. It reads the
+// configuration on every call, so later changes to it take effect, and
+// Update swaps in a whole new section at once, e.g. on a config reload.
+type Policy struct {
+	mu       sync.RWMutex
+	security *config.SecurityConfig
+}
+
+// New creates a policy for security
+func New(security *config.SecurityConfig) *Policy {
+	return &Policy{security: security}
+}
+
+// Update makes the policy apply security from now on
+func (p *Policy) Update(security *config.SecurityConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.security = security
+}
+
+// rules returns the security section currently applied
+func (p *Policy) rules() *config.SecurityConfig {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.security
+}
+
+// ValidateRequest returns why req may not run, or nil. It covers
+// everything decided by configuration alone; state such as cooldowns is
+// left to the engine.
+func (p *Policy) ValidateRequest(req Request) error {
+	// A dry run previews the request, so it may be sent before confirming
+	if p.rules().RequireConfirmation && !req.GetConfirmDestruction() && !req.GetDryRun() {
+		return Reject("unconfirmed", fmt.Errorf("destruction must be confirmed"))
+	}
+
+	if !req.GetDryRun() && !p.ConfirmationPhraseSatisfied(req.GetSeverity(), req.GetConfirmationText()) {
+		return Reject("confirmation_phrase", fmt.Errorf("%s severity requires the configured confirmation phrase", SeverityName(req.GetSeverity())))
+	}
+
+	if req.GetSeverity() > p.MaxSeverity() {
+		return Reject("severity", fmt.Errorf("requested severity exceeds maximum allowed (%s)", p.rules().MaxSeverity))
+	}
+
+	if !p.TypeEnabled(req.GetType()) {
+		return Reject("type_disabled", fmt.Errorf("destruction type %s is not enabled", req.GetType()))
+	}
+
+	if err := p.CheckIrreversible(req.GetType(), req.GetSeverity(), req.GetDryRun(), req.GetConfirmDestruction(), req.GetAcknowledgeIrreversible()); err != nil {
+		return Reject("irreversible", err)
+	}
+
+	if req.GetQuarantine() && p.rules().QuarantineDir == "" {
+		return Reject("quarantine", fmt.Errorf("quarantine requires the server to set security.quarantine_dir"))
+	}
+
+	if err := p.CheckDuration(req.GetDuration().AsDuration()); err != nil {
+		return Reject("duration", err)
+	}
+
+	if err := CheckIntensity(req.GetIntensity()); err != nil {
+		return Reject("intensity", err)
+	}
+
+	if err := CheckFileFilters(req.GetType(), req.GetIncludePatterns(), req.GetExcludePatterns(), req.GetMinAge().AsDuration(), req.GetMaxFileSize()); err != nil {
+		return Reject("filters", err)
+	}
+
+	if err := p.CheckWipe(req.GetType(), req.GetWipe(), req.GetQuarantine()); err != nil {
+		return Reject("wipe", err)
+	}
+
+	for _, target := range req.GetTargets() {
+		if err := p.CheckTarget(target); err != nil {
+			return Reject("target", err)
+		}
+	}
+
+	return nil
+}
+
+// CheckTarget returns why target may not be destroyed under the blocked
+// and allowed lists, or nil when it may
+func (p *Policy) CheckTarget(target string) error {
+	if p.IsBlocked(target) {
+		return fmt.Errorf("target is blocked: %s", target)
+	}
+	if !p.IsAllowed(target) {
+		return fmt.Errorf("target is not in allowed list: %s", target)
+	}
+	return nil
+}
+
+// IsBlocked reports whether target lies within one of blocked_targets, or
+// within backup_dir, whose backups restores depend on
+func (p *Policy) IsBlocked(target string) bool {
+	security := p.rules()
+	if TargetWithin(target, security.BackupDir) {
+		return true
+	}
+	for _, blocked := range security.BlockedTargets {
+		if TargetWithin(target, blocked) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllowed reports whether target lies within one of allowed_targets. An
+// empty list allows every target.
+func (p *Policy) IsAllowed(target string) bool {
+	allowedTargets := p.rules().AllowedTargets
+	if len(allowedTargets) == 0 {
+		return true
+	}
+	for _, allowed := range allowedTargets {
+		if TargetWithin(target, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSeverity returns the highest severity max_severity permits
+func (p *Policy) MaxSeverity() pb.DestructionSeverity {
+	return SeverityLevel(p.rules().MaxSeverity)
+}
+
+// TypeEnabled reports whether enabled_types allows t. An empty list enables
+// every type.
+func (p *Policy) TypeEnabled(t pb.DestructionType) bool {
+	enabled := p.rules().EnabledTypes
+	if len(enabled) == 0 {
+		return true
+	}
+
+	name := strings.TrimPrefix(t.String(), "DESTRUCTION_TYPE_")
+	for _, allowed := range enabled {
+		if strings.EqualFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmationPhraseSatisfied reports whether text satisfies the
+// confirmation phrase required at severity. Requests below
+// confirmation_phrase_severity, or any request when no phrase is
+// configured, are always satisfied.
+func (p *Policy) ConfirmationPhraseSatisfied(severity pb.DestructionSeverity, text string) bool {
+	security := p.rules()
+	phrase := security.ConfirmationPhrase
+	if phrase == "" {
+		return true
+	}
+	threshold := security.ConfirmationPhraseSeverity
+	if threshold == "" {
+		threshold = "HIGH"
+	}
+	if severity < SeverityLevel(threshold) {
+		return true
+	}
+	return text == phrase
+}
+
+// CheckIrreversible returns why a request of type t may not run, or nil.
+// Types that cannot be undone need CRITICAL severity, confirmation, the
+// request's acknowledgement and security.allow_irreversible, and are
+// refused outright while safe mode is enabled; dry runs change nothing and
+// are exempt.
+func (p *Policy) CheckIrreversible(t pb.DestructionType, severity pb.DestructionSeverity, dryRun, confirmed, acknowledged bool) error {
+	if !IrreversibleType(t) || dryRun {
+		return nil
+	}
+
+	security := p.rules()
+	switch {
+	case security.EnableSafeMode:
+		return fmt.Errorf("%w: %s cannot be undone and is disabled while safe mode is enabled", ErrIrreversibleNotAcknowledged, t)
+	case !security.AllowIrreversible:
+		return fmt.Errorf("%w: %s requires security.allow_irreversible", ErrIrreversibleNotAcknowledged, t)
+	case severity != pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL:
+		return fmt.Errorf("%w: %s requires CRITICAL severity", ErrIrreversibleNotAcknowledged, t)
+	case !confirmed:
+		return fmt.Errorf("%w: %s must be confirmed", ErrIrreversibleNotAcknowledged, t)
+	case !acknowledged:
+		return fmt.Errorf("%w: %s requires acknowledge_irreversible", ErrIrreversibleNotAcknowledged, t)
+	}
+	return nil
+}
+
+// IrreversibleType reports whether t cannot be undone
+func IrreversibleType(t pb.DestructionType) bool {
+	return irreversibleTypes[t]
+}
+
+// CheckFileFilters checks the file filters of a request of type t: only
+// file deletion takes them, their patterns must parse and the age and size
+// limits can't be negative
+func CheckFileFilters(t pb.DestructionType, include, exclude []string, minAge time.Duration, maxSize int64) error {
+	if len(include) == 0 && len(exclude) == 0 && minAge == 0 && maxSize == 0 {
+		return nil
+	}
+	if minAge < 0 {
+		return fmt.Errorf("min_age cannot be negative")
+	}
+	if maxSize < 0 {
+		return fmt.Errorf("max_file_size cannot be negative")
+	}
+	for _, pattern := range append(append([]string(nil), include...), exclude...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidPattern, pattern, err)
+		}
+	}
+	if t != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		return fmt.Errorf("file filters only apply to file deletion")
+	}
+	return nil
+}
+
+// CheckWipe returns why a request asking for a wipe may not run, or nil.
+// Quarantined files are moved rather than deleted, so there is nothing to
+// wipe.
+func (p *Policy) CheckWipe(t pb.DestructionType, wipe, quarantine bool) error {
+	if !wipe {
+		return nil
+	}
+	if t != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		return fmt.Errorf("wipe only applies to file deletion")
+	}
+	if quarantine || p.rules().Quarantine {
+		return fmt.Errorf("wipe cannot be combined with quarantine")
+	}
+	return nil
+}
+
+// CheckDuration returns why a request may not run for duration, or nil.
+// Durations above security.max_duration are rejected rather than capped so
+// the caller knows the run is shorter than asked.
+func (p *Policy) CheckDuration(duration time.Duration) error {
+	if duration < 0 {
+		return fmt.Errorf("duration cannot be negative")
+	}
+	if max := p.rules().MaxDuration; max > 0 && duration > max {
+		return fmt.Errorf("duration %s exceeds the maximum of %s", duration, max)
+	}
+	return nil
+}
+
+// CheckIntensity returns why intensity is out of range, or nil. 0 leaves
+// the intensity to the severity.
+func CheckIntensity(intensity int32) error {
+	if intensity < 0 || intensity > 100 {
+		return fmt.Errorf("intensity must be between 0 and 100, got %d", intensity)
+	}
+	return nil
+}
+
+// SeverityLevel parses a configured severity name such as "HIGH".
+// Unknown names fall back to LOW, the most restrictive choice.
+func SeverityLevel(name string) pb.DestructionSeverity {
+	switch name {
+	case "LOW":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW
+	case "MEDIUM":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM
+	case "HIGH":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH
+	case "CRITICAL":
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL
+	default:
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW
+	}
+}
+
+// SeverityName returns severity without its enum prefix, e.g. "HIGH", the
+// name the configuration uses for it
+func SeverityName(severity pb.DestructionSeverity) string {
+	return strings.TrimPrefix(severity.String(), "DESTRUCTION_SEVERITY_")
+}
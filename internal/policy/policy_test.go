@@ -0,0 +1,343 @@
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestValidateRequest(t *testing.T) {
+	security := &config.SecurityConfig{
+		RequireConfirmation: true,
+		MaxSeverity:         "MEDIUM",
+		ConfirmationPhrase:  "burn it",
+		BlockedTargets:      []string{"/tmp/blocked"},
+		AllowedTargets:      []string{"/tmp"},
+		EnabledTypes:        []string{"FILE_DELETION"},
+	}
+	p := New(security)
+
+	valid := func() *pb.ExecuteDestructionRequest {
+		return &pb.ExecuteDestructionRequest{
+			Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+			Targets:            []string{"/tmp/test.txt"},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+			ConfirmDestruction: true,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(req *pb.ExecuteDestructionRequest)
+		wantErr string
+	}{
+		{"valid", func(req *pb.ExecuteDestructionRequest) {}, ""},
+		{"unconfirmed", func(req *pb.ExecuteDestructionRequest) { req.ConfirmDestruction = false }, "must be confirmed"},
+		{"unconfirmed dry run", func(req *pb.ExecuteDestructionRequest) { req.ConfirmDestruction, req.DryRun = false, true }, ""},
+		{"above max severity", func(req *pb.ExecuteDestructionRequest) {
+			req.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL
+			req.ConfirmationText = "burn it"
+		}, "exceeds maximum allowed (MEDIUM)"},
+		{"type not enabled", func(req *pb.ExecuteDestructionRequest) { req.Type = pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN }, "not enabled"},
+		{"quarantine without a directory", func(req *pb.ExecuteDestructionRequest) { req.Quarantine = true }, "quarantine_dir"},
+		{"negative duration", func(req *pb.ExecuteDestructionRequest) { req.Duration = durationpb.New(-time.Second) }, "duration cannot be negative"},
+		{"wipe", func(req *pb.ExecuteDestructionRequest) { req.Wipe = true }, ""},
+		{"invalid filter", func(req *pb.ExecuteDestructionRequest) { req.ExcludePatterns = []string{"[unclosed"} }, "invalid target pattern"},
+		{"blocked target", func(req *pb.ExecuteDestructionRequest) { req.Targets = []string{"/tmp/blocked/file"} }, "target is blocked"},
+		{"target outside allowed list", func(req *pb.ExecuteDestructionRequest) { req.Targets = []string{"/srv/file"} }, "not in allowed list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+			err := p.ValidateRequest(req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Streaming requests are checked by the same rules with the same
+	// messages
+	execErr := p.ValidateRequest(&pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/etc/passwd"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	streamErr := p.ValidateRequest(&pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/etc/passwd"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if execErr == nil || streamErr == nil || execErr.Error() != streamErr.Error() {
+		t.Errorf("Expected matching errors, got %v and %v", execErr, streamErr)
+	}
+
+	// The policy follows later changes to the configuration
+	security.AllowedTargets = append(security.AllowedTargets, "/etc")
+	if err := p.ValidateRequest(&pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/etc/passwd"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}); err != nil {
+		t.Errorf("Expected the updated allowed list to apply, got: %v", err)
+	}
+}
+
+func TestRejectionReason(t *testing.T) {
+	p := New(&config.SecurityConfig{
+		RequireConfirmation: true,
+		MaxSeverity:         "MEDIUM",
+		BlockedTargets:      []string{"/tmp/blocked"},
+	})
+
+	tests := []struct {
+		name   string
+		req    *pb.ExecuteDestructionRequest
+		reason string
+	}{
+		{"unconfirmed", &pb.ExecuteDestructionRequest{Targets: []string{"/tmp/a"}}, "unconfirmed"},
+		{"severity", &pb.ExecuteDestructionRequest{
+			Targets:            []string{"/tmp/a"},
+			Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+			ConfirmDestruction: true,
+		}, "severity"},
+		{"blocked target", &pb.ExecuteDestructionRequest{Targets: []string{"/tmp/blocked/a"}, ConfirmDestruction: true}, "target"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if reason := RejectionReason(p.ValidateRequest(tt.req)); reason != tt.reason {
+				t.Errorf("Expected reason %s, got: %s", tt.reason, reason)
+			}
+		})
+	}
+
+	if reason := RejectionReason(errors.New("unwrapped")); reason != "other" {
+		t.Errorf("Expected reason other for a plain error, got: %s", reason)
+	}
+	if err := Reject("target", nil); err != nil {
+		t.Errorf("Expected rejecting a nil error to return nil, got: %v", err)
+	}
+}
+
+func TestIsBlocked(t *testing.T) {
+	p := New(&config.SecurityConfig{
+		BlockedTargets: []string{"/etc", "/var/log", "/usr/bin"},
+		BackupDir:      "/srv/burndevice/backups",
+	})
+
+	tests := []struct {
+		target   string
+		expected bool
+	}{
+		{"/etc/passwd", true},
+		{"/var/log/messages", true},
+		{"/usr/bin/bash", true},
+		{"/tmp/test.txt", false},
+		{"/home/user/file.txt", false},
+		{"/etcetera/file.txt", false},
+		{"/tmp/../etc/passwd", true},
+		{"/srv/burndevice/backups/tmp/test.txt", true},
+		{"/srv/burndevice/other.txt", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			result := p.IsBlocked(tt.target)
+			if result != tt.expected {
+				t.Errorf("Expected isBlocked %v for '%s', got %v", tt.expected, tt.target, result)
+			}
+		})
+	}
+}
+
+func TestIsAllowed(t *testing.T) {
+	p := New(&config.SecurityConfig{
+		AllowedTargets: []string{"/tmp", "/var/tmp", "/home/user"},
+	})
+
+	tests := []struct {
+		target   string
+		expected bool
+	}{
+		{"/tmp/test.txt", true},
+		{"/var/tmp/file.log", true},
+		{"/home/user/document.txt", true},
+		{"/etc/passwd", false},
+		{"/usr/bin/bash", false},
+		{"/tmpfoo/file.txt", false},
+		{"/tmp/../etc/passwd", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			result := p.IsAllowed(tt.target)
+			if result != tt.expected {
+				t.Errorf("Expected isAllowed %v for '%s', got %v", tt.expected, tt.target, result)
+			}
+		})
+	}
+
+	// An empty list allows everything
+	if !New(&config.SecurityConfig{}).IsAllowed("/etc/passwd") {
+		t.Error("Expected an empty allowed list to allow every target")
+	}
+}
+
+func TestSeverityLevel(t *testing.T) {
+	tests := []struct {
+		severity string
+		expected pb.DestructionSeverity
+	}{
+		{"LOW", pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW},
+		{"MEDIUM", pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM},
+		{"HIGH", pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH},
+		{"CRITICAL", pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL},
+		{"INVALID", pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW}, // Default to LOW for invalid input
+		{"", pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW},        // Default to LOW for empty input
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			result := SeverityLevel(tt.severity)
+			if result != tt.expected {
+				t.Errorf("Expected severity level %v for '%s', got %v", tt.expected, tt.severity, result)
+			}
+		})
+	}
+
+	p := New(&config.SecurityConfig{MaxSeverity: "HIGH"})
+	if got := p.MaxSeverity(); got != pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH {
+		t.Errorf("Expected MaxSeverity HIGH, got %v", got)
+	}
+}
+
+func TestCheckIrreversible(t *testing.T) {
+	kernelPanic := pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC
+	critical := pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL
+
+	p := New(&config.SecurityConfig{AllowIrreversible: true})
+	if err := p.CheckIrreversible(kernelPanic, critical, false, true, true); err != nil {
+		t.Errorf("Expected an acknowledged request to pass, got: %v", err)
+	}
+	if err := p.CheckIrreversible(kernelPanic, critical, false, true, false); !errors.Is(err, ErrIrreversibleNotAcknowledged) {
+		t.Errorf("Expected ErrIrreversibleNotAcknowledged, got: %v", err)
+	}
+	if err := p.CheckIrreversible(kernelPanic, pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH, true, false, false); err != nil {
+		t.Errorf("Expected a dry run to pass, got: %v", err)
+	}
+	if err := New(&config.SecurityConfig{}).CheckIrreversible(kernelPanic, critical, false, true, true); !errors.Is(err, ErrIrreversibleNotAcknowledged) {
+		t.Errorf("Expected allow_irreversible to be required, got: %v", err)
+	}
+
+	// Safe mode refuses them however they are acknowledged, but still
+	// previews them
+	safe := New(&config.SecurityConfig{AllowIrreversible: true, EnableSafeMode: true})
+	if err := safe.CheckIrreversible(kernelPanic, critical, false, true, true); !errors.Is(err, ErrIrreversibleNotAcknowledged) || !strings.Contains(err.Error(), "safe mode") {
+		t.Errorf("Expected safe mode to refuse KERNEL_PANIC, got: %v", err)
+	}
+	if err := safe.CheckIrreversible(kernelPanic, critical, true, false, false); err != nil {
+		t.Errorf("Expected a dry run to pass under safe mode, got: %v", err)
+	}
+	if err := p.CheckIrreversible(pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW, false, false, false); err != nil {
+		t.Errorf("Expected other types to pass, got: %v", err)
+	}
+}
+
+func TestCheckFileFilters(t *testing.T) {
+	fileDeletion := pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION
+
+	if err := CheckFileFilters(fileDeletion, nil, nil, 0, 0); err != nil {
+		t.Errorf("Expected no filters to pass, got: %v", err)
+	}
+	if err := CheckFileFilters(fileDeletion, []string{"*.log"}, []string{".keep"}, time.Hour, 1024); err != nil {
+		t.Errorf("Expected valid filters to pass, got: %v", err)
+	}
+	if err := CheckFileFilters(fileDeletion, nil, []string{"[unclosed"}, 0, 0); !errors.Is(err, ErrInvalidPattern) {
+		t.Errorf("Expected ErrInvalidPattern, got: %v", err)
+	}
+	if err := CheckFileFilters(fileDeletion, nil, nil, -time.Hour, 0); err == nil {
+		t.Error("Expected a negative min_age to be rejected")
+	}
+	if err := CheckFileFilters(fileDeletion, nil, nil, 0, -1); err == nil {
+		t.Error("Expected a negative max_file_size to be rejected")
+	}
+	if err := CheckFileFilters(pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, nil, []string{".keep"}, 0, 0); err == nil {
+		t.Error("Expected filters on another type to be rejected")
+	}
+}
+
+func TestCheckWipe(t *testing.T) {
+	security := &config.SecurityConfig{}
+	p := New(security)
+	fileDeletion := pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION
+
+	if err := p.CheckWipe(pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN, false, false); err != nil {
+		t.Errorf("Expected no wipe to pass, got: %v", err)
+	}
+	if err := p.CheckWipe(fileDeletion, true, false); err != nil {
+		t.Errorf("Expected a file deletion wipe to pass, got: %v", err)
+	}
+	if err := p.CheckWipe(pb.DestructionType_DESTRUCTION_TYPE_FILE_CORRUPTION, true, false); err == nil {
+		t.Error("Expected a wipe on another type to be rejected")
+	}
+	if err := p.CheckWipe(fileDeletion, true, true); err == nil {
+		t.Error("Expected a quarantined wipe to be rejected")
+	}
+
+	// Quarantine forced by the server counts too
+	security.Quarantine = true
+	if err := p.CheckWipe(fileDeletion, true, false); err == nil {
+		t.Error("Expected a wipe under security.quarantine to be rejected")
+	}
+}
+
+func TestCheckDurationAndIntensity(t *testing.T) {
+	security := &config.SecurityConfig{}
+	p := New(security)
+
+	if err := p.CheckDuration(24 * time.Hour); err != nil {
+		t.Errorf("Expected any duration to pass without a maximum, got: %v", err)
+	}
+	if err := p.CheckDuration(-time.Second); err == nil {
+		t.Error("Expected a negative duration to be rejected")
+	}
+
+	security.MaxDuration = time.Minute
+	if err := p.CheckDuration(time.Minute); err != nil {
+		t.Errorf("Expected the maximum duration to pass, got: %v", err)
+	}
+	if err := p.CheckDuration(2 * time.Minute); err == nil || !strings.Contains(err.Error(), "exceeds the maximum") {
+		t.Errorf("Expected a duration above the maximum to be rejected, got: %v", err)
+	}
+
+	for _, intensity := range []int32{0, 1, 100} {
+		if err := CheckIntensity(intensity); err != nil {
+			t.Errorf("Expected intensity %d to pass, got: %v", intensity, err)
+		}
+	}
+	for _, intensity := range []int32{-1, 101} {
+		if err := CheckIntensity(intensity); err == nil {
+			t.Errorf("Expected intensity %d to be rejected", intensity)
+		}
+	}
+}
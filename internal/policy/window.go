@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// CheckWindow returns why nothing may be destroyed at now under
+// security.allowed_windows, naming when the next window opens, or nil when
+// now lies inside one of them or none are configured
+func (p *Policy) CheckWindow(now time.Time) error {
+	windows := p.rules().AllowedWindows
+	if len(windows) == 0 {
+		return nil
+	}
+
+	var next time.Time
+	for i, window := range windows {
+		open, opens, err := windowState(window, now)
+		if err != nil {
+			return Reject("window", fmt.Errorf("allowed_windows[%d]: %w", i, err))
+		}
+		if open {
+			return nil
+		}
+		if next.IsZero() || opens.Before(next) {
+			next = opens
+		}
+	}
+
+	return Reject("window", fmt.Errorf("destructions are only allowed within security.allowed_windows; the next window opens at %s",
+		next.Format(time.RFC3339)))
+}
+
+// WindowOverride reports whether token is the configured
+// window_override_token. Nothing matches when none is configured.
+func (p *Policy) WindowOverride(token string) bool {
+	configured := p.rules().WindowOverrideToken
+	return configured != "" && subtle.ConstantTimeCompare([]byte(token), []byte(configured)) == 1
+}
+
+// windowState reports whether window is open at now and, when it isn't,
+// when it next opens
+func windowState(window config.TimeWindow, now time.Time) (bool, time.Time, error) {
+	weekdays, err := window.Weekdays()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	start, end, err := window.Clock()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	loc, err := window.Location()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	var days [7]bool
+	for _, day := range weekdays {
+		days[day] = true
+	}
+
+	local := now.In(loc)
+	clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+	today := local.Weekday()
+	yesterday := (today + 6) % 7
+
+	if start < end {
+		if days[today] && clock >= start && clock < end {
+			return true, time.Time{}, nil
+		}
+	} else if (days[today] && clock >= start) || (days[yesterday] && clock < end) {
+		// The window runs past midnight, so it is open late on the day it
+		// opens and early on the day after
+		return true, time.Time{}, nil
+	}
+
+	hour, minute := int(start/time.Hour), int(start%time.Hour/time.Minute)
+	for offset := 0; offset <= 7; offset++ {
+		opens := time.Date(local.Year(), local.Month(), local.Day()+offset, hour, minute, 0, 0, loc)
+		if days[opens.Weekday()] && opens.After(now) {
+			return false, opens, nil
+		}
+	}
+	// Every window opens on at least one day a week
+	return false, time.Time{}, fmt.Errorf("window never opens")
+}
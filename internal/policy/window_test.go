@@ -0,0 +1,95 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+func TestCheckWindow(t *testing.T) {
+	// Weeknights from 22:00 to 06:00 UTC, and 09:00 to 17:00 Tokyo time
+	// on Sundays
+	p := New(&config.SecurityConfig{
+		AllowedWindows: []config.TimeWindow{
+			{Days: []string{"mon", "tue", "wed", "thu", "Friday"}, Start: "22:00", End: "06:00", Timezone: "UTC"},
+			{Days: []string{"sun"}, Start: "09:00", End: "17:00", Timezone: "Asia/Tokyo"},
+		},
+	})
+
+	// 2026-10-12 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		nextOpen string
+	}{
+		{"monday night", at(12, 23, 0), ""},
+		{"early tuesday", at(13, 5, 59), ""},
+		{"early saturday after friday night", at(17, 3, 0), ""},
+		{"sunday in tokyo", at(18, 1, 0), ""},
+		{"window end is exclusive", at(13, 6, 0), "2026-10-13T22:00:00Z"},
+		{"monday afternoon", at(12, 15, 0), "2026-10-12T22:00:00Z"},
+		{"saturday night", at(17, 23, 0), "2026-10-18T09:00:00+09:00"},
+		{"sunday evening", at(18, 12, 0), "2026-10-19T22:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.CheckWindow(tt.now)
+			if tt.nextOpen == "" {
+				if err != nil {
+					t.Errorf("Expected the window to be open, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "next window opens at "+tt.nextOpen) {
+				t.Errorf("Expected the next window to open at %s, got: %v", tt.nextOpen, err)
+			}
+			if reason := RejectionReason(err); reason != "window" {
+				t.Errorf("Expected reason window, got: %s", reason)
+			}
+		})
+	}
+
+	// Without windows destructions may run at any time
+	if err := New(&config.SecurityConfig{}).CheckWindow(at(12, 15, 0)); err != nil {
+		t.Errorf("Expected no windows to allow any time, got: %v", err)
+	}
+
+	// A whole-day window
+	allDay := New(&config.SecurityConfig{
+		AllowedWindows: []config.TimeWindow{{Days: []string{"wed"}, Start: "00:00", End: "00:00", Timezone: "UTC"}},
+	})
+	if err := allDay.CheckWindow(at(14, 23, 59)); err != nil {
+		t.Errorf("Expected a window ending at its start to last the day, got: %v", err)
+	}
+	if err := allDay.CheckWindow(at(15, 0, 0)); err == nil {
+		t.Error("Expected the whole-day window to close at midnight")
+	}
+
+	invalid := New(&config.SecurityConfig{
+		AllowedWindows: []config.TimeWindow{{Start: "25:00", End: "06:00"}},
+	})
+	if err := invalid.CheckWindow(at(12, 23, 0)); err == nil || !strings.Contains(err.Error(), "invalid start") {
+		t.Errorf("Expected an invalid window to reject, got: %v", err)
+	}
+}
+
+func TestWindowOverride(t *testing.T) {
+	if New(&config.SecurityConfig{}).WindowOverride("") {
+		t.Error("Expected no override without a configured token")
+	}
+
+	p := New(&config.SecurityConfig{WindowOverrideToken: "break-glass"})
+	if !p.WindowOverride("break-glass") {
+		t.Error("Expected the configured token to override")
+	}
+	if p.WindowOverride("") || p.WindowOverride("break") {
+		t.Error("Expected other tokens not to override")
+	}
+}
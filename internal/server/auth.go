@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenAuth rejects calls whose authorization metadata doesn't carry the
+// configured token. A nil tokenAuth allows everything.
+type tokenAuth struct {
+	token []byte
+}
+
+// newTokenAuth returns an authenticator, or nil when no token is configured
+func newTokenAuth(token string) *tokenAuth {
+	if token == "" {
+		return nil
+	}
+	return &tokenAuth{token: []byte(token)}
+}
+
+// check accepts "Bearer <token>" or the bare token
+func (a *tokenAuth) check(ctx context.Context) error {
+	if a == nil {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+
+	provided := md.Get("authorization")[0]
+	if len(provided) > len("bearer ") && strings.EqualFold(provided[:len("bearer ")], "bearer ") {
+		provided = provided[len("bearer "):]
+	}
+
+	if subtle.ConstantTimeCompare([]byte(provided), a.token) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid authorization token")
+	}
+	return nil
+}
+
+// unaryAuth rejects unauthenticated unary RPCs other than health checks
+func (a *tokenAuth) unaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if isHealthCheck(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	if err := a.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamAuth rejects unauthenticated streaming RPCs other than health
+// watches
+func (a *tokenAuth) streamAuth(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isHealthCheck(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	if err := a.check(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
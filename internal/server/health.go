@@ -0,0 +1,32 @@
+package server
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// healthMethodPrefix is the full method prefix of the standard gRPC health
+// service
+var healthMethodPrefix = "/" + healthpb.Health_ServiceDesc.ServiceName + "/"
+
+// isHealthCheck reports whether fullMethod belongs to the health service.
+// Probes from load balancers and orchestrators carry no token and must not
+// keep an otherwise idle server alive.
+func isHealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, healthMethodPrefix)
+}
+
+// setServing marks the server as a whole and the BurnDevice service as
+// SERVING or NOT_SERVING
+func setServing(healthServer *health.Server, serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	healthServer.SetServingStatus("", status)
+	healthServer.SetServingStatus(pb.BurnDeviceService_ServiceDesc.ServiceName, status)
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// maxIdempotencyKeys bounds how many keys the cache remembers
+const maxIdempotencyKeys = 1024
+
+// idempotencyCache remembers the responses to recent destruction requests
+// sent with an idempotency key, so a retried request is answered with the
+// first response instead of destroying again. Keys belong to the client
+// that sent them, and each is tied to the request it was first sent with.
+type idempotencyCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*idempotencyEntry
+	now     func() time.Time
+}
+
+type idempotencyEntry struct {
+	// fingerprint identifies the request the key was first sent with
+	fingerprint string
+	created     time.Time
+	// done is closed once the first request has finished; response and
+	// expires are set by then, unless it failed and was forgotten
+	done     chan struct{}
+	response *pb.ExecuteDestructionResponse
+	expires  time.Time
+}
+
+// newIdempotencyCache returns a cache keeping responses for ttl, or nil
+// when ttl disables it
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &idempotencyCache{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+// do runs execute for the first request client sends with req's key and
+// answers later ones with its response, reporting whether it did. A retry
+// that arrives while the first request still runs waits for it. A first
+// request that fails, with an error or an unsuccessful response, is
+// forgotten, so a retry runs again, and a key reused for a different
+// request fails with AlreadyExists. A new key fails with ResourceExhausted
+// while the cache is full of requests still running.
+func (c *idempotencyCache) do(ctx context.Context, client string, req *pb.ExecuteDestructionRequest,
+	execute func() (*pb.ExecuteDestructionResponse, error)) (*pb.ExecuteDestructionResponse, bool, error) {
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		return nil, false, status.Errorf(codes.Internal, "failed to fingerprint request: %v", err)
+	}
+	key := client + "\x00" + req.IdempotencyKey
+
+	for {
+		entry, first, err := c.claim(key, fingerprint)
+		if err != nil {
+			return nil, false, err
+		}
+		if first {
+			response, err := execute()
+			c.finish(key, entry, response, err)
+			return response, false, err
+		}
+
+		if entry.fingerprint != fingerprint {
+			return nil, false, status.Errorf(codes.AlreadyExists,
+				"idempotency key %q was already used for a different request", req.IdempotencyKey)
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, false, status.FromContextError(ctx.Err()).Err()
+		}
+		if entry.response != nil {
+			return proto.Clone(entry.response).(*pb.ExecuteDestructionResponse), true, nil
+		}
+		// The first request failed, so this one takes its place
+	}
+}
+
+// claim returns key's live entry, or adds one for fingerprint and reports
+// that the caller runs the request. It fails when there's no room for the
+// new key.
+func (c *idempotencyCache) claim(key, fingerprint string) (*idempotencyEntry, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if entry, ok := c.entries[key]; ok {
+		if entry.expires.IsZero() || now.Before(entry.expires) {
+			return entry, false, nil
+		}
+		delete(c.entries, key)
+	}
+
+	if len(c.entries) >= maxIdempotencyKeys {
+		c.forgetOldest(now)
+		if len(c.entries) >= maxIdempotencyKeys {
+			return nil, false, status.Errorf(codes.ResourceExhausted,
+				"%d requests with idempotency keys are still running; retry once one finishes", len(c.entries))
+		}
+	}
+	entry := &idempotencyEntry{
+		fingerprint: fingerprint,
+		created:     now,
+		done:        make(chan struct{}),
+	}
+	c.entries[key] = entry
+	return entry, true, nil
+}
+
+// finish stores the response to the request that claimed entry, or forgets
+// the key when it failed or its response reports no success, and releases
+// the retries waiting for it
+func (c *idempotencyCache) finish(key string, entry *idempotencyEntry, response *pb.ExecuteDestructionResponse, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil || response == nil || !response.Success {
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+	} else {
+		entry.response = proto.Clone(response).(*pb.ExecuteDestructionResponse)
+		entry.expires = c.now().Add(c.ttl)
+	}
+	close(entry.done)
+}
+
+// forgetOldest drops expired keys, and the oldest finished one when none
+// have expired. Keys of requests still running are kept, since forgetting
+// one would let its retry destroy again. Callers must hold c.mu.
+func (c *idempotencyCache) forgetOldest(now time.Time) {
+	var oldestKey string
+	var oldest *idempotencyEntry
+	for key, entry := range c.entries {
+		if entry.expires.IsZero() {
+			continue
+		}
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldest == nil || entry.created.Before(oldest.created) {
+			oldestKey, oldest = key, entry
+		}
+	}
+	if len(c.entries) >= maxIdempotencyKeys && oldest != nil {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// requestFingerprint hashes everything in req but its idempotency key
+func requestFingerprint(req *pb.ExecuteDestructionRequest) (string, error) {
+	payload := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	payload.IdempotencyKey = ""
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// activityTracker records when the server last handled an RPC
+type activityTracker struct {
+	mu       sync.Mutex
+	last     time.Time
+	inFlight int
+}
+
+func newActivityTracker() *activityTracker {
+	return &activityTracker{last: time.Now()}
+}
+
+func (a *activityTracker) begin() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.inFlight++
+	a.last = time.Now()
+}
+
+func (a *activityTracker) end() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.inFlight--
+	a.last = time.Now()
+}
+
+// idleFor returns how long the server has had no RPC in flight
+func (a *activityTracker) idleFor(now time.Time) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.inFlight > 0 {
+		return 0
+	}
+	return now.Sub(a.last)
+}
+
+// unaryActivity resets the idle timer for every unary RPC except health
+// checks
+func (a *activityTracker) unaryActivity(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if isHealthCheck(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	a.begin()
+	defer a.end()
+	return handler(ctx, req)
+}
+
+// streamActivity resets the idle timer for every streaming RPC except
+// health watches
+func (a *activityTracker) streamActivity(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isHealthCheck(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	a.begin()
+	defer a.end()
+	return handler(srv, ss)
+}
+
+// watchIdle returns a channel that is closed once the server has been idle
+// for the configured timeout with no destruction task running. It returns
+// nil, which never fires, when the idle timeout is disabled.
+func (s *Server) watchIdle(ctx context.Context) <-chan struct{} {
+	timeout := s.config.Server.IdleTimeout
+	if timeout <= 0 {
+		return nil
+	}
+
+	interval := timeout / 10
+	if interval <= 0 {
+		interval = timeout
+	}
+
+	idle := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if s.activity.idleFor(now) >= timeout && len(s.engine.ListTasks()) == 0 {
+					close(idle)
+					return
+				}
+			}
+		}
+	}()
+
+	return idle
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+// serverMetrics counts what the server itself handles, alongside the
+// engine's destruction counters
+type serverMetrics struct {
+	startedAt time.Time
+	scenarios atomic.Int64
+	aiTokens  atomic.Int64
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{startedAt: time.Now()}
+}
+
+// scenarioGenerated counts a generated scenario and the tokens it used
+func (m *serverMetrics) scenarioGenerated(response *pb.GenerateAttackScenarioResponse) {
+	m.scenarios.Add(1)
+	m.aiTokens.Add(response.TokensUsed)
+}
+
+// GetMetrics implements the GetMetrics RPC
+func (s *Server) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	counters := s.engine.Counters()
+
+	return &pb.GetMetricsResponse{
+		Timestamp:               timestamppb.Now(),
+		StartedAt:               timestamppb.New(s.metrics.startedAt),
+		DestructionsTotal:       counters.Destructions,
+		DestructionsFailed:      counters.Failed,
+		FilesDeletedTotal:       counters.FilesDeleted,
+		BytesDestroyedTotal:     counters.BytesDestroyed,
+		ScenariosGeneratedTotal: s.metrics.scenarios.Load(),
+		AiTokensTotal:           s.metrics.aiTokens.Load(),
+	}, nil
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/BurnDevice/BurnDevice/internal/engine"
+)
+
+const (
+	// metricsReadHeaderTimeout bounds how long a scrape may take to send
+	// its request headers
+	metricsReadHeaderTimeout = 10 * time.Second
+	// metricsShutdownTimeout bounds how long the metrics endpoint waits
+	// for in-flight scrapes when the server stops
+	metricsShutdownTimeout = 5 * time.Second
+)
+
+// rpcMetrics counts the RPCs the server handles, by method and status
+// code. It is registered with the engine's metrics.
+type rpcMetrics struct {
+	calls *prometheus.CounterVec
+}
+
+func newRPCMetrics(metrics *engine.Metrics) *rpcMetrics {
+	m := &rpcMetrics{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "burndevice_rpcs_total",
+			Help: "RPCs handled, by method and status code.",
+		}, []string{"method", "code"}),
+	}
+
+	metrics.MustRegister(m.calls)
+	return m
+}
+
+// observe counts a finished call to fullMethod, e.g.
+// "/burndevice.v1.BurnDeviceService/ExecuteDestruction", as its method
+// name alone
+func (m *rpcMetrics) observe(fullMethod string, err error) {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	m.calls.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+// unaryCount counts unary calls, including those later interceptors reject
+func (m *rpcMetrics) unaryCount(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	m.observe(info.FullMethod, err)
+	return resp, err
+}
+
+// streamCount counts streaming calls once they end
+func (m *rpcMetrics) streamCount(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, stream)
+	m.observe(info.FullMethod, err)
+	return err
+}
+
+// metricsAddress returns where to serve /metrics: metrics.address when the
+// metrics section is enabled, else the deprecated server.metrics_port on
+// the server's host, else "" to not serve it
+func (s *Server) metricsAddress() string {
+	if s.config.Metrics.Enabled {
+		return s.config.Metrics.Address
+	}
+	if s.config.Server.MetricsPort > 0 {
+		s.logger.Warn("server.metrics_port is deprecated; use metrics.enabled and metrics.address")
+		return net.JoinHostPort(s.config.Server.Host, strconv.Itoa(s.config.Server.MetricsPort))
+	}
+	return ""
+}
+
+// serveMetrics serves /metrics on listener until the returned server is
+// shut down. Failures are sent to errChan.
+func (s *Server) serveMetrics(listener net.Listener, errChan chan<- error) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.engine.Metrics().Handler())
+
+	httpServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: metricsReadHeaderTimeout,
+	}
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			select {
+			case errChan <- fmt.Errorf("metrics endpoint failed: %w", err):
+			default:
+			}
+		}
+	}()
+
+	return httpServer
+}
+
+// stopMetrics shuts the metrics endpoint down, waiting briefly for
+// in-flight scrapes
+func (s *Server) stopMetrics(httpServer *http.Server) {
+	if httpServer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		s.logger.WithError(err).Warn("Failed to stop metrics endpoint")
+	}
+}
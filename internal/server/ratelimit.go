@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// maxRateLimitPeers bounds how many idle peers the limiter remembers
+const maxRateLimitPeers = 1024
+
+// rateLimiter allows each peer perMinute requests per minute, refilled
+// continuously so a peer that waits regains capacity gradually
+type rateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	buckets   map[string]*tokenBucket
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter, or nil when perMinute disables it
+func newRateLimiter(perMinute int) *rateLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		perMinute: perMinute,
+		buckets:   make(map[string]*tokenBucket),
+		now:       time.Now,
+	}
+}
+
+// allow takes a token from key's bucket, reporting false when it is empty
+func (l *rateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	capacity := float64(l.perMinute)
+	rate := capacity / time.Minute.Seconds()
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateLimitPeers {
+			l.forgetFull(now)
+		}
+		bucket = &tokenBucket{tokens: capacity, last: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens += now.Sub(bucket.last).Seconds() * rate
+	if bucket.tokens > capacity {
+		bucket.tokens = capacity
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// forgetFull drops peers whose buckets have refilled, since a new bucket
+// behaves the same. Callers must hold l.mu.
+func (l *rateLimiter) forgetFull(now time.Time) {
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= time.Minute {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// check rejects the call with ResourceExhausted when its peer is over the
+// limit. A nil limiter allows everything.
+func (l *rateLimiter) check(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	key := peerAddress(ctx)
+	if !l.allow(key) {
+		return status.Errorf(codes.ResourceExhausted, "rate limit of %d requests per minute exceeded for %s", l.perMinute, key)
+	}
+	return nil
+}
+
+// unaryRateLimit rejects unary RPCs from peers over the limit
+func (l *rateLimiter) unaryRateLimit(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := l.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamRateLimit rejects streaming RPCs from peers over the limit
+func (l *rateLimiter) streamRateLimit(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := l.check(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// peerAddress returns the caller's host without its port, so reconnecting
+// on a new port doesn't reset the limit
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
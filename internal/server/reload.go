@@ -0,0 +1,71 @@
+package server
+
+import (
+	"github.com/BurnDevice/BurnDevice/internal/config"
+)
+
+// liveSettings are the settings a reload applies while the server runs:
+// the rules the policy checks every request against. Everything else is
+// read once at startup.
+var liveSettings = map[string]func(applied, loaded *config.SecurityConfig){
+	"security.allowed_targets":              func(a, l *config.SecurityConfig) { a.AllowedTargets = l.AllowedTargets },
+	"security.blocked_targets":              func(a, l *config.SecurityConfig) { a.BlockedTargets = l.BlockedTargets },
+	"security.max_severity":                 func(a, l *config.SecurityConfig) { a.MaxSeverity = l.MaxSeverity },
+	"security.enabled_types":                func(a, l *config.SecurityConfig) { a.EnabledTypes = l.EnabledTypes },
+	"security.require_confirmation":         func(a, l *config.SecurityConfig) { a.RequireConfirmation = l.RequireConfirmation },
+	"security.confirmation_phrase":          func(a, l *config.SecurityConfig) { a.ConfirmationPhrase = l.ConfirmationPhrase },
+	"security.confirmation_phrase_severity": func(a, l *config.SecurityConfig) { a.ConfirmationPhraseSeverity = l.ConfirmationPhraseSeverity },
+	"security.allow_irreversible":           func(a, l *config.SecurityConfig) { a.AllowIrreversible = l.AllowIrreversible },
+	"security.max_duration":                 func(a, l *config.SecurityConfig) { a.MaxDuration = l.MaxDuration },
+	"security.allowed_windows":              func(a, l *config.SecurityConfig) { a.AllowedWindows = l.AllowedWindows },
+	"security.window_override_token":        func(a, l *config.SecurityConfig) { a.WindowOverrideToken = l.WindowOverrideToken },
+}
+
+// Reload applies cfg, a freshly loaded configuration, to the running
+// server as far as it can: allowed and blocked targets, severity limits
+// and the other policy rules are swapped in for the server and engine at
+// once, so they tighten or loosen without a restart. Every change is
+// logged, and those that can't be applied live, such as the server
+// address, are ignored with a warning until the next restart. It returns
+// the configuration now applied.
+func (s *Server) Reload(cfg *config.Config) *config.Config {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	changes := config.Diff(s.applied, cfg)
+	if len(changes) == 0 {
+		s.logger.Info("Configuration reloaded without changes")
+		return s.applied
+	}
+
+	applied := *s.applied
+	for _, change := range changes {
+		entry := s.logger.WithField("change", change.String())
+		apply, live := liveSettings[change.Field]
+		switch {
+		case live:
+			apply(&applied.Security, &cfg.Security)
+			entry.Warn("Configuration change applied")
+		case change.Field == "server.host" || change.Field == "server.port":
+			entry.Warn("⚠️  The server address can't change while it runs; change ignored until restart")
+		default:
+			entry.Warn("Configuration change needs a restart; ignored until then")
+		}
+	}
+
+	s.policy.Update(&applied.Security)
+	s.engine.UpdateSecurity(&applied.Security)
+	s.applied = &applied
+
+	s.logger.WithField("changes", len(changes)).Info("🔄 Configuration reloaded")
+	return s.applied
+}
+
+// security returns the security section in effect, with the changes
+// reloads have applied since startup
+func (s *Server) security() *config.SecurityConfig {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	return &s.applied.Security
+}
@@ -0,0 +1,520 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/engine"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
+	"github.com/BurnDevice/BurnDevice/internal/store"
+)
+
+const (
+	// scenarioDirName is the scenario directory inside the data directory
+	// when storage.scenario_dir is not set
+	scenarioDirName = "scenarios"
+	// legacyScenariosFileName is the JSON-lines file scenarios were kept in
+	// before each got a file of its own
+	legacyScenariosFileName = "scenarios.jsonl"
+	// scenarioFileExt ends every scenario file name
+	scenarioFileExt = ".json"
+)
+
+// scenarioIDPattern restricts scenario IDs to names that are safe to use
+// as file names
+var scenarioIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,127}$`)
+
+// scenarioStore keeps attack scenarios so requests can run them by ID.
+// When dir is set each scenario is written to a JSON file of its own there,
+// so they survive restarts and can be reviewed by hand.
+type scenarioStore struct {
+	mu         sync.Mutex
+	dir        string
+	legacyPath string
+	scenarios  map[string]*pb.GenerateAttackScenarioResponse
+	logger     *logrus.Logger
+}
+
+// newScenarioStore creates an empty store in storage's scenario directory.
+// With neither scenario_dir nor data_dir set, scenarios are kept in memory
+// only.
+func newScenarioStore(storage config.StorageConfig, logger *logrus.Logger) *scenarioStore {
+	s := &scenarioStore{
+		dir:       storage.ScenarioDir,
+		scenarios: make(map[string]*pb.GenerateAttackScenarioResponse),
+		logger:    logger,
+	}
+	if storage.DataDir != "" {
+		if s.dir == "" {
+			s.dir = filepath.Join(storage.DataDir, scenarioDirName)
+		}
+		s.legacyPath = filepath.Join(storage.DataDir, legacyScenariosFileName)
+	}
+	return s
+}
+
+// validScenarioID rejects IDs that can't name a scenario file
+func validScenarioID(id string) error {
+	if !scenarioIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid scenario ID %q: use up to 128 letters, digits, '_', '-' or '.'", id)
+	}
+	return nil
+}
+
+// load reads every scenario file, skipping unreadable ones, and moves
+// scenarios from the legacy JSON-lines file into files of their own
+func (s *scenarioStore) load() error {
+	if s.dir == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read scenarios: %w", err)
+	}
+
+	skipped := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), scenarioFileExt) {
+			continue
+		}
+		scenario, err := readScenarioFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil || scenario.ScenarioId+scenarioFileExt != entry.Name() {
+			skipped++
+			continue
+		}
+		s.scenarios[scenario.ScenarioId] = scenario
+	}
+	if skipped > 0 {
+		s.logger.WithField("skipped", skipped).Warn("Skipped unreadable scenarios")
+	}
+
+	return s.migrateLegacy()
+}
+
+// readScenarioFile decodes a scenario file. Scenarios saved without a
+// creation time take the file's modification time.
+func readScenarioFile(path string) (*pb.GenerateAttackScenarioResponse, error) {
+	// #nosec G304 - Path lies in the configured scenario directory
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	scenario := &pb.GenerateAttackScenarioResponse{}
+	if err := protojson.Unmarshal(data, scenario); err != nil {
+		return nil, err
+	}
+	if scenario.CreatedAt == nil {
+		if info, err := os.Stat(path); err == nil {
+			scenario.CreatedAt = timestamppb.New(info.ModTime())
+		}
+	}
+	return scenario, nil
+}
+
+// migrateLegacy writes each scenario of the legacy JSON-lines file that
+// has no file yet to one of its own, then removes the legacy file. Callers
+// must hold s.mu.
+func (s *scenarioStore) migrateLegacy() error {
+	if s.legacyPath == "" {
+		return nil
+	}
+
+	info, err := os.Stat(s.legacyPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read legacy scenarios: %w", err)
+	}
+	scenarios, _, err := store.ReadJSONLines(s.legacyPath,
+		func() *pb.GenerateAttackScenarioResponse { return &pb.GenerateAttackScenarioResponse{} },
+		func(scenario *pb.GenerateAttackScenarioResponse) bool {
+			return validScenarioID(scenario.ScenarioId) == nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to read legacy scenarios: %w", err)
+	}
+
+	migrated := 0
+	for _, scenario := range scenarios {
+		if _, ok := s.scenarios[scenario.ScenarioId]; ok {
+			continue
+		}
+		if scenario.CreatedAt == nil {
+			scenario.CreatedAt = timestamppb.New(info.ModTime())
+		}
+		if err := s.write(scenario); err != nil {
+			return err
+		}
+		s.scenarios[scenario.ScenarioId] = scenario
+		migrated++
+	}
+
+	if err := os.Remove(s.legacyPath); err != nil {
+		return fmt.Errorf("failed to remove legacy scenarios: %w", err)
+	}
+	s.logger.WithField("migrated", migrated).Info("Moved scenarios to the scenario directory")
+	return nil
+}
+
+// pathFor returns the file scenario id is kept in
+func (s *scenarioStore) pathFor(id string) string {
+	return filepath.Join(s.dir, id+scenarioFileExt)
+}
+
+// write replaces the file of scenario. Callers must hold s.mu.
+func (s *scenarioStore) write(scenario *pb.GenerateAttackScenarioResponse) error {
+	if s.dir == "" {
+		return nil
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(scenario)
+	if err != nil {
+		return fmt.Errorf("failed to encode scenario: %w", err)
+	}
+	if err := store.WriteFile(s.pathFor(scenario.ScenarioId), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write scenario: %w", err)
+	}
+	return nil
+}
+
+// add stores a scenario, replacing any with the same ID, and writes its
+// file. A scenario without a creation time is stamped with the current
+// time. The scenario is kept in memory even when writing the file fails.
+func (s *scenarioStore) add(scenario *pb.GenerateAttackScenarioResponse) error {
+	if err := validScenarioID(scenario.ScenarioId); err != nil {
+		return err
+	}
+	if scenario.CreatedAt == nil {
+		scenario.CreatedAt = timestamppb.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scenarios[scenario.ScenarioId] = scenario
+	return s.write(scenario)
+}
+
+// get returns the scenario with the given ID
+func (s *scenarioStore) get(id string) (*pb.GenerateAttackScenarioResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scenario, ok := s.scenarios[id]
+	return scenario, ok
+}
+
+// list summarizes every stored scenario, oldest first
+func (s *scenarioStore) list() []*pb.ScenarioSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summaries := make([]*pb.ScenarioSummary, 0, len(s.scenarios))
+	for _, scenario := range s.scenarios {
+		summaries = append(summaries, &pb.ScenarioSummary{
+			ScenarioId:        scenario.ScenarioId,
+			Description:       scenario.Description,
+			EstimatedSeverity: scenario.EstimatedSeverity,
+			CreatedAt:         scenario.CreatedAt,
+			Steps:             int32(len(scenario.Steps)),
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i].CreatedAt.AsTime(), summaries[j].CreatedAt.AsTime()
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return summaries[i].ScenarioId < summaries[j].ScenarioId
+	})
+	return summaries
+}
+
+// remove deletes the scenario with the given ID and its file, reporting
+// whether there was one
+func (s *scenarioStore) remove(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.scenarios[id]; !ok {
+		return false, nil
+	}
+	delete(s.scenarios, id)
+
+	if s.dir == "" {
+		return true, nil
+	}
+	if err := os.Remove(s.pathFor(id)); err != nil && !os.IsNotExist(err) {
+		return true, fmt.Errorf("failed to remove scenario: %w", err)
+	}
+	return true, nil
+}
+
+// runsStoredScenario reports whether a request asks for a stored scenario
+// to be run: it names one and leaves the type and targets to it. Requests
+// that set their own type only carry the ID for reference.
+func runsStoredScenario(scenarioID string, t pb.DestructionType, targets []string) bool {
+	return scenarioID != "" && t == pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED && len(targets) == 0
+}
+
+// storedScenarioSteps returns the steps of the stored scenario id in
+// execution order
+func (s *Server) storedScenarioSteps(id string) ([]*pb.AttackStep, error) {
+	scenario, ok := s.scenarios.get(id)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown scenario: %s", id)
+	}
+	if len(scenario.Steps) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "scenario %s has no steps", id)
+	}
+
+	// A step without a type would name the scenario again rather than run
+	for _, step := range scenario.Steps {
+		if step.Type == pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED {
+			return nil, status.Errorf(codes.FailedPrecondition, "scenario %s step %d has no destruction type", id, step.Order)
+		}
+	}
+
+	steps := append([]*pb.AttackStep(nil), scenario.Steps...)
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].Order < steps[j].Order
+	})
+	return steps, nil
+}
+
+// scenarioSeverity returns the severity a request for scenario id runs at
+// when it leaves the severity unspecified: the scenario's estimate, capped
+// at security.max_severity, with a note saying where it came from. It
+// returns an unspecified severity when the scenario is unknown or has no
+// estimate.
+func (s *Server) scenarioSeverity(id string) (pb.DestructionSeverity, string) {
+	scenario, ok := s.scenarios.get(id)
+	if !ok || scenario.EstimatedSeverity == pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED {
+		return pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED, ""
+	}
+
+	severity := scenario.EstimatedSeverity
+	maxSeverity := s.policy.MaxSeverity()
+	if severity > maxSeverity {
+		s.logger.WithFields(logrus.Fields{
+			"scenario_id":  id,
+			"estimated":    severity.String(),
+			"max_severity": maxSeverity.String(),
+		}).Warn("Scenario severity capped at the maximum allowed")
+		return maxSeverity, fmt.Sprintf(" (severity %s from scenario %s, estimate %s capped at max_severity)",
+			policy.SeverityName(maxSeverity), id, policy.SeverityName(severity))
+	}
+	return severity, fmt.Sprintf(" (severity %s from scenario %s)", policy.SeverityName(severity), id)
+}
+
+// executeScenario runs every step of a stored scenario as its own
+// destruction, in order. Every step is validated before the first runs,
+// and the first step that fails ends the scenario.
+func (s *Server) executeScenario(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	steps, err := s.storedScenarioSteps(req.AiScenarioId)
+	if err != nil {
+		return nil, err
+	}
+
+	stepReqs := make([]*pb.ExecuteDestructionRequest, len(steps))
+	for i, step := range steps {
+		stepReq := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+		stepReq.Type = step.Type
+		stepReq.Targets = step.Targets
+		if err := s.policy.ValidateRequest(stepReq); err != nil {
+			s.logger.WithError(err).WithField("scenario_id", req.AiScenarioId).Error("Scenario step validation failed")
+			s.engine.RequestRejected(stepReq.Type, err)
+			if irreversibleErr := irreversibleError(err); irreversibleErr != nil {
+				return nil, irreversibleErr
+			}
+			return &pb.ExecuteDestructionResponse{
+				Success: false,
+				Message: fmt.Sprintf("Validation failed: step %d: %s", step.Order, err.Error()),
+			}, nil
+		}
+		stepReqs[i] = stepReq
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"scenario_id": req.AiScenarioId,
+		"steps":       len(steps),
+	}).Warn("🔥 Running stored scenario")
+
+	response := &pb.ExecuteDestructionResponse{Success: true}
+	var taskIDs []string
+	for i, stepReq := range stepReqs {
+		step := &pb.ScenarioStepResult{
+			Order:       steps[i].Order,
+			Description: steps[i].Description,
+			Type:        steps[i].Type,
+		}
+		response.Steps = append(response.Steps, step)
+		if !response.Success {
+			step.Skipped = true
+			step.Message = "not run: an earlier step failed"
+			continue
+		}
+
+		stepResp, err := s.executeDestruction(ctx, stepReq)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, stepResp.Results...)
+		response.FailedTargets = append(response.FailedTargets, stepResp.FailedTargets...)
+		response.Timestamp = stepResp.Timestamp
+		step.TaskId = stepResp.TaskId
+		step.Success = stepResp.Success
+		step.Message = stepResp.Message
+		if stepResp.TaskId != "" {
+			taskIDs = append(taskIDs, stepResp.TaskId)
+		}
+
+		if !stepResp.Success {
+			response.Success = false
+			response.Message = fmt.Sprintf("Scenario %s stopped at step %d of %d: %s", req.AiScenarioId, i+1, len(stepReqs), stepResp.Message)
+		}
+	}
+	response.TaskId = strings.Join(taskIDs, ",")
+	response.TotalMetrics = engine.TotalMetrics(response.Results)
+	if response.Success {
+		response.Message = fmt.Sprintf("Scenario %s completed: %d steps", req.AiScenarioId, len(stepReqs))
+	}
+
+	return response, nil
+}
+
+// streamScenario streams every step of a stored scenario in order on the
+// same stream, validating them all before the first runs. STEP_STARTED and
+// STEP_FINISHED events frame each step, and the first step that fails
+// ends the scenario.
+func (s *Server) streamScenario(req *pb.StreamDestructionRequest, stream pb.BurnDeviceService_StreamDestructionServer) error {
+	steps, err := s.storedScenarioSteps(req.AiScenarioId)
+	if err != nil {
+		return err
+	}
+
+	stepReqs := make([]*pb.StreamDestructionRequest, len(steps))
+	for i, step := range steps {
+		stepReq := proto.Clone(req).(*pb.StreamDestructionRequest)
+		stepReq.Type = step.Type
+		stepReq.Targets = step.Targets
+		if err := s.policy.ValidateRequest(stepReq); err != nil {
+			s.engine.RequestRejected(stepReq.Type, err)
+			if irreversibleErr := irreversibleError(err); irreversibleErr != nil {
+				return irreversibleErr
+			}
+			return fmt.Errorf("validation failed: step %d: %w", step.Order, err)
+		}
+		stepReqs[i] = stepReq
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"scenario_id": req.AiScenarioId,
+		"steps":       len(steps),
+	}).Warn("🔥 Streaming stored scenario")
+
+	for i, stepReq := range stepReqs {
+		stepStream := &scenarioStepStream{BurnDeviceService_StreamDestructionServer: stream, step: int32(i + 1)}
+		if err := stepStream.boundary(pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_STARTED, float64(i)/float64(len(stepReqs)),
+			fmt.Sprintf("Scenario %s step %d of %d: %s", req.AiScenarioId, i+1, len(stepReqs), stepDescription(steps[i]))); err != nil {
+			return err
+		}
+
+		if err := s.StreamDestruction(stepReq, stepStream); err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"scenario_id": req.AiScenarioId,
+				"step":        i + 1,
+			}).Error("Stored scenario stopped")
+			return err
+		}
+
+		// A failed step ends the scenario on a stream just as it does
+		// for ExecuteDestruction
+		if stepStream.failure != "" {
+			s.logger.WithFields(logrus.Fields{
+				"scenario_id": req.AiScenarioId,
+				"step":        i + 1,
+			}).Error("Stored scenario stopped")
+			return stepStream.boundary(pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_FINISHED, float64(i+1)/float64(len(stepReqs)),
+				fmt.Sprintf("Scenario %s stopped at step %d of %d: %s", req.AiScenarioId, i+1, len(stepReqs), stepStream.failure))
+		}
+		if err := stepStream.boundary(pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_FINISHED, float64(i+1)/float64(len(stepReqs)),
+			fmt.Sprintf("Scenario %s step %d of %d finished", req.AiScenarioId, i+1, len(stepReqs))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stepDescription names a scenario step in events
+func stepDescription(step *pb.AttackStep) string {
+	if step.Description != "" {
+		return step.Description
+	}
+	return fmt.Sprintf("%s on %s", step.Type.String(), strings.Join(step.Targets, ", "))
+}
+
+// scenarioStepStream marks every event of a scenario step with the step
+// number and remembers why the step failed, if it did
+type scenarioStepStream struct {
+	pb.BurnDeviceService_StreamDestructionServer
+	step    int32
+	failure string
+}
+
+func (s *scenarioStepStream) Send(event *pb.StreamDestructionResponse) error {
+	event.Step = s.step
+	switch event.Type {
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR, pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED:
+		s.failure = event.Message
+	}
+	return s.BurnDeviceService_StreamDestructionServer.Send(event)
+}
+
+// boundary sends a step boundary event; progress is that of the whole
+// scenario
+func (s *scenarioStepStream) boundary(t pb.DestructionEventType, progress float64, message string) error {
+	return s.Send(&pb.StreamDestructionResponse{
+		Timestamp: timestamppb.New(time.Now()),
+		Type:      t,
+		Progress:  progress,
+		Message:   message,
+	})
+}
+
+// severityNoteStream appends a note on where the severity came from to the
+// events that end a destruction
+type severityNoteStream struct {
+	pb.BurnDeviceService_StreamDestructionServer
+	note string
+}
+
+func (s *severityNoteStream) Send(event *pb.StreamDestructionResponse) error {
+	switch event.Type {
+	case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED,
+		pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_ERROR,
+		pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_CANCELLED:
+		event.Message += s.note
+	}
+	return s.BurnDeviceService_StreamDestructionServer.Send(event)
+}
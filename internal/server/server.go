@@ -2,18 +2,31 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
+	"runtime"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
 	"github.com/BurnDevice/BurnDevice/internal/ai"
 	"github.com/BurnDevice/BurnDevice/internal/config"
 	"github.com/BurnDevice/BurnDevice/internal/engine"
+	"github.com/BurnDevice/BurnDevice/internal/ids"
+	"github.com/BurnDevice/BurnDevice/internal/policy"
 	"github.com/BurnDevice/BurnDevice/internal/system"
 )
 
@@ -22,40 +35,98 @@ type Server struct {
 	pb.UnimplementedBurnDeviceServiceServer
 
 	config     *config.Config
+	policy     *policy.Policy
 	grpcServer *grpc.Server
+	health     *health.Server
 	engine     *engine.DestructionEngine
-	aiClient   *ai.DeepSeekClient
-	sysInfo    *system.SystemInfo
+	aiClient   ai.AIProvider
+	sysInfo    system.SystemInfoCollector
 	logger     *logrus.Logger
+	activity   *activityTracker
+	privilege  system.Privilege
+	metrics    *serverMetrics
+	scenarios  *scenarioStore
+	// idempotency answers retried destruction requests; nil when
+	// server.idempotency_ttl disables it
+	idempotency *idempotencyCache
+	// applied is the configuration in effect, config plus the changes
+	// reloads have applied since startup
+	reloadMu sync.Mutex
+	applied  *config.Config
+}
+
+// Option customises a Server created by New
+type Option func(*Server)
+
+// WithSystemInfo makes the server report system information from collector
+// instead of the host it runs on
+func WithSystemInfo(collector system.SystemInfoCollector) Option {
+	return func(s *Server) {
+		s.sysInfo = collector
+	}
 }
 
 // New creates a new BurnDevice server
-func New(cfg *config.Config) (*Server, error) {
+func New(cfg *config.Config, opts ...Option) (*Server, error) {
 	logger := logrus.New()
 
 	// Create destruction engine
 	destructionEngine := engine.NewDestructionEngine(cfg)
 
 	// Create AI client
-	aiClient := ai.NewDeepSeekClient(&cfg.AI)
+	aiClient, err := ai.NewProvider(&cfg.AI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AI client: %w", err)
+	}
+
+	// Count RPCs alongside the engine's Prometheus metrics
+	rpcs := newRPCMetrics(destructionEngine.Metrics())
 
-	// Create system info collector
-	sysInfo := system.NewSystemInfo()
+	// Keep generated scenarios so requests can run them by ID
+	scenarios := newScenarioStore(cfg.Storage, logger)
+	if err := scenarios.load(); err != nil {
+		logger.WithError(err).Warn("Failed to load scenarios")
+	}
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	// Create gRPC server, rate limiting each peer before checking its token
+	// so guesses are throttled too, and only counting authenticated calls
+	// as activity for the idle timeout. Every call is counted for
+	// Prometheus, rejected ones included.
+	activity := newActivityTracker()
+	limiter := newRateLimiter(cfg.Security.RateLimitPerMinute)
+	auth := newTokenAuth(cfg.Security.AuthToken)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rpcs.unaryCount, limiter.unaryRateLimit, auth.unaryAuth, activity.unaryActivity),
+		grpc.ChainStreamInterceptor(rpcs.streamCount, limiter.streamRateLimit, auth.streamAuth, activity.streamActivity),
+	)
 
 	server := &Server{
 		config:     cfg,
+		policy:     policy.New(&cfg.Security),
 		grpcServer: grpcServer,
+		health:     health.NewServer(),
 		engine:     destructionEngine,
 		aiClient:   aiClient,
-		sysInfo:    sysInfo,
+		sysInfo:    system.NewSystemInfo(),
 		logger:     logger,
+		activity:   activity,
+		privilege:  system.CurrentPrivilege(),
+		metrics:    newServerMetrics(),
+		scenarios:  scenarios,
+
+		idempotency: newIdempotencyCache(cfg.Server.IdempotencyTTL),
+		applied:     cfg,
+	}
+	for _, opt := range opts {
+		opt(server)
 	}
 
-	// Register the service
+	// Register the service, and the standard health service for load
+	// balancers and readiness probes. The engine and AI client are ready
+	// by now, so both report SERVING until shutdown.
 	pb.RegisterBurnDeviceServiceServer(grpcServer, server)
+	healthpb.RegisterHealthServer(grpcServer, server.health)
+	setServing(server.health, true)
 
 	return server, nil
 }
@@ -69,10 +140,36 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to listen on %s: %w", address, err)
 	}
 
+	var metricsListener net.Listener
+	if metricsAddress := s.metricsAddress(); metricsAddress != "" {
+		metricsListener, err = net.Listen("tcp", metricsAddress)
+		if err != nil {
+			if closeErr := listener.Close(); closeErr != nil {
+				s.logger.WithError(closeErr).Warn("Failed to close gRPC listener")
+			}
+			return fmt.Errorf("failed to listen on %s: %w", metricsAddress, err)
+		}
+		s.logger.WithField("address", metricsAddress).Info("📈 Serving Prometheus metrics at /metrics")
+	}
+
 	s.logger.WithFields(logrus.Fields{
 		"address": address,
 		"tls":     s.config.Server.TLS.Enabled,
 	}).Info("🚀 Starting BurnDevice gRPC server")
+	s.logPrivilege()
+
+	// Run scheduled destructions while the server is up; pending schedules
+	// are persisted and picked up again on the next start
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		s.engine.RunScheduler(schedulerCtx)
+	}()
+	defer func() {
+		stopScheduler()
+		<-schedulerDone
+	}()
 
 	// Start server in goroutine
 	errChan := make(chan error, 1)
@@ -82,10 +179,23 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
-	// Wait for context cancellation or server error
+	if metricsListener != nil {
+		metricsServer := s.serveMetrics(metricsListener, errChan)
+		defer s.stopMetrics(metricsServer)
+	}
+
+	// Wait for context cancellation, idle timeout or server error
 	select {
 	case <-ctx.Done():
 		s.logger.Info("🛑 Shutting down server...")
+		s.health.Shutdown()
+		s.drainTasks()
+		s.grpcServer.GracefulStop()
+		return nil
+	case <-s.watchIdle(ctx):
+		s.logger.WithField("idle_timeout", s.config.Server.IdleTimeout).Warn("💤 No requests within idle timeout, shutting down server...")
+		s.health.Shutdown()
+		s.drainTasks()
 		s.grpcServer.GracefulStop()
 		return nil
 	case err := <-errChan:
@@ -93,8 +203,51 @@ func (s *Server) Start(ctx context.Context) error {
 	}
 }
 
+// drainTasks gives running tasks server.shutdown_timeout to finish before
+// the engine cancels them, so the RPCs running them return before the
+// gRPC server stops
+func (s *Server) drainTasks() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.engine.Shutdown(ctx); err != nil {
+		s.logger.WithError(err).Error("Failed to drain running tasks")
+	}
+}
+
 // ExecuteDestruction implements the ExecuteDestruction RPC
 func (s *Server) ExecuteDestruction(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
+	if req.IdempotencyKey == "" || s.idempotency == nil {
+		return s.executeDestruction(ctx, req)
+	}
+
+	// A retry with the same key gets the first response instead of
+	// destroying again
+	client := clientIdentity(ctx)
+	response, replayed, err := s.idempotency.do(ctx, client, req, func() (*pb.ExecuteDestructionResponse, error) {
+		return s.executeDestruction(ctx, req)
+	})
+	if replayed {
+		s.logger.WithFields(logrus.Fields{
+			"idempotency_key": req.IdempotencyKey,
+			"task_id":         response.TaskId,
+		}).Info("Returning the stored response to a repeated destruction request")
+		if s.config.Security.AuditLog {
+			s.auditLog("DESTRUCTION_REPLAYED", map[string]interface{}{
+				"task_id":         response.TaskId,
+				"type":            req.Type.String(),
+				"targets":         req.Targets,
+				"client":          client,
+				"idempotency_key": req.IdempotencyKey,
+			})
+		}
+	}
+	return response, err
+}
+
+// executeDestruction runs a destruction request, whether or not it carries
+// an idempotency key
+func (s *Server) executeDestruction(ctx context.Context, req *pb.ExecuteDestructionRequest) (*pb.ExecuteDestructionResponse, error) {
 	s.logger.WithFields(logrus.Fields{
 		"type":      req.Type.String(),
 		"targets":   req.Targets,
@@ -102,33 +255,78 @@ func (s *Server) ExecuteDestruction(ctx context.Context, req *pb.ExecuteDestruct
 		"confirmed": req.ConfirmDestruction,
 	}).Warn("🔥 Received destruction request")
 
+	// A scenario request that leaves the severity unspecified runs at the
+	// scenario's own estimate
+	if req.AiScenarioId != "" && req.Severity == pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED {
+		if severity, note := s.scenarioSeverity(req.AiScenarioId); severity != pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED {
+			scenarioReq := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+			scenarioReq.Severity = severity
+			response, err := s.executeDestruction(ctx, scenarioReq)
+			if response != nil {
+				response.Message += note
+			}
+			return response, err
+		}
+	}
+
+	if err := s.checkWindow(ctx, req.Type, req.Targets, req.DryRun, req.WindowOverrideToken, false); err != nil {
+		return nil, err
+	}
+
+	if runsStoredScenario(req.AiScenarioId, req.Type, req.Targets) {
+		return s.executeScenario(ctx, req)
+	}
+
 	// Security validation
-	if err := s.validateDestructionRequest(req); err != nil {
+	if err := s.policy.ValidateRequest(req); err != nil {
 		s.logger.WithError(err).Error("Destruction request validation failed")
+		s.engine.RequestRejected(req.Type, err)
+		if irreversibleErr := irreversibleError(err); irreversibleErr != nil {
+			return nil, irreversibleErr
+		}
 		return &pb.ExecuteDestructionResponse{
 			Success: false,
 			Message: fmt.Sprintf("Validation failed: %s", err.Error()),
 		}, nil
 	}
 
-	// Execute destruction
+	s.auditIrreversible(req.Type, req.Targets, req.Severity, req.DryRun, false)
+
+	// Execute destruction on behalf of the calling client
+	ctx = engine.WithClientIdentity(ctx, clientIdentity(ctx))
 	response, err := s.engine.ExecuteDestruction(ctx, req)
 	if err != nil {
 		s.logger.WithError(err).Error("Destruction execution failed")
+		if quotaErr := quotaError(err); quotaErr != nil {
+			return nil, quotaErr
+		}
 		return &pb.ExecuteDestructionResponse{
 			Success: false,
 			Message: fmt.Sprintf("Execution failed: %s", err.Error()),
 		}, nil
 	}
 
-	// Audit logging
+	// Audit logging, keeping previews apart from real executions
 	if s.config.Security.AuditLog {
-		s.auditLog("DESTRUCTION_EXECUTED", map[string]interface{}{
-			"type":     req.Type.String(),
-			"targets":  req.Targets,
-			"severity": req.Severity.String(),
-			"success":  response.Success,
-		})
+		action := "DESTRUCTION_EXECUTED"
+		if req.DryRun {
+			action = "DESTRUCTION_DRY_RUN"
+		}
+		details := map[string]interface{}{
+			"task_id":      response.TaskId,
+			"type":         req.Type.String(),
+			"targets":      req.Targets,
+			"severity":     req.Severity.String(),
+			"success":      response.Success,
+			"started_at":   auditTime(response.StartedAt),
+			"completed_at": auditTime(response.CompletedAt),
+			"target_times": targetTimes(response.Results),
+			"hooks":        auditHooks(response.HookResults),
+		}
+		if req.IdempotencyKey != "" {
+			details["idempotency_key"] = req.IdempotencyKey
+		}
+		s.auditLog(action, details)
 	}
 
 	return response, nil
@@ -143,7 +341,7 @@ func (s *Server) GetSystemInfo(ctx context.Context, req *pb.GetSystemInfoRequest
 		return nil, fmt.Errorf("failed to collect system info: %w", err)
 	}
 
-	return &pb.GetSystemInfoResponse{
+	response := &pb.GetSystemInfoResponse{
 		Os:              info.OS,
 		Architecture:    info.Architecture,
 		Hostname:        info.Hostname,
@@ -156,7 +354,25 @@ func (s *Server) GetSystemInfo(ctx context.Context, req *pb.GetSystemInfoRequest
 			AvailableDisk:   info.Resources.AvailableDisk,
 			CpuUsage:        info.Resources.CPUUsage,
 		},
-	}, nil
+	}
+
+	// Disk fills land on the target's filesystem, so report the allowed
+	// target directories alongside the critical paths
+	disks := append(info.PathDisks, s.sysInfo.DiskUsage(s.security().AllowedTargets)...)
+	seen := make(map[string]bool)
+	for _, disk := range disks {
+		if seen[disk.Path] {
+			continue
+		}
+		seen[disk.Path] = true
+		response.PathDisks = append(response.PathDisks, &pb.PathDiskUsage{
+			Path:          disk.Path,
+			TotalDisk:     disk.Total,
+			AvailableDisk: disk.Available,
+		})
+	}
+
+	return response, nil
 }
 
 // GenerateAttackScenario implements the GenerateAttackScenario RPC
@@ -177,12 +393,35 @@ func (s *Server) GenerateAttackScenario(ctx context.Context, req *pb.GenerateAtt
 		return nil, fmt.Errorf("AI API key not configured")
 	}
 
+	// Keep the scenario to types the server will actually run
+	allowedTypes, err := s.scenarioTypes(req.AllowedTypes)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req = proto.Clone(req).(*pb.GenerateAttackScenarioRequest)
+	req.AllowedTypes = allowedTypes
+
 	// Generate scenario using AI
 	response, err := s.aiClient.GenerateAttackScenario(ctx, req)
 	if err != nil {
+		if ctx.Err() != nil {
+			s.logger.WithError(err).Info("AI scenario generation cancelled by the client")
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
 		s.logger.WithError(err).Error("AI scenario generation failed")
 		return nil, fmt.Errorf("scenario generation failed: %w", err)
 	}
+	s.metrics.scenarioGenerated(response)
+
+	// The tokens are spent either way, but a scenario the client is no
+	// longer waiting for is not recorded
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	if err := s.scenarios.add(response); err != nil {
+		s.logger.WithError(err).WithField("scenario_id", response.ScenarioId).Warn("Failed to persist scenario")
+	}
 
 	// Audit logging
 	if s.config.Security.AuditLog {
@@ -197,6 +436,94 @@ func (s *Server) GenerateAttackScenario(ctx context.Context, req *pb.GenerateAtt
 	return response, nil
 }
 
+// SaveScenario implements the SaveScenario RPC
+func (s *Server) SaveScenario(ctx context.Context, req *pb.SaveScenarioRequest) (*pb.SaveScenarioResponse, error) {
+	if req.Scenario == nil {
+		return nil, status.Error(codes.InvalidArgument, "a scenario must be provided")
+	}
+
+	scenario := proto.Clone(req.Scenario).(*pb.GenerateAttackScenarioResponse)
+	if scenario.ScenarioId == "" {
+		scenario.ScenarioId = ids.Scenario()
+	}
+	if err := validScenarioID(scenario.ScenarioId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// The ID is stored afresh, so the creation time is now
+	scenario.CreatedAt = nil
+
+	if err := s.scenarios.add(scenario); err != nil {
+		s.logger.WithError(err).WithField("scenario_id", scenario.ScenarioId).Error("Save scenario failed")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if s.config.Security.AuditLog {
+		s.auditLog("AI_SCENARIO_SAVED", map[string]interface{}{
+			"scenario_id": scenario.ScenarioId,
+			"steps_count": len(scenario.Steps),
+		})
+	}
+
+	return &pb.SaveScenarioResponse{ScenarioId: scenario.ScenarioId}, nil
+}
+
+// GetScenario implements the GetScenario RPC
+func (s *Server) GetScenario(ctx context.Context, req *pb.GetScenarioRequest) (*pb.GetScenarioResponse, error) {
+	scenario, ok := s.scenarios.get(req.ScenarioId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown scenario: %s", req.ScenarioId)
+	}
+	return &pb.GetScenarioResponse{Scenario: scenario}, nil
+}
+
+// ListScenarios implements the ListScenarios RPC
+func (s *Server) ListScenarios(ctx context.Context, req *pb.ListScenariosRequest) (*pb.ListScenariosResponse, error) {
+	return &pb.ListScenariosResponse{Scenarios: s.scenarios.list()}, nil
+}
+
+// DeleteScenario implements the DeleteScenario RPC
+func (s *Server) DeleteScenario(ctx context.Context, req *pb.DeleteScenarioRequest) (*pb.DeleteScenarioResponse, error) {
+	deleted, err := s.scenarios.remove(req.ScenarioId)
+	if err != nil {
+		s.logger.WithError(err).Error("Delete scenario failed")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if deleted && s.config.Security.AuditLog {
+		s.auditLog("AI_SCENARIO_DELETED", map[string]interface{}{
+			"scenario_id": req.ScenarioId,
+		})
+	}
+
+	return &pb.DeleteScenarioResponse{Deleted: deleted}, nil
+}
+
+// scenarioTypes combines the types a scenario request allows with the
+// server's enabled_types. Nil means every type is allowed.
+func (s *Server) scenarioTypes(requested []pb.DestructionType) ([]pb.DestructionType, error) {
+	if len(requested) == 0 {
+		if len(s.security().EnabledTypes) == 0 {
+			return nil, nil
+		}
+		for value := range pb.DestructionType_name {
+			requested = append(requested, pb.DestructionType(value))
+		}
+		sort.Slice(requested, func(i, j int) bool { return requested[i] < requested[j] })
+	}
+
+	var allowed []pb.DestructionType
+	for _, t := range requested {
+		if t != pb.DestructionType_DESTRUCTION_TYPE_UNSPECIFIED && s.policy.TypeEnabled(t) {
+			allowed = append(allowed, t)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("none of the allowed destruction types are enabled on this server")
+	}
+
+	return allowed, nil
+}
+
 // StreamDestruction implements the StreamDestruction RPC
 func (s *Server) StreamDestruction(req *pb.StreamDestructionRequest, stream pb.BurnDeviceService_StreamDestructionServer) error {
 	s.logger.WithFields(logrus.Fields{
@@ -205,99 +532,475 @@ func (s *Server) StreamDestruction(req *pb.StreamDestructionRequest, stream pb.B
 		"severity": req.Severity.String(),
 	}).Warn("🔥 Starting streaming destruction")
 
+	// A scenario request that leaves the severity unspecified runs at the
+	// scenario's own estimate
+	if req.AiScenarioId != "" && req.Severity == pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED {
+		if severity, note := s.scenarioSeverity(req.AiScenarioId); severity != pb.DestructionSeverity_DESTRUCTION_SEVERITY_UNSPECIFIED {
+			scenarioReq := proto.Clone(req).(*pb.StreamDestructionRequest)
+			scenarioReq.Severity = severity
+			return s.StreamDestruction(scenarioReq, &severityNoteStream{BurnDeviceService_StreamDestructionServer: stream, note: note})
+		}
+	}
+
+	if err := s.checkWindow(stream.Context(), req.Type, req.Targets, req.DryRun, req.WindowOverrideToken, true); err != nil {
+		return err
+	}
+
+	if runsStoredScenario(req.AiScenarioId, req.Type, req.Targets) {
+		return s.streamScenario(req, stream)
+	}
+
 	// Security validation
-	if err := s.validateStreamDestructionRequest(req); err != nil {
+	if err := s.policy.ValidateRequest(req); err != nil {
+		s.engine.RequestRejected(req.Type, err)
+		if irreversibleErr := irreversibleError(err); irreversibleErr != nil {
+			return irreversibleErr
+		}
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	s.auditIrreversible(req.Type, req.Targets, req.Severity, req.DryRun, true)
+
+	// Execute destruction with streaming on behalf of the calling client
+	ctx := engine.WithClientIdentity(stream.Context(), clientIdentity(stream.Context()))
+	audited := &auditedStream{BurnDeviceService_StreamDestructionServer: stream}
+	if err := s.engine.StreamDestruction(ctx, req, audited); err != nil {
+		if quotaErr := quotaError(err); quotaErr != nil {
+			return quotaErr
+		}
+		if errors.Is(err, engine.ErrNotImplemented) {
+			return status.Error(codes.Unimplemented, err.Error())
+		}
+		return err
+	}
+
+	// Audit logging, keeping previews apart from real executions
+	if s.config.Security.AuditLog {
+		action := "DESTRUCTION_EXECUTED"
+		if req.DryRun {
+			action = "DESTRUCTION_DRY_RUN"
+		}
+		s.auditLog(action, map[string]interface{}{
+			"task_id":      audited.taskID,
+			"type":         req.Type.String(),
+			"targets":      req.Targets,
+			"severity":     req.Severity.String(),
+			"success":      audited.last == pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_COMPLETED,
+			"stream":       true,
+			"started_at":   auditTime(audited.final.GetStartedAt()),
+			"completed_at": auditTime(audited.final.GetCompletedAt()),
+			"target_times": targetTimes(audited.final.GetResults()),
+			"hooks":        auditHooks(audited.final.GetHookResults()),
+		})
+	}
+	return nil
+}
+
+// auditedStream remembers the task ID, the type of the last event sent on
+// a stream and the final event carrying the results, for the audit entry
+// written once the stream ends
+type auditedStream struct {
+	pb.BurnDeviceService_StreamDestructionServer
+	taskID string
+	last   pb.DestructionEventType
+	final  *pb.StreamDestructionResponse
+}
+
+func (s *auditedStream) Send(event *pb.StreamDestructionResponse) error {
+	if event.TaskId != "" {
+		s.taskID = event.TaskId
+	}
+	s.last = event.Type
+	if event.CompletedAt != nil || len(event.Results) > 0 {
+		s.final = event
+	}
+	return s.BurnDeviceService_StreamDestructionServer.Send(event)
+}
+
+// RestoreDestruction implements the RestoreDestruction RPC
+func (s *Server) RestoreDestruction(ctx context.Context, req *pb.RestoreDestructionRequest) (*pb.RestoreDestructionResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"task_id": req.TaskId,
+		"targets": req.Targets,
+		"force":   req.Force,
+	}).Warn("♻️ Received restore request")
+
+	response, err := s.engine.RestoreDestruction(ctx, req)
+	if err != nil {
+		s.logger.WithError(err).Error("Restore failed")
+		return &pb.RestoreDestructionResponse{
+			Success: false,
+			Message: fmt.Sprintf("Restore failed: %s", err.Error()),
+		}, nil
+	}
+
+	// Audit logging
+	if s.config.Security.AuditLog {
+		s.auditLog("DESTRUCTION_RESTORED", map[string]interface{}{
+			"task_id":       req.TaskId,
+			"targets":       req.Targets,
+			"force":         req.Force,
+			"remove_backup": req.RemoveBackup,
+			"success":       response.Success,
+		})
+	}
+
+	return response, nil
+}
+
+// VerifyBackup implements the VerifyBackup RPC
+func (s *Server) VerifyBackup(ctx context.Context, req *pb.VerifyBackupRequest) (*pb.VerifyBackupResponse, error) {
+	response, err := s.engine.VerifyBackup(ctx, req)
+	if err != nil {
+		s.logger.WithError(err).Error("Backup verification failed")
+		return &pb.VerifyBackupResponse{
+			Success: false,
+			Message: fmt.Sprintf("Verification failed: %s", err.Error()),
+		}, nil
+	}
+
+	return response, nil
+}
+
+// CleanupBackups implements the CleanupBackups RPC
+func (s *Server) CleanupBackups(ctx context.Context, req *pb.CleanupBackupsRequest) (*pb.CleanupBackupsResponse, error) {
+	response, err := s.engine.CleanupBackups(req.Dir, req.OlderThan.AsDuration())
+	if err != nil {
+		s.logger.WithError(err).Error("Backup cleanup failed")
+		return &pb.CleanupBackupsResponse{
+			Success: false,
+			Message: fmt.Sprintf("Cleanup failed: %s", err.Error()),
+		}, nil
+	}
+
+	if s.config.Security.AuditLog {
+		s.auditLog("BACKUPS_CLEANED", map[string]interface{}{
+			"dir":         req.Dir,
+			"older_than":  req.OlderThan.AsDuration().String(),
+			"removed":     len(response.Removed),
+			"bytes_freed": response.BytesFreed,
+		})
+	}
+
+	return response, nil
+}
+
+// GetTaskStatus implements the GetTaskStatus RPC
+func (s *Server) GetTaskStatus(ctx context.Context, req *pb.GetTaskStatusRequest) (*pb.GetTaskStatusResponse, error) {
+	task, err := s.engine.GetTaskStatus(req.TaskId)
+	if err != nil {
+		return nil, taskError(err)
+	}
 
-	// Execute destruction with streaming
-	return s.engine.StreamDestruction(stream.Context(), req, stream)
+	return &pb.GetTaskStatusResponse{Task: task}, nil
 }
 
-// Validation helpers
-func (s *Server) validateDestructionRequest(req *pb.ExecuteDestructionRequest) error {
-	// Check confirmation requirement
-	if s.config.Security.RequireConfirmation && !req.ConfirmDestruction {
-		return fmt.Errorf("destruction must be confirmed")
+// ListTasks implements the ListTasks RPC
+func (s *Server) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	return &pb.ListTasksResponse{Tasks: s.engine.ListTasks()}, nil
+}
+
+// GetTaskHistory implements the GetTaskHistory RPC
+func (s *Server) GetTaskHistory(ctx context.Context, req *pb.GetTaskHistoryRequest) (*pb.GetTaskHistoryResponse, error) {
+	response, err := s.engine.GetTaskHistory(req)
+	if err != nil {
+		if errors.Is(err, engine.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	// Check severity limits
-	maxSeverity := s.getSeverityLevel(s.config.Security.MaxSeverity)
-	if int32(req.Severity) > maxSeverity {
-		return fmt.Errorf("requested severity exceeds maximum allowed (%s)", s.config.Security.MaxSeverity)
+	return response, nil
+}
+
+// SubscribeEvents implements the SubscribeEvents RPC
+func (s *Server) SubscribeEvents(req *pb.SubscribeEventsRequest, stream pb.BurnDeviceService_SubscribeEventsServer) error {
+	events, unsubscribe := s.engine.SubscribeEvents()
+	defer unsubscribe()
+
+	s.logger.WithField("task_id", req.TaskId).Info("Event subscriber connected")
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "subscriber fell too far behind and was dropped")
+			}
+			if req.TaskId != "" && event.TaskId != req.TaskId {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
 	}
+}
+
+// ExpandTargets implements the ExpandTargets RPC
+func (s *Server) ExpandTargets(ctx context.Context, req *pb.ExpandTargetsRequest) (*pb.ExpandTargetsResponse, error) {
+	s.logger.WithFields(logrus.Fields{
+		"pattern": req.Pattern,
+		"root":    req.Root,
+	}).Info("🔍 Expanding target pattern")
 
-	// Check target restrictions
-	for _, target := range req.Targets {
-		if s.isBlockedTarget(target) {
-			return fmt.Errorf("target is blocked: %s", target)
+	response, err := s.engine.ExpandTargets(ctx, req)
+	if err != nil {
+		var rejected *policy.RejectedError
+		if errors.Is(err, policy.ErrInvalidPattern) || errors.As(err, &rejected) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
 		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 
-		if len(s.config.Security.AllowedTargets) > 0 && !s.isAllowedTarget(target) {
-			return fmt.Errorf("target is not in allowed list: %s", target)
+	return response, nil
+}
+
+// PlanDestruction implements the PlanDestruction RPC
+func (s *Server) PlanDestruction(ctx context.Context, req *pb.PlanDestructionRequest) (*pb.PlanDestructionResponse, error) {
+	s.logger.WithField("targets", req.Targets).Info("📋 Planning destruction")
+
+	response, err := s.engine.PlanDestruction(ctx, req)
+	if err != nil {
+		if errors.Is(err, policy.ErrInvalidPattern) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
+		return nil, status.FromContextError(err).Err()
 	}
 
-	return nil
+	return response, nil
 }
 
-func (s *Server) validateStreamDestructionRequest(req *pb.StreamDestructionRequest) error {
-	// Check confirmation requirement
-	if s.config.Security.RequireConfirmation && !req.ConfirmDestruction {
-		return fmt.Errorf("destruction must be confirmed")
+// CancelDestruction implements the CancelDestruction RPC
+func (s *Server) CancelDestruction(ctx context.Context, req *pb.CancelDestructionRequest) (*pb.CancelDestructionResponse, error) {
+	s.logger.WithField("task_id", req.TaskId).Warn("🛑 Received cancel request")
+
+	task, err := s.engine.CancelDestruction(req.TaskId)
+	if errors.Is(err, engine.ErrTaskNotFound) {
+		return &pb.CancelDestructionResponse{
+			Found:   false,
+			Message: fmt.Sprintf("No running task with ID %s", req.TaskId),
+		}, nil
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Cancel failed")
+		return nil, taskError(err)
 	}
 
-	// Check severity limits
-	maxSeverity := s.getSeverityLevel(s.config.Security.MaxSeverity)
-	if int32(req.Severity) > maxSeverity {
-		return fmt.Errorf("requested severity exceeds maximum allowed (%s)", s.config.Security.MaxSeverity)
+	// Audit logging
+	if s.config.Security.AuditLog {
+		s.auditLog("DESTRUCTION_CANCELLED", map[string]interface{}{
+			"task_id":           req.TaskId,
+			"targets":           task.Targets,
+			"targets_processed": task.TargetsProcessed,
+		})
 	}
 
-	// Check target restrictions
-	for _, target := range req.Targets {
-		if s.isBlockedTarget(target) {
-			return fmt.Errorf("target is blocked: %s", target)
+	return &pb.CancelDestructionResponse{
+		Found:            true,
+		Message:          fmt.Sprintf("Task %s cancelled after %d of %d targets", req.TaskId, task.TargetsProcessed, len(task.Targets)),
+		TargetsProcessed: task.TargetsProcessed,
+		Task:             task,
+	}, nil
+}
+
+// ScheduleDestruction implements the ScheduleDestruction RPC
+func (s *Server) ScheduleDestruction(ctx context.Context, req *pb.ScheduleDestructionRequest) (*pb.ScheduleDestructionResponse, error) {
+	if req.Request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"type":     req.Request.Type.String(),
+		"targets":  req.Request.Targets,
+		"severity": req.Request.Severity.String(),
+		"delay":    req.Delay.AsDuration().String(),
+		"cron":     req.Cron,
+	}).Warn("⏰ Scheduling destruction")
+
+	if err := s.policy.ValidateRequest(req.Request); err != nil {
+		s.engine.RequestRejected(req.Request.Type, err)
+		if irreversibleErr := irreversibleError(err); irreversibleErr != nil {
+			return nil, irreversibleErr
 		}
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("validation failed: %s", err.Error()))
+	}
 
-		if len(s.config.Security.AllowedTargets) > 0 && !s.isAllowedTarget(target) {
-			return fmt.Errorf("target is not in allowed list: %s", target)
+	ctx = engine.WithClientIdentity(ctx, clientIdentity(ctx))
+	schedule, err := s.engine.ScheduleDestruction(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, engine.ErrInvalidSchedule):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, engine.ErrNotImplemented):
+			return nil, status.Error(codes.Unimplemented, err.Error())
 		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	// Audit logging
+	if s.config.Security.AuditLog {
+		s.auditLog("DESTRUCTION_SCHEDULED", map[string]interface{}{
+			"schedule_id": schedule.ScheduleId,
+			"type":        schedule.Request.Type.String(),
+			"targets":     schedule.Request.Targets,
+			"severity":    schedule.Request.Severity.String(),
+			"next_run":    schedule.NextRun.AsTime().Format(time.RFC3339),
+			"cron":        schedule.Cron,
+			"client":      schedule.Client,
+		})
+	}
+
+	return &pb.ScheduleDestructionResponse{Schedule: schedule}, nil
+}
+
+// ListSchedules implements the ListSchedules RPC
+func (s *Server) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	return &pb.ListSchedulesResponse{Schedules: s.engine.ListSchedules()}, nil
+}
+
+// DeleteSchedule implements the DeleteSchedule RPC
+func (s *Server) DeleteSchedule(ctx context.Context, req *pb.DeleteScheduleRequest) (*pb.DeleteScheduleResponse, error) {
+	deleted, err := s.engine.DeleteSchedule(req.ScheduleId)
+	if err != nil {
+		s.logger.WithError(err).Error("Delete schedule failed")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if deleted && s.config.Security.AuditLog {
+		s.auditLog("SCHEDULE_DELETED", map[string]interface{}{
+			"schedule_id": req.ScheduleId,
+		})
+	}
+
+	return &pb.DeleteScheduleResponse{Deleted: deleted}, nil
+}
+
+// GetServerInfo implements the GetServerInfo RPC
+func (s *Server) GetServerInfo(ctx context.Context, req *pb.GetServerInfoRequest) (*pb.GetServerInfoResponse, error) {
+	return &pb.GetServerInfoResponse{
+		Hostname:         getHostname(),
+		Os:               runtime.GOOS,
+		Uid:              s.privilege.UID,
+		Username:         s.privilege.Username,
+		Privileged:       s.privilege.Privileged,
+		AllowRoot:        s.config.Security.AllowRoot,
+		ConnectionBanner: s.config.Server.ConnectionBanner,
+	}, nil
+}
+
+// CheckCapabilities implements the CheckCapabilities RPC
+func (s *Server) CheckCapabilities(ctx context.Context, req *pb.CheckCapabilitiesRequest) (*pb.CheckCapabilitiesResponse, error) {
+	return s.engine.CheckCapabilities(), nil
+}
+
+// logPrivilege logs the account the server runs as. Running privileged
+// widens the blast radius of any path validation bug, so it is flagged
+// loudly unless security.allow_root acknowledges it.
+func (s *Server) logPrivilege() {
+	fields := logrus.Fields{
+		"uid":        s.privilege.UID,
+		"user":       s.privilege.Username,
+		"privileged": s.privilege.Privileged,
+	}
+
+	if s.privilege.Privileged && !s.config.Security.AllowRoot {
+		s.logger.WithFields(fields).Warn("⚠️  Running with root/Administrator privileges without security.allow_root; anything the server can reach can be destroyed")
+		return
+	}
+	s.logger.WithFields(fields).Info("Running as")
+}
+
+// clientIdentity identifies the caller by its peer address. The server
+// authenticates with one shared token, so nothing the caller sends tells
+// clients apart, and trusting it would let a caller pick a fresh identity,
+// and a fresh quota, per request.
+func clientIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "anonymous"
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return "addr:" + host
+	}
+	return "addr:" + p.Addr.String()
+}
+
+// quotaError converts an engine quota or budget rejection into a gRPC
+// status
+func quotaError(err error) error {
+	var quotaErr *engine.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return status.Error(codes.ResourceExhausted, quotaErr.Error())
+	}
+	var budgetErr *engine.BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return status.Error(codes.ResourceExhausted, budgetErr.Error())
+	}
 	return nil
 }
 
-func (s *Server) getSeverityLevel(severity string) int32 {
-	switch severity {
-	case "LOW":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW)
-	case "MEDIUM":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM)
-	case "HIGH":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH)
-	case "CRITICAL":
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL)
-	default:
-		return int32(pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW)
+// irreversibleError maps a missing safeguard for a type that cannot be
+// undone onto a gRPC status error, or returns nil for other errors
+func irreversibleError(err error) error {
+	if errors.Is(err, policy.ErrIrreversibleNotAcknowledged) {
+		return status.Error(codes.FailedPrecondition, err.Error())
 	}
+	return nil
 }
 
-func (s *Server) isBlockedTarget(target string) bool {
-	for _, blocked := range s.config.Security.BlockedTargets {
-		if target == blocked || (len(target) > len(blocked) && target[:len(blocked)] == blocked) {
-			return true
-		}
+// auditIrreversible records that a request for a type that cannot be
+// undone was acknowledged and is about to run
+func (s *Server) auditIrreversible(t pb.DestructionType, targets []string, severity pb.DestructionSeverity, dryRun, stream bool) {
+	if !s.config.Security.AuditLog || dryRun || !policy.IrreversibleType(t) {
+		return
 	}
-	return false
+	s.auditLog("IRREVERSIBLE_ACKNOWLEDGED", map[string]interface{}{
+		"type":     t.String(),
+		"targets":  targets,
+		"severity": severity.String(),
+		"stream":   stream,
+	})
 }
 
-func (s *Server) isAllowedTarget(target string) bool {
-	for _, allowed := range s.config.Security.AllowedTargets {
-		if target == allowed || (len(target) > len(allowed) && target[:len(allowed)] == allowed) {
-			return true
-		}
+// checkWindow rejects a request that arrives outside
+// security.allowed_windows with FailedPrecondition, unless it is a dry run
+// or carries the window override token. Overrides are audited even with
+// audit_log off, since they bypass a safeguard.
+func (s *Server) checkWindow(ctx context.Context, t pb.DestructionType, targets []string, dryRun bool, overrideToken string, stream bool) error {
+	if dryRun {
+		return nil
+	}
+	err := s.policy.CheckWindow(time.Now())
+	if err == nil {
+		return nil
+	}
+
+	if s.policy.WindowOverride(overrideToken) {
+		s.logger.WithError(err).Warn("⚠️  Destruction outside the allowed windows by override token")
+		s.auditLog("WINDOW_OVERRIDDEN", map[string]interface{}{
+			"type":    t.String(),
+			"targets": targets,
+			"client":  clientIdentity(ctx),
+			"stream":  stream,
+		})
+		return nil
+	}
+
+	s.logger.WithError(err).Error("Destruction request outside the allowed windows")
+	s.engine.RequestRejected(t, err)
+	return status.Error(codes.FailedPrecondition, err.Error())
+}
+
+// taskError maps engine task lookup failures onto gRPC status errors
+func taskError(err error) error {
+	if errors.Is(err, engine.ErrTaskNotFound) {
+		return status.Error(codes.NotFound, err.Error())
 	}
-	return false
+	return status.Error(codes.Internal, err.Error())
 }
 
 func (s *Server) auditLog(action string, details map[string]interface{}) {
@@ -315,6 +1018,42 @@ func (s *Server) auditLog(action string, details map[string]interface{}) {
 	logEntry.Info("🔍 Audit log entry")
 }
 
+// auditTime formats a timestamp for the audit log, or "" when unset
+func auditTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339Nano)
+}
+
+// targetTimes maps each attempted target to when work on it started and
+// completed, e.g. "2024-01-02T15:04:05Z..2024-01-02T15:04:06Z"
+func targetTimes(results []*pb.DestructionResult) map[string]string {
+	times := make(map[string]string, len(results))
+	for _, result := range results {
+		if result.CompletedAt == nil {
+			continue
+		}
+		times[result.Target] = auditTime(result.StartedAt) + ".." + auditTime(result.CompletedAt)
+	}
+	return times
+}
+
+// auditHooks describes a task's pre and post execute hooks for the audit
+// log. Their output is already capped at security.max_command_output.
+func auditHooks(hooks []*pb.HookResult) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(hooks))
+	for _, hook := range hooks {
+		entries = append(entries, map[string]interface{}{
+			"phase":     hook.Phase,
+			"command":   hook.Command,
+			"exit_code": hook.ExitCode,
+			"output":    hook.Output,
+		})
+	}
+	return entries
+}
+
 func getHostname() string {
 	hostname, err := os.Hostname()
 	if err != nil {
@@ -2,14 +2,35 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
 	"github.com/BurnDevice/BurnDevice/internal/config"
+	"github.com/BurnDevice/BurnDevice/internal/system"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestMain(m *testing.M) {
@@ -67,6 +88,18 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewUnsupportedAIProvider(t *testing.T) {
+	cfg := &config.Config{
+		AI: config.AIConfig{
+			Provider: "unknown",
+		},
+	}
+
+	if _, err := New(cfg); err == nil {
+		t.Error("Expected error for unsupported AI provider")
+	}
+}
+
 func TestExecuteDestruction(t *testing.T) {
 	cfg := &config.Config{
 		Server: config.ServerConfig{
@@ -121,14 +154,17 @@ func TestExecuteDestruction(t *testing.T) {
 	}
 }
 
-func TestGetSystemInfo(t *testing.T) {
+func TestRestoreDestruction(t *testing.T) {
 	cfg := &config.Config{
 		Server: config.ServerConfig{
 			Host: "localhost",
 			Port: 8080,
 		},
-		AI: config.AIConfig{
-			APIKey: "test-key",
+		Security: config.SecurityConfig{
+			AuditLog:       true,
+			MaxSeverity:    "HIGH",
+			AllowedTargets: []string{"/tmp"},
+			BlockedTargets: []string{"/etc"},
 		},
 	}
 
@@ -138,45 +174,71 @@ func TestGetSystemInfo(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	req := &pb.GetSystemInfoRequest{}
 
-	resp, err := server.GetSystemInfo(ctx, req)
+	// Empty request is rejected with a response rather than an error
+	resp, err := server.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{})
 	if err != nil {
-		t.Fatalf("Expected no error getting system info, got: %v", err)
+		t.Fatalf("Expected no error (validation should return response), got: %v", err)
+	}
+	if resp.Success {
+		t.Error("Expected empty restore request to fail")
 	}
 
-	if resp == nil {
-		t.Fatal("Expected response to be returned")
+	// Blocked targets cannot be restored into
+	resp, err = server.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{Targets: []string{"/etc/passwd"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success {
+		t.Error("Expected restore into blocked target to fail")
 	}
 
-	if resp.Os == "" {
-		t.Error("Expected OS to be set")
+	// Missing backups are reported per target
+	resp, err = server.RestoreDestruction(ctx, &pb.RestoreDestructionRequest{Targets: []string{"/tmp/burndevice_missing_12345.txt"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
 	}
+	if resp.Success || len(resp.Results) != 1 {
+		t.Errorf("Expected a single failed result, got: %+v", resp)
+	}
+}
 
-	if resp.Architecture == "" {
-		t.Error("Expected Architecture to be set")
+func TestClientIdentity(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 5555}
+
+	if id := clientIdentity(context.Background()); id != "anonymous" {
+		t.Errorf("Expected 'anonymous' without peer, got '%s'", id)
 	}
 
-	if resp.Hostname == "" {
-		t.Error("Expected Hostname to be set")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	if id := clientIdentity(ctx); id != "addr:10.1.2.3" {
+		t.Errorf("Expected peer address identity, got '%s'", id)
 	}
 
-	if resp.Resources == nil {
-		t.Error("Expected Resources to be set")
+	// Whatever the caller sends is ignored, as it could change per request
+	tlsPeer := &peer.Peer{
+		Addr: addr,
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "tester"}}},
+		}},
+	}
+	headerCtx := metadata.NewIncomingContext(peer.NewContext(context.Background(), tlsPeer), metadata.Pairs("authorization", "secret"))
+	if id := clientIdentity(headerCtx); id != "addr:10.1.2.3" {
+		t.Errorf("Expected peer address identity despite token and certificate, got '%s'", id)
 	}
 }
 
-func TestGenerateAttackScenario(t *testing.T) {
+func TestExecuteDestructionQuotaExceeded(t *testing.T) {
 	cfg := &config.Config{
 		Server: config.ServerConfig{
 			Host: "localhost",
 			Port: 8080,
 		},
-		AI: config.AIConfig{
-			APIKey: "test-key",
-		},
 		Security: config.SecurityConfig{
-			AuditLog: true,
+			MaxSeverity: "HIGH",
+			PerClientDailyQuota: config.QuotaConfig{
+				MaxOperations: 1,
+			},
 		},
 	}
 
@@ -185,52 +247,50 @@ func TestGenerateAttackScenario(t *testing.T) {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	ctx := context.Background()
-
-	// Test valid request
-	req := &pb.GenerateAttackScenarioRequest{
-		TargetDescription: "Test environment with temporary files",
-		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
-		AiModel:           "deepseek-chat",
-	}
-
-	// Note: This will likely fail due to AI API call, but we test the validation
-	resp, err := server.GenerateAttackScenario(ctx, req)
-
-	// We expect either success or an API-related error
+	tempDir, err := os.MkdirTemp("", "burndevice_quota_test")
 	if err != nil {
-		// Check if it's a validation error (should not happen with valid request)
-		if strings.Contains(err.Error(), "target description is required") {
-			t.Error("Unexpected validation error with valid request")
-		}
-		// API errors are expected in test environment
-	} else if resp != nil {
-		// If successful, verify response structure
-		if resp.ScenarioId == "" {
-			t.Error("Expected scenario ID to be set")
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
 		}
+	}()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 5555}})
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{filepath.Join(tempDir, "missing.txt")},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		SkipPreflight:      true,
 	}
 
-	// Test invalid request (empty target description)
-	req.TargetDescription = ""
-	_, err = server.GenerateAttackScenario(ctx, req)
-	if err == nil {
-		t.Error("Expected error with empty target description")
+	// A different authorization header per request still counts against
+	// the same quota
+	first := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "junk-1"))
+	if _, err := server.ExecuteDestruction(first, req); err != nil {
+		t.Fatalf("Expected first request to pass, got: %v", err)
 	}
 
-	if !strings.Contains(err.Error(), "target description is required") {
-		t.Errorf("Expected validation error message, got: %v", err)
+	second := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "junk-2"))
+	_, err = server.ExecuteDestruction(second, req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "resets at") {
+		t.Errorf("Expected reset time hint in error, got: %v", err)
 	}
 }
 
-func TestGenerateAttackScenarioWithoutAPIKey(t *testing.T) {
+func TestGetSystemInfo(t *testing.T) {
 	cfg := &config.Config{
 		Server: config.ServerConfig{
 			Host: "localhost",
 			Port: 8080,
 		},
 		AI: config.AIConfig{
-			APIKey: "", // No API key
+			APIKey: "test-key",
 		},
 	}
 
@@ -240,208 +300,329 @@ func TestGenerateAttackScenarioWithoutAPIKey(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	req := &pb.GenerateAttackScenarioRequest{
-		TargetDescription: "Test environment",
-		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+	req := &pb.GetSystemInfoRequest{}
+
+	resp, err := server.GetSystemInfo(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error getting system info, got: %v", err)
 	}
 
-	resp, err := server.GenerateAttackScenario(ctx, req)
-	if err == nil {
-		t.Error("Expected error when API key is not configured")
+	if resp == nil {
+		t.Fatal("Expected response to be returned")
 	}
 
-	if !strings.Contains(err.Error(), "AI API key not configured") {
-		t.Errorf("Expected API key error message, got: %v", err)
+	if resp.Os == "" {
+		t.Error("Expected OS to be set")
 	}
 
-	if resp != nil {
-		t.Error("Expected no response when API key is missing")
+	if resp.Architecture == "" {
+		t.Error("Expected Architecture to be set")
 	}
-}
 
-func TestValidateDestructionRequest(t *testing.T) {
-	cfg := &config.Config{
-		Security: config.SecurityConfig{
-			MaxSeverity:         "MEDIUM", // Only LOW and MEDIUM allowed
-			AllowedTargets:      []string{"/tmp", "/var/tmp"},
-			BlockedTargets:      []string{"/etc", "/var/log"},
-			RequireConfirmation: true,
-		},
+	if resp.Hostname == "" {
+		t.Error("Expected Hostname to be set")
 	}
 
-	server, err := New(cfg)
+	if resp.Resources == nil {
+		t.Error("Expected Resources to be set")
+	}
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	server, err := New(&config.Config{})
 	if err != nil {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Test valid request
-	req := &pb.ExecuteDestructionRequest{
-		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
-		Targets:            []string{"/tmp/test.txt"},
-		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
-		ConfirmDestruction: true,
+	resp, err := server.CheckCapabilities(context.Background(), &pb.CheckCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error checking capabilities, got: %v", err)
+	}
+	if resp.Platform == "" || len(resp.Capabilities) == 0 {
+		t.Fatalf("Expected the platform and a capability matrix, got %v", resp)
 	}
+	for _, capability := range resp.Capabilities {
+		if capability.Type == pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION && !capability.Available {
+			t.Errorf("Expected file deletion to be available everywhere, got %v", capability)
+		}
+	}
+}
 
-	err = server.validateDestructionRequest(req)
+func TestCleanupBackups(t *testing.T) {
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{AllowedTargets: []string{"/tmp/burndevice_allowed"}},
+	})
 	if err != nil {
-		t.Errorf("Expected no error for valid request, got: %v", err)
+		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Test request without confirmation
-	req.ConfirmDestruction = false
-	err = server.validateDestructionRequest(req)
-	if err == nil {
-		t.Error("Expected error for request without confirmation")
+	resp, err := server.CleanupBackups(context.Background(), &pb.CleanupBackupsRequest{
+		Dir:       "/var/lib",
+		OlderThan: durationpb.New(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Expected a refused cleanup to be reported in the response, got: %v", err)
 	}
+	if resp.Success || !strings.Contains(resp.Message, "not in allowed list") {
+		t.Errorf("Expected a directory outside the allowed targets to be refused, got %v", resp)
+	}
+}
 
-	// Test request with high severity (above limit)
-	req.ConfirmDestruction = true
-	req.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH
-	err = server.validateDestructionRequest(req)
-	if err == nil {
-		t.Error("Expected error for severity above limit")
+func TestGetServerInfo(t *testing.T) {
+	banner := "LAB-3: max severity MEDIUM, authorized testers only"
+	server, err := New(&config.Config{
+		Server:   config.ServerConfig{ConnectionBanner: banner},
+		Security: config.SecurityConfig{AllowRoot: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Test request with blocked target
-	req.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW
-	req.Targets = []string{"/etc/passwd"}
-	err = server.validateDestructionRequest(req)
-	if err == nil {
-		t.Error("Expected error for blocked target")
+	resp, err := server.GetServerInfo(context.Background(), &pb.GetServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error getting server info, got: %v", err)
+	}
+
+	if resp.Uid == "" || resp.Username == "" {
+		t.Errorf("Expected the server account to be reported, got %v", resp)
+	}
+	if resp.Privileged != server.privilege.Privileged || !resp.AllowRoot {
+		t.Errorf("Expected the privilege level and allow_root to be reported, got %v", resp)
+	}
+	if resp.ConnectionBanner != banner {
+		t.Errorf("Expected connection banner %q, got %q", banner, resp.ConnectionBanner)
 	}
 }
 
-func TestValidateStreamDestructionRequest(t *testing.T) {
-	cfg := &config.Config{
-		Security: config.SecurityConfig{
-			MaxSeverity:         "MEDIUM",
-			AllowedTargets:      []string{"/tmp"},
-			BlockedTargets:      []string{"/etc"},
-			RequireConfirmation: true,
-		},
+func TestGetMetrics(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_metrics_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	server, err := New(cfg)
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
 	if err != nil {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Test valid request
-	req := &pb.StreamDestructionRequest{
+	ctx := context.Background()
+	resp, err := server.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
 		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
-		Targets:            []string{"/tmp/test.txt"},
-		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
 		ConfirmDestruction: true,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("Expected the destruction to succeed, got %v, %v", resp, err)
 	}
+	server.metrics.scenarioGenerated(&pb.GenerateAttackScenarioResponse{TokensUsed: 42})
 
-	err = server.validateStreamDestructionRequest(req)
+	metrics, err := server.GetMetrics(ctx, &pb.GetMetricsRequest{})
 	if err != nil {
-		t.Errorf("Expected no error for valid request, got: %v", err)
+		t.Fatalf("Expected no error getting metrics, got: %v", err)
 	}
 
-	// Test request without confirmation
-	req.ConfirmDestruction = false
-	err = server.validateStreamDestructionRequest(req)
-	if err == nil {
-		t.Error("Expected error for request without confirmation")
+	if metrics.DestructionsTotal != 1 || metrics.DestructionsFailed != 0 {
+		t.Errorf("Expected one successful destruction, got %v", metrics)
+	}
+	if metrics.FilesDeletedTotal != 1 || metrics.BytesDestroyedTotal != 10 {
+		t.Errorf("Expected the deleted file to be counted, got %v", metrics)
+	}
+	if metrics.ScenariosGeneratedTotal != 1 || metrics.AiTokensTotal != 42 {
+		t.Errorf("Expected the scenario and its tokens to be counted, got %v", metrics)
+	}
+	if metrics.StartedAt.AsTime().After(metrics.Timestamp.AsTime()) {
+		t.Errorf("Expected the start time to precede the snapshot, got %v", metrics)
 	}
 }
 
-func TestGetSeverityLevel(t *testing.T) {
-	server := &Server{}
+func TestPrometheusMetrics(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_prometheus_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
 
-	tests := []struct {
-		severity string
-		expected int32
-	}{
-		{"LOW", 1},
-		{"MEDIUM", 2},
-		{"HIGH", 3},
-		{"CRITICAL", 4},
-		{"INVALID", 1}, // Default to LOW for invalid input
-		{"", 1},        // Default to LOW for empty input
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("0123456789"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.severity, func(t *testing.T) {
-			result := server.getSeverityLevel(tt.severity)
-			if result != tt.expected {
-				t.Errorf("Expected severity level %d for '%s', got %d", tt.expected, tt.severity, result)
-			}
-		})
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH", RequireConfirmation: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
-}
 
-func TestIsBlockedTarget(t *testing.T) {
-	cfg := &config.Config{
-		Security: config.SecurityConfig{
-			BlockedTargets: []string{"/etc", "/var/log", "/usr/bin"},
-		},
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
 	}
+	go func() {
+		_ = server.grpcServer.Serve(grpcListener)
+	}()
+	defer server.grpcServer.Stop()
 
-	server, err := New(cfg)
+	conn, err := grpc.NewClient(grpcListener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
-		t.Fatalf("Failed to create server: %v", err)
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("Failed to close connection: %v", err)
+		}
+	}()
+	client := pb.NewBurnDeviceServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:     pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:  []string{target},
+		Severity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+	}
+	if resp, err := client.ExecuteDestruction(ctx, req); err != nil || resp.Success {
+		t.Fatalf("Expected the unconfirmed request to be rejected, got %v, %v", resp, err)
+	}
+	req.ConfirmDestruction = true
+	if resp, err := client.ExecuteDestruction(ctx, req); err != nil || !resp.Success {
+		t.Fatalf("Expected the destruction to succeed, got %v, %v", resp, err)
+	}
+	if _, err := client.GetTaskHistory(ctx, &pb.GetTaskHistoryRequest{PageToken: "next"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for bad page token, got: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	errChan := make(chan error, 1)
+	metricsServer := server.serveMetrics(listener, errChan)
+	defer server.stopMetrics(metricsServer)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/metrics", listener.Addr()))
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics: %v", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			t.Errorf("Failed to close response body: %v", err)
+		}
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+
+	for _, want := range []string{
+		`burndevice_tasks_total{severity="HIGH",state="completed",type="FILE_DELETION"} 1`,
+		`burndevice_task_duration_seconds_count{type="FILE_DELETION"} 1`,
+		`burndevice_files_deleted_total{type="FILE_DELETION"} 1`,
+		`burndevice_bytes_destroyed_total{type="FILE_DELETION"} 10`,
+		`burndevice_validation_rejections_total{reason="unconfirmed",type="FILE_DELETION"} 1`,
+		`burndevice_running_tasks 0`,
+		`burndevice_rpcs_total{code="OK",method="ExecuteDestruction"} 2`,
+		`burndevice_rpcs_total{code="InvalidArgument",method="GetTaskHistory"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Expected the scrape to contain %q, got:\n%s", want, body)
+		}
 	}
+}
 
+func TestMetricsAddress(t *testing.T) {
 	tests := []struct {
-		target   string
-		expected bool
+		name string
+		cfg  config.Config
+		want string
 	}{
-		{"/etc/passwd", true},
-		{"/var/log/messages", true},
-		{"/usr/bin/bash", true},
-		{"/tmp/test.txt", false},
-		{"/home/user/file.txt", false},
-		{"", false},
+		{"disabled", config.Config{}, ""},
+		{"metrics section", config.Config{Metrics: config.MetricsConfig{Enabled: true, Address: ":9090"}}, ":9090"},
+		{"deprecated metrics port", config.Config{Server: config.ServerConfig{Host: "127.0.0.1", MetricsPort: 9091}}, "127.0.0.1:9091"},
+		{"metrics section takes precedence", config.Config{
+			Server:  config.ServerConfig{Host: "127.0.0.1", MetricsPort: 9091},
+			Metrics: config.MetricsConfig{Enabled: true, Address: "localhost:9092"},
+		}, "localhost:9092"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.target, func(t *testing.T) {
-			result := server.isBlockedTarget(tt.target)
-			if result != tt.expected {
-				t.Errorf("Expected isBlocked %v for '%s', got %v", tt.expected, tt.target, result)
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			server, err := New(&cfg)
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+			if got := server.metricsAddress(); got != tt.want {
+				t.Errorf("Expected metrics address %q, got: %q", tt.want, got)
 			}
 		})
 	}
 }
 
-func TestIsAllowedTarget(t *testing.T) {
-	cfg := &config.Config{
-		Security: config.SecurityConfig{
-			AllowedTargets: []string{"/tmp", "/var/tmp", "/home/user"},
-		},
-	}
-
-	server, err := New(cfg)
-	if err != nil {
-		t.Fatalf("Failed to create server: %v", err)
-	}
-
+func TestLogPrivilege(t *testing.T) {
 	tests := []struct {
-		target   string
-		expected bool
+		name       string
+		privileged bool
+		allowRoot  bool
+		expectWarn bool
 	}{
-		{"/tmp/test.txt", true},
-		{"/var/tmp/file.log", true},
-		{"/home/user/document.txt", true},
-		{"/etc/passwd", false},
-		{"/usr/bin/bash", false},
-		{"", false},
+		{"unprivileged", false, false, false},
+		{"root without acknowledgment", true, false, true},
+		{"root with acknowledgment", true, true, false},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.target, func(t *testing.T) {
-			result := server.isAllowedTarget(tt.target)
-			if result != tt.expected {
-				t.Errorf("Expected isAllowed %v for '%s', got %v", tt.expected, tt.target, result)
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := New(&config.Config{
+				Security: config.SecurityConfig{AllowRoot: tt.allowRoot},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create server: %v", err)
+			}
+			server.privilege = system.Privilege{UID: "0", Username: "root", Privileged: tt.privileged}
+
+			var buf strings.Builder
+			server.logger.SetOutput(&buf)
+			server.logPrivilege()
+
+			warned := strings.Contains(buf.String(), "level=warning")
+			if warned != tt.expectWarn {
+				t.Errorf("Expected warning %v, got log: %s", tt.expectWarn, buf.String())
+			}
+			if !strings.Contains(buf.String(), "user=root") {
+				t.Errorf("Expected the account to be logged, got: %s", buf.String())
 			}
 		})
 	}
 }
 
-func TestAuditLog(t *testing.T) {
+func TestGenerateAttackScenario(t *testing.T) {
 	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Host: "localhost",
+			Port: 8080,
+		},
+		AI: config.AIConfig{
+			APIKey: "test-key",
+		},
 		Security: config.SecurityConfig{
 			AuditLog: true,
 		},
@@ -452,78 +633,1609 @@ func TestAuditLog(t *testing.T) {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Test audit logging (should not panic)
-	details := map[string]interface{}{
-		"action": "test",
-		"user":   "test-user",
-	}
+	ctx := context.Background()
+
+	// Test valid request
+	req := &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "Test environment with temporary files",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		AiModel:           "deepseek-chat",
+	}
+
+	// Note: This will likely fail due to AI API call, but we test the validation
+	resp, err := server.GenerateAttackScenario(ctx, req)
+
+	// We expect either success or an API-related error
+	if err != nil {
+		// Check if it's a validation error (should not happen with valid request)
+		if strings.Contains(err.Error(), "target description is required") {
+			t.Error("Unexpected validation error with valid request")
+		}
+		// API errors are expected in test environment
+	} else if resp != nil {
+		// If successful, verify response structure
+		if resp.ScenarioId == "" {
+			t.Error("Expected scenario ID to be set")
+		}
+	}
+
+	// Test invalid request (empty target description)
+	req.TargetDescription = ""
+	_, err = server.GenerateAttackScenario(ctx, req)
+	if err == nil {
+		t.Error("Expected error with empty target description")
+	}
+
+	if !strings.Contains(err.Error(), "target description is required") {
+		t.Errorf("Expected validation error message, got: %v", err)
+	}
+}
+
+func TestGenerateAttackScenarioCancelled(t *testing.T) {
+	// The AI answers with headers right away and then stalls mid-body
+	aborted := make(chan struct{})
+	aiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, `{"choices": [`)
+		w.(http.Flusher).Flush()
+
+		select {
+		case <-r.Context().Done():
+			close(aborted)
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer aiServer.Close()
+
+	server, err := New(&config.Config{
+		AI: config.AIConfig{
+			APIKey:         "test-key",
+			BaseURL:        aiServer.URL,
+			RequestTimeout: 30 * time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = server.GenerateAttackScenario(ctx, &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "Test environment with temporary files",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("Expected Canceled, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected cancellation to abort generation promptly, took %v", elapsed)
+	}
+
+	select {
+	case <-aborted:
+	case <-time.After(2 * time.Second):
+		t.Error("Expected the in-flight AI request to be aborted")
+	}
+}
+
+func TestStoredScenario(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_scenarios_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	targetDir := filepath.Join(dataDir, "targets")
+	if err := os.Mkdir(targetDir, 0750); err != nil {
+		t.Fatalf("Failed to create target dir: %v", err)
+	}
+	first, second := filepath.Join(targetDir, "first.txt"), filepath.Join(targetDir, "second.txt")
+	for _, path := range []string{first, second} {
+		if err := os.WriteFile(path, []byte("data"), 0600); err != nil {
+			t.Fatalf("Failed to create target: %v", err)
+		}
+	}
+
+	// The AI lists the steps out of order
+	content := fmt.Sprintf(`{"description": "clean up", "severity": "LOW", "steps": [
+		{"order": 2, "type": "FILE_DELETION", "targets": [%q]},
+		{"order": 1, "type": "FILE_DELETION", "targets": [%q]}
+	]}`, second, first)
+	aiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"choices": [{"message": {"role": "assistant", "content": %q}}]}`, content)
+	}))
+	defer aiServer.Close()
+
+	cfg := &config.Config{
+		AI: config.AIConfig{
+			APIKey:         "test-key",
+			BaseURL:        aiServer.URL,
+			RequestTimeout: 5 * time.Second,
+		},
+		Security: config.SecurityConfig{
+			MaxSeverity:         "HIGH",
+			AllowedTargets:      []string{targetDir},
+			RequireConfirmation: true,
+		},
+		Storage: config.StorageConfig{DataDir: dataDir},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	scenario, err := server.GenerateAttackScenario(ctx, &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "Temporary files",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+	})
+	if err != nil {
+		t.Fatalf("Failed to generate scenario: %v", err)
+	}
+
+	_, err = server.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		AiScenarioId:       "scenario_unknown",
+		ConfirmDestruction: true,
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown scenario, got: %v", err)
+	}
+
+	// A restarted server finds the scenario in the data directory
+	restarted, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	resp, err := restarted.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		AiScenarioId:       scenario.ScenarioId,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success {
+		t.Fatalf("Expected the scenario to succeed, got: %s", resp.Message)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].Target != first || resp.Results[1].Target != second {
+		t.Errorf("Expected the steps to run in order, got %+v", resp.Results)
+	}
+	for _, path := range []string{first, second} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted, got: %v", path, err)
+		}
+	}
+
+	// Every step is validated before any runs
+	unconfirmed, err := restarted.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{AiScenarioId: scenario.ScenarioId})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if unconfirmed.Success || !strings.Contains(unconfirmed.Message, "step 1") {
+		t.Errorf("Expected the first step to fail validation, got: %+v", unconfirmed)
+	}
+}
+
+func TestScenarioSeverity(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_scenario_severity_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	target := filepath.Join(dataDir, "target.txt")
+	if err := os.WriteFile(target, []byte("data"), 0600); err != nil {
+		t.Fatalf("Failed to create target: %v", err)
+	}
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "MEDIUM",
+			AllowedTargets: []string{dataDir},
+		},
+		Storage: config.StorageConfig{DataDir: dataDir},
+	}
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	saved, err := server.SaveScenario(ctx, &pb.SaveScenarioRequest{Scenario: &pb.GenerateAttackScenarioResponse{
+		EstimatedSeverity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		Steps: []*pb.AttackStep{
+			{Order: 1, Type: pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, Targets: []string{target}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error saving a scenario, got: %v", err)
+	}
+
+	// The estimate is above the maximum, so the scenario runs at the maximum
+	resp, err := server.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		AiScenarioId:       saved.ScenarioId,
+		ConfirmDestruction: true,
+		DryRun:             true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success || !strings.Contains(resp.Message, "severity MEDIUM from scenario") || !strings.Contains(resp.Message, "estimate HIGH capped") {
+		t.Errorf("Expected the capped scenario severity to be reported, got: %+v", resp)
+	}
+
+	// So does a request that names the scenario alongside its own targets
+	resp, err = server.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		AiScenarioId:       saved.ScenarioId,
+		ConfirmDestruction: true,
+		DryRun:             true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success || !strings.Contains(resp.Message, "severity MEDIUM from scenario") {
+		t.Errorf("Expected the scenario severity to be reported, got: %+v", resp)
+	}
+
+	// A severity in the request wins
+	resp, err = server.ExecuteDestruction(ctx, &pb.ExecuteDestructionRequest{
+		AiScenarioId:       saved.ScenarioId,
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		DryRun:             true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !resp.Success || strings.Contains(resp.Message, "from scenario") {
+		t.Errorf("Expected the requested severity to be used, got: %+v", resp)
+	}
+}
+
+func TestScenarioRepository(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_scenario_repo_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	scenarioDir := filepath.Join(dataDir, "reviewed")
+	cfg := &config.Config{Storage: config.StorageConfig{DataDir: dataDir, ScenarioDir: scenarioDir}}
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := server.SaveScenario(ctx, &pb.SaveScenarioRequest{
+		Scenario: &pb.GenerateAttackScenarioResponse{ScenarioId: "../escape"},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected an ID that isn't a file name to be rejected, got: %v", err)
+	}
+
+	saved, err := server.SaveScenario(ctx, &pb.SaveScenarioRequest{Scenario: &pb.GenerateAttackScenarioResponse{
+		Description:       "Stop the web tier",
+		EstimatedSeverity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM,
+		Steps: []*pb.AttackStep{
+			{Order: 1, Type: pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION, Targets: []string{"nginx"}},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error saving a scenario, got: %v", err)
+	}
+	if saved.ScenarioId == "" {
+		t.Fatal("Expected the server to assign an ID")
+	}
+	if _, err := os.Stat(filepath.Join(scenarioDir, saved.ScenarioId+".json")); err != nil {
+		t.Errorf("Expected the scenario in a file of its own: %v", err)
+	}
+	if _, err := server.SaveScenario(ctx, &pb.SaveScenarioRequest{Scenario: &pb.GenerateAttackScenarioResponse{
+		ScenarioId: "scenario_later",
+	}}); err != nil {
+		t.Fatalf("Expected no error saving a scenario, got: %v", err)
+	}
+
+	// A restarted server reads the scenarios back from their files
+	restarted, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	got, err := restarted.GetScenario(ctx, &pb.GetScenarioRequest{ScenarioId: saved.ScenarioId})
+	if err != nil {
+		t.Fatalf("Expected no error getting the scenario, got: %v", err)
+	}
+	if got.Scenario.Description != "Stop the web tier" || len(got.Scenario.Steps) != 1 || got.Scenario.CreatedAt == nil {
+		t.Errorf("Unexpected scenario: %v", got.Scenario)
+	}
+
+	list, err := restarted.ListScenarios(ctx, &pb.ListScenariosRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error listing scenarios, got: %v", err)
+	}
+	if len(list.Scenarios) != 2 || list.Scenarios[0].ScenarioId != saved.ScenarioId {
+		t.Fatalf("Expected both scenarios oldest first, got %v", list.Scenarios)
+	}
+	if first := list.Scenarios[0]; first.EstimatedSeverity != pb.DestructionSeverity_DESTRUCTION_SEVERITY_MEDIUM || first.Steps != 1 {
+		t.Errorf("Expected the summary to carry severity and steps, got %v", first)
+	}
+
+	deleted, err := restarted.DeleteScenario(ctx, &pb.DeleteScenarioRequest{ScenarioId: saved.ScenarioId})
+	if err != nil || !deleted.Deleted {
+		t.Fatalf("Expected the scenario to be deleted, got %v, %v", deleted, err)
+	}
+	if _, err := restarted.GetScenario(ctx, &pb.GetScenarioRequest{ScenarioId: saved.ScenarioId}); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a deleted scenario, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(scenarioDir, saved.ScenarioId+".json")); !os.IsNotExist(err) {
+		t.Errorf("Expected the scenario file to be removed, got: %v", err)
+	}
+	again, err := restarted.DeleteScenario(ctx, &pb.DeleteScenarioRequest{ScenarioId: saved.ScenarioId})
+	if err != nil || again.Deleted {
+		t.Errorf("Expected deleting twice to report nothing deleted, got %v, %v", again, err)
+	}
+}
+
+func TestScenarioStoreMigratesLegacyFile(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "burndevice_scenario_repo_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	legacy := `{"scenarioId":"scenario_1","description":"old"}` + "\n" + "not json\n"
+	if err := os.WriteFile(filepath.Join(dataDir, legacyScenariosFileName), []byte(legacy), 0600); err != nil {
+		t.Fatalf("Failed to write legacy scenarios: %v", err)
+	}
+
+	store := newScenarioStore(config.StorageConfig{DataDir: dataDir}, logrus.New())
+	if err := store.load(); err != nil {
+		t.Fatalf("Expected no error loading scenarios, got: %v", err)
+	}
+	if scenario, ok := store.get("scenario_1"); !ok || scenario.Description != "old" || scenario.CreatedAt == nil {
+		t.Errorf("Expected the legacy scenario to be loaded, got %v", scenario)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, scenarioDirName, "scenario_1.json")); err != nil {
+		t.Errorf("Expected the legacy scenario in a file of its own: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, legacyScenariosFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the legacy file to be removed, got: %v", err)
+	}
+}
+
+// scenarioRecordingStream collects the events a stream handler sends
+type scenarioRecordingStream struct {
+	grpc.ServerStream
+	events []*pb.StreamDestructionResponse
+}
+
+func (s *scenarioRecordingStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *scenarioRecordingStream) Send(event *pb.StreamDestructionResponse) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestStoredScenarioStopsAtFailedStep(t *testing.T) {
+	targetDir, err := os.MkdirTemp("", "burndevice_scenario_steps_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(targetDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "LOW",
+			AllowedTargets: []string{targetDir},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	first, last := filepath.Join(targetDir, "first.txt"), filepath.Join(targetDir, "last.txt")
+	// Skipping preflight lets the middle step's missing target fail mid-run
+	missing := filepath.Join(targetDir, "missing.txt")
+	scenario := &pb.GenerateAttackScenarioResponse{
+		ScenarioId:        "scenario_steps",
+		EstimatedSeverity: pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		Steps: []*pb.AttackStep{
+			{Order: 1, Description: "delete the first file", Type: pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, Targets: []string{first}},
+			{Order: 2, Type: pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, Targets: []string{missing}},
+			{Order: 3, Type: pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION, Targets: []string{last}},
+		},
+	}
+	if err := server.scenarios.add(scenario); err != nil {
+		t.Fatalf("Failed to store scenario: %v", err)
+	}
+	writeTargets := func() {
+		for _, path := range []string{first, last} {
+			if err := os.WriteFile(path, []byte("data"), 0600); err != nil {
+				t.Fatalf("Failed to create target: %v", err)
+			}
+		}
+	}
+
+	writeTargets()
+	resp, err := server.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		AiScenarioId:       scenario.ScenarioId,
+		ConfirmDestruction: true,
+		SkipPreflight:      true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Message, "stopped at step 2 of 3") {
+		t.Errorf("Expected the scenario to stop at the second step, got: %s", resp.Message)
+	}
+	if len(resp.Steps) != 3 {
+		t.Fatalf("Expected a result for every step, got %+v", resp.Steps)
+	}
+	if !resp.Steps[0].Success || resp.Steps[0].TaskId == "" || resp.Steps[0].Description != "delete the first file" {
+		t.Errorf("Expected the first step to succeed, got %+v", resp.Steps[0])
+	}
+	if resp.Steps[1].Success || resp.Steps[1].Skipped {
+		t.Errorf("Expected the second step to run and fail, got %+v", resp.Steps[1])
+	}
+	if !resp.Steps[2].Skipped {
+		t.Errorf("Expected the last step to be skipped, got %+v", resp.Steps[2])
+	}
+	if _, err := os.Stat(last); err != nil {
+		t.Errorf("Expected the last target to be untouched: %v", err)
+	}
+
+	writeTargets()
+	stream := &scenarioRecordingStream{}
+	if err := server.StreamDestruction(&pb.StreamDestructionRequest{
+		AiScenarioId:       scenario.ScenarioId,
+		ConfirmDestruction: true,
+		SkipPreflight:      true,
+	}, stream); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	var boundaries []string
+	for _, event := range stream.events {
+		if event.Step == 0 {
+			t.Errorf("Expected every event to carry its step, got %v", event)
+		}
+		switch event.Type {
+		case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_STARTED:
+			boundaries = append(boundaries, fmt.Sprintf("start %d", event.Step))
+		case pb.DestructionEventType_DESTRUCTION_EVENT_TYPE_STEP_FINISHED:
+			boundaries = append(boundaries, fmt.Sprintf("finish %d", event.Step))
+		}
+	}
+	if got := strings.Join(boundaries, ", "); got != "start 1, finish 1, start 2, finish 2" {
+		t.Errorf("Expected the stream to stop after the failed second step, got %s", got)
+	}
+	if final := stream.events[len(stream.events)-1]; !strings.Contains(final.Message, "stopped at step 2 of 3") {
+		t.Errorf("Expected the last event to say where the scenario stopped, got %q", final.Message)
+	}
+	if _, err := os.Stat(last); err != nil {
+		t.Errorf("Expected the last target to be untouched: %v", err)
+	}
+}
+
+func TestScenarioTypes(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			EnabledTypes: []string{"FILE_DELETION", "SERVICE_TERMINATION"},
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Nothing requested: every enabled type
+	types, err := server.scenarioTypes(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(types) != 2 || types[0] != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION || types[1] != pb.DestructionType_DESTRUCTION_TYPE_SERVICE_TERMINATION {
+		t.Errorf("Expected the enabled types, got %v", types)
+	}
+
+	// Requested types are narrowed to the enabled ones
+	types, err = server.scenarioTypes([]pb.DestructionType{
+		pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(types) != 1 || types[0] != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		t.Errorf("Expected only FILE_DELETION, got %v", types)
+	}
+
+	// No overlap is an error
+	_, err = server.scenarioTypes([]pb.DestructionType{pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC})
+	if err == nil {
+		t.Error("Expected an error when no requested type is enabled")
+	}
+
+	// Without enabled_types nothing is restricted
+	server.config.Security.EnabledTypes = nil
+	types, err = server.scenarioTypes(nil)
+	if err != nil || types != nil {
+		t.Errorf("Expected no restriction, got %v, %v", types, err)
+	}
+}
+
+func TestGenerateAttackScenarioWithoutAPIKey(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Host: "localhost",
+			Port: 8080,
+		},
+		AI: config.AIConfig{
+			APIKey: "", // No API key
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+	req := &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "Test environment",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+	}
+
+	resp, err := server.GenerateAttackScenario(ctx, req)
+	if err == nil {
+		t.Error("Expected error when API key is not configured")
+	}
+
+	if !strings.Contains(err.Error(), "AI API key not configured") {
+		t.Errorf("Expected API key error message, got: %v", err)
+	}
+
+	if resp != nil {
+		t.Error("Expected no response when API key is missing")
+	}
+}
+
+func TestValidateDestructionRequest(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:         "MEDIUM", // Only LOW and MEDIUM allowed
+			AllowedTargets:      []string{"/tmp", "/var/tmp"},
+			BlockedTargets:      []string{"/etc", "/var/log"},
+			RequireConfirmation: true,
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Test valid request
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/test.txt"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+
+	err = server.policy.ValidateRequest(req)
+	if err != nil {
+		t.Errorf("Expected no error for valid request, got: %v", err)
+	}
+
+	// Test request without confirmation
+	req.ConfirmDestruction = false
+	err = server.policy.ValidateRequest(req)
+	if err == nil {
+		t.Error("Expected error for request without confirmation")
+	}
+
+	// Test request with high severity (above limit)
+	req.ConfirmDestruction = true
+	req.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH
+	err = server.policy.ValidateRequest(req)
+	if err == nil {
+		t.Error("Expected error for severity above limit")
+	}
+
+	// Test request with blocked target
+	req.Severity = pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW
+	req.Targets = []string{"/etc/passwd"}
+	err = server.policy.ValidateRequest(req)
+	if err == nil {
+		t.Error("Expected error for blocked target")
+	}
+}
+
+func TestValidateConfirmationPhrase(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:                "CRITICAL",
+			RequireConfirmation:        true,
+			ConfirmationPhrase:         "burn it down",
+			ConfirmationPhraseSeverity: "HIGH",
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/test.txt"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH,
+		ConfirmDestruction: true,
+	}
+	if err := server.policy.ValidateRequest(req); err == nil {
+		t.Error("Expected a bare confirmation to be rejected at HIGH")
+	}
+
+	req.ConfirmationText = "burn it down"
+	if err := server.policy.ValidateRequest(req); err != nil {
+		t.Errorf("Expected the configured phrase to be accepted, got: %v", err)
+	}
+}
+
+func TestValidateStreamDestructionRequest(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:         "MEDIUM",
+			AllowedTargets:      []string{"/tmp"},
+			BlockedTargets:      []string{"/etc"},
+			RequireConfirmation: true,
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Test valid request
+	req := &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/test.txt"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+
+	err = server.policy.ValidateRequest(req)
+	if err != nil {
+		t.Errorf("Expected no error for valid request, got: %v", err)
+	}
+
+	// Test request without confirmation
+	req.ConfirmDestruction = false
+	err = server.policy.ValidateRequest(req)
+	if err == nil {
+		t.Error("Expected error for request without confirmation")
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			AuditLog: true,
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Test audit logging (should not panic)
+	details := map[string]interface{}{
+		"action": "test",
+		"user":   "test-user",
+	}
+
+	// This should not panic or error
+	server.auditLog("TEST_ACTION", details)
+}
+
+func TestGetHostname(t *testing.T) {
+	hostname := getHostname()
+	if hostname == "" {
+		t.Error("Expected hostname to be returned")
+	}
+
+	// Hostname should not contain invalid characters
+	if strings.ContainsAny(hostname, " \t\n\r") {
+		t.Error("Hostname should not contain whitespace characters")
+	}
+}
+
+func TestServerStartAndStop(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Host: "localhost",
+			Port: 0, // Use random available port
+		},
+		AI: config.AIConfig{
+			APIKey: "test-key",
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Test server start and immediate stop
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Start server in goroutine
+	errChan := make(chan error, 1)
+	go func() {
+		err := server.Start(ctx)
+		errChan <- err
+	}()
+
+	// Give server a moment to start
+	time.Sleep(100 * time.Millisecond)
+
+	// Cancel context to stop server
+	cancel()
+
+	// Wait for server to stop
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Errorf("Expected no error from server start/stop, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Server did not stop within timeout")
+	}
+}
+
+func TestComplexValidationScenarios(t *testing.T) {
+	cfg := &config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:         "MEDIUM",
+			AllowedTargets:      []string{"/tmp"},
+			BlockedTargets:      []string{"/etc", "/tmp/blocked"},
+			RequireConfirmation: true,
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Test target that is both allowed and blocked (blocked should take precedence)
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/blocked/file.txt"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
 
-	// This should not panic or error
-	server.auditLog("TEST_ACTION", details)
+	err = server.policy.ValidateRequest(req)
+	if err == nil {
+		t.Error("Expected error for target that is blocked despite being in allowed path")
+	}
+
+	// Test multiple targets with mixed validity
+	req.Targets = []string{"/tmp/valid.txt", "/etc/passwd"}
+	err = server.policy.ValidateRequest(req)
+	if err == nil {
+		t.Error("Expected error when any target is blocked")
+	}
+
+	// Test empty targets
+	req.Targets = []string{}
+	_ = server.policy.ValidateRequest(req)
+	// This should be handled by the destruction engine, not validation
+	// So we don't expect a validation error here
+}
+
+func TestServerWithMinimalConfig(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Host: "localhost",
+			Port: 8080,
+		},
+		// Minimal config - no AI, no security settings
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Expected server to be created with minimal config, got: %v", err)
+	}
+
+	if server == nil {
+		t.Fatal("Expected server to be created")
+	}
+
+	// Test that server can handle requests even with minimal config
+	ctx := context.Background()
+	sysInfoReq := &pb.GetSystemInfoRequest{}
+
+	resp, err := server.GetSystemInfo(ctx, sysInfoReq)
+	if err != nil {
+		t.Fatalf("Expected system info to work with minimal config, got: %v", err)
+	}
+
+	if resp == nil {
+		t.Error("Expected response even with minimal config")
+	}
+}
+
+// fakeSystemInfo reports fixed system information
+type fakeSystemInfo struct {
+	info *system.Info
+	err  error
+}
+
+func (f *fakeSystemInfo) Collect() (*system.Info, error) {
+	return f.info, f.err
+}
+
+func (f *fakeSystemInfo) DiskUsage(paths []string) []system.PathDisk {
+	var disks []system.PathDisk
+	for _, path := range paths {
+		disks = append(disks, system.PathDisk{Path: path, Total: 100, Available: 40})
+	}
+	return disks
+}
+
+func TestGetSystemInfoInjected(t *testing.T) {
+	fake := &fakeSystemInfo{info: &system.Info{
+		OS:            "plan9",
+		Architecture:  "mips",
+		Hostname:      "fixture",
+		CriticalPaths: []string{"/boot"},
+		Resources: system.Resources{
+			TotalMemory:     2048,
+			AvailableMemory: 1024,
+			CPUUsage:        12.5,
+		},
+		PathDisks: []system.PathDisk{{Path: "/boot", Total: 10, Available: 5}},
+	}}
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{AllowedTargets: []string{"/tmp/targets", "/boot"}},
+	}, WithSystemInfo(fake))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	resp, err := server.GetSystemInfo(context.Background(), &pb.GetSystemInfoRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if resp.Os != "plan9" || resp.Architecture != "mips" || resp.Hostname != "fixture" {
+		t.Errorf("Expected injected host details, got: %+v", resp)
+	}
+	if resp.Resources.AvailableMemory != 1024 || resp.Resources.CpuUsage != 12.5 {
+		t.Errorf("Expected injected resources, got: %+v", resp.Resources)
+	}
+
+	// A path reported by both the critical paths and the allowed targets
+	// appears once, with the critical path's reading
+	if len(resp.PathDisks) != 2 {
+		t.Fatalf("Expected 2 path disks, got: %+v", resp.PathDisks)
+	}
+	if resp.PathDisks[0].Path != "/boot" || resp.PathDisks[0].AvailableDisk != 5 {
+		t.Errorf("Expected /boot from the critical paths, got: %+v", resp.PathDisks[0])
+	}
+	if resp.PathDisks[1].Path != "/tmp/targets" || resp.PathDisks[1].AvailableDisk != 40 {
+		t.Errorf("Expected /tmp/targets from the allowed targets, got: %+v", resp.PathDisks[1])
+	}
+
+	fake.err = fmt.Errorf("collector unavailable")
+	if _, err := server.GetSystemInfo(context.Background(), &pb.GetSystemInfoRequest{}); err == nil {
+		t.Error("Expected collector error to be returned")
+	}
+}
+
+func TestTaskStatusAndCancel(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Host: "localhost",
+			Port: 8080,
+		},
+		Security: config.SecurityConfig{
+			MaxSeverity: "HIGH",
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	ctx := context.Background()
+
+	_, err = server.GetTaskStatus(ctx, &pb.GetTaskStatusRequest{TaskId: "task_missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for unknown task, got: %v", err)
+	}
+
+	resp, err := server.CancelDestruction(ctx, &pb.CancelDestructionRequest{TaskId: "task_missing"})
+	if err != nil {
+		t.Fatalf("Expected no error when cancelling unknown task, got: %v", err)
+	}
+	if resp.Found {
+		t.Error("Expected unknown task not to be found")
+	}
+}
+
+func TestExpandTargetsInvalidPattern(t *testing.T) {
+	server, err := New(&config.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	_, err = server.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: "["})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for bad pattern, got: %v", err)
+	}
+
+	server, err = New(&config.Config{
+		Security: config.SecurityConfig{AllowedTargets: []string{t.TempDir()}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	_, err = server.ExpandTargets(context.Background(), &pb.ExpandTargetsRequest{Pattern: ".*", Root: t.TempDir()})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for root outside the allowed list, got: %v", err)
+	}
+}
+
+func TestStreamDestructionRecordsTaskID(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_task_id_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	testFile := filepath.Join(tempDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:    "MEDIUM",
+			AuditLog:       true,
+			AllowedTargets: []string{tempDir},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	var buf strings.Builder
+	server.logger.SetOutput(&buf)
+
+	stream := &scenarioRecordingStream{}
+	if err := server.StreamDestruction(&pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{testFile},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}, stream); err != nil {
+		t.Fatalf("StreamDestruction failed: %v", err)
+	}
+
+	// The events, the audit log and the task history all name one task
+	if len(stream.events) == 0 {
+		t.Fatal("Expected stream events")
+	}
+	taskID := stream.events[0].TaskId
+	if !strings.HasPrefix(taskID, "task_") {
+		t.Fatalf("Expected a task ID on the events, got %q", taskID)
+	}
+	for i, event := range stream.events {
+		if event.TaskId != taskID {
+			t.Errorf("Expected event %d for task %s, got %s", i, taskID, event.TaskId)
+		}
+	}
+	if !strings.Contains(buf.String(), "action=DESTRUCTION_EXECUTED") || !strings.Contains(buf.String(), "task_id="+taskID) {
+		t.Errorf("Expected the audit log to name task %s, got: %s", taskID, buf.String())
+	}
+	if !strings.Contains(buf.String(), "success=true") {
+		t.Errorf("Expected the audit log to record success, got: %s", buf.String())
+	}
+
+	history, err := server.GetTaskHistory(context.Background(), &pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("GetTaskHistory failed: %v", err)
+	}
+	if len(history.Tasks) != 1 || history.Tasks[0].TaskId != taskID {
+		t.Errorf("Expected task %s in the history, got %v", taskID, history.Tasks)
+	}
+}
+
+func TestGetTaskHistoryInvalidPageToken(t *testing.T) {
+	server, err := New(&config.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	_, err = server.GetTaskHistory(context.Background(), &pb.GetTaskHistoryRequest{PageToken: "next"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for bad page token, got: %v", err)
+	}
+}
+
+func TestScheduleDestructionStatusCodes(t *testing.T) {
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	request := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{"/tmp/burndevice_schedule_test"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+
+	_, err = server.ScheduleDestruction(context.Background(), &pb.ScheduleDestructionRequest{Request: request, Cron: "never"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for a bad cron expression, got: %v", err)
+	}
+
+	resp, err := server.ScheduleDestruction(context.Background(), &pb.ScheduleDestructionRequest{Request: request, Delay: durationpb.New(time.Hour)})
+	if err != nil {
+		t.Fatalf("Expected the schedule to be accepted, got: %v", err)
+	}
+
+	deleted, err := server.DeleteSchedule(context.Background(), &pb.DeleteScheduleRequest{ScheduleId: resp.Schedule.ScheduleId})
+	if err != nil || !deleted.Deleted {
+		t.Errorf("Expected the schedule to be deleted, got %v, %v", deleted, err)
+	}
+
+	list, err := server.ListSchedules(context.Background(), &pb.ListSchedulesRequest{})
+	if err != nil || len(list.Schedules) != 0 {
+		t.Errorf("Expected no schedules left, got %v, %v", list, err)
+	}
+}
+
+func TestAllowedWindows(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_window_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	// The only window opens three hours from now
+	now := time.Now().UTC()
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity: "MEDIUM",
+			AllowedWindows: []config.TimeWindow{{
+				Start:    now.Add(3 * time.Hour).Format("15:04"),
+				End:      now.Add(4 * time.Hour).Format("15:04"),
+				Timezone: "UTC",
+			}},
+			WindowOverrideToken: "break-glass",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	var buf strings.Builder
+	server.logger.SetOutput(&buf)
+
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	}
+
+	_, err = server.ExecuteDestruction(context.Background(), req)
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "next window opens at") {
+		t.Errorf("Expected FailedPrecondition naming the next window, got: %v", err)
+	}
+
+	// Dry runs change nothing, so they may run at any time
+	dryRun := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	dryRun.DryRun = true
+	if resp, err := server.ExecuteDestruction(context.Background(), dryRun); err != nil || !resp.Success {
+		t.Errorf("Expected a dry run outside the window to succeed, got %v, %v", resp, err)
+	}
+
+	wrongToken := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	wrongToken.WindowOverrideToken = "guess"
+	if _, err := server.ExecuteDestruction(context.Background(), wrongToken); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected a wrong override token to be rejected, got: %v", err)
+	}
+
+	overridden := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	overridden.WindowOverrideToken = "break-glass"
+	if resp, err := server.ExecuteDestruction(context.Background(), overridden); err != nil || !resp.Success {
+		t.Fatalf("Expected the override token to allow the request, got %v, %v", resp, err)
+	}
+	if !strings.Contains(buf.String(), "WINDOW_OVERRIDDEN") {
+		t.Errorf("Expected the override to be audited, got:\n%s", buf.String())
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected the overridden request to delete the target, got: %v", err)
+	}
+
+	// Schedules are accepted outside the window
+	if _, err := server.ScheduleDestruction(context.Background(), &pb.ScheduleDestructionRequest{Request: req, Delay: durationpb.New(time.Hour)}); err != nil {
+		t.Errorf("Expected a schedule outside the window to be accepted, got: %v", err)
+	}
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_idempotency_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	server, err := New(&config.Config{
+		Server: config.ServerConfig{IdempotencyTTL: time.Minute},
+		Security: config.SecurityConfig{
+			MaxSeverity: "MEDIUM",
+			AuditLog:    true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	var buf strings.Builder
+	server.logger.SetOutput(&buf)
+
+	target := filepath.Join(tempDir, "target.txt")
+	if err := os.WriteFile(target, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	req := &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
+		Targets:            []string{target},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+		IdempotencyKey:     "retry-1",
+	}
+
+	first, err := server.ExecuteDestruction(context.Background(), req)
+	if err != nil || !first.Success {
+		t.Fatalf("Expected the first request to succeed, got %v, %v", first, err)
+	}
+
+	// The target is gone, so only the stored response can still succeed
+	retry, err := server.ExecuteDestruction(context.Background(), req)
+	if err != nil || !retry.Success || retry.TaskId != first.TaskId {
+		t.Errorf("Expected the retry to get task %s's response, got %v, %v", first.TaskId, retry, err)
+	}
+	if !strings.Contains(buf.String(), "DESTRUCTION_REPLAYED") || !strings.Contains(buf.String(), "idempotency_key=retry-1") {
+		t.Errorf("Expected the replay to be audited with its key, got:\n%s", buf.String())
+	}
+
+	history, err := server.GetTaskHistory(context.Background(), &pb.GetTaskHistoryRequest{})
+	if err != nil {
+		t.Fatalf("Failed to get task history: %v", err)
+	}
+	if len(history.Tasks) != 1 || history.Tasks[0].IdempotencyKey != "retry-1" {
+		t.Errorf("Expected one task recorded with its key, got: %v", history.Tasks)
+	}
+
+	// The same key with a different payload is a conflict
+	other := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	other.Targets = []string{filepath.Join(tempDir, "other.txt")}
+	if _, err := server.ExecuteDestruction(context.Background(), other); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for a reused key, got: %v", err)
+	}
+
+	// Keys belong to the client that sent them
+	otherClient := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.9.8.7"), Port: 5555}})
+	if resp, err := server.ExecuteDestruction(otherClient, other); err != nil || resp.TaskId == first.TaskId {
+		t.Errorf("Expected another client's key to run its own request, got %v, %v", resp, err)
+	}
+}
+
+func TestIdempotencyCache(t *testing.T) {
+	cache := newIdempotencyCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	req := &pb.ExecuteDestructionRequest{
+		Type:           pb.DestructionType_DESTRUCTION_TYPE_CPU_BURN,
+		Targets:        []string{"cpu"},
+		IdempotencyKey: "key",
+	}
+	var runs atomic.Int32
+	execute := func() (*pb.ExecuteDestructionResponse, error) {
+		return &pb.ExecuteDestructionResponse{Success: true, TaskId: fmt.Sprintf("task-%d", runs.Add(1))}, nil
+	}
+
+	// A retry arriving while the first request runs waits for its response
+	release := make(chan struct{})
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		_, _, _ = cache.do(context.Background(), "client", req, func() (*pb.ExecuteDestructionResponse, error) {
+			<-release
+			return execute()
+		})
+	}()
+	for {
+		cache.mu.Lock()
+		claimed := len(cache.entries) == 1
+		cache.mu.Unlock()
+		if claimed {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	type result struct {
+		resp     *pb.ExecuteDestructionResponse
+		replayed bool
+		err      error
+	}
+	retried := make(chan result, 1)
+	go func() {
+		resp, replayed, err := cache.do(context.Background(), "client", req, execute)
+		retried <- result{resp, replayed, err}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	<-firstDone
+	r := <-retried
+	resp, replayed, err := r.resp, r.replayed, r.err
+	if err != nil || !replayed || resp.TaskId != "task-1" || runs.Load() != 1 {
+		t.Errorf("Expected the in-flight retry to get task-1, got %v, %v, %v after %d runs", resp, replayed, err, runs.Load())
+	}
+
+	// Responses expire after the TTL
+	now = now.Add(time.Minute)
+	if resp, replayed, _ := cache.do(context.Background(), "client", req, execute); replayed || resp.TaskId != "task-2" {
+		t.Errorf("Expected an expired key to run again, got %v, %v", resp, replayed)
+	}
+
+	// A request that fails is forgotten so its retry runs
+	failing := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	failing.IdempotencyKey = "failing"
+	if _, _, err := cache.do(context.Background(), "client", failing, func() (*pb.ExecuteDestructionResponse, error) {
+		return nil, status.Error(codes.ResourceExhausted, "quota")
+	}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected the failure to be returned, got: %v", err)
+	}
+	if _, replayed, err := cache.do(context.Background(), "client", failing, execute); err != nil || replayed {
+		t.Errorf("Expected the retry of a failed request to run, got %v, %v", replayed, err)
+	}
+
+	// So is one whose response reports no success
+	unsuccessful := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	unsuccessful.IdempotencyKey = "unsuccessful"
+	if resp, _, err := cache.do(context.Background(), "client", unsuccessful, func() (*pb.ExecuteDestructionResponse, error) {
+		return &pb.ExecuteDestructionResponse{Success: false, Message: "target is busy"}, nil
+	}); err != nil || resp.Success {
+		t.Errorf("Expected the unsuccessful response to be returned, got %v, %v", resp, err)
+	}
+	if resp, replayed, err := cache.do(context.Background(), "client", unsuccessful, execute); err != nil || replayed || !resp.Success {
+		t.Errorf("Expected the retry of an unsuccessful request to run, got %v, %v, %v", resp, replayed, err)
+	}
+
+	// The cache stays bounded
+	for i := 0; i < maxIdempotencyKeys+10; i++ {
+		bounded := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+		bounded.IdempotencyKey = fmt.Sprintf("bounded-%d", i)
+		now = now.Add(time.Millisecond)
+		_, _, _ = cache.do(context.Background(), "client", bounded, execute)
+	}
+	if len(cache.entries) > maxIdempotencyKeys {
+		t.Errorf("Expected at most %d keys, got %d", maxIdempotencyKeys, len(cache.entries))
+	}
+
+	// Keys of requests still running are never dropped to make room; new
+	// keys are turned away until one finishes
+	running := newIdempotencyCache(time.Minute)
+	var inFlight *idempotencyEntry
+	for i := 0; i < maxIdempotencyKeys; i++ {
+		inFlight, _, _ = running.claim(fmt.Sprintf("running-%d", i), "fingerprint")
+	}
+	if _, _, err := running.do(context.Background(), "client", req, execute); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted while every key is running, got: %v", err)
+	}
+	if len(running.entries) != maxIdempotencyKeys {
+		t.Errorf("Expected all %d running keys to be kept, got %d", maxIdempotencyKeys, len(running.entries))
+	}
+	running.finish(fmt.Sprintf("running-%d", maxIdempotencyKeys-1), inFlight, &pb.ExecuteDestructionResponse{Success: true}, nil)
+	if _, replayed, err := running.do(context.Background(), "client", req, execute); err != nil || replayed {
+		t.Errorf("Expected a finished key to make room, got %v, %v", replayed, err)
+	}
+
+	if newIdempotencyCache(0) != nil {
+		t.Error("Expected a zero TTL to disable the cache")
+	}
 }
 
-func TestGetHostname(t *testing.T) {
-	hostname := getHostname()
-	if hostname == "" {
-		t.Error("Expected hostname to be returned")
+func TestReload(t *testing.T) {
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Host: "localhost", Port: 8080},
+		Security: config.SecurityConfig{MaxSeverity: "MEDIUM"},
+	}
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
+	var buf strings.Builder
+	server.logger.SetOutput(&buf)
 
-	// Hostname should not contain invalid characters
-	if strings.ContainsAny(hostname, " \t\n\r") {
-		t.Error("Hostname should not contain whitespace characters")
+	reloaded := &config.Config{
+		Server: config.ServerConfig{Host: "localhost", Port: 9090},
+		Security: config.SecurityConfig{
+			MaxSeverity:    "HIGH",
+			BlockedTargets: []string{"/srv/protected"},
+			AuditLog:       true,
+		},
+	}
+	applied := server.Reload(reloaded)
+
+	// Policy rules apply at once, and everything else waits for a restart
+	if applied.Security.MaxSeverity != "HIGH" || server.policy.MaxSeverity() != pb.DestructionSeverity_DESTRUCTION_SEVERITY_HIGH {
+		t.Errorf("Expected max_severity HIGH to be applied, got %s", applied.Security.MaxSeverity)
+	}
+	if err := server.policy.CheckTarget("/srv/protected/data"); err == nil {
+		t.Error("Expected the newly blocked target to be rejected")
+	}
+	if applied.Server.Port != 8080 || applied.Security.AuditLog {
+		t.Errorf("Expected the port and audit_log to be left for a restart, got %d, %v", applied.Server.Port, applied.Security.AuditLog)
+	}
+	for _, want := range []string{"server.port: 8080 -> 9090", "change ignored until restart", "security.audit_log: false -> true", "needs a restart"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected the reload log to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	// Reloading the same file again still reports what waits for a restart
+	buf.Reset()
+	if again := server.Reload(reloaded); again.Security.MaxSeverity != "HIGH" || strings.Contains(buf.String(), "max_severity") {
+		t.Errorf("Expected only the pending changes to be logged again, got:\n%s", buf.String())
 	}
 }
 
-func TestServerStartAndStop(t *testing.T) {
-	cfg := &config.Config{
-		Server: config.ServerConfig{
-			Host: "localhost",
-			Port: 0, // Use random available port
+// recordingAI answers every scenario request with an empty scenario,
+// keeping the last request it was sent
+type recordingAI struct {
+	req *pb.GenerateAttackScenarioRequest
+}
+
+func (r *recordingAI) GenerateAttackScenario(ctx context.Context, req *pb.GenerateAttackScenarioRequest) (*pb.GenerateAttackScenarioResponse, error) {
+	r.req = req
+	return &pb.GenerateAttackScenarioResponse{ScenarioId: "scenario_reload"}, nil
+}
+
+func TestReloadScenarioTypesAndDisks(t *testing.T) {
+	fake := &fakeSystemInfo{info: &system.Info{}}
+	server, err := New(&config.Config{
+		AI: config.AIConfig{APIKey: "test-key"},
+	}, WithSystemInfo(fake))
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	ai := &recordingAI{}
+	server.aiClient = ai
+	server.logger.SetOutput(io.Discard)
+
+	server.Reload(&config.Config{
+		AI: config.AIConfig{APIKey: "test-key"},
+		Security: config.SecurityConfig{
+			EnabledTypes:   []string{"FILE_DELETION"},
+			AllowedTargets: []string{"/srv/targets"},
 		},
-		AI: config.AIConfig{
-			APIKey: "test-key",
+	})
+
+	// Scenarios are narrowed to the types enabled by the reload
+	if _, err := server.GenerateAttackScenario(context.Background(), &pb.GenerateAttackScenarioRequest{
+		TargetDescription: "Test environment with temporary files",
+		MaxSeverity:       pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+	}); err != nil {
+		t.Fatalf("Expected scenario generation to succeed, got: %v", err)
+	}
+	if types := ai.req.AllowedTypes; len(types) != 1 || types[0] != pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION {
+		t.Errorf("Expected only FILE_DELETION to be offered to the AI, got %v", types)
+	}
+
+	resp, err := server.GetSystemInfo(context.Background(), &pb.GetSystemInfoRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(resp.PathDisks) != 1 || resp.PathDisks[0].Path != "/srv/targets" {
+		t.Errorf("Expected disks for the reloaded allowed targets, got: %+v", resp.PathDisks)
+	}
+}
+
+func TestIrreversibleRequests(t *testing.T) {
+	req := &pb.ExecuteDestructionRequest{
+		Type:                    pb.DestructionType_DESTRUCTION_TYPE_KERNEL_PANIC,
+		Targets:                 []string{"node-1"},
+		Severity:                pb.DestructionSeverity_DESTRUCTION_SEVERITY_CRITICAL,
+		ConfirmDestruction:      true,
+		AcknowledgeIrreversible: true,
+	}
+
+	// Without security.allow_irreversible the request is refused outright
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "CRITICAL"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if _, err := server.ExecuteDestruction(context.Background(), req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without allow_irreversible, got: %v", err)
+	}
+
+	server, err = New(&config.Config{
+		Security: config.SecurityConfig{
+			MaxSeverity:       "CRITICAL",
+			AllowIrreversible: true,
+			AuditLog:          true,
 		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
+	var buf strings.Builder
+	server.logger.SetOutput(&buf)
 
-	server, err := New(cfg)
+	unacknowledged := proto.Clone(req).(*pb.ExecuteDestructionRequest)
+	unacknowledged.AcknowledgeIrreversible = false
+	if _, err := server.ExecuteDestruction(context.Background(), unacknowledged); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition without acknowledge_irreversible, got: %v", err)
+	}
+	if strings.Contains(buf.String(), "IRREVERSIBLE_ACKNOWLEDGED") {
+		t.Errorf("Expected no acknowledgement to be audited for a rejected request, got: %s", buf.String())
+	}
+
+	// The acknowledged request gets as far as the engine, which has no
+	// destructor for it yet
+	resp, err := server.ExecuteDestruction(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Expected execution errors in the response, got: %v", err)
+	}
+	if resp.Success {
+		t.Errorf("Expected the unimplemented type to fail, got: %+v", resp)
+	}
+	if !strings.Contains(buf.String(), "action=IRREVERSIBLE_ACKNOWLEDGED") {
+		t.Errorf("Expected the acknowledgement to be audited, got: %s", buf.String())
+	}
+}
+
+func TestUnimplementedDestructionType(t *testing.T) {
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{MaxSeverity: "HIGH"},
+	})
 	if err != nil {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Test server start and immediate stop
-	ctx, cancel := context.WithCancel(context.Background())
+	resp, err := server.ExecuteDestruction(context.Background(), &pb.ExecuteDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION,
+		Targets:            []string{"node-1"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected execution errors in the response, got: %v", err)
+	}
+	if resp.Success || !strings.Contains(resp.Message, "not implemented") {
+		t.Errorf("Expected a not implemented failure, got: %+v", resp)
+	}
 
-	// Start server in goroutine
-	errChan := make(chan error, 1)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
 	go func() {
-		err := server.Start(ctx)
-		errChan <- err
+		_ = server.grpcServer.Serve(listener)
 	}()
+	defer server.grpcServer.Stop()
 
-	// Give server a moment to start
-	time.Sleep(100 * time.Millisecond)
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("Failed to close connection: %v", err)
+		}
+	}()
+	client := pb.NewBurnDeviceServiceClient(conn)
 
-	// Cancel context to stop server
-	cancel()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamDestruction(ctx, &pb.StreamDestructionRequest{
+		Type:               pb.DestructionType_DESTRUCTION_TYPE_REGISTRY_CORRUPTION,
+		Targets:            []string{"node-1"},
+		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
+		ConfirmDestruction: true,
+	})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("Expected Unimplemented for a stream, got: %v", err)
+	}
+}
+
+func TestIdleTimeoutShutdown(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			Host:        "127.0.0.1",
+			Port:        0,
+			IdleTimeout: 100 * time.Millisecond,
+		},
+	}
+
+	server, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Start(context.Background())
+	}()
 
-	// Wait for server to stop
 	select {
-	case err := <-errChan:
+	case err := <-done:
 		if err != nil {
-			t.Errorf("Expected no error from server start/stop, got: %v", err)
+			t.Errorf("Expected clean shutdown, got: %v", err)
 		}
-	case <-time.After(5 * time.Second):
-		t.Error("Server did not stop within timeout")
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected server to stop after idle timeout")
 	}
 }
 
-func TestComplexValidationScenarios(t *testing.T) {
+func TestIdleTimeoutResetByActivity(t *testing.T) {
 	cfg := &config.Config{
-		Security: config.SecurityConfig{
-			MaxSeverity:         "MEDIUM",
-			AllowedTargets:      []string{"/tmp"},
-			BlockedTargets:      []string{"/etc", "/tmp/blocked"},
-			RequireConfirmation: true,
+		Server: config.ServerConfig{
+			Host:        "127.0.0.1",
+			Port:        0,
+			IdleTimeout: 200 * time.Millisecond,
 		},
 	}
 
@@ -532,61 +2244,266 @@ func TestComplexValidationScenarios(t *testing.T) {
 		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Test target that is both allowed and blocked (blocked should take precedence)
-	req := &pb.ExecuteDestructionRequest{
-		Type:               pb.DestructionType_DESTRUCTION_TYPE_FILE_DELETION,
-		Targets:            []string{"/tmp/blocked/file.txt"},
-		Severity:           pb.DestructionSeverity_DESTRUCTION_SEVERITY_LOW,
-		ConfirmDestruction: true,
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Start(ctx)
+	}()
+
+	// Keep issuing RPCs for longer than the idle timeout
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: pb.BurnDeviceService_ListTasks_FullMethodName}
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		if _, err := server.activity.unaryActivity(ctx, nil, info, handler); err != nil {
+			t.Fatalf("Unexpected error from handler: %v", err)
+		}
+		select {
+		case <-done:
+			t.Fatal("Expected activity to keep the server running")
+		case <-time.After(50 * time.Millisecond):
+		}
 	}
 
-	err = server.validateDestructionRequest(req)
-	if err == nil {
-		t.Error("Expected error for target that is blocked despite being in allowed path")
+	// Once activity stops the server shuts itself down
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected server to stop once activity ceased")
 	}
+}
 
-	// Test multiple targets with mixed validity
-	req.Targets = []string{"/tmp/valid.txt", "/etc/passwd"}
-	err = server.validateDestructionRequest(req)
+func TestActivityTrackerInFlight(t *testing.T) {
+	server, err := New(&config.Config{
+		Server: config.ServerConfig{IdleTimeout: 20 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	// An in-flight RPC is never idle
+	server.activity.begin()
+	if idle := server.activity.idleFor(time.Now().Add(time.Hour)); idle != 0 {
+		t.Errorf("Expected no idle time while an RPC is in flight, got %s", idle)
+	}
+	server.activity.end()
+	if idle := server.activity.idleFor(time.Now().Add(time.Hour)); idle < time.Hour {
+		t.Errorf("Expected idle time to accumulate after the RPC, got %s", idle)
+	}
+}
+
+func TestRateLimitRejectsExcessRequests(t *testing.T) {
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{RateLimitPerMinute: 3},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() {
+		_ = server.grpcServer.Serve(listener)
+	}()
+	defer server.grpcServer.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("Failed to close connection: %v", err)
+		}
+	}()
+	client := pb.NewBurnDeviceServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.ListTasks(ctx, &pb.ListTasksRequest{}); err != nil {
+			t.Fatalf("Expected request %d within the limit to succeed, got: %v", i+1, err)
+		}
+	}
+
+	_, err = client.ListTasks(ctx, &pb.ListTasksRequest{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted past the limit, got: %v", err)
+	}
+
+	// Streams count against the same limit
+	stream, err := client.StreamDestruction(ctx, &pb.StreamDestructionRequest{})
 	if err == nil {
-		t.Error("Expected error when any target is blocked")
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted for a stream past the limit, got: %v", err)
+	}
+}
+
+func TestRateLimiterPerPeer(t *testing.T) {
+	limiter := newRateLimiter(2)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	peerCtx := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000},
+		})
 	}
 
-	// Test empty targets
-	req.Targets = []string{}
-	_ = server.validateDestructionRequest(req)
-	// This should be handled by the destruction engine, not validation
-	// So we don't expect a validation error here
+	for i := 0; i < 2; i++ {
+		if err := limiter.check(peerCtx("10.0.0.1")); err != nil {
+			t.Fatalf("Expected request %d to be allowed, got: %v", i+1, err)
+		}
+	}
+	if err := limiter.check(peerCtx("10.0.0.1")); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted for the third request, got: %v", err)
+	}
+
+	// Another peer has its own budget
+	if err := limiter.check(peerCtx("10.0.0.2")); err != nil {
+		t.Errorf("Expected a different peer to be allowed, got: %v", err)
+	}
+
+	// Capacity refills over time
+	now = now.Add(30 * time.Second)
+	if err := limiter.check(peerCtx("10.0.0.1")); err != nil {
+		t.Errorf("Expected a refilled token after 30s, got: %v", err)
+	}
+
+	if newRateLimiter(0) != nil {
+		t.Error("Expected a zero limit to disable rate limiting")
+	}
+	var disabled *rateLimiter
+	if err := disabled.check(peerCtx("10.0.0.1")); err != nil {
+		t.Errorf("Expected a disabled limiter to allow everything, got: %v", err)
+	}
 }
 
-func TestServerWithMinimalConfig(t *testing.T) {
-	cfg := &config.Config{
-		Server: config.ServerConfig{
-			Host: "localhost",
-			Port: 8080,
-		},
-		// Minimal config - no AI, no security settings
+func TestTokenAuthentication(t *testing.T) {
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{AuthToken: "s3cret"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	server, err := New(cfg)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
-		t.Fatalf("Expected server to be created with minimal config, got: %v", err)
+		t.Fatalf("Failed to listen: %v", err)
 	}
+	go func() {
+		_ = server.grpcServer.Serve(listener)
+	}()
+	defer server.grpcServer.Stop()
 
-	if server == nil {
-		t.Fatal("Expected server to be created")
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
 	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("Failed to close connection: %v", err)
+		}
+	}()
+	client := pb.NewBurnDeviceServiceClient(conn)
 
-	// Test that server can handle requests even with minimal config
-	ctx := context.Background()
-	sysInfoReq := &pb.GetSystemInfoRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	resp, err := server.GetSystemInfo(ctx, sysInfoReq)
+	tests := []struct {
+		name   string
+		header string
+		code   codes.Code
+	}{
+		{"missing token", "", codes.Unauthenticated},
+		{"wrong token", "Bearer wrong", codes.Unauthenticated},
+		{"bearer token", "Bearer s3cret", codes.OK},
+		{"bare token", "s3cret", codes.OK},
+	}
+
+	for _, tt := range tests {
+		callCtx := ctx
+		if tt.header != "" {
+			callCtx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.header)
+		}
+
+		_, err := client.ListTasks(callCtx, &pb.ListTasksRequest{})
+		if status.Code(err) != tt.code {
+			t.Errorf("%s: expected %s, got: %v", tt.name, tt.code, err)
+		}
+	}
+
+	// Streams are checked too
+	stream, err := client.StreamDestruction(ctx, &pb.StreamDestructionRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated for a stream without a token, got: %v", err)
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	server, err := New(&config.Config{
+		Security: config.SecurityConfig{AuthToken: "s3cret"},
+	})
 	if err != nil {
-		t.Fatalf("Expected system info to work with minimal config, got: %v", err)
+		t.Fatalf("Failed to create server: %v", err)
 	}
 
-	if resp == nil {
-		t.Error("Expected response even with minimal config")
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go func() {
+		_ = server.grpcServer.Serve(listener)
+	}()
+	defer server.grpcServer.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Errorf("Failed to close connection: %v", err)
+		}
+	}()
+	client := healthpb.NewHealthClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	before := server.activity.idleFor(time.Now())
+
+	// Probes carry no token
+	for _, service := range []string{"", pb.BurnDeviceService_ServiceDesc.ServiceName} {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Expected health check for %q to succeed without a token, got: %v", service, err)
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Expected %q to be SERVING, got: %s", service, resp.Status)
+		}
+	}
+
+	if server.activity.idleFor(time.Now()) < before {
+		t.Error("Expected health checks not to reset the idle timer")
+	}
+
+	server.health.Shutdown()
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Expected health check to succeed, got: %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING after shutdown, got: %s", resp.Status)
 	}
 }
@@ -0,0 +1,115 @@
+// Package store reads and writes the files the server keeps its state in
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// WriteFile replaces path with data, creating its directory. The data is
+// written to a temporary file that is synced and renamed over path, and
+// the directory is synced after, so a crash leaves the old file or the new
+// one, never a torn one.
+func WriteFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	// #nosec G304 - Path comes from the server configuration
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir flushes dir, so a rename within it survives a crash
+func syncDir(dir string) error {
+	// #nosec G304 - Path comes from the server configuration
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteJSONLines replaces path with messages, one JSON object per line
+func WriteJSONLines[M proto.Message](path string, messages []M) error {
+	var buf bytes.Buffer
+	for _, message := range messages {
+		line, err := protojson.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to encode line: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return WriteFile(path, buf.Bytes())
+}
+
+// ReadJSONLines decodes each line of path into a message from newMessage,
+// skipping lines that don't decode or that valid, when set, rejects. It
+// returns the messages and how many lines it skipped; a missing file has
+// none.
+func ReadJSONLines[M proto.Message](path string, newMessage func() M, valid func(M) bool) ([]M, int, error) {
+	// #nosec G304 - Path comes from the server configuration
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var messages []M
+	skipped := 0
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			message := newMessage()
+			if protojson.Unmarshal(line, message) != nil || (valid != nil && !valid(message)) {
+				skipped++
+			} else {
+				messages = append(messages, message)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return messages, skipped, nil
+}
@@ -0,0 +1,71 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/BurnDevice/BurnDevice/burndevice/v1"
+)
+
+func newTaskStatus() *pb.TaskStatus {
+	return &pb.TaskStatus{}
+}
+
+func TestJSONLinesRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "tasks.jsonl")
+
+	tasks := []*pb.TaskStatus{{TaskId: "task-1"}, {TaskId: "task-2"}}
+	if err := WriteJSONLines(path, tasks); err != nil {
+		t.Fatalf("WriteJSONLines failed: %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("Expected the temporary file to be renamed away, got: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the file to be written with mode 0600, got %v, %v", info, err)
+	}
+
+	read, skipped, err := ReadJSONLines(path, newTaskStatus, nil)
+	if err != nil {
+		t.Fatalf("ReadJSONLines failed: %v", err)
+	}
+	if skipped != 0 || len(read) != 2 || read[0].TaskId != "task-1" || read[1].TaskId != "task-2" {
+		t.Errorf("Expected both tasks back, got %v (skipped %d)", read, skipped)
+	}
+
+	// Writing again replaces the file
+	if err := WriteJSONLines(path, tasks[:1]); err != nil {
+		t.Fatalf("WriteJSONLines failed: %v", err)
+	}
+	if read, _, _ := ReadJSONLines(path, newTaskStatus, nil); len(read) != 1 {
+		t.Errorf("Expected 1 task after rewriting, got %d", len(read))
+	}
+}
+
+func TestReadJSONLinesSkipsBadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.jsonl")
+	data := `{"taskId":"task-1"}
+not json
+
+{"taskId":""}
+{"taskId":"task-2"}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	read, skipped, err := ReadJSONLines(path, newTaskStatus, func(task *pb.TaskStatus) bool { return task.TaskId != "" })
+	if err != nil {
+		t.Fatalf("ReadJSONLines failed: %v", err)
+	}
+	if skipped != 2 || len(read) != 2 || read[1].TaskId != "task-2" {
+		t.Errorf("Expected 2 tasks and 2 skipped lines, got %v (skipped %d)", read, skipped)
+	}
+}
+
+func TestReadJSONLinesMissingFile(t *testing.T) {
+	read, skipped, err := ReadJSONLines(filepath.Join(t.TempDir(), "missing.jsonl"), newTaskStatus, nil)
+	if err != nil || read != nil || skipped != 0 {
+		t.Errorf("Expected nothing from a missing file, got %v, %d, %v", read, skipped, err)
+	}
+}
@@ -4,13 +4,29 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 )
 
+// SystemInfoCollector gathers system information. SystemInfo is the real
+// implementation; tests can substitute a fixed one.
+type SystemInfoCollector interface {
+	// Collect gathers comprehensive system information
+	Collect() (*Info, error)
+	// DiskUsage returns the disk space of the filesystem holding each path
+	DiskUsage(paths []string) []PathDisk
+}
+
 // SystemInfo collects system information
-type SystemInfo struct{}
+type SystemInfo struct {
+	// cpuMu guards the previous /proc/stat reading, which Linux CPU usage
+	// is measured against
+	cpuMu             sync.Mutex
+	cpuBusy, cpuTotal float64
+}
 
 // Info represents collected system information
 type Info struct {
@@ -20,6 +36,15 @@ type Info struct {
 	CriticalPaths   []string
 	RunningServices []string
 	Resources       Resources
+	// PathDisks reports the filesystem holding each critical path
+	PathDisks []PathDisk
+}
+
+// PathDisk is the disk space of the filesystem holding Path
+type PathDisk struct {
+	Path      string
+	Total     int64
+	Available int64
 }
 
 // Resources represents system resource information
@@ -52,6 +77,7 @@ func (s *SystemInfo) Collect() (*Info, error) {
 
 	// Collect critical paths
 	info.CriticalPaths = s.getCriticalPaths()
+	info.PathDisks = s.DiskUsage(info.CriticalPaths)
 
 	// Collect running services
 	services, err := s.getRunningServices()
@@ -117,6 +143,40 @@ func (s *SystemInfo) getCriticalPaths() []string {
 	return existingPaths
 }
 
+// DiskUsage returns the disk space of the filesystem holding each path.
+// Paths that don't exist yet are measured at their nearest existing parent,
+// where they would be created; paths that can't be measured are skipped.
+func (s *SystemInfo) DiskUsage(paths []string) []PathDisk {
+	var disks []PathDisk
+	for _, path := range paths {
+		diskInfo, err := s.getDiskInfoFor(existingAncestor(path))
+		if err != nil {
+			continue
+		}
+		disks = append(disks, PathDisk{
+			Path:      path,
+			Total:     diskInfo.Total,
+			Available: diskInfo.Available,
+		})
+	}
+	return disks
+}
+
+// existingAncestor returns path, or its closest parent that exists
+func existingAncestor(path string) string {
+	path = filepath.Clean(path)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
 // getRunningServices returns a list of running services
 func (s *SystemInfo) getRunningServices() ([]string, error) {
 	var services []string
@@ -442,6 +502,13 @@ func (s *SystemInfo) getDarwinMemoryInfo() (*MemoryInfo, error) {
 	}, nil
 }
 
+// CPUUsage samples the current CPU usage percentage. On Linux it covers the
+// time since the previous sample, so sampling at an interval tracks the load
+// as it changes.
+func (s *SystemInfo) CPUUsage() (float64, error) {
+	return s.getCPUUsage()
+}
+
 // getCPUUsage gets current CPU usage percentage
 func (s *SystemInfo) getCPUUsage() (float64, error) {
 	switch runtime.GOOS {
@@ -476,12 +543,20 @@ func (s *SystemInfo) getLinuxCPUUsage() (float64, error) {
 	system, _ := strconv.ParseFloat(fields[3], 64)
 	idle, _ := strconv.ParseFloat(fields[4], 64)
 
-	total := user + nice + system + idle
-	if total == 0 {
+	busy := user + nice + system
+	total := busy + idle
+
+	// Measure against the previous reading; the first covers uptime
+	s.cpuMu.Lock()
+	busyDelta, totalDelta := busy-s.cpuBusy, total-s.cpuTotal
+	s.cpuBusy, s.cpuTotal = busy, total
+	s.cpuMu.Unlock()
+
+	if totalDelta <= 0 {
 		return 0.0, nil
 	}
 
-	return ((user + nice + system) / total) * 100, nil
+	return (busyDelta / totalDelta) * 100, nil
 }
 
 // getWindowsCPUUsage gets CPU usage on Windows
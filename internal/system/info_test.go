@@ -1,8 +1,11 @@
 package system
 
 import (
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 )
 
 func TestNewSystemInfo(t *testing.T) {
@@ -146,6 +149,57 @@ func TestGetResources(t *testing.T) {
 	}
 }
 
+func TestCPUUsage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("interval sampling is Linux only")
+	}
+	sysInfo := NewSystemInfo()
+
+	// The second sample covers only the time since the first
+	for i := 0; i < 2; i++ {
+		usage, err := sysInfo.CPUUsage()
+		if err != nil {
+			t.Fatalf("CPUUsage failed: %v", err)
+		}
+		if usage < 0 || usage > 100 {
+			t.Errorf("Expected CPU usage to be between 0-100, got %.2f", usage)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestDiskUsage(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "burndevice_disk_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			t.Errorf("Failed to remove temp dir: %v", err)
+		}
+	}()
+
+	sysInfo := NewSystemInfo()
+	// A target that doesn't exist yet is measured where it would be created
+	missing := filepath.Join(tempDir, "not", "created", "yet")
+	disks := sysInfo.DiskUsage([]string{tempDir, missing})
+
+	if len(disks) != 2 {
+		t.Fatalf("Expected disk usage for both paths, got %v", disks)
+	}
+	if disks[0].Path != tempDir || disks[1].Path != missing {
+		t.Errorf("Expected the requested paths to be reported, got %v", disks)
+	}
+	for _, disk := range disks {
+		if disk.Total <= 0 || disk.Available < 0 || disk.Available > disk.Total {
+			t.Errorf("Unexpected disk usage for %s: %+v", disk.Path, disk)
+		}
+	}
+	if disks[0].Total != disks[1].Total {
+		t.Errorf("Expected both paths to share a filesystem, got %+v", disks)
+	}
+}
+
 func TestContains(t *testing.T) {
 	slice := []string{"apple", "banana", "cherry"}
 
@@ -161,3 +215,26 @@ func TestContains(t *testing.T) {
 		t.Error("Expected empty slice to not contain anything")
 	}
 }
+
+func TestFileLimits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Inode and open file limits are not available on Windows")
+	}
+
+	sysInfo := NewSystemInfo()
+	free, err := sysInfo.FreeInodes(os.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to read free inodes: %v", err)
+	}
+	if free < 0 {
+		t.Errorf("Expected free inodes to be non-negative, got %d", free)
+	}
+
+	limit, open, err := sysInfo.OpenFiles()
+	if err != nil {
+		t.Fatalf("Failed to read open files: %v", err)
+	}
+	if open <= 0 || open > limit {
+		t.Errorf("Expected between 1 and %d open files, got %d", limit, open)
+	}
+}
@@ -4,6 +4,7 @@ package system
 
 import (
 	"fmt"
+	"os"
 	"syscall"
 )
 
@@ -13,10 +14,14 @@ type DiskInfo struct {
 	Available int64
 }
 
-// getDiskInfo gets disk space information for Unix systems
+// getDiskInfo gets disk space information for the root filesystem
 func (s *SystemInfo) getDiskInfo() (*DiskInfo, error) {
+	return s.getDiskInfoFor("/")
+}
+
+// getDiskInfoFor gets disk space information for the filesystem holding path
+func (s *SystemInfo) getDiskInfoFor(path string) (*DiskInfo, error) {
 	var stat syscall.Statfs_t
-	path := "/"
 
 	err := syscall.Statfs(path, &stat)
 	if err != nil {
@@ -72,3 +77,42 @@ func (s *SystemInfo) getDiskInfo() (*DiskInfo, error) {
 		Available: available,
 	}, nil
 }
+
+// FreeInodes returns how many more files the filesystem holding path can
+// create
+func (s *SystemInfo) FreeInodes(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	// #nosec G115 - Ffree is unsigned on some platforms, signed on others
+	free := uint64(stat.Ffree)
+	if free > uint64(1<<63-1) {
+		return 0, fmt.Errorf("free inodes value %d exceeds int64 maximum", free)
+	}
+	return int64(free), nil // #nosec G115 - Safe conversion: bounds checked above
+}
+
+// OpenFiles returns the process's open file descriptor limit and how many
+// descriptors it currently has open
+func (s *SystemInfo) OpenFiles() (limit, open int64, err error) {
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return 0, 0, fmt.Errorf("failed to read open file limit: %w", err)
+	}
+
+	// #nosec G115 - Cur is unsigned on some platforms, signed on others
+	cur := uint64(rlimit.Cur)
+	if cur > uint64(1<<63-1) {
+		return 0, 0, fmt.Errorf("open file limit is unlimited")
+	}
+
+	// Listing /dev/fd opens one more descriptor, so the count errs high
+	entries, err := os.ReadDir("/dev/fd")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count open files: %w", err)
+	}
+
+	return int64(cur), int64(len(entries)), nil // #nosec G115 - Safe conversion: bounds checked above
+}
@@ -5,6 +5,7 @@ package system
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -15,22 +16,33 @@ type DiskInfo struct {
 	Available int64
 }
 
-// getDiskInfo gets disk space information for Windows systems
+// getDiskInfo gets disk space information for the C: drive
 func (s *SystemInfo) getDiskInfo() (*DiskInfo, error) {
+	return s.getDiskInfoFor("C:\\")
+}
+
+// getDiskInfoFor gets disk space information for the drive holding path.
+// Paths without a drive letter, such as UNC shares, fall back to C:.
+func (s *SystemInfo) getDiskInfoFor(path string) (*DiskInfo, error) {
+	drive := strings.ToUpper(filepath.VolumeName(path))
+	if len(drive) != 2 || drive[0] < 'A' || drive[0] > 'Z' || drive[1] != ':' {
+		drive = "C:"
+	}
+
 	// Try wmic first
-	diskInfo, err := s.getDiskInfoWmic()
+	diskInfo, err := s.getDiskInfoWmic(drive)
 	if err == nil {
 		return diskInfo, nil
 	}
 
 	// Fallback to PowerShell
-	return s.getDiskInfoPowerShell()
+	return s.getDiskInfoPowerShell(drive)
 }
 
-// getDiskInfoWmic uses wmic to get disk information
-func (s *SystemInfo) getDiskInfoWmic() (*DiskInfo, error) {
-	// Use wmic to get disk space information for C: drive
-	cmd := exec.Command("wmic", "logicaldisk", "where", "caption=\"C:\"", "get", "size,freespace", "/format:list")
+// getDiskInfoWmic uses wmic to get disk information for drive
+func (s *SystemInfo) getDiskInfoWmic(drive string) (*DiskInfo, error) {
+	// #nosec G204 - drive is a validated drive letter
+	cmd := exec.Command("wmic", "logicaldisk", "where", fmt.Sprintf("caption=\"%s\"", drive), "get", "size,freespace", "/format:list")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get disk info via wmic: %v", err)
@@ -70,9 +82,10 @@ func (s *SystemInfo) getDiskInfoWmic() (*DiskInfo, error) {
 	}, nil
 }
 
-// getDiskInfoPowerShell uses PowerShell to get disk information
-func (s *SystemInfo) getDiskInfoPowerShell() (*DiskInfo, error) {
-	cmd := exec.Command("powershell", "-Command", "Get-WmiObject -Class Win32_LogicalDisk -Filter \"DeviceID='C:'\" | Select-Object Size,FreeSpace | ConvertTo-Json")
+// getDiskInfoPowerShell uses PowerShell to get disk information for drive
+func (s *SystemInfo) getDiskInfoPowerShell(drive string) (*DiskInfo, error) {
+	// #nosec G204 - drive is a validated drive letter
+	cmd := exec.Command("powershell", "-Command", fmt.Sprintf("Get-WmiObject -Class Win32_LogicalDisk -Filter \"DeviceID='%s'\" | Select-Object Size,FreeSpace | ConvertTo-Json", drive))
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get disk info via PowerShell: %v", err)
@@ -117,3 +130,15 @@ func (s *SystemInfo) getDiskInfoPowerShell() (*DiskInfo, error) {
 		Available: available,
 	}, nil
 }
+
+// FreeInodes is unsupported on Windows, whose filesystems have no fixed
+// inode count
+func (s *SystemInfo) FreeInodes(path string) (int64, error) {
+	return 0, fmt.Errorf("inode counts are not available on Windows")
+}
+
+// OpenFiles is unsupported on Windows, which has no per-process open file
+// limit
+func (s *SystemInfo) OpenFiles() (limit, open int64, err error) {
+	return 0, 0, fmt.Errorf("open file limits are not available on Windows")
+}
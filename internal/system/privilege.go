@@ -0,0 +1,26 @@
+package system
+
+import "os/user"
+
+// Privilege describes the account the process runs as
+type Privilege struct {
+	// UID is the effective user ID, or the user's SID on Windows
+	UID      string
+	Username string
+	// Privileged is true for root on Unix and for an elevated
+	// Administrator on Windows
+	Privileged bool
+}
+
+// CurrentPrivilege reports the account the process runs as
+func CurrentPrivilege() Privilege {
+	privilege := Privilege{
+		UID:        effectiveUID(),
+		Username:   "unknown",
+		Privileged: isPrivileged(),
+	}
+	if u, err := user.LookupId(privilege.UID); err == nil {
+		privilege.Username = u.Username
+	}
+	return privilege
+}
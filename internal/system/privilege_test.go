@@ -0,0 +1,19 @@
+package system
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestCurrentPrivilege(t *testing.T) {
+	privilege := CurrentPrivilege()
+
+	if privilege.UID == "" || privilege.Username == "" {
+		t.Errorf("Expected the account to be populated, got %+v", privilege)
+	}
+
+	if runtime.GOOS != "windows" && privilege.Privileged != (os.Geteuid() == 0) {
+		t.Errorf("Expected privileged to match euid %d, got %v", os.Geteuid(), privilege.Privileged)
+	}
+}
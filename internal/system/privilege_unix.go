@@ -0,0 +1,19 @@
+//go:build unix
+
+package system
+
+import (
+	"os"
+	"strconv"
+)
+
+// effectiveUID returns the effective user ID, which decides what the
+// process may delete
+func effectiveUID() string {
+	return strconv.Itoa(os.Geteuid())
+}
+
+// isPrivileged reports whether the process runs as root
+func isPrivileged() bool {
+	return os.Geteuid() == 0
+}
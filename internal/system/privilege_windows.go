@@ -0,0 +1,24 @@
+//go:build windows
+
+package system
+
+import (
+	"os/user"
+
+	"golang.org/x/sys/windows"
+)
+
+// effectiveUID returns the SID of the user the process runs as
+func effectiveUID() string {
+	u, err := user.Current()
+	if err != nil {
+		return "unknown"
+	}
+	return u.Uid
+}
+
+// isPrivileged reports whether the process runs with an elevated
+// Administrator token
+func isPrivileged() bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}